@@ -72,9 +72,17 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkSpec.NodeOutboundLB = restored.Spec.NetworkSpec.NodeOutboundLB
 
 	dst.Spec.NetworkSpec.PrivateDNSZoneName = restored.Spec.NetworkSpec.PrivateDNSZoneName
+	dst.Spec.NetworkSpec.OutboundType = restored.Spec.NetworkSpec.OutboundType
+	dst.Spec.NetworkSpec.NextHopIPAddress = restored.Spec.NetworkSpec.NextHopIPAddress
+	dst.Spec.NetworkSpec.ApplicationSecurityGroups = restored.Spec.NetworkSpec.ApplicationSecurityGroups
+	dst.Spec.NetworkSpec.IPv6Only = restored.Spec.NetworkSpec.IPv6Only
 
 	dst.Spec.NetworkSpec.APIServerLB.FrontendIPsCount = restored.Spec.NetworkSpec.APIServerLB.FrontendIPsCount
 	dst.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes = restored.Spec.NetworkSpec.APIServerLB.IdleTimeoutInMinutes
+	dst.Spec.NetworkSpec.APIServerLB.PublicIPPrefix = restored.Spec.NetworkSpec.APIServerLB.PublicIPPrefix
+	dst.Spec.NetworkSpec.APIServerLB.PrivateLinkService = restored.Spec.NetworkSpec.APIServerLB.PrivateLinkService
+	dst.Spec.NetworkSpec.APIServerLB.Probe = restored.Spec.NetworkSpec.APIServerLB.Probe
+	dst.Spec.NetworkSpec.APIServerLB.AdditionalRules = restored.Spec.NetworkSpec.APIServerLB.AdditionalRules
 
 	for _, restoredFrontendIP := range restored.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 		for i, dstFrontendIP := range dst.Spec.NetworkSpec.APIServerLB.FrontendIPs {
@@ -85,6 +93,14 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.CloudProviderConfigOverrides = restored.Spec.CloudProviderConfigOverrides
+	dst.Spec.DiagnosticSettings = restored.Spec.DiagnosticSettings
+
+	// Restore the dedicated host groups.
+	dst.Spec.DedicatedHostGroups = restored.Spec.DedicatedHostGroups
+
+	// Restore the proximity placement groups.
+	dst.Spec.ProximityPlacementGroups = restored.Spec.ProximityPlacementGroups
+
 	dst.Spec.BastionSpec = restored.Spec.BastionSpec
 
 	// Here we manually restore outbound security rules. Since v1alpha3 only supports ingress ("Inbound") rules, all v1alpha4/v1beta1 outbound rules are dropped when an AzureCluster
@@ -100,22 +116,41 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 				if restoredSecurityRule.Direction != infrav1.SecurityRuleDirectionInbound {
 					// For non-inbound rules which are only supported starting in v1alpha4/v1beta1, we restore the entire rule.
 					restoredOutboundRules = append(restoredOutboundRules, restoredSecurityRule)
+					continue
+				}
+				// For inbound rules, we restore the application security groups which do not exist in v1alpha3.
+				for j, dstSecurityRule := range dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules {
+					if dstSecurityRule.Name == restoredSecurityRule.Name {
+						dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules[j].SourceApplicationSecurityGroups = restoredSecurityRule.SourceApplicationSecurityGroups
+						dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules[j].DestinationApplicationSecurityGroups = restoredSecurityRule.DestinationApplicationSecurityGroups
+					}
 				}
 			}
 			dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules = append(dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.SecurityRules, restoredOutboundRules...)
 			dst.Spec.NetworkSpec.Subnets[i].NatGateway = restoredSubnet.NatGateway
 			dst.Spec.NetworkSpec.Subnets[i].ServiceEndpoints = restoredSubnet.ServiceEndpoints
 			dst.Spec.NetworkSpec.Subnets[i].PrivateEndpoints = restoredSubnet.PrivateEndpoints
+			dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.FlowLog = restoredSubnet.SecurityGroup.FlowLog
 
 			break
 		}
 	}
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+	dst.Status.APIServerPrivateLinkServiceAlias = restored.Status.APIServerPrivateLinkServiceAlias
 
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
+	// Restore the IPAM pool of the virtual network.
+	dst.Spec.NetworkSpec.Vnet.IPAMPoolRef = restored.Spec.NetworkSpec.Vnet.IPAMPoolRef
+
+	// Restore the DDoS protection plan, encryption and DNS servers of the virtual network.
+	dst.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID = restored.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID
+	dst.Spec.NetworkSpec.Vnet.Encryption = restored.Spec.NetworkSpec.Vnet.Encryption
+	dst.Spec.NetworkSpec.Vnet.DHCPOptions = restored.Spec.NetworkSpec.Vnet.DHCPOptions
+
 	return nil
 }
 
@@ -82,10 +82,37 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.SystemAssignedIdentityRole = restored.Spec.SystemAssignedIdentityRole
 	}
 
+	if len(restored.Spec.ApplicationSecurityGroups) > 0 {
+		dst.Spec.ApplicationSecurityGroups = restored.Spec.ApplicationSecurityGroups
+	}
+
+	// Restore the dedicated host placement.
+	dst.Spec.HostGroupID = restored.Spec.HostGroupID
+	dst.Spec.HostID = restored.Spec.HostID
+
+	// Restore the proximity placement group and capacity reservation group placement.
+	dst.Spec.ProximityPlacementGroupID = restored.Spec.ProximityPlacementGroupID
+	dst.Spec.CapacityReservationGroupID = restored.Spec.CapacityReservationGroupID
+
+	// Restore the update strategy.
+	dst.Spec.UpdateStrategy = restored.Spec.UpdateStrategy
+
+	restoreSecurityProfile(restored.Spec.SecurityProfile, dst.Spec.SecurityProfile)
+	restoreManagedDiskSecurityProfile(restored.Spec.OSDisk.ManagedDisk, dst.Spec.OSDisk.ManagedDisk)
+	for i := range dst.Spec.DataDisks {
+		if i < len(restored.Spec.DataDisks) {
+			restoreManagedDiskSecurityProfile(restored.Spec.DataDisks[i].ManagedDisk, dst.Spec.DataDisks[i].ManagedDisk)
+		}
+	}
+
 	//nolint:staticcheck // SubnetName is now deprecated, but the v1beta1 defaulting webhook will migrate it to the networkInterfaces field
 	dst.Spec.SubnetName = restored.Spec.SubnetName
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+
+	// Restore the capacity reservation the VM is allocated against.
+	dst.Status.CapacityReservationID = restored.Status.CapacityReservationID
 
 	return nil
 }
@@ -206,3 +233,25 @@ func Convert_v1beta1_Image_To_v1alpha3_Image(in *infrav1.Image, out *Image, s ap
 func Convert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha3_SpotVMOptions(in, out, s)
 }
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile converts from the Hub version (v1beta1) of the SecurityProfile to this version.
+func Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *infrav1.SecurityProfile, out *SecurityProfile, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in, out, s)
+}
+
+// restoreSecurityProfile restores the SecurityProfile fields which do not exist in this version.
+func restoreSecurityProfile(restored, dst *infrav1.SecurityProfile) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.SecurityType = restored.SecurityType
+	dst.UefiSettings = restored.UefiSettings
+}
+
+// restoreManagedDiskSecurityProfile restores the managed disk SecurityProfile, which does not exist in this version.
+func restoreManagedDiskSecurityProfile(restored, dst *infrav1.ManagedDiskParameters) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.SecurityProfile = restored.SecurityProfile
+}
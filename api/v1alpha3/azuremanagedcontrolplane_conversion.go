@@ -48,6 +48,7 @@ func (src *AzureManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.OutboundType = restored.Spec.OutboundType
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
+	dst.Status.OperationHistory = restored.Status.OperationHistory
 	dst.Status.Conditions = restored.Status.Conditions
 
 	return nil
@@ -57,6 +57,7 @@ func (src *AzureManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
+	dst.Status.OperationHistory = restored.Status.OperationHistory
 	dst.Status.Conditions = restored.Status.Conditions
 
 	return nil
@@ -346,11 +346,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SpotVMOptions)(nil), (*v1beta1.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(a.(*SpotVMOptions), b.(*v1beta1.SpotVMOptions), scope)
 	}); err != nil {
@@ -561,6 +556,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SecurityRule)(nil), (*IngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SecurityRule_To_v1alpha3_IngressRule(a.(*v1beta1.SecurityRule), b.(*IngressRule), scope)
 	}); err != nil {
@@ -920,6 +920,8 @@ func autoConvert_v1beta1_AzureClusterStatus_To_v1alpha3_AzureClusterStatus(in *v
 		out.Conditions = nil
 	}
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIServerPrivateLinkServiceAlias requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1043,7 +1045,15 @@ func autoConvert_v1alpha3_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*v1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(v1beta1.SecurityProfile)
+		if err := Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	return nil
 }
 
@@ -1094,11 +1104,25 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha3_AzureMachineSpec(in *v1bet
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfile)
+		if err := Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	// WARNING: in.SubnetName requires manual conversion: does not exist in peer-type
 	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
 	// WARNING: in.VMExtensions requires manual conversion: does not exist in peer-type
 	// WARNING: in.NetworkInterfaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.ApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ProximityPlacementGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpdateStrategy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1126,6 +1150,7 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha3_AzureMachineStatus(in *v
 	out.Ready = in.Ready
 	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
 	out.VMState = (*VMState)(unsafe.Pointer(in.VMState))
+	// WARNING: in.CapacityReservationID requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	if in.Conditions != nil {
@@ -1140,6 +1165,7 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha3_AzureMachineStatus(in *v
 		out.Conditions = nil
 	}
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1519,6 +1545,7 @@ func autoConvert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha3_AzureManaged
 	out.Initialized = in.Initialized
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1653,6 +1680,7 @@ func autoConvert_v1beta1_AzureManagedMachinePoolStatus_To_v1alpha3_AzureManagedM
 	out.ErrorMessage = (*string)(unsafe.Pointer(in.ErrorMessage))
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1946,6 +1974,10 @@ func autoConvert_v1beta1_LoadBalancerSpec_To_v1alpha3_LoadBalancerSpec(in *v1bet
 	}
 	// WARNING: in.FrontendIPsCount requires manual conversion: does not exist in peer-type
 	// WARNING: in.BackendPool requires manual conversion: does not exist in peer-type
+	// WARNING: in.PublicIPPrefix requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateLinkService requires manual conversion: does not exist in peer-type
+	// WARNING: in.Probe requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalRules requires manual conversion: does not exist in peer-type
 	// WARNING: in.LoadBalancerClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -2105,14 +2137,11 @@ func Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(in *SecurityPro
 
 func autoConvert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
 	out.EncryptionAtHost = (*bool)(unsafe.Pointer(in.EncryptionAtHost))
+	// WARNING: in.SecurityType requires manual conversion: does not exist in peer-type
+	// WARNING: in.UefiSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile is an autogenerated conversion function.
-func Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
-	return autoConvert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in, out, s)
-}
-
 func autoConvert_v1alpha3_SpotVMOptions_To_v1beta1_SpotVMOptions(in *SpotVMOptions, out *v1beta1.SpotVMOptions, s conversion.Scope) error {
 	out.MaxPrice = (*resource.Quantity)(unsafe.Pointer(in.MaxPrice))
 	return nil
@@ -2193,6 +2222,7 @@ func autoConvert_v1beta1_VnetSpec_To_v1alpha3_VnetSpec(in *v1beta1.VnetSpec, out
 	out.ID = in.ID
 	out.Name = in.Name
 	// WARNING: in.Peerings requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPAMPoolRef requires manual conversion: does not exist in peer-type
 	// WARNING: in.VnetClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
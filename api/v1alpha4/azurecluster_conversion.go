@@ -41,6 +41,33 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 	// Restore list of virtual network peerings
 	dst.Spec.NetworkSpec.Vnet.Peerings = restored.Spec.NetworkSpec.Vnet.Peerings
 
+	// Restore the IPAM pool of the virtual network.
+	dst.Spec.NetworkSpec.Vnet.IPAMPoolRef = restored.Spec.NetworkSpec.Vnet.IPAMPoolRef
+
+	// Restore the DDoS protection plan, encryption and DNS servers of the virtual network.
+	dst.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID = restored.Spec.NetworkSpec.Vnet.DDoSProtectionPlanID
+	dst.Spec.NetworkSpec.Vnet.Encryption = restored.Spec.NetworkSpec.Vnet.Encryption
+	dst.Spec.NetworkSpec.Vnet.DHCPOptions = restored.Spec.NetworkSpec.Vnet.DHCPOptions
+
+	// Restore the outbound type.
+	dst.Spec.NetworkSpec.OutboundType = restored.Spec.NetworkSpec.OutboundType
+	dst.Spec.NetworkSpec.NextHopIPAddress = restored.Spec.NetworkSpec.NextHopIPAddress
+
+	// Restore the custom application security groups.
+	dst.Spec.NetworkSpec.ApplicationSecurityGroups = restored.Spec.NetworkSpec.ApplicationSecurityGroups
+
+	// Restore the IPv6-only mode.
+	dst.Spec.NetworkSpec.IPv6Only = restored.Spec.NetworkSpec.IPv6Only
+
+	// Restore the diagnostic settings.
+	dst.Spec.DiagnosticSettings = restored.Spec.DiagnosticSettings
+
+	// Restore the dedicated host groups.
+	dst.Spec.DedicatedHostGroups = restored.Spec.DedicatedHostGroups
+
+	// Restore the proximity placement groups.
+	dst.Spec.ProximityPlacementGroups = restored.Spec.ProximityPlacementGroups
+
 	// Restore API Server LB IP tags.
 	for _, restoredFrontendIP := range restored.Spec.NetworkSpec.APIServerLB.FrontendIPs {
 		for i, dstFrontendIP := range dst.Spec.NetworkSpec.APIServerLB.FrontendIPs {
@@ -70,13 +97,16 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
-	// Restore NAT Gateway IP tags, ServiceEndpoints and PrivateEndpoints.
+	// Restore NAT Gateway IP tags, ServiceEndpoints, PrivateEndpoints and security group flow logs.
 	for _, restoredSubnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.Name == restoredSubnet.Name {
 				dst.Spec.NetworkSpec.Subnets[i].NatGateway.NatGatewayIP.IPTags = restoredSubnet.NatGateway.NatGatewayIP.IPTags
+				dst.Spec.NetworkSpec.Subnets[i].NatGateway.PublicIPPrefix = restoredSubnet.NatGateway.PublicIPPrefix
 				dst.Spec.NetworkSpec.Subnets[i].ServiceEndpoints = restoredSubnet.ServiceEndpoints
 				dst.Spec.NetworkSpec.Subnets[i].PrivateEndpoints = restoredSubnet.PrivateEndpoints
+				restoreSecurityRuleApplicationSecurityGroups(&dst.Spec.NetworkSpec.Subnets[i].SecurityGroup, restoredSubnet.SecurityGroup)
+				dst.Spec.NetworkSpec.Subnets[i].SecurityGroup.FlowLog = restoredSubnet.SecurityGroup.FlowLog
 			}
 		}
 	}
@@ -89,8 +119,11 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 		if restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.Name == dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.Name {
 			dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.IPTags = restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.NatGatewayIP.IPTags
 		}
+		dst.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.PublicIPPrefix = restored.Spec.BastionSpec.AzureBastion.Subnet.NatGateway.PublicIPPrefix
 		dst.Spec.BastionSpec.AzureBastion.Subnet.ServiceEndpoints = restored.Spec.BastionSpec.AzureBastion.Subnet.ServiceEndpoints
 		dst.Spec.BastionSpec.AzureBastion.Subnet.PrivateEndpoints = restored.Spec.BastionSpec.AzureBastion.Subnet.PrivateEndpoints
+		restoreSecurityRuleApplicationSecurityGroups(&dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup, restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup)
+		dst.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.FlowLog = restored.Spec.BastionSpec.AzureBastion.Subnet.SecurityGroup.FlowLog
 	}
 
 	// Restore load balancers' backend pool name
@@ -106,9 +139,62 @@ func (src *AzureCluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.BackendPool.Name = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.BackendPool.Name
 	}
 
+	// Restore load balancers' public IP prefix
+	dst.Spec.NetworkSpec.APIServerLB.PublicIPPrefix = restored.Spec.NetworkSpec.APIServerLB.PublicIPPrefix
+
+	if restored.Spec.NetworkSpec.NodeOutboundLB != nil && dst.Spec.NetworkSpec.NodeOutboundLB != nil {
+		dst.Spec.NetworkSpec.NodeOutboundLB.PublicIPPrefix = restored.Spec.NetworkSpec.NodeOutboundLB.PublicIPPrefix
+	}
+
+	if restored.Spec.NetworkSpec.ControlPlaneOutboundLB != nil && dst.Spec.NetworkSpec.ControlPlaneOutboundLB != nil {
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.PublicIPPrefix = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.PublicIPPrefix
+	}
+
+	// Restore load balancers' private link service
+	dst.Spec.NetworkSpec.APIServerLB.PrivateLinkService = restored.Spec.NetworkSpec.APIServerLB.PrivateLinkService
+	dst.Status.APIServerPrivateLinkServiceAlias = restored.Status.APIServerPrivateLinkServiceAlias
+
+	if restored.Spec.NetworkSpec.NodeOutboundLB != nil && dst.Spec.NetworkSpec.NodeOutboundLB != nil {
+		dst.Spec.NetworkSpec.NodeOutboundLB.PrivateLinkService = restored.Spec.NetworkSpec.NodeOutboundLB.PrivateLinkService
+	}
+
+	if restored.Spec.NetworkSpec.ControlPlaneOutboundLB != nil && dst.Spec.NetworkSpec.ControlPlaneOutboundLB != nil {
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.PrivateLinkService = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.PrivateLinkService
+	}
+
+	// Restore load balancers' health probe and additional rules
+	dst.Spec.NetworkSpec.APIServerLB.Probe = restored.Spec.NetworkSpec.APIServerLB.Probe
+	dst.Spec.NetworkSpec.APIServerLB.AdditionalRules = restored.Spec.NetworkSpec.APIServerLB.AdditionalRules
+
+	if restored.Spec.NetworkSpec.NodeOutboundLB != nil && dst.Spec.NetworkSpec.NodeOutboundLB != nil {
+		dst.Spec.NetworkSpec.NodeOutboundLB.Probe = restored.Spec.NetworkSpec.NodeOutboundLB.Probe
+		dst.Spec.NetworkSpec.NodeOutboundLB.AdditionalRules = restored.Spec.NetworkSpec.NodeOutboundLB.AdditionalRules
+	}
+
+	if restored.Spec.NetworkSpec.ControlPlaneOutboundLB != nil && dst.Spec.NetworkSpec.ControlPlaneOutboundLB != nil {
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.Probe = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.Probe
+		dst.Spec.NetworkSpec.ControlPlaneOutboundLB.AdditionalRules = restored.Spec.NetworkSpec.ControlPlaneOutboundLB.AdditionalRules
+	}
+
+	// Restore the history of Azure operations.
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+
 	return nil
 }
 
+// restoreSecurityRuleApplicationSecurityGroups restores the application security groups of the security rules of a
+// security group, which do not exist in this version.
+func restoreSecurityRuleApplicationSecurityGroups(dst *infrav1.SecurityGroup, restored infrav1.SecurityGroup) {
+	for _, restoredRule := range restored.SecurityRules {
+		for i, dstRule := range dst.SecurityRules {
+			if dstRule.Name == restoredRule.Name {
+				dst.SecurityRules[i].SourceApplicationSecurityGroups = restoredRule.SourceApplicationSecurityGroups
+				dst.SecurityRules[i].DestinationApplicationSecurityGroups = restoredRule.DestinationApplicationSecurityGroups
+			}
+		}
+	}
+}
+
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *AzureCluster) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.AzureCluster)
@@ -327,7 +413,17 @@ func Convert_v1alpha4_SecurityGroup_To_v1beta1_SecurityGroup(in *SecurityGroup,
 	}
 
 	// Convert SecurityGroupClass fields
-	out.SecurityRules = *(*infrav1.SecurityRules)(unsafe.Pointer(&in.SecurityRules))
+	if in.SecurityRules != nil {
+		in, out := &in.SecurityRules, &out.SecurityRules
+		*out = make(infrav1.SecurityRules, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SecurityRules = nil
+	}
 	out.Tags = *(*infrav1.Tags)(&in.Tags)
 
 	return nil
@@ -340,12 +436,27 @@ func Convert_v1beta1_SecurityGroup_To_v1alpha4_SecurityGroup(in *infrav1.Securit
 	}
 
 	// Convert SecurityGroupClass fields
-	out.SecurityRules = *(*SecurityRules)(unsafe.Pointer(&in.SecurityRules))
+	if in.SecurityRules != nil {
+		in, out := &in.SecurityRules, &out.SecurityRules
+		*out = make(SecurityRules, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.SecurityRules = nil
+	}
 	out.Tags = *(*Tags)(&in.Tags)
 
 	return nil
 }
 
+// Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule converts a security rule from v1beta1 to v1alpha4.
+func Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *infrav1.SecurityRule, out *SecurityRule, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in, out, s)
+}
+
 // Convert_v1alpha4_NatGateway_To_v1beta1_NatGateway converts a NAT gateway from v1alpha4 to v1beta1.
 func Convert_v1alpha4_NatGateway_To_v1beta1_NatGateway(in *NatGateway, out *infrav1.NatGateway, s apiconversion.Scope) error {
 	if err := autoConvert_v1alpha4_NatGateway_To_v1beta1_NatGateway(in, out, s); err != nil {
@@ -372,3 +483,8 @@ func Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in *infrav1.NatGateway, o
 func Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in *infrav1.PublicIPSpec, out *PublicIPSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(in, out, s)
 }
+
+// Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus is an autogenerated conversion function.
+func Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *infrav1.AzureClusterStatus, out *AzureClusterStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in, out, s)
+}
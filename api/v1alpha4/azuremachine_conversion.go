@@ -68,6 +68,35 @@ func (src *AzureMachine) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.SystemAssignedIdentityRole = restored.Spec.SystemAssignedIdentityRole
 	}
 
+	if len(restored.Spec.ApplicationSecurityGroups) > 0 {
+		dst.Spec.ApplicationSecurityGroups = restored.Spec.ApplicationSecurityGroups
+	}
+
+	// Restore the dedicated host placement.
+	dst.Spec.HostGroupID = restored.Spec.HostGroupID
+	dst.Spec.HostID = restored.Spec.HostID
+
+	// Restore the proximity placement group and capacity reservation group placement.
+	dst.Spec.ProximityPlacementGroupID = restored.Spec.ProximityPlacementGroupID
+	dst.Spec.CapacityReservationGroupID = restored.Spec.CapacityReservationGroupID
+
+	// Restore the update strategy.
+	dst.Spec.UpdateStrategy = restored.Spec.UpdateStrategy
+
+	restoreSecurityProfile(restored.Spec.SecurityProfile, dst.Spec.SecurityProfile)
+	restoreManagedDiskSecurityProfile(restored.Spec.OSDisk.ManagedDisk, dst.Spec.OSDisk.ManagedDisk)
+	for i := range dst.Spec.DataDisks {
+		if i < len(restored.Spec.DataDisks) {
+			restoreManagedDiskSecurityProfile(restored.Spec.DataDisks[i].ManagedDisk, dst.Spec.DataDisks[i].ManagedDisk)
+		}
+	}
+
+	// Restore the history of Azure operations.
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+
+	// Restore the capacity reservation the VM is allocated against.
+	dst.Status.CapacityReservationID = restored.Status.CapacityReservationID
+
 	return nil
 }
 
@@ -126,3 +155,35 @@ func Convert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *infrav1.A
 func Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in *infrav1.SpotVMOptions, out *SpotVMOptions, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(in, out, s)
 }
+
+// Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *infrav1.AzureMachineStatus, out *AzureMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in, out, s)
+}
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile converts from the Hub version (v1beta1) of the SecurityProfile to this version.
+func Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *infrav1.SecurityProfile, out *SecurityProfile, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in, out, s)
+}
+
+// Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters converts from the Hub version (v1beta1) of the ManagedDiskParameters to this version.
+func Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *infrav1.ManagedDiskParameters, out *ManagedDiskParameters, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in, out, s)
+}
+
+// restoreSecurityProfile restores the SecurityProfile fields which do not exist in this version.
+func restoreSecurityProfile(restored, dst *infrav1.SecurityProfile) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.SecurityType = restored.SecurityType
+	dst.UefiSettings = restored.UefiSettings
+}
+
+// restoreManagedDiskSecurityProfile restores the managed disk SecurityProfile, which does not exist in this version.
+func restoreManagedDiskSecurityProfile(restored, dst *infrav1.ManagedDiskParameters) {
+	if restored == nil || dst == nil {
+		return
+	}
+	dst.SecurityProfile = restored.SecurityProfile
+}
@@ -70,6 +70,29 @@ func (src *AzureMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.SystemAssignedIdentityRole = restored.Spec.Template.Spec.SystemAssignedIdentityRole
 	}
 
+	if len(restored.Spec.Template.Spec.ApplicationSecurityGroups) > 0 {
+		dst.Spec.Template.Spec.ApplicationSecurityGroups = restored.Spec.Template.Spec.ApplicationSecurityGroups
+	}
+
+	// Restore the dedicated host placement.
+	dst.Spec.Template.Spec.HostGroupID = restored.Spec.Template.Spec.HostGroupID
+	dst.Spec.Template.Spec.HostID = restored.Spec.Template.Spec.HostID
+
+	// Restore the proximity placement group and capacity reservation group placement.
+	dst.Spec.Template.Spec.ProximityPlacementGroupID = restored.Spec.Template.Spec.ProximityPlacementGroupID
+	dst.Spec.Template.Spec.CapacityReservationGroupID = restored.Spec.Template.Spec.CapacityReservationGroupID
+
+	// Restore the update strategy.
+	dst.Spec.Template.Spec.UpdateStrategy = restored.Spec.Template.Spec.UpdateStrategy
+
+	restoreSecurityProfile(restored.Spec.Template.Spec.SecurityProfile, dst.Spec.Template.Spec.SecurityProfile)
+	restoreManagedDiskSecurityProfile(restored.Spec.Template.Spec.OSDisk.ManagedDisk, dst.Spec.Template.Spec.OSDisk.ManagedDisk)
+	for i := range dst.Spec.Template.Spec.DataDisks {
+		if i < len(restored.Spec.Template.Spec.DataDisks) {
+			restoreManagedDiskSecurityProfile(restored.Spec.Template.Spec.DataDisks[i].ManagedDisk, dst.Spec.Template.Spec.DataDisks[i].ManagedDisk)
+		}
+	}
+
 	return nil
 }
 
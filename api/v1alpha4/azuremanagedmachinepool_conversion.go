@@ -58,6 +58,7 @@ func (src *AzureManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Status.LongRunningOperationStates = restored.Status.LongRunningOperationStates
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.OperationHistory = restored.Status.OperationHistory
 
 	return nil
 }
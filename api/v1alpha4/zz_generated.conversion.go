@@ -167,11 +167,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AzureClusterStatus)(nil), (*AzureClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(a.(*v1beta1.AzureClusterStatus), b.(*AzureClusterStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AzureMachine)(nil), (*v1beta1.AzureMachine)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_AzureMachine_To_v1beta1_AzureMachine(a.(*AzureMachine), b.(*v1beta1.AzureMachine), scope)
 	}); err != nil {
@@ -202,11 +197,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AzureMachineStatus)(nil), (*AzureMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(a.(*v1beta1.AzureMachineStatus), b.(*AzureMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AzureMachineTemplate)(nil), (*v1beta1.AzureMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_AzureMachineTemplate_To_v1beta1_AzureMachineTemplate(a.(*AzureMachineTemplate), b.(*v1beta1.AzureMachineTemplate), scope)
 	}); err != nil {
@@ -462,11 +452,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ManagedDiskParameters)(nil), (*ManagedDiskParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(a.(*v1beta1.ManagedDiskParameters), b.(*ManagedDiskParameters), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*OSDisk)(nil), (*v1beta1.OSDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(a.(*OSDisk), b.(*v1beta1.OSDisk), scope)
 	}); err != nil {
@@ -517,21 +502,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SecurityRule)(nil), (*v1beta1.SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(a.(*SecurityRule), b.(*v1beta1.SecurityRule), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.SecurityRule)(nil), (*SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(a.(*v1beta1.SecurityRule), b.(*SecurityRule), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*SpotVMOptions)(nil), (*v1beta1.SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(a.(*SpotVMOptions), b.(*v1beta1.SpotVMOptions), scope)
 	}); err != nil {
@@ -597,11 +572,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.AzureClusterStatus)(nil), (*AzureClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(a.(*v1beta1.AzureClusterStatus), b.(*AzureClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.AzureMachineSpec)(nil), (*AzureMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(a.(*v1beta1.AzureMachineSpec), b.(*AzureMachineSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.AzureMachineStatus)(nil), (*AzureMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(a.(*v1beta1.AzureMachineStatus), b.(*AzureMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.AzureMachineTemplateResource)(nil), (*AzureMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AzureMachineTemplateResource_To_v1alpha4_AzureMachineTemplateResource(a.(*v1beta1.AzureMachineTemplateResource), b.(*AzureMachineTemplateResource), scope)
 	}); err != nil {
@@ -657,6 +642,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.ManagedDiskParameters)(nil), (*ManagedDiskParameters)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(a.(*v1beta1.ManagedDiskParameters), b.(*ManagedDiskParameters), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.NatGateway)(nil), (*NatGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NatGateway_To_v1alpha4_NatGateway(a.(*v1beta1.NatGateway), b.(*NatGateway), scope)
 	}); err != nil {
@@ -677,6 +667,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.SecurityProfile)(nil), (*SecurityProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(a.(*v1beta1.SecurityProfile), b.(*SecurityProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.SecurityRule)(nil), (*SecurityRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(a.(*v1beta1.SecurityRule), b.(*SecurityRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.SpotVMOptions)(nil), (*SpotVMOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_SpotVMOptions_To_v1alpha4_SpotVMOptions(a.(*v1beta1.SpotVMOptions), b.(*SpotVMOptions), scope)
 	}); err != nil {
@@ -1154,14 +1154,11 @@ func autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *v
 		out.Conditions = nil
 	}
 	out.LongRunningOperationStates = *(*Futures)(unsafe.Pointer(&in.LongRunningOperationStates))
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIServerPrivateLinkServiceAlias requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus is an autogenerated conversion function.
-func Convert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in *v1beta1.AzureClusterStatus, out *AzureClusterStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_AzureClusterStatus_To_v1alpha4_AzureClusterStatus(in, out, s)
-}
-
 func autoConvert_v1alpha4_AzureMachine_To_v1beta1_AzureMachine(in *AzureMachine, out *v1beta1.AzureMachine, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha4_AzureMachineSpec_To_v1beta1_AzureMachineSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1255,7 +1252,17 @@ func autoConvert_v1alpha4_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	if err := Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
-	out.DataDisks = *(*[]v1beta1.DataDisk)(unsafe.Pointer(&in.DataDisks))
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]v1beta1.DataDisk, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DataDisks = nil
+	}
 	out.SSHPublicKey = in.SSHPublicKey
 	out.AdditionalTags = *(*v1beta1.Tags)(unsafe.Pointer(&in.AdditionalTags))
 	out.AllocatePublicIP = in.AllocatePublicIP
@@ -1270,7 +1277,15 @@ func autoConvert_v1alpha4_AzureMachineSpec_To_v1beta1_AzureMachineSpec(in *Azure
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*v1beta1.SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(v1beta1.SecurityProfile)
+		if err := Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	out.SubnetName = in.SubnetName
 	return nil
 }
@@ -1300,7 +1315,17 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *v1bet
 	if err := Convert_v1beta1_OSDisk_To_v1alpha4_OSDisk(&in.OSDisk, &out.OSDisk, s); err != nil {
 		return err
 	}
-	out.DataDisks = *(*[]DataDisk)(unsafe.Pointer(&in.DataDisks))
+	if in.DataDisks != nil {
+		in, out := &in.DataDisks, &out.DataDisks
+		*out = make([]DataDisk, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_DataDisk_To_v1alpha4_DataDisk(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.DataDisks = nil
+	}
 	out.SSHPublicKey = in.SSHPublicKey
 	out.AdditionalTags = *(*Tags)(unsafe.Pointer(&in.AdditionalTags))
 	// WARNING: in.AdditionalCapabilities requires manual conversion: does not exist in peer-type
@@ -1317,11 +1342,25 @@ func autoConvert_v1beta1_AzureMachineSpec_To_v1alpha4_AzureMachineSpec(in *v1bet
 	} else {
 		out.SpotVMOptions = nil
 	}
-	out.SecurityProfile = (*SecurityProfile)(unsafe.Pointer(in.SecurityProfile))
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(SecurityProfile)
+		if err := Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SecurityProfile = nil
+	}
 	out.SubnetName = in.SubnetName
 	// WARNING: in.DNSServers requires manual conversion: does not exist in peer-type
 	// WARNING: in.VMExtensions requires manual conversion: does not exist in peer-type
 	// WARNING: in.NetworkInterfaces requires manual conversion: does not exist in peer-type
+	// WARNING: in.ApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostID requires manual conversion: does not exist in peer-type
+	// WARNING: in.ProximityPlacementGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.CapacityReservationGroupID requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpdateStrategy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1355,6 +1394,7 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *v
 	out.Ready = in.Ready
 	out.Addresses = *(*[]corev1.NodeAddress)(unsafe.Pointer(&in.Addresses))
 	out.VMState = (*ProvisioningState)(unsafe.Pointer(in.VMState))
+	// WARNING: in.CapacityReservationID requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	if in.Conditions != nil {
@@ -1369,14 +1409,10 @@ func autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *v
 		out.Conditions = nil
 	}
 	out.LongRunningOperationStates = *(*Futures)(unsafe.Pointer(&in.LongRunningOperationStates))
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus is an autogenerated conversion function.
-func Convert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in *v1beta1.AzureMachineStatus, out *AzureMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_AzureMachineStatus_To_v1alpha4_AzureMachineStatus(in, out, s)
-}
-
 func autoConvert_v1alpha4_AzureMachineTemplate_To_v1beta1_AzureMachineTemplate(in *AzureMachineTemplate, out *v1beta1.AzureMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha4_AzureMachineTemplateSpec_To_v1beta1_AzureMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1758,6 +1794,7 @@ func autoConvert_v1beta1_AzureManagedControlPlaneStatus_To_v1alpha4_AzureManaged
 	out.Initialized = in.Initialized
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	out.LongRunningOperationStates = *(*Futures)(unsafe.Pointer(&in.LongRunningOperationStates))
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1893,6 +1930,7 @@ func autoConvert_v1beta1_AzureManagedMachinePoolStatus_To_v1alpha4_AzureManagedM
 	out.ErrorMessage = (*string)(unsafe.Pointer(in.ErrorMessage))
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	// WARNING: in.LongRunningOperationStates requires manual conversion: does not exist in peer-type
+	// WARNING: in.OperationHistory requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2069,7 +2107,15 @@ func Convert_v1beta1_CloudProviderConfigOverrides_To_v1alpha4_CloudProviderConfi
 func autoConvert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.DataDisk, s conversion.Scope) error {
 	out.NameSuffix = in.NameSuffix
 	out.DiskSizeGB = in.DiskSizeGB
-	out.ManagedDisk = (*v1beta1.ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(v1beta1.ManagedDiskParameters)
+		if err := Convert_v1alpha4_ManagedDiskParameters_To_v1beta1_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
 	out.Lun = (*int32)(unsafe.Pointer(in.Lun))
 	out.CachingType = in.CachingType
 	return nil
@@ -2083,7 +2129,15 @@ func Convert_v1alpha4_DataDisk_To_v1beta1_DataDisk(in *DataDisk, out *v1beta1.Da
 func autoConvert_v1beta1_DataDisk_To_v1alpha4_DataDisk(in *v1beta1.DataDisk, out *DataDisk, s conversion.Scope) error {
 	out.NameSuffix = in.NameSuffix
 	out.DiskSizeGB = in.DiskSizeGB
-	out.ManagedDisk = (*ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDiskParameters)
+		if err := Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
 	out.Lun = (*int32)(unsafe.Pointer(in.Lun))
 	out.CachingType = in.CachingType
 	return nil
@@ -2293,6 +2347,10 @@ func autoConvert_v1beta1_LoadBalancerSpec_To_v1alpha4_LoadBalancerSpec(in *v1bet
 	}
 	out.FrontendIPsCount = (*int32)(unsafe.Pointer(in.FrontendIPsCount))
 	// WARNING: in.BackendPool requires manual conversion: does not exist in peer-type
+	// WARNING: in.PublicIPPrefix requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrivateLinkService requires manual conversion: does not exist in peer-type
+	// WARNING: in.Probe requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalRules requires manual conversion: does not exist in peer-type
 	// WARNING: in.LoadBalancerClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -2354,14 +2412,10 @@ func Convert_v1alpha4_ManagedDiskParameters_To_v1beta1_ManagedDiskParameters(in
 func autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *v1beta1.ManagedDiskParameters, out *ManagedDiskParameters, s conversion.Scope) error {
 	out.StorageAccountType = in.StorageAccountType
 	out.DiskEncryptionSet = (*DiskEncryptionSetParameters)(unsafe.Pointer(in.DiskEncryptionSet))
+	// WARNING: in.SecurityProfile requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters is an autogenerated conversion function.
-func Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in *v1beta1.ManagedDiskParameters, out *ManagedDiskParameters, s conversion.Scope) error {
-	return autoConvert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(in, out, s)
-}
-
 func autoConvert_v1alpha4_NatGateway_To_v1beta1_NatGateway(in *NatGateway, out *v1beta1.NatGateway, s conversion.Scope) error {
 	out.ID = in.ID
 	// WARNING: in.Name requires manual conversion: does not exist in peer-type
@@ -2376,6 +2430,7 @@ func autoConvert_v1beta1_NatGateway_To_v1alpha4_NatGateway(in *v1beta1.NatGatewa
 	if err := Convert_v1beta1_PublicIPSpec_To_v1alpha4_PublicIPSpec(&in.NatGatewayIP, &out.NatGatewayIP, s); err != nil {
 		return err
 	}
+	// WARNING: in.PublicIPPrefix requires manual conversion: does not exist in peer-type
 	// WARNING: in.NatGatewayClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -2463,7 +2518,15 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in *v1beta1.Network
 func autoConvert_v1alpha4_OSDisk_To_v1beta1_OSDisk(in *OSDisk, out *v1beta1.OSDisk, s conversion.Scope) error {
 	out.OSType = in.OSType
 	out.DiskSizeGB = (*int32)(unsafe.Pointer(in.DiskSizeGB))
-	out.ManagedDisk = (*v1beta1.ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(v1beta1.ManagedDiskParameters)
+		if err := Convert_v1alpha4_ManagedDiskParameters_To_v1beta1_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
 	out.DiffDiskSettings = (*v1beta1.DiffDiskSettings)(unsafe.Pointer(in.DiffDiskSettings))
 	out.CachingType = in.CachingType
 	return nil
@@ -2477,7 +2540,15 @@ func Convert_v1alpha4_OSDisk_To_v1beta1_OSDisk(in *OSDisk, out *v1beta1.OSDisk,
 func autoConvert_v1beta1_OSDisk_To_v1alpha4_OSDisk(in *v1beta1.OSDisk, out *OSDisk, s conversion.Scope) error {
 	out.OSType = in.OSType
 	out.DiskSizeGB = (*int32)(unsafe.Pointer(in.DiskSizeGB))
-	out.ManagedDisk = (*ManagedDiskParameters)(unsafe.Pointer(in.ManagedDisk))
+	if in.ManagedDisk != nil {
+		in, out := &in.ManagedDisk, &out.ManagedDisk
+		*out = new(ManagedDiskParameters)
+		if err := Convert_v1beta1_ManagedDiskParameters_To_v1alpha4_ManagedDiskParameters(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ManagedDisk = nil
+	}
 	out.DiffDiskSettings = (*DiffDiskSettings)(unsafe.Pointer(in.DiffDiskSettings))
 	out.CachingType = in.CachingType
 	return nil
@@ -2609,14 +2680,11 @@ func Convert_v1alpha4_SecurityProfile_To_v1beta1_SecurityProfile(in *SecurityPro
 
 func autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
 	out.EncryptionAtHost = (*bool)(unsafe.Pointer(in.EncryptionAtHost))
+	// WARNING: in.SecurityType requires manual conversion: does not exist in peer-type
+	// WARNING: in.UefiSettings requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile is an autogenerated conversion function.
-func Convert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in *v1beta1.SecurityProfile, out *SecurityProfile, s conversion.Scope) error {
-	return autoConvert_v1beta1_SecurityProfile_To_v1alpha4_SecurityProfile(in, out, s)
-}
-
 func autoConvert_v1alpha4_SecurityRule_To_v1beta1_SecurityRule(in *SecurityRule, out *v1beta1.SecurityRule, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Description = in.Description
@@ -2645,14 +2713,11 @@ func autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *v1beta1.Secur
 	out.DestinationPorts = (*string)(unsafe.Pointer(in.DestinationPorts))
 	out.Source = (*string)(unsafe.Pointer(in.Source))
 	out.Destination = (*string)(unsafe.Pointer(in.Destination))
+	// WARNING: in.SourceApplicationSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.DestinationApplicationSecurityGroups requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule is an autogenerated conversion function.
-func Convert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in *v1beta1.SecurityRule, out *SecurityRule, s conversion.Scope) error {
-	return autoConvert_v1beta1_SecurityRule_To_v1alpha4_SecurityRule(in, out, s)
-}
-
 func autoConvert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(in *SpotVMOptions, out *v1beta1.SpotVMOptions, s conversion.Scope) error {
 	out.MaxPrice = (*resource.Quantity)(unsafe.Pointer(in.MaxPrice))
 	return nil
@@ -2735,6 +2800,7 @@ func autoConvert_v1beta1_VnetSpec_To_v1alpha4_VnetSpec(in *v1beta1.VnetSpec, out
 	out.ID = in.ID
 	out.Name = in.Name
 	// WARNING: in.Peerings requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPAMPoolRef requires manual conversion: does not exist in peer-type
 	// WARNING: in.VnetClassSpec requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -41,6 +41,15 @@ const (
 	DefaultInternalLBIPAddress = "10.0.0.100"
 	// DefaultOutboundRuleIdleTimeoutInMinutes is the default for IdleTimeoutInMinutes for the load balancer.
 	DefaultOutboundRuleIdleTimeoutInMinutes = 4
+	// DefaultLoadBalancerProbeIntervalInSeconds is the default interval between two load balancer health probes.
+	DefaultLoadBalancerProbeIntervalInSeconds = 15
+	// DefaultLoadBalancerProbeNumberOfProbes is the default number of failed load balancer health probes after which
+	// a backend is considered unhealthy.
+	DefaultLoadBalancerProbeNumberOfProbes = 4
+	// DefaultLoadBalancerProbeRequestPath is the default path requested by Http and Https load balancer health probes.
+	DefaultLoadBalancerProbeRequestPath = "/readyz"
+	// DefaultPublicIPPrefixLength is the default length of the public IP prefixes.
+	DefaultPublicIPPrefixLength = 28
 	// DefaultAzureCloud is the public cloud that will be used by most users.
 	DefaultAzureCloud = "AzurePublicCloud"
 )
@@ -80,7 +89,10 @@ func (c *AzureCluster) setVnetDefaults() {
 	if c.Spec.NetworkSpec.Vnet.Name == "" {
 		c.Spec.NetworkSpec.Vnet.Name = generateVnetName(c.ObjectMeta.Name)
 	}
-	c.Spec.NetworkSpec.Vnet.VnetClassSpec.setDefaults()
+	// The address space of a virtual network using IPAM is allocated by the controller.
+	if c.Spec.NetworkSpec.Vnet.IPAMPoolRef == nil {
+		c.Spec.NetworkSpec.Vnet.VnetClassSpec.setDefaults()
+	}
 }
 
 func (c *AzureCluster) setSubnetDefaults() {
@@ -94,13 +106,18 @@ func (c *AzureCluster) setSubnetDefaults() {
 		cpSubnet.Name = generateControlPlaneSubnetName(c.ObjectMeta.Name)
 	}
 
-	cpSubnet.SubnetClassSpec.setDefaults(DefaultControlPlaneSubnetCIDR)
+	cpSubnet.SubnetClassSpec.setDefaults(c.defaultNetworkAddress(DefaultControlPlaneSubnetCIDR))
 
 	if cpSubnet.SecurityGroup.Name == "" {
 		cpSubnet.SecurityGroup.Name = generateControlPlaneSecurityGroupName(c.ObjectMeta.Name)
 	}
 	cpSubnet.SecurityGroup.SecurityGroupClass.setDefaults()
 
+	// The control plane subnet needs a route table of its own to route its egress traffic to the virtual appliance.
+	if c.Spec.NetworkSpec.IsUserDefinedRouting() && cpSubnet.RouteTable.Name == "" {
+		cpSubnet.RouteTable.Name = generateControlPlaneRouteTableName(c.ObjectMeta.Name)
+	}
+
 	c.Spec.NetworkSpec.UpdateControlPlaneSubnet(cpSubnet)
 
 	var nodeSubnetFound bool
@@ -114,7 +131,7 @@ func (c *AzureCluster) setSubnetDefaults() {
 		if subnet.Name == "" {
 			subnet.Name = withIndex(generateNodeSubnetName(c.ObjectMeta.Name), nodeSubnetCounter)
 		}
-		subnet.SubnetClassSpec.setDefaults(fmt.Sprintf(DefaultNodeSubnetCIDRPattern, nodeSubnetCounter))
+		subnet.SubnetClassSpec.setDefaults(c.defaultNetworkAddress(fmt.Sprintf(DefaultNodeSubnetCIDRPattern, nodeSubnetCounter)))
 
 		if subnet.SecurityGroup.Name == "" {
 			subnet.SecurityGroup.Name = generateNodeSecurityGroupName(c.ObjectMeta.Name)
@@ -128,6 +145,7 @@ func (c *AzureCluster) setSubnetDefaults() {
 			if subnet.NatGateway.NatGatewayIP.Name == "" {
 				subnet.NatGateway.NatGatewayIP.Name = generateNatGatewayIPName(c.ObjectMeta.Name, subnet.Name)
 			}
+			c.setPublicIPPrefixDefaults(subnet.NatGateway.PublicIPPrefix)
 		}
 
 		c.Spec.NetworkSpec.Subnets[i] = subnet
@@ -136,9 +154,8 @@ func (c *AzureCluster) setSubnetDefaults() {
 	if !nodeSubnetFound {
 		nodeSubnet := SubnetSpec{
 			SubnetClassSpec: SubnetClassSpec{
-				Role:       SubnetNode,
-				CIDRBlocks: []string{DefaultNodeSubnetCIDR},
-				Name:       generateNodeSubnetName(c.ObjectMeta.Name),
+				Role: SubnetNode,
+				Name: generateNodeSubnetName(c.ObjectMeta.Name),
 			},
 			SecurityGroup: SecurityGroup{
 				Name: generateNodeSecurityGroupName(c.ObjectMeta.Name),
@@ -147,10 +164,20 @@ func (c *AzureCluster) setSubnetDefaults() {
 				Name: generateNodeRouteTableName(c.ObjectMeta.Name),
 			},
 		}
+		nodeSubnet.SubnetClassSpec.setDefaults(c.defaultNetworkAddress(DefaultNodeSubnetCIDR))
 		c.Spec.NetworkSpec.Subnets = append(c.Spec.NetworkSpec.Subnets, nodeSubnet)
 	}
 }
 
+// defaultNetworkAddress returns the given default subnet CIDR or IP address, or an empty string if the address space
+// of the virtual network is allocated by the controller from an IPAM pool.
+func (c *AzureCluster) defaultNetworkAddress(address string) string {
+	if c.Spec.NetworkSpec.Vnet.IPAMPoolRef != nil {
+		return ""
+	}
+	return address
+}
+
 func (c *AzureCluster) setVnetPeeringDefaults() {
 	for i, peering := range c.Spec.NetworkSpec.Vnet.Peerings {
 		if peering.ResourceGroup == "" {
@@ -187,7 +214,8 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 				{
 					Name: generateFrontendIPConfigName(lb.Name),
 					FrontendIPClass: FrontendIPClass{
-						PrivateIPAddress: DefaultInternalLBIPAddress,
+						// The private IP of a virtual network using IPAM is set once its subnets are allocated.
+						PrivateIPAddress: c.defaultNetworkAddress(DefaultInternalLBIPAddress),
 					},
 				},
 			}
@@ -197,12 +225,49 @@ func (c *AzureCluster) setAPIServerLBDefaults() {
 	if lb.BackendPool.Name == "" {
 		lb.BackendPool.Name = generateBackendAddressPoolName(lb.Name)
 	}
+
+	c.setPublicIPPrefixDefaults(lb.PublicIPPrefix)
+
+	if lb.PrivateLinkService != nil && lb.PrivateLinkService.Name == "" {
+		lb.PrivateLinkService.Name = generatePrivateLinkServiceName(c.ObjectMeta.Name)
+	}
+
+	setLoadBalancerProbeDefaults(lb.Probe)
+
+	for i := range lb.AdditionalRules {
+		rule := &lb.AdditionalRules[i]
+		if rule.Protocol == "" {
+			rule.Protocol = LoadBalancingRuleProtocolTCP
+		}
+		if rule.BackendPort == 0 {
+			rule.BackendPort = rule.FrontendPort
+		}
+	}
+}
+
+// setLoadBalancerProbeDefaults sets the default values of a load balancer health probe.
+func setLoadBalancerProbeDefaults(probe *LoadBalancerProbe) {
+	if probe == nil {
+		return
+	}
+	if probe.Protocol == "" {
+		probe.Protocol = LoadBalancerProbeProtocolTCP
+	}
+	if probe.RequestPath == "" && probe.Protocol != LoadBalancerProbeProtocolTCP {
+		probe.RequestPath = DefaultLoadBalancerProbeRequestPath
+	}
+	if probe.IntervalInSeconds == nil {
+		probe.IntervalInSeconds = pointer.Int32(DefaultLoadBalancerProbeIntervalInSeconds)
+	}
+	if probe.NumberOfProbes == nil {
+		probe.NumberOfProbes = pointer.Int32(DefaultLoadBalancerProbeNumberOfProbes)
+	}
 }
 
 // SetNodeOutboundLBDefaults sets the default values for the NodeOutboundLB.
 func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	if c.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.NetworkSpec.IsUserDefinedRouting() {
 			return
 		}
 
@@ -240,6 +305,8 @@ func (c *AzureCluster) SetNodeOutboundLBDefaults() {
 	if lb.BackendPool.Name == "" {
 		lb.BackendPool.Name = generateOutboundBackendAddressPoolName(lb.Name)
 	}
+
+	c.setPublicIPPrefixDefaults(lb.PublicIPPrefix)
 }
 
 // SetControlPlaneOutboundLBDefaults sets the default values for the control plane's outbound LB.
@@ -262,6 +329,21 @@ func (c *AzureCluster) SetControlPlaneOutboundLBDefaults() {
 	if lb.BackendPool.Name == "" {
 		lb.BackendPool.Name = generateOutboundBackendAddressPoolName(generateControlPlaneOutboundLBName(c.ObjectMeta.Name))
 	}
+
+	c.setPublicIPPrefixDefaults(lb.PublicIPPrefix)
+}
+
+// setPublicIPPrefixDefaults sets the default values of a public IP prefix, if any.
+func (c *AzureCluster) setPublicIPPrefixDefaults(prefix *PublicIPPrefixSpec) {
+	if prefix == nil {
+		return
+	}
+	if prefix.ResourceGroup == "" {
+		prefix.ResourceGroup = c.Spec.ResourceGroup
+	}
+	if prefix.PrefixLength == 0 {
+		prefix.PrefixLength = DefaultPublicIPPrefixLength
+	}
 }
 
 // setOutboundLBFrontendIPs sets the frontend ips for the given load balancer.
@@ -301,9 +383,7 @@ func (c *AzureCluster) setBastionDefaults() {
 		if c.Spec.BastionSpec.AzureBastion.Subnet.Name == "" {
 			c.Spec.BastionSpec.AzureBastion.Subnet.Name = DefaultAzureBastionSubnetName
 		}
-		if len(c.Spec.BastionSpec.AzureBastion.Subnet.CIDRBlocks) == 0 {
-			c.Spec.BastionSpec.AzureBastion.Subnet.CIDRBlocks = []string{DefaultAzureBastionSubnetCIDR}
-		}
+		c.Spec.BastionSpec.AzureBastion.Subnet.SubnetClassSpec.setDefaults(c.defaultNetworkAddress(DefaultAzureBastionSubnetCIDR))
 		if c.Spec.BastionSpec.AzureBastion.Subnet.Role == "" {
 			c.Spec.BastionSpec.AzureBastion.Subnet.Role = DefaultAzureBastionSubnetRole
 		}
@@ -396,16 +476,36 @@ func generateNodeSecurityGroupName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-nsg")
 }
 
+// generateControlPlaneApplicationSecurityGroupName generates a control plane application security group name, based on the cluster name.
+func generateControlPlaneApplicationSecurityGroupName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "controlplane-asg")
+}
+
+// generateNodeApplicationSecurityGroupName generates a node application security group name, based on the cluster name.
+func generateNodeApplicationSecurityGroupName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "node-asg")
+}
+
 // generateNodeRouteTableName generates a node route table name, based on the cluster name.
 func generateNodeRouteTableName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "node-routetable")
 }
 
+// generateControlPlaneRouteTableName generates a control plane route table name, based on the cluster name.
+func generateControlPlaneRouteTableName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "controlplane-routetable")
+}
+
 // generateInternalLBName generates a internal load balancer name, based on the cluster name.
 func generateInternalLBName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "internal-lb")
 }
 
+// generatePrivateLinkServiceName generates the name of the private link service of the API server load balancer.
+func generatePrivateLinkServiceName(clusterName string) string {
+	return fmt.Sprintf("%s-%s", clusterName, "apiserver-pls")
+}
+
 // generatePublicLBName generates a public load balancer name, based on the cluster name.
 func generatePublicLBName(clusterName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, "public-lb")
@@ -21,6 +21,8 @@ import (
 	"reflect"
 	"testing"
 
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -259,7 +261,44 @@ func TestVnetDefaults(t *testing.T) {
 	}
 }
 
+func TestIPAMDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		Spec: AzureClusterSpec{
+			BastionSpec: BastionSpec{
+				AzureBastion: &AzureBastion{},
+			},
+			NetworkSpec: NetworkSpec{
+				Vnet: VnetSpec{
+					IPAMPoolRef: &corev1.ObjectReference{Name: "my-pool"},
+				},
+				APIServerLB: LoadBalancerSpec{
+					LoadBalancerClassSpec: LoadBalancerClassSpec{
+						Type: Internal,
+					},
+				},
+			},
+		},
+	}
+	cluster.setDefaults()
+
+	// The address spaces are allocated by the controller instead.
+	g.Expect(cluster.Spec.NetworkSpec.Vnet.CIDRBlocks).To(BeEmpty())
+	g.Expect(cluster.Spec.NetworkSpec.Subnets).To(HaveLen(2))
+	for _, subnet := range cluster.Spec.NetworkSpec.Subnets {
+		g.Expect(subnet.CIDRBlocks).To(BeEmpty())
+	}
+	g.Expect(cluster.Spec.BastionSpec.AzureBastion.Subnet.CIDRBlocks).To(BeEmpty())
+	g.Expect(cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs).To(HaveLen(1))
+	g.Expect(cluster.Spec.NetworkSpec.APIServerLB.FrontendIPs[0].PrivateIPAddress).To(BeEmpty())
+}
+
 func TestSubnetDefaults(t *testing.T) {
+	udr := OutboundTypeUserDefinedRouting
 	cases := []struct {
 		name    string
 		cluster *AzureCluster
@@ -306,6 +345,55 @@ func TestSubnetDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no subnets with user defined routing",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType:     &udr,
+							NextHopIPAddress: "10.0.255.4",
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType:     &udr,
+							NextHopIPAddress: "10.0.255.4",
+						},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetControlPlane,
+									CIDRBlocks: []string{DefaultControlPlaneSubnetCIDR},
+									Name:       "cluster-test-controlplane-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-controlplane-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-controlplane-routetable"},
+							},
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role:       SubnetNode,
+									CIDRBlocks: []string{DefaultNodeSubnetCIDR},
+									Name:       "cluster-test-node-subnet",
+								},
+								SecurityGroup: SecurityGroup{Name: "cluster-test-node-nsg"},
+								RouteTable:    RouteTable{Name: "cluster-test-node-routetable"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "subnets with custom attributes",
 			cluster: &AzureCluster{
@@ -920,6 +1008,126 @@ func TestAPIServerLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "internal lb with a private link service",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+							PrivateLinkService: &PrivateLinkServiceSpec{
+								AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000000"},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-internal-lb-frontEnd",
+									FrontendIPClass: FrontendIPClass{
+										PrivateIPAddress: DefaultInternalLBIPAddress,
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "cluster-test-internal-lb-backendPool",
+							},
+							PrivateLinkService: &PrivateLinkServiceSpec{
+								Name:                      "cluster-test-apiserver-pls",
+								AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000000"},
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Internal,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							Name: "cluster-test-internal-lb",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "internal lb with an https probe and additional rules",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+							Probe: &LoadBalancerProbe{
+								Protocol: LoadBalancerProbeProtocolHTTPS,
+							},
+							AdditionalRules: []LoadBalancingRule{
+								{
+									Name:         "konnectivity",
+									FrontendPort: 8132,
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-internal-lb-frontEnd",
+									FrontendIPClass: FrontendIPClass{
+										PrivateIPAddress: DefaultInternalLBIPAddress,
+									},
+								},
+							},
+							BackendPool: BackendPool{
+								Name: "cluster-test-internal-lb-backendPool",
+							},
+							Probe: &LoadBalancerProbe{
+								Protocol:          LoadBalancerProbeProtocolHTTPS,
+								RequestPath:       DefaultLoadBalancerProbeRequestPath,
+								IntervalInSeconds: pointer.Int32(DefaultLoadBalancerProbeIntervalInSeconds),
+								NumberOfProbes:    pointer.Int32(DefaultLoadBalancerProbeNumberOfProbes),
+							},
+							AdditionalRules: []LoadBalancingRule{
+								{
+									Name:         "konnectivity",
+									Protocol:     LoadBalancingRuleProtocolTCP,
+									FrontendPort: 8132,
+									BackendPort:  8132,
+								},
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Internal,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+							Name: "cluster-test-internal-lb",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "with custom backend pool name",
 			cluster: &AzureCluster{
@@ -1068,6 +1276,7 @@ func TestAzureEnviromentDefault(t *testing.T) {
 }
 
 func TestNodeOutboundLBDefaults(t *testing.T) {
+	udr := OutboundTypeUserDefinedRouting
 	cases := []struct {
 		name    string
 		cluster *AzureCluster
@@ -1764,6 +1973,53 @@ func TestNodeOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no lb with user defined routing",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType:     &udr,
+							NextHopIPAddress: "10.0.255.4",
+						},
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetNode,
+									Name: "node-subnet",
+								},
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{
+							OutboundType:     &udr,
+							NextHopIPAddress: "10.0.255.4",
+						},
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Public}},
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{
+									Role: SubnetNode,
+									Name: "node-subnet",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -1954,6 +2210,65 @@ func TestControlPlaneOutboundLBDefaults(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "outbound lb with a public IP prefix",
+			cluster: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "cluster-rg",
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{LoadBalancerClassSpec: LoadBalancerClassSpec{Type: Internal}},
+						ControlPlaneOutboundLB: &LoadBalancerSpec{
+							PublicIPPrefix: &PublicIPPrefixSpec{
+								Name: "my-prefix",
+							},
+						},
+					},
+				},
+			},
+			output: &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-test",
+				},
+				Spec: AzureClusterSpec{
+					ResourceGroup: "cluster-rg",
+					NetworkSpec: NetworkSpec{
+						APIServerLB: LoadBalancerSpec{
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								Type: Internal,
+							},
+						},
+						ControlPlaneOutboundLB: &LoadBalancerSpec{
+							Name: "cluster-test-outbound-lb",
+							BackendPool: BackendPool{
+								Name: "cluster-test-outbound-lb-outboundBackendPool",
+							},
+							FrontendIPs: []FrontendIP{
+								{
+									Name: "cluster-test-outbound-lb-frontEnd",
+									PublicIP: &PublicIPSpec{
+										Name: "pip-cluster-test-controlplane-outbound",
+									},
+								},
+							},
+							FrontendIPsCount: pointer.Int32(1),
+							PublicIPPrefix: &PublicIPPrefixSpec{
+								Name:          "my-prefix",
+								ResourceGroup: "cluster-rg",
+								PrefixLength:  DefaultPublicIPPrefixLength,
+							},
+							LoadBalancerClassSpec: LoadBalancerClassSpec{
+								SKU:                  SKUStandard,
+								Type:                 Public,
+								IdleTimeoutInMinutes: pointer.Int32(DefaultOutboundRuleIdleTimeoutInMinutes),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -74,6 +74,16 @@ type AzureClusterStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationHistory records the most recent Azure operations that created, updated or deleted resources,
+	// with their timing and result. At most MaxOperationHistory operations are kept.
+	// +optional
+	OperationHistory Operations `json:"operationHistory,omitempty"`
+
+	// APIServerPrivateLinkServiceAlias is the alias of the private link service of the API server load balancer.
+	// Private endpoints use it to connect to the private link service.
+	// +optional
+	APIServerPrivateLinkServiceAlias string `json:"apiServerPrivateLinkServiceAlias,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -128,6 +138,16 @@ func (c *AzureCluster) SetFutures(futures Futures) {
 	c.Status.LongRunningOperationStates = futures
 }
 
+// GetOperationHistory returns the history of the Azure operations of an AzureCluster API object.
+func (c *AzureCluster) GetOperationHistory() Operations {
+	return c.Status.OperationHistory
+}
+
+// SetOperationHistory will set the given history of Azure operations on an AzureCluster object.
+func (c *AzureCluster) SetOperationHistory(operations Operations) {
+	c.Status.OperationHistory = operations
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureCluster{}, &AzureClusterList{})
 }
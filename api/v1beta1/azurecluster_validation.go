@@ -17,16 +17,22 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"reflect"
 	"regexp"
+	"strings"
 
 	valid "github.com/asaskevich/govalidator"
+	"github.com/google/uuid"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -59,18 +65,28 @@ const (
 	privateEndpointRegex = `^[-\w\._]+$`
 	// resource ID Pattern.
 	resourceIDPattern = `(?i)subscriptions/(.+)/resourceGroups/(.+)/providers/(.+?)/(.+?)/(.+)`
+	// DDoS protection plan resource ID pattern.
+	ddosProtectionPlanIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Network/ddosProtectionPlans/[^/]+$`
+	// Storage account resource ID pattern.
+	storageAccountIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Storage/storageAccounts/[^/]+$`
+	// Log Analytics workspace resource ID pattern.
+	workspaceIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.OperationalInsights/workspaces/[^/]+$`
 )
 
 var (
 	serviceEndpointServiceRegex  = regexp.MustCompile(serviceEndpointServiceRegexPattern)
 	serviceEndpointLocationRegex = regexp.MustCompile(serviceEndpointLocationRegexPattern)
+	ddosProtectionPlanIDRegex    = regexp.MustCompile(ddosProtectionPlanIDPattern)
+	storageAccountIDRegex        = regexp.MustCompile(storageAccountIDPattern)
+	workspaceIDRegex             = regexp.MustCompile(workspaceIDPattern)
 )
 
 // validateCluster validates a cluster.
-func (c *AzureCluster) validateCluster(old *AzureCluster) error {
+func (c *AzureCluster) validateCluster(old *AzureCluster, cli client.Client) error {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, c.validateClusterName()...)
 	allErrs = append(allErrs, c.validateClusterSpec(old)...)
+	allErrs = append(allErrs, c.validateIPAMPoolAllocations(cli)...)
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -87,7 +103,7 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	if old != nil {
 		oldNetworkSpec = old.Spec.NetworkSpec
 	}
-	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, field.NewPath("spec").Child("networkSpec"))...)
+	allErrs = append(allErrs, validateNetworkSpec(c.Spec.NetworkSpec, oldNetworkSpec, c.applicationSecurityGroupNames(), field.NewPath("spec").Child("networkSpec"))...)
 
 	var oldCloudProviderConfigOverrides *CloudProviderConfigOverrides
 	if old != nil {
@@ -96,6 +112,170 @@ func (c *AzureCluster) validateClusterSpec(old *AzureCluster) field.ErrorList {
 	allErrs = append(allErrs, validateCloudProviderConfigOverrides(c.Spec.CloudProviderConfigOverrides, oldCloudProviderConfigOverrides,
 		field.NewPath("spec").Child("cloudProviderConfigOverrides"))...)
 
+	if c.Spec.DiagnosticSettings != nil {
+		allErrs = append(allErrs, validateDiagnosticSettings(*c.Spec.DiagnosticSettings, field.NewPath("spec").Child("diagnosticSettings"))...)
+	}
+
+	var oldDedicatedHostGroups []DedicatedHostGroup
+	if old != nil {
+		oldDedicatedHostGroups = old.Spec.DedicatedHostGroups
+	}
+	allErrs = append(allErrs, validateDedicatedHostGroups(c.Spec.DedicatedHostGroups, oldDedicatedHostGroups,
+		field.NewPath("spec").Child("dedicatedHostGroups"))...)
+
+	allErrs = append(allErrs, validateProximityPlacementGroups(c.Spec.ProximityPlacementGroups,
+		field.NewPath("spec").Child("proximityPlacementGroups"))...)
+
+	allErrs = append(allErrs, c.validateVnetProperties()...)
+
+	return allErrs
+}
+
+// applicationSecurityGroupNames returns the names of the application security groups created for the cluster: the
+// control plane and node application security groups, followed by the custom application security groups.
+func (c *AzureCluster) applicationSecurityGroupNames() []string {
+	names := []string{
+		generateControlPlaneApplicationSecurityGroupName(c.clusterName()),
+		generateNodeApplicationSecurityGroupName(c.clusterName()),
+	}
+	return append(names, c.Spec.NetworkSpec.ApplicationSecurityGroups...)
+}
+
+// clusterName returns the name of the owner Cluster of the AzureCluster, which is usually the name of the
+// AzureCluster.
+func (c *AzureCluster) clusterName() string {
+	if name, ok := c.Labels[clusterv1.ClusterLabelName]; ok {
+		return name
+	}
+	return c.Name
+}
+
+// validateProximityPlacementGroups validates the proximity placement groups of a cluster.
+func validateProximityPlacementGroups(groups []ProximityPlacementGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := make(map[string]bool, len(groups))
+	for i, group := range groups {
+		if group.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "name of the proximity placement group is required"))
+		} else if names[group.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), group.Name))
+		}
+		names[group.Name] = true
+	}
+
+	return allErrs
+}
+
+// validateDedicatedHostGroups validates the dedicated host groups of a cluster and the hosts in them. The availability
+// zone and fault domains of existing groups and hosts cannot change.
+func validateDedicatedHostGroups(groups, oldGroups []DedicatedHostGroup, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	oldGroupsByName := make(map[string]DedicatedHostGroup, len(oldGroups))
+	for _, group := range oldGroups {
+		oldGroupsByName[group.Name] = group
+	}
+
+	groupNames := make(map[string]bool, len(groups))
+	for i, group := range groups {
+		groupPath := fldPath.Index(i)
+		if group.Name == "" {
+			allErrs = append(allErrs, field.Required(groupPath.Child("name"), "name of the dedicated host group is required"))
+		} else if groupNames[group.Name] {
+			allErrs = append(allErrs, field.Duplicate(groupPath.Child("name"), group.Name))
+		}
+		groupNames[group.Name] = true
+
+		if group.FailureDomain != nil && *group.FailureDomain == "" {
+			allErrs = append(allErrs, field.Invalid(groupPath.Child("failureDomain"), *group.FailureDomain, "failureDomain cannot be empty"))
+		}
+
+		if group.PlatformFaultDomainCount < 1 {
+			allErrs = append(allErrs, field.Invalid(groupPath.Child("platformFaultDomainCount"), group.PlatformFaultDomainCount,
+				"platformFaultDomainCount must be at least 1"))
+		}
+
+		oldGroup, exists := oldGroupsByName[group.Name]
+		if exists {
+			if !reflect.DeepEqual(oldGroup.FailureDomain, group.FailureDomain) {
+				allErrs = append(allErrs, field.Invalid(groupPath.Child("failureDomain"), group.FailureDomain, "field is immutable"))
+			}
+			if oldGroup.PlatformFaultDomainCount != group.PlatformFaultDomainCount {
+				allErrs = append(allErrs, field.Invalid(groupPath.Child("platformFaultDomainCount"), group.PlatformFaultDomainCount, "field is immutable"))
+			}
+		}
+
+		oldHostsByName := make(map[string]DedicatedHost, len(oldGroup.Hosts))
+		for _, host := range oldGroup.Hosts {
+			oldHostsByName[host.Name] = host
+		}
+
+		hostNames := make(map[string]bool, len(group.Hosts))
+		for j, host := range group.Hosts {
+			hostPath := groupPath.Child("hosts").Index(j)
+			if host.Name == "" {
+				allErrs = append(allErrs, field.Required(hostPath.Child("name"), "name of the dedicated host is required"))
+			} else if hostNames[host.Name] {
+				allErrs = append(allErrs, field.Duplicate(hostPath.Child("name"), host.Name))
+			}
+			hostNames[host.Name] = true
+
+			if host.SKU == "" {
+				allErrs = append(allErrs, field.Required(hostPath.Child("sku"), "sku of the dedicated host is required"))
+			}
+
+			if host.PlatformFaultDomain < 0 || host.PlatformFaultDomain >= group.PlatformFaultDomainCount {
+				allErrs = append(allErrs, field.Invalid(hostPath.Child("platformFaultDomain"), host.PlatformFaultDomain,
+					fmt.Sprintf("platformFaultDomain must be lower than the platformFaultDomainCount %d of the dedicated host group", group.PlatformFaultDomainCount)))
+			}
+
+			if oldHost, ok := oldHostsByName[host.Name]; ok {
+				if oldHost.SKU != host.SKU {
+					allErrs = append(allErrs, field.Invalid(hostPath.Child("sku"), host.SKU, "field is immutable"))
+				}
+				if oldHost.PlatformFaultDomain != host.PlatformFaultDomain {
+					allErrs = append(allErrs, field.Invalid(hostPath.Child("platformFaultDomain"), host.PlatformFaultDomain, "field is immutable"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateDiagnosticSettings validates the destinations of the diagnostic settings of a cluster.
+func validateDiagnosticSettings(settings DiagnosticSettings, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if settings.WorkspaceID == "" && settings.StorageAccountID == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "diagnostic settings need a workspaceID or a storageAccountID"))
+	}
+	if settings.WorkspaceID != "" && !workspaceIDRegex.MatchString(settings.WorkspaceID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("workspaceID"), settings.WorkspaceID,
+			fmt.Sprintf("Log Analytics workspace ID doesn't match regex %s", workspaceIDPattern)))
+	}
+	if settings.StorageAccountID != "" && !storageAccountIDRegex.MatchString(settings.StorageAccountID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountID"), settings.StorageAccountID,
+			fmt.Sprintf("storage account ID doesn't match regex %s", storageAccountIDPattern)))
+	}
+	return allErrs
+}
+
+// validateFlowLog validates the flow log of a security group.
+func validateFlowLog(flowLog FlowLog, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !storageAccountIDRegex.MatchString(flowLog.StorageAccountID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("storageAccountID"), flowLog.StorageAccountID,
+			fmt.Sprintf("storage account ID doesn't match regex %s", storageAccountIDPattern)))
+	}
+	if flowLog.RetentionDays < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retentionDays"), flowLog.RetentionDays, "retention days cannot be negative"))
+	}
+	if flowLog.NetworkWatcherResourceGroup != "" {
+		if err := validateResourceGroup(flowLog.NetworkWatcherResourceGroup, fldPath.Child("networkWatcherResourceGroup")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
 	return allErrs
 }
 
@@ -118,7 +298,7 @@ func (c *AzureCluster) validateClusterName() field.ErrorList {
 }
 
 // validateNetworkSpec validates a NetworkSpec.
-func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *field.Path) field.ErrorList {
+func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, asgNames []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	// If the user specifies a resourceGroup for vnet, it means
 	// that she intends to use a pre-existing vnet. In this case,
@@ -131,11 +311,17 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 		allErrs = append(allErrs, validateVnetCIDR(networkSpec.Vnet.CIDRBlocks, fldPath.Child("cidrBlocks"))...)
 
-		allErrs = append(allErrs, validateSubnets(networkSpec.Subnets, networkSpec.Vnet, fldPath.Child("subnets"))...)
+		allErrs = append(allErrs, validateSubnets(networkSpec.Subnets, networkSpec.Vnet, asgNames, fldPath.Child("subnets"))...)
 
 		allErrs = append(allErrs, validateVnetPeerings(networkSpec.Vnet.Peerings, fldPath.Child("peerings"))...)
 	}
 
+	if networkSpec.Vnet.IPAMPoolRef != nil && networkSpec.Vnet.IPAMPoolRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("vnet").Child("ipamPoolRef").Child("name"), "name of the IPAM pool is required"))
+	}
+
+	allErrs = append(allErrs, validateVnetClassSpec(networkSpec.Vnet.VnetClassSpec, fldPath.Child("vnet"))...)
+
 	var cidrBlocks []string
 	controlPlaneSubnet, err := networkSpec.GetControlPlaneSubnet()
 	if err != nil {
@@ -146,27 +332,172 @@ func validateNetworkSpec(networkSpec NetworkSpec, old NetworkSpec, fldPath *fiel
 
 	allErrs = append(allErrs, validateAPIServerLB(networkSpec.APIServerLB, old.APIServerLB, cidrBlocks, fldPath.Child("apiServerLB"))...)
 
-	var oneSubnetWithoutNatGateway bool
+	var oneSubnetWithoutNatGateway, oneSubnetWithNatGateway bool
 	for _, subnet := range networkSpec.Subnets {
 		if subnet.Role == SubnetNode && !subnet.IsNatGatewayEnabled() {
 			oneSubnetWithoutNatGateway = true
-			break
+		}
+		if subnet.IsNatGatewayEnabled() {
+			oneSubnetWithNatGateway = true
 		}
 	}
-	if oneSubnetWithoutNatGateway {
+	if oneSubnetWithoutNatGateway && !networkSpec.IsUserDefinedRouting() {
 		allErrs = append(allErrs, validateNodeOutboundLB(networkSpec.NodeOutboundLB, old.NodeOutboundLB, networkSpec.APIServerLB, fldPath.Child("nodeOutboundLB"))...)
 	}
 
+	hasOutboundLB := networkSpec.NodeOutboundLB != nil || networkSpec.ControlPlaneOutboundLB != nil
+	allErrs = append(allErrs, validateOutboundType(networkSpec.NetworkClassSpec, hasOutboundLB, oneSubnetWithNatGateway, fldPath)...)
+
 	allErrs = append(allErrs, validateControlPlaneOutboundLB(networkSpec.ControlPlaneOutboundLB, networkSpec.APIServerLB, fldPath.Child("controlPlaneOutboundLB"))...)
 
 	allErrs = append(allErrs, validatePrivateDNSZoneName(networkSpec.PrivateDNSZoneName, networkSpec.APIServerLB.Type, fldPath.Child("privateDNSZoneName"))...)
 
+	allErrs = append(allErrs, validatePublicIPPrefixes(networkSpec, fldPath)...)
+
+	allErrs = append(allErrs, validateIPv6Only(networkSpec, fldPath)...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs
 }
 
+// publicIPPrefixReference is a public IP prefix referenced by a load balancer or a NAT gateway, with the names of the
+// public IPs allocated from it.
+type publicIPPrefixReference struct {
+	prefix  *PublicIPPrefixSpec
+	fldPath *field.Path
+	ipNames []string
+}
+
+// validatePublicIPPrefixes validates that the public IP prefixes of a network spec are referenced with the same length
+// and are large enough for all the public IPs allocated from them.
+func validatePublicIPPrefixes(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var references []publicIPPrefixReference
+	addReference := func(prefix *PublicIPPrefixSpec, prefixPath *field.Path, ips ...*PublicIPSpec) {
+		if prefix == nil {
+			return
+		}
+		reference := publicIPPrefixReference{prefix: prefix, fldPath: prefixPath}
+		for _, ip := range ips {
+			if ip != nil {
+				reference.ipNames = append(reference.ipNames, ip.Name)
+			}
+		}
+		references = append(references, reference)
+	}
+	lbReference := func(lb *LoadBalancerSpec, lbPath *field.Path) {
+		if lb == nil {
+			return
+		}
+		ips := make([]*PublicIPSpec, 0, len(lb.FrontendIPs))
+		for _, frontendIP := range lb.FrontendIPs {
+			ips = append(ips, frontendIP.PublicIP)
+		}
+		addReference(lb.PublicIPPrefix, lbPath.Child("publicIPPrefix"), ips...)
+	}
+	lbReference(&networkSpec.APIServerLB, fldPath.Child("apiServerLB"))
+	lbReference(networkSpec.NodeOutboundLB, fldPath.Child("nodeOutboundLB"))
+	lbReference(networkSpec.ControlPlaneOutboundLB, fldPath.Child("controlPlaneOutboundLB"))
+	for i, subnet := range networkSpec.Subnets {
+		if subnet.IsNatGatewayEnabled() {
+			natGatewayIP := subnet.NatGateway.NatGatewayIP
+			addReference(subnet.NatGateway.PublicIPPrefix, fldPath.Child("subnets").Index(i).Child("natGateway").Child("publicIPPrefix"), &natGatewayIP)
+		}
+	}
+
+	prefixLengths := make(map[string]int32)
+	prefixIPs := make(map[string]map[string]bool)
+	prefixPaths := make(map[string]*field.Path)
+	var prefixKeys []string
+	for _, reference := range references {
+		key := strings.ToLower(reference.prefix.ResourceGroup + "/" + reference.prefix.Name)
+		if length, ok := prefixLengths[key]; !ok {
+			prefixLengths[key] = reference.prefix.PrefixLength
+			prefixIPs[key] = make(map[string]bool)
+			prefixPaths[key] = reference.fldPath
+			prefixKeys = append(prefixKeys, key)
+		} else if length != reference.prefix.PrefixLength {
+			allErrs = append(allErrs, field.Invalid(reference.fldPath.Child("prefixLength"), reference.prefix.PrefixLength,
+				fmt.Sprintf("public IP prefix %s is referenced with different prefix lengths", reference.prefix.Name)))
+		}
+		// NAT gateways of several subnets may share the same public IP.
+		for _, name := range reference.ipNames {
+			prefixIPs[key][name] = true
+		}
+	}
+	for _, key := range prefixKeys {
+		length := prefixLengths[key]
+		if length <= 0 || length > 32 {
+			continue
+		}
+		if capacity := 1 << (32 - length); len(prefixIPs[key]) > capacity {
+			allErrs = append(allErrs, field.Invalid(prefixPaths[key].Child("prefixLength"), length,
+				fmt.Sprintf("public IP prefix of length %d cannot contain the %d public IPs allocated from it", length, len(prefixIPs[key]))))
+		}
+	}
+
+	return allErrs
+}
+
+// validateOutboundType validates the egress configuration of a network spec. Outbound load balancers and NAT gateways
+// cannot be used when the egress traffic is routed to a virtual appliance.
+func validateOutboundType(networkClassSpec NetworkClassSpec, hasOutboundLB, hasNatGateway bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !networkClassSpec.IsUserDefinedRouting() {
+		if networkClassSpec.NextHopIPAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("nextHopIPAddress"), "nextHopIPAddress can only be set when outboundType is userDefinedRouting"))
+		}
+		return allErrs
+	}
+
+	if networkClassSpec.NextHopIPAddress == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("nextHopIPAddress"), "nextHopIPAddress is required when outboundType is userDefinedRouting"))
+	} else if ip := net.ParseIP(networkClassSpec.NextHopIPAddress); ip == nil || ip.To4() == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nextHopIPAddress"), networkClassSpec.NextHopIPAddress, "nextHopIPAddress must be a valid IPv4 address"))
+	}
+	if hasOutboundLB {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("outboundType"), "outbound load balancers cannot be used when outboundType is userDefinedRouting"))
+	}
+	if hasNatGateway {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("outboundType"), "NAT gateways cannot be used when outboundType is userDefinedRouting"))
+	}
+	return allErrs
+}
+
+// validateIPv6Only validates the IPv6-only mode of a network spec. Azure requires the primary IP configuration of a
+// network interface to be IPv4, so the virtual network needs both an IPv4 and an IPv6 CIDR block.
+func validateIPv6Only(networkSpec NetworkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !networkSpec.IPv6Only {
+		return allErrs
+	}
+
+	if !feature.Gates.Enabled(feature.IPv6Only) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ipv6Only"), "can be set only if the IPv6Only feature flag is enabled"))
+	}
+
+	var hasIPv4, hasIPv6 bool
+	for _, cidr := range networkSpec.Vnet.CIDRBlocks {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ip.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+	if !hasIPv4 || !hasIPv6 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("vnet", "cidrBlocks"), networkSpec.Vnet.CIDRBlocks,
+			"an IPv6-only network requires both an IPv4 and an IPv6 CIDR block on the virtual network"))
+	}
+	return allErrs
+}
+
 // validateResourceGroup validates a ResourceGroup.
 func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Error {
 	if success, _ := regexp.MatchString(resourceGroupRegex, resourceGroup); !success {
@@ -177,8 +508,16 @@ func validateResourceGroup(resourceGroup string, fldPath *field.Path) *field.Err
 }
 
 // validateSubnets validates a list of Subnets.
-func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.ErrorList {
+func validateSubnets(subnets Subnets, vnet VnetSpec, asgNames []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
+	isClusterASG := func(name string) bool {
+		for _, asgName := range asgNames {
+			if name == asgName {
+				return true
+			}
+		}
+		return false
+	}
 	subnetNames := make(map[string]bool, len(subnets))
 	requiredSubnetRoles := map[string]bool{
 		"control-plane": false,
@@ -198,14 +537,18 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 				requiredSubnetRoles[role] = true
 			}
 		}
-		for _, rule := range subnet.SecurityGroup.SecurityRules {
+		for j, rule := range subnet.SecurityGroup.SecurityRules {
 			if err := validateSecurityRule(
 				rule,
-				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(i),
+				isClusterASG,
+				fldPath.Index(i).Child("securityGroup").Child("securityRules").Index(j),
 			); err != nil {
 				allErrs = append(allErrs, err)
 			}
 		}
+		if subnet.SecurityGroup.FlowLog != nil {
+			allErrs = append(allErrs, validateFlowLog(*subnet.SecurityGroup.FlowLog, fldPath.Index(i).Child("securityGroup").Child("flowLog"))...)
+		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fldPath.Index(i).Child("cidrBlocks"))...)
 
 		if len(subnet.ServiceEndpoints) > 0 {
@@ -216,6 +559,7 @@ func validateSubnets(subnets Subnets, vnet VnetSpec, fldPath *field.Path) field.
 			allErrs = append(allErrs, validatePrivateEndpoints(subnet.PrivateEndpoints, subnet.CIDRBlocks, fldPath.Index(i).Child("privateEndpoints"))...)
 		}
 	}
+	allErrs = append(allErrs, validateSubnetCIDROverlaps(subnets, fldPath)...)
 	for k, v := range requiredSubnetRoles {
 		if !v {
 			allErrs = append(allErrs, field.Required(fldPath,
@@ -267,6 +611,88 @@ func validateSubnetCIDR(subnetCidrBlocks []string, vnetCidrBlocks []string, fldP
 	return allErrs
 }
 
+// validateSubnetCIDROverlaps validates that the CIDR blocks of the subnets of a Vnet do not overlap.
+func validateSubnetCIDROverlaps(subnets Subnets, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	type subnetNetwork struct {
+		name    string
+		network *net.IPNet
+	}
+	var seen []subnetNetwork
+
+	for i, subnet := range subnets {
+		for _, cidr := range subnet.CIDRBlocks {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				// Invalid CIDRs are reported by validateSubnetCIDR.
+				continue
+			}
+			for _, other := range seen {
+				if other.name != subnet.Name && (other.network.Contains(network.IP) || network.Contains(other.network.IP)) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("cidrBlocks"), cidr,
+						fmt.Sprintf("subnet CIDR overlaps with subnet %s (%s)", other.name, other.network)))
+				}
+			}
+			seen = append(seen, subnetNetwork{name: subnet.Name, network: network})
+		}
+	}
+	return allErrs
+}
+
+// validateIPAMPoolAllocations validates that the address space of a Vnet allocated from an IPAM pool does not overlap
+// with the address space the pool allocated to other clusters.
+func (c *AzureCluster) validateIPAMPoolAllocations(cli client.Client) field.ErrorList {
+	ref := c.Spec.NetworkSpec.Vnet.IPAMPoolRef
+	if ref == nil || ref.Name == "" || len(c.Spec.NetworkSpec.Vnet.CIDRBlocks) == 0 {
+		return nil
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	pool := &AzureIPAMPool{}
+	if err := cli.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: ref.Name}, pool); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The pool may be created after the cluster, the controller checks the address space once it exists.
+			return nil
+		}
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "networkSpec", "vnet", "ipamPoolRef"), err)}
+	}
+
+	allocations, err := pool.Allocations(context.Background(), cli)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "networkSpec", "vnet", "ipamPoolRef"), err)}
+	}
+
+	// The allocations of a pool are recorded for the owner Cluster.
+	clusterName := c.clusterName()
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "networkSpec", "vnet", "cidrBlocks")
+	for i, cidr := range c.Spec.NetworkSpec.Vnet.CIDRBlocks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			// Invalid CIDRs are reported by validateVnetCIDR.
+			continue
+		}
+		for _, allocation := range allocations {
+			if allocation.ClusterNamespace == c.Namespace && allocation.ClusterName == clusterName {
+				continue
+			}
+			_, allocated, err := net.ParseCIDR(allocation.CIDRBlock)
+			if err != nil {
+				continue
+			}
+			if allocated.Contains(network.IP) || network.Contains(allocated.IP) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr,
+					fmt.Sprintf("address space overlaps with %s allocated by IPAM pool %s to cluster %s/%s",
+						allocation.CIDRBlock, pool.Name, allocation.ClusterNamespace, allocation.ClusterName)))
+			}
+		}
+	}
+	return allErrs
+}
+
 // validateVnetCIDR validates the CIDR blocks of a Vnet.
 func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -278,17 +704,83 @@ func validateVnetCIDR(vnetCIDRBlocks []string, fldPath *field.Path) field.ErrorL
 	return allErrs
 }
 
+// validateVnetClassSpec validates the DDoS protection plan and the DHCP options of a Vnet.
+func validateVnetClassSpec(vnet VnetClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if planID := pointer.StringDeref(vnet.DDoSProtectionPlanID, ""); planID != "" && !ddosProtectionPlanIDRegex.MatchString(planID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ddosProtectionPlanID"), planID,
+			fmt.Sprintf("DDoS protection plan ID doesn't match regex %s", ddosProtectionPlanIDPattern)))
+	}
+	if vnet.DHCPOptions != nil {
+		for i, server := range vnet.DHCPOptions.DNSServers {
+			if net.ParseIP(server) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("dhcpOptions", "dnsServers").Index(i), server, "DNS server isn't a valid IPv4 or IPv6 address"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateVnetProperties validates that the DDoS protection, the encryption and the DHCP options are only set on a
+// Vnet managed by CAPZ, as the Vnets that are not managed are never modified.
+func (c *AzureCluster) validateVnetProperties() field.ErrorList {
+	vnet := c.Spec.NetworkSpec.Vnet
+	if vnet.ID == "" || vnet.Tags.HasOwned(c.clusterName()) {
+		return nil
+	}
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "networkSpec", "vnet")
+	if vnet.DDoSProtectionPlanID != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ddosProtectionPlanID"),
+			fmt.Sprintf("ddosProtectionPlanID can only be set on a virtual network managed by CAPZ, but virtual network %s is not", vnet.Name)))
+	}
+	if vnet.Encryption != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("encryption"),
+			fmt.Sprintf("encryption can only be set on a virtual network managed by CAPZ, but virtual network %s is not", vnet.Name)))
+	}
+	if vnet.DHCPOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("dhcpOptions"),
+			fmt.Sprintf("dhcpOptions can only be set on a virtual network managed by CAPZ, but virtual network %s is not", vnet.Name)))
+	}
+	return allErrs
+}
+
 // validateVnetPeerings validates a list of virtual network peerings.
 func validateVnetPeerings(peerings VnetPeerings, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	vnetIdentifiers := make(map[string]bool, len(peerings))
 
-	for _, peering := range peerings {
+	for i, peering := range peerings {
 		vnetIdentifier := peering.ResourceGroup + "/" + peering.RemoteVnetName
+		if peering.SubscriptionID != "" {
+			vnetIdentifier = peering.SubscriptionID + "/" + vnetIdentifier
+		}
 		if _, ok := vnetIdentifiers[vnetIdentifier]; ok {
 			allErrs = append(allErrs, field.Duplicate(fldPath, vnetIdentifier))
 		}
 		vnetIdentifiers[vnetIdentifier] = true
+
+		if peering.IdentityRef != nil && peering.IdentityRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("identityRef", "name"), "name of the remote AzureClusterIdentity is required"))
+		}
+		allErrs = append(allErrs, validateVnetPeeringProperties(peering.ForwardPeeringProperties, peering.ReversePeeringProperties, fldPath.Index(i).Child("forwardPeeringProperties"))...)
+		allErrs = append(allErrs, validateVnetPeeringProperties(peering.ReversePeeringProperties, peering.ForwardPeeringProperties, fldPath.Index(i).Child("reversePeeringProperties"))...)
+	}
+	return allErrs
+}
+
+// validateVnetPeeringProperties validates the properties of one direction of a virtual network peering against the
+// properties of the opposite direction.
+func validateVnetPeeringProperties(properties, remoteProperties VnetPeeringProperties, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if !pointer.BoolDeref(properties.UseRemoteGateways, false) {
+		return allErrs
+	}
+	if pointer.BoolDeref(properties.AllowGatewayTransit, false) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("useRemoteGateways"), "useRemoteGateways cannot be enabled together with allowGatewayTransit"))
+	}
+	if !pointer.BoolDeref(remoteProperties.AllowGatewayTransit, false) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("useRemoteGateways"), "useRemoteGateways requires allowGatewayTransit to be enabled on the opposite peering"))
 	}
 	return allErrs
 }
@@ -319,12 +811,33 @@ func validateInternalLBIPAddress(address string, cidrs []string, fldPath *field.
 		fmt.Sprintf("Internal LB IP address needs to be in control plane subnet range (%s)", cidrs))
 }
 
-// validateSecurityRule validates a SecurityRule.
-func validateSecurityRule(rule SecurityRule, fldPath *field.Path) *field.Error {
+// validateSecurityRule validates a SecurityRule. Its application security groups must be created for the cluster,
+// i.e. be matched by isClusterASG.
+func validateSecurityRule(rule SecurityRule, isClusterASG func(name string) bool, fldPath *field.Path) *field.Error {
 	if rule.Priority < minRulePriority || rule.Priority > maxRulePriority {
 		return field.Invalid(fldPath, rule.Priority, fmt.Sprintf("security rule priorities should be between %d and %d", minRulePriority, maxRulePriority))
 	}
 
+	if rule.Source != nil && len(rule.SourceApplicationSecurityGroups) > 0 {
+		return field.Forbidden(fldPath.Child("sourceApplicationSecurityGroups"), "sourceApplicationSecurityGroups cannot be set together with source")
+	}
+
+	if rule.Destination != nil && len(rule.DestinationApplicationSecurityGroups) > 0 {
+		return field.Forbidden(fldPath.Child("destinationApplicationSecurityGroups"), "destinationApplicationSecurityGroups cannot be set together with destination")
+	}
+
+	for i, name := range rule.SourceApplicationSecurityGroups {
+		if !isClusterASG(name) {
+			return field.NotFound(fldPath.Child("sourceApplicationSecurityGroups").Index(i), name)
+		}
+	}
+
+	for i, name := range rule.DestinationApplicationSecurityGroups {
+		if !isClusterASG(name) {
+			return field.NotFound(fldPath.Child("destinationApplicationSecurityGroups").Index(i), name)
+		}
+	}
+
 	return nil
 }
 
@@ -344,35 +857,184 @@ func validateAPIServerLB(lb LoadBalancerSpec, old LoadBalancerSpec, cidrs []stri
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer name should not be modified after AzureCluster creation."))
 	}
 
-	// There should only be one IP config.
-	if len(lb.FrontendIPs) != 1 || pointer.Int32Deref(lb.FrontendIPsCount, 1) != 1 {
+	if lb.PublicIPPrefix != nil && lb.Type == Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIPPrefix"), "Internal Load Balancers cannot have a public IP prefix"))
+	}
+	// The public IP of the load balancer cannot be moved to another prefix.
+	if old.Name != "" && !reflect.DeepEqual(old.PublicIPPrefix, lb.PublicIPPrefix) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIPPrefix"), "API Server load balancer public IP prefix should not be modified after AzureCluster creation."))
+	}
+
+	if lb.PrivateLinkService != nil {
+		allErrs = append(allErrs, validatePrivateLinkService(*lb.PrivateLinkService, lb.Type, fldPath.Child("privateLinkService"))...)
+	}
+	// A private link service can be added to an existing cluster, but it cannot be removed or renamed.
+	if old.PrivateLinkService != nil && (lb.PrivateLinkService == nil || old.PrivateLinkService.Name != lb.PrivateLinkService.Name) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("privateLinkService"), "API Server load balancer private link service should not be removed or renamed after AzureCluster creation."))
+	}
+
+	// There should be at least one IP config. The first one is the API server endpoint, the others can only be used by
+	// the additional rules.
+	if len(lb.FrontendIPs) == 0 || pointer.Int32Deref(lb.FrontendIPsCount, 1) != 1 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPConfigs"), lb.FrontendIPs,
-			"API Server Load balancer should have 1 Frontend IP"))
-	} else {
+			"API Server Load balancer should have at least 1 Frontend IP"))
+	}
+	frontendIPNames := make(map[string]struct{}, len(lb.FrontendIPs))
+	for i, frontendIP := range lb.FrontendIPs {
+		frontendIPPath := fldPath.Child("frontendIPConfigs").Index(i)
+		if _, ok := frontendIPNames[frontendIP.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(frontendIPPath.Child("name"), frontendIP.Name))
+		}
+		frontendIPNames[frontendIP.Name] = struct{}{}
+
 		// if Internal, IP config should not have a public IP.
 		if lb.Type == Internal {
-			if lb.FrontendIPs[0].PublicIP != nil {
-				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("publicIP"),
+			if frontendIP.PublicIP != nil {
+				allErrs = append(allErrs, field.Forbidden(frontendIPPath.Child("publicIP"),
 					"Internal Load Balancers cannot have a Public IP"))
 			}
-			if lb.FrontendIPs[0].PrivateIPAddress != "" {
-				if err := validateInternalLBIPAddress(lb.FrontendIPs[0].PrivateIPAddress, cidrs,
-					fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP")); err != nil {
+			if frontendIP.PrivateIPAddress != "" {
+				if err := validateInternalLBIPAddress(frontendIP.PrivateIPAddress, cidrs,
+					frontendIPPath.Child("privateIP")); err != nil {
 					allErrs = append(allErrs, err)
 				}
-				if len(old.FrontendIPs) != 0 && old.FrontendIPs[0].PrivateIPAddress != lb.FrontendIPs[0].PrivateIPAddress {
+				// The private IP of a virtual network using IPAM is set once, after its subnets are allocated.
+				if len(old.FrontendIPs) > i && old.FrontendIPs[i].PrivateIPAddress != "" && old.FrontendIPs[i].PrivateIPAddress != frontendIP.PrivateIPAddress {
 					allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "API Server load balancer private IP should not be modified after AzureCluster creation."))
 				}
+			} else if i > 0 {
+				allErrs = append(allErrs, field.Required(frontendIPPath.Child("privateIP"),
+					"Additional Frontend IPs of Internal Load Balancers need a Private IP"))
 			}
 		}
 
 		// if Public, IP config should not have a private IP.
 		if lb.Type == Public {
-			if lb.FrontendIPs[0].PrivateIPAddress != "" {
-				allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPConfigs").Index(0).Child("privateIP"),
+			if frontendIP.PrivateIPAddress != "" {
+				allErrs = append(allErrs, field.Forbidden(frontendIPPath.Child("privateIP"),
 					"Public Load Balancers cannot have a Private IP"))
 			}
+			if i > 0 && frontendIP.PublicIP == nil {
+				allErrs = append(allErrs, field.Required(frontendIPPath.Child("publicIP"),
+					"Additional Frontend IPs of Public Load Balancers need a Public IP"))
+			}
+		}
+	}
+
+	if lb.Probe != nil {
+		allErrs = append(allErrs, validateLoadBalancerProbe(*lb.Probe, fldPath.Child("probe"))...)
+	}
+	allErrs = append(allErrs, validateLoadBalancingRules(lb.AdditionalRules, lb.FrontendIPs, fldPath.Child("additionalRules"))...)
+
+	return allErrs
+}
+
+// validateLoadBalancerProbe validates the health probe of the API server load balancer.
+func validateLoadBalancerProbe(probe LoadBalancerProbe, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch probe.Protocol {
+	case LoadBalancerProbeProtocolTCP:
+		if probe.RequestPath != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("requestPath"), "Tcp probes cannot have a request path"))
 		}
+	case LoadBalancerProbeProtocolHTTP, LoadBalancerProbeProtocolHTTPS:
+		if !strings.HasPrefix(probe.RequestPath, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requestPath"), probe.RequestPath, "request path must start with /"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("protocol"), probe.Protocol,
+			[]string{string(LoadBalancerProbeProtocolTCP), string(LoadBalancerProbeProtocolHTTP), string(LoadBalancerProbeProtocolHTTPS)}))
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancingRules validates the additional rules of the API server load balancer.
+func validateLoadBalancingRules(rules []LoadBalancingRule, frontendIPs []FrontendIP, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(rules) == 0 || len(frontendIPs) == 0 {
+		return allErrs
+	}
+	frontendIPNames := make(map[string]struct{}, len(frontendIPs))
+	for _, frontendIP := range frontendIPs {
+		frontendIPNames[frontendIP.Name] = struct{}{}
+	}
+
+	type frontendPort struct {
+		frontendIPName string
+		protocol       LoadBalancingRuleProtocol
+		port           int32
+	}
+	frontendPorts := make(map[frontendPort]struct{}, len(rules))
+	for i, rule := range rules {
+		rulePath := fldPath.Index(i)
+		if success, _ := regexp.Match(loadBalancerRegex, []byte(rule.Name)); !success {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("name"), rule.Name,
+				fmt.Sprintf("name of load balancing rule doesn't match regex %s", loadBalancerRegex)))
+		}
+		if rule.FrontendIPName != "" {
+			if _, ok := frontendIPNames[rule.FrontendIPName]; !ok {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("frontendIPName"), rule.FrontendIPName,
+					"load balancing rule must use a frontend IP of the load balancer"))
+			}
+		}
+		// Two rules cannot listen on the same port of the same frontend IP.
+		frontendIPName := rule.FrontendIPName
+		if frontendIPName == "" {
+			frontendIPName = frontendIPs[0].Name
+		}
+		key := frontendPort{frontendIPName: frontendIPName, protocol: rule.Protocol, port: rule.FrontendPort}
+		if _, ok := frontendPorts[key]; ok {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("frontendPort"), rule.FrontendPort))
+		}
+		frontendPorts[key] = struct{}{}
+	}
+
+	return allErrs
+}
+
+// validatePrivateLinkService validates the private link service of the API server load balancer.
+func validatePrivateLinkService(pls PrivateLinkServiceSpec, lbType LBType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if lbType != Internal {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "Private link services can only be created for Internal Load Balancers"))
+	}
+	if success, _ := regexp.Match(loadBalancerRegex, []byte(pls.Name)); !success {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), pls.Name,
+			fmt.Sprintf("name of private link service doesn't match regex %s", loadBalancerRegex)))
+	}
+	for i, subscriptionID := range pls.VisibleSubscriptions {
+		if subscriptionID == "*" {
+			continue
+		}
+		if _, err := uuid.Parse(subscriptionID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("visibleSubscriptions").Index(i), subscriptionID,
+				"subscription must be a valid subscription ID or *"))
+		}
+	}
+	for i, subscriptionID := range pls.AutoApprovedSubscriptions {
+		if _, err := uuid.Parse(subscriptionID); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("autoApprovedSubscriptions").Index(i), subscriptionID,
+				"subscription must be a valid subscription ID"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateOutboundLBProbeAndRules validates that an outbound load balancer has neither a health probe nor additional
+// rules, since it has no inbound traffic.
+func validateOutboundLBProbeAndRules(lb *LoadBalancerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if lb.Probe != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("probe"), "Probes are only supported on the API server load balancer"))
+	}
+	if len(lb.AdditionalRules) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalRules"), "Additional rules are only supported on the API server load balancer"))
 	}
 
 	return allErrs
@@ -396,6 +1058,11 @@ func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserv
 		return allErrs
 	}
 
+	if lb.PrivateLinkService != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("privateLinkService"), "Private link services are only supported on the API server load balancer"))
+	}
+	allErrs = append(allErrs, validateOutboundLBProbeAndRules(lb, fldPath)...)
+
 	if old != nil && old.ID != lb.ID {
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("id"), "Node outbound load balancer ID should not be modified after AzureCluster creation."))
 	}
@@ -404,6 +1071,10 @@ func validateNodeOutboundLB(lb *LoadBalancerSpec, old *LoadBalancerSpec, apiserv
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "Node outbound load balancer Name should not be modified after AzureCluster creation."))
 	}
 
+	if old != nil && !reflect.DeepEqual(old.PublicIPPrefix, lb.PublicIPPrefix) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("publicIPPrefix"), "Node outbound load balancer public IP prefix should not be modified after AzureCluster creation."))
+	}
+
 	if old != nil && old.FrontendIPsCount == lb.FrontendIPsCount {
 		if len(old.FrontendIPs) != len(lb.FrontendIPs) {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("frontendIPs"), "Node outbound load balancer FrontendIPs cannot be modified after AzureCluster creation."))
@@ -439,6 +1110,13 @@ func validateControlPlaneOutboundLB(lb *LoadBalancerSpec, apiserverLB LoadBalanc
 
 	allErrs = append(allErrs, validateClassSpecForControlPlaneOutboundLB(lbClassSpec, apiServerLBClassSpec, fldPath)...)
 
+	if lb != nil && lb.PrivateLinkService != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("privateLinkService"), "Private link services are only supported on the API server load balancer"))
+	}
+	if lb != nil {
+		allErrs = append(allErrs, validateOutboundLBProbeAndRules(lb, fldPath)...)
+	}
+
 	if apiServerLBClassSpec.Type == Internal && lb != nil {
 		if lb.FrontendIPsCount != nil && *lb.FrontendIPsCount > MaxLoadBalancerOutboundIPs {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("frontendIPsCount"), *lb.FrontendIPsCount,
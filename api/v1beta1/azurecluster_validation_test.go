@@ -20,9 +20,13 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/feature"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestClusterNameValidation(t *testing.T) {
@@ -115,7 +119,7 @@ func TestClusterWithPreexistingVnetValid(t *testing.T) {
 	}
 
 	t.Run(testCase.name, func(t *testing.T) {
-		err := testCase.cluster.validateCluster(nil)
+		err := testCase.cluster.validateCluster(nil, nil)
 		g.Expect(err).To(BeNil())
 	})
 }
@@ -142,7 +146,7 @@ func TestClusterWithPreexistingVnetInvalid(t *testing.T) {
 	}
 
 	t.Run(testCase.name, func(t *testing.T) {
-		err := testCase.cluster.validateCluster(nil)
+		err := testCase.cluster.validateCluster(nil, nil)
 		g.Expect(err).NotTo(BeNil())
 	})
 }
@@ -165,7 +169,7 @@ func TestClusterWithoutPreexistingVnetValid(t *testing.T) {
 	testCase.cluster.Spec.NetworkSpec.Vnet.ResourceGroup = ""
 
 	t.Run(testCase.name, func(t *testing.T) {
-		err := testCase.cluster.validateCluster(nil)
+		err := testCase.cluster.validateCluster(nil, nil)
 		g.Expect(err).To(BeNil())
 	})
 }
@@ -253,7 +257,7 @@ func TestNetworkSpecWithPreexistingVnetValid(t *testing.T) {
 	}
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, nil, field.NewPath("spec").Child("networkSpec"))
 		g.Expect(errs).To(BeNil())
 	})
 }
@@ -275,7 +279,7 @@ func TestNetworkSpecWithPreexistingVnetLackRequiredSubnets(t *testing.T) {
 	testCase.networkSpec.Subnets = testCase.networkSpec.Subnets[:1]
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, nil, field.NewPath("spec").Child("networkSpec"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
 		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.subnets"))
@@ -299,7 +303,7 @@ func TestNetworkSpecWithPreexistingVnetInvalidResourceGroup(t *testing.T) {
 	testCase.networkSpec.Vnet.ResourceGroup = "invalid-name###"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, nil, field.NewPath("spec").Child("networkSpec"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
 		g.Expect(errs[0].Field).To(Equal("spec.networkSpec.vnet.resourceGroup"))
@@ -323,7 +327,7 @@ func TestNetworkSpecWithoutPreexistingVnetValid(t *testing.T) {
 	testCase.networkSpec.Vnet.ResourceGroup = ""
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, field.NewPath("spec").Child("networkSpec"))
+		errs := validateNetworkSpec(testCase.networkSpec, NetworkSpec{}, nil, field.NewPath("spec").Child("networkSpec"))
 		g.Expect(errs).To(BeNil())
 	})
 }
@@ -423,7 +427,7 @@ func TestSubnetsValid(t *testing.T) {
 	}
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, createValidVnet(), nil,
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(BeNil())
 	})
@@ -445,7 +449,7 @@ func TestSubnetsInvalidSubnetName(t *testing.T) {
 	testCase.subnets[0].Name = "invalid-subnet-name-due-to-bracket)"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, createValidVnet(), nil,
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
@@ -470,7 +474,7 @@ func TestSubnetsInvalidLackRequiredSubnet(t *testing.T) {
 	testCase.subnets[0].Role = "random-role"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, createValidVnet(), nil,
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
@@ -496,7 +500,7 @@ func TestSubnetNamesNotUnique(t *testing.T) {
 	testCase.subnets[1].Name = "subnet-name"
 
 	t.Run(testCase.name, func(t *testing.T) {
-		errs := validateSubnets(testCase.subnets, createValidVnet(),
+		errs := validateSubnets(testCase.subnets, createValidVnet(), nil,
 			field.NewPath("spec").Child("networkSpec").Child("subnets"))
 		g.Expect(errs).To(HaveLen(1))
 		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
@@ -607,6 +611,870 @@ func TestValidateSubnetCIDR(t *testing.T) {
 	}
 }
 
+func TestValidateSubnetCIDROverlaps(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		subnets     Subnets
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "subnets do not overlap",
+			subnets: Subnets{
+				{SubnetClassSpec: SubnetClassSpec{Name: "cp-subnet", CIDRBlocks: []string{"10.0.0.0/24"}}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "node-subnet", CIDRBlocks: []string{"10.0.1.0/24", "10.0.2.0/24"}}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "ipam-subnet"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "subnets overlap",
+			subnets: Subnets{
+				{SubnetClassSpec: SubnetClassSpec{Name: "cp-subnet", CIDRBlocks: []string{"10.0.0.0/16"}}},
+				{SubnetClassSpec: SubnetClassSpec{Name: "node-subnet", CIDRBlocks: []string{"10.0.1.0/24"}}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "subnets[1].cidrBlocks",
+				BadValue: "10.0.1.0/24",
+				Detail:   "subnet CIDR overlaps with subnet cp-subnet (10.0.0.0/16)",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateSubnetCIDROverlaps(testCase.subnets, field.NewPath("subnets"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVnetPeerings(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		peerings    VnetPeerings
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid cross-subscription peerings",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "rg", RemoteVnetName: "hub"}},
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{
+					ResourceGroup:            "rg",
+					RemoteVnetName:           "hub",
+					SubscriptionID:           "other-sub",
+					IdentityRef:              &corev1.ObjectReference{Name: "hub-identity", Namespace: "default"},
+					ForwardPeeringProperties: VnetPeeringProperties{UseRemoteGateways: pointer.Bool(true)},
+					ReversePeeringProperties: VnetPeeringProperties{AllowGatewayTransit: pointer.Bool(true)},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate peerings",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "rg", RemoteVnetName: "hub", SubscriptionID: "other-sub"}},
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{ResourceGroup: "rg", RemoteVnetName: "hub", SubscriptionID: "other-sub"}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "peerings",
+				BadValue: "other-sub/rg/hub",
+			},
+		},
+		{
+			name: "identity ref without a name",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{RemoteVnetName: "hub", IdentityRef: &corev1.ObjectReference{Namespace: "default"}}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "peerings[0].identityRef.name",
+				Detail: "name of the remote AzureClusterIdentity is required",
+			},
+		},
+		{
+			name: "remote gateways used without gateway transit on the remote peering",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{
+					RemoteVnetName:           "hub",
+					ReversePeeringProperties: VnetPeeringProperties{UseRemoteGateways: pointer.Bool(true)},
+				}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "peerings[0].reversePeeringProperties.useRemoteGateways",
+				Detail: "useRemoteGateways requires allowGatewayTransit to be enabled on the opposite peering",
+			},
+		},
+		{
+			name: "remote gateways used together with gateway transit",
+			peerings: VnetPeerings{
+				{VnetPeeringClassSpec: VnetPeeringClassSpec{
+					RemoteVnetName:           "hub",
+					ForwardPeeringProperties: VnetPeeringProperties{UseRemoteGateways: pointer.Bool(true), AllowGatewayTransit: pointer.Bool(true)},
+					ReversePeeringProperties: VnetPeeringProperties{AllowGatewayTransit: pointer.Bool(true)},
+				}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "peerings[0].forwardPeeringProperties.useRemoteGateways",
+				Detail: "useRemoteGateways cannot be enabled together with allowGatewayTransit",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVnetPeerings(testCase.peerings, field.NewPath("peerings"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVnetClassSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		vnet        VnetClassSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid DDoS protection plan, encryption and DNS servers",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/ddosProtectionPlans/my-plan"),
+				Encryption:           &VnetEncryption{Enforcement: VnetEncryptionEnforcementDropUnencrypted},
+				DHCPOptions:          &VnetDHCPOptions{DNSServers: []string{"10.0.0.4", "2001:db8::4"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "DDoS protection disabled",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: pointer.String(""),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid DDoS protection plan ID",
+			vnet: VnetClassSpec{
+				DDoSProtectionPlanID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"),
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "vnet.ddosProtectionPlanID",
+				BadValue: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip",
+				Detail:   "DDoS protection plan ID doesn't match regex " + ddosProtectionPlanIDPattern,
+			},
+		},
+		{
+			name: "invalid DNS server",
+			vnet: VnetClassSpec{
+				DHCPOptions: &VnetDHCPOptions{DNSServers: []string{"10.0.0.4", "dns.example.com"}},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "vnet.dhcpOptions.dnsServers[1]",
+				BadValue: "dns.example.com",
+				Detail:   "DNS server isn't a valid IPv4 or IPv6 address",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateVnetClassSpec(testCase.vnet, field.NewPath("vnet"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidatePublicIPPrefixes(t *testing.T) {
+	g := NewWithT(t)
+
+	frontendIPs := func(names ...string) []FrontendIP {
+		ips := make([]FrontendIP, 0, len(names))
+		for _, name := range names {
+			ips = append(ips, FrontendIP{Name: name, PublicIP: &PublicIPSpec{Name: name}})
+		}
+		return ips
+	}
+
+	tests := []struct {
+		name        string
+		networkSpec NetworkSpec
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "prefix shared by the API server and node outbound load balancers",
+			networkSpec: NetworkSpec{
+				APIServerLB: LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("apiserver-ip"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 30},
+				},
+				NodeOutboundLB: &LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("node-ip-1", "node-ip-2", "node-ip-3"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 30},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "NAT gateways sharing a public IP allocated from a prefix",
+			networkSpec: NetworkSpec{
+				Subnets: Subnets{
+					{
+						SubnetClassSpec: SubnetClassSpec{Name: "node-1", Role: SubnetNode},
+						NatGateway: NatGateway{
+							NatGatewayClassSpec: NatGatewayClassSpec{Name: "nat-gw"},
+							NatGatewayIP:        PublicIPSpec{Name: "nat-gw-ip"},
+							PublicIPPrefix:      &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 31},
+						},
+					},
+					{
+						SubnetClassSpec: SubnetClassSpec{Name: "node-2", Role: SubnetNode},
+						NatGateway: NatGateway{
+							NatGatewayClassSpec: NatGatewayClassSpec{Name: "nat-gw"},
+							NatGatewayIP:        PublicIPSpec{Name: "nat-gw-ip"},
+							PublicIPPrefix:      &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 31},
+						},
+					},
+				},
+				NodeOutboundLB: &LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("node-ip"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 31},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "prefix too small for its public IPs",
+			networkSpec: NetworkSpec{
+				APIServerLB: LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("apiserver-ip"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 31},
+				},
+				NodeOutboundLB: &LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("node-ip-1", "node-ip-2"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 31},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.apiServerLB.publicIPPrefix.prefixLength",
+				BadValue: int32(31),
+				Detail:   "public IP prefix of length 31 cannot contain the 3 public IPs allocated from it",
+			},
+		},
+		{
+			name: "prefix referenced with different lengths",
+			networkSpec: NetworkSpec{
+				APIServerLB: LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("apiserver-ip"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 28},
+				},
+				NodeOutboundLB: &LoadBalancerSpec{
+					FrontendIPs:    frontendIPs("node-ip"),
+					PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 30},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.nodeOutboundLB.publicIPPrefix.prefixLength",
+				BadValue: int32(30),
+				Detail:   "public IP prefix my-prefix is referenced with different prefix lengths",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validatePublicIPPrefixes(testCase.networkSpec, field.NewPath("networkSpec"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateDiagnosticSettings(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		settings    DiagnosticSettings
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid workspace and storage account",
+			settings: DiagnosticSettings{
+				WorkspaceID:      "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+				StorageAccountID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "no destination",
+			settings: DiagnosticSettings{},
+			wantErr:  true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "diagnosticSettings",
+				Detail: "diagnostic settings need a workspaceID or a storageAccountID",
+			},
+		},
+		{
+			name: "invalid workspace ID",
+			settings: DiagnosticSettings{
+				WorkspaceID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "diagnosticSettings.workspaceID",
+				BadValue: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+				Detail:   "Log Analytics workspace ID doesn't match regex " + workspaceIDPattern,
+			},
+		},
+		{
+			name: "invalid storage account ID",
+			settings: DiagnosticSettings{
+				StorageAccountID: "mystorage",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "diagnosticSettings.storageAccountID",
+				BadValue: "mystorage",
+				Detail:   "storage account ID doesn't match regex " + storageAccountIDPattern,
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateDiagnosticSettings(testCase.settings, field.NewPath("diagnosticSettings"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateDedicatedHostGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	validGroup := func() DedicatedHostGroup {
+		return DedicatedHostGroup{
+			Name:                     "my-host-group",
+			FailureDomain:            pointer.String("1"),
+			PlatformFaultDomainCount: 2,
+			AutomaticPlacement:       true,
+			Hosts: []DedicatedHost{
+				{Name: "my-host-0", SKU: "DSv3-Type3", PlatformFaultDomain: 0},
+				{Name: "my-host-1", SKU: "DSv3-Type3", PlatformFaultDomain: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		groups      func() []DedicatedHostGroup
+		oldGroups   []DedicatedHostGroup
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid dedicated host groups",
+			groups: func() []DedicatedHostGroup {
+				return []DedicatedHostGroup{validGroup()}
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid new host in an existing group",
+			groups: func() []DedicatedHostGroup {
+				group := validGroup()
+				group.Hosts = append(group.Hosts, DedicatedHost{Name: "my-host-2", SKU: "DSv3-Type3"})
+				return []DedicatedHostGroup{group}
+			},
+			oldGroups: []DedicatedHostGroup{validGroup()},
+			wantErr:   false,
+		},
+		{
+			name: "duplicate dedicated host group names",
+			groups: func() []DedicatedHostGroup {
+				return []DedicatedHostGroup{validGroup(), validGroup()}
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "dedicatedHostGroups[1].name",
+				BadValue: "my-host-group",
+			},
+		},
+		{
+			name: "dedicated host without SKU",
+			groups: func() []DedicatedHostGroup {
+				group := validGroup()
+				group.Hosts[0].SKU = ""
+				return []DedicatedHostGroup{group}
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "dedicatedHostGroups[0].hosts[0].sku",
+				Detail: "sku of the dedicated host is required",
+			},
+		},
+		{
+			name: "dedicated host fault domain out of the group fault domains",
+			groups: func() []DedicatedHostGroup {
+				group := validGroup()
+				group.Hosts[1].PlatformFaultDomain = 2
+				return []DedicatedHostGroup{group}
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "dedicatedHostGroups[0].hosts[1].platformFaultDomain",
+				BadValue: int32(2),
+				Detail:   "platformFaultDomain must be lower than the platformFaultDomainCount 2 of the dedicated host group",
+			},
+		},
+		{
+			name: "dedicated host group failure domain is immutable",
+			groups: func() []DedicatedHostGroup {
+				group := validGroup()
+				group.FailureDomain = pointer.String("2")
+				return []DedicatedHostGroup{group}
+			},
+			oldGroups: []DedicatedHostGroup{validGroup()},
+			wantErr:   true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "dedicatedHostGroups[0].failureDomain",
+				BadValue: pointer.String("2"),
+				Detail:   "field is immutable",
+			},
+		},
+		{
+			name: "dedicated host SKU is immutable",
+			groups: func() []DedicatedHostGroup {
+				group := validGroup()
+				group.Hosts[0].SKU = "ESv3-Type3"
+				return []DedicatedHostGroup{group}
+			},
+			oldGroups: []DedicatedHostGroup{validGroup()},
+			wantErr:   true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "dedicatedHostGroups[0].hosts[0].sku",
+				BadValue: "ESv3-Type3",
+				Detail:   "field is immutable",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateDedicatedHostGroups(testCase.groups(), testCase.oldGroups, field.NewPath("dedicatedHostGroups"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateProximityPlacementGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		groups      []ProximityPlacementGroup
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name:    "valid proximity placement groups",
+			groups:  []ProximityPlacementGroup{{Name: "my-ppg-1"}, {Name: "my-ppg-2"}},
+			wantErr: false,
+		},
+		{
+			name:    "missing name",
+			groups:  []ProximityPlacementGroup{{Name: "my-ppg-1"}, {}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueRequired",
+				Field:    "proximityPlacementGroups[1].name",
+				BadValue: "",
+				Detail:   "name of the proximity placement group is required",
+			},
+		},
+		{
+			name:    "duplicate name",
+			groups:  []ProximityPlacementGroup{{Name: "my-ppg-1"}, {Name: "my-ppg-1"}},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "proximityPlacementGroups[1].name",
+				BadValue: "my-ppg-1",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateProximityPlacementGroups(testCase.groups, field.NewPath("proximityPlacementGroups"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateFlowLog(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		flowLog     FlowLog
+		wantErr     bool
+		expectedErr field.Error
+	}{
+		{
+			name: "valid flow log",
+			flowLog: FlowLog{
+				StorageAccountID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+				RetentionDays:               30,
+				NetworkWatcherName:          "my-watcher",
+				NetworkWatcherResourceGroup: "my-watcher-rg",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid storage account ID",
+			flowLog: FlowLog{
+				StorageAccountID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "flowLog.storageAccountID",
+				BadValue: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+				Detail:   "storage account ID doesn't match regex " + storageAccountIDPattern,
+			},
+		},
+		{
+			name: "negative retention",
+			flowLog: FlowLog{
+				StorageAccountID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorage",
+				RetentionDays:    -1,
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "flowLog.retentionDays",
+				BadValue: int32(-1),
+				Detail:   "retention days cannot be negative",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateFlowLog(testCase.flowLog, field.NewPath("flowLog"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateOutboundType(t *testing.T) {
+	g := NewWithT(t)
+
+	udr := OutboundTypeUserDefinedRouting
+	lb := OutboundTypeLoadBalancer
+	tests := []struct {
+		name          string
+		networkSpec   NetworkClassSpec
+		hasOutboundLB bool
+		hasNatGateway bool
+		wantErr       bool
+		expectedErr   field.Error
+	}{
+		{
+			name:        "default outbound type",
+			networkSpec: NetworkClassSpec{},
+			wantErr:     false,
+		},
+		{
+			name:          "load balancer outbound type with an outbound load balancer",
+			networkSpec:   NetworkClassSpec{OutboundType: &lb},
+			hasOutboundLB: true,
+			wantErr:       false,
+		},
+		{
+			name:        "user defined routing with a next hop",
+			networkSpec: NetworkClassSpec{OutboundType: &udr, NextHopIPAddress: "10.0.255.4"},
+			wantErr:     false,
+		},
+		{
+			name:        "next hop without user defined routing",
+			networkSpec: NetworkClassSpec{OutboundType: &lb, NextHopIPAddress: "10.0.255.4"},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.nextHopIPAddress",
+				Detail: "nextHopIPAddress can only be set when outboundType is userDefinedRouting",
+			},
+		},
+		{
+			name:        "user defined routing without a next hop",
+			networkSpec: NetworkClassSpec{OutboundType: &udr},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:   "FieldValueRequired",
+				Field:  "networkSpec.nextHopIPAddress",
+				Detail: "nextHopIPAddress is required when outboundType is userDefinedRouting",
+			},
+		},
+		{
+			name:        "user defined routing with an IPv6 next hop",
+			networkSpec: NetworkClassSpec{OutboundType: &udr, NextHopIPAddress: "fd00::4"},
+			wantErr:     true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.nextHopIPAddress",
+				BadValue: "fd00::4",
+				Detail:   "nextHopIPAddress must be a valid IPv4 address",
+			},
+		},
+		{
+			name:          "user defined routing with an outbound load balancer",
+			networkSpec:   NetworkClassSpec{OutboundType: &udr, NextHopIPAddress: "10.0.255.4"},
+			hasOutboundLB: true,
+			wantErr:       true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.outboundType",
+				Detail: "outbound load balancers cannot be used when outboundType is userDefinedRouting",
+			},
+		},
+		{
+			name:          "user defined routing with a NAT gateway",
+			networkSpec:   NetworkClassSpec{OutboundType: &udr, NextHopIPAddress: "10.0.255.4"},
+			hasNatGateway: true,
+			wantErr:       true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.outboundType",
+				Detail: "NAT gateways cannot be used when outboundType is userDefinedRouting",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateOutboundType(testCase.networkSpec, testCase.hasOutboundLB, testCase.hasNatGateway, field.NewPath("networkSpec"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateVnetProperties(t *testing.T) {
+	properties := VnetClassSpec{
+		DDoSProtectionPlanID: pointer.String(""),
+		Encryption:           &VnetEncryption{Enabled: pointer.Bool(false)},
+		DHCPOptions:          &VnetDHCPOptions{},
+	}
+	tests := []struct {
+		name     string
+		vnet     VnetSpec
+		wantErrs field.ErrorList
+	}{
+		{
+			name: "vnet not created yet",
+			vnet: VnetSpec{Name: "my-vnet", VnetClassSpec: properties},
+		},
+		{
+			name: "vnet managed by capz",
+			vnet: VnetSpec{
+				ID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				Name: "my-vnet",
+				VnetClassSpec: VnetClassSpec{
+					Tags:                 Tags{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned"},
+					DDoSProtectionPlanID: properties.DDoSProtectionPlanID,
+					Encryption:           properties.Encryption,
+					DHCPOptions:          properties.DHCPOptions,
+				},
+			},
+		},
+		{
+			name: "vnet brought by the user without properties",
+			vnet: VnetSpec{
+				ID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				Name: "my-vnet",
+			},
+		},
+		{
+			name: "vnet brought by the user with properties",
+			vnet: VnetSpec{
+				ID:            "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+				Name:          "my-vnet",
+				VnetClassSpec: properties,
+			},
+			wantErrs: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "networkSpec", "vnet", "ddosProtectionPlanID"),
+					"ddosProtectionPlanID can only be set on a virtual network managed by CAPZ, but virtual network my-vnet is not"),
+				field.Forbidden(field.NewPath("spec", "networkSpec", "vnet", "encryption"),
+					"encryption can only be set on a virtual network managed by CAPZ, but virtual network my-vnet is not"),
+				field.Forbidden(field.NewPath("spec", "networkSpec", "vnet", "dhcpOptions"),
+					"dhcpOptions can only be set on a virtual network managed by CAPZ, but virtual network my-vnet is not"),
+			},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "my-cluster-abcde",
+					Labels: map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+				},
+			}
+			cluster.Spec.NetworkSpec.Vnet = tc.vnet
+
+			errs := cluster.validateVnetProperties()
+			if tc.wantErrs != nil {
+				g.Expect(errs).To(Equal(tc.wantErrs))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateIPv6Only(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name           string
+		networkSpec    NetworkSpec
+		featureEnabled bool
+		wantErr        bool
+		expectedErr    field.Error
+	}{
+		{
+			name:        "IPv6-only mode disabled",
+			networkSpec: NetworkSpec{Vnet: VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}}}},
+			wantErr:     false,
+		},
+		{
+			name: "IPv6-only mode with IPv4 and IPv6 CIDR blocks",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{IPv6Only: true},
+				Vnet:             VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"}}},
+			},
+			featureEnabled: true,
+			wantErr:        false,
+		},
+		{
+			name: "IPv6-only mode without the feature flag",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{IPv6Only: true},
+				Vnet:             VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"}}},
+			},
+			featureEnabled: false,
+			wantErr:        true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "networkSpec.ipv6Only",
+				Detail: "can be set only if the IPv6Only feature flag is enabled",
+			},
+		},
+		{
+			name: "IPv6-only mode without an IPv6 CIDR block",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{IPv6Only: true},
+				Vnet:             VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"10.0.0.0/8"}}},
+			},
+			featureEnabled: true,
+			wantErr:        true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.vnet.cidrBlocks",
+				BadValue: []string{"10.0.0.0/8"},
+				Detail:   "an IPv6-only network requires both an IPv4 and an IPv6 CIDR block on the virtual network",
+			},
+		},
+		{
+			name: "IPv6-only mode without an IPv4 CIDR block",
+			networkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{IPv6Only: true},
+				Vnet:             VnetSpec{VnetClassSpec: VnetClassSpec{CIDRBlocks: []string{"2001:1234:5678:9a00::/56"}}},
+			},
+			featureEnabled: true,
+			wantErr:        true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "networkSpec.vnet.cidrBlocks",
+				BadValue: []string{"2001:1234:5678:9a00::/56"},
+				Detail:   "an IPv6-only network requires both an IPv4 and an IPv6 CIDR block on the virtual network",
+			},
+		},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.IPv6Only, testCase.featureEnabled)()
+			err := validateIPv6Only(testCase.networkSpec, field.NewPath("networkSpec"))
+			if testCase.wantErr {
+				g.Expect(err).To(ContainElement(MatchError(testCase.expectedErr.Error())))
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestValidateSecurityRule(t *testing.T) {
 	g := NewWithT(t)
 
@@ -625,20 +1493,74 @@ func TestValidateSecurityRule(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "security rule - invalid low priority",
+			name: "security rule - invalid low priority",
+			validRule: SecurityRule{
+				Name:        "allow_apiserver",
+				Description: "Allow K8s API Server",
+				Priority:    99,
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - invalid high priority",
+			validRule: SecurityRule{
+				Name:        "allow_apiserver",
+				Description: "Allow K8s API Server",
+				Priority:    5000,
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - valid application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_apiserver",
+				Description:                          "Allow K8s API Server",
+				Priority:                             101,
+				SourceApplicationSecurityGroups:      []string{"my-cluster-node-asg"},
+				DestinationApplicationSecurityGroups: []string{"my-cluster-controlplane-asg"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - custom application security group",
+			validRule: SecurityRule{
+				Name:                                 "allow_ingress",
+				Description:                          "Allow ingress nodes",
+				Priority:                             102,
+				Source:                               pointer.String("Internet"),
+				DestinationApplicationSecurityGroups: []string{"ingress-asg"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "security rule - application security group not created for the cluster",
 			validRule: SecurityRule{
-				Name:        "allow_apiserver",
-				Description: "Allow K8s API Server",
-				Priority:    99,
+				Name:                            "allow_ingress",
+				Description:                     "Allow ingress nodes",
+				Priority:                        102,
+				SourceApplicationSecurityGroups: []string{"other-cluster-node-asg"},
 			},
 			wantErr: true,
 		},
 		{
-			name: "security rule - invalid high priority",
+			name: "security rule - source set with source application security groups",
 			validRule: SecurityRule{
-				Name:        "allow_apiserver",
-				Description: "Allow K8s API Server",
-				Priority:    5000,
+				Name:                            "allow_apiserver",
+				Description:                     "Allow K8s API Server",
+				Priority:                        101,
+				Source:                          pointer.String("*"),
+				SourceApplicationSecurityGroups: []string{"my-cluster-node-asg"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "security rule - destination set with destination application security groups",
+			validRule: SecurityRule{
+				Name:                                 "allow_apiserver",
+				Description:                          "Allow K8s API Server",
+				Priority:                             101,
+				Destination:                          pointer.String("10.0.0.0/16"),
+				DestinationApplicationSecurityGroups: []string{"my-cluster-controlplane-asg"},
 			},
 			wantErr: true,
 		},
@@ -649,6 +1571,9 @@ func TestValidateSecurityRule(t *testing.T) {
 			t.Parallel()
 			err := validateSecurityRule(
 				testCase.validRule,
+				func(name string) bool {
+					return name == "my-cluster-controlplane-asg" || name == "my-cluster-node-asg" || name == "ingress-asg"
+				},
 				field.NewPath("spec").Child("networkSpec").Child("subnets").Index(0).Child("securityGroup").Child("securityRules").Index(0),
 			)
 			if testCase.wantErr {
@@ -722,30 +1647,39 @@ func TestValidateAPIServerLB(t *testing.T) {
 			},
 		},
 		{
-			name: "too many IP configs",
+			name:    "no IP configs",
+			lb:      LoadBalancerSpec{},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.frontendIPConfigs",
+				BadValue: []FrontendIP(nil),
+				Detail:   "API Server Load balancer should have at least 1 Frontend IP",
+			},
+		},
+		{
+			name: "public LB with an additional IP config without public IP",
 			lb: LoadBalancerSpec{
 				FrontendIPs: []FrontendIP{
 					{
 						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name: "pip-1",
+						},
 					},
 					{
 						Name: "ip-2",
 					},
 				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+				},
 			},
 			wantErr: true,
 			expectedErr: field.Error{
-				Type:  "FieldValueInvalid",
-				Field: "apiServerLB.frontendIPConfigs",
-				BadValue: []FrontendIP{
-					{
-						Name: "ip-1",
-					},
-					{
-						Name: "ip-2",
-					},
-				},
-				Detail: "API Server Load balancer should have 1 Frontend IP",
+				Type:   "FieldValueRequired",
+				Field:  "apiServerLB.frontendIPConfigs[1].publicIP",
+				Detail: "Additional Frontend IPs of Public Load Balancers need a Public IP",
 			},
 		},
 		{
@@ -859,6 +1793,309 @@ func TestValidateAPIServerLB(t *testing.T) {
 			cpCIDRS: []string{"10.0.0.0/24", "10.1.0.0/24"},
 			wantErr: false,
 		},
+		{
+			name: "internal LB private IP set after the subnets are allocated from an IPAM pool",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						FrontendIPClass: FrontendIPClass{
+							PrivateIPAddress: "10.3.0.100",
+						},
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				Name: "my-private-lb",
+			},
+			old: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				Name: "my-private-lb",
+			},
+			cpCIDRS: []string{"10.3.0.0/24"},
+			wantErr: false,
+		},
+		{
+			name: "public LB with an https probe and additional rules on multiple frontends",
+			lb: LoadBalancerSpec{
+				Name: "my-public-lb",
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+						PublicIP: &PublicIPSpec{
+							Name: "pip-1",
+						},
+					},
+					{
+						Name: "ip-2",
+						PublicIP: &PublicIPSpec{
+							Name: "pip-2",
+						},
+					},
+				},
+				Probe: &LoadBalancerProbe{
+					Protocol:          LoadBalancerProbeProtocolHTTPS,
+					RequestPath:       "/readyz",
+					IntervalInSeconds: pointer.Int32(5),
+					NumberOfProbes:    pointer.Int32(2),
+				},
+				AdditionalRules: []LoadBalancingRule{
+					{
+						Name:         "konnectivity",
+						Protocol:     LoadBalancingRuleProtocolTCP,
+						FrontendPort: 8132,
+						BackendPort:  8132,
+					},
+					{
+						Name:           "etcd-metrics",
+						FrontendIPName: "ip-2",
+						Protocol:       LoadBalancingRuleProtocolTCP,
+						FrontendPort:   8132,
+						BackendPort:    2381,
+					},
+				},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "tcp probe with a request path",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				Probe: &LoadBalancerProbe{
+					Protocol:    LoadBalancerProbeProtocolTCP,
+					RequestPath: "/readyz",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.probe.requestPath",
+				Detail: "Tcp probes cannot have a request path",
+			},
+		},
+		{
+			name: "https probe with an invalid request path",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				Probe: &LoadBalancerProbe{
+					Protocol:    LoadBalancerProbeProtocolHTTPS,
+					RequestPath: "readyz",
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.probe.requestPath",
+				BadValue: "readyz",
+				Detail:   "request path must start with /",
+			},
+		},
+		{
+			name: "additional rule with an unknown frontend IP",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				AdditionalRules: []LoadBalancingRule{
+					{
+						Name:           "konnectivity",
+						FrontendIPName: "ip-2",
+						Protocol:       LoadBalancingRuleProtocolTCP,
+						FrontendPort:   8132,
+						BackendPort:    8132,
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.additionalRules[0].frontendIPName",
+				BadValue: "ip-2",
+				Detail:   "load balancing rule must use a frontend IP of the load balancer",
+			},
+		},
+		{
+			name: "additional rules on the same frontend port",
+			lb: LoadBalancerSpec{
+				FrontendIPs: []FrontendIP{
+					{
+						Name: "ip-1",
+					},
+				},
+				AdditionalRules: []LoadBalancingRule{
+					{
+						Name:         "konnectivity",
+						Protocol:     LoadBalancingRuleProtocolTCP,
+						FrontendPort: 8132,
+						BackendPort:  8132,
+					},
+					{
+						Name:           "etcd-metrics",
+						FrontendIPName: "ip-1",
+						Protocol:       LoadBalancingRuleProtocolTCP,
+						FrontendPort:   8132,
+						BackendPort:    2381,
+					},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueDuplicate",
+				Field:    "apiServerLB.additionalRules[1].frontendPort",
+				BadValue: int32(8132),
+			},
+		},
+		{
+			name: "internal LB with a public IP prefix",
+			lb: LoadBalancerSpec{
+				Name:        "my-private-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 28},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.publicIPPrefix",
+				Detail: "Internal Load Balancers cannot have a public IP prefix",
+			},
+		},
+		{
+			name: "public IP prefix added after creation",
+			lb: LoadBalancerSpec{
+				Name:        "my-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1", PublicIP: &PublicIPSpec{Name: "my-ip"}}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				PublicIPPrefix: &PublicIPPrefixSpec{Name: "my-prefix", ResourceGroup: "my-rg", PrefixLength: 28},
+			},
+			old: LoadBalancerSpec{
+				Name:        "my-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1", PublicIP: &PublicIPSpec{Name: "my-ip"}}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.publicIPPrefix",
+				Detail: "API Server load balancer public IP prefix should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "internal LB with a private link service",
+			lb: LoadBalancerSpec{
+				Name:        "my-private-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				PrivateLinkService: &PrivateLinkServiceSpec{
+					Name:                      "my-pls",
+					VisibleSubscriptions:      []string{"*"},
+					AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000000"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "public LB with a private link service",
+			lb: LoadBalancerSpec{
+				Name:        "my-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1", PublicIP: &PublicIPSpec{Name: "my-ip"}}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Public,
+					SKU:  SKUStandard,
+				},
+				PrivateLinkService: &PrivateLinkServiceSpec{Name: "my-pls"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.privateLinkService",
+				Detail: "Private link services can only be created for Internal Load Balancers",
+			},
+		},
+		{
+			name: "private link service with an invalid auto approved subscription",
+			lb: LoadBalancerSpec{
+				Name:        "my-private-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				PrivateLinkService: &PrivateLinkServiceSpec{
+					Name:                      "my-pls",
+					AutoApprovedSubscriptions: []string{"*"},
+				},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:     "FieldValueInvalid",
+				Field:    "apiServerLB.privateLinkService.autoApprovedSubscriptions[0]",
+				BadValue: "*",
+				Detail:   "subscription must be a valid subscription ID",
+			},
+		},
+		{
+			name: "private link service removed after creation",
+			lb: LoadBalancerSpec{
+				Name:        "my-private-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+			},
+			old: LoadBalancerSpec{
+				Name:        "my-private-lb",
+				FrontendIPs: []FrontendIP{{Name: "ip-1"}},
+				LoadBalancerClassSpec: LoadBalancerClassSpec{
+					Type: Internal,
+					SKU:  SKUStandard,
+				},
+				PrivateLinkService: &PrivateLinkServiceSpec{Name: "my-pls"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "apiServerLB.privateLinkService",
+				Detail: "API Server load balancer private link service should not be removed or renamed after AzureCluster creation.",
+			},
+		},
 	}
 
 	for _, test := range testcases {
@@ -1098,6 +2335,45 @@ func TestValidateNodeOutboundLB(t *testing.T) {
 				Detail:   "Max front end ips allowed is 16",
 			},
 		},
+		{
+			name: "invalid public IP prefix update",
+			lb: &LoadBalancerSpec{
+				PublicIPPrefix: &PublicIPPrefixSpec{Name: "new-prefix", ResourceGroup: "my-rg", PrefixLength: 28},
+			},
+			old: &LoadBalancerSpec{
+				PublicIPPrefix: &PublicIPPrefixSpec{Name: "old-prefix", ResourceGroup: "my-rg", PrefixLength: 28},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "nodeOutboundLB.publicIPPrefix",
+				Detail: "Node outbound load balancer public IP prefix should not be modified after AzureCluster creation.",
+			},
+		},
+		{
+			name: "private link service on node outbound lb",
+			lb: &LoadBalancerSpec{
+				PrivateLinkService: &PrivateLinkServiceSpec{Name: "my-pls"},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "nodeOutboundLB.privateLinkService",
+				Detail: "Private link services are only supported on the API server load balancer",
+			},
+		},
+		{
+			name: "probe on node outbound lb",
+			lb: &LoadBalancerSpec{
+				Probe: &LoadBalancerProbe{Protocol: LoadBalancerProbeProtocolTCP},
+			},
+			wantErr: true,
+			expectedErr: field.Error{
+				Type:   "FieldValueForbidden",
+				Field:  "nodeOutboundLB.probe",
+				Detail: "Probes are only supported on the API server load balancer",
+			},
+		},
 	}
 
 	for _, test := range testcases {
@@ -23,35 +23,27 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// SetupWebhookWithManager sets up and registers the webhook with the manager.
-func (c *AzureCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(c).
-		Complete()
-}
-
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusters,versions=v1beta1,name=validation.azurecluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=azureclusters,versions=v1beta1,name=default.azurecluster.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
-var _ webhook.Validator = &AzureCluster{}
-var _ webhook.Defaulter = &AzureCluster{}
+var _ webhookutils.Validator = &AzureCluster{}
+var _ webhookutils.Defaulter = &AzureCluster{}
 
-// Default implements webhook.Defaulter so a webhook will be registered for the type.
-func (c *AzureCluster) Default() {
+// Default implements webhookutils.Defaulter so a webhook will be registered for the type.
+func (c *AzureCluster) Default(_ client.Client) {
 	c.setDefaults()
 }
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (c *AzureCluster) ValidateCreate() error {
-	return c.validateCluster(nil)
+// ValidateCreate implements webhookutils.Validator so a webhook will be registered for the type.
+func (c *AzureCluster) ValidateCreate(cli client.Client) error {
+	return c.validateCluster(nil, cli)
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
+// ValidateUpdate implements webhookutils.Validator so a webhook will be registered for the type.
+func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object, cli client.Client) error {
 	var allErrs field.ErrorList
 	old := oldRaw.(*AzureCluster)
 
@@ -122,6 +114,21 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
+	// An unset outbound type is the same as loadBalancer.
+	if old.Spec.NetworkSpec.IsUserDefinedRouting() != c.Spec.NetworkSpec.IsUserDefinedRouting() {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("Spec", "NetworkSpec", "OutboundType"),
+				c.Spec.NetworkSpec.OutboundType, "field is immutable"),
+		)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "NetworkSpec", "IPv6Only"),
+		old.Spec.NetworkSpec.IPv6Only,
+		c.Spec.NetworkSpec.IPv6Only); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "NetworkSpec", "ControlPlaneOutboundLB"),
 		old.Spec.NetworkSpec.ControlPlaneOutboundLB,
@@ -132,7 +139,7 @@ func (c *AzureCluster) ValidateUpdate(oldRaw runtime.Object) error {
 	allErrs = append(allErrs, c.validateSubnetUpdate(old)...)
 
 	if len(allErrs) == 0 {
-		return c.validateCluster(old)
+		return c.validateCluster(old, cli)
 	}
 
 	return apierrors.NewInvalid(GroupVersion.WithKind("AzureCluster").GroupKind(), c.Name, allErrs)
@@ -174,6 +181,12 @@ func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 						c.Spec.NetworkSpec.Subnets[i].NatGateway.Name, "field is immutable"),
 				)
 			}
+			if !reflect.DeepEqual(subnet.NatGateway.PublicIPPrefix, oldSubnet.NatGateway.PublicIPPrefix) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("NatGateway").Child("PublicIPPrefix"),
+						c.Spec.NetworkSpec.Subnets[i].NatGateway.PublicIPPrefix, "field is immutable"),
+				)
+			}
 			if subnet.SecurityGroup.Name != oldSubnet.SecurityGroup.Name {
 				allErrs = append(allErrs,
 					field.Invalid(field.NewPath("spec", "networkSpec", "subnets").Index(oldSubnetIndex[subnet.Name]).Child("SecurityGroup").Child("Name"),
@@ -186,7 +199,7 @@ func (c *AzureCluster) validateSubnetUpdate(old *AzureCluster) field.ErrorList {
 	return allErrs
 }
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
-func (c *AzureCluster) ValidateDelete() error {
+// ValidateDelete implements webhookutils.Validator so a webhook will be registered for the type.
+func (c *AzureCluster) ValidateDelete(_ client.Client) error {
 	return nil
 }
@@ -20,7 +20,11 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureCluster_ValidateCreate(t *testing.T) {
@@ -97,7 +101,7 @@ func TestAzureCluster_ValidateCreate(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.cluster.ValidateCreate()
+			err := tc.cluster.ValidateCreate(nil)
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -306,12 +310,113 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:       "outbound type can be set to its default",
+			oldCluster: createValidCluster(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				outboundType := OutboundTypeLoadBalancer
+				cluster.Spec.NetworkSpec.OutboundType = &outboundType
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "outbound type is immutable",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NodeOutboundLB = nil
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NodeOutboundLB = nil
+				outboundType := OutboundTypeUserDefinedRouting
+				cluster.Spec.NetworkSpec.OutboundType = &outboundType
+				cluster.Spec.NetworkSpec.NextHopIPAddress = "10.0.255.4"
+				return cluster
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "user defined routing in a vnet brought by the user can be updated",
+			oldCluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NodeOutboundLB = nil
+				outboundType := OutboundTypeUserDefinedRouting
+				cluster.Spec.NetworkSpec.OutboundType = &outboundType
+				cluster.Spec.NetworkSpec.NextHopIPAddress = "10.0.255.4"
+				cluster.Spec.NetworkSpec.Vnet.ID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+				return cluster
+			}(),
+			cluster: func() *AzureCluster {
+				cluster := createValidCluster()
+				cluster.Spec.NetworkSpec.NodeOutboundLB = nil
+				outboundType := OutboundTypeUserDefinedRouting
+				cluster.Spec.NetworkSpec.OutboundType = &outboundType
+				cluster.Spec.NetworkSpec.NextHopIPAddress = "10.0.255.4"
+				cluster.Spec.NetworkSpec.Vnet.ID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet"
+				return cluster
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "IPv6-only mode is immutable",
+			oldCluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{IPv6Only: false},
+					},
+				},
+			},
+			cluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						NetworkClassSpec: NetworkClassSpec{IPv6Only: true},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "nat gateway public IP prefix is immutable",
+			oldCluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{Name: "node-subnet"},
+								NatGateway: NatGateway{
+									NatGatewayClassSpec: NatGatewayClassSpec{Name: "node-natgw"},
+								},
+							},
+						},
+					},
+				},
+			},
+			cluster: &AzureCluster{
+				Spec: AzureClusterSpec{
+					NetworkSpec: NetworkSpec{
+						Subnets: Subnets{
+							{
+								SubnetClassSpec: SubnetClassSpec{Name: "node-subnet"},
+								NatGateway: NatGateway{
+									NatGatewayClassSpec: NatGatewayClassSpec{Name: "node-natgw"},
+									PublicIPPrefix:      &PublicIPPrefixSpec{Name: "my-prefix", PrefixLength: 28},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			err := tc.cluster.ValidateUpdate(tc.oldCluster)
+			err := tc.cluster.ValidateUpdate(tc.oldCluster, nil)
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -320,3 +425,105 @@ func TestAzureCluster_ValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureCluster_ValidateIPAMPoolAllocations(t *testing.T) {
+	pool := &AzureIPAMPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-pool",
+			Namespace: "default",
+		},
+		Spec: AzureIPAMPoolSpec{
+			CIDRBlocks: []string{"10.0.0.0/8"},
+		},
+		Status: AzureIPAMPoolStatus{
+			Allocations: []IPAMAllocation{
+				{ClusterName: "other-cluster", ClusterNamespace: "default", CIDRBlock: "10.0.0.0/16"},
+				{ClusterName: "test-cluster", ClusterNamespace: "default", CIDRBlock: "10.1.0.0/16"},
+			},
+		},
+	}
+
+	// The address space of a cluster moved with clusterctl move is missing from the status of the pool.
+	movedCluster := &AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "moved-cluster",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "moved-cluster"},
+		},
+	}
+	movedCluster.Spec.NetworkSpec.Vnet.IPAMPoolRef = &corev1.ObjectReference{Name: "my-pool"}
+	movedCluster.Spec.NetworkSpec.Vnet.CIDRBlocks = []string{"10.3.0.0/16"}
+
+	otherAllocationErr := "overlaps with 10.0.0.0/16 allocated by IPAM pool my-pool to cluster default/other-cluster"
+	tests := []struct {
+		name       string
+		poolName   string
+		cidrBlocks []string
+		wantErr    string
+	}{
+		{
+			name:       "address space allocated to the cluster",
+			poolName:   "my-pool",
+			cidrBlocks: []string{"10.1.0.0/16"},
+		},
+		{
+			name:       "address space not allocated yet",
+			poolName:   "my-pool",
+			cidrBlocks: []string{"10.2.0.0/16"},
+		},
+		{
+			name:     "address space to be allocated by the controller",
+			poolName: "my-pool",
+		},
+		{
+			name:       "address space overlapping with the allocation of another cluster",
+			poolName:   "my-pool",
+			cidrBlocks: []string{"10.0.128.0/17"},
+			wantErr:    otherAllocationErr,
+		},
+		{
+			name:       "address space containing the allocation of another cluster",
+			poolName:   "my-pool",
+			cidrBlocks: []string{"10.2.0.0/16", "10.0.0.0/15"},
+			wantErr:    otherAllocationErr,
+		},
+		{
+			name:       "address space overlapping with a cluster missing from the status of the pool",
+			poolName:   "my-pool",
+			cidrBlocks: []string{"10.3.0.0/24"},
+			wantErr:    "overlaps with 10.3.0.0/16 allocated by IPAM pool my-pool to cluster default/moved-cluster",
+		},
+		{
+			name:       "pool not created yet",
+			poolName:   "other-pool",
+			cidrBlocks: []string{"10.0.0.0/16"},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme := runtime.NewScheme()
+			_ = AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pool, movedCluster).Build()
+
+			cluster := &AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster-abcde",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				},
+			}
+			cluster.Spec.NetworkSpec.Vnet.IPAMPoolRef = &corev1.ObjectReference{Name: tc.poolName}
+			cluster.Spec.NetworkSpec.Vnet.CIDRBlocks = tc.cidrBlocks
+
+			errs := cluster.validateIPAMPoolAllocations(fakeClient)
+			if tc.wantErr != "" {
+				g.Expect(errs).NotTo(BeEmpty())
+				g.Expect(errs.ToAggregate().Error()).To(ContainSubstring(tc.wantErr))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -88,7 +88,7 @@ func (c *AzureClusterTemplate) setSubnetsTemplateDefaults() {
 
 func (c *AzureClusterTemplate) setNodeOutboundLBDefaults() {
 	if c.Spec.Template.Spec.NetworkSpec.NodeOutboundLB == nil {
-		if c.Spec.Template.Spec.NetworkSpec.APIServerLB.Type == Internal {
+		if c.Spec.Template.Spec.NetworkSpec.APIServerLB.Type == Internal || c.Spec.Template.Spec.NetworkSpec.IsUserDefinedRouting() {
 			return
 		}
 
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -45,9 +46,14 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 		field.NewPath("spec").Child("template").Child("spec").
 			Child("networkSpec").Child("vnet").Child("cidrBlocks"))...)
 
+	allErrs = append(allErrs, validateVnetClassSpec(
+		c.Spec.Template.Spec.NetworkSpec.Vnet.VnetClassSpec,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("vnet"))...)
+
 	allErrs = append(allErrs, validateSubnetTemplates(
 		c.Spec.Template.Spec.NetworkSpec.Subnets,
 		c.Spec.Template.Spec.NetworkSpec.Vnet,
+		c.Spec.Template.Spec.NetworkSpec.ApplicationSecurityGroups,
 		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("subnets"),
 	)...)
 
@@ -55,27 +61,58 @@ func (c *AzureClusterTemplate) validateClusterTemplateSpec() field.ErrorList {
 		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("apiServerLB"),
 	)...)
 
-	var oneSubnetWithoutNatGateway bool
+	var oneSubnetWithoutNatGateway, oneSubnetWithNatGateway bool
 	networkSpec := c.Spec.Template.Spec.NetworkSpec
 	for _, subnet := range networkSpec.Subnets {
 		if subnet.Role == SubnetNode && !subnet.IsNatGatewayEnabled() {
 			oneSubnetWithoutNatGateway = true
-			break
+		}
+		if subnet.IsNatGatewayEnabled() {
+			oneSubnetWithNatGateway = true
 		}
 	}
-	if oneSubnetWithoutNatGateway {
+	if oneSubnetWithoutNatGateway && !networkSpec.IsUserDefinedRouting() {
 		allErrs = append(allErrs, c.validateNodeOutboundLB()...)
 	}
 
+	hasOutboundLB := networkSpec.NodeOutboundLB != nil || networkSpec.ControlPlaneOutboundLB != nil
+	allErrs = append(allErrs, validateOutboundType(networkSpec.NetworkClassSpec, hasOutboundLB, oneSubnetWithNatGateway,
+		field.NewPath("spec").Child("template").Child("spec").Child("networkSpec"))...)
+
 	allErrs = append(allErrs, c.validateControlPlaneOutboundLB()...)
 
 	allErrs = append(allErrs, c.validatePrivateDNSZoneName()...)
 
+	if c.Spec.Template.Spec.DiagnosticSettings != nil {
+		allErrs = append(allErrs, validateDiagnosticSettings(*c.Spec.Template.Spec.DiagnosticSettings,
+			field.NewPath("spec").Child("template").Child("spec").Child("diagnosticSettings"))...)
+	}
+
+	allErrs = append(allErrs, validateDedicatedHostGroups(c.Spec.Template.Spec.DedicatedHostGroups, nil,
+		field.NewPath("spec").Child("template").Child("spec").Child("dedicatedHostGroups"))...)
+
+	allErrs = append(allErrs, validateProximityPlacementGroups(c.Spec.Template.Spec.ProximityPlacementGroups,
+		field.NewPath("spec").Child("template").Child("spec").Child("proximityPlacementGroups"))...)
+
 	return allErrs
 }
 
-func validateSubnetTemplates(subnets SubnetTemplatesSpec, vnet VnetTemplateSpec, fld *field.Path) field.ErrorList {
+func validateSubnetTemplates(subnets SubnetTemplatesSpec, vnet VnetTemplateSpec, asgNames []string, fld *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
+	// The names of the control plane and node application security groups depend on the name of the cluster created
+	// from the template, so they are matched by suffix.
+	isClusterASG := func(name string) bool {
+		if strings.HasSuffix(name, generateControlPlaneApplicationSecurityGroupName("")) ||
+			strings.HasSuffix(name, generateNodeApplicationSecurityGroupName("")) {
+			return true
+		}
+		for _, asgName := range asgNames {
+			if name == asgName {
+				return true
+			}
+		}
+		return false
+	}
 	subnetNames := make(map[string]bool, len(subnets))
 	requiredSubnetRoles := map[string]bool{
 		"control-plane": false,
@@ -98,11 +135,15 @@ func validateSubnetTemplates(subnets SubnetTemplatesSpec, vnet VnetTemplateSpec,
 		for j, rule := range subnet.SecurityGroup.SecurityRules {
 			if err := validateSecurityRule(
 				rule,
+				isClusterASG,
 				fld.Index(i).Child("securityGroup").Child("securityGroup").Child("securityRules").Index(j),
 			); err != nil {
 				allErrs = append(allErrs, err)
 			}
 		}
+		if subnet.SecurityGroup.FlowLog != nil {
+			allErrs = append(allErrs, validateFlowLog(*subnet.SecurityGroup.FlowLog, fld.Index(i).Child("securityGroup").Child("flowLog"))...)
+		}
 		allErrs = append(allErrs, validateSubnetCIDR(subnet.CIDRBlocks, vnet.CIDRBlocks, fld.Index(i).Child("cidrBlocks"))...)
 	}
 	for k, v := range requiredSubnetRoles {
@@ -415,6 +415,7 @@ func TestValidateSubnetTemplates(t *testing.T) {
 			res := validateSubnetTemplates(
 				tc.clusterTemplate.Spec.Template.Spec.NetworkSpec.Subnets,
 				tc.clusterTemplate.Spec.Template.Spec.NetworkSpec.Vnet,
+				tc.clusterTemplate.Spec.Template.Spec.NetworkSpec.ApplicationSecurityGroups,
 				field.NewPath("spec").Child("template").Child("spec").Child("networkSpec").Child("subnets"),
 			)
 
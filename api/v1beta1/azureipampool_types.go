@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultIPAMVnetPrefixLength is the default prefix length of the virtual networks allocated from an AzureIPAMPool.
+	DefaultIPAMVnetPrefixLength = 16
+	// DefaultIPAMSubnetPrefixLength is the default prefix length of the subnets allocated in a virtual network
+	// allocated from an AzureIPAMPool.
+	DefaultIPAMSubnetPrefixLength = 20
+	// IPAMBastionSubnetPrefixLength is the prefix length of the Azure Bastion subnet allocated in a virtual network
+	// allocated from an AzureIPAMPool.
+	IPAMBastionSubnetPrefixLength = 27
+)
+
+// AzureIPAMPoolSpec defines the address space an AzureIPAMPool allocates virtual networks from.
+type AzureIPAMPoolSpec struct {
+	// CIDRBlocks is the address space of the pool, specified as one or more address prefixes in CIDR notation.
+	// +kubebuilder:validation:MinItems=1
+	CIDRBlocks []string `json:"cidrBlocks"`
+
+	// VnetPrefixLength is the prefix length of the address space allocated to the virtual network of each cluster.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=29
+	// +kubebuilder:default=16
+	// +optional
+	VnetPrefixLength int32 `json:"vnetPrefixLength,omitempty"`
+
+	// SubnetPrefixLength is the prefix length of the subnets allocated in the virtual network of each cluster.
+	// It must be greater than VnetPrefixLength.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=29
+	// +kubebuilder:default=20
+	// +optional
+	SubnetPrefixLength int32 `json:"subnetPrefixLength,omitempty"`
+}
+
+// IPAMAllocation is an address prefix allocated from an AzureIPAMPool to a cluster.
+type IPAMAllocation struct {
+	// ClusterName is the name of the cluster the address prefix is allocated to.
+	ClusterName string `json:"clusterName"`
+
+	// ClusterNamespace is the namespace of the cluster the address prefix is allocated to.
+	ClusterNamespace string `json:"clusterNamespace"`
+
+	// CIDRBlock is the allocated address prefix in CIDR notation.
+	CIDRBlock string `json:"cidrBlock"`
+}
+
+// AzureIPAMPoolStatus defines the observed state of AzureIPAMPool.
+type AzureIPAMPoolStatus struct {
+	// Allocations are the address prefixes allocated from the pool.
+	// +optional
+	Allocations []IPAMAllocation `json:"allocations,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="VnetPrefixLength",type="integer",JSONPath=".spec.vnetPrefixLength",description="Prefix length of the allocated virtual networks"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of this AzureIPAMPool"
+// +kubebuilder:resource:path=azureipampools,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+
+// AzureIPAMPool is the Schema for the azureipampools API. It is a pool of address space from which non-overlapping
+// virtual network address spaces are allocated to the AzureClusters that reference it.
+type AzureIPAMPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AzureIPAMPoolSpec   `json:"spec,omitempty"`
+	Status AzureIPAMPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureIPAMPoolList contains a list of AzureIPAMPool.
+type AzureIPAMPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureIPAMPool `json:"items"`
+}
+
+// GetVnetPrefixLength returns the prefix length of the virtual networks allocated from the pool.
+func (p *AzureIPAMPool) GetVnetPrefixLength() int {
+	if p.Spec.VnetPrefixLength == 0 {
+		return DefaultIPAMVnetPrefixLength
+	}
+	return int(p.Spec.VnetPrefixLength)
+}
+
+// GetSubnetPrefixLength returns the prefix length of the subnets allocated in the virtual networks allocated from
+// the pool.
+func (p *AzureIPAMPool) GetSubnetPrefixLength() int {
+	if p.Spec.SubnetPrefixLength == 0 {
+		return DefaultIPAMSubnetPrefixLength
+	}
+	return int(p.Spec.SubnetPrefixLength)
+}
+
+// Allocations returns the address prefixes allocated from the pool: the allocations recorded in its status, followed by the
+// address space of the virtual networks of the AzureClusters that reference the pool. The latter are the source of truth
+// when the status is lost, e.g. when the pool is recreated or moved to another management cluster by clusterctl move.
+func (p *AzureIPAMPool) Allocations(ctx context.Context, cli client.Client) ([]IPAMAllocation, error) {
+	allocations := append([]IPAMAllocation{}, p.Status.Allocations...)
+	seen := make(map[IPAMAllocation]bool, len(allocations))
+	for _, allocation := range allocations {
+		seen[allocation] = true
+	}
+
+	clusters := &AzureClusterList{}
+	if err := cli.List(ctx, clusters); err != nil {
+		return nil, errors.Wrap(err, "failed to list AzureClusters")
+	}
+	for _, cluster := range clusters.Items {
+		ref := cluster.Spec.NetworkSpec.Vnet.IPAMPoolRef
+		if ref == nil || ref.Name != p.Name {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cluster.Namespace
+		}
+		if namespace != p.Namespace {
+			continue
+		}
+		for _, cidrBlock := range cluster.Spec.NetworkSpec.Vnet.CIDRBlocks {
+			allocation := IPAMAllocation{
+				ClusterName:      cluster.clusterName(),
+				ClusterNamespace: cluster.Namespace,
+				CIDRBlock:        cidrBlock,
+			}
+			if !seen[allocation] {
+				seen[allocation] = true
+				allocations = append(allocations, allocation)
+			}
+		}
+	}
+	return allocations, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&AzureIPAMPool{}, &AzureIPAMPoolList{})
+}
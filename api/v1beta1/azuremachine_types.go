@@ -139,8 +139,65 @@ type AzureMachineSpec struct {
 	// The primary interface will be the first networkInterface specified (index 0) in the list.
 	// +optional
 	NetworkInterfaces []NetworkInterface `json:"networkInterfaces,omitempty"`
+
+	// ApplicationSecurityGroups are the names of the custom application security groups of the cluster the network
+	// interfaces of the VM join, in addition to the application security group of the machine's role.
+	// +optional
+	ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
+
+	// HostGroupID is the Azure resource ID of the dedicated host group the virtual machine is placed in. Azure chooses
+	// the dedicated host, which requires automatic placement to be enabled on the group.
+	// It cannot be set with hostID.
+	// +optional
+	HostGroupID string `json:"hostGroupID,omitempty"`
+
+	// HostID is the Azure resource ID of the dedicated host the virtual machine is placed on.
+	// It cannot be set with hostGroupID.
+	// +optional
+	HostID string `json:"hostID,omitempty"`
+
+	// ProximityPlacementGroupID is the Azure resource ID of the proximity placement group the virtual machine, and its
+	// availability set if any, are placed in.
+	// +optional
+	ProximityPlacementGroupID string `json:"proximityPlacementGroupID,omitempty"`
+
+	// CapacityReservationGroupID is the Azure resource ID of the capacity reservation group the virtual machine is
+	// allocated from.
+	// +optional
+	CapacityReservationGroupID string `json:"capacityReservationGroupID,omitempty"`
+
+	// UpdateStrategy defines how changes to the spec of the machine are applied to the existing virtual machine.
+	// With the InPlace strategy, changes to VMSize are applied by resizing the running virtual machine, which is only
+	// deallocated, resized and started again when the new size is not available on its hardware cluster, and data
+	// disks can be added or removed while it is running.
+	// Defaults to Immutable.
+	// +optional
+	UpdateStrategy AzureMachineUpdateStrategy `json:"updateStrategy,omitempty"`
 }
 
+// AzureMachineUpdateStrategy defines how changes to an AzureMachine are applied to its virtual machine.
+// +kubebuilder:validation:Enum=Immutable;InPlace
+type AzureMachineUpdateStrategy string
+
+const (
+	// AzureMachineUpdateStrategyImmutable means the virtual machine is never updated. Changes require replacing the machine.
+	AzureMachineUpdateStrategyImmutable AzureMachineUpdateStrategy = "Immutable"
+	// AzureMachineUpdateStrategyInPlace means changes to the VM size and data disks are applied to the existing virtual machine.
+	AzureMachineUpdateStrategyInPlace AzureMachineUpdateStrategy = "InPlace"
+)
+
+const (
+	// DeleteRemovedDataDisksAnnotation is the key for the AzureMachine object annotation which, when set to "true",
+	// confirms that the data disks removed from a machine using the InPlace update strategy are deleted once they are
+	// detached from its virtual machine. Data disks cannot be removed in place without it.
+	DeleteRemovedDataDisksAnnotation = "sigs.k8s.io/cluster-api-provider-azure-delete-removed-data-disks"
+
+	// DataDisksLastAppliedAnnotation is the key for the AzureMachine object annotation which tracks the data disks
+	// attached to the virtual machine of a machine using the InPlace update strategy, so the managed disks of the
+	// removed ones can be deleted once they are detached.
+	DataDisksLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-data-disks"
+)
+
 // SpotVMOptions defines the options relevant to running the Machine on Spot VMs.
 type SpotVMOptions struct {
 	// MaxPrice defines the maximum price the user is willing to pay for Spot VM instances
@@ -184,6 +241,11 @@ type AzureMachineStatus struct {
 	// +optional
 	VMState *ProvisioningState `json:"vmState,omitempty"`
 
+	// CapacityReservationID is the Azure resource ID of the capacity reservation the virtual machine is allocated
+	// against, when it is allocated from a capacity reservation group.
+	// +optional
+	CapacityReservationID string `json:"capacityReservationID,omitempty"`
+
 	// ErrorReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -230,6 +292,11 @@ type AzureMachineStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationHistory records the most recent Azure operations that created, updated or deleted resources,
+	// with their timing and result. At most MaxOperationHistory operations are kept.
+	// +optional
+	OperationHistory Operations `json:"operationHistory,omitempty"`
 }
 
 // AdditionalCapabilities enables or disables a capability on the virtual machine.
@@ -293,6 +360,16 @@ func (m *AzureMachine) SetFutures(futures Futures) {
 	m.Status.LongRunningOperationStates = futures
 }
 
+// GetOperationHistory returns the history of the Azure operations of an AzureMachine API object.
+func (m *AzureMachine) GetOperationHistory() Operations {
+	return m.Status.OperationHistory
+}
+
+// SetOperationHistory will set the given history of Azure operations on an AzureMachine object.
+func (m *AzureMachine) SetOperationHistory(operations Operations) {
+	m.Status.OperationHistory = operations
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureMachine{}, &AzureMachineList{})
 }
@@ -17,15 +17,71 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// Dedicated host group resource ID pattern.
+	dedicatedHostGroupIDPattern = `(?i)^/subscriptions/([^/]+)/resourceGroups/([^/]+)/providers/Microsoft\.Compute/hostGroups/([^/]+)$`
+	// Dedicated host resource ID pattern.
+	dedicatedHostIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/hostGroups/[^/]+/hosts/[^/]+$`
+	// Proximity placement group resource ID pattern.
+	proximityPlacementGroupIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/proximityPlacementGroups/[^/]+$`
+	// Capacity reservation group resource ID pattern.
+	capacityReservationGroupIDPattern = `(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/capacityReservationGroups/[^/]+$`
+	// azureClusterTimeout is the maximum time the webhooks wait for the AzureCluster of a machine.
+	azureClusterTimeout = 2 * time.Second
+	// vmSizeCapabilitiesTimeout is the maximum time the webhooks wait for the capabilities of a VM size, it leaves the
+	// rest of the default 10 seconds timeout of the admission webhooks to the other validations.
+	vmSizeCapabilitiesTimeout = 5 * time.Second
+)
+
+var (
+	dedicatedHostGroupIDRegex       = regexp.MustCompile(dedicatedHostGroupIDPattern)
+	dedicatedHostIDRegex            = regexp.MustCompile(dedicatedHostIDPattern)
+	proximityPlacementGroupIDRegex  = regexp.MustCompile(proximityPlacementGroupIDPattern)
+	capacityReservationGroupIDRegex = regexp.MustCompile(capacityReservationGroupIDPattern)
+)
+
+// VMSizeCapabilities are the capabilities of a VM size the security profile of a virtual machine is validated against.
+type VMSizeCapabilities struct {
+	// TrustedLaunch is true if the VM size supports Trusted Launch.
+	TrustedLaunch bool
+	// ConfidentialVM is true if the VM size supports Confidential VM.
+	ConfidentialVM bool
+}
+
+// VMSizeCapabilitiesGetter gets the capabilities of the VM sizes of the location of a cluster.
+// +kubebuilder:object:generate=false
+type VMSizeCapabilitiesGetter interface {
+	// GetVMSizeCapabilities returns the capabilities of a VM size in the location of the AzureCluster of a cluster.
+	GetVMSizeCapabilities(ctx context.Context, namespace, clusterName, vmSize string) (VMSizeCapabilities, error)
+}
+
+// ClientWithVMSizeCapabilities is a client which also gets the capabilities of VM sizes. The AzureMachine and
+// AzureMachinePool webhooks only validate the security profiles of the virtual machines against the capabilities of
+// their VM size when they are registered with one.
+// +kubebuilder:object:generate=false
+type ClientWithVMSizeCapabilities struct {
+	client.Client
+	VMSizeCapabilitiesGetter
+}
+
 // ValidateAzureMachineSpec check for validation errors of azuremachine.spec.
 func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 	var allErrs field.ErrorList
@@ -62,6 +118,239 @@ func ValidateAzureMachineSpec(spec AzureMachineSpec) field.ErrorList {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := ValidateSecurityProfile(spec.SecurityProfile, spec.OSDisk, field.NewPath("securityProfile"), field.NewPath("osDisk")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := ValidateDedicatedHost(spec.HostGroupID, spec.HostID, spec.SpotVMOptions, field.NewPath("hostGroupID"), field.NewPath("hostID")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	onDedicatedHost := spec.HostGroupID != "" || spec.HostID != ""
+	if errs := ValidatePlacementGroups(spec.ProximityPlacementGroupID, spec.CapacityReservationGroupID, onDedicatedHost, spec.SpotVMOptions, field.NewPath("proximityPlacementGroupID"), field.NewPath("capacityReservationGroupID")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
+}
+
+// ValidateDedicatedHost validates the dedicated host group or dedicated host a virtual machine or scale set is placed on.
+func ValidateDedicatedHost(hostGroupID, hostID string, spotVMOptions *SpotVMOptions, hostGroupIDPath, hostIDPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if hostGroupID != "" && !dedicatedHostGroupIDRegex.MatchString(hostGroupID) {
+		allErrs = append(allErrs, field.Invalid(hostGroupIDPath, hostGroupID,
+			fmt.Sprintf("dedicated host group ID doesn't match regex %s", dedicatedHostGroupIDPattern)))
+	}
+
+	if hostID != "" && !dedicatedHostIDRegex.MatchString(hostID) {
+		allErrs = append(allErrs, field.Invalid(hostIDPath, hostID,
+			fmt.Sprintf("dedicated host ID doesn't match regex %s", dedicatedHostIDPattern)))
+	}
+
+	if hostGroupID != "" && hostID != "" {
+		allErrs = append(allErrs, field.Forbidden(hostIDPath, "hostID cannot be set with hostGroupID"))
+	}
+
+	if (hostGroupID != "" || hostID != "") && spotVMOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spotVMOptions"), "spot VMs cannot be placed on a dedicated host"))
+	}
+
+	return allErrs
+}
+
+// GetDedicatedHostGroupZone returns the availability zone of a dedicated host group managed by the AzureCluster of a
+// cluster, or an empty string if the cluster does not manage the group, the group spans all the availability zones of
+// the region or the AzureCluster cannot be found.
+func GetDedicatedHostGroupZone(cli client.Client, namespace, clusterName, hostGroupID string) string {
+	match := dedicatedHostGroupIDRegex.FindStringSubmatch(hostGroupID)
+	if match == nil {
+		return ""
+	}
+	subscriptionID, resourceGroup, name := match[1], match[2], match[3]
+
+	azureCluster := getAzureCluster(cli, namespace, clusterName)
+	if azureCluster == nil {
+		// The zone is validated again when the virtual machine or scale set is created.
+		return ""
+	}
+
+	// The subscription of the AzureCluster is often defaulted from the credentials of the manager and left empty.
+	if azureCluster.Spec.SubscriptionID != "" && !strings.EqualFold(subscriptionID, azureCluster.Spec.SubscriptionID) {
+		return ""
+	}
+	if !strings.EqualFold(resourceGroup, azureCluster.Spec.ResourceGroup) {
+		return ""
+	}
+	for _, group := range azureCluster.Spec.DedicatedHostGroups {
+		if strings.EqualFold(name, group.Name) {
+			return pointer.StringDeref(group.FailureDomain, "")
+		}
+	}
+
+	return ""
+}
+
+// getAzureCluster returns the AzureCluster the infrastructure reference of a cluster points to, or nil if it cannot
+// be found.
+func getAzureCluster(cli client.Client, namespace, clusterName string) *AzureCluster {
+	if cli == nil || clusterName == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), azureClusterTimeout)
+	defer cancel()
+
+	cluster := &clusterv1.Cluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, cluster); err != nil {
+		return nil
+	}
+	ref := cluster.Spec.InfrastructureRef
+	if ref == nil || ref.Kind != "AzureCluster" {
+		return nil
+	}
+
+	azureCluster := &AzureCluster{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, azureCluster); err != nil {
+		return nil
+	}
+	return azureCluster
+}
+
+// DedicatedHostGroupIDFromHostID returns the resource ID of the dedicated host group of a dedicated host.
+func DedicatedHostGroupIDFromHostID(hostID string) string {
+	// The resource ID of a dedicated host is nested in the resource ID of its group.
+	if i := strings.LastIndex(strings.ToLower(hostID), "/hosts/"); i > 0 {
+		return hostID[:i]
+	}
+	return ""
+}
+
+// ValidatePlacementGroups validates the proximity placement group and the capacity reservation group a virtual machine
+// or scale set is placed in. Capacity reservations cannot be used for VMs in a proximity placement group, on a
+// dedicated host or with spot VMs.
+func ValidatePlacementGroups(proximityPlacementGroupID, capacityReservationGroupID string, onDedicatedHost bool, spotVMOptions *SpotVMOptions, proximityPlacementGroupIDPath, capacityReservationGroupIDPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if proximityPlacementGroupID != "" && !proximityPlacementGroupIDRegex.MatchString(proximityPlacementGroupID) {
+		allErrs = append(allErrs, field.Invalid(proximityPlacementGroupIDPath, proximityPlacementGroupID,
+			fmt.Sprintf("proximity placement group ID doesn't match regex %s", proximityPlacementGroupIDPattern)))
+	}
+
+	if capacityReservationGroupID == "" {
+		return allErrs
+	}
+
+	if !capacityReservationGroupIDRegex.MatchString(capacityReservationGroupID) {
+		allErrs = append(allErrs, field.Invalid(capacityReservationGroupIDPath, capacityReservationGroupID,
+			fmt.Sprintf("capacity reservation group ID doesn't match regex %s", capacityReservationGroupIDPattern)))
+	}
+
+	if proximityPlacementGroupID != "" {
+		allErrs = append(allErrs, field.Forbidden(capacityReservationGroupIDPath, "capacityReservationGroupID cannot be set with proximityPlacementGroupID"))
+	}
+
+	if onDedicatedHost {
+		allErrs = append(allErrs, field.Forbidden(capacityReservationGroupIDPath, "VMs placed on a dedicated host cannot be allocated from a capacity reservation group"))
+	}
+
+	if spotVMOptions != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spotVMOptions"), "spot VMs cannot be allocated from a capacity reservation group"))
+	}
+
+	return allErrs
+}
+
+// ValidateVMSizeCapabilities validates the security type of a virtual machine against the capabilities of its VM size,
+// when cli is a ClientWithVMSizeCapabilities. The security type is not validated when the capabilities cannot be
+// determined, it is validated again when the virtual machine is created.
+func ValidateVMSizeCapabilities(cli client.Client, namespace, clusterName, vmSize string, securityProfile *SecurityProfile, fieldPath *field.Path) field.ErrorList {
+	getter, ok := cli.(VMSizeCapabilitiesGetter)
+	if !ok || securityProfile == nil || securityProfile.SecurityType == "" || clusterName == "" || vmSize == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), vmSizeCapabilitiesTimeout)
+	defer cancel()
+
+	capabilities, err := getter.GetVMSizeCapabilities(ctx, namespace, clusterName, vmSize)
+	if err != nil {
+		ctrl.Log.WithName("ValidateVMSizeCapabilities").Info("WARNING, skipping the validation of the security type against the capabilities of the VM size, it is validated when the virtual machine is created",
+			"namespace", namespace, "cluster", clusterName, "vmSize", vmSize, "securityType", securityProfile.SecurityType, "error", err.Error())
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	switch securityProfile.SecurityType {
+	case SecurityTypesTrustedLaunch:
+		if !capabilities.TrustedLaunch {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("securityType"), securityProfile.SecurityType,
+				fmt.Sprintf("trusted launch is not supported for VM size %s", vmSize)))
+		}
+	case SecurityTypesConfidentialVM:
+		if !capabilities.ConfidentialVM {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("securityType"), securityProfile.SecurityType,
+				fmt.Sprintf("confidential VM is not supported for VM size %s", vmSize)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateSecurityProfile validates the security type and UEFI settings of a virtual machine
+// against the security profile of its OS disk.
+func ValidateSecurityProfile(securityProfile *SecurityProfile, osDisk OSDisk, fieldPath, osDiskFieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var diskProfile *VMDiskSecurityProfile
+	if osDisk.ManagedDisk != nil {
+		diskProfile = osDisk.ManagedDisk.SecurityProfile
+	}
+	diskFieldPath := osDiskFieldPath.Child("managedDisk", "securityProfile")
+
+	var securityType SecurityTypes
+	var uefiSettings *UefiSettings
+	if securityProfile != nil {
+		securityType = securityProfile.SecurityType
+		uefiSettings = securityProfile.UefiSettings
+	}
+
+	if securityType == "" && uefiSettings != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("uefiSettings"), "uefiSettings can be set only if securityType is set"))
+	}
+
+	if securityType != SecurityTypesConfidentialVM {
+		if diskProfile != nil && diskProfile.SecurityEncryptionType != "" {
+			allErrs = append(allErrs, field.Forbidden(diskFieldPath.Child("securityEncryptionType"), "securityEncryptionType can be set only if securityType is ConfidentialVM"))
+		}
+	} else {
+		if diskProfile == nil || diskProfile.SecurityEncryptionType == "" {
+			allErrs = append(allErrs, field.Required(diskFieldPath.Child("securityEncryptionType"), "securityEncryptionType must be set if securityType is ConfidentialVM"))
+		}
+
+		if uefiSettings == nil || uefiSettings.VTpmEnabled == nil || !*uefiSettings.VTpmEnabled {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "vTpmEnabled"), uefiSettings, "vTpmEnabled must be true if securityType is ConfidentialVM"))
+		}
+
+		if diskProfile != nil && diskProfile.SecurityEncryptionType == SecurityEncryptionTypeDiskWithVMGuestState {
+			if uefiSettings == nil || uefiSettings.SecureBootEnabled == nil || !*uefiSettings.SecureBootEnabled {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("uefiSettings", "secureBootEnabled"), uefiSettings, "secureBootEnabled must be true if securityEncryptionType is DiskWithVMGuestState"))
+			}
+
+			if securityProfile.EncryptionAtHost != nil && *securityProfile.EncryptionAtHost {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("encryptionAtHost"), *securityProfile.EncryptionAtHost, "encryptionAtHost cannot be enabled if securityEncryptionType is DiskWithVMGuestState"))
+			}
+		}
+	}
+
+	if diskProfile != nil && diskProfile.DiskEncryptionSet != nil && diskProfile.SecurityEncryptionType != SecurityEncryptionTypeDiskWithVMGuestState {
+		allErrs = append(allErrs, field.Forbidden(diskFieldPath.Child("diskEncryptionSet"), "diskEncryptionSet can be set only if securityEncryptionType is DiskWithVMGuestState"))
+	}
+
+	if diskProfile != nil && osDisk.DiffDiskSettings != nil {
+		allErrs = append(allErrs, field.Forbidden(diskFieldPath, "securityProfile is not supported when diffDiskSettings.option is 'Local'"))
+	}
+
 	return allErrs
 }
 
@@ -234,6 +523,10 @@ func validateManagedDisk(m *ManagedDiskParameters, fieldPath *field.Path, isOSDi
 
 	if m != nil {
 		allErrs = append(allErrs, validateStorageAccountType(m.StorageAccountType, fieldPath.Child("StorageAccountType"), isOSDisk)...)
+
+		if m.SecurityProfile != nil && !isOSDisk {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("securityProfile"), "securityProfile can be set only on the OS disk"))
+		}
 	}
 
 	return allErrs
@@ -259,29 +552,81 @@ func ValidateDataDisksUpdate(oldDataDisks, newDataDisks []DataDisk, fieldPath *f
 
 	for i, newDisk := range newDataDisks {
 		if oldDisk, ok := oldDisks[newDisk.NameSuffix]; ok {
-			if newDisk.DiskSizeGB != oldDisk.DiskSizeGB {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("diskSizeGB"), newDataDisks, fieldErrMsg))
-			}
+			allErrs = append(allErrs, validateDataDiskUpdate(oldDisk, newDisk, newDataDisks, fieldPath.Index(i), fieldErrMsg)...)
+		} else {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("nameSuffix"), newDataDisks, diskErrMsg))
+		}
+	}
 
-			allErrs = append(allErrs, validateManagedDisksUpdate(oldDisk.ManagedDisk, newDisk.ManagedDisk, fieldPath.Index(i).Child("managedDisk"))...)
+	return allErrs
+}
 
-			if (newDisk.Lun != nil && oldDisk.Lun != nil) && (*newDisk.Lun != *oldDisk.Lun) {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("lun"), newDataDisks, fieldErrMsg))
-			} else if (newDisk.Lun != nil && oldDisk.Lun == nil) || (newDisk.Lun == nil && oldDisk.Lun != nil) {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("lun"), newDataDisks, fieldErrMsg))
-			}
+// ValidateDataDisksInPlaceUpdate validates updates to Data disks of a machine using the InPlace update strategy.
+// Data disks can be added or removed, but the disks that are kept cannot be changed. Since the managed disks of the
+// removed data disks are deleted once detached, removing a data disk requires the DeleteRemovedDataDisksAnnotation,
+// and a removed data disk cannot be added back until its managed disk is deleted.
+func ValidateDataDisksInPlaceUpdate(oldDataDisks, newDataDisks []DataDisk, annotations map[string]string, fieldPath *field.Path) field.ErrorList {
+	allErrs := ValidateDataDisks(newDataDisks, fieldPath)
 
-			if newDisk.CachingType != oldDisk.CachingType {
-				allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("cachingType"), newDataDisks, fieldErrMsg))
-			}
-		} else {
-			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("nameSuffix"), newDataDisks, diskErrMsg))
+	fieldErrMsg := "modifying the fields of an attached data disk is not allowed"
+
+	oldDisks := make(map[string]DataDisk)
+
+	for _, disk := range oldDataDisks {
+		oldDisks[disk.NameSuffix] = disk
+	}
+
+	// The data disks attached by the controller whose managed disk may still exist, see DataDisksLastAppliedAnnotation.
+	lastApplied := map[string]interface{}{}
+	if annotation, ok := annotations[DataDisksLastAppliedAnnotation]; ok {
+		if err := json.Unmarshal([]byte(annotation), &lastApplied); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations", DataDisksLastAppliedAnnotation), annotation, err.Error()))
+		}
+	}
+
+	newDisks := make(map[string]struct{})
+	for i, newDisk := range newDataDisks {
+		newDisks[newDisk.NameSuffix] = struct{}{}
+		if oldDisk, ok := oldDisks[newDisk.NameSuffix]; ok {
+			allErrs = append(allErrs, validateDataDiskUpdate(oldDisk, newDisk, newDataDisks, fieldPath.Index(i), fieldErrMsg)...)
+		} else if _, ok := lastApplied[newDisk.NameSuffix]; ok {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("nameSuffix"),
+				fmt.Sprintf("the managed disk of the removed data disk %q is not deleted yet, use another nameSuffix", newDisk.NameSuffix)))
+		}
+	}
+
+	for _, oldDisk := range oldDataDisks {
+		if _, ok := newDisks[oldDisk.NameSuffix]; !ok && annotations[DeleteRemovedDataDisksAnnotation] != "true" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath,
+				fmt.Sprintf("removing the data disk %q deletes its managed disk, set the %s annotation to \"true\" to confirm it", oldDisk.NameSuffix, DeleteRemovedDataDisksAnnotation)))
 		}
 	}
 
 	return allErrs
 }
 
+func validateDataDiskUpdate(oldDisk, newDisk DataDisk, newDataDisks []DataDisk, fieldPath *field.Path, fieldErrMsg string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if newDisk.DiskSizeGB != oldDisk.DiskSizeGB {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("diskSizeGB"), newDataDisks, fieldErrMsg))
+	}
+
+	allErrs = append(allErrs, validateManagedDisksUpdate(oldDisk.ManagedDisk, newDisk.ManagedDisk, fieldPath.Child("managedDisk"))...)
+
+	if (newDisk.Lun != nil && oldDisk.Lun != nil) && (*newDisk.Lun != *oldDisk.Lun) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("lun"), newDataDisks, fieldErrMsg))
+	} else if (newDisk.Lun != nil && oldDisk.Lun == nil) || (newDisk.Lun == nil && oldDisk.Lun != nil) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("lun"), newDataDisks, fieldErrMsg))
+	}
+
+	if newDisk.CachingType != oldDisk.CachingType {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("cachingType"), newDataDisks, fieldErrMsg))
+	}
+
+	return allErrs
+}
+
 func validateManagedDisksUpdate(oldDiskParams, newDiskParams *ManagedDiskParameters, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	fieldErrMsg := "changing managed disk options after machine creation is not allowed"
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/base64"
@@ -26,6 +27,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"golang.org/x/crypto/ssh"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
@@ -226,6 +228,387 @@ func createOSDiskWithCacheType(cacheType string) OSDisk {
 	return osDisk
 }
 
+func TestAzureMachine_ValidateSecurityProfile(t *testing.T) {
+	g := NewWithT(t)
+
+	confidentialOSDisk := func(encryptionType SecurityEncryptionType) OSDisk {
+		return OSDisk{
+			OSType: "Linux",
+			ManagedDisk: &ManagedDiskParameters{
+				StorageAccountType: "Premium_LRS",
+				SecurityProfile: &VMDiskSecurityProfile{
+					SecurityEncryptionType: encryptionType,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		securityProfile *SecurityProfile
+		osDisk          OSDisk
+		wantErr         bool
+	}{
+		{
+			name:            "valid nil security profile",
+			securityProfile: nil,
+			osDisk:          generateValidOSDisk(),
+		},
+		{
+			name: "valid trusted launch",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(true),
+					VTpmEnabled:       pointer.Bool(true),
+				},
+			},
+			osDisk: generateValidOSDisk(),
+		},
+		{
+			name: "valid confidential VM with VMGuestStateOnly",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{
+					VTpmEnabled: pointer.Bool(true),
+				},
+			},
+			osDisk: confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+		},
+		{
+			name: "valid confidential VM with DiskWithVMGuestState",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(true),
+					VTpmEnabled:       pointer.Bool(true),
+				},
+			},
+			osDisk: confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+		},
+		{
+			name: "uefi settings without security type",
+			securityProfile: &SecurityProfile{
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(true),
+				},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: true,
+		},
+		{
+			name: "security encryption type with trusted launch",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesTrustedLaunch,
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM without security encryption type",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{
+					VTpmEnabled: pointer.Bool(true),
+				},
+			},
+			osDisk:  generateValidOSDisk(),
+			wantErr: true,
+		},
+		{
+			name: "confidential VM without vTPM",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeVMGuestStateOnly),
+			wantErr: true,
+		},
+		{
+			name: "DiskWithVMGuestState without secure boot",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{
+					VTpmEnabled: pointer.Bool(true),
+				},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			wantErr: true,
+		},
+		{
+			name: "DiskWithVMGuestState with encryption at host",
+			securityProfile: &SecurityProfile{
+				EncryptionAtHost: pointer.Bool(true),
+				SecurityType:     SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{
+					SecureBootEnabled: pointer.Bool(true),
+					VTpmEnabled:       pointer.Bool(true),
+				},
+			},
+			osDisk:  confidentialOSDisk(SecurityEncryptionTypeDiskWithVMGuestState),
+			wantErr: true,
+		},
+		{
+			name: "disk encryption set with VMGuestStateOnly",
+			securityProfile: &SecurityProfile{
+				SecurityType: SecurityTypesConfidentialVM,
+				UefiSettings: &UefiSettings{
+					VTpmEnabled: pointer.Bool(true),
+				},
+			},
+			osDisk: OSDisk{
+				OSType: "Linux",
+				ManagedDisk: &ManagedDiskParameters{
+					StorageAccountType: "Premium_LRS",
+					SecurityProfile: &VMDiskSecurityProfile{
+						SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+						DiskEncryptionSet:      &DiskEncryptionSetParameters{ID: "disk-encryption-set"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSecurityProfile(tc.securityProfile, tc.osDisk, field.NewPath("securityProfile"), field.NewPath("osDisk"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+type fakeVMSizeCapabilitiesGetter struct {
+	capabilities map[string]VMSizeCapabilities
+}
+
+func (f fakeVMSizeCapabilitiesGetter) GetVMSizeCapabilities(_ context.Context, _, _, vmSize string) (VMSizeCapabilities, error) {
+	capabilities, ok := f.capabilities[vmSize]
+	if !ok {
+		return VMSizeCapabilities{}, errors.Errorf("unknown VM size %s", vmSize)
+	}
+	return capabilities, nil
+}
+
+func TestAzureMachine_ValidateVMSizeCapabilities(t *testing.T) {
+	g := NewWithT(t)
+
+	cli := ClientWithVMSizeCapabilities{
+		VMSizeCapabilitiesGetter: fakeVMSizeCapabilitiesGetter{
+			capabilities: map[string]VMSizeCapabilities{
+				"Standard_D2s_v3":   {TrustedLaunch: true},
+				"Standard_DC2as_v5": {TrustedLaunch: true, ConfidentialVM: true},
+				"Standard_A2_v2":    {},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		clusterName     string
+		vmSize          string
+		securityProfile *SecurityProfile
+		wantErr         bool
+	}{
+		{
+			name:            "valid nil security profile",
+			clusterName:     "my-cluster",
+			vmSize:          "Standard_A2_v2",
+			securityProfile: nil,
+		},
+		{
+			name:            "valid trusted launch",
+			clusterName:     "my-cluster",
+			vmSize:          "Standard_D2s_v3",
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesTrustedLaunch},
+		},
+		{
+			name:            "invalid trusted launch not supported by the VM size",
+			clusterName:     "my-cluster",
+			vmSize:          "Standard_A2_v2",
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesTrustedLaunch},
+			wantErr:         true,
+		},
+		{
+			name:            "valid confidential VM",
+			clusterName:     "my-cluster",
+			vmSize:          "Standard_DC2as_v5",
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesConfidentialVM},
+		},
+		{
+			name:            "invalid confidential VM not supported by the VM size",
+			clusterName:     "my-cluster",
+			vmSize:          "Standard_D2s_v3",
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesConfidentialVM},
+			wantErr:         true,
+		},
+		{
+			name:            "valid confidential VM with unknown VM size capabilities",
+			clusterName:     "my-cluster",
+			vmSize:          "Standard_Unknown",
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesConfidentialVM},
+		},
+		{
+			name:            "valid confidential VM without cluster",
+			vmSize:          "Standard_D2s_v3",
+			securityProfile: &SecurityProfile{SecurityType: SecurityTypesConfidentialVM},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateVMSizeCapabilities(cli, "default", tc.clusterName, tc.vmSize, tc.securityProfile, field.NewPath("securityProfile"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateDedicatedHost(t *testing.T) {
+	g := NewWithT(t)
+
+	hostGroupID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+	hostID := hostGroupID + "/hosts/my-host"
+
+	tests := []struct {
+		name          string
+		hostGroupID   string
+		hostID        string
+		spotVMOptions *SpotVMOptions
+		wantErr       bool
+	}{
+		{
+			name:    "valid without dedicated host",
+			wantErr: false,
+		},
+		{
+			name:        "valid dedicated host group",
+			hostGroupID: hostGroupID,
+			wantErr:     false,
+		},
+		{
+			name:    "valid dedicated host",
+			hostID:  hostID,
+			wantErr: false,
+		},
+		{
+			name:        "invalid dedicated host group ID",
+			hostGroupID: hostID,
+			wantErr:     true,
+		},
+		{
+			name:    "invalid dedicated host ID",
+			hostID:  hostGroupID,
+			wantErr: true,
+		},
+		{
+			name:        "invalid dedicated host group and dedicated host",
+			hostGroupID: hostGroupID,
+			hostID:      hostID,
+			wantErr:     true,
+		},
+		{
+			name:          "invalid spot VM on a dedicated host",
+			hostID:        hostID,
+			spotVMOptions: &SpotVMOptions{},
+			wantErr:       true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDedicatedHost(tc.hostGroupID, tc.hostID, tc.spotVMOptions, field.NewPath("hostGroupID"), field.NewPath("hostID"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidatePlacementGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	proximityPlacementGroupID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+	capacityReservationGroupID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"
+
+	tests := []struct {
+		name                       string
+		proximityPlacementGroupID  string
+		capacityReservationGroupID string
+		onDedicatedHost            bool
+		spotVMOptions              *SpotVMOptions
+		wantErr                    bool
+	}{
+		{
+			name:    "valid without placement groups",
+			wantErr: false,
+		},
+		{
+			name:                      "valid proximity placement group",
+			proximityPlacementGroupID: proximityPlacementGroupID,
+			wantErr:                   false,
+		},
+		{
+			name:                       "valid capacity reservation group",
+			capacityReservationGroupID: capacityReservationGroupID,
+			wantErr:                    false,
+		},
+		{
+			name:                      "valid spot VM on a dedicated host in a proximity placement group",
+			proximityPlacementGroupID: proximityPlacementGroupID,
+			onDedicatedHost:           true,
+			spotVMOptions:             &SpotVMOptions{},
+			wantErr:                   false,
+		},
+		{
+			name:                      "invalid proximity placement group ID",
+			proximityPlacementGroupID: capacityReservationGroupID,
+			wantErr:                   true,
+		},
+		{
+			name:                       "invalid capacity reservation group ID",
+			capacityReservationGroupID: proximityPlacementGroupID,
+			wantErr:                    true,
+		},
+		{
+			name:                       "invalid capacity reservation group with proximity placement group",
+			proximityPlacementGroupID:  proximityPlacementGroupID,
+			capacityReservationGroupID: capacityReservationGroupID,
+			wantErr:                    true,
+		},
+		{
+			name:                       "invalid capacity reservation group on a dedicated host",
+			capacityReservationGroupID: capacityReservationGroupID,
+			onDedicatedHost:            true,
+			wantErr:                    true,
+		},
+		{
+			name:                       "invalid spot VM allocated from a capacity reservation group",
+			capacityReservationGroupID: capacityReservationGroupID,
+			spotVMOptions:              &SpotVMOptions{},
+			wantErr:                    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePlacementGroups(tc.proximityPlacementGroupID, tc.capacityReservationGroupID, tc.onDedicatedHost, tc.spotVMOptions,
+				field.NewPath("proximityPlacementGroupID"), field.NewPath("capacityReservationGroupID"))
+			if tc.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 	g := NewWithT(t)
 
@@ -262,6 +645,24 @@ func TestAzureMachine_ValidateDataDisks(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "security profile on a data disk",
+			disks: []DataDisk{
+				{
+					NameSuffix:  "my_disk",
+					DiskSizeGB:  64,
+					Lun:         pointer.Int32(0),
+					CachingType: string(compute.PossibleCachingTypesValues()[0]),
+					ManagedDisk: &ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &VMDiskSecurityProfile{
+							SecurityEncryptionType: SecurityEncryptionTypeVMGuestStateOnly,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "duplicate names",
 			disks: []DataDisk{
@@ -776,6 +1177,118 @@ func TestAzureMachine_ValidateDataDisksUpdate(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateDataDisksInPlaceUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	etcdDisk := DataDisk{
+		NameSuffix: "etcddisk",
+		DiskSizeGB: 256,
+		Lun:        pointer.Int32(0),
+		ManagedDisk: &ManagedDiskParameters{
+			StorageAccountType: "Premium_LRS",
+		},
+		CachingType: string(compute.CachingTypesReadWrite),
+	}
+	newDisk := DataDisk{
+		NameSuffix:  "newdisk",
+		DiskSizeGB:  128,
+		Lun:         pointer.Int32(1),
+		CachingType: string(compute.CachingTypesReadWrite),
+	}
+
+	confirmed := map[string]string{DeleteRemovedDataDisksAnnotation: "true"}
+
+	tests := []struct {
+		name        string
+		disks       []DataDisk
+		oldDisks    []DataDisk
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{
+			name:     "valid unchanged data disks",
+			disks:    []DataDisk{etcdDisk},
+			oldDisks: []DataDisk{etcdDisk},
+			wantErr:  false,
+		},
+		{
+			name:     "valid data disk added",
+			disks:    []DataDisk{etcdDisk, newDisk},
+			oldDisks: []DataDisk{etcdDisk},
+			wantErr:  false,
+		},
+		{
+			name:        "valid data disk removed with the confirmation annotation",
+			disks:       []DataDisk{newDisk},
+			oldDisks:    []DataDisk{etcdDisk, newDisk},
+			annotations: confirmed,
+			wantErr:     false,
+		},
+		{
+			name:     "invalid data disk removed without the confirmation annotation",
+			disks:    []DataDisk{newDisk},
+			oldDisks: []DataDisk{etcdDisk, newDisk},
+			wantErr:  true,
+		},
+		{
+			name:     "valid data disk added back once its managed disk is deleted",
+			disks:    []DataDisk{etcdDisk, newDisk},
+			oldDisks: []DataDisk{etcdDisk},
+			annotations: map[string]string{
+				DataDisksLastAppliedAnnotation: `{"etcddisk":"my-vm_etcddisk"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid data disk added back before its managed disk is deleted",
+			disks:    []DataDisk{etcdDisk, newDisk},
+			oldDisks: []DataDisk{etcdDisk},
+			annotations: map[string]string{
+				DataDisksLastAppliedAnnotation: `{"etcddisk":"my-vm_etcddisk","newdisk":"my-vm_newdisk"}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid data disk resized",
+			disks: []DataDisk{
+				{
+					NameSuffix:  etcdDisk.NameSuffix,
+					DiskSizeGB:  512,
+					Lun:         etcdDisk.Lun,
+					ManagedDisk: etcdDisk.ManagedDisk,
+					CachingType: etcdDisk.CachingType,
+				},
+			},
+			oldDisks: []DataDisk{etcdDisk},
+			wantErr:  true,
+		},
+		{
+			name: "invalid data disk added with a LUN in use",
+			disks: []DataDisk{
+				etcdDisk,
+				{
+					NameSuffix:  newDisk.NameSuffix,
+					DiskSizeGB:  newDisk.DiskSizeGB,
+					Lun:         etcdDisk.Lun,
+					CachingType: newDisk.CachingType,
+				},
+			},
+			oldDisks: []DataDisk{etcdDisk},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDataDisksInPlaceUpdate(test.oldDisks, test.disks, test.annotations, field.NewPath("dataDisks"))
+			if test.wantErr {
+				g.Expect(err).NotTo(BeEmpty())
+			} else {
+				g.Expect(err).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestAzureMachine_ValidateNetwork(t *testing.T) {
 	g := NewWithT(t)
 
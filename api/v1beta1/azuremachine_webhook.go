@@ -17,12 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -44,6 +46,13 @@ func (m *AzureMachine) ValidateCreate(client client.Client) error {
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, ValidateVMSizeCapabilities(client, m.Namespace, m.Labels[clusterv1.ClusterLabelName],
+		spec.VMSize, spec.SecurityProfile, field.NewPath("securityProfile"))...)
+
+	allErrs = append(allErrs, m.validateDedicatedHostGroupZone(client, field.NewPath("failureDomain"))...)
+
+	allErrs = append(allErrs, m.validateApplicationSecurityGroups(client, field.NewPath("applicationSecurityGroups"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -98,7 +107,10 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object, client client.Clien
 		allErrs = append(allErrs, err)
 	}
 
-	if err := webhookutils.ValidateImmutable(
+	// Data disks can be attached and detached in place, but are otherwise immutable.
+	if m.Spec.UpdateStrategy == AzureMachineUpdateStrategyInPlace {
+		allErrs = append(allErrs, ValidateDataDisksInPlaceUpdate(old.Spec.DataDisks, m.Spec.DataDisks, m.Annotations, field.NewPath("Spec", "DataDisks"))...)
+	} else if err := webhookutils.ValidateImmutable(
 		field.NewPath("Spec", "DataDisks"),
 		old.Spec.DataDisks,
 		m.Spec.DataDisks); err != nil {
@@ -147,6 +159,41 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object, client client.Clien
 		allErrs = append(allErrs, err)
 	}
 
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ApplicationSecurityGroups"),
+		old.Spec.ApplicationSecurityGroups,
+		m.Spec.ApplicationSecurityGroups); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "HostGroupID"),
+		old.Spec.HostGroupID,
+		m.Spec.HostGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "HostID"),
+		old.Spec.HostID,
+		m.Spec.HostID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "ProximityPlacementGroupID"),
+		old.Spec.ProximityPlacementGroupID,
+		m.Spec.ProximityPlacementGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := webhookutils.ValidateImmutable(
+		field.NewPath("Spec", "CapacityReservationGroupID"),
+		old.Spec.CapacityReservationGroupID,
+		m.Spec.CapacityReservationGroupID); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if old.Spec.Diagnostics != nil {
 		if err := webhookutils.ValidateImmutable(
 			field.NewPath("Spec", "Diagnostics"),
@@ -156,6 +203,16 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object, client client.Clien
 		}
 	}
 
+	if !reflect.DeepEqual(m.Spec.FailureDomain, old.Spec.FailureDomain) {
+		allErrs = append(allErrs, m.validateDedicatedHostGroupZone(client, field.NewPath("Spec", "FailureDomain"))...)
+	}
+
+	// The VM size can change with in-place updates, so the security profile must remain supported by the new VM size.
+	if m.Spec.VMSize != old.Spec.VMSize {
+		allErrs = append(allErrs, ValidateVMSizeCapabilities(client, m.Namespace, m.Labels[clusterv1.ClusterLabelName],
+			m.Spec.VMSize, m.Spec.SecurityProfile, field.NewPath("Spec", "SecurityProfile"))...)
+	}
+
 	if !reflect.DeepEqual(m.Spec.NetworkInterfaces, old.Spec.NetworkInterfaces) {
 		// The defaulting webhook may have migrated values from the old SubnetName field to the new NetworkInterfaces format.
 		old.Spec.SetNetworkInterfacesDefaults()
@@ -180,6 +237,57 @@ func (m *AzureMachine) ValidateUpdate(oldRaw runtime.Object, client client.Clien
 	return apierrors.NewInvalid(GroupVersion.WithKind("AzureMachine").GroupKind(), m.Name, allErrs)
 }
 
+// validateDedicatedHostGroupZone validates that the failure domain of an AzureMachine placed in a dedicated host group
+// managed by the cluster matches the availability zone of the group.
+func (m *AzureMachine) validateDedicatedHostGroupZone(client client.Client, fldPath *field.Path) field.ErrorList {
+	if m.Spec.FailureDomain == nil {
+		return nil
+	}
+
+	hostGroupID := m.Spec.HostGroupID
+	if m.Spec.HostID != "" {
+		hostGroupID = DedicatedHostGroupIDFromHostID(m.Spec.HostID)
+	}
+	zone := GetDedicatedHostGroupZone(client, m.Namespace, m.Labels[clusterv1.ClusterLabelName], hostGroupID)
+	if zone == "" || *m.Spec.FailureDomain == zone {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(fldPath, *m.Spec.FailureDomain,
+		fmt.Sprintf("failure domain does not match the availability zone %s of the dedicated host group", zone))}
+}
+
+// validateApplicationSecurityGroups validates that the application security groups of the machine are created for its
+// cluster, i.e. are the control plane or node application security group or one of the custom application security
+// groups of the AzureCluster.
+func (m *AzureMachine) validateApplicationSecurityGroups(client client.Client, fldPath *field.Path) field.ErrorList {
+	if len(m.Spec.ApplicationSecurityGroups) == 0 {
+		return nil
+	}
+
+	azureCluster := getAzureCluster(client, m.Namespace, m.Labels[clusterv1.ClusterLabelName])
+	if azureCluster == nil {
+		// The network interfaces of the machine fail to join an application security group that does not exist.
+		return nil
+	}
+
+	asgNames := azureCluster.applicationSecurityGroupNames()
+	var allErrs field.ErrorList
+	for i, name := range m.Spec.ApplicationSecurityGroups {
+		found := false
+		for _, asgName := range asgNames {
+			if name == asgName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(fldPath.Index(i), name))
+		}
+	}
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (m *AzureMachine) ValidateDelete(client client.Client) error {
 	return nil
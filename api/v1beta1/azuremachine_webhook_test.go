@@ -22,9 +22,14 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -419,6 +424,56 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "validTest: azuremachine.spec.DataDisks can be added with the InPlace update strategy",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					UpdateStrategy: AzureMachineUpdateStrategyInPlace,
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					UpdateStrategy: AzureMachineUpdateStrategyInPlace,
+					DataDisks: []DataDisk{
+						{
+							NameSuffix:  "newdisk",
+							DiskSizeGB:  128,
+							Lun:         pointer.Int32(0),
+							CachingType: "ReadWrite",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.DataDisks cannot be changed with the InPlace update strategy",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					UpdateStrategy: AzureMachineUpdateStrategyInPlace,
+					DataDisks: []DataDisk{
+						{
+							NameSuffix: "disk",
+							DiskSizeGB: 128,
+							Lun:        pointer.Int32(0),
+						},
+					},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					UpdateStrategy: AzureMachineUpdateStrategyInPlace,
+					DataDisks: []DataDisk{
+						{
+							NameSuffix: "disk",
+							DiskSizeGB: 64,
+							Lun:        pointer.Int32(0),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.SSHPublicKey is immutable",
 			oldMachine: &AzureMachine{
@@ -595,6 +650,86 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalidTest: azuremachine.spec.ApplicationSecurityGroups is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: []string{"ingress-asg"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: []string{"ingress-asg", "monitoring-asg"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "validTest: azuremachine.spec.ApplicationSecurityGroups is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: []string{"ingress-asg"},
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ApplicationSecurityGroups: []string{"ingress-asg"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.HostGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					HostGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-other-host-group",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.HostID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					HostID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group/hosts/my-host",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.ProximityPlacementGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					ProximityPlacementGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalidTest: azuremachine.spec.CapacityReservationGroupID is immutable",
+			oldMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+				},
+			},
+			newMachine: &AzureMachine{
+				Spec: AzureMachineSpec{
+					CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-other-crg",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalidTest: azuremachine.spec.Diagnostics is immutable",
 			oldMachine: &AzureMachine{
@@ -706,6 +841,217 @@ func TestAzureMachine_ValidateUpdate(t *testing.T) {
 	}
 }
 
+func TestAzureMachine_ValidateUpdateVMSizeCapabilities(t *testing.T) {
+	g := NewWithT(t)
+
+	cli := ClientWithVMSizeCapabilities{
+		VMSizeCapabilitiesGetter: fakeVMSizeCapabilitiesGetter{
+			capabilities: map[string]VMSizeCapabilities{
+				"Standard_D2s_v3": {TrustedLaunch: true},
+				"Standard_A2_v2":  {},
+			},
+		},
+	}
+
+	withVMSize := func(vmSize string) *AzureMachine {
+		return &AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+			},
+			Spec: AzureMachineSpec{
+				VMSize:          vmSize,
+				SecurityProfile: &SecurityProfile{SecurityType: SecurityTypesTrustedLaunch},
+			},
+		}
+	}
+
+	g.Expect(withVMSize("Standard_A2_v2").ValidateUpdate(withVMSize("Standard_D2s_v3"), cli)).To(HaveOccurred())
+	g.Expect(withVMSize("Standard_D2s_v3").ValidateUpdate(withVMSize("Standard_A2_v2"), cli)).NotTo(HaveOccurred())
+	// The VM size is only validated when it changes.
+	g.Expect(withVMSize("Standard_A2_v2").ValidateUpdate(withVMSize("Standard_A2_v2"), cli)).NotTo(HaveOccurred())
+}
+
+func TestAzureMachine_ValidateDedicatedHostGroupZone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Kind: "AzureCluster", Name: "my-cluster-abcde"},
+		},
+	}
+	// The subscription of the AzureCluster is left empty to be defaulted from the credentials of the manager.
+	azureCluster := &AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-abcde",
+			Namespace: "default",
+		},
+		Spec: AzureClusterSpec{
+			ResourceGroup: "my-rg",
+			AzureClusterClassSpec: AzureClusterClassSpec{
+				DedicatedHostGroups: []DedicatedHostGroup{
+					{Name: "zonal-group", FailureDomain: pointer.String("1")},
+					{Name: "regional-group"},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster).Build()
+
+	tests := []struct {
+		name          string
+		hostGroupID   string
+		hostID        string
+		failureDomain *string
+		wantErr       bool
+	}{
+		{
+			name:          "failure domain matching the zone of the host group",
+			hostGroupID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/zonal-group",
+			failureDomain: pointer.String("1"),
+		},
+		{
+			name:          "failure domain not matching the zone of the host group",
+			hostGroupID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/zonal-group",
+			failureDomain: pointer.String("2"),
+			wantErr:       true,
+		},
+		{
+			name:          "failure domain not matching the zone of the host group of the host",
+			hostID:        "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/zonal-group/hosts/my-host",
+			failureDomain: pointer.String("2"),
+			wantErr:       true,
+		},
+		{
+			name:          "regional host group",
+			hostGroupID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/regional-group",
+			failureDomain: pointer.String("2"),
+		},
+		{
+			name:          "host group not managed by the cluster",
+			hostGroupID:   "/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Compute/hostGroups/zonal-group",
+			failureDomain: pointer.String("2"),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+				},
+				Spec: AzureMachineSpec{
+					SSHPublicKey:  validSSHPublicKey,
+					OSDisk:        validOSDisk,
+					HostGroupID:   tc.hostGroupID,
+					HostID:        tc.hostID,
+					FailureDomain: tc.failureDomain,
+				},
+			}
+			err := machine.ValidateCreate(fakeClient)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachine_ValidateApplicationSecurityGroups(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Kind: "AzureCluster", Name: "my-cluster-abcde"},
+		},
+	}
+	azureCluster := &AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+		},
+		Spec: AzureClusterSpec{
+			NetworkSpec: NetworkSpec{
+				NetworkClassSpec: NetworkClassSpec{
+					ApplicationSecurityGroups: []string{"ingress-asg"},
+				},
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster).Build()
+
+	tests := []struct {
+		name        string
+		clusterName string
+		asgs        []string
+		wantErr     bool
+	}{
+		{
+			name:        "custom application security group of the cluster",
+			clusterName: "my-cluster",
+			asgs:        []string{"ingress-asg"},
+		},
+		{
+			name:        "node application security group of the cluster",
+			clusterName: "my-cluster",
+			asgs:        []string{"my-cluster-node-asg"},
+		},
+		{
+			name:        "application security group not created for the cluster",
+			clusterName: "my-cluster",
+			asgs:        []string{"ingress-asg", "monitoring-asg"},
+			wantErr:     true,
+		},
+		{
+			name:        "application security group of another cluster",
+			clusterName: "my-cluster",
+			asgs:        []string{"other-cluster-node-asg"},
+			wantErr:     true,
+		},
+		{
+			name:        "AzureCluster not found",
+			clusterName: "other-cluster",
+			asgs:        []string{"monitoring-asg"},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterLabelName: tc.clusterName},
+				},
+				Spec: AzureMachineSpec{
+					SSHPublicKey:              validSSHPublicKey,
+					OSDisk:                    validOSDisk,
+					ApplicationSecurityGroups: tc.asgs,
+				},
+			}
+			err := machine.ValidateCreate(fakeClient)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 type mockDefaultClient struct {
 	client.Client
 	SubscriptionID string
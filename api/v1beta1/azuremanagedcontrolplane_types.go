@@ -271,6 +271,11 @@ type AzureManagedControlPlaneStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationHistory records the most recent Azure operations that created, updated or deleted resources,
+	// with their timing and result. At most MaxOperationHistory operations are kept.
+	// +optional
+	OperationHistory Operations `json:"operationHistory,omitempty"`
 }
 
 // AutoScalerProfile parameters to be applied to the cluster-autoscaler.
@@ -432,6 +437,16 @@ func (m *AzureManagedControlPlane) SetFutures(futures Futures) {
 	m.Status.LongRunningOperationStates = futures
 }
 
+// GetOperationHistory returns the history of the Azure operations of an AzureManagedControlPlane API object.
+func (m *AzureManagedControlPlane) GetOperationHistory() Operations {
+	return m.Status.OperationHistory
+}
+
+// SetOperationHistory will set the given history of Azure operations on an AzureManagedControlPlane object.
+func (m *AzureManagedControlPlane) SetOperationHistory(operations Operations) {
+	m.Status.OperationHistory = operations
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureManagedControlPlane{}, &AzureManagedControlPlaneList{})
 }
@@ -513,6 +513,11 @@ type AzureManagedMachinePoolStatus struct {
 	// next reconciliation loop.
 	// +optional
 	LongRunningOperationStates Futures `json:"longRunningOperationStates,omitempty"`
+
+	// OperationHistory records the most recent Azure operations that created, updated or deleted resources,
+	// with their timing and result. At most MaxOperationHistory operations are kept.
+	// +optional
+	OperationHistory Operations `json:"operationHistory,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -559,6 +564,16 @@ func (m *AzureManagedMachinePool) SetFutures(futures Futures) {
 	m.Status.LongRunningOperationStates = futures
 }
 
+// GetOperationHistory returns the history of the Azure operations of an AzureManagedMachinePool API object.
+func (m *AzureManagedMachinePool) GetOperationHistory() Operations {
+	return m.Status.OperationHistory
+}
+
+// SetOperationHistory will set the given history of Azure operations on an AzureManagedMachinePool object.
+func (m *AzureManagedMachinePool) SetOperationHistory(operations Operations) {
+	m.Status.OperationHistory = operations
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureManagedMachinePool{}, &AzureManagedMachinePoolList{})
 }
@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1beta1
 
-import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+import (
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+)
 
 // AzureCluster Conditions and Reasons.
 const (
@@ -40,6 +43,18 @@ const (
 	VMDeletingReason = "VMDeleting"
 	// VMProvisionFailedReason used for failures during vm provisioning.
 	VMProvisionFailedReason = "VMProvisionFailed"
+	// VMSizeReadyCondition reports on the in-place resize of the Azure VM to the desired VM size.
+	VMSizeReadyCondition clusterv1.ConditionType = "VMSizeReady"
+	// VMDeallocatingReason used when the vm is being deallocated before it is resized.
+	VMDeallocatingReason = "VMDeallocating"
+	// VMResizingReason used when the vm is being resized.
+	VMResizingReason = "VMResizing"
+	// VMStartingReason used when the vm is being started after it was resized.
+	VMStartingReason = "VMStarting"
+	// VMResizeFailedReason used for failures during the in-place resize of the vm.
+	VMResizeFailedReason = "VMResizeFailed"
+	// SpotEvictedReason used when the Spot vm has been evicted by Azure.
+	SpotEvictedReason = "SpotEvicted"
 	// UserAssignedIdentityMissingReason used for failures when a user-assigned identity is missing.
 	UserAssignedIdentityMissingReason = "UserAssignedIdentityMissing"
 	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
@@ -100,8 +115,12 @@ const (
 	VnetPeeringReadyCondition clusterv1.ConditionType = "VnetPeeringReady"
 	// SecurityGroupsReadyCondition means the security groups exist and are ready to be used.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
+	// ApplicationSecurityGroupsReadyCondition means the application security groups exist and are ready to be used.
+	ApplicationSecurityGroupsReadyCondition clusterv1.ConditionType = "ApplicationSecurityGroupsReady"
 	// RouteTablesReadyCondition means the route tables exist and are ready to be used.
 	RouteTablesReadyCondition clusterv1.ConditionType = "RouteTablesReady"
+	// PublicIPPrefixesReadyCondition means the public IP prefixes exist and are ready to be used.
+	PublicIPPrefixesReadyCondition clusterv1.ConditionType = "PublicIPPrefixesReady"
 	// PublicIPsReadyCondition means the public IPs exist and are ready to be used.
 	PublicIPsReadyCondition clusterv1.ConditionType = "PublicIPsReady"
 	// NATGatewaysReadyCondition means the NAT gateways exist and are ready to be used.
@@ -130,6 +149,19 @@ const (
 	NetworkInterfaceReadyCondition clusterv1.ConditionType = "NetworkInterfacesReady"
 	// PrivateEndpointsReadyCondition means the private endpoints exist and are ready to be used.
 	PrivateEndpointsReadyCondition clusterv1.ConditionType = "PrivateEndpointsReady"
+	// PrivateLinkServicesReadyCondition means the private link services exist and are ready to be used.
+	PrivateLinkServicesReadyCondition clusterv1.ConditionType = "PrivateLinkServicesReady"
+	// DiagnosticSettingsReadyCondition means the diagnostic settings of the cluster resources exist and are ready to be used.
+	DiagnosticSettingsReadyCondition clusterv1.ConditionType = "DiagnosticSettingsReady"
+	// FlowLogsReadyCondition means the flow logs of the security groups exist and are ready to be used.
+	FlowLogsReadyCondition clusterv1.ConditionType = "FlowLogsReady"
+	// DedicatedHostsReadyCondition means the dedicated host groups and dedicated hosts of the cluster exist and are ready to be used.
+	DedicatedHostsReadyCondition clusterv1.ConditionType = "DedicatedHostsReady"
+	// ProximityPlacementGroupsReadyCondition means the proximity placement groups of the cluster exist and are ready to be used.
+	ProximityPlacementGroupsReadyCondition clusterv1.ConditionType = "ProximityPlacementGroupsReady"
+	// DriftDetectedCondition means existing Azure resources drifted from their desired state.
+	// It is only set when drift detection is enabled and drift was detected.
+	DriftDetectedCondition clusterv1.ConditionType = "DriftDetected"
 
 	// CreatingReason means the resource is being created.
 	CreatingReason = "Creating"
@@ -143,6 +175,15 @@ const (
 	DeletionFailedReason = "DeletionFailed"
 	// UpdatingReason means the resource is being updated.
 	UpdatingReason = "Updating"
+	// DriftedReason means the resource drifted from its desired state.
+	DriftedReason = "Drifted"
+)
+
+// AzureMachine failure reasons.
+const (
+	// SpotEvictedMachineError indicates that the Spot VM of the machine has been evicted by Azure. Such a machine
+	// cannot recover on its own and should be remediated.
+	SpotEvictedMachineError capierrors.MachineStatusError = "SpotEvicted"
 )
 
 const (
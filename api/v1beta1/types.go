@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -40,6 +42,10 @@ const (
 	PutFuture string = "PUT"
 	// DeleteFuture is a future that was derived from a DELETE request.
 	DeleteFuture string = "DELETE"
+	// DeallocateFuture is a future that was derived from a POST request deallocating a VM.
+	DeallocateFuture string = "DEALLOCATE"
+	// StartFuture is a future that was derived from a POST request starting a VM.
+	StartFuture string = "START"
 )
 
 // Future contains the data needed for an Azure long-running operation to continue across reconcile loops.
@@ -63,6 +69,60 @@ type Future struct {
 	Data string `json:"data"`
 }
 
+// OperationResult is the result of an Azure operation.
+type OperationResult string
+
+const (
+	// OperationInProgress means that the long-running operation has not completed yet.
+	OperationInProgress OperationResult = "InProgress"
+	// OperationSucceeded means that the operation completed successfully.
+	OperationSucceeded OperationResult = "Succeeded"
+	// OperationFailed means that the operation failed.
+	OperationFailed OperationResult = "Failed"
+)
+
+// MaxOperationHistory is the maximum number of operations kept in the operation history of an object.
+const MaxOperationHistory = 10
+
+// Operations is a slice of Operation, ordered from the oldest to the most recent operation.
+type Operations []Operation
+
+// Operation records an Azure operation that created, updated or deleted a resource.
+type Operation struct {
+	// Type describes the type of operation, such as PUT, PATCH or DELETE.
+	Type string `json:"type"`
+
+	// ResourceGroup is the Azure resource group for the resource.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+
+	// ServiceName is the name of the Azure service.
+	ServiceName string `json:"serviceName"`
+
+	// Name is the name of the Azure resource.
+	Name string `json:"name"`
+
+	// StartTime is the time the operation was started.
+	// It is unset for operations that were started before they were recorded in the history.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time the operation completed. It is unset while the operation is in progress.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Result is the result of the operation.
+	Result OperationResult `json:"result"`
+
+	// Error is the error message of a failed operation.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// CorrelationID is the Azure correlation ID of the operation, which can be used to find it in the Azure activity log.
+	// +optional
+	CorrelationID string `json:"correlationID,omitempty"`
+}
+
 // NetworkSpec specifies what the Azure networking resources should look like.
 type NetworkSpec struct {
 	// Vnet is the configuration for the Azure virtual network.
@@ -108,6 +168,13 @@ type VnetSpec struct {
 	// +optional
 	Peerings VnetPeerings `json:"peerings,omitempty"`
 
+	// IPAMPoolRef is a reference to an AzureIPAMPool from which the address space of the virtual network is allocated.
+	// When set, the CIDR blocks of the virtual network and of its subnets are allocated by the controller instead of
+	// being defaulted, so that they do not overlap with the other clusters of the pool or with the existing virtual
+	// networks of the subscription.
+	// +optional
+	IPAMPoolRef *corev1.ObjectReference `json:"ipamPoolRef,omitempty"`
+
 	VnetClassSpec `json:",inline"`
 }
 
@@ -124,6 +191,48 @@ type VnetPeeringClassSpec struct {
 
 	// RemoteVnetName defines name of the remote virtual network.
 	RemoteVnetName string `json:"remoteVnetName"`
+
+	// SubscriptionID is the ID of the subscription of the remote virtual network.
+	// Defaults to the subscription of the AzureCluster.
+	// +optional
+	SubscriptionID string `json:"subscriptionID,omitempty"`
+
+	// IdentityRef is a reference to an AzureClusterIdentity used to manage the peering from the remote virtual
+	// network to the AzureCluster's virtual network. It is needed when the remote virtual network belongs to a
+	// tenant, or to a subscription, that the identity of the AzureCluster has no access to.
+	// Defaults to the identity of the AzureCluster.
+	// +optional
+	IdentityRef *corev1.ObjectReference `json:"identityRef,omitempty"`
+
+	// ForwardPeeringProperties are the properties of the peering from the AzureCluster's virtual network to the
+	// remote virtual network.
+	// +optional
+	ForwardPeeringProperties VnetPeeringProperties `json:"forwardPeeringProperties,omitempty"`
+
+	// ReversePeeringProperties are the properties of the peering from the remote virtual network to the
+	// AzureCluster's virtual network.
+	// +optional
+	ReversePeeringProperties VnetPeeringProperties `json:"reversePeeringProperties,omitempty"`
+}
+
+// VnetPeeringProperties specifies the properties of one direction of a virtual network peering.
+type VnetPeeringProperties struct {
+	// AllowForwardedTraffic specifies whether the forwarded traffic from the VMs in the local virtual network will be
+	// allowed/disallowed in the remote virtual network.
+	// +optional
+	AllowForwardedTraffic *bool `json:"allowForwardedTraffic,omitempty"`
+
+	// AllowGatewayTransit specifies if gateway links can be used in the remote virtual network's link to the local
+	// virtual network.
+	// +optional
+	AllowGatewayTransit *bool `json:"allowGatewayTransit,omitempty"`
+
+	// UseRemoteGateways specifies if remote gateways can be used on the local virtual network. If the flag is set to
+	// true, and AllowGatewayTransit on the remote peering is also set to true, the local virtual network will use
+	// the gateways of the remote virtual network for transit. Only one peering can have this flag set to true. This
+	// flag cannot be set if the virtual network already has a gateway.
+	// +optional
+	UseRemoteGateways *bool `json:"useRemoteGateways,omitempty"`
 }
 
 // VnetPeerings is a slice of VnetPeering.
@@ -178,6 +287,10 @@ type NatGateway struct {
 	ID string `json:"id,omitempty"`
 	// +optional
 	NatGatewayIP PublicIPSpec `json:"ip,omitempty"`
+	// PublicIPPrefix is the public IP prefix from which the public IP of the NAT gateway is allocated.
+	// When not set, the public IP is allocated from the pool of Azure.
+	// +optional
+	PublicIPPrefix *PublicIPPrefixSpec `json:"publicIPPrefix,omitempty"`
 
 	NatGatewayClassSpec `json:",inline"`
 }
@@ -239,6 +352,14 @@ type SecurityRule struct {
 	// Destination is the destination address prefix. CIDR or destination IP range. Asterix '*' can also be used to match all source IPs. Default tags such as 'VirtualNetwork', 'AzureLoadBalancer' and 'Internet' can also be used.
 	// +optional
 	Destination *string `json:"destination,omitempty"`
+	// SourceApplicationSecurityGroups are the names of the application security groups of the cluster the network
+	// traffic originates from. It cannot be set together with Source.
+	// +optional
+	SourceApplicationSecurityGroups []string `json:"sourceApplicationSecurityGroups,omitempty"`
+	// DestinationApplicationSecurityGroups are the names of the application security groups of the cluster the network
+	// traffic is destined to. It cannot be set together with Destination.
+	// +optional
+	DestinationApplicationSecurityGroups []string `json:"destinationApplicationSecurityGroups,omitempty"`
 }
 
 // SecurityRules is a slice of Azure security rules for security groups.
@@ -262,6 +383,24 @@ type LoadBalancerSpec struct {
 	// BackendPool describes the backend pool of the load balancer.
 	// +optional
 	BackendPool BackendPool `json:"backendPool,omitempty"`
+	// PublicIPPrefix is the public IP prefix from which the public IPs of the frontends of the load balancer are
+	// allocated. When not set, the public IPs are allocated from the pool of Azure.
+	// +optional
+	PublicIPPrefix *PublicIPPrefixSpec `json:"publicIPPrefix,omitempty"`
+	// PrivateLinkService exposes the Internal API server load balancer through an Azure private link service, so that
+	// private endpoints in other virtual networks can reach the API server without peering.
+	// +optional
+	PrivateLinkService *PrivateLinkServiceSpec `json:"privateLinkService,omitempty"`
+	// Probe configures the health probe of the API server load balancer. When not set, the API server port is probed
+	// with TCP, which only detects API servers that do not accept connections.
+	// +optional
+	Probe *LoadBalancerProbe `json:"probe,omitempty"`
+	// AdditionalRules are load balancing rules of the API server load balancer in addition to the API server rule,
+	// e.g. for konnectivity or etcd metrics. They use the health probe of the API server.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AdditionalRules []LoadBalancingRule `json:"additionalRules,omitempty"`
 
 	LoadBalancerClassSpec `json:",inline"`
 }
@@ -284,6 +423,71 @@ const (
 	Public = LBType("Public")
 )
 
+// LoadBalancerProbeProtocol defines the protocol of an Azure load balancer health probe.
+// +kubebuilder:validation:Enum=Tcp;Http;Https
+type LoadBalancerProbeProtocol string
+
+const (
+	// LoadBalancerProbeProtocolTCP probes the backend port by opening a TCP connection.
+	LoadBalancerProbeProtocolTCP = LoadBalancerProbeProtocol("Tcp")
+	// LoadBalancerProbeProtocolHTTP probes the backend port with an HTTP GET request, which must return 200.
+	LoadBalancerProbeProtocolHTTP = LoadBalancerProbeProtocol("Http")
+	// LoadBalancerProbeProtocolHTTPS probes the backend port with an HTTPS GET request, which must return 200.
+	LoadBalancerProbeProtocolHTTPS = LoadBalancerProbeProtocol("Https")
+)
+
+// LoadBalancerProbe defines the health probe of a load balancer.
+type LoadBalancerProbe struct {
+	// Protocol is the protocol of the probe. Defaults to Tcp.
+	// +optional
+	Protocol LoadBalancerProbeProtocol `json:"protocol,omitempty"`
+	// RequestPath is the path requested by Http and Https probes. Defaults to /readyz for Http and Https probes.
+	// +optional
+	RequestPath string `json:"requestPath,omitempty"`
+	// IntervalInSeconds is the interval between two probes. Defaults to 15.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	IntervalInSeconds *int32 `json:"intervalInSeconds,omitempty"`
+	// NumberOfProbes is the number of consecutive failed probes after which a backend is considered unhealthy.
+	// Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfProbes *int32 `json:"numberOfProbes,omitempty"`
+}
+
+// LoadBalancingRuleProtocol defines the transport protocol of an Azure load balancing rule.
+// +kubebuilder:validation:Enum=Tcp;Udp
+type LoadBalancingRuleProtocol string
+
+const (
+	// LoadBalancingRuleProtocolTCP is the TCP transport protocol.
+	LoadBalancingRuleProtocolTCP = LoadBalancingRuleProtocol("Tcp")
+	// LoadBalancingRuleProtocolUDP is the UDP transport protocol.
+	LoadBalancingRuleProtocolUDP = LoadBalancingRuleProtocol("Udp")
+)
+
+// LoadBalancingRule defines a load balancing rule which forwards the traffic of a frontend port to the backend pool.
+type LoadBalancingRule struct {
+	// Name is the name of the load balancing rule.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// FrontendIPName is the name of the frontend IP of the rule. Defaults to the first frontend IP of the load balancer.
+	// +optional
+	FrontendIPName string `json:"frontendIPName,omitempty"`
+	// Protocol is the transport protocol of the rule. Defaults to Tcp.
+	// +optional
+	Protocol LoadBalancingRuleProtocol `json:"protocol,omitempty"`
+	// FrontendPort is the port of the frontend IP.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	FrontendPort int32 `json:"frontendPort"`
+	// BackendPort is the port of the backend pool the traffic is forwarded to. Defaults to FrontendPort.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	BackendPort int32 `json:"backendPort,omitempty"`
+}
+
 // FrontendIP defines a load balancer frontend IP configuration.
 type FrontendIP struct {
 	// +kubebuilder:validation:MinLength=1
@@ -303,6 +507,42 @@ type PublicIPSpec struct {
 	IPTags []IPTag `json:"ipTags,omitempty"`
 }
 
+// PublicIPPrefixSpec defines a public IP prefix, a contiguous range of public IP addresses from which public IPs are
+// allocated. The public IP prefix is created if it does not exist, an existing public IP prefix is used as is.
+type PublicIPPrefixSpec struct {
+	// Name is the name of the public IP prefix.
+	Name string `json:"name"`
+	// ResourceGroup is the name of the resource group of the existing public IP prefix or the resource group where
+	// the public IP prefix should be created. Defaults to the resource group of the cluster.
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// PrefixLength is the length of the public IP prefix created by CAPZ, which contains 2^(32-PrefixLength) IPv4
+	// addresses. It must be the length of the public IP prefix when using an existing one.
+	// +kubebuilder:validation:Minimum=28
+	// +kubebuilder:validation:Maximum=31
+	// +kubebuilder:default=28
+	// +optional
+	PrefixLength int32 `json:"prefixLength,omitempty"`
+}
+
+// PrivateLinkServiceSpec defines an Azure private link service in front of an internal load balancer.
+type PrivateLinkServiceSpec struct {
+	// Name is the name of the private link service. Defaults to <cluster-name>-apiserver-pls.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// VisibleSubscriptions are the IDs of the subscriptions that can find the private link service by its alias and
+	// request a private endpoint connection to it. "*" makes the private link service visible to all subscriptions.
+	// The AutoApprovedSubscriptions are always visible.
+	// +optional
+	// +listType=set
+	VisibleSubscriptions []string `json:"visibleSubscriptions,omitempty"`
+	// AutoApprovedSubscriptions are the IDs of the subscriptions whose private endpoint connections to the private
+	// link service are approved automatically. Connections from other subscriptions must be approved manually.
+	// +optional
+	// +listType=set
+	AutoApprovedSubscriptions []string `json:"autoApprovedSubscriptions,omitempty"`
+}
+
 // IPTag contains the IpTag associated with the object.
 type IPTag struct {
 	// Type specifies the IP tag type. Example: FirstPartyUsage.
@@ -499,7 +739,7 @@ const (
 )
 
 // IdentityType represents different types of identities.
-// +kubebuilder:validation:Enum=ServicePrincipal;UserAssignedMSI;ManualServicePrincipal;ServicePrincipalCertificate
+// +kubebuilder:validation:Enum=ServicePrincipal;UserAssignedMSI;ManualServicePrincipal;ServicePrincipalCertificate;WorkloadIdentity
 type IdentityType string
 
 const (
@@ -514,6 +754,10 @@ const (
 
 	// ServicePrincipalCertificate represents a service principal using a certificate as secret.
 	ServicePrincipalCertificate IdentityType = "ServicePrincipalCertificate"
+
+	// WorkloadIdentity represents an Azure AD application or user-assigned identity with a federated credential
+	// trusting the projected service account token of the controller.
+	WorkloadIdentity IdentityType = "WorkloadIdentity"
 )
 
 // OSDisk defines the operating system disk for a VM.
@@ -580,8 +824,37 @@ type ManagedDiskParameters struct {
 	StorageAccountType string `json:"storageAccountType,omitempty"`
 	// +optional
 	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityProfile specifies the security profile for the managed disk.
+	// +optional
+	SecurityProfile *VMDiskSecurityProfile `json:"securityProfile,omitempty"`
 }
 
+// VMDiskSecurityProfile specifies the security profile settings for the managed disk.
+// It can be set only for Confidential VMs.
+type VMDiskSecurityProfile struct {
+	// DiskEncryptionSet specifies the customer managed disk encryption set resource id for the
+	// managed disk that is used for Customer Managed Key encrypted ConfidentialVM OS Disk and VMGuest blob.
+	// +optional
+	DiskEncryptionSet *DiskEncryptionSetParameters `json:"diskEncryptionSet,omitempty"`
+	// SecurityEncryptionType specifies the encryption type of the managed disk.
+	// It is set to DiskWithVMGuestState to encrypt the managed disk along with the VMGuestState blob,
+	// and to VMGuestStateOnly to encrypt the VMGuestState blob only.
+	// +kubebuilder:validation:Enum=VMGuestStateOnly;DiskWithVMGuestState
+	// +optional
+	SecurityEncryptionType SecurityEncryptionType `json:"securityEncryptionType,omitempty"`
+}
+
+// SecurityEncryptionType represents the encryption type of a Confidential VM managed disk.
+type SecurityEncryptionType string
+
+const (
+	// SecurityEncryptionTypeVMGuestStateOnly disables OS disk confidential encryption.
+	SecurityEncryptionTypeVMGuestStateOnly SecurityEncryptionType = "VMGuestStateOnly"
+	// SecurityEncryptionTypeDiskWithVMGuestState enables OS disk confidential encryption with a
+	// platform-managed key (PMK) or a customer-managed key (CMK).
+	SecurityEncryptionTypeDiskWithVMGuestState SecurityEncryptionType = "DiskWithVMGuestState"
+)
+
 // DiskEncryptionSetParameters defines disk encryption options.
 type DiskEncryptionSetParameters struct {
 	// ID defines resourceID for diskEncryptionSet resource. It must be in the same subscription
@@ -741,6 +1014,40 @@ type SecurityProfile struct {
 	// set. Default is disabled.
 	// +optional
 	EncryptionAtHost *bool `json:"encryptionAtHost,omitempty"`
+	// SecurityType specifies the security type of the virtual machine. It must be set to enable UefiSettings.
+	// Default is unset, which disables UefiSettings.
+	// +kubebuilder:validation:Enum=ConfidentialVM;TrustedLaunch
+	// +optional
+	SecurityType SecurityTypes `json:"securityType,omitempty"`
+	// UefiSettings specifies the security settings like secure boot and vTPM used while creating the virtual machine.
+	// +optional
+	UefiSettings *UefiSettings `json:"uefiSettings,omitempty"`
+}
+
+// SecurityTypes represents the security type of a virtual machine.
+type SecurityTypes string
+
+const (
+	// SecurityTypesTrustedLaunch enables Trusted Launch, which provides secure boot and a virtual TPM.
+	SecurityTypesTrustedLaunch SecurityTypes = "TrustedLaunch"
+	// SecurityTypesConfidentialVM enables Confidential VM, which adds hardware-based memory encryption.
+	SecurityTypesConfidentialVM SecurityTypes = "ConfidentialVM"
+)
+
+// UefiSettings specifies the security settings like secure boot and vTPM used while creating the
+// virtual machine.
+type UefiSettings struct {
+	// SecureBootEnabled specifies whether secure boot should be enabled on the virtual machine.
+	// Secure Boot verifies the digital signature of all boot components and halts the boot process if
+	// signature verification fails.
+	// +optional
+	SecureBootEnabled *bool `json:"secureBootEnabled,omitempty"`
+	// VTpmEnabled specifies whether vTPM should be enabled on the virtual machine.
+	// When true it enables the virtualized trusted platform module measurements to create a known good boot integrity policy baseline.
+	// The integrity policy baseline is used for comparison with measurements from subsequent VM boots to determine if anything has changed.
+	// This is required to be set to Enabled if SecurityEncryptionType is defined.
+	// +optional
+	VTpmEnabled *bool `json:"vTpmEnabled,omitempty"`
 }
 
 // AddressRecord specifies a DNS record mapping a hostname to an IPV4 or IPv6 address.
@@ -50,6 +50,89 @@ type AzureClusterClassSpec struct {
 	// Note: All cloud provider config values can be customized by creating the secret beforehand. CloudProviderConfigOverrides is only used when the secret is managed by the Azure Provider.
 	// +optional
 	CloudProviderConfigOverrides *CloudProviderConfigOverrides `json:"cloudProviderConfigOverrides,omitempty"`
+
+	// DiagnosticSettings configures Azure Monitor diagnostic settings which send the resource logs and metrics of the
+	// security groups, load balancers, public IPs and Azure Bastion of the cluster to a Log Analytics workspace and/or
+	// a storage account. No diagnostic settings are created when not set.
+	// +optional
+	DiagnosticSettings *DiagnosticSettings `json:"diagnosticSettings,omitempty"`
+
+	// DedicatedHostGroups is a list of Azure Dedicated Host groups, and of the dedicated hosts in them, to create in the
+	// cluster resource group. Machines are placed on them with their hostGroupID or hostID. The host groups and hosts
+	// are deleted with the cluster.
+	// +optional
+	DedicatedHostGroups []DedicatedHostGroup `json:"dedicatedHostGroups,omitempty"`
+
+	// ProximityPlacementGroups is a list of Azure proximity placement groups to create in the cluster resource group.
+	// Machines and machine pools are placed in them with their proximityPlacementGroupID. The proximity placement
+	// groups are deleted with the cluster.
+	// +optional
+	ProximityPlacementGroups []ProximityPlacementGroup `json:"proximityPlacementGroups,omitempty"`
+}
+
+// DedicatedHostGroup defines an Azure Dedicated Host group and the dedicated hosts in it.
+type DedicatedHostGroup struct {
+	// Name is the name of the dedicated host group.
+	Name string `json:"name"`
+
+	// FailureDomain is the availability zone of the dedicated host group. The virtual machines placed in the group must
+	// be in the same failure domain. The group spans all the availability zones of the region when not set.
+	// +optional
+	FailureDomain *string `json:"failureDomain,omitempty"`
+
+	// PlatformFaultDomainCount is the number of fault domains the dedicated host group can span.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3
+	PlatformFaultDomainCount int32 `json:"platformFaultDomainCount"`
+
+	// AutomaticPlacement lets Azure choose the dedicated host of the virtual machines and scale sets placed in the group.
+	// It is required to place machines with a hostGroupID and no hostID, and machine pools.
+	// +optional
+	AutomaticPlacement bool `json:"automaticPlacement,omitempty"`
+
+	// Hosts is the list of dedicated hosts to create in the group.
+	// +optional
+	Hosts []DedicatedHost `json:"hosts,omitempty"`
+}
+
+// DedicatedHost defines an Azure Dedicated Host.
+type DedicatedHost struct {
+	// Name is the name of the dedicated host.
+	Name string `json:"name"`
+
+	// SKU is the SKU of the dedicated host, which defines its hardware generation and the VM sizes it can run,
+	// e.g. DSv3-Type3.
+	SKU string `json:"sku"`
+
+	// PlatformFaultDomain is the fault domain of the dedicated host within its group. It must be lower than the
+	// platformFaultDomainCount of the group.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PlatformFaultDomain int32 `json:"platformFaultDomain,omitempty"`
+
+	// AutoReplaceOnFailure specifies whether Azure replaces the dedicated host automatically when it fails.
+	// Defaults to true.
+	// +optional
+	AutoReplaceOnFailure *bool `json:"autoReplaceOnFailure,omitempty"`
+}
+
+// ProximityPlacementGroup defines an Azure proximity placement group, which keeps the virtual machines in it
+// physically close to each other to reduce the network latency between them.
+type ProximityPlacementGroup struct {
+	// Name is the name of the proximity placement group.
+	Name string `json:"name"`
+}
+
+// DiagnosticSettings defines the destinations of the Azure Monitor resource logs and metrics of Azure resources.
+// At least one destination is required.
+type DiagnosticSettings struct {
+	// WorkspaceID is the Azure resource ID of the Log Analytics workspace the logs and metrics are sent to.
+	// +optional
+	WorkspaceID string `json:"workspaceID,omitempty"`
+
+	// StorageAccountID is the Azure resource ID of the storage account the logs and metrics are archived to.
+	// +optional
+	StorageAccountID string `json:"storageAccountID,omitempty"`
 }
 
 // NetworkClassSpec defines the NetworkSpec properties that may be shared across several Azure clusters.
@@ -57,6 +140,76 @@ type NetworkClassSpec struct {
 	// PrivateDNSZoneName defines the zone name for the Azure Private DNS.
 	// +optional
 	PrivateDNSZoneName string `json:"privateDNSZoneName,omitempty"`
+
+	// OutboundType is the method used to provide egress to the nodes and the control plane of the cluster.
+	// With loadBalancer, egress is provided by outbound load balancers and NAT gateways. With userDefinedRouting, the
+	// route tables of the cluster's subnets route all the egress traffic to the virtual appliance at NextHopIPAddress,
+	// e.g. an Azure Firewall, and no outbound load balancer is created. In a virtual network not managed by CAPZ, the
+	// routes to the virtual appliance must be configured by the user.
+	// Defaults to loadBalancer.
+	// +kubebuilder:validation:Enum=loadBalancer;userDefinedRouting
+	// +optional
+	OutboundType *OutboundType `json:"outboundType,omitempty"`
+
+	// NextHopIPAddress is the IPv4 address of the virtual appliance the egress traffic of the cluster is routed to.
+	// It is required when OutboundType is userDefinedRouting.
+	// +optional
+	NextHopIPAddress string `json:"nextHopIPAddress,omitempty"`
+
+	// ApplicationSecurityGroups are the names of custom application security groups to create in the cluster resource
+	// group, in addition to the control plane and node application security groups that are always created.
+	// AzureMachines join them with their ApplicationSecurityGroups field.
+	// +listType=set
+	// +optional
+	ApplicationSecurityGroups []string `json:"applicationSecurityGroups,omitempty"`
+
+	// IPv6Only is an experimental mode in which the machines of the cluster only use IPv6. Azure requires the primary
+	// IP configuration of a network interface to be IPv4, so the virtual network still needs an IPv4 CIDR block next
+	// to its IPv6 one, but the IPv4 addresses of the machines are not reported and the outbound load balancers only
+	// provide IPv6 egress. It requires the IPv6Only feature gate and cannot be changed once set.
+	// +optional
+	IPv6Only bool `json:"ipv6Only,omitempty"`
+}
+
+// IsUserDefinedRouting returns true if the egress traffic of the cluster is routed to a virtual appliance.
+func (n NetworkClassSpec) IsUserDefinedRouting() bool {
+	return n.OutboundType != nil && *n.OutboundType == OutboundTypeUserDefinedRouting
+}
+
+// OutboundType enumerates the methods used to provide egress to an AzureCluster.
+type OutboundType string
+
+const (
+	// OutboundTypeLoadBalancer provides egress with outbound load balancers and NAT gateways.
+	OutboundTypeLoadBalancer OutboundType = "loadBalancer"
+	// OutboundTypeUserDefinedRouting routes the egress traffic to a virtual appliance with user-defined routes.
+	OutboundTypeUserDefinedRouting OutboundType = "userDefinedRouting"
+)
+
+// VnetEncryptionEnforcement defines whether an encrypted virtual network allows virtual machines that do not support
+// encryption.
+type VnetEncryptionEnforcement string
+
+const (
+	// VnetEncryptionEnforcementAllowUnencrypted allows the virtual machines that do not support encryption in the
+	// virtual network, their traffic is not encrypted.
+	VnetEncryptionEnforcementAllowUnencrypted VnetEncryptionEnforcement = "AllowUnencrypted"
+	// VnetEncryptionEnforcementDropUnencrypted drops the traffic of the virtual machines that do not support encryption.
+	VnetEncryptionEnforcementDropUnencrypted VnetEncryptionEnforcement = "DropUnencrypted"
+)
+
+// VnetEncryption defines the encryption of the traffic between the virtual machines of a virtual network.
+type VnetEncryption struct {
+	// Enabled defines whether the traffic between the virtual machines of the virtual network is encrypted.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Enforcement defines whether virtual machines that do not support encryption are allowed in the virtual network.
+	// +kubebuilder:validation:Enum=AllowUnencrypted;DropUnencrypted
+	// +kubebuilder:default=AllowUnencrypted
+	// +optional
+	Enforcement VnetEncryptionEnforcement `json:"enforcement,omitempty"`
 }
 
 // VnetClassSpec defines the VnetSpec properties that may be shared across several Azure clusters.
@@ -68,6 +221,30 @@ type VnetClassSpec struct {
 	// Tags is a collection of tags describing the resource.
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+
+	// DDoSProtectionPlanID is the Azure resource ID of an existing DDoS protection plan. DDoS Network Protection is
+	// enabled on the virtual network with this plan, which may belong to another subscription, and disabled when it is
+	// set to an empty string. The DDoS protection of the virtual network is left unchanged when not set.
+	// +optional
+	DDoSProtectionPlanID *string `json:"ddosProtectionPlanID,omitempty"`
+
+	// Encryption defines the encryption of the traffic between the virtual machines of the virtual network.
+	// The encryption of the virtual network is left unchanged when not set.
+	// +optional
+	Encryption *VnetEncryption `json:"encryption,omitempty"`
+
+	// DHCPOptions defines the DHCP options of the virtual network.
+	// The DHCP options of the virtual network are left unchanged when not set.
+	// +optional
+	DHCPOptions *VnetDHCPOptions `json:"dhcpOptions,omitempty"`
+}
+
+// VnetDHCPOptions defines the DHCP options of a virtual network.
+type VnetDHCPOptions struct {
+	// DNSServers is the list of the IP addresses of the DNS servers of the virtual network, in order of preference.
+	// The Azure-provided DNS is used when empty.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
 }
 
 // SubnetClassSpec defines the SubnetSpec properties that may be shared across several Azure clusters.
@@ -109,6 +286,33 @@ type SecurityGroupClass struct {
 	SecurityRules SecurityRules `json:"securityRules,omitempty"`
 	// +optional
 	Tags Tags `json:"tags,omitempty"`
+	// FlowLog enables the flow logs of the security group, which record the IP traffic allowed or denied by its
+	// security rules. Flow logs are only managed for security groups of managed virtual networks.
+	// Azure no longer allows the creation of new NSG flow logs since June 30, 2025.
+	// +optional
+	FlowLog *FlowLog `json:"flowLog,omitempty"`
+}
+
+// FlowLog defines the flow logs of a network security group.
+type FlowLog struct {
+	// StorageAccountID is the Azure resource ID of the storage account the flow logs are written to.
+	// The storage account must be in the location of the cluster.
+	StorageAccountID string `json:"storageAccountID"`
+
+	// RetentionDays is the number of days the flow logs are kept in the storage account.
+	// Flow logs are kept forever when not set or 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+
+	// NetworkWatcherName is the name of the network watcher of the location of the cluster, which the flow log
+	// belongs to. Defaults to NetworkWatcher_<location>, the network watcher created by Azure.
+	// +optional
+	NetworkWatcherName string `json:"networkWatcherName,omitempty"`
+
+	// NetworkWatcherResourceGroup is the resource group of the network watcher. Defaults to NetworkWatcherRG.
+	// +optional
+	NetworkWatcherResourceGroup string `json:"networkWatcherResourceGroup,omitempty"`
 }
 
 // FrontendIPClass defines the FrontendIP properties that may be shared across several Azure clusters.
@@ -133,7 +337,7 @@ func (vc *VnetClassSpec) setDefaults() {
 
 // setDefaults sets default values for SubnetClassSpec.
 func (sc *SubnetClassSpec) setDefaults(cidr string) {
-	if len(sc.CIDRBlocks) == 0 {
+	if len(sc.CIDRBlocks) == 0 && cidr != "" {
 		sc.CIDRBlocks = []string{cidr}
 	}
 }
@@ -361,6 +361,23 @@ func (in *AzureClusterClassSpec) DeepCopyInto(out *AzureClusterClassSpec) {
 		*out = new(CloudProviderConfigOverrides)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DiagnosticSettings != nil {
+		in, out := &in.DiagnosticSettings, &out.DiagnosticSettings
+		*out = new(DiagnosticSettings)
+		**out = **in
+	}
+	if in.DedicatedHostGroups != nil {
+		in, out := &in.DedicatedHostGroups, &out.DedicatedHostGroups
+		*out = make([]DedicatedHostGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ProximityPlacementGroups != nil {
+		in, out := &in.ProximityPlacementGroups, &out.ProximityPlacementGroups
+		*out = make([]ProximityPlacementGroup, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterClassSpec.
@@ -548,6 +565,13 @@ func (in *AzureClusterStatus) DeepCopyInto(out *AzureClusterStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make(Operations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureClusterStatus.
@@ -698,6 +722,105 @@ func (in *AzureComputeGalleryImage) DeepCopy() *AzureComputeGalleryImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureIPAMPool) DeepCopyInto(out *AzureIPAMPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureIPAMPool.
+func (in *AzureIPAMPool) DeepCopy() *AzureIPAMPool {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureIPAMPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureIPAMPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureIPAMPoolList) DeepCopyInto(out *AzureIPAMPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AzureIPAMPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureIPAMPoolList.
+func (in *AzureIPAMPoolList) DeepCopy() *AzureIPAMPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureIPAMPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AzureIPAMPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureIPAMPoolSpec) DeepCopyInto(out *AzureIPAMPoolSpec) {
+	*out = *in
+	if in.CIDRBlocks != nil {
+		in, out := &in.CIDRBlocks, &out.CIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureIPAMPoolSpec.
+func (in *AzureIPAMPoolSpec) DeepCopy() *AzureIPAMPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureIPAMPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureIPAMPoolStatus) DeepCopyInto(out *AzureIPAMPoolStatus) {
+	*out = *in
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]IPAMAllocation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureIPAMPoolStatus.
+func (in *AzureIPAMPoolStatus) DeepCopy() *AzureIPAMPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureIPAMPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureMachine) DeepCopyInto(out *AzureMachine) {
 	*out = *in
@@ -844,6 +967,11 @@ func (in *AzureMachineSpec) DeepCopyInto(out *AzureMachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineSpec.
@@ -891,6 +1019,13 @@ func (in *AzureMachineStatus) DeepCopyInto(out *AzureMachineStatus) {
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make(Operations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureMachineStatus.
@@ -1244,6 +1379,13 @@ func (in *AzureManagedControlPlaneStatus) DeepCopyInto(out *AzureManagedControlP
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make(Operations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedControlPlaneStatus.
@@ -1449,6 +1591,13 @@ func (in *AzureManagedMachinePoolStatus) DeepCopyInto(out *AzureManagedMachinePo
 		*out = make(Futures, len(*in))
 		copy(*out, *in)
 	}
+	if in.OperationHistory != nil {
+		in, out := &in.OperationHistory, &out.OperationHistory
+		*out = make(Operations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureManagedMachinePoolStatus.
@@ -1687,6 +1836,68 @@ func (in *DataDisk) DeepCopy() *DataDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedHost) DeepCopyInto(out *DedicatedHost) {
+	*out = *in
+	if in.AutoReplaceOnFailure != nil {
+		in, out := &in.AutoReplaceOnFailure, &out.AutoReplaceOnFailure
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedHost.
+func (in *DedicatedHost) DeepCopy() *DedicatedHost {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedHostGroup) DeepCopyInto(out *DedicatedHostGroup) {
+	*out = *in
+	if in.FailureDomain != nil {
+		in, out := &in.FailureDomain, &out.FailureDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]DedicatedHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedHostGroup.
+func (in *DedicatedHostGroup) DeepCopy() *DedicatedHostGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedHostGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticSettings) DeepCopyInto(out *DiagnosticSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticSettings.
+func (in *DiagnosticSettings) DeepCopy() *DiagnosticSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Diagnostics) DeepCopyInto(out *Diagnostics) {
 	*out = *in
@@ -1737,6 +1948,21 @@ func (in *DiskEncryptionSetParameters) DeepCopy() *DiskEncryptionSetParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowLog) DeepCopyInto(out *FlowLog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowLog.
+func (in *FlowLog) DeepCopy() *FlowLog {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FrontendIP) DeepCopyInto(out *FrontendIP) {
 	*out = *in
@@ -1807,6 +2033,21 @@ func (in Futures) DeepCopy() Futures {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAMAllocation) DeepCopyInto(out *IPAMAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAMAllocation.
+func (in *IPAMAllocation) DeepCopy() *IPAMAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAMAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPTag) DeepCopyInto(out *IPTag) {
 	*out = *in
@@ -1997,6 +2238,31 @@ func (in *LoadBalancerClassSpec) DeepCopy() *LoadBalancerClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerProbe) DeepCopyInto(out *LoadBalancerProbe) {
+	*out = *in
+	if in.IntervalInSeconds != nil {
+		in, out := &in.IntervalInSeconds, &out.IntervalInSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumberOfProbes != nil {
+		in, out := &in.NumberOfProbes, &out.NumberOfProbes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerProbe.
+func (in *LoadBalancerProbe) DeepCopy() *LoadBalancerProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerProfile) DeepCopyInto(out *LoadBalancerProfile) {
 	*out = *in
@@ -2053,6 +2319,26 @@ func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 		**out = **in
 	}
 	out.BackendPool = in.BackendPool
+	if in.PublicIPPrefix != nil {
+		in, out := &in.PublicIPPrefix, &out.PublicIPPrefix
+		*out = new(PublicIPPrefixSpec)
+		**out = **in
+	}
+	if in.PrivateLinkService != nil {
+		in, out := &in.PrivateLinkService, &out.PrivateLinkService
+		*out = new(PrivateLinkServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(LoadBalancerProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalRules != nil {
+		in, out := &in.AdditionalRules, &out.AdditionalRules
+		*out = make([]LoadBalancingRule, len(*in))
+		copy(*out, *in)
+	}
 	in.LoadBalancerClassSpec.DeepCopyInto(&out.LoadBalancerClassSpec)
 }
 
@@ -2066,6 +2352,21 @@ func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancingRule) DeepCopyInto(out *LoadBalancingRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancingRule.
+func (in *LoadBalancingRule) DeepCopy() *LoadBalancingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedControlPlaneSubnet) DeepCopyInto(out *ManagedControlPlaneSubnet) {
 	*out = *in
@@ -2119,6 +2420,11 @@ func (in *ManagedDiskParameters) DeepCopyInto(out *ManagedDiskParameters) {
 		*out = new(DiskEncryptionSetParameters)
 		**out = **in
 	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(VMDiskSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedDiskParameters.
@@ -2160,6 +2466,11 @@ func (in *ManagedMachinePoolScaling) DeepCopy() *ManagedMachinePoolScaling {
 func (in *NatGateway) DeepCopyInto(out *NatGateway) {
 	*out = *in
 	in.NatGatewayIP.DeepCopyInto(&out.NatGatewayIP)
+	if in.PublicIPPrefix != nil {
+		in, out := &in.PublicIPPrefix, &out.PublicIPPrefix
+		*out = new(PublicIPPrefixSpec)
+		**out = **in
+	}
 	out.NatGatewayClassSpec = in.NatGatewayClassSpec
 }
 
@@ -2191,6 +2502,16 @@ func (in *NatGatewayClassSpec) DeepCopy() *NatGatewayClassSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkClassSpec) DeepCopyInto(out *NetworkClassSpec) {
 	*out = *in
+	if in.OutboundType != nil {
+		in, out := &in.OutboundType, &out.OutboundType
+		*out = new(OutboundType)
+		**out = **in
+	}
+	if in.ApplicationSecurityGroups != nil {
+		in, out := &in.ApplicationSecurityGroups, &out.ApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkClassSpec.
@@ -2245,7 +2566,7 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(LoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -2261,7 +2582,7 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkTemplateSpec) DeepCopyInto(out *NetworkTemplateSpec) {
 	*out = *in
-	out.NetworkClassSpec = in.NetworkClassSpec
+	in.NetworkClassSpec.DeepCopyInto(&out.NetworkClassSpec)
 	in.Vnet.DeepCopyInto(&out.Vnet)
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -2323,6 +2644,50 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operation) DeepCopyInto(out *Operation) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operation.
+func (in *Operation) DeepCopy() *Operation {
+	if in == nil {
+		return nil
+	}
+	out := new(Operation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Operations) DeepCopyInto(out *Operations) {
+	{
+		in := &in
+		*out = make(Operations, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operations.
+func (in Operations) DeepCopy() Operations {
+	if in == nil {
+		return nil
+	}
+	out := new(Operations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateEndpointSpec) DeepCopyInto(out *PrivateEndpointSpec) {
 	*out = *in
@@ -2396,6 +2761,61 @@ func (in *PrivateLinkServiceConnection) DeepCopy() *PrivateLinkServiceConnection
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkServiceSpec) DeepCopyInto(out *PrivateLinkServiceSpec) {
+	*out = *in
+	if in.VisibleSubscriptions != nil {
+		in, out := &in.VisibleSubscriptions, &out.VisibleSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovedSubscriptions != nil {
+		in, out := &in.AutoApprovedSubscriptions, &out.AutoApprovedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkServiceSpec.
+func (in *PrivateLinkServiceSpec) DeepCopy() *PrivateLinkServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProximityPlacementGroup) DeepCopyInto(out *ProximityPlacementGroup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProximityPlacementGroup.
+func (in *ProximityPlacementGroup) DeepCopy() *ProximityPlacementGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProximityPlacementGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPPrefixSpec) DeepCopyInto(out *PublicIPPrefixSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPPrefixSpec.
+func (in *PublicIPPrefixSpec) DeepCopy() *PublicIPPrefixSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPPrefixSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
 	*out = *in
@@ -2505,6 +2925,11 @@ func (in *SecurityGroupClass) DeepCopyInto(out *SecurityGroupClass) {
 			(*out)[key] = val
 		}
 	}
+	if in.FlowLog != nil {
+		in, out := &in.FlowLog, &out.FlowLog
+		*out = new(FlowLog)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupClass.
@@ -2525,6 +2950,11 @@ func (in *SecurityProfile) DeepCopyInto(out *SecurityProfile) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UefiSettings != nil {
+		in, out := &in.UefiSettings, &out.UefiSettings
+		*out = new(UefiSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityProfile.
@@ -2560,6 +2990,16 @@ func (in *SecurityRule) DeepCopyInto(out *SecurityRule) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SourceApplicationSecurityGroups != nil {
+		in, out := &in.SourceApplicationSecurityGroups, &out.SourceApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationApplicationSecurityGroups != nil {
+		in, out := &in.DestinationApplicationSecurityGroups, &out.DestinationApplicationSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityRule.
@@ -2997,6 +3437,31 @@ func (in Taints) DeepCopy() Taints {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UefiSettings) DeepCopyInto(out *UefiSettings) {
+	*out = *in
+	if in.SecureBootEnabled != nil {
+		in, out := &in.SecureBootEnabled, &out.SecureBootEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VTpmEnabled != nil {
+		in, out := &in.VTpmEnabled, &out.VTpmEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UefiSettings.
+func (in *UefiSettings) DeepCopy() *UefiSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UefiSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserAssignedIdentity) DeepCopyInto(out *UserAssignedIdentity) {
 	*out = *in
@@ -3027,6 +3492,26 @@ func (in *UserManagedBootDiagnostics) DeepCopy() *UserManagedBootDiagnostics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMDiskSecurityProfile) DeepCopyInto(out *VMDiskSecurityProfile) {
+	*out = *in
+	if in.DiskEncryptionSet != nil {
+		in, out := &in.DiskEncryptionSet, &out.DiskEncryptionSet
+		*out = new(DiskEncryptionSetParameters)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMDiskSecurityProfile.
+func (in *VMDiskSecurityProfile) DeepCopy() *VMDiskSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VMDiskSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMExtension) DeepCopyInto(out *VMExtension) {
 	*out = *in
@@ -3056,6 +3541,21 @@ func (in *VMExtension) DeepCopy() *VMExtension {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSizeCapabilities) DeepCopyInto(out *VMSizeCapabilities) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSizeCapabilities.
+func (in *VMSizeCapabilities) DeepCopy() *VMSizeCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSizeCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetClassSpec) DeepCopyInto(out *VnetClassSpec) {
 	*out = *in
@@ -3071,6 +3571,21 @@ func (in *VnetClassSpec) DeepCopyInto(out *VnetClassSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.DDoSProtectionPlanID != nil {
+		in, out := &in.DDoSProtectionPlanID, &out.DDoSProtectionPlanID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(VnetEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = new(VnetDHCPOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetClassSpec.
@@ -3083,9 +3598,56 @@ func (in *VnetClassSpec) DeepCopy() *VnetClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VnetDHCPOptions) DeepCopyInto(out *VnetDHCPOptions) {
+	*out = *in
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetDHCPOptions.
+func (in *VnetDHCPOptions) DeepCopy() *VnetDHCPOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(VnetDHCPOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VnetEncryption) DeepCopyInto(out *VnetEncryption) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetEncryption.
+func (in *VnetEncryption) DeepCopy() *VnetEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(VnetEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringClassSpec) DeepCopyInto(out *VnetPeeringClassSpec) {
 	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	in.ForwardPeeringProperties.DeepCopyInto(&out.ForwardPeeringProperties)
+	in.ReversePeeringProperties.DeepCopyInto(&out.ReversePeeringProperties)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringClassSpec.
@@ -3098,10 +3660,40 @@ func (in *VnetPeeringClassSpec) DeepCopy() *VnetPeeringClassSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VnetPeeringProperties) DeepCopyInto(out *VnetPeeringProperties) {
+	*out = *in
+	if in.AllowForwardedTraffic != nil {
+		in, out := &in.AllowForwardedTraffic, &out.AllowForwardedTraffic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowGatewayTransit != nil {
+		in, out := &in.AllowGatewayTransit, &out.AllowGatewayTransit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseRemoteGateways != nil {
+		in, out := &in.UseRemoteGateways, &out.UseRemoteGateways
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringProperties.
+func (in *VnetPeeringProperties) DeepCopy() *VnetPeeringProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(VnetPeeringProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VnetPeeringSpec) DeepCopyInto(out *VnetPeeringSpec) {
 	*out = *in
-	out.VnetPeeringClassSpec = in.VnetPeeringClassSpec
+	in.VnetPeeringClassSpec.DeepCopyInto(&out.VnetPeeringClassSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VnetPeeringSpec.
@@ -3119,7 +3711,9 @@ func (in VnetPeerings) DeepCopyInto(out *VnetPeerings) {
 	{
 		in := &in
 		*out = make(VnetPeerings, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -3138,7 +3732,9 @@ func (in VnetPeeringsTemplateSpec) DeepCopyInto(out *VnetPeeringsTemplateSpec) {
 	{
 		in := &in
 		*out = make(VnetPeeringsTemplateSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -3158,7 +3754,14 @@ func (in *VnetSpec) DeepCopyInto(out *VnetSpec) {
 	if in.Peerings != nil {
 		in, out := &in.Peerings, &out.Peerings
 		*out = make(VnetPeerings, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPAMPoolRef != nil {
+		in, out := &in.IPAMPoolRef, &out.IPAMPoolRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
 	}
 	in.VnetClassSpec.DeepCopyInto(&out.VnetClassSpec)
 }
@@ -3180,7 +3783,9 @@ func (in *VnetTemplateSpec) DeepCopyInto(out *VnetTemplateSpec) {
 	if in.Peerings != nil {
 		in, out := &in.Peerings, &out.Peerings
 		*out = make(VnetPeeringsTemplateSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
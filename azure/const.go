@@ -28,4 +28,23 @@ const (
 	// See https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations/
 	// for annotation formatting rules.
 	RGTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-tags-rg"
+
+	// DriftDetectionAnnotation is the key for the AzureCluster and AzureMachine object annotation
+	// which sets the DriftDetectionMode used when reconciling the Azure resources of the object,
+	// i.e. "enabled" or "report-only".
+	DriftDetectionAnnotation = "sigs.k8s.io/cluster-api-provider-azure-drift-detection"
+
+	// DryRunAnnotation is the key for the AzureCluster and AzureManagedControlPlane object annotation
+	// which, when set to "true", makes the controller publish the plan of the Azure API calls it would make
+	// instead of creating, updating or deleting any Azure resource.
+	DryRunAnnotation = "sigs.k8s.io/cluster-api-provider-azure-dry-run"
+
+	// SpotVMStoppedAnnotation is the key for the AzureMachine object annotation which, when set to "true", marks the
+	// deallocation of its Spot VM as intended, so the deallocated VM is not considered evicted.
+	SpotVMStoppedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-spot-vm-stopped"
+
+	// IdentitiesLastAppliedAnnotation is the key for the AzureCluster object annotation
+	// which tracks the AzureClusterIdentities the cluster holds a finalizer on, so the identities
+	// no longer referenced by the cluster can be released without listing every identity.
+	IdentitiesLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-azure-last-applied-identities"
 )
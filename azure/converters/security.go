@@ -0,0 +1,51 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+// GetUefiSettings converts CAPZ UEFI settings to Azure SDK UEFI settings.
+func GetUefiSettings(uefiSettings *infrav1.UefiSettings) *compute.UefiSettings {
+	if uefiSettings == nil {
+		return nil
+	}
+
+	return &compute.UefiSettings{
+		SecureBootEnabled: uefiSettings.SecureBootEnabled,
+		VTpmEnabled:       uefiSettings.VTpmEnabled,
+	}
+}
+
+// GetDiskSecurityProfile converts a CAPZ managed disk security profile to an Azure SDK managed disk security profile.
+func GetDiskSecurityProfile(securityProfile *infrav1.VMDiskSecurityProfile) *compute.VMDiskSecurityProfile {
+	if securityProfile == nil {
+		return nil
+	}
+
+	diskSecurityProfile := &compute.VMDiskSecurityProfile{
+		SecurityEncryptionType: compute.SecurityEncryptionTypes(securityProfile.SecurityEncryptionType),
+	}
+	if securityProfile.DiskEncryptionSet != nil {
+		diskSecurityProfile.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(securityProfile.DiskEncryptionSet.ID)}
+	}
+
+	return diskSecurityProfile
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converters
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestGetUefiSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		uefiSettings *infrav1.UefiSettings
+		want         *compute.UefiSettings
+	}{
+		{
+			name:         "nil uefi settings",
+			uefiSettings: nil,
+			want:         nil,
+		},
+		{
+			name: "secure boot and vTPM enabled",
+			uefiSettings: &infrav1.UefiSettings{
+				SecureBootEnabled: pointer.Bool(true),
+				VTpmEnabled:       pointer.Bool(true),
+			},
+			want: &compute.UefiSettings{
+				SecureBootEnabled: pointer.Bool(true),
+				VTpmEnabled:       pointer.Bool(true),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := GetUefiSettings(tt.uefiSettings)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetUefiSettings(%s) mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestGetDiskSecurityProfile(t *testing.T) {
+	tests := []struct {
+		name            string
+		securityProfile *infrav1.VMDiskSecurityProfile
+		want            *compute.VMDiskSecurityProfile
+	}{
+		{
+			name:            "nil security profile",
+			securityProfile: nil,
+			want:            nil,
+		},
+		{
+			name: "guest state only",
+			securityProfile: &infrav1.VMDiskSecurityProfile{
+				SecurityEncryptionType: infrav1.SecurityEncryptionTypeVMGuestStateOnly,
+			},
+			want: &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypesVMGuestStateOnly,
+			},
+		},
+		{
+			name: "disk with guest state and a customer managed key",
+			securityProfile: &infrav1.VMDiskSecurityProfile{
+				SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+				DiskEncryptionSet:      &infrav1.DiskEncryptionSetParameters{ID: "my-disk-encryption-set"},
+			},
+			want: &compute.VMDiskSecurityProfile{
+				SecurityEncryptionType: compute.SecurityEncryptionTypesDiskWithVMGuestState,
+				DiskEncryptionSet:      &compute.DiskEncryptionSetParameters{ID: pointer.String("my-disk-encryption-set")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := GetDiskSecurityProfile(tt.securityProfile)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetDiskSecurityProfile(%s) mismatch (-want +got):\n%s", tt.name, diff)
+			}
+		})
+	}
+}
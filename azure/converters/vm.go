@@ -78,3 +78,26 @@ func SDKToVM(v compute.VirtualMachine) *VM {
 
 	return vm
 }
+
+// DataDiskToSDK converts a CAPZ data disk of a VM to an Azure SDK data disk, created empty.
+func DataDiskToSDK(vmName string, disk infrav1.DataDisk) compute.DataDisk {
+	dataDisk := compute.DataDisk{
+		CreateOption: compute.DiskCreateOptionTypesEmpty,
+		DiskSizeGB:   pointer.Int32(disk.DiskSizeGB),
+		Lun:          disk.Lun,
+		Name:         pointer.String(azure.GenerateDataDiskName(vmName, disk.NameSuffix)),
+		Caching:      compute.CachingTypes(disk.CachingType),
+	}
+
+	if disk.ManagedDisk != nil {
+		dataDisk.ManagedDisk = &compute.ManagedDiskParameters{
+			StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
+		}
+
+		if disk.ManagedDisk.DiskEncryptionSet != nil {
+			dataDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(disk.ManagedDisk.DiskEncryptionSet.ID)}
+		}
+	}
+
+	return dataDisk
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
 )
@@ -90,6 +91,11 @@ const (
 	bootstrapSentinelFile = "/run/cluster-api/bootstrap-success.complete"
 )
 
+const (
+	// DefaultNetworkWatcherResourceGroup is the resource group of the network watchers created by Azure.
+	DefaultNetworkWatcherResourceGroup = "NetworkWatcherRG"
+)
+
 const (
 	// ProviderIDPrefix will be appended to the beginning of Azure resource IDs to form the Kubernetes Provider ID.
 	// NOTE: this format matches the 2 slashes format used in cloud-provider and cluster-autoscaler.
@@ -127,6 +133,22 @@ func GenerateFrontendIPConfigName(lbName string) string {
 	return fmt.Sprintf("%s-%s", lbName, "frontEnd")
 }
 
+// GenerateIPv6FrontendIPConfigName generates the name of the IPv6 frontend IP config of an outbound load balancer.
+func GenerateIPv6FrontendIPConfigName(lbName string) string {
+	return fmt.Sprintf("%s-%s", lbName, "frontEnd-ipv6")
+}
+
+// GenerateIPv6PublicIPName generates the name of the IPv6 public IP of an outbound load balancer.
+func GenerateIPv6PublicIPName(lbName string) string {
+	return fmt.Sprintf("pip-%s-%s", lbName, "ipv6")
+}
+
+// GenerateIPv6BackendAddressPoolName generates the name of the IPv6 backend pool of an outbound load balancer from
+// the name of its IPv4 backend pool.
+func GenerateIPv6BackendAddressPoolName(poolName string) string {
+	return fmt.Sprintf("%s-%s", poolName, "ipv6")
+}
+
 // GenerateNatGatewayIPName generates a NAT gateway IP name.
 func GenerateNatGatewayIPName(clusterName, subnetName string) string {
 	return fmt.Sprintf("pip-%s-%s-natgw", clusterName, subnetName)
@@ -203,6 +225,31 @@ func GenerateAvailabilitySetName(clusterName, nodeGroup string) string {
 	return fmt.Sprintf("%s_%s-as", clusterName, nodeGroup)
 }
 
+// GenerateControlPlaneApplicationSecurityGroupName generates the name of the application security group of the control plane machines, based on the cluster name.
+func GenerateControlPlaneApplicationSecurityGroupName(clusterName string) string {
+	return fmt.Sprintf("%s-controlplane-asg", clusterName)
+}
+
+// GenerateNodeApplicationSecurityGroupName generates the name of the application security group of the worker machines, based on the cluster name.
+func GenerateNodeApplicationSecurityGroupName(clusterName string) string {
+	return fmt.Sprintf("%s-node-asg", clusterName)
+}
+
+// GenerateNetworkWatcherName generates the name of the network watcher created by Azure in a location.
+func GenerateNetworkWatcherName(location string) string {
+	return fmt.Sprintf("NetworkWatcher_%s", location)
+}
+
+// GenerateFlowLogName generates the name of the flow log of a security group.
+func GenerateFlowLogName(nsgName string) string {
+	return fmt.Sprintf("%s-flowlog", nsgName)
+}
+
+// GenerateDiagnosticSettingName generates the name of the diagnostic setting of an Azure resource.
+func GenerateDiagnosticSettingName(resourceName string) string {
+	return fmt.Sprintf("%s-diagnostics", resourceName)
+}
+
 // WithIndex appends the index as suffix to a generated name.
 func WithIndex(name string, n int) string {
 	return fmt.Sprintf("%s-%d", name, n)
@@ -233,6 +280,11 @@ func PublicIPID(subscriptionID, resourceGroup, ipName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPAddresses/%s", subscriptionID, resourceGroup, ipName)
 }
 
+// PublicIPPrefixID returns the azure resource ID for a given public IP prefix.
+func PublicIPPrefixID(subscriptionID, resourceGroup, prefixName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/publicIPPrefixes/%s", subscriptionID, resourceGroup, prefixName)
+}
+
 // RouteTableID returns the azure resource ID for a given route table.
 func RouteTableID(subscriptionID, resourceGroup, routeTableName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/routeTables/%s", subscriptionID, resourceGroup, routeTableName)
@@ -243,6 +295,21 @@ func SecurityGroupID(subscriptionID, resourceGroup, nsgName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/networkSecurityGroups/%s", subscriptionID, resourceGroup, nsgName)
 }
 
+// LoadBalancerID returns the azure resource ID for a given load balancer.
+func LoadBalancerID(subscriptionID, resourceGroup, loadBalancerName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers/%s", subscriptionID, resourceGroup, loadBalancerName)
+}
+
+// BastionHostID returns the azure resource ID for a given bastion host.
+func BastionHostID(subscriptionID, resourceGroup, bastionName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/bastionHosts/%s", subscriptionID, resourceGroup, bastionName)
+}
+
+// ApplicationSecurityGroupID returns the azure resource ID for a given application security group.
+func ApplicationSecurityGroupID(subscriptionID, resourceGroup, asgName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/applicationSecurityGroups/%s", subscriptionID, resourceGroup, asgName)
+}
+
 // NatGatewayID returns the azure resource ID for a given NAT gateway.
 func NatGatewayID(subscriptionID, resourceGroup, natgatewayName string) string {
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/natGateways/%s", subscriptionID, resourceGroup, natgatewayName)
@@ -278,6 +345,27 @@ func AvailabilitySetID(subscriptionID, resourceGroup, availabilitySetName string
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/availabilitySets/%s", subscriptionID, resourceGroup, availabilitySetName)
 }
 
+// DedicatedHostGroupID returns the azure resource ID for a given dedicated host group.
+func DedicatedHostGroupID(subscriptionID, resourceGroup, hostGroupName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/hostGroups/%s", subscriptionID, resourceGroup, hostGroupName)
+}
+
+// DedicatedHostID returns the azure resource ID for a given dedicated host.
+func DedicatedHostID(subscriptionID, resourceGroup, hostGroupName, hostName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/hostGroups/%s/hosts/%s", subscriptionID, resourceGroup, hostGroupName, hostName)
+}
+
+// ProximityPlacementGroupID returns the azure resource ID for a given proximity placement group.
+func ProximityPlacementGroupID(subscriptionID, resourceGroup, proximityPlacementGroupName string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/proximityPlacementGroups/%s", subscriptionID, resourceGroup, proximityPlacementGroupName)
+}
+
+// CapacityReservationID returns the azure resource ID for a given capacity reservation, given the resource ID of its
+// capacity reservation group.
+func CapacityReservationID(capacityReservationGroupID, capacityReservationName string) string {
+	return fmt.Sprintf("%s/capacityReservations/%s", capacityReservationGroupID, capacityReservationName)
+}
+
 // PrivateDNSZoneID returns the azure resource ID for a given private DNS zone.
 func PrivateDNSZoneID(subscriptionID, resourceGroup, privateDNSZoneName string) string {
 	return fmt.Sprintf("subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/privateDnsZones/%s", subscriptionID, resourceGroup, privateDNSZoneName)
@@ -339,7 +427,9 @@ func SetAutoRestClientDefaults(c *autorest.Client, auth autorest.Authorizer) {
 	// Wrap the original Sender on the autorest.Client c.
 	// The wrapped Sender should set the x-ms-correlation-request-id on the given
 	// request, then pass the new request to the underlying Sender.
-	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator)
+	// Requests also wait for the process-wide rate limiter of their subscription, so that the clusters sharing a
+	// subscription stay under the Azure Resource Manager throttling limits.
+	c.Sender = autorest.DecorateSender(c.Sender, msCorrelationIDSendDecorator, ratelimit.SendDecorator)
 	// The default number of retries is 3. This means the client will attempt to retry operation results like resource
 	// conflicts (HTTP 409). For a reconciling controller, this is undesirable behavior since if the controller runs
 	// into an error reconciling, the controller would be better off to end with an error and try again later.
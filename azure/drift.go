@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DriftDetectionMode defines whether the drift of existing Azure resources from their desired state is detected,
+// and whether drifted resources are then updated.
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionDisabled disables drift detection. This is the default.
+	DriftDetectionDisabled DriftDetectionMode = ""
+	// DriftDetectionEnabled reports the drift of existing Azure resources, which are then updated as usual.
+	DriftDetectionEnabled DriftDetectionMode = "enabled"
+	// DriftDetectionReportOnly reports the drift of existing Azure resources without updating them.
+	// Missing resources are still created.
+	DriftDetectionReportOnly DriftDetectionMode = "report-only"
+)
+
+// Difference is a difference between the desired and the actual value of a property of an Azure resource.
+type Difference struct {
+	// Path is the path of the property in the JSON representation of the resource, e.g. "properties.idleTimeoutInMinutes".
+	Path    string
+	Desired interface{}
+	Actual  interface{}
+}
+
+// String returns a human-readable representation of the difference.
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: desired %s, actual %s", d.Path, jsonString(d.Desired), jsonString(d.Actual))
+}
+
+// ResourceDrift describes how an existing Azure resource drifted from its desired state.
+type ResourceDrift struct {
+	ServiceName   string
+	ResourceGroup string
+	ResourceName  string
+	Differences   []Difference
+}
+
+// String returns a human-readable representation of the drift.
+func (d ResourceDrift) String() string {
+	differences := make([]string, len(d.Differences))
+	for i, difference := range d.Differences {
+		differences[i] = difference.String()
+	}
+	return fmt.Sprintf("resource %s/%s (service: %s) drifted from its desired state: %s", d.ResourceGroup, d.ResourceName, d.ServiceName, strings.Join(differences, "; "))
+}
+
+// Diff returns the differences between the desired and the actual state of an Azure resource, sorted by path.
+// Both are compared through their JSON representation, and only the properties set in desired are compared
+// since Azure adds defaults and read-only properties to the actual resource. Lists of named sub-resources, such as
+// security rules, are compared by name since Azure doesn't preserve their order, and sub-resources that are not
+// desired are accepted. The properties at ignoredPaths, with "[]" in place of list indexes and names, are not compared.
+func Diff(desired, actual interface{}, ignoredPaths ...string) ([]Difference, error) {
+	desiredJSON, err := toJSONValue(desired)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert the desired resource to JSON")
+	}
+	actualJSON, err := toJSONValue(actual)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert the actual resource to JSON")
+	}
+
+	d := differ{ignoredPaths: make(map[string]bool, len(ignoredPaths))}
+	for _, path := range ignoredPaths {
+		d.ignoredPaths[path] = true
+	}
+	d.diff("", desiredJSON, actualJSON)
+	return d.differences, nil
+}
+
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// listElementRegex matches the index or name of a list element in a difference path, e.g. "[0]" or "[my-rule]".
+var listElementRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+type differ struct {
+	ignoredPaths map[string]bool
+	differences  []Difference
+}
+
+func (df *differ) diff(path string, desired, actual interface{}) {
+	if df.ignoredPaths[listElementRegex.ReplaceAllString(path, "[]")] {
+		return
+	}
+
+	switch d := desired.(type) {
+	case nil:
+		// The property is not set in the desired state, so any actual value is accepted.
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			df.add(path, desired, actual)
+			return
+		}
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			df.diff(keyPath, d[key], a[key])
+		}
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			df.add(path, desired, actual)
+			return
+		}
+		if names, ok := elementNames(d); ok {
+			actualByName := make(map[string]interface{}, len(a))
+			for _, element := range a {
+				if name, ok := elementName(element); ok {
+					actualByName[strings.ToLower(name)] = element
+				}
+			}
+			for i, name := range names {
+				df.diff(fmt.Sprintf("%s[%s]", path, name), d[i], actualByName[strings.ToLower(name)])
+			}
+			return
+		}
+		if len(a) != len(d) {
+			df.add(path, desired, actual)
+			return
+		}
+		for i := range d {
+			df.diff(fmt.Sprintf("%s[%d]", path, i), d[i], a[i])
+		}
+	case string:
+		a, ok := actual.(string)
+		// Azure doesn't preserve the case of the resource IDs it returns, and sub-resources are matched by name
+		// regardless of its case.
+		if ok && (d == a || (isCaseInsensitivePath(path) && strings.EqualFold(d, a))) {
+			return
+		}
+		df.add(path, desired, actual)
+	default:
+		if !reflect.DeepEqual(desired, actual) {
+			df.add(path, desired, actual)
+		}
+	}
+}
+
+func (df *differ) add(path string, desired, actual interface{}) {
+	df.differences = append(df.differences, Difference{Path: path, Desired: desired, Actual: actual})
+}
+
+// elementNames returns the names of the elements of a list, if they are all named sub-resources.
+func elementNames(list []interface{}) ([]string, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	names := make([]string, len(list))
+	for i, element := range list {
+		name, ok := elementName(element)
+		if !ok {
+			return nil, false
+		}
+		names[i] = name
+	}
+	return names, true
+}
+
+func elementName(element interface{}) (string, bool) {
+	object, ok := element.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := object["name"].(string)
+	return name, ok && name != ""
+}
+
+func isCaseInsensitivePath(path string) bool {
+	return path == "id" || strings.HasSuffix(path, ".id") || strings.HasSuffix(path, "].name")
+}
+
+func jsonString(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
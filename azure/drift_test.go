@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestDiff(t *testing.T) {
+	desired := network.PublicIPAddress{
+		Location: pointer.String("eastus"),
+		Tags:     map[string]*string{"owner": pointer.String("capz")},
+		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+			IdleTimeoutInMinutes:     pointer.Int32(4),
+			DNSSettings: &network.PublicIPAddressDNSSettings{
+				DomainNameLabel: pointer.String("my-cluster"),
+			},
+		},
+		Zones: &[]string{"1", "2", "3"},
+	}
+
+	testcases := []struct {
+		name     string
+		actual   network.PublicIPAddress
+		expected []Difference
+	}{
+		{
+			name: "no drift when only properties that are not desired are set",
+			actual: network.PublicIPAddress{
+				Location: pointer.String("eastus"),
+				Tags:     map[string]*string{"owner": pointer.String("capz"), "added": pointer.String("by-azure-policy")},
+				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+					IdleTimeoutInMinutes:     pointer.Int32(4),
+					IPAddress:                pointer.String("1.2.3.4"),
+					DNSSettings: &network.PublicIPAddressDNSSettings{
+						DomainNameLabel: pointer.String("my-cluster"),
+						Fqdn:            pointer.String("my-cluster.eastus.cloudapp.azure.com"),
+					},
+				},
+				Zones: &[]string{"1", "2", "3"},
+			},
+		},
+		{
+			name: "drift of scalar, nested and list properties",
+			actual: network.PublicIPAddress{
+				Location: pointer.String("eastus"),
+				Tags:     map[string]*string{},
+				PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: network.IPAllocationMethodStatic,
+					IdleTimeoutInMinutes:     pointer.Int32(30),
+				},
+				Zones: &[]string{"1"},
+			},
+			expected: []Difference{
+				{Path: "properties.dnsSettings", Desired: map[string]interface{}{"domainNameLabel": "my-cluster"}, Actual: nil},
+				{Path: "properties.idleTimeoutInMinutes", Desired: float64(4), Actual: float64(30)},
+				{Path: "tags.owner", Desired: "capz", Actual: nil},
+				{Path: "zones", Desired: []interface{}{"1", "2", "3"}, Actual: []interface{}{"1"}},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			differences, err := Diff(desired, tc.actual)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(differences).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestDiffIgnoresCaseOfIDs(t *testing.T) {
+	g := NewWithT(t)
+
+	desired := network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			RouteTable: &network.RouteTable{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/routeTables/my-rt")},
+		},
+	}
+	actual := network.Subnet{
+		SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+			RouteTable: &network.RouteTable{ID: pointer.String("/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Network/routeTables/my-rt")},
+		},
+	}
+	differences, err := Diff(desired, actual)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(differences).To(BeEmpty())
+}
+
+func TestDiffComparesNamedSubResourcesByName(t *testing.T) {
+	rule := func(name string, priority int32) network.SecurityRule {
+		return network.SecurityRule{
+			Name: pointer.String(name),
+			SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+				Priority: pointer.Int32(priority),
+				Access:   network.SecurityRuleAccessAllow,
+			},
+		}
+	}
+	desired := network.SecurityGroup{
+		SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+			SecurityRules: &[]network.SecurityRule{rule("allow_ssh", 2200), rule("allow_apiserver", 2201)},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		actual   []network.SecurityRule
+		expected []Difference
+	}{
+		{
+			name:   "no drift when rules are reordered and other rules are added",
+			actual: []network.SecurityRule{rule("allow_apiserver", 2201), rule("added_by_user", 100), rule("ALLOW_SSH", 2200)},
+		},
+		{
+			name:   "drift of a rule and a missing rule",
+			actual: []network.SecurityRule{rule("allow_ssh", 100)},
+			expected: []Difference{
+				{Path: "properties.securityRules[allow_ssh].properties.priority", Desired: float64(2200), Actual: float64(100)},
+				{
+					Path:    "properties.securityRules[allow_apiserver]",
+					Desired: map[string]interface{}{"name": "allow_apiserver", "properties": map[string]interface{}{"access": "Allow", "priority": float64(2201)}},
+					Actual:  nil,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			actual := network.SecurityGroup{
+				SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{SecurityRules: &tc.actual},
+			}
+			differences, err := Diff(desired, actual)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(differences).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestDiffIgnoredPaths(t *testing.T) {
+	g := NewWithT(t)
+
+	desired := network.LoadBalancer{
+		Location: pointer.String("eastus"),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			Probes: &[]network.Probe{{
+				Name:                  pointer.String("probe"),
+				ProbePropertiesFormat: &network.ProbePropertiesFormat{Port: pointer.Int32(6443), IntervalInSeconds: pointer.Int32(15)},
+			}},
+		},
+	}
+	actual := network.LoadBalancer{
+		Location: pointer.String("EastUS"),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			Probes: &[]network.Probe{{
+				Name:                  pointer.String("probe"),
+				ProbePropertiesFormat: &network.ProbePropertiesFormat{Port: pointer.Int32(6443), IntervalInSeconds: pointer.Int32(5)},
+			}},
+		},
+	}
+	differences, err := Diff(desired, actual, "location", "properties.probes[].properties.intervalInSeconds")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(differences).To(BeEmpty())
+}
+
+func TestResourceDriftString(t *testing.T) {
+	g := NewWithT(t)
+
+	drift := ResourceDrift{
+		ServiceName:   "publicips",
+		ResourceGroup: "my-rg",
+		ResourceName:  "my-publicip",
+		Differences: []Difference{
+			{Path: "properties.idleTimeoutInMinutes", Desired: float64(4), Actual: float64(30)},
+			{Path: "tags.owner", Desired: "capz"},
+		},
+	}
+	g.Expect(drift.String()).To(Equal(`resource my-rg/my-publicip (service: publicips) drifted from its desired state: properties.idleTimeoutInMinutes: desired 4, actual 30; tags.owner: desired "capz", actual <unset>`))
+}
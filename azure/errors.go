@@ -26,7 +26,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
-const codeResourceGroupNotFound = "ResourceGroupNotFound"
+const (
+	codeResourceGroupNotFound                 = "ResourceGroupNotFound"
+	codeAllocationFailed                      = "AllocationFailed"
+	codeZonalAllocationFailed                 = "ZonalAllocationFailed"
+	codeOverconstrainedAllocationRequest      = "OverconstrainedAllocationRequest"
+	codeOverconstrainedZonalAllocationRequest = "OverconstrainedZonalAllocationRequest"
+)
 
 // ResourceGroupNotFound parses the error to check if it's a resource group not found error.
 func ResourceGroupNotFound(err error) bool {
@@ -47,6 +53,23 @@ func ResourceConflict(err error) bool {
 	return errors.As(err, &derr) && derr.StatusCode == 409
 }
 
+// AllocationFailed parses the error to check if Azure could not allocate the capacity an operation on a VM required,
+// for instance when a VM is resized to a size that is not available on its current hardware cluster.
+func AllocationFailed(err error) bool {
+	var serr *azureautorest.ServiceError
+	rerr := &azureautorest.RequestError{}
+	if errors.As(err, &rerr) && rerr.ServiceError != nil {
+		serr = rerr.ServiceError
+	} else if !errors.As(err, &serr) {
+		return false
+	}
+	switch serr.Code {
+	case codeAllocationFailed, codeZonalAllocationFailed, codeOverconstrainedAllocationRequest, codeOverconstrainedZonalAllocationRequest:
+		return true
+	}
+	return false
+}
+
 // VMDeletedError is returned when a virtual machine is deleted outside of capz.
 type VMDeletedError struct {
 	ProviderID string
@@ -57,6 +80,16 @@ func (vde VMDeletedError) Error() string {
 	return fmt.Sprintf("VM with provider id %q has been deleted", vde.ProviderID)
 }
 
+// VMEvictedError is returned when a Spot virtual machine has been evicted by Azure.
+type VMEvictedError struct {
+	ProviderID string
+}
+
+// Error returns the error string.
+func (vee VMEvictedError) Error() string {
+	return fmt.Sprintf("Spot VM with provider id %q has been evicted", vee.ProviderID)
+}
+
 // ReconcileError represents an error that is not automatically recoverable
 // errorType indicates what type of action is required to recover. It can take two values:
 // 1. `Transient` - Can be recovered through manual intervention, will be requeued after.
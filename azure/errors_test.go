@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestAllocationFailed(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "allocation failure returned by a request",
+			err: autorest.NewErrorWithError(&azureautorest.RequestError{
+				ServiceError: &azureautorest.ServiceError{Code: "AllocationFailed"},
+			}, "", "", &http.Response{StatusCode: http.StatusConflict}, "Allocation failed"),
+			want: true,
+		},
+		{
+			name: "zonal allocation failure returned by a long running operation",
+			err:  errors.Wrap(&azureautorest.ServiceError{Code: "ZonalAllocationFailed"}, "failed to run operation"),
+			want: true,
+		},
+		{
+			name: "other service error",
+			err:  &azureautorest.ServiceError{Code: "OperationNotAllowed"},
+			want: false,
+		},
+		{
+			name: "not a service error",
+			err:  autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"),
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(AllocationFailed(tc.err)).To(Equal(tc.want))
+		})
+	}
+}
@@ -37,6 +37,13 @@ type ServiceReconciler interface {
 	Reconciler
 }
 
+// ServiceDependencies is implemented by ServiceReconcilers that must be reconciled after other services.
+type ServiceDependencies interface {
+	// Dependencies returns the names of the services that must be reconciled before this service,
+	// and deleted after it.
+	Dependencies() []string
+}
+
 // Authorizer is an interface which can get the subscription ID, base URI, and authorizer for an Azure service.
 type Authorizer interface {
 	SubscriptionID() string
@@ -59,6 +66,7 @@ type NetworkDescriber interface {
 	NodeSubnets() []infrav1.SubnetSpec
 	SetSubnet(infrav1.SubnetSpec)
 	IsIPv6Enabled() bool
+	IsIPv6Only() bool
 	ControlPlaneRouteTable() infrav1.RouteTable
 	APIServerLB() *infrav1.LoadBalancerSpec
 	APIServerLBName() string
@@ -91,10 +99,42 @@ type AsyncStatusUpdater interface {
 	UpdatePatchStatus(clusterv1.ConditionType, string, error)
 }
 
+// DriftReporter is implemented by scopes that detect and report the drift of the existing Azure resources they manage.
+type DriftReporter interface {
+	// DriftDetectionMode returns whether drift is detected, and whether drifted resources are updated.
+	DriftDetectionMode() DriftDetectionMode
+	// ReportDrift records the drift of an Azure resource.
+	ReportDrift(ResourceDrift)
+}
+
+// DedicatedHostDescriber is implemented by scopes that manage the dedicated host groups of a cluster.
+type DedicatedHostDescriber interface {
+	// DedicatedHostGroupZone returns the availability zone of the dedicated host group with the given resource ID, and
+	// whether the group is managed by the cluster.
+	DedicatedHostGroupZone(hostGroupID string) (zone string, ok bool)
+}
+
+// OperationPlanner is implemented by scopes that can be reconciled in dry-run mode, where the Azure API calls that
+// would create, update or delete resources are recorded in a plan instead of being made.
+type OperationPlanner interface {
+	// DryRun returns whether resources are only planned to be created, updated or deleted.
+	DryRun() bool
+	// PlanOperation records an Azure API call that would have been made outside of dry-run mode.
+	PlanOperation(PlannedOperation)
+}
+
+// OperationRecorder is implemented by scopes that keep a history of the Azure operations that created, updated or
+// deleted their resources.
+type OperationRecorder interface {
+	// RecordOperation records an Azure operation, or the completion of an in-progress one.
+	RecordOperation(infrav1.Operation)
+}
+
 // ClusterScoper combines the ClusterDescriber and NetworkDescriber interfaces.
 type ClusterScoper interface {
 	ClusterDescriber
 	NetworkDescriber
+	DedicatedHostDescriber
 }
 
 // ManagedClusterScoper defines the interface for ManagedClusterScope.
@@ -124,3 +164,14 @@ type ResourceSpecGetterWithHeaders interface {
 	// CustomHeaders returns the headers that should be added to Azure API calls.
 	CustomHeaders() map[string]string
 }
+
+// ResourceSpecGetterWithDrift is a ResourceSpecGetter that customizes how the drift of its existing resource is detected.
+type ResourceSpecGetterWithDrift interface {
+	ResourceSpecGetter
+	// DesiredParameters returns the parameters the existing resource is compared with, instead of the parameters
+	// returned by Parameters when the resource does not exist.
+	DesiredParameters(ctx context.Context) (interface{}, error)
+	// DriftIgnoredPaths returns the paths of the properties that are not compared, such as secrets that Azure never
+	// returns. Paths use the format of Difference.Path, with "[]" in place of list indexes and names.
+	DriftIgnoredPaths() []string
+}
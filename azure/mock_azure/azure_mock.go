@@ -161,6 +161,43 @@ func (mr *MockServiceReconcilerMockRecorder) Reconcile(ctx interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockServiceReconciler)(nil).Reconcile), ctx)
 }
 
+// MockServiceDependencies is a mock of ServiceDependencies interface.
+type MockServiceDependencies struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceDependenciesMockRecorder
+}
+
+// MockServiceDependenciesMockRecorder is the mock recorder for MockServiceDependencies.
+type MockServiceDependenciesMockRecorder struct {
+	mock *MockServiceDependencies
+}
+
+// NewMockServiceDependencies creates a new mock instance.
+func NewMockServiceDependencies(ctrl *gomock.Controller) *MockServiceDependencies {
+	mock := &MockServiceDependencies{ctrl: ctrl}
+	mock.recorder = &MockServiceDependenciesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceDependencies) EXPECT() *MockServiceDependenciesMockRecorder {
+	return m.recorder
+}
+
+// Dependencies mocks base method.
+func (m *MockServiceDependencies) Dependencies() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dependencies")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Dependencies indicates an expected call of Dependencies.
+func (mr *MockServiceDependenciesMockRecorder) Dependencies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dependencies", reflect.TypeOf((*MockServiceDependencies)(nil).Dependencies))
+}
+
 // MockAuthorizer is a mock of Authorizer interface.
 type MockAuthorizer struct {
 	ctrl     *gomock.Controller
@@ -431,6 +468,20 @@ func (mr *MockNetworkDescriberMockRecorder) IsIPv6Enabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Enabled", reflect.TypeOf((*MockNetworkDescriber)(nil).IsIPv6Enabled))
 }
 
+// IsIPv6Only mocks base method.
+func (m *MockNetworkDescriber) IsIPv6Only() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIPv6Only")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIPv6Only indicates an expected call of IsIPv6Only.
+func (mr *MockNetworkDescriberMockRecorder) IsIPv6Only() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Only", reflect.TypeOf((*MockNetworkDescriber)(nil).IsIPv6Only))
+}
+
 // IsVnetManaged mocks base method.
 func (m *MockNetworkDescriber) IsVnetManaged() bool {
 	m.ctrl.T.Helper()
@@ -1090,6 +1141,21 @@ func (mr *MockClusterScoperMockRecorder) ControlPlaneSubnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneSubnet", reflect.TypeOf((*MockClusterScoper)(nil).ControlPlaneSubnet))
 }
 
+// DedicatedHostGroupZone mocks base method.
+func (m *MockClusterScoper) DedicatedHostGroupZone(hostGroupID string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DedicatedHostGroupZone", hostGroupID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// DedicatedHostGroupZone indicates an expected call of DedicatedHostGroupZone.
+func (mr *MockClusterScoperMockRecorder) DedicatedHostGroupZone(hostGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedHostGroupZone", reflect.TypeOf((*MockClusterScoper)(nil).DedicatedHostGroupZone), hostGroupID)
+}
+
 // FailureDomains mocks base method.
 func (m *MockClusterScoper) FailureDomains() []string {
 	m.ctrl.T.Helper()
@@ -1160,6 +1226,20 @@ func (mr *MockClusterScoperMockRecorder) IsIPv6Enabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Enabled", reflect.TypeOf((*MockClusterScoper)(nil).IsIPv6Enabled))
 }
 
+// IsIPv6Only mocks base method.
+func (m *MockClusterScoper) IsIPv6Only() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIPv6Only")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIPv6Only indicates an expected call of IsIPv6Only.
+func (mr *MockClusterScoperMockRecorder) IsIPv6Only() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Only", reflect.TypeOf((*MockClusterScoper)(nil).IsIPv6Only))
+}
+
 // IsVnetManaged mocks base method.
 func (m *MockClusterScoper) IsVnetManaged() bool {
 	m.ctrl.T.Helper()
@@ -1746,3 +1826,112 @@ func (mr *MockResourceSpecGetterWithHeadersMockRecorder) ResourceName() *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceName", reflect.TypeOf((*MockResourceSpecGetterWithHeaders)(nil).ResourceName))
 }
+
+// MockResourceSpecGetterWithDrift is a mock of ResourceSpecGetterWithDrift interface.
+type MockResourceSpecGetterWithDrift struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourceSpecGetterWithDriftMockRecorder
+}
+
+// MockResourceSpecGetterWithDriftMockRecorder is the mock recorder for MockResourceSpecGetterWithDrift.
+type MockResourceSpecGetterWithDriftMockRecorder struct {
+	mock *MockResourceSpecGetterWithDrift
+}
+
+// NewMockResourceSpecGetterWithDrift creates a new mock instance.
+func NewMockResourceSpecGetterWithDrift(ctrl *gomock.Controller) *MockResourceSpecGetterWithDrift {
+	mock := &MockResourceSpecGetterWithDrift{ctrl: ctrl}
+	mock.recorder = &MockResourceSpecGetterWithDriftMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResourceSpecGetterWithDrift) EXPECT() *MockResourceSpecGetterWithDriftMockRecorder {
+	return m.recorder
+}
+
+// DesiredParameters mocks base method.
+func (m *MockResourceSpecGetterWithDrift) DesiredParameters(ctx context.Context) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DesiredParameters", ctx)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DesiredParameters indicates an expected call of DesiredParameters.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) DesiredParameters(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DesiredParameters", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).DesiredParameters), ctx)
+}
+
+// DriftIgnoredPaths mocks base method.
+func (m *MockResourceSpecGetterWithDrift) DriftIgnoredPaths() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DriftIgnoredPaths")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DriftIgnoredPaths indicates an expected call of DriftIgnoredPaths.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) DriftIgnoredPaths() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DriftIgnoredPaths", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).DriftIgnoredPaths))
+}
+
+// OwnerResourceName mocks base method.
+func (m *MockResourceSpecGetterWithDrift) OwnerResourceName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnerResourceName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// OwnerResourceName indicates an expected call of OwnerResourceName.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) OwnerResourceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnerResourceName", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).OwnerResourceName))
+}
+
+// Parameters mocks base method.
+func (m *MockResourceSpecGetterWithDrift) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parameters", ctx, existing)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parameters indicates an expected call of Parameters.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) Parameters(ctx, existing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parameters", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).Parameters), ctx, existing)
+}
+
+// ResourceGroupName mocks base method.
+func (m *MockResourceSpecGetterWithDrift) ResourceGroupName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceGroupName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceGroupName indicates an expected call of ResourceGroupName.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) ResourceGroupName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceGroupName", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).ResourceGroupName))
+}
+
+// ResourceName mocks base method.
+func (m *MockResourceSpecGetterWithDrift) ResourceName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ResourceName indicates an expected call of ResourceName.
+func (mr *MockResourceSpecGetterWithDriftMockRecorder) ResourceName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceName", reflect.TypeOf((*MockResourceSpecGetterWithDrift)(nil).ResourceName))
+}
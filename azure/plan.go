@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// OperationMethod is the HTTP method of an Azure API call that creates, updates or deletes a resource.
+type OperationMethod string
+
+const (
+	// PutOperation creates or updates a resource.
+	PutOperation OperationMethod = "PUT"
+	// PatchOperation partially updates a resource, e.g. its tags.
+	PatchOperation OperationMethod = "PATCH"
+	// PostOperation performs an action on a resource, e.g. deallocates a VM.
+	PostOperation OperationMethod = "POST"
+	// DeleteOperation deletes a resource.
+	DeleteOperation OperationMethod = "DELETE"
+)
+
+// PlannedOperation is an Azure API call that a controller would have made to reconcile a resource
+// if it was not running in dry-run mode.
+type PlannedOperation struct {
+	ServiceName   string          `json:"service"`
+	Method        OperationMethod `json:"method"`
+	ResourceGroup string          `json:"resourceGroup,omitempty"`
+	// ResourceName is the name of the resource, or its ID for operations on a resource scope such as tags.
+	ResourceName string `json:"resourceName"`
+	// Parameters is the payload of the PUT and PATCH operations.
+	Parameters interface{} `json:"parameters,omitempty"`
+}
+
+// String returns a human-readable representation of the operation, without its parameters.
+func (o PlannedOperation) String() string {
+	if o.ResourceGroup == "" {
+		return fmt.Sprintf("%s %s (service: %s)", o.Method, o.ResourceName, o.ServiceName)
+	}
+	return fmt.Sprintf("%s %s/%s (service: %s)", o.Method, o.ResourceGroup, o.ResourceName, o.ServiceName)
+}
+
+// Plan is the ordered list of the operations a controller would have made during a reconcile in dry-run mode.
+type Plan []PlannedOperation
+
+// JSON returns the indented JSON representation of the plan.
+func (p Plan) JSON() ([]byte, error) {
+	if p == nil {
+		p = Plan{}
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the plan to JSON")
+	}
+	return data, nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPlannedOperationString(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(PlannedOperation{ServiceName: "publicips", Method: PutOperation, ResourceGroup: "my-rg", ResourceName: "my-publicip"}.String()).To(Equal("PUT my-rg/my-publicip (service: publicips)"))
+	g.Expect(PlannedOperation{ServiceName: "tags", Method: PatchOperation, ResourceName: "/subscriptions/123/resourceGroups/my-rg"}.String()).To(Equal("PATCH /subscriptions/123/resourceGroups/my-rg (service: tags)"))
+}
+
+func TestPlanJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := Plan(nil).JSON()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(data)).To(Equal("[]"))
+
+	data, err = Plan{
+		{ServiceName: "groups", Method: PutOperation, ResourceName: "my-rg", Parameters: map[string]string{"location": "eastus"}},
+		{ServiceName: "virtualnetworks", Method: DeleteOperation, ResourceGroup: "my-rg", ResourceName: "my-vnet"},
+	}.JSON()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(data).To(MatchJSON(`[
+		{"service": "groups", "method": "PUT", "resourceName": "my-rg", "parameters": {"location": "eastus"}},
+		{"service": "virtualnetworks", "method": "DELETE", "resourceGroup": "my-rg", "resourceName": "my-vnet"}
+	]`))
+}
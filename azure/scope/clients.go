@@ -21,12 +21,14 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/pkg/errors"
 	azureutil "sigs.k8s.io/cluster-api-provider-azure/util/azure"
 )
 
@@ -73,6 +75,63 @@ func (c *AzureClients) HashKey() string {
 	return base64.URLEncoding.EncodeToString(hasher.Sum(nil))
 }
 
+// clientsAuthorizer is an azure.Authorizer for AzureClients that are not embedded in a scope.
+type clientsAuthorizer struct {
+	*AzureClients
+}
+
+// BaseURI returns the Azure ResourceManagerEndpoint.
+func (a *clientsAuthorizer) BaseURI() string {
+	return a.ResourceManagerEndpoint
+}
+
+// Authorizer returns the Azure client Authorizer.
+func (a *clientsAuthorizer) Authorizer() autorest.Authorizer {
+	return a.AzureClients.Authorizer
+}
+
+// auxiliaryTokenHeader is the header of the tokens of the other tenants whose resources are referenced by a request.
+const auxiliaryTokenHeader = "x-ms-authorization-auxiliary"
+
+// auxiliaryTokenAuthorizer is an autorest.Authorizer that authorizes requests with a primary authorizer, and adds the
+// tokens of auxiliary authorizers of other tenants to them, e.g. to peer with a virtual network of another tenant.
+type auxiliaryTokenAuthorizer struct {
+	primary     autorest.Authorizer
+	auxiliaries []autorest.Authorizer
+}
+
+// newAuxiliaryTokenAuthorizer creates a new auxiliaryTokenAuthorizer.
+func newAuxiliaryTokenAuthorizer(primary autorest.Authorizer, auxiliaries ...autorest.Authorizer) *auxiliaryTokenAuthorizer {
+	return &auxiliaryTokenAuthorizer{
+		primary:     primary,
+		auxiliaries: auxiliaries,
+	}
+}
+
+// WithAuthorization returns a PrepareDecorator that sets the token of the primary authorizer in the Authorization
+// header, and the tokens of the auxiliary authorizers in the x-ms-authorization-auxiliary header.
+func (a *auxiliaryTokenAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := a.primary.WithAuthorization()(p).Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			tokens := make([]string, 0, len(a.auxiliaries))
+			for _, auxiliary := range a.auxiliaries {
+				// The auxiliary authorizer sets its token in the Authorization header of a copy of the request.
+				authorized, err := auxiliary.WithAuthorization()(autorest.CreatePreparer()).Prepare(r.Clone(r.Context()))
+				if err != nil {
+					return r, errors.Wrap(err, "failed to get auxiliary token")
+				}
+				tokens = append(tokens, authorized.Header.Get("Authorization"))
+			}
+			r.Header.Set(auxiliaryTokenHeader, strings.Join(tokens, ", "))
+			return r, nil
+		})
+	}
+}
+
 func (c *AzureClients) setCredentials(subscriptionID, environmentName string) error {
 	settings, err := c.getSettingsFromEnvironment(environmentName)
 	if err != nil {
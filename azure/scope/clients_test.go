@@ -17,6 +17,8 @@ limitations under the License.
 package scope
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -82,3 +84,27 @@ func TestGettingEnvironment(t *testing.T) {
 		})
 	}
 }
+
+// fakeTokenProvider is an adal.OAuthTokenProvider with a static token.
+type fakeTokenProvider string
+
+func (f fakeTokenProvider) OAuthToken() string {
+	return string(f)
+}
+
+func TestAuxiliaryTokenAuthorizer(t *testing.T) {
+	g := NewWithT(t)
+
+	authorizer := newAuxiliaryTokenAuthorizer(
+		autorest.NewBearerAuthorizer(fakeTokenProvider("primary-token")),
+		autorest.NewBearerAuthorizer(fakeTokenProvider("tenant1-token")),
+		autorest.NewBearerAuthorizer(fakeTokenProvider("tenant2-token")),
+	)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPut, "https://management.azure.com/subscriptions/123", http.NoBody)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	req, err = autorest.Prepare(req, authorizer.WithAuthorization())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(req.Header.Get("Authorization")).To(Equal("Bearer primary-token"))
+	g.Expect(req.Header.Get("x-ms-authorization-auxiliary")).To(Equal("Bearer tenant1-token, Bearer tenant2-token"))
+}
@@ -24,19 +24,28 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/net"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
@@ -44,6 +53,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -113,6 +123,14 @@ type ClusterScope struct {
 	Client      client.Client
 	patchHelper *patch.Helper
 	cache       *ClusterCache
+	// mu guards the fields of the AzureCluster that services update while they are reconciled in parallel:
+	// the subnets, the status conditions and futures, the private link service alias, and the annotations.
+	// It also guards drifts and plan.
+	mu sync.Mutex
+	// drifts holds the drift of the Azure resources detected during this reconcile.
+	drifts []azure.ResourceDrift
+	// plan holds the operations planned during this reconcile in dry-run mode.
+	plan azure.Plan
 
 	AzureClients
 	Cluster      *clusterv1.Cluster
@@ -145,31 +163,54 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 		if s.ControlPlaneOutboundLB() != nil {
 			for _, ip := range s.ControlPlaneOutboundLB().FrontendIPs {
 				controlPlaneOutboundIPSpecs = append(controlPlaneOutboundIPSpecs, &publicips.PublicIPSpec{
-					Name:           ip.PublicIP.Name,
-					ResourceGroup:  s.ResourceGroup(),
-					ClusterName:    s.ClusterName(),
-					DNSName:        "",    // Set to default value
-					IsIPv6:         false, // Set to default value
-					Location:       s.Location(),
-					FailureDomains: s.FailureDomains(),
-					AdditionalTags: s.AdditionalTags(),
+					Name:             ip.PublicIP.Name,
+					ResourceGroup:    s.ResourceGroup(),
+					ClusterName:      s.ClusterName(),
+					DNSName:          "",    // Set to default value
+					IsIPv6:           false, // Set to default value
+					Location:         s.Location(),
+					FailureDomains:   s.FailureDomains(),
+					AdditionalTags:   s.AdditionalTags(),
+					PublicIPPrefixID: s.publicIPPrefixID(s.ControlPlaneOutboundLB().PublicIPPrefix),
 				})
 			}
+			if s.IsIPv6Enabled() {
+				controlPlaneOutboundIPSpecs = append(controlPlaneOutboundIPSpecs, s.outboundLBIPv6PublicIPSpec(s.ControlPlaneOutboundLB()))
+			}
 		}
 	} else {
 		controlPlaneOutboundIPSpecs = []azure.ResourceSpecGetter{
 			&publicips.PublicIPSpec{
-				Name:           s.APIServerPublicIP().Name,
-				ResourceGroup:  s.ResourceGroup(),
-				DNSName:        s.APIServerPublicIP().DNSName,
-				IsIPv6:         false, // Currently azure requires an IPv4 lb rule to enable IPv6
-				ClusterName:    s.ClusterName(),
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
-				IPTags:         s.APIServerPublicIP().IPTags,
+				Name:             s.APIServerPublicIP().Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          s.APIServerPublicIP().DNSName,
+				IsIPv6:           false, // Currently azure requires an IPv4 lb rule to enable IPv6
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				AdditionalTags:   s.AdditionalTags(),
+				IPTags:           s.APIServerPublicIP().IPTags,
+				PublicIPPrefixID: s.publicIPPrefixID(s.APIServerLB().PublicIPPrefix),
 			},
 		}
+		// The additional frontends of the API server load balancer are only used by its additional rules.
+		for i, ip := range s.APIServerLB().FrontendIPs {
+			if i == 0 || ip.PublicIP == nil {
+				continue
+			}
+			controlPlaneOutboundIPSpecs = append(controlPlaneOutboundIPSpecs, &publicips.PublicIPSpec{
+				Name:             ip.PublicIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          ip.PublicIP.DNSName,
+				IsIPv6:           false,
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				AdditionalTags:   s.AdditionalTags(),
+				IPTags:           ip.PublicIP.IPTags,
+				PublicIPPrefixID: s.publicIPPrefixID(s.APIServerLB().PublicIPPrefix),
+			})
+		}
 	}
 	publicIPSpecs = append(publicIPSpecs, controlPlaneOutboundIPSpecs...)
 
@@ -177,16 +218,20 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	if s.NodeOutboundLB() != nil {
 		for _, ip := range s.NodeOutboundLB().FrontendIPs {
 			publicIPSpecs = append(publicIPSpecs, &publicips.PublicIPSpec{
-				Name:           ip.PublicIP.Name,
-				ResourceGroup:  s.ResourceGroup(),
-				ClusterName:    s.ClusterName(),
-				DNSName:        "",    // Set to default value
-				IsIPv6:         false, // Set to default value
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
+				Name:             ip.PublicIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				ClusterName:      s.ClusterName(),
+				DNSName:          "",    // Set to default value
+				IsIPv6:           false, // Set to default value
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				AdditionalTags:   s.AdditionalTags(),
+				PublicIPPrefixID: s.publicIPPrefixID(s.NodeOutboundLB().PublicIPPrefix),
 			})
 		}
+		if s.IsIPv6Enabled() {
+			publicIPSpecs = append(publicIPSpecs, s.outboundLBIPv6PublicIPSpec(s.NodeOutboundLB()))
+		}
 	}
 
 	// Public IP specs for node NAT gateways
@@ -194,15 +239,16 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	for _, subnet := range s.NodeSubnets() {
 		if subnet.IsNatGatewayEnabled() {
 			nodeNatGatewayIPSpecs = append(nodeNatGatewayIPSpecs, &publicips.PublicIPSpec{
-				Name:           subnet.NatGateway.NatGatewayIP.Name,
-				ResourceGroup:  s.ResourceGroup(),
-				DNSName:        subnet.NatGateway.NatGatewayIP.DNSName,
-				IsIPv6:         false, // Public IP is IPv4 by default
-				ClusterName:    s.ClusterName(),
-				Location:       s.Location(),
-				FailureDomains: s.FailureDomains(),
-				AdditionalTags: s.AdditionalTags(),
-				IPTags:         subnet.NatGateway.NatGatewayIP.IPTags,
+				Name:             subnet.NatGateway.NatGatewayIP.Name,
+				ResourceGroup:    s.ResourceGroup(),
+				DNSName:          subnet.NatGateway.NatGatewayIP.DNSName,
+				IsIPv6:           false, // Public IP is IPv4 by default
+				ClusterName:      s.ClusterName(),
+				Location:         s.Location(),
+				FailureDomains:   s.FailureDomains(),
+				AdditionalTags:   s.AdditionalTags(),
+				IPTags:           subnet.NatGateway.NatGatewayIP.IPTags,
+				PublicIPPrefixID: s.publicIPPrefixID(subnet.NatGateway.PublicIPPrefix),
 			})
 		}
 		publicIPSpecs = append(publicIPSpecs, nodeNatGatewayIPSpecs...)
@@ -227,6 +273,71 @@ func (s *ClusterScope) PublicIPSpecs() []azure.ResourceSpecGetter {
 	return publicIPSpecs
 }
 
+// PublicIPPrefixSpecs returns the public IP prefix specs of the load balancers and NAT gateways.
+func (s *ClusterScope) PublicIPPrefixSpecs() []azure.ResourceSpecGetter {
+	prefixSet := make(map[string]struct{})
+	var prefixSpecs []azure.ResourceSpecGetter
+	addPrefix := func(prefix *infrav1.PublicIPPrefixSpec) {
+		if prefix == nil {
+			return
+		}
+		key := strings.ToLower(prefix.ResourceGroup + "/" + prefix.Name)
+		if _, ok := prefixSet[key]; ok {
+			return
+		}
+		prefixSet[key] = struct{}{} // empty struct to represent hash set
+		prefixSpecs = append(prefixSpecs, &publicipprefixes.PublicIPPrefixSpec{
+			Name:           prefix.Name,
+			ResourceGroup:  prefix.ResourceGroup,
+			ClusterName:    s.ClusterName(),
+			Location:       s.Location(),
+			PrefixLength:   prefix.PrefixLength,
+			FailureDomains: s.FailureDomains(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+
+	if s.IsAPIServerPrivate() {
+		if s.ControlPlaneOutboundLB() != nil {
+			addPrefix(s.ControlPlaneOutboundLB().PublicIPPrefix)
+		}
+	} else {
+		addPrefix(s.APIServerLB().PublicIPPrefix)
+	}
+	if s.NodeOutboundLB() != nil {
+		addPrefix(s.NodeOutboundLB().PublicIPPrefix)
+	}
+	for _, subnet := range s.NodeSubnets() {
+		if subnet.IsNatGatewayEnabled() {
+			addPrefix(subnet.NatGateway.PublicIPPrefix)
+		}
+	}
+
+	return prefixSpecs
+}
+
+// publicIPPrefixID returns the ID of the public IP prefix to allocate public IPs from, or an empty string if there is none.
+func (s *ClusterScope) publicIPPrefixID(prefix *infrav1.PublicIPPrefixSpec) string {
+	if prefix == nil {
+		return ""
+	}
+	return azure.PublicIPPrefixID(s.SubscriptionID(), prefix.ResourceGroup, prefix.Name)
+}
+
+// outboundLBIPv6PublicIPSpec returns the spec of the public IP of the IPv6 frontend of an outbound load balancer. It
+// isn't allocated from the public IP prefix of the load balancer, which only holds IPv4 addresses.
+func (s *ClusterScope) outboundLBIPv6PublicIPSpec(lb *infrav1.LoadBalancerSpec) azure.ResourceSpecGetter {
+	return &publicips.PublicIPSpec{
+		Name:           azure.GenerateIPv6PublicIPName(lb.Name),
+		ResourceGroup:  s.ResourceGroup(),
+		ClusterName:    s.ClusterName(),
+		IsIPv6:         true,
+		Location:       s.Location(),
+		FailureDomains: s.FailureDomains(),
+		AdditionalTags: s.AdditionalTags(),
+	}
+}
+
 // LBSpecs returns the load balancer specs.
 func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 	specs := []azure.ResourceSpecGetter{
@@ -248,6 +359,10 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			BackendPoolName:      s.APIServerLB().BackendPool.Name,
 			IdleTimeoutInMinutes: s.APIServerLB().IdleTimeoutInMinutes,
 			AdditionalTags:       s.AdditionalTags(),
+			// The egress traffic of the control plane goes to the virtual appliance instead.
+			DisableOutboundRule: s.IsUserDefinedRouting(),
+			Probe:               s.APIServerLB().Probe,
+			AdditionalRules:     s.APIServerLB().AdditionalRules,
 		},
 	}
 
@@ -268,6 +383,8 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			IdleTimeoutInMinutes: s.NodeOutboundLB().IdleTimeoutInMinutes,
 			Role:                 infrav1.NodeOutboundRole,
 			AdditionalTags:       s.AdditionalTags(),
+			IPv6Enabled:          s.IsIPv6Enabled(),
+			IPv6Only:             s.IsIPv6Only(),
 		})
 	}
 
@@ -288,6 +405,8 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 			IdleTimeoutInMinutes: s.ControlPlaneOutboundLB().IdleTimeoutInMinutes,
 			Role:                 infrav1.ControlPlaneOutboundRole,
 			AdditionalTags:       s.AdditionalTags(),
+			IPv6Enabled:          s.IsIPv6Enabled(),
+			IPv6Only:             s.IsIPv6Only(),
 		})
 	}
 
@@ -297,15 +416,19 @@ func (s *ClusterScope) LBSpecs() []azure.ResourceSpecGetter {
 // RouteTableSpecs returns the subnet route tables.
 func (s *ClusterScope) RouteTableSpecs() []azure.ResourceSpecGetter {
 	var specs []azure.ResourceSpecGetter
-	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, subnet := range s.Subnets() {
 		if subnet.RouteTable.Name != "" {
-			specs = append(specs, &routetables.RouteTableSpec{
+			spec := &routetables.RouteTableSpec{
 				Name:           subnet.RouteTable.Name,
 				Location:       s.Location(),
 				ResourceGroup:  s.ResourceGroup(),
 				ClusterName:    s.ClusterName(),
 				AdditionalTags: s.AdditionalTags(),
-			})
+			}
+			if s.IsUserDefinedRouting() {
+				spec.NextHopIPAddress = s.AzureCluster.Spec.NetworkSpec.NextHopIPAddress
+			}
+			specs = append(specs, spec)
 		}
 	}
 
@@ -342,12 +465,14 @@ func (s *ClusterScope) NatGatewaySpecs() []azure.ResourceSpecGetter {
 
 // NSGSpecs returns the security group specs.
 func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
-	nsgspecs := make([]azure.ResourceSpecGetter, len(s.AzureCluster.Spec.NetworkSpec.Subnets))
-	for i, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	subnets := s.Subnets()
+	nsgspecs := make([]azure.ResourceSpecGetter, len(subnets))
+	for i, subnet := range subnets {
 		nsgspecs[i] = &securitygroups.NSGSpec{
 			Name:           subnet.SecurityGroup.Name,
 			SecurityRules:  subnet.SecurityGroup.SecurityRules,
 			ResourceGroup:  s.ResourceGroup(),
+			SubscriptionID: s.SubscriptionID(),
 			Location:       s.Location(),
 			ClusterName:    s.ClusterName(),
 			AdditionalTags: s.AdditionalTags(),
@@ -357,16 +482,203 @@ func (s *ClusterScope) NSGSpecs() []azure.ResourceSpecGetter {
 	return nsgspecs
 }
 
+// ApplicationSecurityGroupSpecs returns the application security groups specs: the control plane and node
+// application security groups, followed by the custom application security groups of the cluster.
+func (s *ClusterScope) ApplicationSecurityGroupSpecs() []azure.ResourceSpecGetter {
+	names := []string{
+		azure.GenerateControlPlaneApplicationSecurityGroupName(s.ClusterName()),
+		azure.GenerateNodeApplicationSecurityGroupName(s.ClusterName()),
+	}
+	names = append(names, s.AzureCluster.Spec.NetworkSpec.ApplicationSecurityGroups...)
+
+	asgSpecs := make([]azure.ResourceSpecGetter, 0, len(names))
+	for _, name := range names {
+		asgSpecs = append(asgSpecs, &applicationsecuritygroups.ASGSpec{
+			Name:           name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+
+	return asgSpecs
+}
+
+// FlowLogSpecs returns the flow log specs of the security groups of the subnets.
+func (s *ClusterScope) FlowLogSpecs() []azure.ResourceSpecGetter {
+	// Security groups are only created in managed virtual networks.
+	if !s.IsVnetManaged() {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var specs []azure.ResourceSpecGetter
+	for _, subnet := range s.Subnets() {
+		flowLog := subnet.SecurityGroup.FlowLog
+		if flowLog == nil || subnet.SecurityGroup.Name == "" {
+			continue
+		}
+		if _, ok := seen[subnet.SecurityGroup.Name]; ok {
+			continue
+		}
+		seen[subnet.SecurityGroup.Name] = struct{}{}
+
+		networkWatcherName := flowLog.NetworkWatcherName
+		if networkWatcherName == "" {
+			networkWatcherName = azure.GenerateNetworkWatcherName(s.Location())
+		}
+		networkWatcherResourceGroup := flowLog.NetworkWatcherResourceGroup
+		if networkWatcherResourceGroup == "" {
+			networkWatcherResourceGroup = azure.DefaultNetworkWatcherResourceGroup
+		}
+		specs = append(specs, &flowlogs.FlowLogSpec{
+			Name:               azure.GenerateFlowLogName(subnet.SecurityGroup.Name),
+			ResourceGroup:      networkWatcherResourceGroup,
+			NetworkWatcherName: networkWatcherName,
+			Location:           s.Location(),
+			ClusterName:        s.ClusterName(),
+			SecurityGroupID:    azure.SecurityGroupID(s.SubscriptionID(), s.ResourceGroup(), subnet.SecurityGroup.Name),
+			StorageAccountID:   flowLog.StorageAccountID,
+			RetentionDays:      flowLog.RetentionDays,
+			AdditionalTags:     s.AdditionalTags(),
+		})
+	}
+
+	return specs
+}
+
+// DiagnosticSettingSpecs returns the diagnostic setting specs of the security groups, load balancers, public IPs and
+// bastion host of the cluster.
+func (s *ClusterScope) DiagnosticSettingSpecs() []azure.ResourceSpecGetter {
+	settings := s.AzureCluster.Spec.DiagnosticSettings
+	if settings == nil {
+		return nil
+	}
+
+	var specs []azure.ResourceSpecGetter
+	addSpec := func(resourceName, resourceID string, logCategories []string, metrics bool) {
+		specs = append(specs, &diagnosticsettings.DiagnosticSettingSpec{
+			Name:             azure.GenerateDiagnosticSettingName(resourceName),
+			ResourceGroup:    s.ResourceGroup(),
+			ResourceID:       resourceID,
+			LogCategories:    logCategories,
+			Metrics:          metrics,
+			WorkspaceID:      settings.WorkspaceID,
+			StorageAccountID: settings.StorageAccountID,
+		})
+	}
+
+	// Security groups are only created in managed virtual networks.
+	if s.IsVnetManaged() {
+		seen := make(map[string]struct{})
+		for _, subnet := range s.Subnets() {
+			name := subnet.SecurityGroup.Name
+			if name == "" {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			addSpec(name, azure.SecurityGroupID(s.SubscriptionID(), s.ResourceGroup(), name), diagnosticsettings.SecurityGroupLogCategories, false)
+		}
+	}
+
+	for _, lbSpec := range s.LBSpecs() {
+		name := lbSpec.ResourceName()
+		addSpec(name, azure.LoadBalancerID(s.SubscriptionID(), s.ResourceGroup(), name), nil, true)
+	}
+
+	for _, ipSpec := range s.PublicIPSpecs() {
+		name := ipSpec.ResourceName()
+		addSpec(name, azure.PublicIPID(s.SubscriptionID(), s.ResourceGroup(), name), diagnosticsettings.PublicIPLogCategories, true)
+	}
+
+	if bastionSpec := s.AzureBastionSpec(); bastionSpec != nil {
+		name := bastionSpec.ResourceName()
+		addSpec(name, azure.BastionHostID(s.SubscriptionID(), s.ResourceGroup(), name), diagnosticsettings.BastionHostLogCategories, true)
+	}
+
+	return specs
+}
+
+// DedicatedHostGroupSpecs returns the specs of the dedicated host groups of the cluster.
+func (s *ClusterScope) DedicatedHostGroupSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.AzureCluster.Spec.DedicatedHostGroups))
+	for _, group := range s.AzureCluster.Spec.DedicatedHostGroups {
+		specs = append(specs, &dedicatedhosts.HostGroupSpec{
+			Name:                     group.Name,
+			ResourceGroup:            s.ResourceGroup(),
+			Location:                 s.Location(),
+			ClusterName:              s.ClusterName(),
+			Zone:                     pointer.StringDeref(group.FailureDomain, ""),
+			PlatformFaultDomainCount: group.PlatformFaultDomainCount,
+			AutomaticPlacement:       group.AutomaticPlacement,
+			AdditionalTags:           s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
+// DedicatedHostSpecs returns the specs of the dedicated hosts in the dedicated host groups of the cluster.
+func (s *ClusterScope) DedicatedHostSpecs() []azure.ResourceSpecGetter {
+	var specs []azure.ResourceSpecGetter
+	for _, group := range s.AzureCluster.Spec.DedicatedHostGroups {
+		for _, host := range group.Hosts {
+			specs = append(specs, &dedicatedhosts.HostSpec{
+				Name:                 host.Name,
+				ResourceGroup:        s.ResourceGroup(),
+				HostGroupName:        group.Name,
+				Location:             s.Location(),
+				ClusterName:          s.ClusterName(),
+				SKU:                  host.SKU,
+				PlatformFaultDomain:  host.PlatformFaultDomain,
+				AutoReplaceOnFailure: host.AutoReplaceOnFailure,
+				AdditionalTags:       s.AdditionalTags(),
+			})
+		}
+	}
+	return specs
+}
+
+// DedicatedHostGroupZone returns the availability zone of the dedicated host group of the cluster with the given
+// resource ID, and whether the cluster manages that group.
+func (s *ClusterScope) DedicatedHostGroupZone(hostGroupID string) (string, bool) {
+	for _, group := range s.AzureCluster.Spec.DedicatedHostGroups {
+		if strings.EqualFold(hostGroupID, azure.DedicatedHostGroupID(s.SubscriptionID(), s.ResourceGroup(), group.Name)) {
+			return pointer.StringDeref(group.FailureDomain, ""), true
+		}
+	}
+	return "", false
+}
+
+// ProximityPlacementGroupSpecs returns the specs of the proximity placement groups of the cluster.
+func (s *ClusterScope) ProximityPlacementGroupSpecs() []azure.ResourceSpecGetter {
+	specs := make([]azure.ResourceSpecGetter, 0, len(s.AzureCluster.Spec.ProximityPlacementGroups))
+	for _, group := range s.AzureCluster.Spec.ProximityPlacementGroups {
+		specs = append(specs, &proximityplacementgroups.ProximityPlacementGroupSpec{
+			Name:           group.Name,
+			ResourceGroup:  s.ResourceGroup(),
+			Location:       s.Location(),
+			ClusterName:    s.ClusterName(),
+			AdditionalTags: s.AdditionalTags(),
+		})
+	}
+	return specs
+}
+
 // SubnetSpecs returns the subnets specs.
 func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
-	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
+	clusterSubnets := s.Subnets()
+	numberOfSubnets := len(clusterSubnets)
 	if s.IsAzureBastionEnabled() {
 		numberOfSubnets++
 	}
 
 	subnetSpecs := make([]azure.ResourceSpecGetter, 0, numberOfSubnets)
 
-	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, subnet := range clusterSubnets {
 		subnetSpec := &subnets.SubnetSpec{
 			Name:              subnet.Name,
 			ResourceGroup:     s.ResourceGroup(),
@@ -380,6 +692,8 @@ func (s *ClusterScope) SubnetSpecs() []azure.ResourceSpecGetter {
 			Role:              subnet.Role,
 			NatGatewayName:    subnet.NatGateway.Name,
 			ServiceEndpoints:  subnet.ServiceEndpoints,
+			// The private link service of the API server is placed in the control plane subnet.
+			DisablePrivateLinkServiceNetworkPolicies: subnet.Role == infrav1.SubnetControlPlane && s.hasAPIServerPrivateLinkService(),
 		}
 		subnetSpecs = append(subnetSpecs, subnetSpec)
 	}
@@ -418,21 +732,36 @@ func (s *ClusterScope) GroupSpec() azure.ResourceSpecGetter {
 func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	peeringSpecs := make([]azure.ResourceSpecGetter, 2*len(s.Vnet().Peerings))
 	for i, peering := range s.Vnet().Peerings {
+		remoteSubscriptionID := peering.SubscriptionID
+		if remoteSubscriptionID == "" {
+			remoteSubscriptionID = s.SubscriptionID()
+		}
 		forwardPeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:         azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
-			SourceVnetName:      s.Vnet().Name,
-			SourceResourceGroup: s.Vnet().ResourceGroup,
-			RemoteVnetName:      peering.RemoteVnetName,
-			RemoteResourceGroup: peering.ResourceGroup,
-			SubscriptionID:      s.SubscriptionID(),
+			PeeringName:           azure.GenerateVnetPeeringName(s.Vnet().Name, peering.RemoteVnetName),
+			SourceVnetName:        s.Vnet().Name,
+			SourceResourceGroup:   s.Vnet().ResourceGroup,
+			RemoteVnetName:        peering.RemoteVnetName,
+			RemoteResourceGroup:   peering.ResourceGroup,
+			SubscriptionID:        remoteSubscriptionID,
+			AuxiliaryIdentityRef:  peering.IdentityRef,
+			AllowForwardedTraffic: peering.ForwardPeeringProperties.AllowForwardedTraffic,
+			AllowGatewayTransit:   peering.ForwardPeeringProperties.AllowGatewayTransit,
+			UseRemoteGateways:     peering.ForwardPeeringProperties.UseRemoteGateways,
 		}
+		// The reverse peering belongs to the remote virtual network, so it is managed in the subscription of the
+		// remote virtual network with the identity of the peering, if any.
 		reversePeering := &vnetpeerings.VnetPeeringSpec{
-			PeeringName:         azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
-			SourceVnetName:      peering.RemoteVnetName,
-			SourceResourceGroup: peering.ResourceGroup,
-			RemoteVnetName:      s.Vnet().Name,
-			RemoteResourceGroup: s.Vnet().ResourceGroup,
-			SubscriptionID:      s.SubscriptionID(),
+			PeeringName:           azure.GenerateVnetPeeringName(peering.RemoteVnetName, s.Vnet().Name),
+			SourceVnetName:        peering.RemoteVnetName,
+			SourceResourceGroup:   peering.ResourceGroup,
+			SourceSubscriptionID:  peering.SubscriptionID,
+			RemoteVnetName:        s.Vnet().Name,
+			RemoteResourceGroup:   s.Vnet().ResourceGroup,
+			SubscriptionID:        s.SubscriptionID(),
+			IdentityRef:           peering.IdentityRef,
+			AllowForwardedTraffic: peering.ReversePeeringProperties.AllowForwardedTraffic,
+			AllowGatewayTransit:   peering.ReversePeeringProperties.AllowGatewayTransit,
+			UseRemoteGateways:     peering.ReversePeeringProperties.UseRemoteGateways,
 		}
 		peeringSpecs[i*2] = forwardPeering
 		peeringSpecs[i*2+1] = reversePeering
@@ -441,15 +770,63 @@ func (s *ClusterScope) VnetPeeringSpecs() []azure.ResourceSpecGetter {
 	return peeringSpecs
 }
 
+// VnetPeeringAuthorizer returns an authorizer for the given subscription with the credentials of the given
+// AzureClusterIdentity, to manage the peerings of remote virtual networks. It defaults to the subscription and to the
+// identity of the cluster. When the AzureClusterIdentity referenced by auxiliaryIdentityRef belongs to another tenant,
+// the authorizer also sends a token of that tenant as an auxiliary token, which Azure requires to peer with a virtual
+// network of the tenant.
+func (s *ClusterScope) VnetPeeringAuthorizer(ctx context.Context, subscriptionID string, identityRef, auxiliaryIdentityRef *corev1.ObjectReference) (azure.Authorizer, error) {
+	if subscriptionID == "" {
+		subscriptionID = s.SubscriptionID()
+	}
+	if identityRef == nil {
+		identityRef = s.AzureCluster.Spec.IdentityRef
+	}
+
+	clients := &AzureClients{}
+	if identityRef == nil {
+		if err := clients.setCredentials(subscriptionID, s.AzureCluster.Spec.AzureEnvironment); err != nil {
+			return nil, errors.Wrap(err, "failed to configure azure settings and credentials from environment")
+		}
+	} else {
+		credentialsProvider, err := newAzureClusterCredentialsProvider(ctx, s.Client, s.AzureCluster, identityRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to init credentials provider")
+		}
+		if err := clients.setCredentialsWithProvider(ctx, subscriptionID, s.AzureCluster.Spec.AzureEnvironment, credentialsProvider); err != nil {
+			return nil, errors.Wrap(err, "failed to configure azure settings and credentials for Identity")
+		}
+	}
+
+	if auxiliaryIdentityRef != nil {
+		auxiliaryProvider, err := newAzureClusterCredentialsProvider(ctx, s.Client, s.AzureCluster, auxiliaryIdentityRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to init auxiliary credentials provider")
+		}
+		if !strings.EqualFold(auxiliaryProvider.GetTenantID(), clients.TenantID()) {
+			auxiliaryClients := &AzureClients{}
+			if err := auxiliaryClients.setCredentialsWithProvider(ctx, subscriptionID, s.AzureCluster.Spec.AzureEnvironment, auxiliaryProvider); err != nil {
+				return nil, errors.Wrap(err, "failed to configure azure settings and credentials for auxiliary Identity")
+			}
+			clients.Authorizer = newAuxiliaryTokenAuthorizer(clients.Authorizer, auxiliaryClients.Authorizer)
+		}
+	}
+	return &clientsAuthorizer{AzureClients: clients}, nil
+}
+
 // VNetSpec returns the virtual network spec.
 func (s *ClusterScope) VNetSpec() azure.ResourceSpecGetter {
+	vnet := s.Vnet()
 	return &virtualnetworks.VNetSpec{
-		ResourceGroup:  s.Vnet().ResourceGroup,
-		Name:           s.Vnet().Name,
-		CIDRs:          s.Vnet().CIDRBlocks,
-		Location:       s.Location(),
-		ClusterName:    s.ClusterName(),
-		AdditionalTags: s.AdditionalTags(),
+		ResourceGroup:        vnet.ResourceGroup,
+		Name:                 vnet.Name,
+		CIDRs:                vnet.CIDRBlocks,
+		Location:             s.Location(),
+		ClusterName:          s.ClusterName(),
+		AdditionalTags:       s.AdditionalTags(),
+		DDoSProtectionPlanID: vnet.DDoSProtectionPlanID,
+		Encryption:           vnet.Encryption,
+		DHCPOptions:          vnet.DHCPOptions,
 	}
 }
 
@@ -532,24 +909,46 @@ func (s *ClusterScope) AzureBastionSpec() azure.ResourceSpecGetter {
 	return nil
 }
 
-// Vnet returns the cluster Vnet.
+// Vnet returns a copy of the cluster Vnet. Use UpdateVnet and SetVnetCIDRBlocks to update it.
 func (s *ClusterScope) Vnet() *infrav1.VnetSpec {
-	return &s.AzureCluster.Spec.NetworkSpec.Vnet
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AzureCluster.Spec.NetworkSpec.Vnet.DeepCopy()
+}
+
+// UpdateVnet updates the ID, tags and CIDR blocks of the cluster Vnet with the ones of the Azure virtual network.
+func (s *ClusterScope) UpdateVnet(id string, tags infrav1.Tags, cidrBlocks []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vnet := &s.AzureCluster.Spec.NetworkSpec.Vnet
+	vnet.ID = id
+	vnet.Tags = tags
+	vnet.CIDRBlocks = cidrBlocks
+}
+
+// SetVnetCIDRBlocks sets the CIDR blocks of the cluster Vnet.
+func (s *ClusterScope) SetVnetCIDRBlocks(cidrBlocks []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.AzureCluster.Spec.NetworkSpec.Vnet.CIDRBlocks = cidrBlocks
 }
 
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ClusterScope) IsVnetManaged() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.cache.isVnetManaged != nil {
 		return pointer.BoolDeref(s.cache.isVnetManaged, false)
 	}
-	isVnetManaged := s.Vnet().ID == "" || s.Vnet().Tags.HasOwned(s.ClusterName())
+	vnet := s.AzureCluster.Spec.NetworkSpec.Vnet
+	isVnetManaged := vnet.ID == "" || vnet.Tags.HasOwned(s.ClusterName())
 	s.cache.isVnetManaged = pointer.Bool(isVnetManaged)
 	return isVnetManaged
 }
 
 // IsIPv6Enabled returns true if IPv6 is enabled.
 func (s *ClusterScope) IsIPv6Enabled() bool {
-	for _, cidr := range s.AzureCluster.Spec.NetworkSpec.Vnet.CIDRBlocks {
+	for _, cidr := range s.Vnet().CIDRBlocks {
 		if net.IsIPv6CIDRString(cidr) {
 			return true
 		}
@@ -557,13 +956,25 @@ func (s *ClusterScope) IsIPv6Enabled() bool {
 	return false
 }
 
-// Subnets returns the cluster subnets.
+// IsIPv6Only returns true if the cluster uses the experimental IPv6-only network mode.
+func (s *ClusterScope) IsIPv6Only() bool {
+	return s.AzureCluster.Spec.NetworkSpec.IPv6Only
+}
+
+// Subnets returns a copy of the cluster subnets.
 func (s *ClusterScope) Subnets() infrav1.Subnets {
-	return s.AzureCluster.Spec.NetworkSpec.Subnets
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.AzureCluster.Spec.NetworkSpec.Subnets == nil {
+		return nil
+	}
+	return append(infrav1.Subnets{}, s.AzureCluster.Spec.NetworkSpec.Subnets...)
 }
 
 // ControlPlaneSubnet returns the cluster control plane subnet.
 func (s *ClusterScope) ControlPlaneSubnet() infrav1.SubnetSpec {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	subnet, _ := s.AzureCluster.Spec.NetworkSpec.GetControlPlaneSubnet()
 	return subnet
 }
@@ -571,7 +982,7 @@ func (s *ClusterScope) ControlPlaneSubnet() infrav1.SubnetSpec {
 // NodeSubnets returns the subnets with the node role.
 func (s *ClusterScope) NodeSubnets() []infrav1.SubnetSpec {
 	subnets := []infrav1.SubnetSpec{}
-	for _, subnet := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, subnet := range s.Subnets() {
 		if subnet.Role == infrav1.SubnetNode {
 			subnets = append(subnets, subnet)
 		}
@@ -582,7 +993,7 @@ func (s *ClusterScope) NodeSubnets() []infrav1.SubnetSpec {
 
 // Subnet returns the subnet with the provided name.
 func (s *ClusterScope) Subnet(name string) infrav1.SubnetSpec {
-	for _, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+	for _, sn := range s.Subnets() {
 		if sn.Name == name {
 			return sn
 		}
@@ -593,47 +1004,68 @@ func (s *ClusterScope) Subnet(name string) infrav1.SubnetSpec {
 
 // SetSubnet sets the subnet spec for the subnet with the same name.
 func (s *ClusterScope) SetSubnet(subnetSpec infrav1.SubnetSpec) {
-	for i, sn := range s.AzureCluster.Spec.NetworkSpec.Subnets {
-		if sn.Name == subnetSpec.Name {
-			s.AzureCluster.Spec.NetworkSpec.Subnets[i] = subnetSpec
-			return
+	s.updateSubnets(func(subnet *infrav1.SubnetSpec) {
+		if subnet.Name == subnetSpec.Name {
+			*subnet = subnetSpec
 		}
-	}
+	})
 }
 
 // SetNatGatewayIDInSubnets sets the NAT Gateway ID in the subnets with the same name.
 func (s *ClusterScope) SetNatGatewayIDInSubnets(name string, id string) {
-	for _, subnet := range s.Subnets() {
+	s.updateSubnets(func(subnet *infrav1.SubnetSpec) {
 		if subnet.NatGateway.Name == name {
 			subnet.NatGateway.ID = id
-			s.SetSubnet(subnet)
 		}
-	}
+	})
 }
 
 // UpdateSubnetCIDRs updates the subnet CIDRs for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetCIDRs(name string, cidrBlocks []string) {
-	subnetSpecInfra := s.Subnet(name)
-	subnetSpecInfra.CIDRBlocks = cidrBlocks
-	s.SetSubnet(subnetSpecInfra)
+	s.updateSubnets(func(subnet *infrav1.SubnetSpec) {
+		if subnet.Name == name {
+			subnet.CIDRBlocks = cidrBlocks
+		}
+	})
 }
 
 // UpdateSubnetID updates the subnet ID for the subnet with the same name.
 func (s *ClusterScope) UpdateSubnetID(name string, id string) {
-	subnetSpecInfra := s.Subnet(name)
-	subnetSpecInfra.ID = id
-	s.SetSubnet(subnetSpecInfra)
+	s.updateSubnets(func(subnet *infrav1.SubnetSpec) {
+		if subnet.Name == name {
+			subnet.ID = id
+		}
+	})
+}
+
+// updateSubnets calls update on every subnet of the cluster while holding the lock, so that services updating
+// different fields of the same subnet in parallel don't overwrite each other's changes.
+func (s *ClusterScope) updateSubnets(update func(*infrav1.SubnetSpec)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.AzureCluster.Spec.NetworkSpec.Subnets {
+		update(&s.AzureCluster.Spec.NetworkSpec.Subnets[i])
+	}
 }
 
 // ControlPlaneRouteTable returns the cluster controlplane routetable.
 func (s *ClusterScope) ControlPlaneRouteTable() infrav1.RouteTable {
-	subnet, _ := s.AzureCluster.Spec.NetworkSpec.GetControlPlaneSubnet()
+	subnet := s.ControlPlaneSubnet()
 	return subnet.RouteTable
 }
 
-// APIServerLB returns the cluster API Server load balancer.
+// APIServerLB returns a copy of the cluster API Server load balancer. Use SetAPIServerLB to update it.
 func (s *ClusterScope) APIServerLB() *infrav1.LoadBalancerSpec {
-	return &s.AzureCluster.Spec.NetworkSpec.APIServerLB
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.AzureCluster.Spec.NetworkSpec.APIServerLB.DeepCopy()
+}
+
+// SetAPIServerLB sets the cluster API Server load balancer.
+func (s *ClusterScope) SetAPIServerLB(lb infrav1.LoadBalancerSpec) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb.DeepCopyInto(&s.AzureCluster.Spec.NetworkSpec.APIServerLB)
 }
 
 // NodeOutboundLB returns the cluster node outbound load balancer.
@@ -656,6 +1088,11 @@ func (s *ClusterScope) IsAPIServerPrivate() bool {
 	return s.APIServerLB().Type == infrav1.Internal
 }
 
+// IsUserDefinedRouting returns true if the egress traffic of the cluster is routed to a virtual appliance.
+func (s *ClusterScope) IsUserDefinedRouting() bool {
+	return s.AzureCluster.Spec.NetworkSpec.IsUserDefinedRouting()
+}
+
 // APIServerPublicIP returns the API Server public IP.
 func (s *ClusterScope) APIServerPublicIP() *infrav1.PublicIPSpec {
 	return s.APIServerLB().FrontendIPs[0].PublicIP
@@ -704,6 +1141,11 @@ func (s *ClusterScope) OutboundPoolName(loadBalancerName string) string {
 	return azure.GenerateOutboundBackendAddressPoolName(loadBalancerName)
 }
 
+// GetClient returns the controller-runtime client.
+func (s *ClusterScope) GetClient() client.Client {
+	return s.Client
+}
+
 // ResourceGroup returns the cluster resource group.
 func (s *ClusterScope) ResourceGroup() string {
 	return s.AzureCluster.Spec.ResourceGroup
@@ -768,6 +1210,7 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.ClusterScope.PatchObject")
 	defer done()
 
+	setDriftDetectedCondition(s.AzureCluster, s.DriftDetectionMode(), s.Drifts())
 	conditions.SetSummary(s.AzureCluster)
 
 	return s.patchHelper.Patch(
@@ -790,6 +1233,8 @@ func (s *ClusterScope) PatchObject(ctx context.Context) error {
 			infrav1.PrivateDNSLinkReadyCondition,
 			infrav1.PrivateDNSRecordReadyCondition,
 			infrav1.PrivateEndpointsReadyCondition,
+			infrav1.PrivateLinkServicesReadyCondition,
+			infrav1.DriftDetectedCondition,
 		}})
 }
 
@@ -874,7 +1319,7 @@ func (s *ClusterScope) SetControlPlaneSecurityRules() {
 				DestinationPorts: pointer.String(strconv.Itoa(int(s.APIServerPort()))),
 			},
 		}
-		s.AzureCluster.Spec.NetworkSpec.UpdateControlPlaneSubnet(subnet)
+		s.SetSubnet(subnet)
 	}
 }
 
@@ -883,7 +1328,7 @@ func (s *ClusterScope) SetControlPlaneSecurityRules() {
 func (s *ClusterScope) SetDNSName() {
 	// for back compat, set the old API Server defaults if no API Server Spec has been set by new webhooks.
 	lb := s.APIServerLB()
-	if lb == nil || lb.Name == "" {
+	if lb.Name == "" {
 		lbName := fmt.Sprintf("%s-%s", s.ClusterName(), "public-lb")
 		ip, dns := s.GenerateLegacyFQDN()
 		lb = &infrav1.LoadBalancerSpec{
@@ -902,34 +1347,51 @@ func (s *ClusterScope) SetDNSName() {
 				Type: infrav1.Public,
 			},
 		}
-		lb.DeepCopyInto(s.APIServerLB())
 	}
 	// Generate valid FQDN if not set.
 	// Note: this function uses the AzureCluster subscription ID.
-	if !s.IsAPIServerPrivate() && s.APIServerPublicIP().DNSName == "" {
-		s.APIServerPublicIP().DNSName = s.GenerateFQDN(s.APIServerPublicIP().Name)
+	if lb.Type != infrav1.Internal && lb.FrontendIPs[0].PublicIP.DNSName == "" {
+		lb.FrontendIPs[0].PublicIP.DNSName = s.GenerateFQDN(lb.FrontendIPs[0].PublicIP.Name)
 	}
+	s.SetAPIServerLB(*lb)
 }
 
 // SetLongRunningOperationState will set the future on the AzureCluster status to allow the resource to continue
 // in the next reconciliation.
 func (s *ClusterScope) SetLongRunningOperationState(future *infrav1.Future) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	futures.Set(s.AzureCluster, future)
 }
 
 // GetLongRunningOperationState will get the future on the AzureCluster status.
 func (s *ClusterScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	return futures.Get(s.AzureCluster, name, service, futureType)
 }
 
 // DeleteLongRunningOperationState will delete the future from the AzureCluster status.
 func (s *ClusterScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	futures.Delete(s.AzureCluster, name, service, futureType)
 }
 
+// RecordOperation records an Azure operation in the operation history of the AzureCluster status.
+func (s *ClusterScope) RecordOperation(operation infrav1.Operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	operations.Record(s.AzureCluster, operation)
+}
+
 // UpdateDeleteStatus updates a condition on the AzureCluster status after a DELETE operation.
 func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
+	case err == nil && s.dryRun():
+		// Nothing was changed in Azure, so the condition is left as is.
 	case err == nil:
 		conditions.MarkFalse(s.AzureCluster, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
@@ -941,7 +1403,11 @@ func (s *ClusterScope) UpdateDeleteStatus(condition clusterv1.ConditionType, ser
 
 // UpdatePutStatus updates a condition on the AzureCluster status after a PUT operation.
 func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
+	case err == nil && s.dryRun():
+		// Nothing was changed in Azure, so the condition is left as is.
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
@@ -953,7 +1419,11 @@ func (s *ClusterScope) UpdatePutStatus(condition clusterv1.ConditionType, servic
 
 // UpdatePatchStatus updates a condition on the AzureCluster status after a PATCH operation.
 func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	switch {
+	case err == nil && s.dryRun():
+		// Nothing was changed in Azure, so the condition is left as is.
 	case err == nil:
 		conditions.MarkTrue(s.AzureCluster, condition)
 	case azure.IsOperationNotDoneError(err):
@@ -965,6 +1435,8 @@ func (s *ClusterScope) UpdatePatchStatus(condition clusterv1.ConditionType, serv
 
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ClusterScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	out := map[string]interface{}{}
 	jsonAnnotation := s.AzureCluster.GetAnnotations()[annotation]
 	if jsonAnnotation == "" {
@@ -992,12 +1464,60 @@ func (s *ClusterScope) UpdateAnnotationJSON(annotation string, content map[strin
 
 // SetAnnotation sets a key value annotation on the AzureCluster.
 func (s *ClusterScope) SetAnnotation(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.AzureCluster.Annotations == nil {
 		s.AzureCluster.Annotations = map[string]string{}
 	}
 	s.AzureCluster.Annotations[key] = value
 }
 
+// DriftDetectionMode returns the drift detection mode set on the AzureCluster with the DriftDetectionAnnotation.
+func (s *ClusterScope) DriftDetectionMode() azure.DriftDetectionMode {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return azure.DriftDetectionMode(s.AzureCluster.GetAnnotations()[azure.DriftDetectionAnnotation])
+}
+
+// ReportDrift records the drift of an Azure resource of the AzureCluster.
+func (s *ClusterScope) ReportDrift(drift azure.ResourceDrift) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drifts = append(s.drifts, drift)
+}
+
+// Drifts returns the drift of the Azure resources detected during this reconcile.
+func (s *ClusterScope) Drifts() []azure.ResourceDrift {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]azure.ResourceDrift{}, s.drifts...)
+}
+
+// DryRun returns whether the AzureCluster is reconciled in dry-run mode, i.e. whether its DryRunAnnotation is "true".
+func (s *ClusterScope) DryRun() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dryRun()
+}
+
+func (s *ClusterScope) dryRun() bool {
+	return s.AzureCluster.GetAnnotations()[azure.DryRunAnnotation] == "true"
+}
+
+// PlanOperation records an Azure API call that would have been made to reconcile the AzureCluster.
+func (s *ClusterScope) PlanOperation(operation azure.PlannedOperation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plan = append(s.plan, operation)
+}
+
+// Plan returns the operations planned during this reconcile in dry-run mode, in the order they were planned.
+func (s *ClusterScope) Plan() azure.Plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(azure.Plan{}, s.plan...)
+}
+
 // TagsSpecs returns the tag specs for the AzureCluster.
 func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
 	return []azure.TagsSpec{
@@ -1011,14 +1531,14 @@ func (s *ClusterScope) TagsSpecs() []azure.TagsSpec {
 
 // PrivateEndpointSpecs returns the private endpoint specs.
 func (s *ClusterScope) PrivateEndpointSpecs() []azure.ResourceSpecGetter {
-	numberOfSubnets := len(s.AzureCluster.Spec.NetworkSpec.Subnets)
+	subnets := s.Subnets()
+	numberOfSubnets := len(subnets)
 	if s.IsAzureBastionEnabled() {
 		numberOfSubnets++
 	}
 
 	privateEndpointSpecs := make([]azure.ResourceSpecGetter, 0, numberOfSubnets)
 
-	subnets := s.AzureCluster.Spec.NetworkSpec.Subnets
 	if s.IsAzureBastionEnabled() {
 		subnets = append(subnets, s.AzureCluster.Spec.BastionSpec.AzureBastion.Subnet)
 	}
@@ -1062,3 +1582,66 @@ func (s *ClusterScope) getPrivateEndpoints(subnet infrav1.SubnetSpec) []azure.Re
 
 	return privateEndpointSpecs
 }
+
+// PrivateLinkServiceSpecs returns the private link service specs.
+func (s *ClusterScope) PrivateLinkServiceSpecs() []azure.ResourceSpecGetter {
+	if !s.hasAPIServerPrivateLinkService() {
+		return nil
+	}
+
+	apiServerLB := s.APIServerLB()
+	frontendIPConfigNames := make([]string, 0, len(apiServerLB.FrontendIPs))
+	for _, frontendIP := range apiServerLB.FrontendIPs {
+		frontendIPConfigNames = append(frontendIPConfigNames, frontendIP.Name)
+	}
+
+	return []azure.ResourceSpecGetter{
+		&privatelinkservices.PrivateLinkServiceSpec{
+			Name:                      apiServerLB.PrivateLinkService.Name,
+			ResourceGroup:             s.ResourceGroup(),
+			SubscriptionID:            s.SubscriptionID(),
+			Location:                  s.Location(),
+			ClusterName:               s.ClusterName(),
+			LoadBalancerName:          apiServerLB.Name,
+			FrontendIPConfigNames:     frontendIPConfigNames,
+			VNetName:                  s.Vnet().Name,
+			VNetResourceGroup:         s.Vnet().ResourceGroup,
+			SubnetName:                s.ControlPlaneSubnet().Name,
+			VisibleSubscriptions:      apiServerLB.PrivateLinkService.VisibleSubscriptions,
+			AutoApprovedSubscriptions: apiServerLB.PrivateLinkService.AutoApprovedSubscriptions,
+			AdditionalTags:            s.AdditionalTags(),
+		},
+	}
+}
+
+// SetAPIServerPrivateLinkServiceAlias sets the alias of the API server private link service in the status.
+func (s *ClusterScope) SetAPIServerPrivateLinkServiceAlias(alias string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.AzureCluster.Status.APIServerPrivateLinkServiceAlias = alias
+}
+
+// hasAPIServerPrivateLinkService returns true if the internal API server load balancer is exposed through a private link service.
+func (s *ClusterScope) hasAPIServerPrivateLinkService() bool {
+	return s.IsAPIServerPrivate() && s.APIServerLB().PrivateLinkService != nil
+}
+
+// setDriftDetectedCondition sets the DriftDetectedCondition to true when drift was detected, and removes it otherwise
+// so that it does not affect the Ready condition summary.
+func setDriftDetectedCondition(to conditions.Setter, mode azure.DriftDetectionMode, drifts []azure.ResourceDrift) {
+	if mode == azure.DriftDetectionDisabled || len(drifts) == 0 {
+		conditions.Delete(to, infrav1.DriftDetectedCondition)
+		return
+	}
+
+	resources := make([]string, len(drifts))
+	for i, drift := range drifts {
+		resources[i] = fmt.Sprintf("%s/%s (service: %s)", drift.ResourceGroup, drift.ResourceName, drift.ServiceName)
+	}
+	conditions.Set(to, &clusterv1.Condition{
+		Type:    infrav1.DriftDetectedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  infrav1.DriftedReason,
+		Message: fmt.Sprintf("%d Azure resource(s) drifted from their desired state: %s", len(drifts), strings.Join(resources, ", ")),
+	})
+}
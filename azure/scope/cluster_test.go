@@ -21,25 +21,36 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -166,7 +177,7 @@ func TestAPIServerHost(t *testing.T) {
 				},
 			},
 		}
-		tc.azureCluster.Default()
+		tc.azureCluster.Default(nil)
 
 		initObjects := []runtime.Object{cluster, &tc.azureCluster}
 		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
@@ -225,7 +236,7 @@ func TestGettingSecurityRules(t *testing.T) {
 			},
 		},
 	}
-	azureCluster.Default()
+	azureCluster.Default(nil)
 
 	initObjects := []runtime.Object{cluster, azureCluster}
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
@@ -641,6 +652,76 @@ func TestPublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Azure cluster with public type apiserver LB and dual-stack public node outbound lb",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							VnetClassSpec: infrav1.VnetClassSpec{
+								CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							Name: "my-cluster",
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-my-cluster-node-outbound",
+									},
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:    "40.60.89.22",
+										DNSName: "fake-dns",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:           "40.60.89.22",
+					ResourceGroup:  "my-rg",
+					DNSName:        "fake-dns",
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-my-cluster-node-outbound",
+					ResourceGroup:  "my-rg",
+					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+				&publicips.PublicIPSpec{
+					Name:           "pip-my-cluster-ipv6",
+					ResourceGroup:  "my-rg",
+					ClusterName:    "my-cluster",
+					IsIPv6:         true,
+					Location:       "centralIndia",
+					FailureDomains: []string{},
+					AdditionalTags: infrav1.Tags{},
+				},
+			},
+		},
 		{
 			name: "Azure cluster with public type apiserver LB and public node outbound lb, NAT gateways and bastions",
 			azureCluster: &infrav1.AzureCluster{
@@ -742,6 +823,89 @@ func TestPublicIPSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Azure cluster with public type apiserver LB and public node outbound lb allocated from public IP prefixes",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "cluster.x-k8s.io/v1beta1",
+							Kind:       "Cluster",
+							Name:       "my-cluster",
+						},
+					},
+				},
+				Status: infrav1.AzureClusterStatus{
+					FailureDomains: map[string]clusterv1.FailureDomainSpec{
+						"failure-domain-id-1": {},
+					},
+				},
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "centralIndia",
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									Name: "my-cluster-frontEnd",
+									PublicIP: &infrav1.PublicIPSpec{
+										Name: "pip-my-cluster-node-outbound",
+									},
+								},
+							},
+							PublicIPPrefix: &infrav1.PublicIPPrefixSpec{
+								Name:          "my-node-prefix",
+								ResourceGroup: "my-prefix-rg",
+								PrefixLength:  30,
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							FrontendIPs: []infrav1.FrontendIP{
+								{
+									PublicIP: &infrav1.PublicIPSpec{
+										Name:    "40.60.89.22",
+										DNSName: "fake-dns",
+									},
+								},
+							},
+							PublicIPPrefix: &infrav1.PublicIPPrefixSpec{
+								Name:          "my-apiserver-prefix",
+								ResourceGroup: "my-rg",
+								PrefixLength:  31,
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Public,
+							},
+						},
+					},
+				},
+			},
+			expectedPublicIPSpec: []azure.ResourceSpecGetter{
+				&publicips.PublicIPSpec{
+					Name:             "40.60.89.22",
+					ResourceGroup:    "my-rg",
+					DNSName:          "fake-dns",
+					ClusterName:      "my-cluster",
+					Location:         "centralIndia",
+					FailureDomains:   []string{"failure-domain-id-1"},
+					AdditionalTags:   infrav1.Tags{},
+					PublicIPPrefixID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPPrefixes/my-apiserver-prefix",
+				},
+				&publicips.PublicIPSpec{
+					Name:             "pip-my-cluster-node-outbound",
+					ResourceGroup:    "my-rg",
+					ClusterName:      "my-cluster",
+					Location:         "centralIndia",
+					FailureDomains:   []string{"failure-domain-id-1"},
+					AdditionalTags:   infrav1.Tags{},
+					PublicIPPrefixID: "/subscriptions/123/resourceGroups/my-prefix-rg/providers/Microsoft.Network/publicIPPrefixes/my-node-prefix",
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -778,19 +942,24 @@ func TestPublicIPSpecs(t *testing.T) {
 	}
 }
 
-func TestRouteTableSpecs(t *testing.T) {
+func TestPublicIPPrefixSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
-			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			name: "returns nil if no public IP prefixes are specified",
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
-							Subnets: infrav1.Subnets{},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Public,
+								},
+							},
+							NodeOutboundLB: &infrav1.LoadBalancerSpec{},
 						},
 					},
 				},
@@ -799,8 +968,8 @@ func TestRouteTableSpecs(t *testing.T) {
 			want: nil,
 		},
 		{
-			name: "returns specified route tables if present",
-			clusterScope: ClusterScope{
+			name: "returns the public IP prefixes of the load balancers and NAT gateways and ignores duplicates",
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -813,17 +982,40 @@ func TestRouteTableSpecs(t *testing.T) {
 							Location: "centralIndia",
 						},
 						NetworkSpec: infrav1.NetworkSpec{
+							APIServerLB: infrav1.LoadBalancerSpec{
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Public,
+								},
+								PublicIPPrefix: &infrav1.PublicIPPrefixSpec{
+									Name:          "my-prefix",
+									ResourceGroup: "my-rg",
+									PrefixLength:  28,
+								},
+							},
+							NodeOutboundLB: &infrav1.LoadBalancerSpec{
+								PublicIPPrefix: &infrav1.PublicIPPrefixSpec{
+									Name:          "my-prefix",
+									ResourceGroup: "my-rg",
+									PrefixLength:  28,
+								},
+							},
 							Subnets: infrav1.Subnets{
 								{
-									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-1",
-										Name: "fake-route-table-1",
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
 									},
-								},
-								{
-									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-2",
-										Name: "fake-route-table-2",
+									NatGateway: infrav1.NatGateway{
+										NatGatewayIP: infrav1.PublicIPSpec{
+											Name: "pip-node-natgw",
+										},
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "fake-nat-gateway-1",
+										},
+										PublicIPPrefix: &infrav1.PublicIPPrefixSpec{
+											Name:          "my-natgw-prefix",
+											ResourceGroup: "my-other-rg",
+											PrefixLength:  31,
+										},
 									},
 								},
 							},
@@ -833,18 +1025,22 @@ func TestRouteTableSpecs(t *testing.T) {
 				cache: &ClusterCache{},
 			},
 			want: []azure.ResourceSpecGetter{
-				&routetables.RouteTableSpec{
-					Name:           "fake-route-table-1",
+				&publicipprefixes.PublicIPPrefixSpec{
+					Name:           "my-prefix",
 					ResourceGroup:  "my-rg",
-					Location:       "centralIndia",
 					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					PrefixLength:   28,
+					FailureDomains: []string{},
 					AdditionalTags: make(infrav1.Tags),
 				},
-				&routetables.RouteTableSpec{
-					Name:           "fake-route-table-2",
-					ResourceGroup:  "my-rg",
-					Location:       "centralIndia",
+				&publicipprefixes.PublicIPPrefixSpec{
+					Name:           "my-natgw-prefix",
+					ResourceGroup:  "my-other-rg",
 					ClusterName:    "my-cluster",
+					Location:       "centralIndia",
+					PrefixLength:   31,
+					FailureDomains: []string{},
 					AdditionalTags: make(infrav1.Tags),
 				},
 			},
@@ -855,26 +1051,30 @@ func TestRouteTableSpecs(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := tt.clusterScope.RouteTableSpecs(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("RouteTableSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			if got := tt.clusterScope.PublicIPPrefixSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PublicIPPrefixSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
 			}
 		})
 	}
 }
 
-func TestNatGatewaySpecs(t *testing.T) {
+func TestPrivateLinkServiceSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
-			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			name: "returns nil if the API server load balancer has no private link service",
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
-							Subnets: infrav1.Subnets{},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Internal,
+								},
+							},
 						},
 					},
 				},
@@ -883,13 +1083,8 @@ func TestNatGatewaySpecs(t *testing.T) {
 			want: nil,
 		},
 		{
-			name: "returns specified node NAT gateway if present",
-			clusterScope: ClusterScope{
-				Cluster: &clusterv1.Cluster{
-					ObjectMeta: metav1.ObjectMeta{
-						Name: "my-cluster",
-					},
-				},
+			name: "returns the private link service of the internal API server load balancer",
+			clusterScope: &ClusterScope{
 				AzureClients: AzureClients{
 					EnvironmentSettings: auth.EnvironmentSettings{
 						Values: map[string]string{
@@ -897,29 +1092,43 @@ func TestNatGatewaySpecs(t *testing.T) {
 						},
 					},
 				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						ResourceGroup: "my-rg",
 						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
-							Location: "centralIndia",
+							Location: "westus2",
 						},
 						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-vnet-rg",
+							},
+							APIServerLB: infrav1.LoadBalancerSpec{
+								Name: "my-cluster-internal-lb",
+								FrontendIPs: []infrav1.FrontendIP{
+									{
+										Name: "my-cluster-internal-lb-frontEnd",
+									},
+								},
+								LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+									Type: infrav1.Internal,
+								},
+								PrivateLinkService: &infrav1.PrivateLinkServiceSpec{
+									Name:                      "my-cluster-apiserver-pls",
+									VisibleSubscriptions:      []string{"*"},
+									AutoApprovedSubscriptions: []string{"11111111-1111-1111-1111-111111111111"},
+								},
+							},
 							Subnets: infrav1.Subnets{
 								{
 									SubnetClassSpec: infrav1.SubnetClassSpec{
-										Role: infrav1.SubnetNode,
-									},
-									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-1",
-										Name: "fake-route-table-1",
-									},
-									NatGateway: infrav1.NatGateway{
-										NatGatewayIP: infrav1.PublicIPSpec{
-											Name: "44.78.67.90",
-										},
-										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
-											Name: "fake-nat-gateway-1",
-										},
+										Role: infrav1.SubnetControlPlane,
+										Name: "my-cluster-controlplane-subnet",
 									},
 								},
 							},
@@ -929,34 +1138,65 @@ func TestNatGatewaySpecs(t *testing.T) {
 				cache: &ClusterCache{},
 			},
 			want: []azure.ResourceSpecGetter{
-				&natgateways.NatGatewaySpec{
-					Name:           "fake-nat-gateway-1",
-					ResourceGroup:  "my-rg",
-					Location:       "centralIndia",
-					SubscriptionID: "123",
-					ClusterName:    "my-cluster",
-					NatGatewayIP: infrav1.PublicIPSpec{
-						Name: "44.78.67.90",
+				&privatelinkservices.PrivateLinkServiceSpec{
+					Name:                      "my-cluster-apiserver-pls",
+					ResourceGroup:             "my-rg",
+					SubscriptionID:            "123",
+					Location:                  "westus2",
+					ClusterName:               "my-cluster",
+					LoadBalancerName:          "my-cluster-internal-lb",
+					FrontendIPConfigNames:     []string{"my-cluster-internal-lb-frontEnd"},
+					VNetName:                  "my-vnet",
+					VNetResourceGroup:         "my-vnet-rg",
+					SubnetName:                "my-cluster-controlplane-subnet",
+					VisibleSubscriptions:      []string{"*"},
+					AutoApprovedSubscriptions: []string{"11111111-1111-1111-1111-111111111111"},
+					AdditionalTags:            make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.PrivateLinkServiceSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrivateLinkServiceSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestRouteTableSpecs(t *testing.T) {
+	udr := infrav1.OutboundTypeUserDefinedRouting
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no subnets are specified",
+			clusterScope: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{},
+						},
 					},
-					AdditionalTags: make(infrav1.Tags),
 				},
+				cache: &ClusterCache{},
 			},
+			want: nil,
 		},
 		{
-			name: "returns specified node NAT gateway if present and ignores duplicate",
-			clusterScope: ClusterScope{
+			name: "returns specified route tables if present",
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
 					},
 				},
-				AzureClients: AzureClients{
-					EnvironmentSettings: auth.EnvironmentSettings{
-						Values: map[string]string{
-							auth.SubscriptionID: "123",
-						},
-					},
-				},
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						ResourceGroup: "my-rg",
@@ -966,32 +1206,238 @@ func TestNatGatewaySpecs(t *testing.T) {
 						NetworkSpec: infrav1.NetworkSpec{
 							Subnets: infrav1.Subnets{
 								{
-									SubnetClassSpec: infrav1.SubnetClassSpec{
-										Role: infrav1.SubnetNode,
-									},
 									RouteTable: infrav1.RouteTable{
 										ID:   "fake-route-table-id-1",
 										Name: "fake-route-table-1",
 									},
-									NatGateway: infrav1.NatGateway{
-										NatGatewayIP: infrav1.PublicIPSpec{
-											Name: "44.78.67.90",
-										},
-										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
-											Name: "fake-nat-gateway-1",
-										},
-									},
 								},
-								// Duplicate Entry
 								{
-									SubnetClassSpec: infrav1.SubnetClassSpec{
-										Role: infrav1.SubnetNode,
-									},
 									RouteTable: infrav1.RouteTable{
-										ID:   "fake-route-table-id-1",
-										Name: "fake-route-table-1",
+										ID:   "fake-route-table-id-2",
+										Name: "fake-route-table-2",
 									},
-									NatGateway: infrav1.NatGateway{
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:           "fake-route-table-1",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&routetables.RouteTableSpec{
+					Name:           "fake-route-table-2",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns route tables with a default route when outbound type is userDefinedRouting",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								OutboundType:     &udr,
+								NextHopIPAddress: "10.0.255.4",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									RouteTable: infrav1.RouteTable{
+										Name: "my-cluster-controlplane-routetable",
+									},
+								},
+								{
+									RouteTable: infrav1.RouteTable{
+										Name: "my-cluster-node-routetable",
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&routetables.RouteTableSpec{
+					Name:             "my-cluster-controlplane-routetable",
+					ResourceGroup:    "my-rg",
+					Location:         "centralIndia",
+					ClusterName:      "my-cluster",
+					AdditionalTags:   make(infrav1.Tags),
+					NextHopIPAddress: "10.0.255.4",
+				},
+				&routetables.RouteTableSpec{
+					Name:             "my-cluster-node-routetable",
+					ResourceGroup:    "my-rg",
+					Location:         "centralIndia",
+					ClusterName:      "my-cluster",
+					AdditionalTags:   make(infrav1.Tags),
+					NextHopIPAddress: "10.0.255.4",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.RouteTableSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RouteTableSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestNatGatewaySpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if no subnets are specified",
+			clusterScope: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: nil,
+		},
+		{
+			name: "returns specified node NAT gateway if present",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										ID:   "fake-route-table-id-1",
+										Name: "fake-route-table-1",
+									},
+									NatGateway: infrav1.NatGateway{
+										NatGatewayIP: infrav1.PublicIPSpec{
+											Name: "44.78.67.90",
+										},
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "fake-nat-gateway-1",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&natgateways.NatGatewaySpec{
+					Name:           "fake-nat-gateway-1",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					SubscriptionID: "123",
+					ClusterName:    "my-cluster",
+					NatGatewayIP: infrav1.PublicIPSpec{
+						Name: "44.78.67.90",
+					},
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns specified node NAT gateway if present and ignores duplicate",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										ID:   "fake-route-table-id-1",
+										Name: "fake-route-table-1",
+									},
+									NatGateway: infrav1.NatGateway{
+										NatGatewayIP: infrav1.PublicIPSpec{
+											Name: "44.78.67.90",
+										},
+										NatGatewayClassSpec: infrav1.NatGatewayClassSpec{
+											Name: "fake-nat-gateway-1",
+										},
+									},
+								},
+								// Duplicate Entry
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{
+										Role: infrav1.SubnetNode,
+									},
+									RouteTable: infrav1.RouteTable{
+										ID:   "fake-route-table-id-1",
+										Name: "fake-route-table-1",
+									},
+									NatGateway: infrav1.NatGateway{
 										NatGatewayIP: infrav1.PublicIPSpec{
 											Name: "44.78.67.90",
 										},
@@ -1022,7 +1468,7 @@ func TestNatGatewaySpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified node NAT gateway if present and ignores control plane nat gateway",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1113,12 +1559,12 @@ func TestNatGatewaySpecs(t *testing.T) {
 func TestNSGSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1131,7 +1577,14 @@ func TestNSGSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified security groups if present",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1172,34 +1625,616 @@ func TestNSGSpecs(t *testing.T) {
 						},
 					},
 					ResourceGroup:  "my-rg",
+					SubscriptionID: "123",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.NSGSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RouteTableSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestApplicationSecurityGroupSpecs(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns the control plane and node application security groups",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "my-cluster-controlplane-asg",
+					ResourceGroup:  "my-rg",
 					Location:       "centralIndia",
 					ClusterName:    "my-cluster",
 					AdditionalTags: make(infrav1.Tags),
 				},
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "my-cluster-node-asg",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns the custom application security groups after the control plane and node ones",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							NetworkClassSpec: infrav1.NetworkClassSpec{
+								ApplicationSecurityGroups: []string{"web-asg"},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "my-cluster-controlplane-asg",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "my-cluster-node-asg",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+				&applicationsecuritygroups.ASGSpec{
+					Name:           "web-asg",
+					ResourceGroup:  "my-rg",
+					Location:       "centralIndia",
+					ClusterName:    "my-cluster",
+					AdditionalTags: make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.ApplicationSecurityGroupSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplicationSecurityGroupSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestFlowLogSpecs(t *testing.T) {
+	storageAccountID := "/subscriptions/123/resourceGroups/my-monitoring-rg/providers/Microsoft.Storage/storageAccounts/myflowlogs"
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns nil if the vnet is not managed",
+			clusterScope: &ClusterScope{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								ID: "my-vnet-id",
+							},
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "my-cluster-node-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											FlowLog: &infrav1.FlowLog{StorageAccountID: storageAccountID},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: nil,
+		},
+		{
+			name: "returns one flow log per security group with the default network watcher",
+			clusterScope: &ClusterScope{
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Name: "subnet-1"},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "my-cluster-node-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											FlowLog: &infrav1.FlowLog{StorageAccountID: storageAccountID, RetentionDays: 30},
+										},
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Name: "subnet-2"},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "my-cluster-node-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											FlowLog: &infrav1.FlowLog{StorageAccountID: storageAccountID, RetentionDays: 30},
+										},
+									},
+								},
+								{
+									SubnetClassSpec: infrav1.SubnetClassSpec{Name: "subnet-3"},
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "my-cluster-controlplane-nsg",
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&flowlogs.FlowLogSpec{
+					Name:               "my-cluster-node-nsg-flowlog",
+					ResourceGroup:      "NetworkWatcherRG",
+					NetworkWatcherName: "NetworkWatcher_centralIndia",
+					Location:           "centralIndia",
+					ClusterName:        "my-cluster",
+					SecurityGroupID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-cluster-node-nsg",
+					StorageAccountID:   storageAccountID,
+					RetentionDays:      30,
+					AdditionalTags:     make(infrav1.Tags),
+				},
+			},
+		},
+		{
+			name: "returns flow logs with a custom network watcher",
+			clusterScope: &ClusterScope{
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cluster",
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+						AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+							Location: "centralIndia",
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							Subnets: infrav1.Subnets{
+								{
+									SecurityGroup: infrav1.SecurityGroup{
+										Name: "my-cluster-node-nsg",
+										SecurityGroupClass: infrav1.SecurityGroupClass{
+											FlowLog: &infrav1.FlowLog{
+												StorageAccountID:            storageAccountID,
+												NetworkWatcherName:          "my-watcher",
+												NetworkWatcherResourceGroup: "my-watcher-rg",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				cache: &ClusterCache{},
+			},
+			want: []azure.ResourceSpecGetter{
+				&flowlogs.FlowLogSpec{
+					Name:               "my-cluster-node-nsg-flowlog",
+					ResourceGroup:      "my-watcher-rg",
+					NetworkWatcherName: "my-watcher",
+					Location:           "centralIndia",
+					ClusterName:        "my-cluster",
+					SecurityGroupID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-cluster-node-nsg",
+					StorageAccountID:   storageAccountID,
+					AdditionalTags:     make(infrav1.Tags),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.FlowLogSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlowLogSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestDiagnosticSettingSpecs(t *testing.T) {
+	workspaceID := "/subscriptions/123/resourceGroups/my-monitoring-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace"
+	clusterScope := func(diagnosticSettings *infrav1.DiagnosticSettings) *ClusterScope {
+		return &ClusterScope{
+			AzureClients: AzureClients{
+				EnvironmentSettings: auth.EnvironmentSettings{
+					Values: map[string]string{
+						auth.SubscriptionID: "123",
+					},
+				},
+			},
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+			},
+			AzureCluster: &infrav1.AzureCluster{
+				Spec: infrav1.AzureClusterSpec{
+					ResourceGroup: "my-rg",
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						Location:           "centralIndia",
+						DiagnosticSettings: diagnosticSettings,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						Vnet: infrav1.VnetSpec{
+							Name: "my-vnet",
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "my-cluster-internal-lb",
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type: infrav1.Internal,
+							},
+						},
+						Subnets: infrav1.Subnets{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-1", Role: infrav1.SubnetNode},
+								SecurityGroup:   infrav1.SecurityGroup{Name: "my-cluster-node-nsg"},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet-2", Role: infrav1.SubnetNode},
+								SecurityGroup:   infrav1.SecurityGroup{Name: "my-cluster-node-nsg"},
+							},
+						},
+					},
+					BastionSpec: infrav1.BastionSpec{
+						AzureBastion: &infrav1.AzureBastion{
+							Name:     "my-bastion",
+							Subnet:   infrav1.SubnetSpec{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "AzureBastionSubnet"}},
+							PublicIP: infrav1.PublicIPSpec{Name: "my-bastion-pip"},
+						},
+					},
+				},
+			},
+			cache: &ClusterCache{},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nil if diagnostic settings are not configured",
+			clusterScope: clusterScope(nil),
+			want:         nil,
+		},
+		{
+			name:         "returns the diagnostic settings of the security groups, load balancers, public IPs and bastion",
+			clusterScope: clusterScope(&infrav1.DiagnosticSettings{WorkspaceID: workspaceID}),
+			want: []azure.ResourceSpecGetter{
+				&diagnosticsettings.DiagnosticSettingSpec{
+					Name:          "my-cluster-node-nsg-diagnostics",
+					ResourceGroup: "my-rg",
+					ResourceID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-cluster-node-nsg",
+					LogCategories: diagnosticsettings.SecurityGroupLogCategories,
+					WorkspaceID:   workspaceID,
+				},
+				&diagnosticsettings.DiagnosticSettingSpec{
+					Name:          "my-cluster-internal-lb-diagnostics",
+					ResourceGroup: "my-rg",
+					ResourceID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-internal-lb",
+					Metrics:       true,
+					WorkspaceID:   workspaceID,
+				},
+				&diagnosticsettings.DiagnosticSettingSpec{
+					Name:          "my-bastion-pip-diagnostics",
+					ResourceGroup: "my-rg",
+					ResourceID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-bastion-pip",
+					LogCategories: diagnosticsettings.PublicIPLogCategories,
+					Metrics:       true,
+					WorkspaceID:   workspaceID,
+				},
+				&diagnosticsettings.DiagnosticSettingSpec{
+					Name:          "my-bastion-diagnostics",
+					ResourceGroup: "my-rg",
+					ResourceID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/bastionHosts/my-bastion",
+					LogCategories: diagnosticsettings.BastionHostLogCategories,
+					Metrics:       true,
+					WorkspaceID:   workspaceID,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.DiagnosticSettingSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiagnosticSettingSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestVnetPeeringSpecs(t *testing.T) {
+	remoteIdentity := &corev1.ObjectReference{Name: "hub-identity", Namespace: "default"}
+	tests := []struct {
+		name         string
+		clusterScope *ClusterScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name: "returns empty if no peerings are specified",
+			clusterScope: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{},
+			},
+			want: []azure.ResourceSpecGetter{},
+		},
+		{
+			name: "returns both directions of the peerings in the subscriptions of their virtual networks",
+			clusterScope: &ClusterScope{
+				AzureClients: AzureClients{
+					EnvironmentSettings: auth.EnvironmentSettings{
+						Values: map[string]string{
+							auth.SubscriptionID: "123",
+						},
+					},
+				},
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							Vnet: infrav1.VnetSpec{
+								Name:          "my-vnet",
+								ResourceGroup: "my-rg",
+								Peerings: infrav1.VnetPeerings{
+									{
+										VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+											ResourceGroup:  "other-rg",
+											RemoteVnetName: "other-vnet",
+										},
+									},
+									{
+										VnetPeeringClassSpec: infrav1.VnetPeeringClassSpec{
+											ResourceGroup:  "hub-rg",
+											RemoteVnetName: "hub-vnet",
+											SubscriptionID: "456",
+											IdentityRef:    remoteIdentity,
+											ForwardPeeringProperties: infrav1.VnetPeeringProperties{
+												AllowForwardedTraffic: pointer.Bool(true),
+												UseRemoteGateways:     pointer.Bool(true),
+											},
+											ReversePeeringProperties: infrav1.VnetPeeringProperties{
+												AllowGatewayTransit: pointer.Bool(true),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:         "my-vnet-To-other-vnet",
+					SourceVnetName:      "my-vnet",
+					SourceResourceGroup: "my-rg",
+					RemoteVnetName:      "other-vnet",
+					RemoteResourceGroup: "other-rg",
+					SubscriptionID:      "123",
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:         "other-vnet-To-my-vnet",
+					SourceVnetName:      "other-vnet",
+					SourceResourceGroup: "other-rg",
+					RemoteVnetName:      "my-vnet",
+					RemoteResourceGroup: "my-rg",
+					SubscriptionID:      "123",
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:           "my-vnet-To-hub-vnet",
+					SourceVnetName:        "my-vnet",
+					SourceResourceGroup:   "my-rg",
+					RemoteVnetName:        "hub-vnet",
+					RemoteResourceGroup:   "hub-rg",
+					SubscriptionID:        "456",
+					AuxiliaryIdentityRef:  remoteIdentity,
+					AllowForwardedTraffic: pointer.Bool(true),
+					UseRemoteGateways:     pointer.Bool(true),
+				},
+				&vnetpeerings.VnetPeeringSpec{
+					PeeringName:          "hub-vnet-To-my-vnet",
+					SourceVnetName:       "hub-vnet",
+					SourceResourceGroup:  "hub-rg",
+					SourceSubscriptionID: "456",
+					RemoteVnetName:       "my-vnet",
+					RemoteResourceGroup:  "my-rg",
+					SubscriptionID:       "123",
+					IdentityRef:          remoteIdentity,
+					AllowGatewayTransit:  pointer.Bool(true),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.clusterScope.VnetPeeringSpecs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VnetPeeringSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
+			}
+		})
+	}
+}
+
+func TestVnetPeeringAuthorizer(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+	newIdentity := func(name, tenantID string) []runtime.Object {
+		return []runtime.Object{
+			&infrav1.AzureClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type:         infrav1.ManualServicePrincipal,
+					TenantID:     tenantID,
+					ClientID:     name + "-client",
+					ClientSecret: corev1.SecretReference{Name: name + "-secret", Namespace: "default"},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-secret", Namespace: "default"},
+				Data:       map[string][]byte{"clientSecret": []byte(name + "-secret-value")},
+			},
+		}
+	}
+	var initObjects []runtime.Object
+	initObjects = append(initObjects, newIdentity("cluster-identity", "cluster-tenant")...)
+	initObjects = append(initObjects, newIdentity("hub-identity", "hub-tenant")...)
+	initObjects = append(initObjects, newIdentity("spoke-identity", "cluster-tenant")...)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
+
+	clusterScope := &ClusterScope{
+		Client: fakeClient,
+		AzureClients: AzureClients{
+			EnvironmentSettings: auth.EnvironmentSettings{
+				Values: map[string]string{
+					auth.SubscriptionID: "123",
+				},
+			},
+		},
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+			Spec: infrav1.AzureClusterSpec{
+				AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+					IdentityRef: &corev1.ObjectReference{Name: "cluster-identity"},
+				},
 			},
 		},
 	}
 
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			if got := tt.clusterScope.NSGSpecs(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("RouteTableSpecs() = %s, want %s", specArrayToString(got), specArrayToString(tt.want))
-			}
-		})
-	}
+	authorizer, err := clusterScope.VnetPeeringAuthorizer(context.TODO(), "456", &corev1.ObjectReference{Name: "hub-identity"}, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizer.SubscriptionID()).To(Equal("456"))
+	g.Expect(authorizer.TenantID()).To(Equal("hub-tenant"))
+	g.Expect(authorizer.ClientID()).To(Equal("hub-identity-client"))
+	g.Expect(authorizer.BaseURI()).To(Equal("https://management.azure.com/"))
+	g.Expect(authorizer.Authorizer()).NotTo(BeNil())
+	g.Expect(authorizer.Authorizer()).NotTo(BeAssignableToTypeOf(&auxiliaryTokenAuthorizer{}))
+
+	// The peering with a virtual network of another tenant is authorized with an auxiliary token of the tenant.
+	authorizer, err = clusterScope.VnetPeeringAuthorizer(context.TODO(), "", nil, &corev1.ObjectReference{Name: "hub-identity"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizer.SubscriptionID()).To(Equal("123"))
+	g.Expect(authorizer.TenantID()).To(Equal("cluster-tenant"))
+	g.Expect(authorizer.ClientID()).To(Equal("cluster-identity-client"))
+	g.Expect(authorizer.Authorizer()).To(BeAssignableToTypeOf(&auxiliaryTokenAuthorizer{}))
+
+	// No auxiliary token is needed in the same tenant.
+	authorizer, err = clusterScope.VnetPeeringAuthorizer(context.TODO(), "", nil, &corev1.ObjectReference{Name: "spoke-identity"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(authorizer.TenantID()).To(Equal("cluster-tenant"))
+	g.Expect(authorizer.Authorizer()).NotTo(BeAssignableToTypeOf(&auxiliaryTokenAuthorizer{}))
+
+	_, err = clusterScope.VnetPeeringAuthorizer(context.TODO(), "456", &corev1.ObjectReference{Name: "missing-identity"}, nil)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("failed to retrieve AzureClusterIdentity external object \"default\"/\"missing-identity\""))
+
+	_, err = clusterScope.VnetPeeringAuthorizer(context.TODO(), "", nil, &corev1.ObjectReference{Name: "missing-identity"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("failed to init auxiliary credentials provider"))
 }
 
 func TestSubnetSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         []azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns empty if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1213,7 +2248,7 @@ func TestSubnetSpecs(t *testing.T) {
 		},
 		{
 			name: "returns specified subnet spec",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1290,7 +2325,7 @@ func TestSubnetSpecs(t *testing.T) {
 
 		{
 			name: "returns specified subnet spec and bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1415,15 +2450,79 @@ func TestSubnetSpecs(t *testing.T) {
 	}
 }
 
+func TestClusterScopeConcurrentNetworkUpdates(t *testing.T) {
+	g := NewWithT(t)
+
+	const numSubnets = 20
+	subnets := make(infrav1.Subnets, numSubnets)
+	for i := range subnets {
+		subnets[i] = infrav1.SubnetSpec{
+			SubnetClassSpec: infrav1.SubnetClassSpec{Name: fmt.Sprintf("subnet-%d", i), Role: infrav1.SubnetNode},
+			NatGateway:      infrav1.NatGateway{NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: fmt.Sprintf("natgw-%d", i)}},
+		}
+	}
+	clusterScope := &ClusterScope{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+		AzureCluster: &infrav1.AzureCluster{
+			Spec: infrav1.AzureClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Vnet:    infrav1.VnetSpec{Name: "my-vnet"},
+					Subnets: subnets,
+					APIServerLB: infrav1.LoadBalancerSpec{
+						Name:        "my-lb",
+						FrontendIPs: []infrav1.FrontendIP{{Name: "my-frontend"}},
+					},
+				},
+			},
+		},
+		cache: &ClusterCache{},
+	}
+
+	// Update different fields of the same subnets from different goroutines, the way the virtualnetworks, subnets and
+	// natgateways services do when they are reconciled in parallel, while other goroutines read the network spec.
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				f()
+			}
+		}()
+	}
+	for i := 0; i < numSubnets; i++ {
+		name := fmt.Sprintf("subnet-%d", i)
+		natGatewayName := fmt.Sprintf("natgw-%d", i)
+		run(func() { clusterScope.UpdateSubnetID(name, "id-"+name) })
+		run(func() { clusterScope.UpdateSubnetCIDRs(name, []string{"cidr-" + name}) })
+		run(func() { clusterScope.SetNatGatewayIDInSubnets(natGatewayName, "id-"+natGatewayName) })
+		run(func() { _ = clusterScope.SubnetSpecs() })
+	}
+	run(func() { clusterScope.UpdateVnet("my-vnet-id", infrav1.Tags{"foo": "bar"}, []string{"10.0.0.0/8"}) })
+	run(func() { _ = clusterScope.VNetSpec() })
+	run(func() { _ = clusterScope.IsIPv6Enabled() })
+	run(func() { clusterScope.SetAPIServerPrivateLinkServiceAlias("my-alias") })
+	run(func() { _ = clusterScope.APIServerLB() })
+	wg.Wait()
+
+	for i, subnet := range clusterScope.Subnets() {
+		g.Expect(subnet.ID).To(Equal(fmt.Sprintf("id-subnet-%d", i)))
+		g.Expect(subnet.CIDRBlocks).To(Equal([]string{fmt.Sprintf("cidr-subnet-%d", i)}))
+		g.Expect(subnet.NatGateway.ID).To(Equal(fmt.Sprintf("id-natgw-%d", i)))
+	}
+	g.Expect(clusterScope.Vnet().ID).To(Equal("my-vnet-id"))
+	g.Expect(clusterScope.Vnet().CIDRBlocks).To(Equal([]string{"10.0.0.0/8"}))
+}
+
 func TestIsVnetManaged(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         bool
 	}{
 		{
 			name: "VNET ID is empty",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1444,7 +2543,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Wrong tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1468,7 +2567,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has owning tags",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -1492,7 +2591,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of false",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1504,7 +2603,7 @@ func TestIsVnetManaged(t *testing.T) {
 		},
 		{
 			name: "Has cached value of true",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{},
 				},
@@ -1534,12 +2633,12 @@ func TestIsVnetManaged(t *testing.T) {
 func TestAzureBastionSpec(t *testing.T) {
 	tests := []struct {
 		name         string
-		clusterScope ClusterScope
+		clusterScope *ClusterScope
 		want         azure.ResourceSpecGetter
 	}{
 		{
 			name: "returns nil if no subnets are specified",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				AzureCluster: &infrav1.AzureCluster{
 					Spec: infrav1.AzureClusterSpec{
 						NetworkSpec: infrav1.NetworkSpec{
@@ -1552,7 +2651,7 @@ func TestAzureBastionSpec(t *testing.T) {
 		},
 		{
 			name: "returns bastion spec if enabled",
-			clusterScope: ClusterScope{
+			clusterScope: &ClusterScope{
 				Cluster: &clusterv1.Cluster{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "my-cluster",
@@ -2111,7 +3210,7 @@ func TestOutboundLBName(t *testing.T) {
 				azureCluster.Spec.NetworkSpec.NodeOutboundLB = tc.nodeOutboundLB
 			}
 
-			azureCluster.Default()
+			azureCluster.Default(nil)
 
 			initObjects := []runtime.Object{cluster, azureCluster}
 			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
@@ -2227,7 +3326,7 @@ func TestBackendPoolName(t *testing.T) {
 				},
 			}
 
-			azureCluster.Default()
+			azureCluster.Default(nil)
 
 			if tc.customAPIServerBackendPoolName != "" {
 				azureCluster.Spec.NetworkSpec.APIServerLB.BackendPool.Name = tc.customAPIServerBackendPoolName
@@ -2675,6 +3774,189 @@ func TestFailureDomains(t *testing.T) {
 	}
 }
 
+func TestClusterScope_DedicatedHosts(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-cluster",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "cluster.x-k8s.io/v1beta1",
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+				},
+			},
+		},
+		Spec: infrav1.AzureClusterSpec{
+			ResourceGroup: "my-rg",
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+				Location:       "westus2",
+				DedicatedHostGroups: []infrav1.DedicatedHostGroup{
+					{
+						Name:                     "my-hg-1",
+						FailureDomain:            pointer.String("1"),
+						PlatformFaultDomainCount: 2,
+						AutomaticPlacement:       true,
+						Hosts: []infrav1.DedicatedHost{
+							{Name: "my-host-0", SKU: "DSv3-Type3"},
+							{Name: "my-host-1", SKU: "DSv3-Type3", PlatformFaultDomain: 1, AutoReplaceOnFailure: pointer.Bool(false)},
+						},
+					},
+					{
+						Name:                     "my-hg-regional",
+						PlatformFaultDomainCount: 1,
+					},
+				},
+			},
+		},
+	}
+
+	initObjects := []runtime.Object{cluster, azureCluster}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
+
+	clusterScope, err := NewClusterScope(context.TODO(), ClusterScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+		Client:       fakeClient,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(clusterScope.DedicatedHostGroupSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&dedicatedhosts.HostGroupSpec{
+			Name:                     "my-hg-1",
+			ResourceGroup:            "my-rg",
+			Location:                 "westus2",
+			ClusterName:              "my-cluster",
+			Zone:                     "1",
+			PlatformFaultDomainCount: 2,
+			AutomaticPlacement:       true,
+			AdditionalTags:           clusterScope.AdditionalTags(),
+		},
+		&dedicatedhosts.HostGroupSpec{
+			Name:                     "my-hg-regional",
+			ResourceGroup:            "my-rg",
+			Location:                 "westus2",
+			ClusterName:              "my-cluster",
+			PlatformFaultDomainCount: 1,
+			AdditionalTags:           clusterScope.AdditionalTags(),
+		},
+	}))
+	g.Expect(clusterScope.DedicatedHostSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&dedicatedhosts.HostSpec{
+			Name:           "my-host-0",
+			ResourceGroup:  "my-rg",
+			HostGroupName:  "my-hg-1",
+			Location:       "westus2",
+			ClusterName:    "my-cluster",
+			SKU:            "DSv3-Type3",
+			AdditionalTags: clusterScope.AdditionalTags(),
+		},
+		&dedicatedhosts.HostSpec{
+			Name:                 "my-host-1",
+			ResourceGroup:        "my-rg",
+			HostGroupName:        "my-hg-1",
+			Location:             "westus2",
+			ClusterName:          "my-cluster",
+			SKU:                  "DSv3-Type3",
+			PlatformFaultDomain:  1,
+			AutoReplaceOnFailure: pointer.Bool(false),
+			AdditionalTags:       clusterScope.AdditionalTags(),
+		},
+	}))
+
+	zone, ok := clusterScope.DedicatedHostGroupZone("/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Compute/hostGroups/my-hg-1")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(zone).To(Equal("1"))
+
+	zone, ok = clusterScope.DedicatedHostGroupZone("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg-regional")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(zone).To(BeEmpty())
+
+	_, ok = clusterScope.DedicatedHostGroupZone("/subscriptions/123/resourceGroups/other-rg/providers/Microsoft.Compute/hostGroups/my-hg-1")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestClusterScope_ProximityPlacementGroupSpecs(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-cluster",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "cluster.x-k8s.io/v1beta1",
+					Kind:       "Cluster",
+					Name:       "my-cluster",
+				},
+			},
+		},
+		Spec: infrav1.AzureClusterSpec{
+			ResourceGroup: "my-rg",
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+				Location:       "westus2",
+				ProximityPlacementGroups: []infrav1.ProximityPlacementGroup{
+					{Name: "my-ppg-1"},
+					{Name: "my-ppg-2"},
+				},
+			},
+		},
+	}
+
+	initObjects := []runtime.Object{cluster, azureCluster}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
+
+	clusterScope, err := NewClusterScope(context.TODO(), ClusterScopeParams{
+		AzureClients: AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+		Client:       fakeClient,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(clusterScope.ProximityPlacementGroupSpecs()).To(Equal([]azure.ResourceSpecGetter{
+		&proximityplacementgroups.ProximityPlacementGroupSpec{
+			Name:           "my-ppg-1",
+			ResourceGroup:  "my-rg",
+			Location:       "westus2",
+			ClusterName:    "my-cluster",
+			AdditionalTags: clusterScope.AdditionalTags(),
+		},
+		&proximityplacementgroups.ProximityPlacementGroupSpec{
+			Name:           "my-ppg-2",
+			ResourceGroup:  "my-rg",
+			Location:       "westus2",
+			ClusterName:    "my-cluster",
+			AdditionalTags: clusterScope.AdditionalTags(),
+		},
+	}))
+}
+
 func TestClusterScope_LBSpecs(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2725,6 +4007,18 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 								IdleTimeoutInMinutes: pointer.Int32(30),
 								SKU:                  infrav1.SKUStandard,
 							},
+							Probe: &infrav1.LoadBalancerProbe{
+								Protocol:    infrav1.LoadBalancerProbeProtocolHTTPS,
+								RequestPath: "/readyz",
+							},
+							AdditionalRules: []infrav1.LoadBalancingRule{
+								{
+									Name:         "konnectivity",
+									Protocol:     infrav1.LoadBalancingRuleProtocolTCP,
+									FrontendPort: 8132,
+									BackendPort:  8132,
+								},
+							},
 							FrontendIPs: []infrav1.FrontendIP{
 								{
 									Name: "api-server-lb-frontend-ip",
@@ -2802,6 +4096,18 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"foo": "bar",
 					},
+					Probe: &infrav1.LoadBalancerProbe{
+						Protocol:    infrav1.LoadBalancerProbeProtocolHTTPS,
+						RequestPath: "/readyz",
+					},
+					AdditionalRules: []infrav1.LoadBalancingRule{
+						{
+							Name:         "konnectivity",
+							Protocol:     infrav1.LoadBalancingRuleProtocolTCP,
+							FrontendPort: 8132,
+							BackendPort:  8132,
+						},
+					},
 				},
 				&loadbalancers.LBSpec{
 					Name:              "node-outbound-lb",
@@ -2920,6 +4226,105 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv6-only node outbound LB",
+			azureCluster: &infrav1.AzureCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-cluster",
+				},
+				Spec: infrav1.AzureClusterSpec{
+					AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+						SubscriptionID: "123",
+						Location:       "westus2",
+					},
+					ResourceGroup: "my-rg",
+					NetworkSpec: infrav1.NetworkSpec{
+						NetworkClassSpec: infrav1.NetworkClassSpec{
+							IPv6Only: true,
+						},
+						Vnet: infrav1.VnetSpec{
+							Name:          "my-vnet",
+							ResourceGroup: "my-rg",
+							VnetClassSpec: infrav1.VnetClassSpec{
+								CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+							},
+						},
+						Subnets: []infrav1.SubnetSpec{
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "cp-subnet",
+									Role: infrav1.SubnetControlPlane,
+								},
+							},
+							{
+								SubnetClassSpec: infrav1.SubnetClassSpec{
+									Name: "node-subnet",
+									Role: infrav1.SubnetNode,
+								},
+							},
+						},
+						APIServerLB: infrav1.LoadBalancerSpec{
+							Name: "api-server-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "api-server-lb-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type:                 infrav1.Internal,
+								IdleTimeoutInMinutes: pointer.Int32(30),
+								SKU:                  infrav1.SKUStandard,
+							},
+						},
+						NodeOutboundLB: &infrav1.LoadBalancerSpec{
+							Name: "node-outbound-lb",
+							BackendPool: infrav1.BackendPool{
+								Name: "node-outbound-backend-pool",
+							},
+							LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+								Type:                 infrav1.Public,
+								IdleTimeoutInMinutes: pointer.Int32(50),
+								SKU:                  infrav1.SKUStandard,
+							},
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&loadbalancers.LBSpec{
+					Name:                 "api-server-lb",
+					ResourceGroup:        "my-rg",
+					SubscriptionID:       "123",
+					ClusterName:          "my-cluster",
+					Location:             "westus2",
+					VNetName:             "my-vnet",
+					VNetResourceGroup:    "my-rg",
+					SubnetName:           "cp-subnet",
+					APIServerPort:        6443,
+					Type:                 infrav1.Internal,
+					SKU:                  infrav1.SKUStandard,
+					Role:                 infrav1.APIServerRole,
+					BackendPoolName:      "api-server-lb-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(30),
+					AdditionalTags:       infrav1.Tags{},
+				},
+				&loadbalancers.LBSpec{
+					Name:                 "node-outbound-lb",
+					ResourceGroup:        "my-rg",
+					SubscriptionID:       "123",
+					ClusterName:          "my-cluster",
+					Location:             "westus2",
+					VNetName:             "my-vnet",
+					VNetResourceGroup:    "my-rg",
+					Type:                 infrav1.Public,
+					SKU:                  infrav1.SKUStandard,
+					Role:                 infrav1.NodeOutboundRole,
+					BackendPoolName:      "node-outbound-backend-pool",
+					IdleTimeoutInMinutes: pointer.Int32(50),
+					AdditionalTags:       infrav1.Tags{},
+					IPv6Enabled:          true,
+					IPv6Only:             true,
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -2955,3 +4360,29 @@ func TestClusterScope_LBSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterScopeDriftDetection(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterScope := &ClusterScope{
+		AzureCluster: &infrav1.AzureCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{azure.DriftDetectionAnnotation: string(azure.DriftDetectionReportOnly)},
+			},
+		},
+	}
+	g.Expect(clusterScope.DriftDetectionMode()).To(Equal(azure.DriftDetectionReportOnly))
+
+	setDriftDetectedCondition(clusterScope.AzureCluster, clusterScope.DriftDetectionMode(), clusterScope.Drifts())
+	g.Expect(conditions.Has(clusterScope.AzureCluster, infrav1.DriftDetectedCondition)).To(BeFalse())
+
+	clusterScope.ReportDrift(azure.ResourceDrift{ServiceName: "publicips", ResourceGroup: "my-rg", ResourceName: "my-publicip"})
+	setDriftDetectedCondition(clusterScope.AzureCluster, clusterScope.DriftDetectionMode(), clusterScope.Drifts())
+	g.Expect(conditions.IsTrue(clusterScope.AzureCluster, infrav1.DriftDetectedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(clusterScope.AzureCluster, infrav1.DriftDetectedCondition)).To(Equal(infrav1.DriftedReason))
+	g.Expect(conditions.GetMessage(clusterScope.AzureCluster, infrav1.DriftDetectedCondition)).To(Equal("1 Azure resource(s) drifted from their desired state: my-rg/my-publicip (service: publicips)"))
+
+	// The condition is removed once drift detection is disabled.
+	setDriftDetectedCondition(clusterScope.AzureCluster, azure.DriftDetectionDisabled, clusterScope.Drifts())
+	g.Expect(conditions.Has(clusterScope.AzureCluster, infrav1.DriftDetectedCondition)).To(BeFalse())
+}
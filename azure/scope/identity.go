@@ -19,13 +19,17 @@ package scope
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	aadpodid "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity"
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/jongio/azidext/go/azidext"
@@ -42,7 +46,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const azureSecretKey = "clientSecret"
+const (
+	azureSecretKey = "clientSecret"
+
+	// federatedTokenFileEnvKey is the environment variable set by the Azure workload identity webhook to the path of
+	// the projected service account token.
+	federatedTokenFileEnvKey = "AZURE_FEDERATED_TOKEN_FILE"
+	// defaultFederatedTokenFile is the path of the projected service account token when it is not set in the environment.
+	defaultFederatedTokenFile = "/var/run/secrets/azure/tokens/azure-identity-token" //nolint:gosec // Not a credential, only the path to one.
+	// workloadIdentityRequestTimeout is the timeout of a request to Azure AD for a workload identity token.
+	workloadIdentityRequestTimeout = 30 * time.Second
+	// tokenRefreshMargin is how long before its expiry a cached Azure AD token is refreshed.
+	tokenRefreshMargin = 5 * time.Minute
+)
 
 // CredentialsProvider defines the behavior for azure identity based credential providers.
 type CredentialsProvider interface {
@@ -56,6 +72,9 @@ type CredentialsProvider interface {
 type AzureCredentialsProvider struct {
 	Client   client.Client
 	Identity *infrav1.AzureClusterIdentity
+	// WorkloadIdentityProvider creates the credential of a WorkloadIdentity. It defaults to a
+	// WorkloadIdentityCredentialsProvider.
+	WorkloadIdentityProvider TokenCredentialProvider
 }
 
 // AzureClusterCredentialsProvider wraps AzureCredentialsProvider with AzureCluster.
@@ -78,8 +97,12 @@ func NewAzureClusterCredentialsProvider(ctx context.Context, kubeClient client.C
 	if azureCluster.Spec.IdentityRef == nil {
 		return nil, errors.New("failed to generate new AzureClusterCredentialsProvider from empty identityName")
 	}
+	return newAzureClusterCredentialsProvider(ctx, kubeClient, azureCluster, azureCluster.Spec.IdentityRef)
+}
 
-	ref := azureCluster.Spec.IdentityRef
+// newAzureClusterCredentialsProvider creates a new AzureClusterCredentialsProvider for the AzureClusterIdentity
+// referenced by ref, which may differ from the identity of the AzureCluster.
+func newAzureClusterCredentialsProvider(ctx context.Context, kubeClient client.Client, azureCluster *infrav1.AzureCluster, ref *corev1.ObjectReference) (*AzureClusterCredentialsProvider, error) {
 	// if the namespace isn't specified then assume it's in the same namespace as the AzureCluster
 	namespace := ref.Namespace
 	if namespace == "" {
@@ -141,6 +164,7 @@ func (p *ManagedControlPlaneCredentialsProvider) GetAuthorizer(ctx context.Conte
 func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceManagerEndpoint, activeDirectoryEndpoint, tokenAudience string, clusterMeta metav1.ObjectMeta) (autorest.Authorizer, error) {
 	var authErr error
 	var cred azcore.TokenCredential
+	var clientSecret string
 	switch p.Identity.Spec.Type {
 	case infrav1.ServicePrincipal, infrav1.ServicePrincipalCertificate, infrav1.UserAssignedMSI:
 		if err := createAzureIdentityWithBindings(ctx, p.Identity, resourceManagerEndpoint, activeDirectoryEndpoint, clusterMeta, p.Client); err != nil {
@@ -153,7 +177,8 @@ func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceMa
 		cred, authErr = azidentity.NewManagedIdentityCredential(&options)
 
 	case infrav1.ManualServicePrincipal:
-		clientSecret, err := p.GetClientSecret(ctx)
+		var err error
+		clientSecret, err = p.GetClientSecret(ctx)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get client secret")
 		}
@@ -173,6 +198,13 @@ func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceMa
 		}
 		cred, authErr = azidentity.NewClientSecretCredential(p.GetTenantID(), p.Identity.Spec.ClientID, clientSecret, &options)
 
+	case infrav1.WorkloadIdentity:
+		provider := p.WorkloadIdentityProvider
+		if provider == nil {
+			provider = &WorkloadIdentityCredentialsProvider{}
+		}
+		cred, authErr = provider.GetTokenCredential(ctx, p.Identity, activeDirectoryEndpoint)
+
 	default:
 		return nil, errors.Errorf("identity type %s not supported", p.Identity.Spec.Type)
 	}
@@ -186,7 +218,21 @@ func (p *AzureCredentialsProvider) GetAuthorizer(ctx context.Context, resourceMa
 	if !strings.HasSuffix(scope, "/.default") {
 		scope += "/.default"
 	}
-	authorizer := azidext.NewTokenCredentialAdapter(cred, []string{scope})
+
+	// Clusters sharing an identity share its tokens. The resource version of the referenced Secret and the client
+	// secret are part of the key so that rotated credentials are picked up.
+	secretVersion, err := p.clientSecretResourceVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := tokenCacheKey(string(p.Identity.UID), string(p.Identity.Spec.Type), p.GetTenantID(), p.GetClientID(),
+		p.Identity.Spec.ResourceID, activeDirectoryEndpoint, scope, secretVersion, clientSecret)
+	identityName := p.Identity.Namespace + "/" + p.Identity.Name
+	cachedCred, err := getCachedTokenCredential(key, identityName, cred)
+	if err != nil {
+		return nil, err
+	}
+	authorizer := azidext.NewTokenCredentialAdapter(cachedCred, []string{scope})
 	return authorizer, nil
 }
 
@@ -215,6 +261,21 @@ func (p *AzureCredentialsProvider) GetClientSecret(ctx context.Context) (string,
 	return "", nil
 }
 
+// clientSecretResourceVersion returns the resource version of the Secret referenced by the identity, or an empty
+// string if it doesn't reference one.
+func (p *AzureCredentialsProvider) clientSecretResourceVersion(ctx context.Context) (string, error) {
+	secretRef := p.Identity.Spec.ClientSecret
+	if secretRef.Name == "" {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: secretRef.Namespace, Name: secretRef.Name}
+	if err := p.Client.Get(ctx, key, secret); err != nil {
+		return "", errors.Wrap(err, "Unable to fetch ClientSecret")
+	}
+	return secret.ResourceVersion, nil
+}
+
 // GetTenantID returns the Tenant ID associated with the AzureCredentialsProvider's Identity.
 func (p *AzureCredentialsProvider) GetTenantID() string {
 	return p.Identity.Spec.TenantID
@@ -226,6 +287,71 @@ func (p *AzureCredentialsProvider) hasClientSecret() bool {
 	return p.Identity.Spec.Type == infrav1.ServicePrincipal || p.Identity.Spec.Type == infrav1.ManualServicePrincipal
 }
 
+// TokenCredentialProvider creates the azcore.TokenCredential of an AzureClusterIdentity whose type does not rely on
+// aad-pod-identity.
+type TokenCredentialProvider interface {
+	GetTokenCredential(ctx context.Context, identity *infrav1.AzureClusterIdentity, activeDirectoryEndpoint string) (azcore.TokenCredential, error)
+}
+
+// WorkloadIdentityCredentialsProvider is the TokenCredentialProvider of the WorkloadIdentity type. Its credential
+// exchanges a projected Kubernetes service account token for an Azure AD token, using a federated identity credential
+// of the Azure AD application or user-assigned identity.
+type WorkloadIdentityCredentialsProvider struct {
+	// TokenFilePath is the path of the projected service account token. It defaults to the value of the
+	// AZURE_FEDERATED_TOKEN_FILE environment variable, or to the path used by the Azure workload identity webhook.
+	TokenFilePath string
+	// Transport sends the requests to Azure AD. It defaults to an HTTP client that times out after
+	// workloadIdentityRequestTimeout.
+	Transport policy.Transporter
+}
+
+var _ TokenCredentialProvider = (*WorkloadIdentityCredentialsProvider)(nil)
+
+// GetTokenCredential returns an azidentity.ClientAssertionCredential for the client ID and tenant of the identity,
+// which reads the service account token again for every token request because the kubelet rotates it.
+func (w *WorkloadIdentityCredentialsProvider) GetTokenCredential(_ context.Context, identity *infrav1.AzureClusterIdentity, activeDirectoryEndpoint string) (azcore.TokenCredential, error) {
+	if identity.Spec.TenantID == "" {
+		return nil, errors.New("tenant ID is required for workload identity")
+	}
+	if identity.Spec.ClientID == "" {
+		return nil, errors.New("client ID is required for workload identity")
+	}
+
+	tokenFilePath := w.tokenFilePath()
+	getAssertion := func(context.Context) (string, error) {
+		assertion, err := os.ReadFile(tokenFilePath)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read service account token from %s", tokenFilePath)
+		}
+		return strings.TrimSpace(string(assertion)), nil
+	}
+
+	transport := w.Transport
+	if transport == nil {
+		transport = &http.Client{Timeout: workloadIdentityRequestTimeout}
+	}
+	options := azidentity.ClientAssertionCredentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: activeDirectoryEndpoint,
+			},
+			Transport: transport,
+		},
+	}
+	return azidentity.NewClientAssertionCredential(identity.Spec.TenantID, identity.Spec.ClientID, getAssertion, &options)
+}
+
+// tokenFilePath returns the path of the projected service account token.
+func (w *WorkloadIdentityCredentialsProvider) tokenFilePath() string {
+	if w.TokenFilePath != "" {
+		return w.TokenFilePath
+	}
+	if path := os.Getenv(federatedTokenFileEnvKey); path != "" {
+		return path
+	}
+	return defaultFederatedTokenFile
+}
+
 func createAzureIdentityWithBindings(ctx context.Context, azureIdentity *infrav1.AzureClusterIdentity, resourceManagerEndpoint, activeDirectoryEndpoint string, clusterMeta metav1.ObjectMeta,
 	kubeClient client.Client) error {
 	azureIdentityType, err := getAzureIdentityType(azureIdentity)
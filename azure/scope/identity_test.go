@@ -18,10 +18,19 @@ package scope
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	aadpodid "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity"
 	aadpodv1 "github.com/Azure/aad-pod-identity/pkg/apis/aadpodidentity/v1"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -341,6 +350,15 @@ func TestHasClientSecret(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "workload identity",
+			identity: &infrav1.AzureClusterIdentity{
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type: infrav1.WorkloadIdentity,
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -353,3 +371,141 @@ func TestHasClientSecret(t *testing.T) {
 		})
 	}
 }
+
+// fakeAADTransport is a policy.Transporter that answers the Azure AD instance discovery, OpenID configuration and
+// token requests of a client assertion credential.
+type fakeAADTransport struct {
+	tokenStatus   int
+	tokenResponse map[string]interface{}
+	tokenRequests []url.Values
+}
+
+func (f *fakeAADTransport) Do(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	var body interface{}
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/discovery/instance"):
+		body = map[string]interface{}{
+			"tenant_discovery_endpoint": "https://login.microsoftonline.com/fake-tenant/v2.0/.well-known/openid-configuration",
+			"metadata": []map[string]interface{}{
+				{"preferred_network": "login.microsoftonline.com", "preferred_cache": "login.windows.net", "aliases": []string{"login.microsoftonline.com"}},
+			},
+		}
+	case strings.HasSuffix(req.URL.Path, "/.well-known/openid-configuration"):
+		body = map[string]interface{}{
+			"authorization_endpoint": "https://login.microsoftonline.com/fake-tenant/oauth2/v2.0/authorize",
+			"token_endpoint":         "https://login.microsoftonline.com/fake-tenant/oauth2/v2.0/token",
+			"issuer":                 "https://login.microsoftonline.com/fake-tenant/v2.0",
+		}
+	case strings.HasSuffix(req.URL.Path, "/fake-tenant/oauth2/v2.0/token"):
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		f.tokenRequests = append(f.tokenRequests, req.PostForm)
+		rec.WriteHeader(f.tokenStatus)
+		body = f.tokenResponse
+	default:
+		rec.WriteHeader(http.StatusNotFound)
+	}
+	if err := json.NewEncoder(rec).Encode(body); err != nil {
+		return nil, err
+	}
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+func TestWorkloadIdentityCredentialsProvider(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		response      map[string]interface{}
+		noTokenFile   bool
+		expectedToken string
+		expectedError string
+	}{
+		{
+			name:          "exchanges the service account token for an Azure AD token",
+			status:        http.StatusOK,
+			response:      map[string]interface{}{"access_token": "fake-access-token", "expires_in": 3600, "token_type": "Bearer"},
+			expectedToken: "fake-access-token",
+		},
+		{
+			name:          "returns the error of the token endpoint",
+			status:        http.StatusUnauthorized,
+			response:      map[string]interface{}{"error": "invalid_client", "error_description": "AADSTS70021: No matching federated identity record found"},
+			expectedError: "AADSTS70021",
+		},
+		{
+			name:          "fails without a service account token",
+			noTokenFile:   true,
+			expectedError: "failed to read service account token",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tokenFile := filepath.Join(t.TempDir(), "azure-identity-token")
+			if !tc.noTokenFile {
+				g.Expect(os.WriteFile(tokenFile, []byte("fake-service-account-token\n"), 0600)).To(Succeed())
+			}
+			transport := &fakeAADTransport{tokenStatus: tc.status, tokenResponse: tc.response}
+			provider := &WorkloadIdentityCredentialsProvider{
+				TokenFilePath: tokenFile,
+				Transport:     transport,
+			}
+			identity := &infrav1.AzureClusterIdentity{
+				Spec: infrav1.AzureClusterIdentitySpec{
+					Type:     infrav1.WorkloadIdentity,
+					TenantID: "fake-tenant",
+					ClientID: "fake-client-id",
+				},
+			}
+
+			cred, err := provider.GetTokenCredential(context.TODO(), identity, "https://login.microsoftonline.com/")
+			g.Expect(err).NotTo(HaveOccurred())
+
+			token, err := cred.GetToken(context.TODO(), policy.TokenRequestOptions{Scopes: []string{"https://management.azure.com/.default"}})
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(token.Token).To(Equal(tc.expectedToken))
+			g.Expect(token.ExpiresOn).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+			g.Expect(transport.tokenRequests).To(HaveLen(1))
+			form := transport.tokenRequests[0]
+			g.Expect(form.Get("client_id")).To(Equal("fake-client-id"))
+			g.Expect(form.Get("grant_type")).To(Equal("client_credentials"))
+			g.Expect(form.Get("client_assertion_type")).To(Equal("urn:ietf:params:oauth:client-assertion-type:jwt-bearer"))
+			g.Expect(form.Get("client_assertion")).To(Equal("fake-service-account-token"))
+		})
+	}
+}
+
+func TestWorkloadIdentityCredentialsProviderDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := &WorkloadIdentityCredentialsProvider{}
+	g.Expect(provider.tokenFilePath()).To(Equal(defaultFederatedTokenFile))
+	t.Setenv(federatedTokenFileEnvKey, "/fake/token/path")
+	g.Expect(provider.tokenFilePath()).To(Equal("/fake/token/path"))
+
+	identity := &infrav1.AzureClusterIdentity{
+		Spec: infrav1.AzureClusterIdentitySpec{
+			Type:     infrav1.WorkloadIdentity,
+			ClientID: "fake-client-id",
+		},
+	}
+	_, err := provider.GetTokenCredential(context.TODO(), identity, "https://login.microsoftonline.com/")
+	g.Expect(err).To(MatchError("tenant ID is required for workload identity"))
+	identity.Spec.TenantID = "fake-tenant"
+	identity.Spec.ClientID = ""
+	_, err = provider.GetTokenCredential(context.TODO(), identity, "https://login.microsoftonline.com/")
+	g.Expect(err).To(MatchError("client ID is required for workload identity"))
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"net"
+	"sort"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
@@ -40,6 +42,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachines"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vmextensions"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
@@ -96,6 +99,8 @@ type MachineScope struct {
 	Machine      *clusterv1.Machine
 	AzureMachine *infrav1.AzureMachine
 	cache        *MachineCache
+	// drifts holds the drift of the Azure resources detected during this reconcile.
+	drifts []azure.ResourceDrift
 }
 
 // MachineCache stores common machine information so we don't have to hit the API multiple times within the same reconcile loop.
@@ -147,26 +152,32 @@ func (m *MachineScope) InitMachineCache(ctx context.Context) error {
 // VMSpec returns the VM spec.
 func (m *MachineScope) VMSpec() azure.ResourceSpecGetter {
 	spec := &virtualmachines.VMSpec{
-		Name:                   m.Name(),
-		Location:               m.Location(),
-		ResourceGroup:          m.ResourceGroup(),
-		ClusterName:            m.ClusterName(),
-		Role:                   m.Role(),
-		NICIDs:                 m.NICIDs(),
-		SSHKeyData:             m.AzureMachine.Spec.SSHPublicKey,
-		Size:                   m.AzureMachine.Spec.VMSize,
-		OSDisk:                 m.AzureMachine.Spec.OSDisk,
-		DataDisks:              m.AzureMachine.Spec.DataDisks,
-		AvailabilitySetID:      m.AvailabilitySetID(),
-		Zone:                   m.AvailabilityZone(),
-		Identity:               m.AzureMachine.Spec.Identity,
-		UserAssignedIdentities: m.AzureMachine.Spec.UserAssignedIdentities,
-		SpotVMOptions:          m.AzureMachine.Spec.SpotVMOptions,
-		SecurityProfile:        m.AzureMachine.Spec.SecurityProfile,
-		DiagnosticsProfile:     m.AzureMachine.Spec.Diagnostics,
-		AdditionalTags:         m.AdditionalTags(),
-		AdditionalCapabilities: m.AzureMachine.Spec.AdditionalCapabilities,
-		ProviderID:             m.ProviderID(),
+		Name:                       m.Name(),
+		Location:                   m.Location(),
+		ResourceGroup:              m.ResourceGroup(),
+		ClusterName:                m.ClusterName(),
+		Role:                       m.Role(),
+		NICIDs:                     m.NICIDs(),
+		SSHKeyData:                 m.AzureMachine.Spec.SSHPublicKey,
+		Size:                       m.AzureMachine.Spec.VMSize,
+		OSDisk:                     m.AzureMachine.Spec.OSDisk,
+		DataDisks:                  m.AzureMachine.Spec.DataDisks,
+		AvailabilitySetID:          m.AvailabilitySetID(),
+		Zone:                       m.AvailabilityZone(),
+		HostGroupID:                m.AzureMachine.Spec.HostGroupID,
+		HostID:                     m.AzureMachine.Spec.HostID,
+		HostGroupZone:              m.dedicatedHostGroupZone(),
+		ProximityPlacementGroupID:  m.AzureMachine.Spec.ProximityPlacementGroupID,
+		CapacityReservationGroupID: m.AzureMachine.Spec.CapacityReservationGroupID,
+		Identity:                   m.AzureMachine.Spec.Identity,
+		UserAssignedIdentities:     m.AzureMachine.Spec.UserAssignedIdentities,
+		SpotVMOptions:              m.AzureMachine.Spec.SpotVMOptions,
+		SecurityProfile:            m.AzureMachine.Spec.SecurityProfile,
+		DiagnosticsProfile:         m.AzureMachine.Spec.Diagnostics,
+		AdditionalTags:             m.AdditionalTags(),
+		AdditionalCapabilities:     m.AzureMachine.Spec.AdditionalCapabilities,
+		ProviderID:                 m.ProviderID(),
+		UpdateStrategy:             m.AzureMachine.Spec.UpdateStrategy,
 	}
 	if m.cache != nil {
 		spec.SKU = m.cache.VMSKU
@@ -261,6 +272,13 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 		IPConfigs:             []networkinterfaces.IPConfig{},
 	}
 
+	if m.Role() == infrav1.ControlPlane {
+		spec.ApplicationSecurityGroups = []string{azure.GenerateControlPlaneApplicationSecurityGroupName(m.ClusterName())}
+	} else {
+		spec.ApplicationSecurityGroups = []string{azure.GenerateNodeApplicationSecurityGroupName(m.ClusterName())}
+	}
+	spec.ApplicationSecurityGroups = append(spec.ApplicationSecurityGroups, m.AzureMachine.Spec.ApplicationSecurityGroups...)
+
 	if m.cache != nil {
 		spec.SKU = &m.cache.VMSKU
 	}
@@ -278,6 +296,7 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 			if m.IsAPIServerPrivate() {
 				spec.InternalLBName = m.APIServerLBName()
 				spec.InternalLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
+				spec.PublicLBIPv6AddressPoolName = outboundIPv6PoolName(m.ClusterScoper, m.OutboundLBName(m.Role()))
 			} else {
 				spec.PublicLBNATRuleName = m.Name()
 				spec.PublicLBAddressPoolName = m.APIServerLBPoolName(m.APIServerLBName())
@@ -296,12 +315,22 @@ func (m *MachineScope) BuildNICSpec(nicName string, infrav1NetworkInterface infr
 		if m.Role() == infrav1.Node && !m.Subnet().IsNatGatewayEnabled() && !m.AzureMachine.Spec.AllocatePublicIP {
 			spec.PublicLBName = m.OutboundLBName(m.Role())
 			spec.PublicLBAddressPoolName = m.OutboundPoolName(m.OutboundLBName(m.Role()))
+			spec.PublicLBIPv6AddressPoolName = outboundIPv6PoolName(m.ClusterScoper, m.OutboundLBName(m.Role()))
 		}
 	}
 
 	return spec
 }
 
+// outboundIPv6PoolName returns the IPv6 backend pool of an outbound load balancer, or an empty string if IPv6 isn't
+// enabled or there is no outbound load balancer.
+func outboundIPv6PoolName(scope azure.ClusterScoper, loadBalancerName string) string {
+	if !scope.IsIPv6Enabled() || loadBalancerName == "" {
+		return ""
+	}
+	return azure.GenerateIPv6BackendAddressPoolName(scope.OutboundPoolName(loadBalancerName))
+}
+
 // NICIDs returns the NIC resource IDs.
 func (m *MachineScope) NICIDs() []string {
 	nicspecs := m.NICSpecs()
@@ -327,7 +356,61 @@ func (m *MachineScope) DiskSpecs() []azure.ResourceSpecGetter {
 			ResourceGroup: m.ResourceGroup(),
 		}
 	}
-	return diskSpecs
+	// The data disks removed in place may not be deleted yet.
+	return append(diskSpecs, m.RemovedDataDiskSpecs()...)
+}
+
+// DataDisksSpec returns the data disks spec of the VM if it is updated in place, so that data disks can be attached
+// and detached while it is running.
+func (m *MachineScope) DataDisksSpec() azure.ResourceSpecGetter {
+	if m.AzureMachine.Spec.UpdateStrategy != infrav1.AzureMachineUpdateStrategyInPlace {
+		return nil
+	}
+	return &disks.DataDisksSpec{
+		VMName:        m.Name(),
+		ResourceGroup: m.ResourceGroup(),
+		DataDisks:     m.AzureMachine.Spec.DataDisks,
+	}
+}
+
+// RemovedDataDiskSpecs returns the specs of the data disks removed from the VM since they were last applied, whose
+// managed disks are deleted once they are detached.
+func (m *MachineScope) RemovedDataDiskSpecs() []azure.ResourceSpecGetter {
+	lastApplied, err := m.AnnotationJSON(infrav1.DataDisksLastAppliedAnnotation)
+	if err != nil {
+		// The annotation is only written by the controller, ignore it if it was tampered with.
+		return nil
+	}
+
+	desired := make(map[string]struct{}, len(m.AzureMachine.Spec.DataDisks))
+	for _, dd := range m.AzureMachine.Spec.DataDisks {
+		desired[dd.NameSuffix] = struct{}{}
+	}
+
+	var specs []azure.ResourceSpecGetter
+	for nameSuffix, name := range lastApplied {
+		diskName, ok := name.(string)
+		if _, found := desired[nameSuffix]; found || !ok {
+			continue
+		}
+		specs = append(specs, &disks.DiskSpec{
+			Name:          diskName,
+			ResourceGroup: m.ResourceGroup(),
+		})
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].ResourceName() < specs[j].ResourceName()
+	})
+	return specs
+}
+
+// UpdateDataDisksLastApplied records the data disks attached to the VM, once the removed ones are deleted.
+func (m *MachineScope) UpdateDataDisksLastApplied() error {
+	lastApplied := make(map[string]interface{}, len(m.AzureMachine.Spec.DataDisks))
+	for _, dd := range m.AzureMachine.Spec.DataDisks {
+		lastApplied[dd.NameSuffix] = azure.GenerateDataDiskName(m.Name(), dd.NameSuffix)
+	}
+	return m.UpdateAnnotationJSON(infrav1.DataDisksLastAppliedAnnotation, lastApplied)
 }
 
 // RoleAssignmentSpecs returns the role assignment specs.
@@ -418,6 +501,21 @@ func (m *MachineScope) AvailabilityZone() string {
 	return ""
 }
 
+// dedicatedHostGroupZone returns the availability zone of the dedicated host group the VM is placed in, when the group
+// is managed by the cluster.
+func (m *MachineScope) dedicatedHostGroupZone() string {
+	hostGroupID := m.AzureMachine.Spec.HostGroupID
+	if hostID := m.AzureMachine.Spec.HostID; hostID != "" {
+		hostGroupID = infrav1.DedicatedHostGroupIDFromHostID(hostID)
+	}
+	if hostGroupID == "" {
+		return ""
+	}
+
+	zone, _ := m.DedicatedHostGroupZone(hostGroupID)
+	return zone
+}
+
 // Name returns the AzureMachine name.
 func (m *MachineScope) Name() string {
 	if id := m.GetVMID(); id != "" {
@@ -474,12 +572,13 @@ func (m *MachineScope) AvailabilitySetSpec() azure.ResourceSpecGetter {
 	}
 
 	spec := &availabilitysets.AvailabilitySetSpec{
-		Name:           availabilitySetName,
-		ResourceGroup:  m.ResourceGroup(),
-		ClusterName:    m.ClusterName(),
-		Location:       m.Location(),
-		SKU:            nil,
-		AdditionalTags: m.AdditionalTags(),
+		Name:                      availabilitySetName,
+		ResourceGroup:             m.ResourceGroup(),
+		ClusterName:               m.ClusterName(),
+		Location:                  m.Location(),
+		SKU:                       nil,
+		AdditionalTags:            m.AdditionalTags(),
+		ProximityPlacementGroupID: m.AzureMachine.Spec.ProximityPlacementGroupID,
 	}
 
 	if m.cache != nil {
@@ -495,6 +594,12 @@ func (m *MachineScope) AvailabilitySet() (string, bool) {
 		return "", false
 	}
 
+	// VMs placed on dedicated hosts or allocated from a capacity reservation group cannot be in an availability set.
+	if m.AzureMachine != nil && (m.AzureMachine.Spec.HostGroupID != "" || m.AzureMachine.Spec.HostID != "" ||
+		m.AzureMachine.Spec.CapacityReservationGroupID != "") {
+		return "", false
+	}
+
 	if m.IsControlPlane() {
 		return azure.GenerateAvailabilitySetName(m.ClusterName(), azure.ControlPlaneNodeGroup), true
 	}
@@ -563,6 +668,16 @@ func (m *MachineScope) SetVMState(v infrav1.ProvisioningState) {
 	m.AzureMachine.Status.VMState = &v
 }
 
+// CapacityReservationID returns the AzureMachine capacity reservation ID status.
+func (m *MachineScope) CapacityReservationID() string {
+	return m.AzureMachine.Status.CapacityReservationID
+}
+
+// SetCapacityReservationID sets the AzureMachine capacity reservation ID status.
+func (m *MachineScope) SetCapacityReservationID(id string) {
+	m.AzureMachine.Status.CapacityReservationID = id
+}
+
 // SetReady sets the AzureMachine Ready Status to true.
 func (m *MachineScope) SetReady() {
 	m.AzureMachine.Status.Ready = true
@@ -588,6 +703,16 @@ func (m *MachineScope) SetConditionFalse(conditionType clusterv1.ConditionType,
 	conditions.MarkFalse(m.AzureMachine, conditionType, reason, severity, message)
 }
 
+// VMResizeInProgress returns true if an in-place resize of the VM has started and has not completed yet.
+func (m *MachineScope) VMResizeInProgress() bool {
+	return conditions.IsFalse(m.AzureMachine, infrav1.VMSizeReadyCondition)
+}
+
+// SpotVMStopped returns true if the AzureMachine is annotated to mark the deallocation of its Spot VM as intended.
+func (m *MachineScope) SpotVMStopped() bool {
+	return m.AzureMachine.GetAnnotations()[azure.SpotVMStoppedAnnotation] == "true"
+}
+
 // SetAnnotation sets a key value annotation on the AzureMachine.
 func (m *MachineScope) SetAnnotation(key, value string) {
 	if m.AzureMachine.Annotations == nil {
@@ -596,6 +721,28 @@ func (m *MachineScope) SetAnnotation(key, value string) {
 	m.AzureMachine.Annotations[key] = value
 }
 
+// DriftDetectionMode returns the drift detection mode set on the AzureMachine with the DriftDetectionAnnotation.
+// If the AzureMachine doesn't set one, the mode of the AzureCluster is used.
+func (m *MachineScope) DriftDetectionMode() azure.DriftDetectionMode {
+	if mode, ok := m.AzureMachine.GetAnnotations()[azure.DriftDetectionAnnotation]; ok {
+		return azure.DriftDetectionMode(mode)
+	}
+	if reporter, ok := m.ClusterScoper.(azure.DriftReporter); ok {
+		return reporter.DriftDetectionMode()
+	}
+	return azure.DriftDetectionDisabled
+}
+
+// ReportDrift records the drift of an Azure resource of the AzureMachine.
+func (m *MachineScope) ReportDrift(drift azure.ResourceDrift) {
+	m.drifts = append(m.drifts, drift)
+}
+
+// Drifts returns the drift of the Azure resources detected during this reconcile.
+func (m *MachineScope) Drifts() []azure.ResourceDrift {
+	return m.drifts
+}
+
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (m *MachineScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
@@ -625,11 +772,27 @@ func (m *MachineScope) UpdateAnnotationJSON(annotation string, content map[strin
 
 // SetAddresses sets the Azure address status.
 func (m *MachineScope) SetAddresses(addrs []corev1.NodeAddress) {
+	if m.IsIPv6Only() {
+		addrs = ipv6OnlyAddresses(addrs)
+	}
 	m.AzureMachine.Status.Addresses = addrs
 }
 
+// ipv6OnlyAddresses filters out the IPv4 addresses of a machine, which aren't used in the IPv6-only network mode.
+func ipv6OnlyAddresses(addrs []corev1.NodeAddress) []corev1.NodeAddress {
+	var filtered []corev1.NodeAddress
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr.Address); ip != nil && ip.To4() != nil {
+			continue
+		}
+		filtered = append(filtered, addr)
+	}
+	return filtered
+}
+
 // PatchObject persists the machine spec and status.
 func (m *MachineScope) PatchObject(ctx context.Context) error {
+	setDriftDetectedCondition(m.AzureMachine, m.DriftDetectionMode(), m.Drifts())
 	conditions.SetSummary(m.AzureMachine)
 
 	return m.patchHelper.Patch(
@@ -638,8 +801,10 @@ func (m *MachineScope) PatchObject(ctx context.Context) error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
 			infrav1.VMRunningCondition,
+			infrav1.VMSizeReadyCondition,
 			infrav1.AvailabilitySetReadyCondition,
 			infrav1.NetworkInterfaceReadyCondition,
+			infrav1.DriftDetectedCondition,
 		}})
 }
 
@@ -746,6 +911,11 @@ func (m *MachineScope) DeleteLongRunningOperationState(name, service, futureType
 	futures.Delete(m.AzureMachine, name, service, futureType)
 }
 
+// RecordOperation records an Azure operation in the operation history of the AzureMachine status.
+func (m *MachineScope) RecordOperation(operation infrav1.Operation) {
+	operations.Record(m.AzureMachine, operation)
+}
+
 // UpdateDeleteStatus updates a condition on the AzureMachine status after a DELETE operation.
 func (m *MachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -1289,6 +1289,64 @@ func TestMachineScope_AvailabilitySet(t *testing.T) {
 			wantAvailabilitySetName:      "cluster_foo-machine-deployment-as",
 			wantAvailabilitySetExistence: true,
 		},
+		{
+			name: "returns empty and false if AvailabilitySet is enabled but machine is placed on a dedicated host",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineDeploymentLabelName: "foo-machine-deployment",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						HostID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg/hosts/my-host",
+					},
+				},
+			},
+			wantAvailabilitySetName:      "",
+			wantAvailabilitySetExistence: false,
+		},
+		{
+			name: "returns empty and false if AvailabilitySet is enabled but machine is allocated from a capacity reservation group",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "cluster",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						Status: infrav1.AzureClusterStatus{},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineDeploymentLabelName: "foo-machine-deployment",
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					Spec: infrav1.AzureMachineSpec{
+						CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+					},
+				},
+			},
+			wantAvailabilitySetName:      "",
+			wantAvailabilitySetExistence: false,
+		},
 		{
 			name: "returns empty and false if AvailabilitySet is enabled but worker machine is not part of machine deployment or machine set",
 			machineScope: MachineScope{
@@ -1767,6 +1825,116 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
+				},
+			},
+		},
+		{
+			name: "Dual-stack Node Machine with no NAT gateway and no public IP address",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+									VnetClassSpec: infrav1.VnetClassSpec{
+										CIDRBlocks: []string{"10.0.0.0/8", "2001:1234:5678:9a00::/56"},
+									},
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ProviderID: pointer.String("azure://compute/virtual-machines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabelName: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                        "machine-name-nic",
+					ResourceGroup:               "my-rg",
+					Location:                    "westus",
+					SubscriptionID:              "123",
+					MachineName:                 "machine-name",
+					SubnetName:                  "subnet1",
+					IPConfigs:                   []networkinterfaces.IPConfig{{}},
+					VNetName:                    "vnet1",
+					VNetResourceGroup:           "rg1",
+					PublicLBName:                "outbound-lb",
+					PublicLBAddressPoolName:     "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:         "",
+					PublicLBIPv6AddressPoolName: "outbound-lb-outboundBackendPool-ipv6",
+					InternalLBName:              "",
+					InternalLBAddressPoolName:   "",
+					PublicIPName:                "",
+					AcceleratedNetworking:       nil,
+					DNSServers:                  nil,
+					IPv6Enabled:                 true,
+					EnableIPForwarding:          false,
+					SKU:                         nil,
+					ClusterName:                 "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 			},
 		},
@@ -1878,6 +2046,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 			},
 		},
@@ -1987,6 +2156,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 			},
 		},
@@ -2092,6 +2262,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 			},
 		},
@@ -2202,6 +2373,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-controlplane-asg"},
 				},
 			},
 		},
@@ -2309,6 +2481,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-controlplane-asg"},
 				},
 			},
 		},
@@ -2417,6 +2590,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: infrav1.Tags{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-controlplane-asg"},
 				},
 			},
 		},
@@ -2529,6 +2703,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: map[string]string{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 				&networkinterfaces.NICSpec{
 					Name:                      "machine-name-nic-1",
@@ -2554,6 +2729,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: map[string]string{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 			},
 		},
@@ -2667,6 +2843,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: map[string]string{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 				&networkinterfaces.NICSpec{
 					Name:                      "machine-name-nic-1",
@@ -2692,6 +2869,7 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: map[string]string{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
 				},
 			},
 		},
@@ -2799,6 +2977,113 @@ func TestMachineScope_NICSpecs(t *testing.T) {
 					AdditionalTags: map[string]string{
 						"kubernetes.io_cluster_cluster": "owned",
 					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg"},
+				},
+			},
+		},
+		{
+			name: "Node Machine in custom application security groups",
+			machineScope: MachineScope{
+				ClusterScoper: &ClusterScope{
+					AzureClients: AzureClients{
+						EnvironmentSettings: auth.EnvironmentSettings{
+							Values: map[string]string{
+								auth.SubscriptionID: "123",
+							},
+						},
+					},
+					Cluster: &clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+						},
+					},
+					AzureCluster: &infrav1.AzureCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "cluster",
+							Namespace: "default",
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "cluster.x-k8s.io/v1beta1",
+									Kind:       "Cluster",
+									Name:       "cluster",
+								},
+							},
+						},
+						Spec: infrav1.AzureClusterSpec{
+							ResourceGroup: "my-rg",
+							AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+								Location: "westus",
+							},
+							NetworkSpec: infrav1.NetworkSpec{
+								Vnet: infrav1.VnetSpec{
+									Name:          "vnet1",
+									ResourceGroup: "rg1",
+								},
+								Subnets: []infrav1.SubnetSpec{
+									{
+										SubnetClassSpec: infrav1.SubnetClassSpec{
+											Role: infrav1.SubnetNode,
+											Name: "subnet1",
+										},
+									},
+								},
+								NodeOutboundLB: &infrav1.LoadBalancerSpec{
+									Name: "outbound-lb",
+								},
+							},
+						},
+					},
+				},
+				AzureMachine: &infrav1.AzureMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machine",
+					},
+					Spec: infrav1.AzureMachineSpec{
+						ApplicationSecurityGroups: []string{"asg-1", "asg-2"},
+						ProviderID:                pointer.String("azure://compute/virtual-machines/machine-name"),
+						NetworkInterfaces: []infrav1.NetworkInterface{{
+							SubnetName:       "subnet1",
+							PrivateIPConfigs: 1,
+						}},
+					},
+				},
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "machine",
+						Labels: map[string]string{
+							// clusterv1.MachineControlPlaneLabelName: "true",
+						},
+					},
+				},
+			},
+			want: []azure.ResourceSpecGetter{
+				&networkinterfaces.NICSpec{
+					Name:                      "machine-name-nic",
+					ResourceGroup:             "my-rg",
+					Location:                  "westus",
+					SubscriptionID:            "123",
+					MachineName:               "machine-name",
+					SubnetName:                "subnet1",
+					IPConfigs:                 []networkinterfaces.IPConfig{{}},
+					VNetName:                  "vnet1",
+					VNetResourceGroup:         "rg1",
+					PublicLBName:              "outbound-lb",
+					PublicLBAddressPoolName:   "outbound-lb-outboundBackendPool",
+					PublicLBNATRuleName:       "",
+					InternalLBName:            "",
+					InternalLBAddressPoolName: "",
+					PublicIPName:              "",
+					AcceleratedNetworking:     nil,
+					DNSServers:                nil,
+					IPv6Enabled:               false,
+					EnableIPForwarding:        false,
+					SKU:                       nil,
+					ClusterName:               "cluster",
+					AdditionalTags: infrav1.Tags{
+						"kubernetes.io_cluster_cluster": "owned",
+					},
+					ApplicationSecurityGroups: []string{"cluster-node-asg", "asg-1", "asg-2"},
 				},
 			},
 		},
@@ -2984,3 +3269,171 @@ func TestDiskSpecs(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineScope_DataDisksSpec(t *testing.T) {
+	newMachineScope := func(updateStrategy infrav1.AzureMachineUpdateStrategy) MachineScope {
+		return MachineScope{
+			ClusterScoper: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+					},
+				},
+			},
+			AzureMachine: &infrav1.AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-azure-machine",
+				},
+				Spec: infrav1.AzureMachineSpec{
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix: "etcddisk",
+						},
+					},
+					UpdateStrategy: updateStrategy,
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name         string
+		machineScope MachineScope
+		want         azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nil if the machine is not updated in place",
+			machineScope: newMachineScope(""),
+			want:         nil,
+		},
+		{
+			name:         "returns the data disks if the machine is updated in place",
+			machineScope: newMachineScope(infrav1.AzureMachineUpdateStrategyInPlace),
+			want: &disks.DataDisksSpec{
+				VMName:        "my-azure-machine",
+				ResourceGroup: "my-rg",
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix: "etcddisk",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testcases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			result := tt.machineScope.DataDisksSpec()
+			if tt.want == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tt.want))
+			}
+		})
+	}
+}
+
+func TestMachineScope_RemovedDataDiskSpecs(t *testing.T) {
+	newMachineScope := func(lastApplied string) MachineScope {
+		return MachineScope{
+			ClusterScoper: &ClusterScope{
+				AzureCluster: &infrav1.AzureCluster{
+					Spec: infrav1.AzureClusterSpec{
+						ResourceGroup: "my-rg",
+					},
+				},
+			},
+			AzureMachine: &infrav1.AzureMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-azure-machine",
+					Annotations: map[string]string{
+						infrav1.DataDisksLastAppliedAnnotation: lastApplied,
+					},
+				},
+				Spec: infrav1.AzureMachineSpec{
+					DataDisks: []infrav1.DataDisk{
+						{
+							NameSuffix: "etcddisk",
+						},
+					},
+					UpdateStrategy: infrav1.AzureMachineUpdateStrategyInPlace,
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name         string
+		machineScope MachineScope
+		want         []azure.ResourceSpecGetter
+	}{
+		{
+			name:         "returns nothing if the data disks were never applied",
+			machineScope: newMachineScope(""),
+			want:         nil,
+		},
+		{
+			name:         "returns nothing if no data disk was removed",
+			machineScope: newMachineScope(`{"etcddisk":"my-azure-machine_etcddisk"}`),
+			want:         nil,
+		},
+		{
+			name:         "returns the removed data disks",
+			machineScope: newMachineScope(`{"etcddisk":"my-azure-machine_etcddisk","otherdisk":"my-azure-machine_otherdisk","olddisk":"my-azure-machine_olddisk"}`),
+			want: []azure.ResourceSpecGetter{
+				&disks.DiskSpec{
+					Name:          "my-azure-machine_olddisk",
+					ResourceGroup: "my-rg",
+				},
+				&disks.DiskSpec{
+					Name:          "my-azure-machine_otherdisk",
+					ResourceGroup: "my-rg",
+				},
+			},
+		},
+		{
+			name:         "ignores an invalid annotation",
+			machineScope: newMachineScope(`["otherdisk"]`),
+			want:         nil,
+		},
+	}
+
+	for _, tt := range testcases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			g.Expect(tt.machineScope.RemovedDataDiskSpecs()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMachineScope_UpdateDataDisksLastApplied(t *testing.T) {
+	g := NewWithT(t)
+
+	machineScope := MachineScope{
+		AzureMachine: &infrav1.AzureMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-azure-machine",
+				Annotations: map[string]string{
+					infrav1.DataDisksLastAppliedAnnotation: `{"etcddisk":"my-azure-machine_etcddisk","olddisk":"my-azure-machine_olddisk"}`,
+				},
+			},
+			Spec: infrav1.AzureMachineSpec{
+				DataDisks: []infrav1.DataDisk{
+					{
+						NameSuffix: "etcddisk",
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(machineScope.UpdateDataDisksLastApplied()).To(Succeed())
+	g.Expect(machineScope.AzureMachine.Annotations).To(HaveKeyWithValue(infrav1.DataDisksLastAppliedAnnotation, `{"etcddisk":"my-azure-machine_etcddisk"}`))
+}
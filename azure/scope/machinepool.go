@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualmachineimages"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
@@ -129,6 +130,8 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		VNetResourceGroup:            m.Vnet().ResourceGroup,
 		PublicLBName:                 m.OutboundLBName(infrav1.Node),
 		PublicLBAddressPoolName:      azure.GenerateOutboundBackendAddressPoolName(m.OutboundLBName(infrav1.Node)),
+		PublicLBIPv6AddressPoolName:  outboundIPv6PoolName(m.ClusterScoper, m.OutboundLBName(infrav1.Node)),
+		IPv6Enabled:                  m.IsIPv6Enabled(),
 		AcceleratedNetworking:        m.AzureMachinePool.Spec.Template.NetworkInterfaces[0].AcceleratedNetworking,
 		Identity:                     m.AzureMachinePool.Spec.Identity,
 		UserAssignedIdentities:       m.AzureMachinePool.Spec.UserAssignedIdentities,
@@ -139,9 +142,24 @@ func (m *MachinePoolScope) ScaleSetSpec() azure.ScaleSetSpec {
 		TerminateNotificationTimeout: m.AzureMachinePool.Spec.Template.TerminateNotificationTimeout,
 		NetworkInterfaces:            m.AzureMachinePool.Spec.Template.NetworkInterfaces,
 		OrchestrationMode:            m.AzureMachinePool.Spec.OrchestrationMode,
+		HostGroupID:                  m.AzureMachinePool.Spec.HostGroupID,
+		HostGroupZone:                m.dedicatedHostGroupZone(),
+		ProximityPlacementGroupID:    m.AzureMachinePool.Spec.ProximityPlacementGroupID,
+		CapacityReservationGroupID:   m.AzureMachinePool.Spec.CapacityReservationGroupID,
 	}
 }
 
+// dedicatedHostGroupZone returns the availability zone of the dedicated host group the scale set is placed in, when
+// the group is managed by the cluster.
+func (m *MachinePoolScope) dedicatedHostGroupZone() string {
+	if m.AzureMachinePool.Spec.HostGroupID == "" {
+		return ""
+	}
+
+	zone, _ := m.DedicatedHostGroupZone(m.AzureMachinePool.Spec.HostGroupID)
+	return zone
+}
+
 // Name returns the Azure Machine Pool Name.
 func (m *MachinePoolScope) Name() string {
 	// Windows Machine pools names cannot be longer than 9 chars
@@ -425,6 +443,11 @@ func (m *MachinePoolScope) DeleteLongRunningOperationState(name, service, future
 	futures.Delete(m.AzureMachinePool, name, service, futureType)
 }
 
+// RecordOperation records an Azure operation in the operation history of the AzureMachinePool status.
+func (m *MachinePoolScope) RecordOperation(operation infrav1.Operation) {
+	operations.Record(m.AzureMachinePool, operation)
+}
+
 // setProvisioningStateAndConditions sets the AzureMachinePool provisioning state and conditions.
 func (m *MachinePoolScope) setProvisioningStateAndConditions(v infrav1.ProvisioningState) {
 	m.AzureMachinePool.Status.ProvisioningState = &v
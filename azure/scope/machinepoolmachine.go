@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
@@ -179,6 +180,11 @@ func (s *MachinePoolMachineScope) DeleteLongRunningOperationState(name, service,
 	futures.Delete(s.AzureMachinePoolMachine, name, service, futureType)
 }
 
+// RecordOperation records an Azure operation in the operation history of the AzureMachinePoolMachine status.
+func (s *MachinePoolMachineScope) RecordOperation(operation infrav1.Operation) {
+	operations.Record(s.AzureMachinePoolMachine, operation)
+}
+
 // UpdateDeleteStatus updates a condition on the AzureMachinePoolMachine status after a DELETE operation.
 func (s *MachinePoolMachineScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -220,6 +226,11 @@ func (s *MachinePoolMachineScope) SetVMSSVM(instance *azure.VMSSVM) {
 	s.instance = instance
 }
 
+// Addresses returns the addresses of the AzureMachinePoolMachine recorded in its status.
+func (s *MachinePoolMachineScope) Addresses() []corev1.NodeAddress {
+	return s.AzureMachinePoolMachine.Status.Addresses
+}
+
 // ProvisioningState returns the AzureMachinePoolMachine provisioning state.
 func (s *MachinePoolMachineScope) ProvisioningState() infrav1.ProvisioningState {
 	if s.AzureMachinePoolMachine.Status.ProvisioningState != nil {
@@ -338,8 +349,8 @@ func (s *MachinePoolMachineScope) UpdateNodeStatus(ctx context.Context) error {
 	return nil
 }
 
-// UpdateInstanceStatus updates the provisioning state of the AzureMachinePoolMachine and if it has the latest model applied
-// using the VMSS VM instance.
+// UpdateInstanceStatus updates the provisioning state and the addresses of the AzureMachinePoolMachine and if it has the
+// latest model applied using the VMSS VM instance.
 // Note: This func should be called at the end of a reconcile request and after updating the scope with the most recent Azure data.
 func (s *MachinePoolMachineScope) UpdateInstanceStatus(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(
@@ -350,6 +361,10 @@ func (s *MachinePoolMachineScope) UpdateInstanceStatus(ctx context.Context) erro
 
 	if s.instance != nil {
 		s.AzureMachinePoolMachine.Status.ProvisioningState = &s.instance.State
+		s.AzureMachinePoolMachine.Status.Addresses = s.instance.Addresses
+		if s.IsIPv6Only() {
+			s.AzureMachinePoolMachine.Status.Addresses = ipv6OnlyAddresses(s.instance.Addresses)
+		}
 		hasLatestModel, err := s.hasLatestModelApplied(ctx)
 		if err != nil {
 			return errors.Wrap(err, "failed to determine if the VMSS instance has the latest model")
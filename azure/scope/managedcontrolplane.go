@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -113,6 +114,8 @@ type ManagedControlPlaneScope struct {
 	patchHelper    *patch.Helper
 	kubeConfigData []byte
 	cache          *ManagedControlPlaneCache
+	// plan holds the operations planned during this reconcile in dry-run mode.
+	plan azure.Plan
 
 	AzureClients
 	Cluster             *clusterv1.Cluster
@@ -223,6 +226,12 @@ func (s *ManagedControlPlaneScope) Vnet() *infrav1.VnetSpec {
 	}
 }
 
+// UpdateVnet updates the ID, tags and CIDR blocks of the cluster Vnet.
+// This is not used when using a managed control plane.
+func (s *ManagedControlPlaneScope) UpdateVnet(_ string, _ infrav1.Tags, _ []string) {
+	// no-op
+}
+
 // GroupSpec returns the resource group spec.
 func (s *ManagedControlPlaneScope) GroupSpec() azure.ResourceSpecGetter {
 	return &groups.GroupSpec{
@@ -350,6 +359,12 @@ func (s *ManagedControlPlaneScope) IsIPv6Enabled() bool {
 	return false
 }
 
+// IsIPv6Only returns true if a cluster uses the IPv6-only network mode.
+// Currently always false as managed control planes do not currently implement ipv6.
+func (s *ManagedControlPlaneScope) IsIPv6Only() bool {
+	return false
+}
+
 // IsVnetManaged returns true if the vnet is managed.
 func (s *ManagedControlPlaneScope) IsVnetManaged() bool {
 	if s.cache.isVnetManaged != nil {
@@ -609,9 +624,16 @@ func (s *ManagedControlPlaneScope) DeleteLongRunningOperationState(name, service
 	futures.Delete(s.ControlPlane, name, service, futureType)
 }
 
+// RecordOperation records an Azure operation in the operation history of the AzureManagedControlPlane status.
+func (s *ManagedControlPlaneScope) RecordOperation(operation infrav1.Operation) {
+	operations.Record(s.ControlPlane, operation)
+}
+
 // UpdateDeleteStatus updates a condition on the AzureManagedControlPlane status after a DELETE operation.
 func (s *ManagedControlPlaneScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
+	case err == nil && s.DryRun():
+		// Nothing was changed in Azure, so the condition is left as is.
 	case err == nil:
 		conditions.MarkFalse(s.ControlPlane, condition, infrav1.DeletedReason, clusterv1.ConditionSeverityInfo, "%s successfully deleted", service)
 	case azure.IsOperationNotDoneError(err):
@@ -624,6 +646,8 @@ func (s *ManagedControlPlaneScope) UpdateDeleteStatus(condition clusterv1.Condit
 // UpdatePutStatus updates a condition on the AzureManagedControlPlane status after a PUT operation.
 func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
+	case err == nil && s.DryRun():
+		// Nothing was changed in Azure, so the condition is left as is.
 	case err == nil:
 		conditions.MarkTrue(s.ControlPlane, condition)
 	case azure.IsOperationNotDoneError(err):
@@ -636,6 +660,8 @@ func (s *ManagedControlPlaneScope) UpdatePutStatus(condition clusterv1.Condition
 // UpdatePatchStatus updates a condition on the AzureManagedControlPlane status after a PATCH operation.
 func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
+	case err == nil && s.DryRun():
+		// Nothing was changed in Azure, so the condition is left as is.
 	case err == nil:
 		conditions.MarkTrue(s.ControlPlane, condition)
 	case azure.IsOperationNotDoneError(err):
@@ -645,6 +671,21 @@ func (s *ManagedControlPlaneScope) UpdatePatchStatus(condition clusterv1.Conditi
 	}
 }
 
+// DryRun returns whether the AzureManagedControlPlane is reconciled in dry-run mode, i.e. whether its DryRunAnnotation is "true".
+func (s *ManagedControlPlaneScope) DryRun() bool {
+	return s.ControlPlane.GetAnnotations()[azure.DryRunAnnotation] == "true"
+}
+
+// PlanOperation records an Azure API call that would have been made to reconcile the AzureManagedControlPlane.
+func (s *ManagedControlPlaneScope) PlanOperation(operation azure.PlannedOperation) {
+	s.plan = append(s.plan, operation)
+}
+
+// Plan returns the operations planned during this reconcile in dry-run mode, in the order they were planned.
+func (s *ManagedControlPlaneScope) Plan() azure.Plan {
+	return append(azure.Plan{}, s.plan...)
+}
+
 // AnnotationJSON returns a map[string]interface from a JSON annotation.
 func (s *ManagedControlPlaneScope) AnnotationJSON(annotation string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/agentpools"
 	"sigs.k8s.io/cluster-api-provider-azure/util/futures"
 	"sigs.k8s.io/cluster-api-provider-azure/util/maps"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -259,6 +260,11 @@ func (s *ManagedMachinePoolScope) DeleteLongRunningOperationState(name, service,
 	futures.Delete(s.InfraMachinePool, name, service, futureType)
 }
 
+// RecordOperation records an Azure operation in the operation history of the AzureManagedMachinePool status.
+func (s *ManagedMachinePoolScope) RecordOperation(operation infrav1.Operation) {
+	operations.Record(s.InfraMachinePool, operation)
+}
+
 // UpdateDeleteStatus updates a condition on the AzureManagedControlPlane status after a DELETE operation.
 func (s *ManagedMachinePoolScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
 	switch {
@@ -0,0 +1,236 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// tokenCacheSize is the maximum number of credentials kept in the token cache.
+	tokenCacheSize = 1024
+	// tokenCacheTTL is how long a credential that is not used stays in the token cache.
+	tokenCacheTTL = 24 * time.Hour
+	// tokenRefreshTimeout is the timeout of a background token refresh.
+	tokenRefreshTimeout = time.Minute
+)
+
+var (
+	tokenCacheOnce sync.Once
+	tokenCache     ttllru.PeekingCacher
+	tokenCacheErr  error
+
+	tokenAcquisitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_token_acquisitions_total",
+			Help: "Total number of Azure AD tokens acquired for an identity.",
+		},
+		[]string{"identity"},
+	)
+	tokenAcquisitionFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_token_acquisition_failures_total",
+			Help: "Total number of failed Azure AD token acquisitions for an identity.",
+		},
+		[]string{"identity"},
+	)
+	tokenAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_azure_token_age_seconds",
+			Help: "Age in seconds of the cached Azure AD token last used for an identity.",
+		},
+		[]string{"identity"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(tokenAcquisitions, tokenAcquisitionFailures, tokenAge)
+}
+
+// cachedToken is an Azure AD token kept in a CachedTokenCredential.
+type cachedToken struct {
+	token      azcore.AccessToken
+	acquiredAt time.Time
+	refreshAt  time.Time
+	lastUsed   time.Time
+	refreshing bool
+}
+
+// CachedTokenCredential is an azcore.TokenCredential that caches the tokens of another credential and refreshes them
+// in the background before they expire, so that the scopes of all the clusters sharing an identity reuse its tokens.
+type CachedTokenCredential struct {
+	cred          azcore.TokenCredential
+	identity      string
+	refreshMargin time.Duration
+
+	// acquireMu serializes token requests to the underlying credential.
+	acquireMu sync.Mutex
+	mu        sync.Mutex
+	tokens    map[string]*cachedToken
+}
+
+var _ azcore.TokenCredential = (*CachedTokenCredential)(nil)
+
+// NewCachedTokenCredential creates a new CachedTokenCredential for the named identity, which refreshes tokens of the
+// given credential when they are within the refresh margin of their expiry.
+func NewCachedTokenCredential(cred azcore.TokenCredential, identity string, refreshMargin time.Duration) *CachedTokenCredential {
+	return &CachedTokenCredential{
+		cred:          cred,
+		identity:      identity,
+		refreshMargin: refreshMargin,
+		tokens:        map[string]*cachedToken{},
+	}
+}
+
+// getCachedTokenCredential returns the shared credential for the given key, or caches the given credential if there
+// is none.
+func getCachedTokenCredential(key, identity string, cred azcore.TokenCredential) (azcore.TokenCredential, error) {
+	tokenCacheOnce.Do(func() {
+		tokenCache, tokenCacheErr = ttllru.New(tokenCacheSize, tokenCacheTTL)
+	})
+	if tokenCacheErr != nil {
+		return nil, errors.Wrap(tokenCacheErr, "failed creating LRU cache for Azure AD tokens")
+	}
+
+	if c, ok := tokenCache.Get(key); ok {
+		return c.(*CachedTokenCredential), nil
+	}
+
+	c := NewCachedTokenCredential(cred, identity, tokenRefreshMargin)
+	_ = tokenCache.Add(key, c)
+	return c, nil
+}
+
+// tokenCacheKey returns a base64 url encoded sha256 hash of the given parts, used as the key of a credential in the
+// token cache.
+func tokenCacheKey(parts ...string) string {
+	hasher := sha256.New()
+	_, _ = hasher.Write([]byte(strings.Join(parts, "\x00")))
+	return base64.URLEncoding.EncodeToString(hasher.Sum(nil))
+}
+
+// GetToken returns a cached token for the given scopes, or requests a new one if there is no valid cached token.
+// A cached token within the refresh margin of its expiry is returned while a new one is requested in the background.
+func (c *CachedTokenCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	key := strings.Join(opts.Scopes, " ")
+
+	c.mu.Lock()
+	if t, ok := c.tokens[key]; ok && time.Now().Before(t.token.ExpiresOn) {
+		now := time.Now()
+		t.lastUsed = now
+		tokenAge.WithLabelValues(c.identity).Set(now.Sub(t.acquiredAt).Seconds())
+		if !now.Before(t.refreshAt) && !t.refreshing {
+			t.refreshing = true
+			go c.refresh(opts)
+		}
+		token := t.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	c.mu.Unlock()
+
+	return c.acquire(ctx, opts, false)
+}
+
+// acquire requests a token from the underlying credential and caches it. Unless force is set, a valid token cached
+// by a concurrent request is returned instead.
+func (c *CachedTokenCredential) acquire(ctx context.Context, opts policy.TokenRequestOptions, force bool) (azcore.AccessToken, error) {
+	key := strings.Join(opts.Scopes, " ")
+
+	c.acquireMu.Lock()
+	defer c.acquireMu.Unlock()
+
+	if !force {
+		c.mu.Lock()
+		if t, ok := c.tokens[key]; ok && time.Now().Before(t.token.ExpiresOn) {
+			t.lastUsed = time.Now()
+			token := t.token
+			c.mu.Unlock()
+			return token, nil
+		}
+		c.mu.Unlock()
+	}
+
+	token, err := c.cred.GetToken(ctx, opts)
+	if err != nil {
+		tokenAcquisitionFailures.WithLabelValues(c.identity).Inc()
+		return azcore.AccessToken{}, err
+	}
+	tokenAcquisitions.WithLabelValues(c.identity).Inc()
+	tokenAge.WithLabelValues(c.identity).Set(0)
+
+	now := time.Now()
+	// Tokens living less than twice the refresh margin are refreshed halfway through their lifetime instead.
+	lifetime := token.ExpiresOn.Sub(now)
+	refreshIn := lifetime - c.refreshMargin
+	if refreshIn < lifetime/2 {
+		refreshIn = lifetime / 2
+	}
+	t := &cachedToken{
+		token:      token,
+		acquiredAt: now,
+		refreshAt:  now.Add(refreshIn),
+		lastUsed:   now,
+	}
+
+	c.mu.Lock()
+	c.tokens[key] = t
+	c.mu.Unlock()
+
+	if refreshIn > 0 {
+		time.AfterFunc(refreshIn, func() {
+			c.mu.Lock()
+			// Only tokens still in use are refreshed, so that credentials evicted from the cache stop refreshing.
+			refresh := c.tokens[key] == t && t.lastUsed.After(t.acquiredAt) && !t.refreshing
+			if refresh {
+				t.refreshing = true
+			}
+			c.mu.Unlock()
+			if refresh {
+				c.refresh(opts)
+			}
+		})
+	}
+
+	return token, nil
+}
+
+// refresh requests a new token in the background. On failure, the cached token is kept until it expires.
+func (c *CachedTokenCredential) refresh(opts policy.TokenRequestOptions) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenRefreshTimeout)
+	defer cancel()
+
+	if _, err := c.acquire(ctx, opts, true); err != nil {
+		c.mu.Lock()
+		if t, ok := c.tokens[strings.Join(opts.Scopes, " ")]; ok {
+			t.refreshing = false
+		}
+		c.mu.Unlock()
+	}
+}
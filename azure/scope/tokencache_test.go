@@ -0,0 +1,265 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/util/cache/ttllru"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testTokenOptions = policy.TokenRequestOptions{Scopes: []string{"https://management.azure.com/.default"}}
+
+// fakeTokenCredential returns tokens with the given lifetime, or the given error.
+type fakeTokenCredential struct {
+	mu       sync.Mutex
+	lifetime time.Duration
+	err      error
+	calls    int
+}
+
+func (f *fakeTokenCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return azcore.AccessToken{}, f.err
+	}
+	return azcore.AccessToken{Token: fmt.Sprintf("token-%d", f.calls), ExpiresOn: time.Now().Add(f.lifetime)}, nil
+}
+
+func (f *fakeTokenCredential) getCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestCachedTokenCredential(t *testing.T) {
+	tests := []struct {
+		name           string
+		lifetime       time.Duration
+		refreshMargin  time.Duration
+		expectedTokens []string
+		expectedCalls  int
+	}{
+		{
+			name:           "reuses a valid token",
+			lifetime:       time.Hour,
+			refreshMargin:  5 * time.Minute,
+			expectedTokens: []string{"token-1", "token-1", "token-1"},
+			expectedCalls:  1,
+		},
+		{
+			name:           "requests a new token when the cached one expired",
+			lifetime:       0,
+			refreshMargin:  5 * time.Minute,
+			expectedTokens: []string{"token-1", "token-2", "token-3"},
+			expectedCalls:  3,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fake := &fakeTokenCredential{lifetime: tc.lifetime}
+			identity := "default/" + tc.name
+			acquisitions := testutil.ToFloat64(tokenAcquisitions.WithLabelValues(identity))
+			cred := NewCachedTokenCredential(fake, identity, tc.refreshMargin)
+			for _, expected := range tc.expectedTokens {
+				token, err := cred.GetToken(context.TODO(), testTokenOptions)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(token.Token).To(Equal(expected))
+			}
+			g.Expect(fake.getCalls()).To(Equal(tc.expectedCalls))
+			g.Expect(testutil.ToFloat64(tokenAcquisitions.WithLabelValues(identity)) - acquisitions).To(BeEquivalentTo(tc.expectedCalls))
+		})
+	}
+}
+
+func TestCachedTokenCredentialRefresh(t *testing.T) {
+	t.Run("refreshes a token within the refresh margin in the background", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fake := &fakeTokenCredential{lifetime: 400 * time.Millisecond}
+		cred := NewCachedTokenCredential(fake, "default/refreshed-identity", 300*time.Millisecond)
+
+		token, err := cred.GetToken(context.TODO(), testTokenOptions)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(token.Token).To(Equal("token-1"))
+
+		// The token is refreshed halfway through its lifetime, since its lifetime is shorter than twice the margin.
+		time.Sleep(250 * time.Millisecond)
+		token, err = cred.GetToken(context.TODO(), testTokenOptions)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(token.Token).To(Equal("token-1"))
+		g.Eventually(fake.getCalls).Should(Equal(2))
+
+		token, err = cred.GetToken(context.TODO(), testTokenOptions)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(token.Token).To(Equal("token-2"))
+	})
+
+	t.Run("refreshes a token in use before it expires", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fake := &fakeTokenCredential{lifetime: 400 * time.Millisecond}
+		cred := NewCachedTokenCredential(fake, "default/used-identity", 300*time.Millisecond)
+
+		for i := 0; i < 2; i++ {
+			_, err := cred.GetToken(context.TODO(), testTokenOptions)
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		g.Eventually(fake.getCalls).Should(Equal(2))
+	})
+
+	t.Run("does not refresh a token that is not used", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fake := &fakeTokenCredential{lifetime: 400 * time.Millisecond}
+		cred := NewCachedTokenCredential(fake, "default/unused-identity", 300*time.Millisecond)
+
+		_, err := cred.GetToken(context.TODO(), testTokenOptions)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Consistently(fake.getCalls, 500*time.Millisecond).Should(Equal(1))
+	})
+}
+
+func TestCachedTokenCredentialFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	fake := &fakeTokenCredential{err: errors.New("AADSTS7000215: Invalid client secret provided")}
+	failures := testutil.ToFloat64(tokenAcquisitionFailures.WithLabelValues("default/failing-identity"))
+	cred := NewCachedTokenCredential(fake, "default/failing-identity", 5*time.Minute)
+
+	_, err := cred.GetToken(context.TODO(), testTokenOptions)
+	g.Expect(err).To(MatchError(ContainSubstring("AADSTS7000215")))
+	_, err = cred.GetToken(context.TODO(), testTokenOptions)
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(fake.getCalls()).To(Equal(2))
+	g.Expect(testutil.ToFloat64(tokenAcquisitionFailures.WithLabelValues("default/failing-identity")) - failures).To(BeEquivalentTo(2))
+}
+
+func TestGetCachedTokenCredential(t *testing.T) {
+	g := NewWithT(t)
+
+	// Start from an empty token cache.
+	tokenCacheOnce.Do(func() {})
+	var err error
+	tokenCache, err = ttllru.New(tokenCacheSize, tokenCacheTTL)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	first := &fakeTokenCredential{lifetime: time.Hour}
+	second := &fakeTokenCredential{lifetime: time.Hour}
+	key := tokenCacheKey(t.Name(), "1", "ManualServicePrincipal", "fake-tenant", "fake-client-id")
+
+	cred1, err := getCachedTokenCredential(key, "default/shared-identity", first)
+	g.Expect(err).NotTo(HaveOccurred())
+	cred2, err := getCachedTokenCredential(key, "default/shared-identity", second)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cred2).To(BeIdenticalTo(cred1))
+
+	other, err := getCachedTokenCredential(tokenCacheKey(t.Name(), "2", "ManualServicePrincipal", "fake-tenant", "fake-client-id"), "default/shared-identity", second)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(other).NotTo(BeIdenticalTo(cred1))
+
+	_, err = cred1.GetToken(context.TODO(), testTokenOptions)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = cred2.GetToken(context.TODO(), testTokenOptions)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(first.getCalls()).To(Equal(1))
+	g.Expect(second.getCalls()).To(Equal(0))
+}
+
+// fakeTokenCredentialProvider returns a new fakeTokenCredential every time it is called.
+type fakeTokenCredentialProvider struct {
+	creds []*fakeTokenCredential
+}
+
+func (f *fakeTokenCredentialProvider) GetTokenCredential(_ context.Context, _ *infrav1.AzureClusterIdentity, _ string) (azcore.TokenCredential, error) {
+	cred := &fakeTokenCredential{lifetime: time.Hour}
+	f.creds = append(f.creds, cred)
+	return cred, nil
+}
+
+func TestGetAuthorizerTokenCacheKey(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	// Start from an empty token cache.
+	tokenCacheOnce.Do(func() {})
+	var err error
+	tokenCache, err = ttllru.New(tokenCacheSize, tokenCacheTTL)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "default"},
+		Data:       map[string][]byte{azureSecretKey: []byte("fake-secret")},
+	}
+	identity := &infrav1.AzureClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-identity", Namespace: "default", UID: "fake-uid", ResourceVersion: "1"},
+		Spec: infrav1.AzureClusterIdentitySpec{
+			Type:         infrav1.WorkloadIdentity,
+			TenantID:     "fake-tenant",
+			ClientID:     "fake-client-id",
+			ClientSecret: corev1.SecretReference{Name: "my-secret", Namespace: "default"},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+	credProvider := &fakeTokenCredentialProvider{}
+	p := &AzureCredentialsProvider{Client: fakeClient, Identity: identity, WorkloadIdentityProvider: credProvider}
+
+	// authorize gets an authorizer for the identity and authorizes a request with it, returning the number of
+	// tokens requested from the credential created for the authorizer.
+	authorize := func() int {
+		authorizer, err := p.GetAuthorizer(ctx, "https://management.azure.com/", "https://login.microsoftonline.com/", "https://management.azure.com/", metav1.ObjectMeta{})
+		g.Expect(err).NotTo(HaveOccurred())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/subscriptions", http.NoBody)
+		g.Expect(err).NotTo(HaveOccurred())
+		_, err = autorest.Prepare(req, authorizer.WithAuthorization())
+		g.Expect(err).NotTo(HaveOccurred())
+		return credProvider.creds[len(credProvider.creds)-1].getCalls()
+	}
+
+	g.Expect(authorize()).To(Equal(1))
+
+	// Updates of the identity that don't change its credentials keep using the cached tokens.
+	identity.ResourceVersion = "2"
+	identity.Labels = map[string]string{"foo": "bar"}
+	g.Expect(authorize()).To(Equal(0))
+
+	// Rotating the secret of the identity invalidates the cached tokens.
+	secret.Data[azureSecretKey] = []byte("rotated-secret")
+	g.Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+	g.Expect(authorize()).To(Equal(1))
+}
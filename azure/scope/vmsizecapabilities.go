@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VMSizeCapabilitiesGetter gets the capabilities of the VM sizes of the location of a cluster from the resource SKUs cache.
+type VMSizeCapabilitiesGetter struct {
+	client   client.Client
+	getCache func(auth azure.Authorizer, location string) (*resourceskus.Cache, error)
+}
+
+var _ infrav1.VMSizeCapabilitiesGetter = &VMSizeCapabilitiesGetter{}
+
+// NewVMSizeCapabilitiesGetter creates a new VMSizeCapabilitiesGetter.
+func NewVMSizeCapabilitiesGetter(cli client.Client) *VMSizeCapabilitiesGetter {
+	return &VMSizeCapabilitiesGetter{
+		client:   cli,
+		getCache: resourceskus.GetCache,
+	}
+}
+
+// GetVMSizeCapabilities returns the capabilities of a VM size in the location of the AzureCluster of a cluster.
+func (g *VMSizeCapabilitiesGetter) GetVMSizeCapabilities(ctx context.Context, namespace, clusterName, vmSize string) (infrav1.VMSizeCapabilities, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scope.VMSizeCapabilitiesGetter.GetVMSizeCapabilities")
+	defer done()
+
+	sku, err := g.getSKU(ctx, namespace, clusterName, vmSize)
+	if err != nil {
+		return infrav1.VMSizeCapabilities{}, errors.Wrapf(err, "failed to get the capabilities of VM size %s", vmSize)
+	}
+
+	_, confidentialVM := sku.GetCapability(resourceskus.ConfidentialComputingType)
+	return infrav1.VMSizeCapabilities{
+		TrustedLaunch:  !sku.HasCapability(resourceskus.TrustedLaunchDisabled),
+		ConfidentialVM: confidentialVM,
+	}, nil
+}
+
+func (g *VMSizeCapabilitiesGetter) getSKU(ctx context.Context, namespace, clusterName, vmSize string) (resourceskus.SKU, error) {
+	cluster, err := util.GetClusterByName(ctx, g.client, namespace, clusterName)
+	if err != nil {
+		return resourceskus.SKU{}, err
+	}
+	if cluster.Spec.InfrastructureRef == nil || cluster.Spec.InfrastructureRef.Kind != "AzureCluster" {
+		return resourceskus.SKU{}, errors.Errorf("cluster %s is not backed by an AzureCluster", clusterName)
+	}
+
+	azureCluster := &infrav1.AzureCluster{}
+	key := client.ObjectKey{Namespace: namespace, Name: cluster.Spec.InfrastructureRef.Name}
+	if err := g.client.Get(ctx, key, azureCluster); err != nil {
+		return resourceskus.SKU{}, errors.Wrapf(err, "failed to get AzureCluster %s", key)
+	}
+
+	clusterScope, err := NewClusterScope(ctx, ClusterScopeParams{
+		Client:       g.client,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	if err != nil {
+		return resourceskus.SKU{}, errors.Wrap(err, "failed to create cluster scope")
+	}
+
+	cache, err := g.getCache(clusterScope, clusterScope.Location())
+	if err != nil {
+		return resourceskus.SKU{}, errors.Wrap(err, "failed to get resource SKUs cache")
+	}
+
+	return cache.Get(ctx, vmSize, resourceskus.VirtualMachines)
+}
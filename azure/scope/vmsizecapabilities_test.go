@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVMSizeCapabilitiesGetter_GetVMSizeCapabilities(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				Kind: "AzureCluster",
+				Name: "my-azure-cluster",
+			},
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-azure-cluster",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+				Location:       "test-location",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster).Build()
+
+	skus := []compute.ResourceSku{
+		{
+			Name:         pointer.String("Standard_D2s_v3"),
+			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
+			Locations:    &[]string{"test-location"},
+		},
+		{
+			Name:         pointer.String("Standard_A2_v2"),
+			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
+			Locations:    &[]string{"test-location"},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: pointer.String(resourceskus.TrustedLaunchDisabled), Value: pointer.String("True")},
+			},
+		},
+		{
+			Name:         pointer.String("Standard_DC2as_v5"),
+			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
+			Locations:    &[]string{"test-location"},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: pointer.String(resourceskus.ConfidentialComputingType), Value: pointer.String("SNP")},
+			},
+		},
+	}
+	getter := &VMSizeCapabilitiesGetter{
+		client: fakeClient,
+		getCache: func(_ azure.Authorizer, location string) (*resourceskus.Cache, error) {
+			return resourceskus.NewStaticCache(skus, location), nil
+		},
+	}
+
+	tests := []struct {
+		name        string
+		clusterName string
+		vmSize      string
+		want        infrav1.VMSizeCapabilities
+		wantErr     bool
+	}{
+		{
+			name:        "VM size supporting trusted launch",
+			clusterName: "my-cluster",
+			vmSize:      "Standard_D2s_v3",
+			want:        infrav1.VMSizeCapabilities{TrustedLaunch: true},
+		},
+		{
+			name:        "VM size not supporting trusted launch",
+			clusterName: "my-cluster",
+			vmSize:      "Standard_A2_v2",
+			want:        infrav1.VMSizeCapabilities{},
+		},
+		{
+			name:        "VM size supporting confidential VM",
+			clusterName: "my-cluster",
+			vmSize:      "Standard_DC2as_v5",
+			want:        infrav1.VMSizeCapabilities{TrustedLaunch: true, ConfidentialVM: true},
+		},
+		{
+			name:        "unknown VM size",
+			clusterName: "my-cluster",
+			vmSize:      "Standard_Unknown",
+			wantErr:     true,
+		},
+		{
+			name:        "unknown cluster",
+			clusterName: "other-cluster",
+			vmSize:      "Standard_D2s_v3",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := getter.GetVMSizeCapabilities(context.TODO(), "default", tc.clusterName, tc.vmSize)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(got).To(Equal(tc.want))
+			}
+		})
+	}
+}
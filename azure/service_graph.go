@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// ServiceGraph is a directed acyclic graph of ServiceReconcilers built from the dependencies they declare.
+// Services that implement ServiceDependencies run once all of their dependencies have completed, while services
+// that do not are assumed to depend on the service that precedes them in the list, which preserves the
+// sequential ordering they were written for.
+type ServiceGraph struct {
+	services []ServiceReconciler
+	// dependencies holds, for each service, the indexes of the services it depends on.
+	dependencies [][]int
+	// dependents holds, for each service, the indexes of the services that depend on it.
+	dependents     [][]int
+	maxConcurrency int
+}
+
+// NewServiceGraph builds the dependency graph of the given services. At most maxConcurrency services are run at the same time.
+// Dependencies on services that are not part of the list are ignored, so the same service can be used in graphs
+// that don't include all of its dependencies. An error is returned if the dependencies contain a cycle.
+func NewServiceGraph(services []ServiceReconciler, maxConcurrency int) (*ServiceGraph, error) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	g := &ServiceGraph{
+		services:       services,
+		dependencies:   make([][]int, len(services)),
+		dependents:     make([][]int, len(services)),
+		maxConcurrency: maxConcurrency,
+	}
+
+	var indexByName map[string]int
+	for i, service := range services {
+		describer, ok := service.(ServiceDependencies)
+		if !ok {
+			if i > 0 {
+				g.addEdge(i-1, i)
+			}
+			continue
+		}
+		if indexByName == nil {
+			indexByName = make(map[string]int, len(services))
+			for j, s := range services {
+				indexByName[s.Name()] = j
+			}
+		}
+		for _, name := range describer.Dependencies() {
+			if j, ok := indexByName[name]; ok {
+				g.addEdge(j, i)
+			}
+		}
+	}
+
+	if err := g.checkAcyclic(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *ServiceGraph) addEdge(from, to int) {
+	g.dependencies[to] = append(g.dependencies[to], from)
+	g.dependents[from] = append(g.dependents[from], to)
+}
+
+// checkAcyclic returns an error if the graph contains a dependency cycle.
+func (g *ServiceGraph) checkAcyclic() error {
+	pending := make([]int, len(g.services))
+	var ready []int
+	for i := range g.services {
+		pending[i] = len(g.dependencies[i])
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	visited := 0
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		visited++
+		for _, j := range g.dependents[i] {
+			pending[j]--
+			if pending[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+	if visited != len(g.services) {
+		return errors.New("service dependencies contain a cycle")
+	}
+	return nil
+}
+
+// Reconcile calls fn on every service once all the services it depends on have completed successfully.
+// Independent services are run in parallel. Once fn returns an error for a service, no new services are started
+// and the error of the first failed service, in list order, is returned after the running services complete.
+func (g *ServiceGraph) Reconcile(ctx context.Context, fn func(context.Context, ServiceReconciler) error) error {
+	return g.run(ctx, g.dependencies, g.dependents, fn)
+}
+
+// Delete calls fn on every service once all the services that depend on it have completed successfully,
+// i.e. in reverse topological order. It otherwise behaves like Reconcile.
+func (g *ServiceGraph) Delete(ctx context.Context, fn func(context.Context, ServiceReconciler) error) error {
+	return g.run(ctx, g.dependents, g.dependencies, fn)
+}
+
+func (g *ServiceGraph) run(ctx context.Context, waitFor, unblocks [][]int, fn func(context.Context, ServiceReconciler) error) error {
+	type result struct {
+		index int
+		err   error
+	}
+
+	pending := make([]int, len(g.services))
+	var ready []int
+	for i := range g.services {
+		pending[i] = len(waitFor[i])
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	results := make(chan result)
+	errs := make([]error, len(g.services))
+	failed := false
+	running := 0
+	for {
+		for !failed && len(ready) > 0 && running < g.maxConcurrency {
+			// Start services in list order so that a concurrency of one is deterministic.
+			sort.Ints(ready)
+			i := ready[0]
+			ready = ready[1:]
+			running++
+			go func(i int) {
+				results <- result{index: i, err: fn(ctx, g.services[i])}
+			}(i)
+		}
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		if r.err != nil {
+			errs[r.index] = r.err
+			failed = true
+			continue
+		}
+		for _, j := range unblocks[r.index] {
+			pending[j]--
+			if pending[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
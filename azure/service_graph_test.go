@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+type fakeService struct {
+	name string
+}
+
+func (f *fakeService) Name() string                                { return f.name }
+func (f *fakeService) IsManaged(ctx context.Context) (bool, error) { return true, nil }
+func (f *fakeService) Reconcile(ctx context.Context) error         { return nil }
+func (f *fakeService) Delete(ctx context.Context) error            { return nil }
+
+// fakeDependentService is a fakeService that declares its dependencies.
+type fakeDependentService struct {
+	fakeService
+	dependencies []string
+}
+
+func (f *fakeDependentService) Dependencies() []string { return f.dependencies }
+
+func newService(name string) ServiceReconciler {
+	return &fakeService{name: name}
+}
+
+func newDependentService(name string, deps ...string) ServiceReconciler {
+	return &fakeDependentService{fakeService: fakeService{name: name}, dependencies: deps}
+}
+
+// recorder records the order in which services run and the maximum number of services running at the same time.
+type recorder struct {
+	mu            sync.Mutex
+	order         []string
+	running       int
+	maxConcurrent int
+	fail          map[string]error
+}
+
+func (r *recorder) run(ctx context.Context, service ServiceReconciler) error {
+	r.mu.Lock()
+	r.running++
+	if r.running > r.maxConcurrent {
+		r.maxConcurrent = r.running
+	}
+	r.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running--
+	r.order = append(r.order, service.Name())
+	return r.fail[service.Name()]
+}
+
+func indexOf(order []string, name string) int {
+	for i, n := range order {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestServiceGraphReconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	services := []ServiceReconciler{
+		newService("group"),
+		newDependentService("vnet", "group"),
+		newDependentService("nsg", "group"),
+		newDependentService("routetable", "group"),
+		newDependentService("subnet", "vnet", "nsg", "routetable", "not-in-graph"),
+		newService("tags"),
+	}
+	graph, err := NewServiceGraph(services, 2)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &recorder{}
+	g.Expect(graph.Reconcile(context.Background(), r.run)).To(Succeed())
+	g.Expect(r.order).To(HaveLen(len(services)))
+	g.Expect(r.order[0]).To(Equal("group"))
+	g.Expect(r.order[4]).To(Equal("subnet"))
+	g.Expect(r.order[5]).To(Equal("tags"))
+	g.Expect(r.maxConcurrent).To(Equal(2))
+
+	r = &recorder{}
+	g.Expect(graph.Delete(context.Background(), r.run)).To(Succeed())
+	g.Expect(r.order[0]).To(Equal("tags"))
+	g.Expect(r.order[1]).To(Equal("subnet"))
+	g.Expect(r.order[5]).To(Equal("group"))
+}
+
+func TestServiceGraphSequentialWithoutDependencies(t *testing.T) {
+	g := NewWithT(t)
+
+	graph, err := NewServiceGraph([]ServiceReconciler{newService("one"), newService("two"), newService("three")}, 4)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &recorder{}
+	g.Expect(graph.Reconcile(context.Background(), r.run)).To(Succeed())
+	g.Expect(r.order).To(Equal([]string{"one", "two", "three"}))
+	g.Expect(r.maxConcurrent).To(Equal(1))
+
+	r = &recorder{}
+	g.Expect(graph.Delete(context.Background(), r.run)).To(Succeed())
+	g.Expect(r.order).To(Equal([]string{"three", "two", "one"}))
+}
+
+func TestServiceGraphStopsOnError(t *testing.T) {
+	g := NewWithT(t)
+
+	graph, err := NewServiceGraph([]ServiceReconciler{
+		newService("group"),
+		newDependentService("vnet", "group"),
+		newDependentService("nsg", "group"),
+		newDependentService("subnet", "vnet", "nsg"),
+	}, 4)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	r := &recorder{fail: map[string]error{"vnet": errors.New("vnet failed")}}
+	g.Expect(graph.Reconcile(context.Background(), r.run)).To(MatchError("vnet failed"))
+	g.Expect(indexOf(r.order, "nsg")).NotTo(Equal(-1))
+	g.Expect(indexOf(r.order, "subnet")).To(Equal(-1))
+}
+
+func TestServiceGraphCycle(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewServiceGraph([]ServiceReconciler{
+		newDependentService("one", "two"),
+		newDependentService("two", "one"),
+	}, 1)
+	g.Expect(err).To(MatchError("service dependencies contain a cycle"))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
@@ -94,7 +95,14 @@ func processOngoingOperation(ctx context.Context, scope FutureScope, client Futu
 
 	// Resource has been created/deleted/updated.
 	log.V(2).Info("long running operation has completed", "service", serviceName, "resource", resourceName)
-	return client.Result(ctx, sdkFuture, future.Type)
+	result, err = client.Result(ctx, sdkFuture, future.Type)
+	recordOperation(scope, infrav1.Operation{
+		Type:          futureType,
+		ResourceGroup: future.ResourceGroup,
+		ServiceName:   serviceName,
+		Name:          resourceName,
+	}, result, nil, err)
+	return result, err
 }
 
 // CreateOrUpdateResource implements the logic for creating a new, or updating an existing, resource Asynchronously.
@@ -122,6 +130,20 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 		log.V(2).Info("successfully got existing resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
 	}
 
+	// Report how the existing resource drifted from its desired state, if the scope asks for it.
+	if reporter, ok := s.Scope.(azure.DriftReporter); ok && existingResource != nil {
+		mode := reporter.DriftDetectionMode()
+		if mode != azure.DriftDetectionDisabled {
+			if err := detectDrift(ctx, reporter, spec, existingResource, serviceName); err != nil {
+				return nil, err
+			}
+		}
+		if mode == azure.DriftDetectionReportOnly {
+			log.V(2).Info("skipping update of existing resource in report-only drift detection mode", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+			return existingResource, nil
+		}
+	}
+
 	// Construct parameters using the resource spec and information from the existing resource, if there is one.
 	parameters, err := spec.Parameters(ctx, existingResource)
 	if err != nil {
@@ -132,13 +154,34 @@ func (s *Service) CreateOrUpdateResource(ctx context.Context, spec azure.Resourc
 		return existingResource, nil
 	}
 
+	// Record the operation in the plan instead of making it in dry-run mode.
+	if planner, ok := s.Scope.(azure.OperationPlanner); ok && planner.DryRun() {
+		planner.PlanOperation(azure.PlannedOperation{
+			ServiceName:   serviceName,
+			Method:        azure.PutOperation,
+			ResourceGroup: rgName,
+			ResourceName:  resourceName,
+			Parameters:    parameters,
+		})
+		log.V(2).Info("planned create or update of resource in dry-run mode", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		return existingResource, nil
+	}
+
 	// Create or update the resource with the desired parameters.
 	logMessageVerbPrefix := "creat"
 	if existingResource != nil {
 		logMessageVerbPrefix = "updat"
 	}
 	log.V(2).Info(fmt.Sprintf("%sing resource", logMessageVerbPrefix), "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	startTime := metav1.Now()
 	result, sdkFuture, err := s.Creator.CreateOrUpdateAsync(ctx, spec, parameters)
+	recordOperation(s.Scope, infrav1.Operation{
+		Type:          futureType,
+		ResourceGroup: rgName,
+		ServiceName:   serviceName,
+		Name:          resourceName,
+		StartTime:     &startTime,
+	}, result, sdkFuture, err)
 	errWrapped := errors.Wrapf(err, fmt.Sprintf("failed to %se resource %s/%s (service: %s)", logMessageVerbPrefix, rgName, resourceName, serviceName))
 	if sdkFuture != nil {
 		future, err := converters.SDKToFuture(sdkFuture, infrav1.PutFuture, serviceName, resourceName, rgName)
@@ -171,9 +214,31 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 		return err
 	}
 
+	// Record the operation in the plan instead of making it in dry-run mode.
+	if planner, ok := s.Scope.(azure.OperationPlanner); ok && planner.DryRun() {
+		planner.PlanOperation(azure.PlannedOperation{
+			ServiceName:   serviceName,
+			Method:        azure.DeleteOperation,
+			ResourceGroup: rgName,
+			ResourceName:  resourceName,
+		})
+		log.V(2).Info("planned delete of resource in dry-run mode", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+		return nil
+	}
+
 	// No long running operation is active, so delete the resource.
 	log.V(2).Info("deleting resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName)
+	startTime := metav1.Now()
 	sdkFuture, err := s.Deleter.DeleteAsync(ctx, spec)
+	if !azure.ResourceNotFound(err) {
+		recordOperation(s.Scope, infrav1.Operation{
+			Type:          futureType,
+			ResourceGroup: rgName,
+			ServiceName:   serviceName,
+			Name:          resourceName,
+			StartTime:     &startTime,
+		}, nil, sdkFuture, err)
+	}
 	if sdkFuture != nil {
 		future, err := converters.SDKToFuture(sdkFuture, infrav1.DeleteFuture, serviceName, resourceName, rgName)
 		if err != nil {
@@ -193,6 +258,61 @@ func (s *Service) DeleteResource(ctx context.Context, spec azure.ResourceSpecGet
 	return nil
 }
 
+// ExecuteOperation implements the logic for running a long-running operation on an existing resource that is neither a
+// create, an update nor a delete, such as the deallocation of a VM. The futureType identifies the operation so it can be
+// continued across reconcile loops. The operation func starts the operation and returns a future if the operation did
+// not complete before the client timed out waiting for it.
+func (s *Service) ExecuteOperation(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, method azure.OperationMethod, futureType string, operation func(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)) (err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.Service.ExecuteOperation")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+
+	// Check if there is an ongoing long running operation.
+	future := s.Scope.GetLongRunningOperationState(resourceName, serviceName, futureType)
+	if future != nil {
+		_, err := processOngoingOperation(ctx, s.Scope, s.Creator, resourceName, serviceName, futureType)
+		return err
+	}
+
+	// Record the operation in the plan instead of making it in dry-run mode.
+	if planner, ok := s.Scope.(azure.OperationPlanner); ok && planner.DryRun() {
+		planner.PlanOperation(azure.PlannedOperation{
+			ServiceName:   serviceName,
+			Method:        method,
+			ResourceGroup: rgName,
+			ResourceName:  resourceName,
+		})
+		log.V(2).Info("planned operation on resource in dry-run mode", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "operation", futureType)
+		return nil
+	}
+
+	log.V(2).Info("starting operation on resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "operation", futureType)
+	startTime := metav1.Now()
+	sdkFuture, err := operation(ctx, spec)
+	recordOperation(s.Scope, infrav1.Operation{
+		Type:          futureType,
+		ResourceGroup: rgName,
+		ServiceName:   serviceName,
+		Name:          resourceName,
+		StartTime:     &startTime,
+	}, nil, sdkFuture, err)
+	if sdkFuture != nil {
+		future, err := converters.SDKToFuture(sdkFuture, futureType, serviceName, resourceName, rgName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to run %s operation on resource %s/%s (service: %s)", futureType, rgName, resourceName, serviceName)
+		}
+		s.Scope.SetLongRunningOperationState(future)
+		return azure.WithTransientError(azure.NewOperationNotDoneError(future), getRequeueAfterFromFuture(sdkFuture))
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to run %s operation on resource %s/%s (service: %s)", futureType, rgName, resourceName, serviceName)
+	}
+
+	log.V(2).Info("successfully completed operation on resource", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "operation", futureType)
+	return nil
+}
+
 // getRequeueAfterFromFuture returns the max between the `RETRY-AFTER` header and the default requeue time.
 // This ensures we respect the retry-after header if it is set and avoid retrying too often during an API throttling event.
 func getRequeueAfterFromFuture(sdkFuture azureautorest.FutureAPI) time.Duration {
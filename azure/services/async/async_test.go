@@ -29,6 +29,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -50,6 +51,13 @@ var (
 		ResourceGroup: "test-group",
 		Data:          "eyJtZXRob2QiOiJERUxFVEUiLCJwb2xsaW5nTWV0aG9kIjoiTG9jYXRpb24iLCJscm9TdGF0ZSI6IkluUHJvZ3Jlc3MifQ==",
 	}
+	validDeallocateFuture = infrav1.Future{
+		Type:          infrav1.DeallocateFuture,
+		ServiceName:   "test-service",
+		Name:          "test-resource",
+		ResourceGroup: "test-group",
+		Data:          "eyJtZXRob2QiOiJQT1NUIiwicG9sbGluZ01ldGhvZCI6IkxvY2F0aW9uIiwibHJvU3RhdGUiOiJJblByb2dyZXNzIn0=",
+	}
 	invalidFuture = infrav1.Future{
 		Type:          infrav1.DeleteFuture,
 		ServiceName:   "test-service",
@@ -437,6 +445,99 @@ func TestDeleteResource(t *testing.T) {
 	}
 }
 
+func TestExecuteOperation(t *testing.T) {
+	testcases := []struct {
+		name            string
+		expectedError   string
+		operationFuture azureautorest.FutureAPI
+		operationErr    error
+		expect          func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:          "operation is already in progress",
+			expectedError: "operation type DEALLOCATE on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.DeallocateFuture).Times(2).Return(&validDeallocateFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(false, nil)
+			},
+		},
+		{
+			name:          "operation in progress is done",
+			expectedError: "",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.DeallocateFuture).Times(2).Return(&validDeallocateFuture)
+				c.IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(true, nil)
+				s.DeleteLongRunningOperationState("test-resource", "test-service", infrav1.DeallocateFuture)
+				c.Result(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{}), infrav1.DeallocateFuture).Return(nil, nil)
+			},
+		},
+		{
+			name:          "operation completes",
+			expectedError: "",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.DeallocateFuture).Return(nil)
+			},
+		},
+		{
+			name:          "error occurs while running the operation",
+			expectedError: "failed to run DEALLOCATE operation on resource test-group/test-resource (service: test-service)",
+			operationErr:  fakeInternalError,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.DeallocateFuture).Return(nil)
+			},
+		},
+		{
+			name:            "operation exits before completing",
+			expectedError:   "operation type DEALLOCATE on Azure resource test-group/test-resource is not done. Object will be requeued after 15s",
+			operationFuture: &azureautorest.Future{},
+			operationErr:    errCtxExceeded,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				r.ResourceName().Return("test-resource")
+				r.ResourceGroupName().Return("test-group")
+				s.GetLongRunningOperationState("test-resource", "test-service", infrav1.DeallocateFuture).Return(nil)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), creatorMock.EXPECT(), specMock.EXPECT())
+
+			operation := func(ctx context.Context, spec azure.ResourceSpecGetter) (azureautorest.FutureAPI, error) {
+				return tc.operationFuture, tc.operationErr
+			}
+
+			s := New(scopeMock, creatorMock, nil)
+			err := s.ExecuteOperation(context.TODO(), specMock, "test-service", azure.PostOperation, infrav1.DeallocateFuture, operation)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestGetRetryAfterFromError(t *testing.T) {
 	cases := []struct {
 		name                   string
@@ -0,0 +1,259 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// maxConcurrentOperations is the maximum number of resources of a batch that are created, updated or deleted at the same time.
+const maxConcurrentOperations = 10
+
+// CreateOrUpdateResources creates or updates the resources described by specs concurrently, independently of the result of the others.
+// The returned results are in the same order as specs, with a nil result for every resource that could not be created or updated yet.
+// The errors are aggregated into a single error, see AggregateErrors. Unless condition is empty, the condition is then
+// updated once with the aggregated error.
+func (s *Service) CreateOrUpdateResources(ctx context.Context, specs []azure.ResourceSpecGetter, serviceName string, condition clusterv1.ConditionType) (results []interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "async.Service.CreateOrUpdateResources")
+	defer done()
+
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	results = make([]interface{}, len(specs))
+	errs := make([]error, len(specs))
+	svc := s.withLockedScope()
+	forEachConcurrently(specs, s.maxConcurrentOperations(), func(i int) {
+		results[i], errs[i] = svc.CreateOrUpdateResource(ctx, specs[i], serviceName)
+	})
+
+	err = AggregateErrors(errs)
+	if condition != "" {
+		s.Scope.UpdatePutStatus(condition, serviceName, err)
+	}
+	return results, err
+}
+
+// DeleteResources deletes the resources described by specs concurrently, independently of the result of the others.
+// The errors are aggregated into a single error, see AggregateErrors. Unless condition is empty, the condition is then
+// updated once with the aggregated error.
+func (s *Service) DeleteResources(ctx context.Context, specs []azure.ResourceSpecGetter, serviceName string, condition clusterv1.ConditionType) (err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "async.Service.DeleteResources")
+	defer done()
+
+	if len(specs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(specs))
+	svc := s.withLockedScope()
+	forEachConcurrently(specs, s.maxConcurrentOperations(), func(i int) {
+		errs[i] = svc.DeleteResource(ctx, specs[i], serviceName)
+	})
+
+	err = AggregateErrors(errs)
+	if condition != "" {
+		s.Scope.UpdateDeleteStatus(condition, serviceName, err)
+	}
+	return err
+}
+
+// withLockedScope returns a copy of the service whose scope can safely be used by concurrent operations.
+func (s *Service) withLockedScope() *Service {
+	return &Service{
+		Scope:   &lockedScope{scope: s.Scope},
+		Creator: s.Creator,
+		Deleter: s.Deleter,
+	}
+}
+
+// maxConcurrentOperations returns the maximum number of resources of a batch that are processed at the same time.
+// Resources are processed one at a time in dry-run mode so that the planned operations are recorded in a stable order.
+func (s *Service) maxConcurrentOperations() int {
+	if planner, ok := s.Scope.(azure.OperationPlanner); ok && planner.DryRun() {
+		return 1
+	}
+	return maxConcurrentOperations
+}
+
+// forEachConcurrently calls fn with the index of every spec, running at most maxConcurrency calls at the same time.
+// Specs of subresources that share the same owner resource, e.g. the subnets of a virtual network, are processed one
+// after the other since Azure rejects concurrent operations on the same parent resource.
+func forEachConcurrently(specs []azure.ResourceSpecGetter, maxConcurrency int, fn func(i int)) {
+	var order []string
+	groups := make(map[string][]int)
+	for i, spec := range specs {
+		key := strconv.Itoa(i)
+		if owner := spec.OwnerResourceName(); owner != "" {
+			key = spec.ResourceGroupName() + "/" + owner
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrency)
+	for _, key := range order {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(indexes []int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			for _, i := range indexes {
+				fn(i)
+			}
+		}(groups[key])
+	}
+	wg.Wait()
+}
+
+// AggregateErrors combines the errors of a batch into a single error.
+// Errors other than OperationNotDoneErrors take precedence since they need attention: a single one is returned as is
+// and several are aggregated. Otherwise the first OperationNotDoneError is returned, as it only means the
+// batch needs to be requeued.
+func AggregateErrors(errs []error) error {
+	var failures []error
+	var notDone error
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case azure.IsOperationNotDoneError(err):
+			if notDone == nil {
+				notDone = err
+			}
+		default:
+			failures = append(failures, err)
+		}
+	}
+
+	switch len(failures) {
+	case 0:
+		return notDone
+	case 1:
+		return failures[0]
+	default:
+		return kerrors.NewAggregate(failures)
+	}
+}
+
+// lockedScope is a FutureScope that serializes access to the wrapped scope.
+// It is also an azure.DriftReporter, which detects no drift unless the wrapped scope is one, an
+// azure.OperationPlanner, which never runs in dry-run mode unless the wrapped scope is one, and an
+// azure.OperationRecorder, which records nothing unless the wrapped scope is one.
+type lockedScope struct {
+	mu    sync.Mutex
+	scope FutureScope
+}
+
+func (l *lockedScope) SetLongRunningOperationState(future *infrav1.Future) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scope.SetLongRunningOperationState(future)
+}
+
+func (l *lockedScope) GetLongRunningOperationState(name, service, futureType string) *infrav1.Future {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.scope.GetLongRunningOperationState(name, service, futureType)
+}
+
+func (l *lockedScope) DeleteLongRunningOperationState(name, service, futureType string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scope.DeleteLongRunningOperationState(name, service, futureType)
+}
+
+func (l *lockedScope) UpdatePutStatus(condition clusterv1.ConditionType, service string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scope.UpdatePutStatus(condition, service, err)
+}
+
+func (l *lockedScope) UpdateDeleteStatus(condition clusterv1.ConditionType, service string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scope.UpdateDeleteStatus(condition, service, err)
+}
+
+func (l *lockedScope) UpdatePatchStatus(condition clusterv1.ConditionType, service string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scope.UpdatePatchStatus(condition, service, err)
+}
+
+func (l *lockedScope) DriftDetectionMode() azure.DriftDetectionMode {
+	reporter, ok := l.scope.(azure.DriftReporter)
+	if !ok {
+		return azure.DriftDetectionDisabled
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return reporter.DriftDetectionMode()
+}
+
+func (l *lockedScope) ReportDrift(drift azure.ResourceDrift) {
+	reporter, ok := l.scope.(azure.DriftReporter)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	reporter.ReportDrift(drift)
+}
+
+func (l *lockedScope) DryRun() bool {
+	planner, ok := l.scope.(azure.OperationPlanner)
+	if !ok {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return planner.DryRun()
+}
+
+func (l *lockedScope) PlanOperation(operation azure.PlannedOperation) {
+	planner, ok := l.scope.(azure.OperationPlanner)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	planner.PlanOperation(operation)
+}
+
+func (l *lockedScope) RecordOperation(operation infrav1.Operation) {
+	recorder, ok := l.scope.(azure.OperationRecorder)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	recorder.RecordOperation(operation)
+}
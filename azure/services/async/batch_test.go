@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// fakeSpec is a ResourceSpecGetter that can safely be used concurrently.
+type fakeSpec struct {
+	name  string
+	owner string
+}
+
+func (s *fakeSpec) ResourceName() string      { return s.name }
+func (s *fakeSpec) OwnerResourceName() string { return s.owner }
+func (s *fakeSpec) ResourceGroupName() string { return "test-group" }
+func (s *fakeSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
+	return &fakeResourceParameters, nil
+}
+
+var (
+	fakeSpec1 = &fakeSpec{name: "test-resource-1"}
+	fakeSpec2 = &fakeSpec{name: "test-resource-2"}
+	fakeSpec3 = &fakeSpec{name: "test-resource-3"}
+)
+
+// TestCreateOrUpdateResources tests the CreateOrUpdateResources function.
+func TestCreateOrUpdateResources(t *testing.T) {
+	testcases := []struct {
+		name            string
+		condition       clusterv1.ConditionType
+		expectedError   string
+		expectedResults []interface{}
+		expect          func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder)
+	}{
+		{
+			name:            "all resources are created",
+			condition:       infrav1.PublicIPsReadyCondition,
+			expectedResults: []interface{}{"test-resource-1", "test-resource-2"},
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder) {
+				s.GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.PutFuture).Times(2).Return(nil)
+				c.Get(gomockinternal.AContext(), fakeSpec1).Return(nil, fakeNotFoundError)
+				c.Get(gomockinternal.AContext(), fakeSpec2).Return(nil, fakeNotFoundError)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec1, &fakeResourceParameters).Return("test-resource-1", nil, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec2, &fakeResourceParameters).Return("test-resource-2", nil, nil)
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, "test-service", nil)
+			},
+		},
+		{
+			name:            "error takes precedence over an operation that is not done",
+			condition:       infrav1.PublicIPsReadyCondition,
+			expectedError:   "failed to create resource test-group/test-resource-2 (service: test-service)",
+			expectedResults: []interface{}{nil, nil},
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder) {
+				s.GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.PutFuture).Times(2).Return(nil)
+				c.Get(gomockinternal.AContext(), fakeSpec1).Return(nil, fakeNotFoundError)
+				c.Get(gomockinternal.AContext(), fakeSpec2).Return(nil, fakeNotFoundError)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec1, &fakeResourceParameters).Return(nil, &azureautorest.Future{}, errCtxExceeded)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec2, &fakeResourceParameters).Return(nil, nil, fakeInternalError)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, "test-service", gomockinternal.ErrStrEq("failed to create resource test-group/test-resource-2 (service: test-service): #: Internal Server Error: StatusCode=500"))
+			},
+		},
+		{
+			name:            "operation that is not done is returned when there is no other error",
+			condition:       infrav1.PublicIPsReadyCondition,
+			expectedError:   "operation type PUT on Azure resource test-group/test-resource-1 is not done",
+			expectedResults: []interface{}{nil, "test-resource-2"},
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder) {
+				s.GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.PutFuture).Times(2).Return(nil)
+				c.Get(gomockinternal.AContext(), fakeSpec1).Return(nil, fakeNotFoundError)
+				c.Get(gomockinternal.AContext(), fakeSpec2).Return(nil, fakeNotFoundError)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec1, &fakeResourceParameters).Return(nil, &azureautorest.Future{}, errCtxExceeded)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec2, &fakeResourceParameters).Return("test-resource-2", nil, nil)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+				s.UpdatePutStatus(infrav1.PublicIPsReadyCondition, "test-service", gomock.Any())
+			},
+		},
+		{
+			name:            "condition is not updated when it is empty",
+			expectedError:   "failed to create resource test-group/test-resource-1 (service: test-service)",
+			expectedResults: []interface{}{nil, "test-resource-2"},
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, c *mock_async.MockCreatorMockRecorder) {
+				s.GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.PutFuture).Times(2).Return(nil)
+				c.Get(gomockinternal.AContext(), fakeSpec1).Return(nil, fakeNotFoundError)
+				c.Get(gomockinternal.AContext(), fakeSpec2).Return(nil, fakeNotFoundError)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec1, &fakeResourceParameters).Return(nil, nil, fakeInternalError)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), fakeSpec2, &fakeResourceParameters).Return("test-resource-2", nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), creatorMock.EXPECT())
+
+			s := New(scopeMock, creatorMock, nil)
+			results, err := s.CreateOrUpdateResources(context.TODO(), []azure.ResourceSpecGetter{fakeSpec1, fakeSpec2}, "test-service", tc.condition)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(results).To(Equal(tc.expectedResults))
+		})
+	}
+}
+
+// TestDeleteResources tests the DeleteResources function.
+func TestDeleteResources(t *testing.T) {
+	testcases := []struct {
+		name          string
+		condition     clusterv1.ConditionType
+		expectedError string
+		expect        func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDeleterMockRecorder)
+	}{
+		{
+			name:      "all resources are deleted",
+			condition: infrav1.PublicIPsReadyCondition,
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDeleterMockRecorder) {
+				s.GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.DeleteFuture).Times(3).Return(nil)
+				d.DeleteAsync(gomockinternal.AContext(), fakeSpec1).Return(nil, nil)
+				d.DeleteAsync(gomockinternal.AContext(), fakeSpec2).Return(nil, fakeNotFoundError)
+				d.DeleteAsync(gomockinternal.AContext(), fakeSpec3).Return(nil, nil)
+				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, "test-service", nil)
+			},
+		},
+		{
+			name:          "multiple errors are aggregated",
+			condition:     infrav1.PublicIPsReadyCondition,
+			expectedError: "[failed to delete resource test-group/test-resource-1 (service: test-service): #: Internal Server Error: StatusCode=500, failed to delete resource test-group/test-resource-3 (service: test-service): #: Internal Server Error: StatusCode=500]",
+			expect: func(s *mock_async.MockFutureScopeMockRecorder, d *mock_async.MockDeleterMockRecorder) {
+				s.GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.DeleteFuture).Times(3).Return(nil)
+				d.DeleteAsync(gomockinternal.AContext(), fakeSpec1).Return(nil, fakeInternalError)
+				d.DeleteAsync(gomockinternal.AContext(), fakeSpec2).Return(&azureautorest.Future{}, errCtxExceeded)
+				d.DeleteAsync(gomockinternal.AContext(), fakeSpec3).Return(nil, fakeInternalError)
+				s.SetLongRunningOperationState(gomock.AssignableToTypeOf(&infrav1.Future{}))
+				s.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, "test-service", gomockinternal.ErrStrEq("[failed to delete resource test-group/test-resource-1 (service: test-service): #: Internal Server Error: StatusCode=500, failed to delete resource test-group/test-resource-3 (service: test-service): #: Internal Server Error: StatusCode=500]"))
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_async.NewMockFutureScope(mockCtrl)
+			deleterMock := mock_async.NewMockDeleter(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), deleterMock.EXPECT())
+
+			s := New(scopeMock, nil, deleterMock)
+			err := s.DeleteResources(context.TODO(), []azure.ResourceSpecGetter{fakeSpec1, fakeSpec2, fakeSpec3}, "test-service", tc.condition)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+// TestForEachConcurrently tests that subresources of the same owner are processed one after the other.
+func TestForEachConcurrently(t *testing.T) {
+	g := NewWithT(t)
+
+	specs := []azure.ResourceSpecGetter{
+		&fakeSpec{name: "subnet-1", owner: "vnet"},
+		&fakeSpec{name: "subnet-2", owner: "vnet"},
+		&fakeSpec{name: "subnet-3", owner: "vnet"},
+		&fakeSpec{name: "nsg-1"},
+		&fakeSpec{name: "nsg-2"},
+	}
+
+	var mu sync.Mutex
+	var order []string
+	running := map[string]int{}
+	maxRunning := map[string]int{}
+	forEachConcurrently(specs, maxConcurrentOperations, func(i int) {
+		key := specs[i].OwnerResourceName()
+		mu.Lock()
+		running[key]++
+		if running[key] > maxRunning[key] {
+			maxRunning[key] = running[key]
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		defer mu.Unlock()
+		running[key]--
+		order = append(order, specs[i].ResourceName())
+	})
+
+	g.Expect(order).To(HaveLen(len(specs)))
+	g.Expect(maxRunning["vnet"]).To(Equal(1))
+	g.Expect(maxRunning[""]).To(Equal(2))
+	var subnets []string
+	for _, name := range order {
+		if strings.HasPrefix(name, "subnet") {
+			subnets = append(subnets, name)
+		}
+	}
+	g.Expect(subnets).To(Equal([]string{"subnet-1", "subnet-2", "subnet-3"}))
+}
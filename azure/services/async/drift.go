@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// detectDrift compares the existing resource with the parameters the spec would use to create it from scratch,
+// and reports the differences, if any, to the reporter. Specs implementing azure.ResourceSpecGetterWithDrift provide
+// these parameters themselves, along with the properties to ignore.
+func detectDrift(ctx context.Context, reporter azure.DriftReporter, spec azure.ResourceSpecGetter, existing interface{}, serviceName string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "async.detectDrift")
+	defer done()
+
+	resourceName := spec.ResourceName()
+	rgName := spec.ResourceGroupName()
+
+	var desired interface{}
+	var ignoredPaths []string
+	var err error
+	if driftSpec, ok := spec.(azure.ResourceSpecGetterWithDrift); ok {
+		desired, err = driftSpec.DesiredParameters(ctx)
+		ignoredPaths = driftSpec.DriftIgnoredPaths()
+	} else {
+		desired, err = spec.Parameters(ctx, nil)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get desired parameters for resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	} else if desired == nil {
+		return nil
+	}
+
+	differences, err := azure.Diff(desired, existing, ignoredPaths...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to detect drift of resource %s/%s (service: %s)", rgName, resourceName, serviceName)
+	}
+	if len(differences) == 0 {
+		return nil
+	}
+
+	drift := azure.ResourceDrift{
+		ServiceName:   serviceName,
+		ResourceGroup: rgName,
+		ResourceName:  resourceName,
+		Differences:   differences,
+	}
+	log.V(2).Info("resource drifted from its desired state", "service", serviceName, "resource", resourceName, "resourceGroup", rgName, "differences", len(differences))
+	reporter.ReportDrift(drift)
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+// driftReporterScope is a FutureScope that records the drift reported to it.
+type driftReporterScope struct {
+	*mock_async.MockFutureScope
+	mode   azure.DriftDetectionMode
+	drifts []azure.ResourceDrift
+}
+
+func (s *driftReporterScope) DriftDetectionMode() azure.DriftDetectionMode { return s.mode }
+func (s *driftReporterScope) ReportDrift(drift azure.ResourceDrift) {
+	s.drifts = append(s.drifts, drift)
+}
+
+var (
+	driftedExistingResource = resources.GenericResource{Location: pointer.String("westus")}
+	driftedDesiredResource  = resources.GenericResource{Location: pointer.String("eastus")}
+	expectedDrift           = azure.ResourceDrift{
+		ServiceName:   "test-service",
+		ResourceGroup: "test-group",
+		ResourceName:  "test-resource",
+		Differences:   []azure.Difference{{Path: "location", Desired: "eastus", Actual: "westus"}},
+	}
+)
+
+// TestCreateOrUpdateResourceDriftDetection tests the drift detection of the CreateOrUpdateResource function.
+func TestCreateOrUpdateResourceDriftDetection(t *testing.T) {
+	testcases := []struct {
+		name           string
+		mode           azure.DriftDetectionMode
+		expectedResult interface{}
+		expectedDrifts []azure.ResourceDrift
+		expect         func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:           "drift is not detected when drift detection is disabled",
+			mode:           azure.DriftDetectionDisabled,
+			expectedResult: "test-resource",
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&driftedExistingResource, nil)
+				r.Parameters(gomockinternal.AContext(), &driftedExistingResource).Return(&driftedDesiredResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &driftedDesiredResource).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:           "drift is reported and the resource is updated when drift detection is enabled",
+			mode:           azure.DriftDetectionEnabled,
+			expectedResult: "test-resource",
+			expectedDrifts: []azure.ResourceDrift{expectedDrift},
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&driftedExistingResource, nil)
+				r.Parameters(gomockinternal.AContext(), nil).Return(&driftedDesiredResource, nil)
+				r.Parameters(gomockinternal.AContext(), &driftedExistingResource).Return(&driftedDesiredResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &driftedDesiredResource).Return("test-resource", nil, nil)
+			},
+		},
+		{
+			name:           "drift is reported and the resource is not updated in report-only mode",
+			mode:           azure.DriftDetectionReportOnly,
+			expectedResult: &driftedExistingResource,
+			expectedDrifts: []azure.ResourceDrift{expectedDrift},
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&driftedExistingResource, nil)
+				r.Parameters(gomockinternal.AContext(), nil).Return(&driftedDesiredResource, nil)
+			},
+		},
+		{
+			name:           "missing resource is created in report-only mode",
+			mode:           azure.DriftDetectionReportOnly,
+			expectedResult: "test-resource",
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				r.Parameters(gomockinternal.AContext(), nil).Return(&driftedDesiredResource, nil)
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &driftedDesiredResource).Return("test-resource", nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scope := &driftReporterScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl), mode: tc.mode}
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			specMock.EXPECT().ResourceName().Return("test-resource").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("test-group").AnyTimes()
+			scope.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+			tc.expect(creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(scope, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(tc.expectedResult))
+			g.Expect(scope.drifts).To(Equal(tc.expectedDrifts))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+const (
+	// correlationIDHeader is the header Azure Resource Manager uses to return the correlation ID of a request.
+	correlationIDHeader = "x-ms-correlation-request-id"
+
+	// maxOperationErrorLength is the maximum length of the error message of an operation kept in the history.
+	maxOperationErrorLength = 1024
+)
+
+// recordOperation records the outcome of an Azure operation in the operation history of the scope, if the scope
+// keeps one. An operation that returned a future is recorded as in progress.
+func recordOperation(scope FutureScope, op infrav1.Operation, result interface{}, sdkFuture azureautorest.FutureAPI, err error) {
+	recorder, ok := scope.(azure.OperationRecorder)
+	if !ok {
+		return
+	}
+
+	switch {
+	case sdkFuture != nil:
+		op.Result = infrav1.OperationInProgress
+		op.CorrelationID = correlationIDFromResponse(sdkFuture.Response())
+	case err != nil:
+		op.Result = infrav1.OperationFailed
+		op.Error = truncate(err.Error(), maxOperationErrorLength)
+		op.CorrelationID = correlationIDFromError(err)
+	default:
+		op.Result = infrav1.OperationSucceeded
+		op.CorrelationID = correlationIDFromResult(result)
+	}
+	if op.Result != infrav1.OperationInProgress {
+		now := metav1.Now()
+		op.EndTime = &now
+	}
+
+	recorder.RecordOperation(op)
+}
+
+// correlationIDFromResponse returns the Azure correlation ID of an HTTP response.
+func correlationIDFromResponse(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	return resp.Header.Get(correlationIDHeader)
+}
+
+// correlationIDFromError returns the Azure correlation ID of the response that caused an error, if there is one.
+func correlationIDFromError(err error) string {
+	var detailedError autorest.DetailedError
+	if errors.As(err, &detailedError) {
+		return correlationIDFromResponse(detailedError.Response)
+	}
+	return ""
+}
+
+// correlationIDFromResult returns the Azure correlation ID of the response embedded in an SDK result, if there is one.
+func correlationIDFromResult(result interface{}) string {
+	v := reflect.ValueOf(result)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("Response")
+	if !f.IsValid() || !f.CanInterface() {
+		return ""
+	}
+	if resp, ok := f.Interface().(autorest.Response); ok {
+		return correlationIDFromResponse(resp.Response)
+	}
+	return ""
+}
+
+// truncate shortens a string to at most max bytes.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
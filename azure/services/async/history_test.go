@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/util/operations"
+)
+
+// recorderScope is a FutureScope that keeps a history of the Azure operations.
+type recorderScope struct {
+	*mock_async.MockFutureScope
+	cluster infrav1.AzureCluster
+}
+
+func (s *recorderScope) RecordOperation(operation infrav1.Operation) {
+	operations.Record(&s.cluster, operation)
+}
+
+func responseWithCorrelationID(statusCode int, method string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{http.CanonicalHeaderKey(correlationIDHeader): []string{"test-correlation-id"}},
+		Request:    &http.Request{Method: method, URL: &url.URL{Scheme: "https", Host: "management.azure.com", Path: "/test-resource"}},
+	}
+}
+
+// TestCreateOrUpdateResourceHistory tests that the CreateOrUpdateResource function records its operations.
+func TestCreateOrUpdateResourceHistory(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(c *mock_async.MockCreatorMockRecorder)
+		verify        func(g *WithT, op infrav1.Operation)
+	}{
+		{
+			name: "successful operation is recorded with the correlation ID of its response",
+			expect: func(c *mock_async.MockCreatorMockRecorder) {
+				result := resources.GenericResource{Response: autorest.Response{Response: responseWithCorrelationID(http.StatusOK, http.MethodPut)}}
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(result, nil, nil)
+			},
+			verify: func(g *WithT, op infrav1.Operation) {
+				g.Expect(op.Result).To(Equal(infrav1.OperationSucceeded))
+				g.Expect(op.CorrelationID).To(Equal("test-correlation-id"))
+				g.Expect(op.StartTime).NotTo(BeNil())
+				g.Expect(op.EndTime).NotTo(BeNil())
+				g.Expect(op.Error).To(BeEmpty())
+			},
+		},
+		{
+			name:          "failed operation is recorded with its error",
+			expectedError: "failed to update resource test-group/test-resource (service: test-service)",
+			expect: func(c *mock_async.MockCreatorMockRecorder) {
+				err := autorest.NewErrorWithResponse("", "", responseWithCorrelationID(http.StatusBadRequest, http.MethodPut), strings.Repeat("x", 2*maxOperationErrorLength))
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(nil, nil, err)
+			},
+			verify: func(g *WithT, op infrav1.Operation) {
+				g.Expect(op.Result).To(Equal(infrav1.OperationFailed))
+				g.Expect(op.CorrelationID).To(Equal("test-correlation-id"))
+				g.Expect(op.Error).To(HaveLen(maxOperationErrorLength))
+				g.Expect(op.EndTime).NotTo(BeNil())
+			},
+		},
+		{
+			name:          "long-running operation is recorded as in progress",
+			expectedError: "operation type PUT on Azure resource test-group/test-resource is not done",
+			expect: func(c *mock_async.MockCreatorMockRecorder) {
+				sdkFuture, _ := azureautorest.NewFutureFromResponse(responseWithCorrelationID(http.StatusAccepted, http.MethodPut))
+				c.CreateOrUpdateAsync(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{}), &fakeResourceParameters).Return(nil, &sdkFuture, nil)
+			},
+			verify: func(g *WithT, op infrav1.Operation) {
+				g.Expect(op.Result).To(Equal(infrav1.OperationInProgress))
+				g.Expect(op.CorrelationID).To(Equal("test-correlation-id"))
+				g.Expect(op.StartTime).NotTo(BeNil())
+				g.Expect(op.EndTime).To(BeNil())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scope := &recorderScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl)}
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			specMock.EXPECT().ResourceName().Return("test-resource").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("test-group").AnyTimes()
+			scope.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+			scope.EXPECT().SetLongRunningOperationState(gomock.Any()).AnyTimes()
+			creatorMock.EXPECT().Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(&fakeExistingResource, nil)
+			specMock.EXPECT().Parameters(gomockinternal.AContext(), &fakeExistingResource).Return(&fakeResourceParameters, nil)
+			tc.expect(creatorMock.EXPECT())
+
+			s := New(scope, creatorMock, nil)
+			_, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			history := scope.cluster.GetOperationHistory()
+			g.Expect(history).To(HaveLen(1))
+			g.Expect(history[0].Type).To(Equal(infrav1.PutFuture))
+			g.Expect(history[0].ResourceGroup).To(Equal("test-group"))
+			g.Expect(history[0].ServiceName).To(Equal("test-service"))
+			g.Expect(history[0].Name).To(Equal("test-resource"))
+			tc.verify(g, history[0])
+		})
+	}
+}
+
+// TestProcessOngoingOperationHistory tests that the completion of a long-running operation replaces its in-progress
+// entry in the history.
+func TestProcessOngoingOperationHistory(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scope := &recorderScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl)}
+	handlerMock := mock_async.NewMockFutureHandler(mockCtrl)
+
+	inProgress := infrav1.Operation{
+		Type:          infrav1.DeleteFuture,
+		ResourceGroup: "test-group",
+		ServiceName:   "test-service",
+		Name:          "test-resource",
+		Result:        infrav1.OperationInProgress,
+	}
+	scope.RecordOperation(inProgress)
+
+	scope.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.DeleteFuture).Return(&validDeleteFuture)
+	scope.EXPECT().DeleteLongRunningOperationState("test-resource", "test-service", infrav1.DeleteFuture)
+	handlerMock.EXPECT().IsDone(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{})).Return(true, nil)
+	handlerMock.EXPECT().Result(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azureautorest.Future{}), infrav1.DeleteFuture).Return(nil, fakeInternalError)
+
+	_, err := processOngoingOperation(context.TODO(), scope, handlerMock, "test-resource", "test-service", infrav1.DeleteFuture)
+	g.Expect(err).To(HaveOccurred())
+
+	history := scope.cluster.GetOperationHistory()
+	g.Expect(history).To(HaveLen(1))
+	g.Expect(history[0].Result).To(Equal(infrav1.OperationFailed))
+	g.Expect(history[0].EndTime).NotTo(BeNil())
+	g.Expect(history[0].Error).To(ContainSubstring("Internal Server Error"))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // FutureScope is a scope that can perform store futures and conditions in Status.
@@ -64,4 +65,7 @@ type Deleter interface {
 type Reconciler interface {
 	CreateOrUpdateResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (result interface{}, err error)
 	DeleteResource(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string) (err error)
+	CreateOrUpdateResources(ctx context.Context, specs []azure.ResourceSpecGetter, serviceName string, condition clusterv1.ConditionType) (results []interface{}, err error)
+	DeleteResources(ctx context.Context, specs []azure.ResourceSpecGetter, serviceName string, condition clusterv1.ConditionType) (err error)
+	ExecuteOperation(ctx context.Context, spec azure.ResourceSpecGetter, serviceName string, method azure.OperationMethod, futureType string, operation func(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)) (err error)
 }
@@ -448,6 +448,21 @@ func (mr *MockReconcilerMockRecorder) CreateOrUpdateResource(ctx, spec, serviceN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateResource", reflect.TypeOf((*MockReconciler)(nil).CreateOrUpdateResource), ctx, spec, serviceName)
 }
 
+// CreateOrUpdateResources mocks base method.
+func (m *MockReconciler) CreateOrUpdateResources(ctx context.Context, specs []azure0.ResourceSpecGetter, serviceName string, condition v1beta10.ConditionType) ([]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateResources", ctx, specs, serviceName, condition)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrUpdateResources indicates an expected call of CreateOrUpdateResources.
+func (mr *MockReconcilerMockRecorder) CreateOrUpdateResources(ctx, specs, serviceName, condition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateResources", reflect.TypeOf((*MockReconciler)(nil).CreateOrUpdateResources), ctx, specs, serviceName, condition)
+}
+
 // DeleteResource mocks base method.
 func (m *MockReconciler) DeleteResource(ctx context.Context, spec azure0.ResourceSpecGetter, serviceName string) error {
 	m.ctrl.T.Helper()
@@ -461,3 +476,31 @@ func (mr *MockReconcilerMockRecorder) DeleteResource(ctx, spec, serviceName inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockReconciler)(nil).DeleteResource), ctx, spec, serviceName)
 }
+
+// DeleteResources mocks base method.
+func (m *MockReconciler) DeleteResources(ctx context.Context, specs []azure0.ResourceSpecGetter, serviceName string, condition v1beta10.ConditionType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResources", ctx, specs, serviceName, condition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResources indicates an expected call of DeleteResources.
+func (mr *MockReconcilerMockRecorder) DeleteResources(ctx, specs, serviceName, condition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResources", reflect.TypeOf((*MockReconciler)(nil).DeleteResources), ctx, specs, serviceName, condition)
+}
+
+// ExecuteOperation mocks base method.
+func (m *MockReconciler) ExecuteOperation(ctx context.Context, spec azure0.ResourceSpecGetter, serviceName string, method azure0.OperationMethod, futureType string, operation func(context.Context, azure0.ResourceSpecGetter) (azure.FutureAPI, error)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteOperation", ctx, spec, serviceName, method, futureType, operation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteOperation indicates an expected call of ExecuteOperation.
+func (mr *MockReconcilerMockRecorder) ExecuteOperation(ctx, spec, serviceName, method, futureType, operation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteOperation", reflect.TypeOf((*MockReconciler)(nil).ExecuteOperation), ctx, spec, serviceName, method, futureType, operation)
+}
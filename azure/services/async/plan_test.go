@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package async
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+// plannerScope is a FutureScope that records the operations planned in dry-run mode.
+type plannerScope struct {
+	*mock_async.MockFutureScope
+	dryRun bool
+	plan   azure.Plan
+}
+
+func (s *plannerScope) DryRun() bool { return s.dryRun }
+func (s *plannerScope) PlanOperation(operation azure.PlannedOperation) {
+	s.plan = append(s.plan, operation)
+}
+
+// TestCreateOrUpdateResourceDryRun tests the dry-run mode of the CreateOrUpdateResource function.
+func TestCreateOrUpdateResourceDryRun(t *testing.T) {
+	testcases := []struct {
+		name           string
+		expectedResult interface{}
+		expectedPlan   azure.Plan
+		expect         func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder)
+	}{
+		{
+			name:         "creation of a missing resource is planned",
+			expectedPlan: azure.Plan{{ServiceName: "test-service", Method: azure.PutOperation, ResourceGroup: "test-group", ResourceName: "test-resource", Parameters: "test-params"}},
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(nil, fakeNotFoundError)
+				r.Parameters(gomockinternal.AContext(), nil).Return("test-params", nil)
+			},
+		},
+		{
+			name:           "update of an existing resource is planned",
+			expectedResult: fakeExistingResource,
+			expectedPlan:   azure.Plan{{ServiceName: "test-service", Method: azure.PutOperation, ResourceGroup: "test-group", ResourceName: "test-resource", Parameters: "test-params"}},
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(fakeExistingResource, nil)
+				r.Parameters(gomockinternal.AContext(), fakeExistingResource).Return("test-params", nil)
+			},
+		},
+		{
+			name:           "nothing is planned for an up to date resource",
+			expectedResult: fakeExistingResource,
+			expect: func(c *mock_async.MockCreatorMockRecorder, r *mock_azure.MockResourceSpecGetterMockRecorder) {
+				c.Get(gomockinternal.AContext(), gomock.AssignableToTypeOf(&mock_azure.MockResourceSpecGetter{})).Return(fakeExistingResource, nil)
+				r.Parameters(gomockinternal.AContext(), fakeExistingResource).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scope := &plannerScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl), dryRun: true}
+			creatorMock := mock_async.NewMockCreator(mockCtrl)
+			specMock := mock_azure.NewMockResourceSpecGetter(mockCtrl)
+
+			specMock.EXPECT().ResourceName().Return("test-resource").AnyTimes()
+			specMock.EXPECT().ResourceGroupName().Return("test-group").AnyTimes()
+			scope.EXPECT().GetLongRunningOperationState("test-resource", "test-service", infrav1.PutFuture).Return(nil)
+			tc.expect(creatorMock.EXPECT(), specMock.EXPECT())
+
+			s := New(scope, creatorMock, nil)
+			result, err := s.CreateOrUpdateResource(context.TODO(), specMock, "test-service")
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expectedResult == nil {
+				g.Expect(result).To(BeNil())
+			} else {
+				g.Expect(result).To(Equal(tc.expectedResult))
+			}
+			g.Expect(scope.plan).To(Equal(tc.expectedPlan))
+		})
+	}
+}
+
+// TestDeleteResourcesDryRun tests the dry-run mode of the DeleteResources function.
+func TestDeleteResourcesDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scope := &plannerScope{MockFutureScope: mock_async.NewMockFutureScope(mockCtrl), dryRun: true}
+	deleterMock := mock_async.NewMockDeleter(mockCtrl)
+
+	specs := []azure.ResourceSpecGetter{
+		&fakeSpec{name: "resource-1"},
+		&fakeSpec{name: "resource-2"},
+	}
+	scope.EXPECT().GetLongRunningOperationState(gomock.Any(), "test-service", infrav1.DeleteFuture).Return(nil).Times(2)
+	scope.EXPECT().UpdateDeleteStatus(infrav1.DisksReadyCondition, "test-service", nil)
+
+	s := New(scope, nil, deleterMock)
+	g.Expect(s.DeleteResources(context.TODO(), specs, "test-service", infrav1.DisksReadyCondition)).To(Succeed())
+	g.Expect(scope.plan).To(Equal(azure.Plan{
+		{ServiceName: "test-service", Method: azure.DeleteOperation, ResourceGroup: "test-group", ResourceName: "resource-1"},
+		{ServiceName: "test-service", Method: azure.DeleteOperation, ResourceGroup: "test-group", ResourceName: "resource-2"},
+	}))
+}
@@ -30,12 +30,13 @@ import (
 
 // AvailabilitySetSpec defines the specification for an availability set.
 type AvailabilitySetSpec struct {
-	Name           string
-	ResourceGroup  string
-	ClusterName    string
-	Location       string
-	SKU            *resourceskus.SKU
-	AdditionalTags infrav1.Tags
+	Name                      string
+	ResourceGroup             string
+	ClusterName               string
+	Location                  string
+	SKU                       *resourceskus.SKU
+	AdditionalTags            infrav1.Tags
+	ProximityPlacementGroupID string
 }
 
 // ResourceName returns the name of the availability set.
@@ -95,5 +96,9 @@ func (s *AvailabilitySetSpec) Parameters(ctx context.Context, existing interface
 		Location: pointer.String(s.Location),
 	}
 
+	if s.ProximityPlacementGroupID != "" {
+		asParams.AvailabilitySetProperties.ProximityPlacementGroup = &compute.SubResource{ID: pointer.String(s.ProximityPlacementGroupID)}
+	}
+
 	return asParams, nil
 }
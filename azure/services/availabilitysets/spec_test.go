@@ -73,6 +73,25 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "get parameters of an availability set in a proximity placement group",
+			spec: &AvailabilitySetSpec{
+				Name:                      "test-as",
+				ResourceGroup:             "test-rg",
+				ClusterName:               "test-cluster",
+				Location:                  "test-location",
+				SKU:                       &fakeSku,
+				ProximityPlacementGroupID: "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Compute/proximityPlacementGroups/test-ppg",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.AvailabilitySet{}))
+				g.Expect(result.(compute.AvailabilitySet).ProximityPlacementGroup).To(Equal(&compute.SubResource{
+					ID: pointer.String("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Compute/proximityPlacementGroups/test-ppg"),
+				}))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
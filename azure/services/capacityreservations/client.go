@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityreservations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	GetCapacityReservationID(ctx context.Context, capacityReservationGroupID, vmID string) (string, error)
+}
+
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	capacityreservationgroups compute.CapacityReservationGroupsClient
+}
+
+// NewClient creates a new capacity reservation groups client from auth info.
+func NewClient(auth azure.Authorizer) *AzureClient {
+	c := newCapacityReservationGroupsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &AzureClient{c}
+}
+
+// newCapacityReservationGroupsClient creates a new capacity reservation groups client from subscription ID, base URI,
+// and authorizer.
+func newCapacityReservationGroupsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.CapacityReservationGroupsClient {
+	capacityReservationGroupsClient := compute.NewCapacityReservationGroupsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&capacityReservationGroupsClient.Client, authorizer)
+	return capacityReservationGroupsClient
+}
+
+// GetCapacityReservationID returns the resource ID of the capacity reservation of a capacity reservation group, given
+// its full URL identifier, that a virtual machine is allocated against. It returns an empty string when the virtual
+// machine is not allocated against any capacity reservation of the group, e.g. when the group had no capacity left.
+func (ac *AzureClient) GetCapacityReservationID(ctx context.Context, capacityReservationGroupID, vmID string) (string, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "capacityreservations.AzureClient.GetCapacityReservationID")
+	defer done()
+
+	parsed, err := azureautorest.ParseResourceID(capacityReservationGroupID)
+	if err != nil {
+		return "", err
+	}
+	group, err := ac.capacityreservationgroups.Get(ctx, parsed.ResourceGroup, parsed.ResourceName, compute.CapacityReservationGroupInstanceViewTypesInstanceView)
+	if err != nil {
+		return "", err
+	}
+
+	name := allocatedCapacityReservationName(group, vmID)
+	if name == "" {
+		return "", nil
+	}
+	return azure.CapacityReservationID(capacityReservationGroupID, name), nil
+}
+
+// allocatedCapacityReservationName returns the name of the capacity reservation of a capacity reservation group that a
+// virtual machine is allocated against, or an empty string if there is none.
+func allocatedCapacityReservationName(group compute.CapacityReservationGroup, vmID string) string {
+	if group.CapacityReservationGroupProperties == nil || group.InstanceView == nil || group.InstanceView.CapacityReservations == nil {
+		return ""
+	}
+
+	for _, reservation := range *group.InstanceView.CapacityReservations {
+		if reservation.UtilizationInfo == nil || reservation.UtilizationInfo.VirtualMachinesAllocated == nil {
+			continue
+		}
+		for _, vm := range *reservation.UtilizationInfo.VirtualMachinesAllocated {
+			// Azure doesn't preserve the casing of the resource group in the resource IDs it returns.
+			if strings.EqualFold(pointer.StringDeref(vm.ID, ""), vmID) {
+				return pointer.StringDeref(reservation.Name, "")
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityreservations
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestAllocatedCapacityReservationName(t *testing.T) {
+	vmID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm"
+	group := compute.CapacityReservationGroup{
+		CapacityReservationGroupProperties: &compute.CapacityReservationGroupProperties{
+			InstanceView: &compute.CapacityReservationGroupInstanceView{
+				CapacityReservations: &[]compute.CapacityReservationInstanceViewWithName{
+					{
+						Name: pointer.String("empty-reservation"),
+					},
+					{
+						Name: pointer.String("other-reservation"),
+						UtilizationInfo: &compute.CapacityReservationUtilization{
+							VirtualMachinesAllocated: &[]compute.SubResourceReadOnly{
+								{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/other-vm")},
+							},
+						},
+					},
+					{
+						Name: pointer.String("my-reservation"),
+						UtilizationInfo: &compute.CapacityReservationUtilization{
+							VirtualMachinesAllocated: &[]compute.SubResourceReadOnly{
+								{ID: pointer.String("/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Compute/virtualMachines/my-vm")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		group    compute.CapacityReservationGroup
+		vmID     string
+		expected string
+	}{
+		{
+			name:     "vm is allocated against a capacity reservation",
+			group:    group,
+			vmID:     vmID,
+			expected: "my-reservation",
+		},
+		{
+			name:     "vm is not allocated against any capacity reservation",
+			group:    group,
+			vmID:     "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/unknown-vm",
+			expected: "",
+		},
+		{
+			name:     "capacity reservation group without instance view",
+			group:    compute.CapacityReservationGroup{},
+			vmID:     vmID,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			g.Expect(allocatedCapacityReservationName(tc.group, tc.vmID)).To(Equal(tc.expected))
+		})
+	}
+}
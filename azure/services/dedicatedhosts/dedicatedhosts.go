@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dedicatedhosts
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "dedicatedhosts"
+
+// DedicatedHostScope defines the scope interface for a dedicated hosts service.
+type DedicatedHostScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	DedicatedHostGroupSpecs() []azure.ResourceSpecGetter
+	DedicatedHostSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope               DedicatedHostScope
+	TagsGetter          async.TagsGetter
+	hostGroupReconciler async.Reconciler
+	hostReconciler      async.Reconciler
+}
+
+// New creates a new service.
+func New(scope DedicatedHostScope) *Service {
+	hostGroupClient := newHostGroupClient(scope)
+	hostClient := newHostClient(scope)
+	return &Service{
+		Scope:               scope,
+		TagsGetter:          tags.NewClient(scope),
+		hostGroupReconciler: async.New(scope, hostGroupClient, hostGroupClient),
+		hostReconciler:      async.New(scope, hostClient, hostClient),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+	}
+}
+
+// Reconcile idempotently creates the dedicated host groups of the cluster, then the dedicated hosts in them.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	groupSpecs := s.Scope.DedicatedHostGroupSpecs()
+	if len(groupSpecs) == 0 {
+		return nil
+	}
+
+	if _, err := s.hostGroupReconciler.CreateOrUpdateResources(ctx, groupSpecs, ServiceName, infrav1.DedicatedHostsReadyCondition); err != nil {
+		return err
+	}
+
+	hostSpecs := s.Scope.DedicatedHostSpecs()
+	if len(hostSpecs) == 0 {
+		return nil
+	}
+
+	_, err := s.hostReconciler.CreateOrUpdateResources(ctx, hostSpecs, ServiceName, infrav1.DedicatedHostsReadyCondition)
+	return err
+}
+
+// Delete deletes the dedicated hosts, then the dedicated host groups created by CAPZ.
+// Azure refuses to delete a dedicated host while virtual machines are placed on it.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	groupSpecs := s.Scope.DedicatedHostGroupSpecs()
+	if len(groupSpecs) == 0 {
+		return nil
+	}
+
+	hostSpecs, err := s.managedSpecs(ctx, s.Scope.DedicatedHostSpecs())
+	if err != nil {
+		return errors.Wrap(err, "could not get dedicated host management state")
+	}
+	if len(hostSpecs) > 0 {
+		if err := s.hostReconciler.DeleteResources(ctx, hostSpecs, ServiceName, infrav1.DedicatedHostsReadyCondition); err != nil {
+			return err
+		}
+	}
+
+	groupSpecs, err = s.managedSpecs(ctx, groupSpecs)
+	if err != nil {
+		return errors.Wrap(err, "could not get dedicated host group management state")
+	}
+	if len(groupSpecs) == 0 {
+		return nil
+	}
+
+	return s.hostGroupReconciler.DeleteResources(ctx, groupSpecs, ServiceName, infrav1.DedicatedHostsReadyCondition)
+}
+
+// managedSpecs returns the specs of the dedicated host groups or hosts which have an owned tag with the cluster name
+// as value, meaning that their lifecycle is managed. Resources that don't exist are skipped.
+func (s *Service) managedSpecs(ctx context.Context, specs []azure.ResourceSpecGetter) ([]azure.ResourceSpecGetter, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.Service.managedSpecs")
+	defer done()
+
+	var managedSpecs []azure.ResourceSpecGetter
+	for _, spec := range specs {
+		resourceID := azure.DedicatedHostGroupID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+		if spec.OwnerResourceName() != "" {
+			resourceID = azure.DedicatedHostID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.OwnerResourceName(), spec.ResourceName())
+		}
+
+		result, err := s.TagsGetter.GetAtScope(ctx, resourceID)
+		if err != nil {
+			if azure.ResourceNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		tagsMap := make(map[string]*string)
+		if result.Properties != nil && result.Properties.Tags != nil {
+			tagsMap = result.Properties.Tags
+		}
+
+		if !converters.MapToTags(tagsMap).HasOwned(s.Scope.ClusterName()) {
+			log.V(2).Info("Skipping deletion of unmanaged dedicated host resource", "resource", resourceID)
+			continue
+		}
+		managedSpecs = append(managedSpecs, spec)
+	}
+
+	return managedSpecs, nil
+}
+
+// IsManaged returns always returns true as dedicated host groups and hosts are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
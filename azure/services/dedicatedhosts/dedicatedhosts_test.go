@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dedicatedhosts
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts/mock_dedicatedhosts"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeHostGroupSpec = HostGroupSpec{
+		Name:                     "my-host-group",
+		ResourceGroup:            "my-rg",
+		Location:                 "eastus",
+		ClusterName:              "my-cluster",
+		Zone:                     "1",
+		PlatformFaultDomainCount: 2,
+		AutomaticPlacement:       true,
+	}
+
+	fakeHostSpec = HostSpec{
+		Name:                "my-host",
+		ResourceGroup:       "my-rg",
+		HostGroupName:       "my-host-group",
+		Location:            "eastus",
+		ClusterName:         "my-cluster",
+		SKU:                 "DSv3-Type3",
+		PlatformFaultDomain: 1,
+	}
+
+	fakeHostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+	fakeHostID      = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group/hosts/my-host"
+
+	managedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+			},
+		},
+	}
+
+	unmanagedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"foo": pointer.String("bar"),
+			},
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+)
+
+func TestReconcileDedicatedHosts(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no dedicated host groups",
+			expectedError: "",
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create dedicated host groups and hosts",
+			expectedError: "",
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				g.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostGroupSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return([]interface{}{nil}, nil)
+				s.DedicatedHostSpecs().Return([]azure.ResourceSpecGetter{&fakeHostSpec})
+				h.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return([]interface{}{nil}, nil)
+			},
+		},
+		{
+			name:          "create dedicated host groups without hosts",
+			expectedError: "",
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				g.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostGroupSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return([]interface{}{nil}, nil)
+				s.DedicatedHostSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "hosts are not created when a dedicated host group fails to be created",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				g.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostGroupSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return([]interface{}{nil}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_dedicatedhosts.NewMockDedicatedHostScope(mockCtrl)
+			hostGroupReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			hostReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), hostGroupReconcilerMock.EXPECT(), hostReconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:               scopeMock,
+				hostGroupReconciler: hostGroupReconcilerMock,
+				hostReconciler:      hostReconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteDedicatedHosts(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no dedicated host groups",
+			expectedError: "",
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete managed dedicated hosts, then their groups",
+			expectedError: "",
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				s.DedicatedHostSpecs().Return([]azure.ResourceSpecGetter{&fakeHostSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				gomock.InOrder(
+					m.GetAtScope(gomockinternal.AContext(), fakeHostID).Return(managedTags, nil),
+					h.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return(nil),
+					m.GetAtScope(gomockinternal.AContext(), fakeHostGroupID).Return(managedTags, nil),
+					g.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostGroupSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return(nil),
+				)
+			},
+		},
+		{
+			name:          "skip unmanaged and deleted resources",
+			expectedError: "",
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				s.DedicatedHostSpecs().Return([]azure.ResourceSpecGetter{&fakeHostSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetAtScope(gomockinternal.AContext(), fakeHostID).Return(resources.TagsResource{}, notFoundError)
+				m.GetAtScope(gomockinternal.AContext(), fakeHostGroupID).Return(unmanagedTags, nil)
+			},
+		},
+		{
+			name:          "dedicated host groups are not deleted when a host fails to be deleted",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				s.DedicatedHostSpecs().Return([]azure.ResourceSpecGetter{&fakeHostSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetAtScope(gomockinternal.AContext(), fakeHostID).Return(managedTags, nil)
+				h.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeHostSpec}, ServiceName, infrav1.DedicatedHostsReadyCondition).Return(internalError)
+			},
+		},
+		{
+			name:          "fail to get the management state of a dedicated host",
+			expectedError: "could not get dedicated host management state: " + internalError.Error(),
+			expect: func(s *mock_dedicatedhosts.MockDedicatedHostScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, g *mock_async.MockReconcilerMockRecorder, h *mock_async.MockReconcilerMockRecorder) {
+				s.DedicatedHostGroupSpecs().Return([]azure.ResourceSpecGetter{&fakeHostGroupSpec})
+				s.DedicatedHostSpecs().Return([]azure.ResourceSpecGetter{&fakeHostSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), fakeHostID).Return(resources.TagsResource{}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_dedicatedhosts.NewMockDedicatedHostScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			hostGroupReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+			hostReconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), hostGroupReconcilerMock.EXPECT(), hostReconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:               scopeMock,
+				TagsGetter:          tagsGetterMock,
+				hostGroupReconciler: hostGroupReconcilerMock,
+				hostReconciler:      hostReconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dedicatedhosts
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// HostSpec defines the specification for a dedicated host.
+type HostSpec struct {
+	Name                 string
+	ResourceGroup        string
+	HostGroupName        string
+	Location             string
+	ClusterName          string
+	SKU                  string
+	PlatformFaultDomain  int32
+	AutoReplaceOnFailure *bool
+	AdditionalTags       infrav1.Tags
+}
+
+// ResourceName returns the name of the dedicated host.
+func (s *HostSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *HostSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the dedicated host group the host belongs to.
+func (s *HostSpec) OwnerResourceName() string {
+	return s.HostGroupName
+}
+
+// Parameters returns the parameters for the dedicated host.
+func (s *HostSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	// Azure replaces failed hosts automatically unless told otherwise.
+	autoReplaceOnFailure := pointer.BoolDeref(s.AutoReplaceOnFailure, true)
+
+	if existing != nil {
+		existingHost, ok := existing.(compute.DedicatedHost)
+		if !ok {
+			return nil, errors.Errorf("%T is not a compute.DedicatedHost", existing)
+		}
+		if existingHost.DedicatedHostProperties != nil &&
+			pointer.BoolDeref(existingHost.DedicatedHostProperties.AutoReplaceOnFailure, true) == autoReplaceOnFailure {
+			// dedicated host is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	return compute.DedicatedHost{
+		Location: pointer.String(s.Location),
+		Sku: &compute.Sku{
+			Name: pointer.String(s.SKU),
+		},
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		DedicatedHostProperties: &compute.DedicatedHostProperties{
+			PlatformFaultDomain:  pointer.Int32(s.PlatformFaultDomain),
+			AutoReplaceOnFailure: pointer.Bool(autoReplaceOnFailure),
+		},
+	}, nil
+}
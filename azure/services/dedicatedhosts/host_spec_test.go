@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dedicatedhosts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestHostParameters(t *testing.T) {
+	expectedHost := func(autoReplaceOnFailure bool) compute.DedicatedHost {
+		return compute.DedicatedHost{
+			Location: pointer.String("eastus"),
+			Sku: &compute.Sku{
+				Name: pointer.String("DSv3-Type3"),
+			},
+			Tags: map[string]*string{
+				"Name": pointer.String("my-host"),
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+			},
+			DedicatedHostProperties: &compute.DedicatedHostProperties{
+				PlatformFaultDomain:  pointer.Int32(1),
+				AutoReplaceOnFailure: pointer.Bool(autoReplaceOnFailure),
+			},
+		}
+	}
+
+	existingHost := func(autoReplaceOnFailure bool) compute.DedicatedHost {
+		return compute.DedicatedHost{
+			Name: pointer.String("my-host"),
+			DedicatedHostProperties: &compute.DedicatedHostProperties{
+				PlatformFaultDomain:  pointer.Int32(1),
+				AutoReplaceOnFailure: pointer.Bool(autoReplaceOnFailure),
+			},
+		}
+	}
+
+	withoutAutoReplace := fakeHostSpec
+	withoutAutoReplace.AutoReplaceOnFailure = pointer.Bool(false)
+
+	testCases := []struct {
+		name          string
+		spec          HostSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "dedicated host does not exist",
+			spec:     fakeHostSpec,
+			existing: nil,
+			expected: expectedHost(true),
+		},
+		{
+			name:     "dedicated host without automatic replacement does not exist",
+			spec:     withoutAutoReplace,
+			existing: nil,
+			expected: expectedHost(false),
+		},
+		{
+			name:     "noop if the existing dedicated host is up to date",
+			spec:     fakeHostSpec,
+			existing: existingHost(true),
+			expected: nil,
+		},
+		{
+			name:     "update the existing dedicated host if its automatic replacement changed",
+			spec:     withoutAutoReplace,
+			existing: existingHost(true),
+			expected: expectedHost(false),
+		},
+		{
+			name:          "existing is not a dedicated host",
+			spec:          fakeHostSpec,
+			existing:      compute.DedicatedHostGroup{},
+			expectedError: "compute.DedicatedHostGroup is not a compute.DedicatedHost",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(cmp.Diff(tc.expected, result)).To(BeEmpty())
+		})
+	}
+}
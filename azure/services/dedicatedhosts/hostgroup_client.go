@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dedicatedhosts
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// azureHostGroupClient contains the Azure go-sdk Client for dedicated host groups.
+type azureHostGroupClient struct {
+	hostgroups compute.DedicatedHostGroupsClient
+}
+
+// newHostGroupClient creates a new dedicated host group client from subscription ID.
+func newHostGroupClient(auth azure.Authorizer) *azureHostGroupClient {
+	c := newDedicatedHostGroupsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureHostGroupClient{c}
+}
+
+// newDedicatedHostGroupsClient creates a dedicated host groups client from subscription ID.
+func newDedicatedHostGroupsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) compute.DedicatedHostGroupsClient {
+	hostGroupsClient := compute.NewDedicatedHostGroupsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&hostGroupsClient.Client, authorizer)
+	return hostGroupsClient
+}
+
+// Get gets the specified dedicated host group.
+func (ac *azureHostGroupClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.azureHostGroupClient.Get")
+	defer done()
+
+	return ac.hostgroups.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
+}
+
+// CreateOrUpdateAsync creates or updates a dedicated host group.
+// Creating a dedicated host group is not a long running operation, so we don't ever return a future.
+func (ac *azureHostGroupClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.azureHostGroupClient.CreateOrUpdateAsync")
+	defer done()
+
+	hostGroup, ok := parameters.(compute.DedicatedHostGroup)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a compute.DedicatedHostGroup", parameters)
+	}
+
+	result, err = ac.hostgroups.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), hostGroup)
+	return result, nil, err
+}
+
+// DeleteAsync deletes a dedicated host group.
+// Deleting a dedicated host group is not a long running operation, so we don't ever return a future.
+func (ac *azureHostGroupClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.azureHostGroupClient.DeleteAsync")
+	defer done()
+
+	_, err = ac.hostgroups.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azureHostGroupClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "dedicatedhosts.azureHostGroupClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.hostgroups)
+}
+
+// Result fetches the result of a long-running operation future.
+func (ac *azureHostGroupClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	// Result is a no-op for dedicated host groups as their operations never return a future.
+	return nil, nil
+}
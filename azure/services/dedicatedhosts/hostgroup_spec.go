@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dedicatedhosts
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// HostGroupSpec defines the specification for a dedicated host group.
+type HostGroupSpec struct {
+	Name                     string
+	ResourceGroup            string
+	Location                 string
+	ClusterName              string
+	Zone                     string
+	PlatformFaultDomainCount int32
+	AutomaticPlacement       bool
+	AdditionalTags           infrav1.Tags
+}
+
+// ResourceName returns the name of the dedicated host group.
+func (s *HostGroupSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *HostGroupSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for dedicated host groups.
+func (s *HostGroupSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the dedicated host group.
+func (s *HostGroupSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		if _, ok := existing.(compute.DedicatedHostGroup); !ok {
+			return nil, errors.Errorf("%T is not a compute.DedicatedHostGroup", existing)
+		}
+		// dedicated host group already exists, its zone, fault domains and placement cannot be updated.
+		return nil, nil
+	}
+
+	var zones *[]string
+	if s.Zone != "" {
+		zones = &[]string{s.Zone}
+	}
+
+	return compute.DedicatedHostGroup{
+		Location: pointer.String(s.Location),
+		Zones:    zones,
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+			PlatformFaultDomainCount:  pointer.Int32(s.PlatformFaultDomainCount),
+			SupportAutomaticPlacement: pointer.Bool(s.AutomaticPlacement),
+		},
+	}, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dedicatedhosts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestHostGroupParameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          HostGroupSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "zonal dedicated host group does not exist",
+			spec:     fakeHostGroupSpec,
+			existing: nil,
+			expected: compute.DedicatedHostGroup{
+				Location: pointer.String("eastus"),
+				Zones:    &[]string{"1"},
+				Tags: map[string]*string{
+					"Name": pointer.String("my-host-group"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				},
+				DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+					PlatformFaultDomainCount:  pointer.Int32(2),
+					SupportAutomaticPlacement: pointer.Bool(true),
+				},
+			},
+		},
+		{
+			name: "regional dedicated host group does not exist",
+			spec: HostGroupSpec{
+				Name:                     "my-host-group",
+				ResourceGroup:            "my-rg",
+				Location:                 "eastus",
+				ClusterName:              "my-cluster",
+				PlatformFaultDomainCount: 1,
+			},
+			existing: nil,
+			expected: compute.DedicatedHostGroup{
+				Location: pointer.String("eastus"),
+				Tags: map[string]*string{
+					"Name": pointer.String("my-host-group"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				},
+				DedicatedHostGroupProperties: &compute.DedicatedHostGroupProperties{
+					PlatformFaultDomainCount:  pointer.Int32(1),
+					SupportAutomaticPlacement: pointer.Bool(false),
+				},
+			},
+		},
+		{
+			name:     "noop if the dedicated host group exists",
+			spec:     fakeHostGroupSpec,
+			existing: compute.DedicatedHostGroup{Name: pointer.String("my-host-group")},
+			expected: nil,
+		},
+		{
+			name:          "existing is not a dedicated host group",
+			spec:          fakeHostGroupSpec,
+			existing:      compute.DedicatedHost{},
+			expectedError: "compute.DedicatedHost is not a compute.DedicatedHostGroup",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(cmp.Diff(tc.expected, result)).To(BeEmpty())
+		})
+	}
+}
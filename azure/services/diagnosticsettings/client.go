@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// azureClient contains the Azure go-sdk Client for diagnostic settings.
+type azureClient struct {
+	diagnosticsettings insights.DiagnosticSettingsClient
+}
+
+// newClient creates a new diagnostic settings client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newDiagnosticSettingsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{c}
+}
+
+// newDiagnosticSettingsClient creates a diagnostic settings client from subscription ID.
+func newDiagnosticSettingsClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) insights.DiagnosticSettingsClient {
+	diagnosticSettingsClient := insights.NewDiagnosticSettingsClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&diagnosticSettingsClient.Client, authorizer)
+	return diagnosticSettingsClient
+}
+
+// Get gets the specified diagnostic setting of the resource which owns it.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (interface{}, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.azureClient.Get")
+	defer done()
+
+	return ac.diagnosticsettings.Get(ctx, spec.OwnerResourceName(), spec.ResourceName())
+}
+
+// CreateOrUpdateAsync creates or updates a diagnostic setting.
+// Creating a diagnostic setting is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	setting, ok := parameters.(insights.DiagnosticSettingsResource)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not an insights.DiagnosticSettingsResource", parameters)
+	}
+
+	result, err = ac.diagnosticsettings.CreateOrUpdate(ctx, spec.OwnerResourceName(), setting, spec.ResourceName())
+	return result, nil, err
+}
+
+// DeleteAsync deletes a diagnostic setting.
+// Deleting a diagnostic setting is not a long running operation, so we don't ever return a future.
+func (ac *azureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.azureClient.DeleteAsync")
+	defer done()
+
+	_, err = ac.diagnosticsettings.Delete(ctx, spec.OwnerResourceName(), spec.ResourceName())
+	return nil, err
+}
+
+// IsDone returns true if the long-running operation has completed.
+func (ac *azureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.azureClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.diagnosticsettings)
+}
+
+// Result fetches the result of a long-running operation future.
+func (ac *azureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	// Result is a no-op for diagnostic settings as their operations never return a future.
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "diagnosticsettings"
+
+// DiagnosticSettingsScope defines the scope interface for a diagnostic settings service.
+type DiagnosticSettingsScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	DiagnosticSettingSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope DiagnosticSettingsScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope DiagnosticSettingsScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+// Diagnostic settings are deleted before the resources they observe, since Azure does not delete them together with
+// these resources.
+func (s *Service) Dependencies() []string {
+	return []string{
+		securitygroups.ServiceName,
+		loadbalancers.ServiceName,
+		publicips.ServiceName,
+		bastionhosts.ServiceName,
+	}
+}
+
+// Reconcile idempotently creates or updates the diagnostic settings of the cluster resources.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.DiagnosticSettingSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.DiagnosticSettingsReadyCondition)
+	return err
+}
+
+// Delete deletes the diagnostic settings of the cluster resources.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "diagnosticsettings.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.DiagnosticSettingSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.DiagnosticSettingsReadyCondition)
+}
+
+// IsManaged always returns true as CAPZ does not support BYO diagnostic settings.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings/mock_diagnosticsettings"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeDiagnosticSettingSpec = DiagnosticSettingSpec{
+		Name:          "my-cluster-node-nsg-diagnostics",
+		ResourceGroup: "my-rg",
+		ResourceID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-cluster-node-nsg",
+		LogCategories: SecurityGroupLogCategories,
+		WorkspaceID:   "/subscriptions/123/resourceGroups/my-monitoring-rg/providers/Microsoft.OperationalInsights/workspaces/my-workspace",
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileDiagnosticSettings(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no diagnostic settings",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiagnosticSettingSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create diagnostic settings",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiagnosticSettingSpecs().Return([]azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec}, ServiceName, infrav1.DiagnosticSettingsReadyCondition).Return([]interface{}{nil}, nil)
+			},
+		},
+		{
+			name:          "fail to create diagnostic settings",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiagnosticSettingSpecs().Return([]azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec}, ServiceName, infrav1.DiagnosticSettingsReadyCondition).Return([]interface{}{nil}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_diagnosticsettings.NewMockDiagnosticSettingsScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteDiagnosticSettings(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no diagnostic settings",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiagnosticSettingSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete diagnostic settings",
+			expectedError: "",
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiagnosticSettingSpecs().Return([]azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec})
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec}, ServiceName, infrav1.DiagnosticSettingsReadyCondition).Return(nil)
+			},
+		},
+		{
+			name:          "fail to delete diagnostic settings",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_diagnosticsettings.MockDiagnosticSettingsScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DiagnosticSettingSpecs().Return([]azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec})
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeDiagnosticSettingSpec}, ServiceName, infrav1.DiagnosticSettingsReadyCondition).Return(internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_diagnosticsettings.NewMockDiagnosticSettingsScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+)
+
+// allMetricsCategory is the diagnostic metric category which includes all the platform metrics of a resource.
+const allMetricsCategory = "AllMetrics"
+
+var (
+	// SecurityGroupLogCategories are the diagnostic log categories of a network security group.
+	SecurityGroupLogCategories = []string{"NetworkSecurityGroupEvent", "NetworkSecurityGroupRuleCounter"}
+	// PublicIPLogCategories are the diagnostic log categories of a public IP address.
+	PublicIPLogCategories = []string{"DDoSProtectionNotifications", "DDoSMitigationFlowLogs", "DDoSMitigationReports"}
+	// BastionHostLogCategories are the diagnostic log categories of a bastion host.
+	BastionHostLogCategories = []string{"BastionAuditLogs"}
+)
+
+// DiagnosticSettingSpec defines the specification for the diagnostic setting of an Azure resource.
+type DiagnosticSettingSpec struct {
+	Name             string
+	ResourceGroup    string
+	ResourceID       string
+	LogCategories    []string
+	Metrics          bool
+	WorkspaceID      string
+	StorageAccountID string
+}
+
+// ResourceName returns the name of the diagnostic setting.
+func (s *DiagnosticSettingSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *DiagnosticSettingSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the ID of the resource the diagnostic setting belongs to.
+func (s *DiagnosticSettingSpec) OwnerResourceName() string {
+	return s.ResourceID
+}
+
+// Parameters returns the parameters for the diagnostic setting.
+func (s *DiagnosticSettingSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingSetting, ok := existing.(insights.DiagnosticSettingsResource)
+		if !ok {
+			return nil, errors.Errorf("%T is not an insights.DiagnosticSettingsResource", existing)
+		}
+		if existingSetting.DiagnosticSettings != nil &&
+			strings.EqualFold(pointer.StringDeref(existingSetting.WorkspaceID, ""), s.WorkspaceID) &&
+			strings.EqualFold(pointer.StringDeref(existingSetting.StorageAccountID, ""), s.StorageAccountID) {
+			// diagnostic setting already ships to the expected destinations, nothing to do
+			return nil, nil
+		}
+	}
+
+	logs := make([]insights.LogSettings, 0, len(s.LogCategories))
+	for _, category := range s.LogCategories {
+		logs = append(logs, insights.LogSettings{
+			Category: pointer.String(category),
+			Enabled:  pointer.Bool(true),
+		})
+	}
+	metrics := []insights.MetricSettings{}
+	if s.Metrics {
+		metrics = append(metrics, insights.MetricSettings{
+			Category: pointer.String(allMetricsCategory),
+			Enabled:  pointer.Bool(true),
+		})
+	}
+
+	settings := &insights.DiagnosticSettings{
+		Logs:    &logs,
+		Metrics: &metrics,
+	}
+	if s.WorkspaceID != "" {
+		settings.WorkspaceID = pointer.String(s.WorkspaceID)
+	}
+	if s.StorageAccountID != "" {
+		settings.StorageAccountID = pointer.String(s.StorageAccountID)
+	}
+
+	return insights.DiagnosticSettingsResource{
+		Name:               pointer.String(s.Name),
+		DiagnosticSettings: settings,
+	}, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnosticsettings
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2019-06-01/insights"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestDiagnosticSettingParameters(t *testing.T) {
+	workspaceID := fakeDiagnosticSettingSpec.WorkspaceID
+	storageAccountID := "/subscriptions/123/resourceGroups/my-monitoring-rg/providers/Microsoft.Storage/storageAccounts/mydiagnostics"
+
+	testCases := []struct {
+		name          string
+		spec          DiagnosticSettingSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "diagnostic setting of a security group does not exist",
+			spec:     fakeDiagnosticSettingSpec,
+			existing: nil,
+			expected: insights.DiagnosticSettingsResource{
+				Name: pointer.String("my-cluster-node-nsg-diagnostics"),
+				DiagnosticSettings: &insights.DiagnosticSettings{
+					WorkspaceID: pointer.String(workspaceID),
+					Logs: &[]insights.LogSettings{
+						{Category: pointer.String("NetworkSecurityGroupEvent"), Enabled: pointer.Bool(true)},
+						{Category: pointer.String("NetworkSecurityGroupRuleCounter"), Enabled: pointer.Bool(true)},
+					},
+					Metrics: &[]insights.MetricSettings{},
+				},
+			},
+		},
+		{
+			name: "diagnostic setting of a load balancer does not exist",
+			spec: DiagnosticSettingSpec{
+				Name:             "my-cluster-public-lb-diagnostics",
+				ResourceGroup:    "my-rg",
+				ResourceID:       "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-public-lb",
+				Metrics:          true,
+				StorageAccountID: storageAccountID,
+			},
+			existing: nil,
+			expected: insights.DiagnosticSettingsResource{
+				Name: pointer.String("my-cluster-public-lb-diagnostics"),
+				DiagnosticSettings: &insights.DiagnosticSettings{
+					StorageAccountID: pointer.String(storageAccountID),
+					Logs:             &[]insights.LogSettings{},
+					Metrics: &[]insights.MetricSettings{
+						{Category: pointer.String("AllMetrics"), Enabled: pointer.Bool(true)},
+					},
+				},
+			},
+		},
+		{
+			name: "noop if the existing diagnostic setting ships to the same destinations",
+			spec: fakeDiagnosticSettingSpec,
+			existing: insights.DiagnosticSettingsResource{
+				Name: pointer.String("my-cluster-node-nsg-diagnostics"),
+				DiagnosticSettings: &insights.DiagnosticSettings{
+					WorkspaceID: pointer.String(strings.ToLower(workspaceID)),
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "update the existing diagnostic setting if its destinations changed",
+			spec: fakeDiagnosticSettingSpec,
+			existing: insights.DiagnosticSettingsResource{
+				Name: pointer.String("my-cluster-node-nsg-diagnostics"),
+				DiagnosticSettings: &insights.DiagnosticSettings{
+					StorageAccountID: pointer.String(storageAccountID),
+				},
+			},
+			expected: insights.DiagnosticSettingsResource{
+				Name: pointer.String("my-cluster-node-nsg-diagnostics"),
+				DiagnosticSettings: &insights.DiagnosticSettings{
+					WorkspaceID: pointer.String(workspaceID),
+					Logs: &[]insights.LogSettings{
+						{Category: pointer.String("NetworkSecurityGroupEvent"), Enabled: pointer.Bool(true)},
+						{Category: pointer.String("NetworkSecurityGroupRuleCounter"), Enabled: pointer.Bool(true)},
+					},
+					Metrics: &[]insights.MetricSettings{},
+				},
+			},
+		},
+		{
+			name:          "existing is not a diagnostic setting",
+			spec:          fakeDiagnosticSettingSpec,
+			existing:      insights.LogProfileResource{},
+			expectedError: "insights.LogProfileResource is not an insights.DiagnosticSettingsResource",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(cmp.Diff(tc.expected, result)).To(BeEmpty())
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/go-autorest/autorest"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
@@ -29,13 +30,16 @@ import (
 
 // azureClient contains the Azure go-sdk Client.
 type azureClient struct {
-	disks compute.DisksClient
+	disks           compute.DisksClient
+	virtualmachines compute.VirtualMachinesClient
 }
 
 // newClient creates a new disk Client from subscription ID.
 func newClient(auth azure.Authorizer) *azureClient {
 	c := NewDisksClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
-	return &azureClient{c}
+	vmClient := compute.NewVirtualMachinesClientWithBaseURI(auth.BaseURI(), auth.SubscriptionID())
+	azure.SetAutoRestClientDefaults(&vmClient.Client, auth.Authorizer())
+	return &azureClient{c, vmClient}
 }
 
 // NewDisksClient creates a new disks Client from subscription ID.
@@ -45,6 +49,45 @@ func NewDisksClient(subscriptionID string, baseURI string, authorizer autorest.A
 	return disksClient
 }
 
+// Get gets the virtual machine the data disks are attached to.
+func (ac *azureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.azureClient.Get")
+	defer done()
+
+	return ac.virtualmachines.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
+}
+
+// CreateOrUpdateAsync attaches and detaches the data disks of a virtual machine asynchronously.
+// It sends a PATCH request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *azureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.azureClient.CreateOrUpdateAsync")
+	defer done()
+
+	vm, ok := parameters.(compute.VirtualMachineUpdate)
+	if !ok {
+		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachineUpdate", parameters)
+	}
+
+	updateFuture, err := ac.virtualmachines.Update(ctx, spec.ResourceGroupName(), spec.ResourceName(), vm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = updateFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return nil, &updateFuture, err
+	}
+	result, err = updateFuture.Result(ac.virtualmachines)
+	// if the operation completed, return a nil future
+	return result, nil, err
+}
+
 // DeleteAsync deletes a route table asynchronously. DeleteAsync sends a DELETE
 // request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
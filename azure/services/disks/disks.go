@@ -33,6 +33,9 @@ type DiskScope interface {
 	azure.ClusterDescriber
 	azure.AsyncStatusUpdater
 	DiskSpecs() []azure.ResourceSpecGetter
+	DataDisksSpec() azure.ResourceSpecGetter
+	RemovedDataDiskSpecs() []azure.ResourceSpecGetter
+	UpdateDataDisksLastApplied() error
 }
 
 // Service provides operations on Azure resources.
@@ -46,7 +49,7 @@ func New(scope DiskScope) *Service {
 	client := newClient(scope)
 	return &Service{
 		Scope:      scope,
-		Reconciler: async.New(scope, nil, client),
+		Reconciler: async.New(scope, client, client),
 	}
 }
 
@@ -55,13 +58,34 @@ func (s *Service) Name() string {
 	return serviceName
 }
 
-// Reconcile on disk is currently no-op. OS disks should only be deleted and will create with the VM automatically.
+// Reconcile attaches and detaches the data disks of an existing VM that is updated in place, and deletes the managed
+// disks of the detached ones so they are not left behind. Disks are otherwise created with the VM automatically, and OS
+// disks should only be deleted.
 func (s *Service) Reconcile(ctx context.Context) error {
-	_, _, done := tele.StartSpanWithLogger(ctx, "disks.Service.Reconcile")
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "disks.Service.Reconcile")
 	defer done()
 
-	// DisksReadyCondition is set in the VM service.
-	return nil
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	spec := s.Scope.DataDisksSpec()
+	if spec == nil {
+		// DisksReadyCondition is set in the VM service.
+		return nil
+	}
+
+	if _, err := s.CreateOrUpdateResource(ctx, spec, serviceName); err != nil {
+		s.Scope.UpdatePatchStatus(infrav1.DisksReadyCondition, serviceName, err)
+		return err
+	}
+
+	// The removed data disks are detached at this point.
+	if err := s.DeleteResources(ctx, s.Scope.RemovedDataDiskSpecs(), serviceName, ""); err != nil {
+		s.Scope.UpdatePatchStatus(infrav1.DisksReadyCondition, serviceName, err)
+		return err
+	}
+
+	return s.Scope.UpdateDataDisksLastApplied()
 }
 
 // Delete deletes the disk associated with a VM.
@@ -77,19 +101,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of DiskSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, diskSpec := range specs {
-		if err := s.DeleteResource(ctx, diskSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-	s.Scope.UpdateDeleteStatus(infrav1.DisksReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, serviceName, infrav1.DisksReadyCondition)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO disk.
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/disks/mock_disks"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -50,6 +51,86 @@ var (
 	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
 )
 
+func TestReconcileDisks(t *testing.T) {
+	dataDisksSpec := DataDisksSpec{
+		VMName:        "my-vm",
+		ResourceGroup: "my-group",
+	}
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if the vm is not updated in place",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDisksSpec().Return(nil)
+			},
+		},
+		{
+			name:          "attach and detach data disks",
+			expectedError: "",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDisksSpec().Return(&dataDisksSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &dataDisksSpec, serviceName).Return(nil, nil)
+				s.RemovedDataDiskSpecs().Return(fakeDiskSpecs)
+				r.DeleteResources(gomockinternal.AContext(), fakeDiskSpecs, serviceName, clusterv1.ConditionType("")).Return(nil)
+				s.UpdateDataDisksLastApplied().Return(nil)
+			},
+		},
+		{
+			name:          "error while trying to delete the detached data disks",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDisksSpec().Return(&dataDisksSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &dataDisksSpec, serviceName).Return(nil, nil)
+				s.RemovedDataDiskSpecs().Return(fakeDiskSpecs)
+				r.DeleteResources(gomockinternal.AContext(), fakeDiskSpecs, serviceName, clusterv1.ConditionType("")).Return(internalError)
+				s.UpdatePatchStatus(infrav1.DisksReadyCondition, serviceName, internalError)
+			},
+		},
+		{
+			name:          "error while trying to attach and detach data disks",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.DataDisksSpec().Return(&dataDisksSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &dataDisksSpec, serviceName).Return(nil, internalError)
+				s.UpdatePatchStatus(infrav1.DisksReadyCondition, serviceName, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_disks.NewMockDiskScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeleteDisk(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -68,11 +149,7 @@ func TestDeleteDisk(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DiskSpecs().Return(fakeDiskSpecs)
-				gomock.InOrder(
-					r.DeleteResource(gomockinternal.AContext(), &diskSpec1, serviceName).Return(nil),
-					r.DeleteResource(gomockinternal.AContext(), &diskSpec2, serviceName).Return(nil),
-					s.UpdateDeleteStatus(infrav1.DisksReadyCondition, serviceName, nil),
-				)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&diskSpec1, &diskSpec2}, serviceName, infrav1.DisksReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -80,11 +157,7 @@ func TestDeleteDisk(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DiskSpecs().Return(fakeDiskSpecs)
-				gomock.InOrder(
-					r.DeleteResource(gomockinternal.AContext(), &diskSpec1, serviceName).Return(nil),
-					r.DeleteResource(gomockinternal.AContext(), &diskSpec2, serviceName).Return(nil),
-					s.UpdateDeleteStatus(infrav1.DisksReadyCondition, serviceName, nil),
-				)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&diskSpec1, &diskSpec2}, serviceName, infrav1.DisksReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -92,11 +165,7 @@ func TestDeleteDisk(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_disks.MockDiskScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.DiskSpecs().Return(fakeDiskSpecs)
-				gomock.InOrder(
-					r.DeleteResource(gomockinternal.AContext(), &diskSpec1, serviceName).Return(internalError),
-					r.DeleteResource(gomockinternal.AContext(), &diskSpec2, serviceName).Return(nil),
-					s.UpdateDeleteStatus(infrav1.DisksReadyCondition, serviceName, internalError),
-				)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&diskSpec1, &diskSpec2}, serviceName, infrav1.DisksReadyCondition).Return(internalError)
 			},
 		},
 	}
@@ -179,6 +179,20 @@ func (mr *MockDiskScopeMockRecorder) ClusterName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockDiskScope)(nil).ClusterName))
 }
 
+// DataDisksSpec mocks base method.
+func (m *MockDiskScope) DataDisksSpec() azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataDisksSpec")
+	ret0, _ := ret[0].(azure.ResourceSpecGetter)
+	return ret0
+}
+
+// DataDisksSpec indicates an expected call of DataDisksSpec.
+func (mr *MockDiskScopeMockRecorder) DataDisksSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataDisksSpec", reflect.TypeOf((*MockDiskScope)(nil).DataDisksSpec))
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockDiskScope) DeleteLongRunningOperationState(arg0, arg1, arg2 string) {
 	m.ctrl.T.Helper()
@@ -261,6 +275,20 @@ func (mr *MockDiskScopeMockRecorder) Location() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockDiskScope)(nil).Location))
 }
 
+// RemovedDataDiskSpecs mocks base method.
+func (m *MockDiskScope) RemovedDataDiskSpecs() []azure.ResourceSpecGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovedDataDiskSpecs")
+	ret0, _ := ret[0].([]azure.ResourceSpecGetter)
+	return ret0
+}
+
+// RemovedDataDiskSpecs indicates an expected call of RemovedDataDiskSpecs.
+func (mr *MockDiskScopeMockRecorder) RemovedDataDiskSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovedDataDiskSpecs", reflect.TypeOf((*MockDiskScope)(nil).RemovedDataDiskSpecs))
+}
+
 // ResourceGroup mocks base method.
 func (m *MockDiskScope) ResourceGroup() string {
 	m.ctrl.T.Helper()
@@ -315,6 +343,20 @@ func (mr *MockDiskScopeMockRecorder) TenantID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockDiskScope)(nil).TenantID))
 }
 
+// UpdateDataDisksLastApplied mocks base method.
+func (m *MockDiskScope) UpdateDataDisksLastApplied() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDataDisksLastApplied")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDataDisksLastApplied indicates an expected call of UpdateDataDisksLastApplied.
+func (mr *MockDiskScopeMockRecorder) UpdateDataDisksLastApplied() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDataDisksLastApplied", reflect.TypeOf((*MockDiskScope)(nil).UpdateDataDisksLastApplied))
+}
+
 // UpdateDeleteStatus mocks base method.
 func (m *MockDiskScope) UpdateDeleteStatus(arg0 v1beta10.ConditionType, arg1 string, arg2 error) {
 	m.ctrl.T.Helper()
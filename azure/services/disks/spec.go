@@ -16,7 +16,17 @@ limitations under the License.
 
 package disks
 
-import "context"
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
 
 // DiskSpec defines the specification for a disk.
 type DiskSpec struct {
@@ -43,3 +53,83 @@ func (s *DiskSpec) OwnerResourceName() string {
 func (s *DiskSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	return nil, nil
 }
+
+// DataDisksSpec defines the specification for the data disks attached to an existing virtual machine.
+type DataDisksSpec struct {
+	VMName        string
+	ResourceGroup string
+	DataDisks     []infrav1.DataDisk
+}
+
+// ResourceName returns the name of the virtual machine the data disks are attached to.
+func (s *DataDisksSpec) ResourceName() string {
+	return s.VMName
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *DataDisksSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for data disks.
+func (s *DataDisksSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the update of the virtual machine that attaches the missing data disks and detaches the
+// removed ones. Disks attached to the virtual machine outside of CAPZ, such as persistent volumes, are left untouched.
+func (s *DataDisksSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing == nil {
+		// The data disks of a new virtual machine are created with it.
+		return nil, nil
+	}
+
+	vm, ok := existing.(compute.VirtualMachine)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachine", existing)
+	}
+
+	var current []compute.DataDisk
+	if vm.VirtualMachineProperties != nil && vm.StorageProfile != nil && vm.StorageProfile.DataDisks != nil {
+		current = *vm.StorageProfile.DataDisks
+	}
+
+	desired := make(map[string]infrav1.DataDisk, len(s.DataDisks))
+	for _, disk := range s.DataDisks {
+		desired[strings.ToLower(azure.GenerateDataDiskName(s.VMName, disk.NameSuffix))] = disk
+	}
+
+	changed := false
+	attached := make(map[string]struct{}, len(current))
+	dataDisks := make([]compute.DataDisk, 0, len(s.DataDisks))
+	for _, disk := range current {
+		name := strings.ToLower(pointer.StringDeref(disk.Name, ""))
+		_, ok := desired[name]
+		if !ok && strings.HasPrefix(name, strings.ToLower(s.VMName+"_")) {
+			// Detach the data disk by leaving it out.
+			changed = true
+			continue
+		}
+		attached[name] = struct{}{}
+		dataDisks = append(dataDisks, disk)
+	}
+
+	for _, disk := range s.DataDisks {
+		if _, ok := attached[strings.ToLower(azure.GenerateDataDiskName(s.VMName, disk.NameSuffix))]; !ok {
+			changed = true
+			dataDisks = append(dataDisks, converters.DataDiskToSDK(s.VMName, disk))
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			StorageProfile: &compute.StorageProfile{
+				DataDisks: &dataDisks,
+			},
+		},
+	}, nil
+}
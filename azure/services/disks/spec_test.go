@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestDataDisksParameters(t *testing.T) {
+	attachedDisk := compute.DataDisk{
+		Name:         pointer.String("my-vm_etcddisk"),
+		Lun:          pointer.Int32(0),
+		CreateOption: compute.DiskCreateOptionTypesEmpty,
+		DiskSizeGB:   pointer.Int32(256),
+		ManagedDisk:  &compute.ManagedDiskParameters{ID: pointer.String("/subscriptions/123/resourceGroups/my-group/providers/Microsoft.Compute/disks/my-vm_etcddisk")},
+	}
+	persistentVolumeDisk := compute.DataDisk{
+		Name:         pointer.String("pvc-1234"),
+		Lun:          pointer.Int32(5),
+		CreateOption: compute.DiskCreateOptionTypesAttach,
+		ManagedDisk:  &compute.ManagedDiskParameters{ID: pointer.String("/subscriptions/123/resourceGroups/my-group/providers/Microsoft.Compute/disks/pvc-1234")},
+	}
+	etcdDisk := infrav1.DataDisk{
+		NameSuffix: "etcddisk",
+		DiskSizeGB: 256,
+		Lun:        pointer.Int32(0),
+	}
+	newDisk := infrav1.DataDisk{
+		NameSuffix:  "newdisk",
+		DiskSizeGB:  128,
+		Lun:         pointer.Int32(1),
+		CachingType: "ReadWrite",
+		ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "Premium_LRS"},
+	}
+	vmWithDataDisks := func(disks ...compute.DataDisk) compute.VirtualMachine {
+		return compute.VirtualMachine{
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				StorageProfile: &compute.StorageProfile{
+					DataDisks: &disks,
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name          string
+		spec          *DataDisksSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "noop if the vm does not exist yet",
+			spec:     &DataDisksSpec{VMName: "my-vm", ResourceGroup: "my-group", DataDisks: []infrav1.DataDisk{etcdDisk}},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "noop if the data disks are up to date",
+			spec:     &DataDisksSpec{VMName: "my-vm", ResourceGroup: "my-group", DataDisks: []infrav1.DataDisk{etcdDisk}},
+			existing: vmWithDataDisks(attachedDisk, persistentVolumeDisk),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "attach a new data disk",
+			spec:     &DataDisksSpec{VMName: "my-vm", ResourceGroup: "my-group", DataDisks: []infrav1.DataDisk{etcdDisk, newDisk}},
+			existing: vmWithDataDisks(attachedDisk, persistentVolumeDisk),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						StorageProfile: &compute.StorageProfile{
+							DataDisks: &[]compute.DataDisk{
+								attachedDisk,
+								persistentVolumeDisk,
+								{
+									Name:         pointer.String("my-vm_newdisk"),
+									Lun:          pointer.Int32(1),
+									CreateOption: compute.DiskCreateOptionTypesEmpty,
+									DiskSizeGB:   pointer.Int32(128),
+									Caching:      compute.CachingTypesReadWrite,
+									ManagedDisk:  &compute.ManagedDiskParameters{StorageAccountType: compute.StorageAccountTypesPremiumLRS},
+								},
+							},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:     "detach a removed data disk and keep the disks attached outside of capz",
+			spec:     &DataDisksSpec{VMName: "my-vm", ResourceGroup: "my-group"},
+			existing: vmWithDataDisks(attachedDisk, persistentVolumeDisk),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(compute.VirtualMachineUpdate{
+					VirtualMachineProperties: &compute.VirtualMachineProperties{
+						StorageProfile: &compute.StorageProfile{
+							DataDisks: &[]compute.DataDisk{persistentVolumeDisk},
+						},
+					},
+				}))
+			},
+		},
+		{
+			name:          "existing is not a virtual machine",
+			spec:          &DataDisksSpec{VMName: "my-vm", ResourceGroup: "my-group"},
+			existing:      "wrong type",
+			expectedError: "string is not a compute.VirtualMachine",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+				tc.expect(g, result)
+			}
+		})
+	}
+}
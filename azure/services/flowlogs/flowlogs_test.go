@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs/mock_flowlogs"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakeFlowLogSpec = FlowLogSpec{
+		Name:               "my-cluster-node-nsg-flowlog",
+		ResourceGroup:      "NetworkWatcherRG",
+		NetworkWatcherName: "NetworkWatcher_eastus",
+		Location:           "eastus",
+		ClusterName:        "my-cluster",
+		SecurityGroupID:    "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkSecurityGroups/my-cluster-node-nsg",
+		StorageAccountID:   "/subscriptions/123/resourceGroups/my-monitoring-rg/providers/Microsoft.Storage/storageAccounts/myflowlogs",
+		RetentionDays:      30,
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcileFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLogSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeFlowLogSpec}, ServiceName, infrav1.FlowLogsReadyCondition).Return([]interface{}{nil}, nil)
+			},
+		},
+		{
+			name:          "fail to create flow logs",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLogSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeFlowLogSpec}, ServiceName, infrav1.FlowLogsReadyCondition).Return([]interface{}{nil}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_flowlogs.NewMockFlowLogScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteFlowLogs(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete flow logs",
+			expectedError: "",
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLogSpec})
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeFlowLogSpec}, ServiceName, infrav1.FlowLogsReadyCondition).Return(nil)
+			},
+		},
+		{
+			name:          "fail to delete flow logs",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_flowlogs.MockFlowLogScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.FlowLogSpecs().Return([]azure.ResourceSpecGetter{&fakeFlowLogSpec})
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeFlowLogSpec}, ServiceName, infrav1.FlowLogsReadyCondition).Return(internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_flowlogs.NewMockFlowLogScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
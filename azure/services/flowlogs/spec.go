@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// flowLogFormatVersion is the version of the JSON flow log format, version 2 includes the flow state and throughput.
+const flowLogFormatVersion = 2
+
+// FlowLogSpec defines the specification for the flow log of a network security group.
+type FlowLogSpec struct {
+	Name               string
+	ResourceGroup      string
+	NetworkWatcherName string
+	Location           string
+	ClusterName        string
+	SecurityGroupID    string
+	StorageAccountID   string
+	RetentionDays      int32
+	AdditionalTags     infrav1.Tags
+}
+
+// ResourceName returns the name of the flow log.
+func (s *FlowLogSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group of the network watcher.
+func (s *FlowLogSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName returns the name of the network watcher the flow log belongs to.
+func (s *FlowLogSpec) OwnerResourceName() string {
+	return s.NetworkWatcherName
+}
+
+// Parameters returns the parameters for the flow log.
+func (s *FlowLogSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	if existing != nil {
+		existingFlowLog, ok := existing.(network.FlowLog)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.FlowLog", existing)
+		}
+		if s.isUpToDate(existingFlowLog) {
+			// flow log is up to date, nothing to do
+			return nil, nil
+		}
+	}
+
+	return network.FlowLog{
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		FlowLogPropertiesFormat: &network.FlowLogPropertiesFormat{
+			TargetResourceID: pointer.String(s.SecurityGroupID),
+			StorageID:        pointer.String(s.StorageAccountID),
+			Enabled:          pointer.Bool(true),
+			RetentionPolicy: &network.RetentionPolicyParameters{
+				Days:    pointer.Int32(s.RetentionDays),
+				Enabled: pointer.Bool(s.RetentionDays > 0),
+			},
+			Format: &network.FlowLogFormatParameters{
+				Type:    network.FlowLogFormatTypeJSON,
+				Version: pointer.Int32(flowLogFormatVersion),
+			},
+		},
+	}, nil
+}
+
+// isUpToDate returns true if the existing flow log is enabled and matches the spec.
+func (s *FlowLogSpec) isUpToDate(existing network.FlowLog) bool {
+	props := existing.FlowLogPropertiesFormat
+	if props == nil || !pointer.BoolDeref(props.Enabled, false) {
+		return false
+	}
+	if !strings.EqualFold(pointer.StringDeref(props.TargetResourceID, ""), s.SecurityGroupID) ||
+		!strings.EqualFold(pointer.StringDeref(props.StorageID, ""), s.StorageAccountID) {
+		return false
+	}
+	var retentionDays int32
+	if props.RetentionPolicy != nil && pointer.BoolDeref(props.RetentionPolicy.Enabled, false) {
+		retentionDays = pointer.Int32Deref(props.RetentionPolicy.Days, 0)
+	}
+	return retentionDays == s.RetentionDays
+}
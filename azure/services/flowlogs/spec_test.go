@@ -0,0 +1,118 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowlogs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestFlowLogParameters(t *testing.T) {
+	expectedFlowLog := network.FlowLog{
+		Location: pointer.String("eastus"),
+		Tags: map[string]*string{
+			"Name": pointer.String("my-cluster-node-nsg-flowlog"),
+			"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+		},
+		FlowLogPropertiesFormat: &network.FlowLogPropertiesFormat{
+			TargetResourceID: pointer.String(fakeFlowLogSpec.SecurityGroupID),
+			StorageID:        pointer.String(fakeFlowLogSpec.StorageAccountID),
+			Enabled:          pointer.Bool(true),
+			RetentionPolicy: &network.RetentionPolicyParameters{
+				Days:    pointer.Int32(30),
+				Enabled: pointer.Bool(true),
+			},
+			Format: &network.FlowLogFormatParameters{
+				Type:    network.FlowLogFormatTypeJSON,
+				Version: pointer.Int32(2),
+			},
+		},
+	}
+
+	existingFlowLog := func(enabled bool, retentionDays int32) network.FlowLog {
+		return network.FlowLog{
+			Name:     pointer.String("my-cluster-node-nsg-flowlog"),
+			Location: pointer.String("eastus"),
+			FlowLogPropertiesFormat: &network.FlowLogPropertiesFormat{
+				TargetResourceID: pointer.String(strings.ToLower(fakeFlowLogSpec.SecurityGroupID)),
+				StorageID:        pointer.String(fakeFlowLogSpec.StorageAccountID),
+				Enabled:          pointer.Bool(enabled),
+				RetentionPolicy: &network.RetentionPolicyParameters{
+					Days:    pointer.Int32(retentionDays),
+					Enabled: pointer.Bool(retentionDays > 0),
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "flow log does not exist",
+			existing: nil,
+			expected: expectedFlowLog,
+		},
+		{
+			name:     "noop if the existing flow log is up to date",
+			existing: existingFlowLog(true, 30),
+			expected: nil,
+		},
+		{
+			name:     "update the existing flow log if it is disabled",
+			existing: existingFlowLog(false, 30),
+			expected: expectedFlowLog,
+		},
+		{
+			name:     "update the existing flow log if its retention changed",
+			existing: existingFlowLog(true, 0),
+			expected: expectedFlowLog,
+		},
+		{
+			name:          "existing is not a flow log",
+			existing:      network.SecurityGroup{},
+			expectedError: "network.SecurityGroup is not a network.FlowLog",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := fakeFlowLogSpec
+			result, err := spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(cmp.Diff(tc.expected, result)).To(BeEmpty())
+		})
+	}
+}
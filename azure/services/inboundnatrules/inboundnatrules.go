@@ -90,10 +90,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		portsInUse[*rule.InboundNatRulePropertiesFormat.FrontendPort] = struct{}{} // Mark frontend port as in use
 	}
 
-	// We go through the list of InboundNatSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
+	// SSH frontend ports are assigned to every rule before the rules are created, so that no two rules get the same port.
 	for _, spec := range specs {
 		// Find an available SSH port for the rule.
 		sshFrontendPort, err := getAvailableSSHFrontendPort(portsInUse)
@@ -102,21 +99,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		}
 		natRule, ok := spec.(*InboundNatSpec)
 		if !ok {
-			result = errors.Errorf("%T is not of type InboundNatSpec", spec)
+			return errors.Errorf("%T is not of type InboundNatSpec", spec)
 		}
 		natRule.SSHFrontendPort = &sshFrontendPort
 		// Add the SSH frontend port to the list of ports in use
 		portsInUse[sshFrontendPort] = struct{}{}
-		if _, err := s.CreateOrUpdateResource(ctx, natRule, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, result)
-
-	return result
+	_, err = s.CreateOrUpdateResources(ctx, specs, serviceName, infrav1.InboundNATRulesReadyCondition)
+	return err
 }
 
 // Delete deletes the inbound NAT rule with the provided name.
@@ -132,20 +123,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of InboundNatSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, natRule := range specs {
-		if err := s.DeleteResource(ctx, natRule, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdateDeleteStatus(infrav1.InboundNATRulesReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, serviceName, infrav1.InboundNATRulesReadyCondition)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO inbound NAT rules.
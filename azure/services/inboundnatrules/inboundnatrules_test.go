@@ -111,11 +111,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				s.APIServerLBName().AnyTimes().Return(fakeLBName)
 				m.List(gomockinternal.AContext(), fakeGroupName, fakeLBName).Return(noExistingRules, nil)
 				s.InboundNatSpecs().Return([]azure.ResourceSpecGetter{getFakeNatSpecWithoutPort(fakeNatSpec), getFakeNatSpecWithoutPort(fakeNatSpec2)})
-				gomock.InOrder(
-					r.CreateOrUpdateResource(gomockinternal.AContext(), getFakeNatSpecWithPort(fakeNatSpec, 22), serviceName).Return(nil, nil),
-					r.CreateOrUpdateResource(gomockinternal.AContext(), getFakeNatSpecWithPort(fakeNatSpec2, 2201), serviceName).Return(nil, nil),
-					s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil),
-				)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{getFakeNatSpecWithPort(fakeNatSpec, 22), getFakeNatSpecWithPort(fakeNatSpec2, 2201)}, serviceName, infrav1.InboundNATRulesReadyCondition).Return([]interface{}{nil, nil}, nil)
 			},
 		},
 		{
@@ -128,10 +124,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				s.APIServerLBName().AnyTimes().Return("my-lb")
 				m.List(gomockinternal.AContext(), fakeGroupName, "my-lb").Return(fakeExistingRules, nil)
 				s.InboundNatSpecs().Return([]azure.ResourceSpecGetter{getFakeNatSpecWithoutPort(fakeNatSpec)})
-				gomock.InOrder(
-					r.CreateOrUpdateResource(gomockinternal.AContext(), getFakeNatSpecWithPort(fakeNatSpec, 2202), serviceName).Return(nil, nil),
-					s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil),
-				)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{getFakeNatSpecWithPort(fakeNatSpec, 2202)}, serviceName, infrav1.InboundNATRulesReadyCondition).Return([]interface{}{nil}, nil)
 			},
 		},
 		{
@@ -166,10 +159,7 @@ func TestReconcileInboundNATRule(t *testing.T) {
 				s.APIServerLBName().AnyTimes().Return("my-lb")
 				m.List(gomockinternal.AContext(), fakeGroupName, "my-lb").Return(fakeExistingRules, nil)
 				s.InboundNatSpecs().Return([]azure.ResourceSpecGetter{&fakeNatSpec})
-				gomock.InOrder(
-					r.CreateOrUpdateResource(gomockinternal.AContext(), getFakeNatSpecWithPort(fakeNatSpec, 2202), serviceName).Return(nil, internalError),
-					s.UpdatePutStatus(infrav1.InboundNATRulesReadyCondition, serviceName, internalError),
-				)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{getFakeNatSpecWithPort(fakeNatSpec, 2202)}, serviceName, infrav1.InboundNATRulesReadyCondition).Return([]interface{}{nil}, internalError)
 			},
 		},
 	}
@@ -227,10 +217,7 @@ func TestDeleteNetworkInterface(t *testing.T) {
 				s.InboundNatSpecs().Return([]azure.ResourceSpecGetter{&fakeNatSpec})
 				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
 				s.APIServerLBName().AnyTimes().Return(fakeLBName)
-				gomock.InOrder(
-					r.DeleteResource(gomockinternal.AContext(), &fakeNatSpec, serviceName).Return(nil),
-					s.UpdateDeleteStatus(infrav1.InboundNATRulesReadyCondition, serviceName, nil),
-				)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNatSpec}, serviceName, infrav1.InboundNATRulesReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -241,10 +228,7 @@ func TestDeleteNetworkInterface(t *testing.T) {
 				s.InboundNatSpecs().Return([]azure.ResourceSpecGetter{&fakeNatSpec})
 				s.ResourceGroup().AnyTimes().Return(fakeGroupName)
 				s.APIServerLBName().AnyTimes().Return(fakeLBName)
-				gomock.InOrder(
-					r.DeleteResource(gomockinternal.AContext(), &fakeNatSpec, serviceName).Return(internalError),
-					s.UpdateDeleteStatus(infrav1.InboundNATRulesReadyCondition, serviceName, internalError),
-				)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNatSpec}, serviceName, infrav1.InboundNATRulesReadyCondition).Return(internalError)
 			},
 		},
 	}
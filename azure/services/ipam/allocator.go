@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"net/netip"
+
+	"github.com/pkg/errors"
+)
+
+// internalLBIPOffset is the offset in the control plane subnet of the private IP allocated to an internal API server
+// load balancer, matching the default of the non-IPAM control plane subnet.
+const internalLBIPOffset = 100
+
+// parsePrefixes parses a list of address prefixes in CIDR notation. Prefixes that are not valid are ignored.
+func parsePrefixes(cidrs []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+// allocate returns the first prefix of the given length in the pools which does not overlap with any of the reserved
+// prefixes.
+func allocate(pools []netip.Prefix, bits int, reserved []netip.Prefix) (netip.Prefix, error) {
+	for _, pool := range pools {
+		if bits < pool.Bits() || bits > pool.Addr().BitLen() {
+			continue
+		}
+		candidate := netip.PrefixFrom(pool.Addr(), bits)
+		for pool.Contains(candidate.Addr()) {
+			conflict, ok := firstOverlap(candidate, reserved)
+			if !ok {
+				return candidate, nil
+			}
+			// Skip past the conflicting prefix, or to the next candidate if the conflicting prefix is smaller.
+			next, ok := nextPrefix(candidate, conflict)
+			if !ok {
+				break
+			}
+			candidate = next
+		}
+	}
+	return netip.Prefix{}, errors.Errorf("no free /%d address prefix left", bits)
+}
+
+// firstOverlap returns the first of the reserved prefixes which overlaps with the candidate.
+func firstOverlap(candidate netip.Prefix, reserved []netip.Prefix) (netip.Prefix, bool) {
+	for _, prefix := range reserved {
+		if prefix.Overlaps(candidate) {
+			return prefix, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// nextPrefix returns the first prefix with the length of the candidate after both the candidate and the conflicting
+// prefix.
+func nextPrefix(candidate, conflict netip.Prefix) (netip.Prefix, bool) {
+	last := lastAddr(candidate)
+	if conflict.Bits() < candidate.Bits() {
+		last = lastAddr(conflict)
+	}
+	next := last.Next()
+	if !next.IsValid() {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(next, candidate.Bits()), true
+}
+
+// lastAddr returns the last address of a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(addr)*8; i++ {
+		addr[i/8] |= 1 << (7 - uint(i%8))
+	}
+	last, _ := netip.AddrFromSlice(addr)
+	return last
+}
+
+// internalLBIP returns the private IP of the internal API server load balancer in the control plane subnet.
+func internalLBIP(subnet netip.Prefix) netip.Addr {
+	addr := subnet.Masked().Addr()
+	for i := 0; i < internalLBIPOffset; i++ {
+		addr = addr.Next()
+	}
+	if subnet.Contains(addr) && addr != lastAddr(subnet) {
+		return addr
+	}
+	// The subnet is too small, use its last usable address instead.
+	return lastAddr(subnet).Prev()
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"net/netip"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name          string
+		pools         []string
+		bits          int
+		reserved      []string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "allocates the first prefix of an empty pool",
+			pools:    []string{"10.0.0.0/8"},
+			bits:     16,
+			expected: "10.0.0.0/16",
+		},
+		{
+			name:     "skips reserved prefixes",
+			pools:    []string{"10.0.0.0/8"},
+			bits:     16,
+			reserved: []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expected: "10.2.0.0/16",
+		},
+		{
+			name:     "skips past a larger reserved prefix",
+			pools:    []string{"10.0.0.0/8"},
+			bits:     16,
+			reserved: []string{"10.0.0.0/14"},
+			expected: "10.4.0.0/16",
+		},
+		{
+			name:     "skips a prefix overlapping with a smaller reserved prefix",
+			pools:    []string{"10.0.0.0/8"},
+			bits:     16,
+			reserved: []string{"10.0.128.0/24", "192.168.0.0/16"},
+			expected: "10.1.0.0/16",
+		},
+		{
+			name:     "allocates from the next pool when the first one is full",
+			pools:    []string{"10.0.0.0/15", "172.16.0.0/12"},
+			bits:     16,
+			reserved: []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expected: "172.16.0.0/16",
+		},
+		{
+			name:     "ignores pools smaller than the prefix",
+			pools:    []string{"10.0.0.0/24", "172.16.0.0/12"},
+			bits:     16,
+			expected: "172.16.0.0/16",
+		},
+		{
+			name:     "allocates at the end of the address space",
+			pools:    []string{"255.255.255.0/24"},
+			bits:     25,
+			reserved: []string{"255.255.255.0/25"},
+			expected: "255.255.255.128/25",
+		},
+		{
+			name:          "fails when the pools are full",
+			pools:         []string{"10.0.0.0/15"},
+			bits:          16,
+			reserved:      []string{"10.0.0.0/16", "10.1.0.0/16"},
+			expectedError: "no free /16 address prefix left",
+		},
+		{
+			name:          "fails when the address space is exhausted",
+			pools:         []string{"255.255.255.0/24"},
+			bits:          24,
+			reserved:      []string{"255.255.255.0/24"},
+			expectedError: "no free /24 address prefix left",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			prefix, err := allocate(parsePrefixes(tc.pools), tc.bits, parsePrefixes(tc.reserved))
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(prefix.String()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestInternalLBIP(t *testing.T) {
+	tests := []struct {
+		subnet   string
+		expected string
+	}{
+		{
+			subnet:   "10.0.0.0/16",
+			expected: "10.0.0.100",
+		},
+		{
+			subnet:   "10.0.0.0/25",
+			expected: "10.0.0.100",
+		},
+		{
+			subnet:   "10.0.0.64/27",
+			expected: "10.0.0.94",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.subnet, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(internalLBIP(netip.MustParsePrefix(tc.subnet)).String()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(parsePrefixes([]string{"10.0.1.5/16", "not-a-cidr", "2001:db8::/32"})).To(Equal([]netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/16"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}))
+}
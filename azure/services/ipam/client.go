@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// Client wraps go-sdk.
+type Client interface {
+	ListVirtualNetworks(context.Context) ([]network.VirtualNetwork, error)
+}
+
+// azureClient contains the Azure go-sdk Client.
+type azureClient struct {
+	virtualnetworks network.VirtualNetworksClient
+}
+
+var _ Client = (*azureClient)(nil)
+
+// newClient creates a new virtual networks client from subscription ID.
+func newClient(auth azure.Authorizer) *azureClient {
+	c := newVirtualNetworksClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer())
+	return &azureClient{
+		virtualnetworks: c,
+	}
+}
+
+// newVirtualNetworksClient creates a new vnet client from subscription ID.
+func newVirtualNetworksClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.VirtualNetworksClient {
+	vnetsClient := network.NewVirtualNetworksClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&vnetsClient.Client, authorizer)
+	return vnetsClient
+}
+
+// ListVirtualNetworks lists all the virtual networks in the subscription.
+func (ac *azureClient) ListVirtualNetworks(ctx context.Context) ([]network.VirtualNetwork, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "ipam.azureClient.ListVirtualNetworks")
+	defer done()
+
+	var vnets []network.VirtualNetwork
+	iter, err := ac.virtualnetworks.ListAllComplete(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for iter.NotDone() {
+		vnets = append(vnets, iter.Value())
+		if err := iter.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return vnets, nil
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "ipam"
+
+// conflictRequeueAfter is the time to wait before allocating again when the pool was updated concurrently.
+const conflictRequeueAfter = 5 * time.Second
+
+// IPAMScope defines the scope interface for an IPAM service.
+type IPAMScope interface {
+	azure.Authorizer
+	GetClient() client.Client
+	ClusterName() string
+	Namespace() string
+	DryRun() bool
+	Vnet() *infrav1.VnetSpec
+	SetVnetCIDRBlocks([]string)
+	Subnets() infrav1.Subnets
+	UpdateSubnetCIDRs(string, []string)
+	AzureBastion() *infrav1.AzureBastion
+	APIServerLB() *infrav1.LoadBalancerSpec
+	SetAPIServerLB(infrav1.LoadBalancerSpec)
+}
+
+// Service allocates the address spaces of the virtual network and subnets of a cluster from an AzureIPAMPool.
+type Service struct {
+	Scope IPAMScope
+	Client
+}
+
+// New creates a new service.
+func New(scope IPAMScope) *Service {
+	return &Service{
+		Scope:  scope,
+		Client: newClient(scope),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Reconcile allocates the address space of the virtual network from the IPAM pool it references, then allocates
+// the address prefixes of the subnets without one in the virtual network.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "ipam.Service.Reconcile")
+	defer done()
+
+	vnet := s.Scope.Vnet()
+	if vnet.IPAMPoolRef == nil {
+		return nil
+	}
+	if s.Scope.DryRun() {
+		log.V(2).Info("skipping IPAM allocation in dry-run mode", "pool", vnet.IPAMPoolRef.Name)
+		return nil
+	}
+
+	pool, err := s.getPool(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.restoreAllocations(ctx, pool); err != nil {
+		return err
+	}
+	if err := s.reconcileVnet(ctx, pool); err != nil {
+		return err
+	}
+	return s.reconcileSubnets(ctx, pool)
+}
+
+// Delete releases the address prefixes allocated to the cluster by the IPAM pool.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "ipam.Service.Delete")
+	defer done()
+
+	vnet := s.Scope.Vnet()
+	if vnet.IPAMPoolRef == nil || s.Scope.DryRun() {
+		return nil
+	}
+
+	pool, err := s.getPool(ctx)
+	if apierrors.IsNotFound(errors.Cause(err)) {
+		// There is nothing to release if the pool was deleted.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	allocations := make([]infrav1.IPAMAllocation, 0, len(pool.Status.Allocations))
+	for _, allocation := range pool.Status.Allocations {
+		if !s.ownedBy(allocation) {
+			allocations = append(allocations, allocation)
+		}
+	}
+	if len(allocations) == len(pool.Status.Allocations) {
+		return nil
+	}
+
+	pool.Status.Allocations = allocations
+	if err := s.updatePoolStatus(ctx, pool); err != nil {
+		return err
+	}
+	log.V(2).Info("released IPAM allocations", "pool", pool.Name)
+	return nil
+}
+
+// restoreAllocations records the address space of the other clusters of the pool that is missing from its status, e.g.
+// because the pool was recreated or moved by clusterctl move, so that it is not allocated again. The address space of
+// this cluster is checked and recorded by reconcileVnet.
+func (s *Service) restoreAllocations(ctx context.Context, pool *infrav1.AzureIPAMPool) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "ipam.Service.restoreAllocations")
+	defer done()
+
+	allocations, err := pool.Allocations(ctx, s.Scope.GetClient())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the allocations of pool %s", pool.Name)
+	}
+
+	var restored []infrav1.IPAMAllocation
+	for _, allocation := range allocations[len(pool.Status.Allocations):] {
+		if !s.ownedBy(allocation) {
+			restored = append(restored, allocation)
+		}
+	}
+	if len(restored) == 0 {
+		return nil
+	}
+
+	pool.Status.Allocations = append(pool.Status.Allocations, restored...)
+	if err := s.updatePoolStatus(ctx, pool); err != nil {
+		return err
+	}
+	log.V(2).Info("restored IPAM allocations of other clusters", "pool", pool.Name, "allocations", len(restored))
+	return nil
+}
+
+// reconcileVnet ensures the address space of the virtual network is allocated to the cluster by the pool.
+func (s *Service) reconcileVnet(ctx context.Context, pool *infrav1.AzureIPAMPool) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "ipam.Service.reconcileVnet")
+	defer done()
+
+	vnet := s.Scope.Vnet()
+	var allocated []string
+	for _, allocation := range pool.Status.Allocations {
+		if s.ownedBy(allocation) {
+			allocated = append(allocated, allocation.CIDRBlock)
+		}
+	}
+
+	switch {
+	case len(allocated) > 0 && len(vnet.CIDRBlocks) == 0:
+		// The address space was allocated, but the cluster was not updated before the controller restarted.
+		s.Scope.SetVnetCIDRBlocks(allocated)
+		return nil
+	case len(allocated) > 0:
+		return nil
+	case len(vnet.CIDRBlocks) > 0:
+		// Adopt the address space of the virtual network so that it is not allocated to another cluster.
+		if err := s.checkAvailable(pool, vnet.CIDRBlocks); err != nil {
+			return azure.WithTerminalError(err)
+		}
+		return s.recordAllocations(ctx, pool, vnet.CIDRBlocks)
+	}
+
+	cidrBlocks, err := s.allocateVnet(ctx, pool)
+	if err != nil {
+		return err
+	}
+	if err := s.recordAllocations(ctx, pool, cidrBlocks); err != nil {
+		return err
+	}
+	log.V(2).Info("allocated virtual network address space", "pool", pool.Name, "cidrBlocks", cidrBlocks)
+	s.Scope.SetVnetCIDRBlocks(cidrBlocks)
+	return nil
+}
+
+// allocateVnet returns the address space of the virtual network. The address space of an existing virtual network
+// is reused, otherwise a prefix which overlaps neither with the other allocations of the pool nor with the virtual
+// networks of the subscription and their peerings is allocated.
+func (s *Service) allocateVnet(ctx context.Context, pool *infrav1.AzureIPAMPool) ([]string, error) {
+	vnets, err := s.ListVirtualNetworks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list virtual networks")
+	}
+
+	vnet := s.Scope.Vnet()
+	reserved := parsePrefixes(allocatedCIDRBlocks(pool))
+	for _, existing := range vnets {
+		if existing.VirtualNetworkPropertiesFormat != nil && strings.EqualFold(pointer.StringDeref(existing.Name, ""), vnet.Name) &&
+			strings.EqualFold(resourceGroupOf(existing), vnet.ResourceGroup) {
+			if prefixes := addressPrefixes(existing.AddressSpace); len(prefixes) > 0 {
+				return prefixes, nil
+			}
+		}
+		reserved = append(reserved, parsePrefixes(vnetAddressPrefixes(existing))...)
+	}
+
+	prefix, err := allocate(parsePrefixes(pool.Spec.CIDRBlocks), pool.GetVnetPrefixLength(), reserved)
+	if err != nil {
+		return nil, azure.WithTerminalError(errors.Wrapf(err, "failed to allocate a virtual network address space from pool %s", pool.Name))
+	}
+	return []string{prefix.String()}, nil
+}
+
+// reconcileSubnets allocates the address prefixes of the subnets without one, control plane subnets first, and sets
+// the private IP of an internal API server load balancer.
+func (s *Service) reconcileSubnets(ctx context.Context, pool *infrav1.AzureIPAMPool) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "ipam.Service.reconcileSubnets")
+	defer done()
+
+	vnetPrefixes := parsePrefixes(s.Scope.Vnet().CIDRBlocks)
+	subnets := s.Scope.Subnets()
+	bastion := s.Scope.AzureBastion()
+
+	var reserved []netip.Prefix
+	for _, subnet := range subnets {
+		reserved = append(reserved, parsePrefixes(subnet.CIDRBlocks)...)
+	}
+	if bastion != nil {
+		reserved = append(reserved, parsePrefixes(bastion.Subnet.CIDRBlocks)...)
+	}
+
+	allocateSubnet := func(name string, bits int) ([]string, error) {
+		prefix, err := allocate(vnetPrefixes, bits, reserved)
+		if err != nil {
+			return nil, azure.WithTerminalError(errors.Wrapf(err, "failed to allocate an address prefix to subnet %s", name))
+		}
+		reserved = append(reserved, prefix)
+		log.V(2).Info("allocated subnet address prefix", "subnet", name, "cidrBlock", prefix.String())
+		return []string{prefix.String()}, nil
+	}
+
+	for _, role := range []infrav1.SubnetRole{infrav1.SubnetControlPlane, infrav1.SubnetNode} {
+		for _, subnet := range subnets {
+			if subnet.Role != role || len(subnet.CIDRBlocks) > 0 {
+				continue
+			}
+			cidrBlocks, err := allocateSubnet(subnet.Name, pool.GetSubnetPrefixLength())
+			if err != nil {
+				return err
+			}
+			s.Scope.UpdateSubnetCIDRs(subnet.Name, cidrBlocks)
+		}
+	}
+
+	if bastion != nil && len(bastion.Subnet.CIDRBlocks) == 0 {
+		cidrBlocks, err := allocateSubnet(bastion.Subnet.Name, infrav1.IPAMBastionSubnetPrefixLength)
+		if err != nil {
+			return err
+		}
+		bastion.Subnet.CIDRBlocks = cidrBlocks
+	}
+
+	s.setInternalLBIP()
+	return nil
+}
+
+// setInternalLBIP sets the private IP of an internal API server load balancer without one in the control plane subnet.
+func (s *Service) setInternalLBIP() {
+	lb := s.Scope.APIServerLB()
+	if lb.Type != infrav1.Internal {
+		return
+	}
+	for _, subnet := range s.Scope.Subnets() {
+		if subnet.Role != infrav1.SubnetControlPlane {
+			continue
+		}
+		prefixes := parsePrefixes(subnet.CIDRBlocks)
+		if len(prefixes) == 0 {
+			return
+		}
+		for i := range lb.FrontendIPs {
+			if lb.FrontendIPs[i].PrivateIPAddress == "" {
+				lb.FrontendIPs[i].PrivateIPAddress = internalLBIP(prefixes[0]).String()
+			}
+		}
+		s.Scope.SetAPIServerLB(*lb)
+		return
+	}
+}
+
+// checkAvailable returns an error if the CIDR blocks are not in the pool or are allocated to another cluster.
+func (s *Service) checkAvailable(pool *infrav1.AzureIPAMPool, cidrBlocks []string) error {
+	pools := parsePrefixes(pool.Spec.CIDRBlocks)
+	reserved := parsePrefixes(allocatedCIDRBlocks(pool))
+	for _, prefix := range parsePrefixes(cidrBlocks) {
+		inPool := false
+		for _, p := range pools {
+			if p.Bits() <= prefix.Bits() && p.Contains(prefix.Addr()) {
+				inPool = true
+				break
+			}
+		}
+		if !inPool {
+			return errors.Errorf("address space %s of the virtual network is not in pool %s", prefix, pool.Name)
+		}
+		if conflict, ok := firstOverlap(prefix, reserved); ok {
+			return errors.Errorf("address space %s of the virtual network overlaps with %s allocated by pool %s", prefix, conflict, pool.Name)
+		}
+	}
+	return nil
+}
+
+// recordAllocations records the allocation of the CIDR blocks to the cluster in the status of the pool.
+func (s *Service) recordAllocations(ctx context.Context, pool *infrav1.AzureIPAMPool, cidrBlocks []string) error {
+	for _, cidrBlock := range cidrBlocks {
+		pool.Status.Allocations = append(pool.Status.Allocations, infrav1.IPAMAllocation{
+			ClusterName:      s.Scope.ClusterName(),
+			ClusterNamespace: s.Scope.Namespace(),
+			CIDRBlock:        cidrBlock,
+		})
+	}
+	return s.updatePoolStatus(ctx, pool)
+}
+
+// updatePoolStatus updates the status of the pool. The update fails if the pool was updated since it was read, so
+// that concurrent allocations never overlap.
+func (s *Service) updatePoolStatus(ctx context.Context, pool *infrav1.AzureIPAMPool) error {
+	if err := s.Scope.GetClient().Status().Update(ctx, pool); err != nil {
+		if apierrors.IsConflict(err) {
+			return azure.WithTransientError(errors.Wrapf(err, "pool %s was updated concurrently", pool.Name), conflictRequeueAfter)
+		}
+		return errors.Wrapf(err, "failed to update the status of pool %s", pool.Name)
+	}
+	return nil
+}
+
+// getPool returns the IPAM pool referenced by the virtual network.
+func (s *Service) getPool(ctx context.Context) (*infrav1.AzureIPAMPool, error) {
+	ref := s.Scope.Vnet().IPAMPoolRef
+	key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if key.Namespace == "" {
+		key.Namespace = s.Scope.Namespace()
+	}
+
+	pool := &infrav1.AzureIPAMPool{}
+	if err := s.Scope.GetClient().Get(ctx, key, pool); err != nil {
+		return nil, errors.Wrapf(err, "failed to get AzureIPAMPool %s", key)
+	}
+	return pool, nil
+}
+
+// ownedBy returns whether an allocation belongs to the cluster.
+func (s *Service) ownedBy(allocation infrav1.IPAMAllocation) bool {
+	return allocation.ClusterName == s.Scope.ClusterName() && allocation.ClusterNamespace == s.Scope.Namespace()
+}
+
+// allocatedCIDRBlocks returns the CIDR blocks allocated by a pool.
+func allocatedCIDRBlocks(pool *infrav1.AzureIPAMPool) []string {
+	cidrBlocks := make([]string, 0, len(pool.Status.Allocations))
+	for _, allocation := range pool.Status.Allocations {
+		cidrBlocks = append(cidrBlocks, allocation.CIDRBlock)
+	}
+	return cidrBlocks
+}
+
+// vnetAddressPrefixes returns the address prefixes of a virtual network and of the virtual networks peered with it.
+func vnetAddressPrefixes(vnet network.VirtualNetwork) []string {
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		return nil
+	}
+	prefixes := addressPrefixes(vnet.AddressSpace)
+	if vnet.VirtualNetworkPeerings != nil {
+		for _, peering := range *vnet.VirtualNetworkPeerings {
+			if peering.VirtualNetworkPeeringPropertiesFormat != nil {
+				prefixes = append(prefixes, addressPrefixes(peering.RemoteAddressSpace)...)
+			}
+		}
+	}
+	return prefixes
+}
+
+// addressPrefixes returns the address prefixes of an address space.
+func addressPrefixes(space *network.AddressSpace) []string {
+	if space == nil {
+		return nil
+	}
+	return azure.StringSlice(space.AddressPrefixes)
+}
+
+// resourceGroupOf returns the resource group of a virtual network.
+func resourceGroupOf(vnet network.VirtualNetwork) string {
+	parsed, err := azureautorest.ParseResourceID(pointer.StringDeref(vnet.ID, ""))
+	if err != nil {
+		return ""
+	}
+	return parsed.ResourceGroup
+}
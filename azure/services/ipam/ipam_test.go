@@ -0,0 +1,346 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/ipam/mock_ipam"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	fakeClusterName = "my-cluster"
+	fakeNamespace   = "default"
+)
+
+func fakePool(allocations ...infrav1.IPAMAllocation) *infrav1.AzureIPAMPool {
+	return &infrav1.AzureIPAMPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-pool", Namespace: fakeNamespace},
+		Spec: infrav1.AzureIPAMPoolSpec{
+			CIDRBlocks:         []string{"10.0.0.0/8"},
+			VnetPrefixLength:   16,
+			SubnetPrefixLength: 24,
+		},
+		Status: infrav1.AzureIPAMPoolStatus{Allocations: allocations},
+	}
+}
+
+func fakeAzureCluster(name string, poolRef *corev1.ObjectReference, cidrBlocks ...string) *infrav1.AzureCluster {
+	return &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: fakeNamespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: name},
+		},
+		Spec: infrav1.AzureClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				Vnet: infrav1.VnetSpec{IPAMPoolRef: poolRef, VnetClassSpec: infrav1.VnetClassSpec{CIDRBlocks: cidrBlocks}},
+			},
+		},
+	}
+}
+
+func fakeVnet(name, id string, prefixes ...string) network.VirtualNetwork {
+	return network.VirtualNetwork{
+		Name: pointer.String(name),
+		ID:   pointer.String(id),
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			AddressSpace: &network.AddressSpace{AddressPrefixes: &prefixes},
+		},
+	}
+}
+
+// fakeScope holds the parts of the cluster spec the IPAM service reads and updates.
+type fakeScope struct {
+	vnet    *infrav1.VnetSpec
+	subnets infrav1.Subnets
+	bastion *infrav1.AzureBastion
+	lb      *infrav1.LoadBalancerSpec
+}
+
+func newFakeScope() *fakeScope {
+	return &fakeScope{
+		vnet: &infrav1.VnetSpec{
+			ResourceGroup: "my-rg",
+			Name:          "my-vnet",
+			IPAMPoolRef:   &corev1.ObjectReference{Name: "my-pool"},
+		},
+		subnets: infrav1.Subnets{
+			{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet", Role: infrav1.SubnetNode}},
+			{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane}},
+		},
+		lb: &infrav1.LoadBalancerSpec{
+			FrontendIPs: []infrav1.FrontendIP{{Name: "ip-config"}},
+			LoadBalancerClassSpec: infrav1.LoadBalancerClassSpec{
+				Type: infrav1.Internal,
+			},
+		},
+	}
+}
+
+func (f *fakeScope) expect(s *mock_ipam.MockIPAMScopeMockRecorder, c client.Client) {
+	s.GetClient().Return(c).AnyTimes()
+	s.ClusterName().Return(fakeClusterName).AnyTimes()
+	s.Namespace().Return(fakeNamespace).AnyTimes()
+	s.DryRun().Return(false).AnyTimes()
+	s.Vnet().DoAndReturn(f.vnet.DeepCopy).AnyTimes()
+	s.SetVnetCIDRBlocks(gomock.Any()).Do(func(cidrBlocks []string) {
+		f.vnet.CIDRBlocks = cidrBlocks
+	}).AnyTimes()
+	s.Subnets().DoAndReturn(func() infrav1.Subnets {
+		return append(infrav1.Subnets{}, f.subnets...)
+	}).AnyTimes()
+	s.UpdateSubnetCIDRs(gomock.Any(), gomock.Any()).Do(func(name string, cidrBlocks []string) {
+		for i := range f.subnets {
+			if f.subnets[i].Name == name {
+				f.subnets[i].CIDRBlocks = cidrBlocks
+			}
+		}
+	}).AnyTimes()
+	s.AzureBastion().Return(f.bastion).AnyTimes()
+	s.APIServerLB().DoAndReturn(f.lb.DeepCopy).AnyTimes()
+	s.SetAPIServerLB(gomock.Any()).Do(func(lb infrav1.LoadBalancerSpec) {
+		*f.lb = lb
+	}).AnyTimes()
+}
+
+func TestReconcileIPAM(t *testing.T) {
+	ownAllocation := infrav1.IPAMAllocation{ClusterName: fakeClusterName, ClusterNamespace: fakeNamespace, CIDRBlock: "10.5.0.0/16"}
+	otherAllocation := infrav1.IPAMAllocation{ClusterName: "other-cluster", ClusterNamespace: fakeNamespace, CIDRBlock: "10.0.0.0/16"}
+
+	testcases := []struct {
+		name                string
+		pool                *infrav1.AzureIPAMPool
+		objects             []client.Object
+		setup               func(f *fakeScope)
+		expectClient        func(c *mock_ipam.MockClientMockRecorder)
+		expectedVnetCIDRs   []string
+		expectedSubnetCIDRs map[string][]string
+		expectedLBIP        string
+		expectedAllocations []infrav1.IPAMAllocation
+		expectedError       string
+	}{
+		{
+			name: "allocates a virtual network around the existing networks and their peerings",
+			pool: fakePool(otherAllocation),
+			expectClient: func(c *mock_ipam.MockClientMockRecorder) {
+				peered := fakeVnet("hub-vnet", "/subscriptions/123/resourceGroups/hub-rg/providers/Microsoft.Network/virtualNetworks/hub-vnet", "10.1.0.0/16")
+				peered.VirtualNetworkPeerings = &[]network.VirtualNetworkPeering{{
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+						RemoteAddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.2.0.0/16"}},
+					},
+				}}
+				c.ListVirtualNetworks(gomockinternal.AContext()).Return([]network.VirtualNetwork{peered}, nil)
+			},
+			expectedVnetCIDRs: []string{"10.3.0.0/16"},
+			expectedSubnetCIDRs: map[string][]string{
+				"cp-subnet":   {"10.3.0.0/24"},
+				"node-subnet": {"10.3.1.0/24"},
+			},
+			expectedLBIP: "10.3.0.100",
+			expectedAllocations: []infrav1.IPAMAllocation{
+				otherAllocation,
+				{ClusterName: fakeClusterName, ClusterNamespace: fakeNamespace, CIDRBlock: "10.3.0.0/16"},
+			},
+		},
+		{
+			name: "restores the allocations of the other clusters of the pool missing from its status",
+			pool: fakePool(),
+			objects: []client.Object{
+				fakeAzureCluster("other-cluster", &corev1.ObjectReference{Name: "my-pool"}, "10.0.0.0/16"),
+				fakeAzureCluster("other-pool-cluster", &corev1.ObjectReference{Name: "other-pool"}, "10.1.0.0/16"),
+				fakeAzureCluster("unmanaged-cluster", nil, "10.2.0.0/16"),
+			},
+			expectClient: func(c *mock_ipam.MockClientMockRecorder) {
+				c.ListVirtualNetworks(gomockinternal.AContext()).Return(nil, nil)
+			},
+			expectedVnetCIDRs: []string{"10.1.0.0/16"},
+			expectedSubnetCIDRs: map[string][]string{
+				"cp-subnet":   {"10.1.0.0/24"},
+				"node-subnet": {"10.1.1.0/24"},
+			},
+			expectedLBIP: "10.1.0.100",
+			expectedAllocations: []infrav1.IPAMAllocation{
+				otherAllocation,
+				{ClusterName: fakeClusterName, ClusterNamespace: fakeNamespace, CIDRBlock: "10.1.0.0/16"},
+			},
+		},
+		{
+			name: "reuses the address space of the existing virtual network of the cluster",
+			pool: fakePool(),
+			expectClient: func(c *mock_ipam.MockClientMockRecorder) {
+				c.ListVirtualNetworks(gomockinternal.AContext()).Return([]network.VirtualNetwork{
+					fakeVnet("my-vnet", "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet", "10.9.0.0/16"),
+				}, nil)
+			},
+			expectedVnetCIDRs: []string{"10.9.0.0/16"},
+			expectedSubnetCIDRs: map[string][]string{
+				"cp-subnet":   {"10.9.0.0/24"},
+				"node-subnet": {"10.9.1.0/24"},
+			},
+			expectedLBIP: "10.9.0.100",
+			expectedAllocations: []infrav1.IPAMAllocation{
+				{ClusterName: fakeClusterName, ClusterNamespace: fakeNamespace, CIDRBlock: "10.9.0.0/16"},
+			},
+		},
+		{
+			name: "reuses the allocation recorded in the pool",
+			pool: fakePool(otherAllocation, ownAllocation),
+			setup: func(f *fakeScope) {
+				f.subnets[1].CIDRBlocks = []string{"10.5.0.0/24"}
+				f.bastion = &infrav1.AzureBastion{Subnet: infrav1.SubnetSpec{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "AzureBastionSubnet"}}}
+				f.lb.FrontendIPs[0].PrivateIPAddress = "10.5.0.4"
+			},
+			expectedVnetCIDRs: []string{"10.5.0.0/16"},
+			expectedSubnetCIDRs: map[string][]string{
+				"cp-subnet":          {"10.5.0.0/24"},
+				"node-subnet":        {"10.5.1.0/24"},
+				"AzureBastionSubnet": {"10.5.2.0/27"},
+			},
+			expectedLBIP:        "10.5.0.4",
+			expectedAllocations: []infrav1.IPAMAllocation{otherAllocation, ownAllocation},
+		},
+		{
+			name: "records the address space of the virtual network in the pool",
+			pool: fakePool(otherAllocation),
+			setup: func(f *fakeScope) {
+				f.vnet.CIDRBlocks = []string{"10.7.0.0/16"}
+			},
+			expectedVnetCIDRs: []string{"10.7.0.0/16"},
+			expectedSubnetCIDRs: map[string][]string{
+				"cp-subnet":   {"10.7.0.0/24"},
+				"node-subnet": {"10.7.1.0/24"},
+			},
+			expectedLBIP: "10.7.0.100",
+			expectedAllocations: []infrav1.IPAMAllocation{
+				otherAllocation,
+				{ClusterName: fakeClusterName, ClusterNamespace: fakeNamespace, CIDRBlock: "10.7.0.0/16"},
+			},
+		},
+		{
+			name: "fails when the address space of the virtual network is allocated to another cluster",
+			pool: fakePool(otherAllocation),
+			setup: func(f *fakeScope) {
+				f.vnet.CIDRBlocks = []string{"10.0.0.0/12"}
+			},
+			expectedVnetCIDRs:   []string{"10.0.0.0/12"},
+			expectedAllocations: []infrav1.IPAMAllocation{otherAllocation},
+			expectedError:       "address space 10.0.0.0/12 of the virtual network overlaps with 10.0.0.0/16 allocated by pool my-pool",
+		},
+		{
+			name: "fails when the pool is full",
+			pool: func() *infrav1.AzureIPAMPool {
+				pool := fakePool(otherAllocation)
+				pool.Spec.CIDRBlocks = []string{"10.0.0.0/16"}
+				return pool
+			}(),
+			expectClient: func(c *mock_ipam.MockClientMockRecorder) {
+				c.ListVirtualNetworks(gomockinternal.AContext()).Return(nil, nil)
+			},
+			expectedAllocations: []infrav1.IPAMAllocation{otherAllocation},
+			expectedError:       "failed to allocate a virtual network address space from pool my-pool: no free /16 address prefix left",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tc.objects, tc.pool)...).Build()
+
+			f := newFakeScope()
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+			scopeMock := mock_ipam.NewMockIPAMScope(mockCtrl)
+			clientMock := mock_ipam.NewMockClient(mockCtrl)
+			f.expect(scopeMock.EXPECT(), c)
+			if tc.expectClient != nil {
+				tc.expectClient(clientMock.EXPECT())
+			}
+
+			s := &Service{
+				Scope:  scopeMock,
+				Client: clientMock,
+			}
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(f.vnet.CIDRBlocks).To(Equal(tc.expectedVnetCIDRs))
+			for _, subnet := range f.subnets {
+				g.Expect(subnet.CIDRBlocks).To(Equal(tc.expectedSubnetCIDRs[subnet.Name]), "subnet %s", subnet.Name)
+			}
+			if f.bastion != nil {
+				g.Expect(f.bastion.Subnet.CIDRBlocks).To(Equal(tc.expectedSubnetCIDRs[f.bastion.Subnet.Name]))
+			}
+			g.Expect(f.lb.FrontendIPs[0].PrivateIPAddress).To(Equal(tc.expectedLBIP))
+
+			pool := &infrav1.AzureIPAMPool{}
+			g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(tc.pool), pool)).To(Succeed())
+			g.Expect(pool.Status.Allocations).To(Equal(tc.expectedAllocations))
+		})
+	}
+}
+
+func TestDeleteIPAM(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	otherAllocation := infrav1.IPAMAllocation{ClusterName: "other-cluster", ClusterNamespace: fakeNamespace, CIDRBlock: "10.0.0.0/16"}
+	pool := fakePool(otherAllocation, infrav1.IPAMAllocation{ClusterName: fakeClusterName, ClusterNamespace: fakeNamespace, CIDRBlock: "10.1.0.0/16"})
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool).Build()
+
+	scopeMock := mock_ipam.NewMockIPAMScope(mockCtrl)
+	newFakeScope().expect(scopeMock.EXPECT(), c)
+
+	s := &Service{Scope: scopeMock}
+	g.Expect(s.Delete(context.TODO())).To(Succeed())
+
+	updated := &infrav1.AzureIPAMPool{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(pool), updated)).To(Succeed())
+	g.Expect(updated.Status.Allocations).To(Equal([]infrav1.IPAMAllocation{otherAllocation}))
+
+	// Releasing the allocations again, or from a deleted pool, is a no-op.
+	g.Expect(s.Delete(context.TODO())).To(Succeed())
+	g.Expect(c.Delete(context.TODO(), updated)).To(Succeed())
+	g.Expect(s.Delete(context.TODO())).To(Succeed())
+}
@@ -0,0 +1,313 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../ipam.go
+
+// Package mock_ipam is a generated GoMock package.
+package mock_ipam
+
+import (
+	reflect "reflect"
+
+	autorest "github.com/Azure/go-autorest/autorest"
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MockIPAMScope is a mock of IPAMScope interface.
+type MockIPAMScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPAMScopeMockRecorder
+}
+
+// MockIPAMScopeMockRecorder is the mock recorder for MockIPAMScope.
+type MockIPAMScopeMockRecorder struct {
+	mock *MockIPAMScope
+}
+
+// NewMockIPAMScope creates a new mock instance.
+func NewMockIPAMScope(ctrl *gomock.Controller) *MockIPAMScope {
+	mock := &MockIPAMScope{ctrl: ctrl}
+	mock.recorder = &MockIPAMScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPAMScope) EXPECT() *MockIPAMScopeMockRecorder {
+	return m.recorder
+}
+
+// APIServerLB mocks base method.
+func (m *MockIPAMScope) APIServerLB() *v1beta1.LoadBalancerSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIServerLB")
+	ret0, _ := ret[0].(*v1beta1.LoadBalancerSpec)
+	return ret0
+}
+
+// APIServerLB indicates an expected call of APIServerLB.
+func (mr *MockIPAMScopeMockRecorder) APIServerLB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIServerLB", reflect.TypeOf((*MockIPAMScope)(nil).APIServerLB))
+}
+
+// Authorizer mocks base method.
+func (m *MockIPAMScope) Authorizer() autorest.Authorizer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizer")
+	ret0, _ := ret[0].(autorest.Authorizer)
+	return ret0
+}
+
+// Authorizer indicates an expected call of Authorizer.
+func (mr *MockIPAMScopeMockRecorder) Authorizer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizer", reflect.TypeOf((*MockIPAMScope)(nil).Authorizer))
+}
+
+// AzureBastion mocks base method.
+func (m *MockIPAMScope) AzureBastion() *v1beta1.AzureBastion {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AzureBastion")
+	ret0, _ := ret[0].(*v1beta1.AzureBastion)
+	return ret0
+}
+
+// AzureBastion indicates an expected call of AzureBastion.
+func (mr *MockIPAMScopeMockRecorder) AzureBastion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AzureBastion", reflect.TypeOf((*MockIPAMScope)(nil).AzureBastion))
+}
+
+// BaseURI mocks base method.
+func (m *MockIPAMScope) BaseURI() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseURI")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseURI indicates an expected call of BaseURI.
+func (mr *MockIPAMScopeMockRecorder) BaseURI() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockIPAMScope)(nil).BaseURI))
+}
+
+// ClientID mocks base method.
+func (m *MockIPAMScope) ClientID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientID indicates an expected call of ClientID.
+func (mr *MockIPAMScopeMockRecorder) ClientID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientID", reflect.TypeOf((*MockIPAMScope)(nil).ClientID))
+}
+
+// ClientSecret mocks base method.
+func (m *MockIPAMScope) ClientSecret() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientSecret")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientSecret indicates an expected call of ClientSecret.
+func (mr *MockIPAMScopeMockRecorder) ClientSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientSecret", reflect.TypeOf((*MockIPAMScope)(nil).ClientSecret))
+}
+
+// CloudEnvironment mocks base method.
+func (m *MockIPAMScope) CloudEnvironment() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloudEnvironment")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CloudEnvironment indicates an expected call of CloudEnvironment.
+func (mr *MockIPAMScopeMockRecorder) CloudEnvironment() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloudEnvironment", reflect.TypeOf((*MockIPAMScope)(nil).CloudEnvironment))
+}
+
+// ClusterName mocks base method.
+func (m *MockIPAMScope) ClusterName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClusterName indicates an expected call of ClusterName.
+func (mr *MockIPAMScopeMockRecorder) ClusterName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterName", reflect.TypeOf((*MockIPAMScope)(nil).ClusterName))
+}
+
+// DryRun mocks base method.
+func (m *MockIPAMScope) DryRun() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DryRun indicates an expected call of DryRun.
+func (mr *MockIPAMScopeMockRecorder) DryRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockIPAMScope)(nil).DryRun))
+}
+
+// GetClient mocks base method.
+func (m *MockIPAMScope) GetClient() client.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClient")
+	ret0, _ := ret[0].(client.Client)
+	return ret0
+}
+
+// GetClient indicates an expected call of GetClient.
+func (mr *MockIPAMScopeMockRecorder) GetClient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClient", reflect.TypeOf((*MockIPAMScope)(nil).GetClient))
+}
+
+// HashKey mocks base method.
+func (m *MockIPAMScope) HashKey() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashKey")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashKey indicates an expected call of HashKey.
+func (mr *MockIPAMScopeMockRecorder) HashKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashKey", reflect.TypeOf((*MockIPAMScope)(nil).HashKey))
+}
+
+// Namespace mocks base method.
+func (m *MockIPAMScope) Namespace() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Namespace")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Namespace indicates an expected call of Namespace.
+func (mr *MockIPAMScopeMockRecorder) Namespace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Namespace", reflect.TypeOf((*MockIPAMScope)(nil).Namespace))
+}
+
+// SetAPIServerLB mocks base method.
+func (m *MockIPAMScope) SetAPIServerLB(arg0 v1beta1.LoadBalancerSpec) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAPIServerLB", arg0)
+}
+
+// SetAPIServerLB indicates an expected call of SetAPIServerLB.
+func (mr *MockIPAMScopeMockRecorder) SetAPIServerLB(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIServerLB", reflect.TypeOf((*MockIPAMScope)(nil).SetAPIServerLB), arg0)
+}
+
+// SetVnetCIDRBlocks mocks base method.
+func (m *MockIPAMScope) SetVnetCIDRBlocks(arg0 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVnetCIDRBlocks", arg0)
+}
+
+// SetVnetCIDRBlocks indicates an expected call of SetVnetCIDRBlocks.
+func (mr *MockIPAMScopeMockRecorder) SetVnetCIDRBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVnetCIDRBlocks", reflect.TypeOf((*MockIPAMScope)(nil).SetVnetCIDRBlocks), arg0)
+}
+
+// Subnets mocks base method.
+func (m *MockIPAMScope) Subnets() v1beta1.Subnets {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subnets")
+	ret0, _ := ret[0].(v1beta1.Subnets)
+	return ret0
+}
+
+// Subnets indicates an expected call of Subnets.
+func (mr *MockIPAMScopeMockRecorder) Subnets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subnets", reflect.TypeOf((*MockIPAMScope)(nil).Subnets))
+}
+
+// SubscriptionID mocks base method.
+func (m *MockIPAMScope) SubscriptionID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscriptionID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SubscriptionID indicates an expected call of SubscriptionID.
+func (mr *MockIPAMScopeMockRecorder) SubscriptionID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscriptionID", reflect.TypeOf((*MockIPAMScope)(nil).SubscriptionID))
+}
+
+// TenantID mocks base method.
+func (m *MockIPAMScope) TenantID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TenantID indicates an expected call of TenantID.
+func (mr *MockIPAMScopeMockRecorder) TenantID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantID", reflect.TypeOf((*MockIPAMScope)(nil).TenantID))
+}
+
+// UpdateSubnetCIDRs mocks base method.
+func (m *MockIPAMScope) UpdateSubnetCIDRs(arg0 string, arg1 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateSubnetCIDRs", arg0, arg1)
+}
+
+// UpdateSubnetCIDRs indicates an expected call of UpdateSubnetCIDRs.
+func (mr *MockIPAMScopeMockRecorder) UpdateSubnetCIDRs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnetCIDRs", reflect.TypeOf((*MockIPAMScope)(nil).UpdateSubnetCIDRs), arg0, arg1)
+}
+
+// Vnet mocks base method.
+func (m *MockIPAMScope) Vnet() *v1beta1.VnetSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vnet")
+	ret0, _ := ret[0].(*v1beta1.VnetSpec)
+	return ret0
+}
+
+// Vnet indicates an expected call of Vnet.
+func (mr *MockIPAMScopeMockRecorder) Vnet() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockIPAMScope)(nil).Vnet))
+}
@@ -22,15 +22,22 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
+// ServiceName is the name of this service.
+const ServiceName = "loadbalancers"
+
 const (
-	serviceName = "loadbalancers"
-	tcpProbe    = "TCPProbe"
-	lbRuleHTTPS = "LBRuleHTTPS"
-	outboundNAT = "OutboundNATAllProtocols"
+	tcpProbe        = "TCPProbe"
+	httpProbe       = "HTTPProbe"
+	httpsProbe      = "HTTPSProbe"
+	lbRuleHTTPS     = "LBRuleHTTPS"
+	outboundNAT     = "OutboundNATAllProtocols"
+	outboundNATIPv6 = "OutboundNATAllProtocolsIPv6"
 )
 
 // LBScope defines the scope interface for a load balancer service.
@@ -57,7 +64,15 @@ func New(scope LBScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		publicips.ServiceName,
+		subnets.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a load balancer.
@@ -73,20 +88,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of LBSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, lbSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, lbSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
-	return result
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.LoadBalancersReadyCondition)
+	return err
 }
 
 // Delete deletes the public load balancer with the provided name.
@@ -102,20 +105,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of LBSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, lbSpec := range specs {
-		if err := s.DeleteResource(ctx, lbSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.LoadBalancersReadyCondition)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO load balancers.
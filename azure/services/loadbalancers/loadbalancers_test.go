@@ -122,8 +122,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, internalError)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicAPILBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return([]interface{}{nil}, internalError)
 			},
 		},
 		{
@@ -131,8 +130,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicAPILBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return([]interface{}{nil}, nil)
 			},
 		},
 		{
@@ -140,8 +138,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeInternalAPILBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeInternalAPILBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return([]interface{}{nil}, nil)
 			},
 		},
 		{
@@ -149,8 +146,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakeNodeOutboundLBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNodeOutboundLBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return([]interface{}{nil}, nil)
 			},
 		},
 		{
@@ -158,10 +154,7 @@ func TestReconcileLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return([]interface{}{nil, nil, nil}, nil)
 			},
 		},
 	}
@@ -213,8 +206,7 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicAPILBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -222,10 +214,7 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeInternalAPILBSpec, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNodeOutboundLBSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicAPILBSpec, &fakeInternalAPILBSpec, &fakeNodeOutboundLBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -233,8 +222,7 @@ func TestDeleteLoadBalancer(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_loadbalancers.MockLBScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.LBSpecs().Return([]azure.ResourceSpecGetter{&fakePublicAPILBSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakePublicAPILBSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.LoadBalancersReadyCondition, serviceName, internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicAPILBSpec}, ServiceName, infrav1.LoadBalancersReadyCondition).Return(internalError)
 			},
 		},
 	}
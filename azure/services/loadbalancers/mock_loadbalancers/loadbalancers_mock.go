@@ -249,6 +249,21 @@ func (mr *MockLBScopeMockRecorder) ControlPlaneSubnet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlPlaneSubnet", reflect.TypeOf((*MockLBScope)(nil).ControlPlaneSubnet))
 }
 
+// DedicatedHostGroupZone mocks base method.
+func (m *MockLBScope) DedicatedHostGroupZone(hostGroupID string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DedicatedHostGroupZone", hostGroupID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// DedicatedHostGroupZone indicates an expected call of DedicatedHostGroupZone.
+func (mr *MockLBScopeMockRecorder) DedicatedHostGroupZone(hostGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedHostGroupZone", reflect.TypeOf((*MockLBScope)(nil).DedicatedHostGroupZone), hostGroupID)
+}
+
 // DeleteLongRunningOperationState mocks base method.
 func (m *MockLBScope) DeleteLongRunningOperationState(arg0, arg1, arg2 string) {
 	m.ctrl.T.Helper()
@@ -345,6 +360,20 @@ func (mr *MockLBScopeMockRecorder) IsIPv6Enabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Enabled", reflect.TypeOf((*MockLBScope)(nil).IsIPv6Enabled))
 }
 
+// IsIPv6Only mocks base method.
+func (m *MockLBScope) IsIPv6Only() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIPv6Only")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIPv6Only indicates an expected call of IsIPv6Only.
+func (mr *MockLBScopeMockRecorder) IsIPv6Only() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Only", reflect.TypeOf((*MockLBScope)(nil).IsIPv6Only))
+}
+
 // IsVnetManaged mocks base method.
 func (m *MockLBScope) IsVnetManaged() bool {
 	m.ctrl.T.Helper()
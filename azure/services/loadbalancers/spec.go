@@ -18,6 +18,7 @@ package loadbalancers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
@@ -45,6 +46,18 @@ type LBSpec struct {
 	APIServerPort        int32
 	IdleTimeoutInMinutes *int32
 	AdditionalTags       map[string]string
+	// DisableOutboundRule disables the outbound rule of a public load balancer, which then provides no egress to
+	// its backend pool.
+	DisableOutboundRule bool
+	// Probe is the health probe of the API server. The API server port is probed with TCP when it is nil.
+	Probe *infrav1.LoadBalancerProbe
+	// AdditionalRules are the load balancing rules of the API server load balancer besides the API server rule.
+	AdditionalRules []infrav1.LoadBalancingRule
+	// IPv6Enabled adds an IPv6 frontend, backend pool and outbound rule to a public load balancer, which then
+	// provides IPv6 egress to the IPv6 IP configurations of its backends.
+	IPv6Enabled bool
+	// IPv6Only removes the IPv4 outbound rule of a public load balancer, which then only provides IPv6 egress.
+	IPv6Only bool
 }
 
 // ResourceName returns the name of the load balancer.
@@ -87,6 +100,7 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 		// merge existing LB properties with desired properties
 		frontendIPConfigs = *existingLB.FrontendIPConfigurations
 		wantedIPs, wantedFrontendIDs := getFrontendIPConfigs(*s)
+		wantedIPs = append(wantedIPs, getIPv6FrontendIPConfigs(*s)...)
 		for _, ip := range wantedIPs {
 			if !ipExists(frontendIPConfigs, ip) {
 				update = true
@@ -94,13 +108,10 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			}
 		}
 
-		loadBalancingRules = *existingLB.LoadBalancingRules
-		for _, rule := range getLoadBalancingRules(*s, wantedFrontendIDs) {
-			if !lbRuleExists(loadBalancingRules, rule) {
-				update = true
-				loadBalancingRules = append(loadBalancingRules, rule)
-			}
-		}
+		// The rules and probes which are not managed by CAPZ are preserved.
+		var updated bool
+		loadBalancingRules, updated = mergeLBRules(*existingLB.LoadBalancingRules, getLoadBalancingRules(*s, wantedFrontendIDs))
+		update = update || updated
 
 		backendAddressPools = *existingLB.BackendAddressPools
 		for _, pool := range getBackendAddressPools(*s) {
@@ -118,13 +129,9 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 			}
 		}
 
-		probes = *existingLB.Probes
-		for _, probe := range getProbes(*s) {
-			if !probeExists(probes, probe) {
-				update = true
-				probes = append(probes, probe)
-			}
-		}
+		// The default TCP probe is left as is once it exists, a configured probe is kept up to date.
+		probes, updated = mergeProbes(*existingLB.Probes, getProbes(*s), s.Probe != nil)
+		update = update || updated
 
 		if !update {
 			// load balancer already exists with all required defaults
@@ -132,6 +139,7 @@ func (s *LBSpec) Parameters(ctx context.Context, existing interface{}) (paramete
 		}
 	} else {
 		frontendIPConfigs, frontendIDs = getFrontendIPConfigs(*s)
+		frontendIPConfigs = append(frontendIPConfigs, getIPv6FrontendIPConfigs(*s)...)
 		loadBalancingRules = getLoadBalancingRules(*s, frontendIDs)
 		backendAddressPools = getBackendAddressPools(*s)
 		outboundRules = getOutboundRules(*s, frontendIDs)
@@ -191,25 +199,58 @@ func getFrontendIPConfigs(lbSpec LBSpec) ([]network.FrontendIPConfiguration, []n
 	return frontendIPConfigurations, frontendIDs
 }
 
-func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.OutboundRule {
-	if lbSpec.Type == infrav1.Internal {
-		return []network.OutboundRule{}
+// getIPv6FrontendIPConfigs returns the IPv6 frontend IP config of a public load balancer with IPv6 enabled. It isn't
+// part of the frontends of the IPv4 outbound rule.
+func getIPv6FrontendIPConfigs(lbSpec LBSpec) []network.FrontendIPConfiguration {
+	if lbSpec.Type == infrav1.Internal || !lbSpec.IPv6Enabled {
+		return []network.FrontendIPConfiguration{}
 	}
-	return []network.OutboundRule{
+	return []network.FrontendIPConfiguration{
 		{
-			Name: pointer.String(outboundNAT),
-			OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
-				Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
-				IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
-				FrontendIPConfigurations: &frontendIDs,
-				BackendAddressPool: &network.SubResource{
-					ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+			Name: pointer.String(azure.GenerateIPv6FrontendIPConfigName(lbSpec.Name)),
+			FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+				PublicIPAddress: &network.PublicIPAddress{
+					ID: pointer.String(azure.PublicIPID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, azure.GenerateIPv6PublicIPName(lbSpec.Name))),
 				},
 			},
 		},
 	}
 }
 
+func getOutboundRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.OutboundRule {
+	if lbSpec.Type == infrav1.Internal || lbSpec.DisableOutboundRule {
+		return []network.OutboundRule{}
+	}
+	rules := []network.OutboundRule{}
+	if !lbSpec.IPv6Only {
+		rules = append(rules, newOutboundRule(lbSpec, outboundNAT, frontendIDs, lbSpec.BackendPoolName))
+	}
+	if lbSpec.IPv6Enabled {
+		ipv6FrontendIDs := []network.SubResource{
+			{
+				ID: pointer.String(azure.FrontendIPConfigID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, azure.GenerateIPv6FrontendIPConfigName(lbSpec.Name))),
+			},
+		}
+		rules = append(rules, newOutboundRule(lbSpec, outboundNATIPv6, ipv6FrontendIDs, azure.GenerateIPv6BackendAddressPoolName(lbSpec.BackendPoolName)))
+	}
+	return rules
+}
+
+// newOutboundRule returns an outbound rule which provides egress to a backend pool through the given frontends.
+func newOutboundRule(lbSpec LBSpec, name string, frontendIDs []network.SubResource, backendPoolName string) network.OutboundRule {
+	return network.OutboundRule{
+		Name: pointer.String(name),
+		OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+			Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
+			IdleTimeoutInMinutes:     lbSpec.IdleTimeoutInMinutes,
+			FrontendIPConfigurations: &frontendIDs,
+			BackendAddressPool: &network.SubResource{
+				ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, backendPoolName)),
+			},
+		},
+	}
+}
+
 func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []network.LoadBalancingRule {
 	if lbSpec.Role == infrav1.APIServerRole {
 		// We disable outbound SNAT explicitly in the HTTPS LB rule and enable TCP and UDP outbound NAT with an outbound rule.
@@ -218,63 +259,160 @@ func getLoadBalancingRules(lbSpec LBSpec, frontendIDs []network.SubResource) []n
 		if len(frontendIDs) != 0 {
 			frontendIPConfig = frontendIDs[0]
 		}
-		return []network.LoadBalancingRule{
-			{
-				Name: pointer.String(lbRuleHTTPS),
-				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
-					DisableOutboundSnat:     pointer.Bool(true),
-					Protocol:                network.TransportProtocolTCP,
-					FrontendPort:            pointer.Int32(lbSpec.APIServerPort),
-					BackendPort:             pointer.Int32(lbSpec.APIServerPort),
-					IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
-					EnableFloatingIP:        pointer.Bool(false),
-					LoadDistribution:        network.LoadDistributionDefault,
-					FrontendIPConfiguration: &frontendIPConfig,
-					BackendAddressPool: &network.SubResource{
-						ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
-					},
-					Probe: &network.SubResource{
-						ID: pointer.String(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, tcpProbe)),
-					},
-				},
-			},
+		rules := []network.LoadBalancingRule{
+			newLoadBalancingRule(lbSpec, lbRuleHTTPS, network.TransportProtocolTCP, lbSpec.APIServerPort, lbSpec.APIServerPort, frontendIPConfig),
 		}
+		for _, rule := range lbSpec.AdditionalRules {
+			frontendIPConfig := frontendIPConfig
+			for i, ipConfig := range lbSpec.FrontendIPConfigs {
+				if ipConfig.Name == rule.FrontendIPName && i < len(frontendIDs) {
+					frontendIPConfig = frontendIDs[i]
+				}
+			}
+			protocol := network.TransportProtocolTCP
+			if rule.Protocol == infrav1.LoadBalancingRuleProtocolUDP {
+				protocol = network.TransportProtocolUDP
+			}
+			backendPort := rule.BackendPort
+			if backendPort == 0 {
+				backendPort = rule.FrontendPort
+			}
+			rules = append(rules, newLoadBalancingRule(lbSpec, rule.Name, protocol, rule.FrontendPort, backendPort, frontendIPConfig))
+		}
+		return rules
 	}
 	return []network.LoadBalancingRule{}
 }
 
+// newLoadBalancingRule returns a load balancing rule of the API server load balancer, which uses the API server probe.
+func newLoadBalancingRule(lbSpec LBSpec, name string, protocol network.TransportProtocol, frontendPort, backendPort int32, frontendIPConfig network.SubResource) network.LoadBalancingRule {
+	return network.LoadBalancingRule{
+		Name: pointer.String(name),
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			DisableOutboundSnat:     pointer.Bool(true),
+			Protocol:                protocol,
+			FrontendPort:            pointer.Int32(frontendPort),
+			BackendPort:             pointer.Int32(backendPort),
+			IdleTimeoutInMinutes:    lbSpec.IdleTimeoutInMinutes,
+			EnableFloatingIP:        pointer.Bool(false),
+			LoadDistribution:        network.LoadDistributionDefault,
+			FrontendIPConfiguration: &frontendIPConfig,
+			BackendAddressPool: &network.SubResource{
+				ID: pointer.String(azure.AddressPoolID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, lbSpec.BackendPoolName)),
+			},
+			Probe: &network.SubResource{
+				ID: pointer.String(azure.ProbeID(lbSpec.SubscriptionID, lbSpec.ResourceGroup, lbSpec.Name, apiServerProbeName(lbSpec))),
+			},
+		},
+	}
+}
+
 func getBackendAddressPools(lbSpec LBSpec) []network.BackendAddressPool {
-	return []network.BackendAddressPool{
+	pools := []network.BackendAddressPool{
 		{
 			Name: pointer.String(lbSpec.BackendPoolName),
 		},
 	}
+	if lbSpec.Type != infrav1.Internal && lbSpec.IPv6Enabled {
+		pools = append(pools, network.BackendAddressPool{
+			Name: pointer.String(azure.GenerateIPv6BackendAddressPoolName(lbSpec.BackendPoolName)),
+		})
+	}
+	return pools
 }
 
 func getProbes(lbSpec LBSpec) []network.Probe {
 	if lbSpec.Role == infrav1.APIServerRole {
+		if lbSpec.Probe == nil {
+			return []network.Probe{
+				{
+					Name: pointer.String(tcpProbe),
+					ProbePropertiesFormat: &network.ProbePropertiesFormat{
+						Protocol:          network.ProbeProtocolTCP,
+						Port:              pointer.Int32(lbSpec.APIServerPort),
+						IntervalInSeconds: pointer.Int32(15),
+						NumberOfProbes:    pointer.Int32(4),
+					},
+				},
+			}
+		}
+
+		properties := network.ProbePropertiesFormat{
+			Protocol:          network.ProbeProtocolTCP,
+			Port:              pointer.Int32(lbSpec.APIServerPort),
+			IntervalInSeconds: pointer.Int32(pointer.Int32Deref(lbSpec.Probe.IntervalInSeconds, infrav1.DefaultLoadBalancerProbeIntervalInSeconds)),
+			NumberOfProbes:    pointer.Int32(pointer.Int32Deref(lbSpec.Probe.NumberOfProbes, infrav1.DefaultLoadBalancerProbeNumberOfProbes)),
+		}
+		switch lbSpec.Probe.Protocol {
+		case infrav1.LoadBalancerProbeProtocolHTTP:
+			properties.Protocol = network.ProbeProtocolHTTP
+			properties.RequestPath = pointer.String(lbSpec.Probe.RequestPath)
+		case infrav1.LoadBalancerProbeProtocolHTTPS:
+			properties.Protocol = network.ProbeProtocolHTTPS
+			properties.RequestPath = pointer.String(lbSpec.Probe.RequestPath)
+		}
 		return []network.Probe{
 			{
-				Name: pointer.String(tcpProbe),
-				ProbePropertiesFormat: &network.ProbePropertiesFormat{
-					Protocol:          network.ProbeProtocolTCP,
-					Port:              pointer.Int32(lbSpec.APIServerPort),
-					IntervalInSeconds: pointer.Int32(15),
-					NumberOfProbes:    pointer.Int32(4),
-				},
+				Name:                  pointer.String(apiServerProbeName(lbSpec)),
+				ProbePropertiesFormat: &properties,
 			},
 		}
 	}
 	return []network.Probe{}
 }
 
-func probeExists(probes []network.Probe, probe network.Probe) bool {
-	for _, p := range probes {
+// apiServerProbeName returns the name of the API server probe, which depends on its protocol.
+func apiServerProbeName(lbSpec LBSpec) string {
+	if lbSpec.Probe == nil {
+		return tcpProbe
+	}
+	switch lbSpec.Probe.Protocol {
+	case infrav1.LoadBalancerProbeProtocolHTTP:
+		return httpProbe
+	case infrav1.LoadBalancerProbeProtocolHTTPS:
+		return httpsProbe
+	default:
+		return tcpProbe
+	}
+}
+
+// mergeProbes adds the wanted probes which are missing from the existing probes. When strict is true, the existing
+// probes which differ from the wanted ones are replaced too. It returns true if the probes were modified.
+func mergeProbes(existing []network.Probe, wanted []network.Probe, strict bool) ([]network.Probe, bool) {
+	probes := append([]network.Probe{}, existing...)
+	update := false
+	for _, probe := range wanted {
+		i := probeIndex(probes, probe)
+		switch {
+		case i == -1:
+			update = true
+			probes = append(probes, probe)
+		case strict && !probeUpToDate(probes[i], probe):
+			update = true
+			probes[i] = probe
+		}
+	}
+	return probes, update
+}
+
+func probeIndex(probes []network.Probe, probe network.Probe) int {
+	for i, p := range probes {
 		if pointer.StringDeref(p.Name, "") == pointer.StringDeref(probe.Name, "") {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
+}
+
+func probeUpToDate(existing network.Probe, wanted network.Probe) bool {
+	if existing.ProbePropertiesFormat == nil {
+		return false
+	}
+	return existing.Protocol == wanted.Protocol &&
+		pointer.Int32Deref(existing.Port, 0) == pointer.Int32Deref(wanted.Port, 0) &&
+		pointer.StringDeref(existing.RequestPath, "") == pointer.StringDeref(wanted.RequestPath, "") &&
+		pointer.Int32Deref(existing.IntervalInSeconds, 0) == pointer.Int32Deref(wanted.IntervalInSeconds, 0) &&
+		pointer.Int32Deref(existing.NumberOfProbes, 0) == pointer.Int32Deref(wanted.NumberOfProbes, 0)
 }
 
 func outboundRuleExists(rules []network.OutboundRule, rule network.OutboundRule) bool {
@@ -295,13 +433,51 @@ func poolExists(pools []network.BackendAddressPool, pool network.BackendAddressP
 	return false
 }
 
-func lbRuleExists(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) bool {
-	for _, r := range rules {
+// mergeLBRules adds the wanted rules which are missing from the existing rules, and replaces the existing rules whose
+// ports, protocol, frontend or probe differ from the wanted ones. It returns true if the rules were modified.
+func mergeLBRules(existing []network.LoadBalancingRule, wanted []network.LoadBalancingRule) ([]network.LoadBalancingRule, bool) {
+	rules := append([]network.LoadBalancingRule{}, existing...)
+	update := false
+	for _, rule := range wanted {
+		i := lbRuleIndex(rules, rule)
+		switch {
+		case i == -1:
+			update = true
+			rules = append(rules, rule)
+		case !lbRuleUpToDate(rules[i], rule):
+			update = true
+			rules[i] = rule
+		}
+	}
+	return rules, update
+}
+
+func lbRuleIndex(rules []network.LoadBalancingRule, rule network.LoadBalancingRule) int {
+	for i, r := range rules {
 		if pointer.StringDeref(r.Name, "") == pointer.StringDeref(rule.Name, "") {
-			return true
+			return i
 		}
 	}
-	return false
+	return -1
+}
+
+func lbRuleUpToDate(existing network.LoadBalancingRule, wanted network.LoadBalancingRule) bool {
+	if existing.LoadBalancingRulePropertiesFormat == nil {
+		return false
+	}
+	return existing.Protocol == wanted.Protocol &&
+		pointer.Int32Deref(existing.FrontendPort, 0) == pointer.Int32Deref(wanted.FrontendPort, 0) &&
+		pointer.Int32Deref(existing.BackendPort, 0) == pointer.Int32Deref(wanted.BackendPort, 0) &&
+		subResourceEqual(existing.FrontendIPConfiguration, wanted.FrontendIPConfiguration) &&
+		subResourceEqual(existing.Probe, wanted.Probe)
+}
+
+// subResourceEqual returns true if both sub resources have the same ID. Azure resource IDs are case-insensitive.
+func subResourceEqual(a, b *network.SubResource) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return strings.EqualFold(pointer.StringDeref(a.ID, ""), pointer.StringDeref(b.ID, ""))
 }
 
 func ipExists(configs []network.FrontendIPConfiguration, config network.FrontendIPConfiguration) bool {
@@ -61,6 +61,69 @@ func getExistingLBWithMissingOutboundRules() network.LoadBalancer {
 	return existingLB
 }
 
+func getPublicAPILBSpecWithoutOutboundRule() *LBSpec {
+	spec := fakePublicAPILBSpec
+	spec.DisableOutboundRule = true
+
+	return &spec
+}
+
+func getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules() *LBSpec {
+	spec := fakePublicAPILBSpec
+	spec.Probe = &infrav1.LoadBalancerProbe{
+		Protocol:          infrav1.LoadBalancerProbeProtocolHTTPS,
+		RequestPath:       "/readyz",
+		IntervalInSeconds: pointer.Int32(5),
+		NumberOfProbes:    pointer.Int32(2),
+	}
+	spec.AdditionalRules = []infrav1.LoadBalancingRule{
+		{
+			Name:         "konnectivity",
+			Protocol:     infrav1.LoadBalancingRuleProtocolTCP,
+			FrontendPort: 8132,
+			BackendPort:  8132,
+		},
+	}
+
+	return &spec
+}
+
+func getDualStackNodeOutboundLBSpec(ipv6Only bool) *LBSpec {
+	spec := fakeNodeOutboundLBSpec
+	spec.IPv6Enabled = true
+	spec.IPv6Only = ipv6Only
+
+	return &spec
+}
+
+// getExistingLBWithUserAddedRule returns the default public API server load balancer with a rule and a probe which
+// are not managed by CAPZ.
+func getExistingLBWithUserAddedRule() network.LoadBalancer {
+	existingLB := newSamplePublicAPIServerLB(false, false, false, false, false)
+	userProbe := network.Probe{
+		Name: pointer.String("user-probe"),
+		ProbePropertiesFormat: &network.ProbePropertiesFormat{
+			Protocol: network.ProbeProtocolTCP,
+			Port:     pointer.Int32(2379),
+		},
+	}
+	userRule := network.LoadBalancingRule{
+		Name: pointer.String("user-rule"),
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			Protocol:     network.TransportProtocolTCP,
+			FrontendPort: pointer.Int32(2379),
+			BackendPort:  pointer.Int32(2379),
+			Probe: &network.SubResource{
+				ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/user-probe"),
+			},
+		},
+	}
+	existingLB.Probes = &[]network.Probe{(*existingLB.Probes)[0], userProbe}
+	existingLB.LoadBalancingRules = &[]network.LoadBalancingRule{(*existingLB.LoadBalancingRules)[0], userRule}
+
+	return existingLB
+}
+
 func TestParameters(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -146,6 +209,159 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "public API load balancer without outbound rule",
+			spec:     getPublicAPILBSpecWithoutOutboundRule(),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.OutboundRules).To(BeEmpty())
+				g.Expect(*lb.LoadBalancingRules).To(HaveLen(1))
+				g.Expect(*(*lb.LoadBalancingRules)[0].DisableOutboundSnat).To(BeTrue())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing public API load balancer without outbound rule is up to date",
+			spec:     getPublicAPILBSpecWithoutOutboundRule(),
+			existing: getExistingLBWithMissingOutboundRules(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "public API load balancer with an https probe and additional rules",
+			spec:     getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules(),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.Probes).To(Equal([]network.Probe{
+					{
+						Name: pointer.String(httpsProbe),
+						ProbePropertiesFormat: &network.ProbePropertiesFormat{
+							Protocol:          network.ProbeProtocolHTTPS,
+							Port:              pointer.Int32(6443),
+							RequestPath:       pointer.String("/readyz"),
+							IntervalInSeconds: pointer.Int32(5),
+							NumberOfProbes:    pointer.Int32(2),
+						},
+					},
+				}))
+				g.Expect(*lb.LoadBalancingRules).To(HaveLen(2))
+				for _, rule := range *lb.LoadBalancingRules {
+					g.Expect(*rule.Probe.ID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/probes/HTTPSProbe"))
+					g.Expect(*rule.DisableOutboundSnat).To(BeTrue())
+				}
+				g.Expect(*(*lb.LoadBalancingRules)[1].Name).To(Equal("konnectivity"))
+				g.Expect(*(*lb.LoadBalancingRules)[1].FrontendPort).To(Equal(int32(8132)))
+				g.Expect(*(*lb.LoadBalancingRules)[1].FrontendIPConfiguration.ID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-publiclb/frontendIPConfigurations/my-publiclb-frontEnd"))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing public API load balancer switched to an https probe preserves the rules not managed by CAPZ",
+			spec:     getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules(),
+			existing: getExistingLBWithUserAddedRule(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				var probeNames, ruleNames []string
+				for _, probe := range *lb.Probes {
+					probeNames = append(probeNames, *probe.Name)
+				}
+				for _, rule := range *lb.LoadBalancingRules {
+					ruleNames = append(ruleNames, *rule.Name)
+				}
+				g.Expect(probeNames).To(Equal([]string{tcpProbe, "user-probe", httpsProbe}))
+				g.Expect(ruleNames).To(Equal([]string{lbRuleHTTPS, "user-rule", "konnectivity"}))
+				g.Expect(*(*lb.LoadBalancingRules)[0].Probe.ID).To(HaveSuffix("/probes/HTTPSProbe"))
+				g.Expect(*(*lb.LoadBalancingRules)[1].Probe.ID).To(HaveSuffix("/probes/user-probe"))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing https probe with outdated health settings is updated",
+			spec: getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules(),
+			existing: func() network.LoadBalancer {
+				spec := getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules()
+				existingLB, _ := spec.Parameters(context.TODO(), nil)
+				lb := existingLB.(network.LoadBalancer)
+				(*lb.Probes)[0].NumberOfProbes = pointer.Int32(4)
+				return lb
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.Probes).To(HaveLen(1))
+				g.Expect(*(*lb.Probes)[0].NumberOfProbes).To(Equal(int32(2)))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing public API load balancer with an https probe and additional rules is up to date",
+			spec: getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules(),
+			existing: func() network.LoadBalancer {
+				spec := getPublicAPILBSpecWithHTTPSProbeAndAdditionalRules()
+				existingLB, _ := spec.Parameters(context.TODO(), nil)
+				return existingLB.(network.LoadBalancer)
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "dual-stack node outbound load balancer",
+			spec:     getDualStackNodeOutboundLBSpec(false),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.FrontendIPConfigurations).To(HaveLen(2))
+				g.Expect(*(*lb.FrontendIPConfigurations)[1].Name).To(Equal("my-cluster-frontEnd-ipv6"))
+				g.Expect(*(*lb.FrontendIPConfigurations)[1].PublicIPAddress.ID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/pip-my-cluster-ipv6"))
+				g.Expect(*lb.BackendAddressPools).To(HaveLen(2))
+				g.Expect(*(*lb.BackendAddressPools)[1].Name).To(Equal("my-cluster-outboundBackendPool-ipv6"))
+				g.Expect(*lb.OutboundRules).To(HaveLen(2))
+				g.Expect(*(*lb.OutboundRules)[0].FrontendIPConfigurations).To(Equal([]network.SubResource{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd")},
+				}))
+				g.Expect(*(*lb.OutboundRules)[1].Name).To(Equal("OutboundNATAllProtocolsIPv6"))
+				g.Expect(*(*lb.OutboundRules)[1].FrontendIPConfigurations).To(Equal([]network.SubResource{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/frontendIPConfigurations/my-cluster-frontEnd-ipv6")},
+				}))
+				g.Expect(*(*lb.OutboundRules)[1].BackendAddressPool.ID).To(Equal("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster/backendAddressPools/my-cluster-outboundBackendPool-ipv6"))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing node outbound load balancer gets IPv6 egress when IPv6 is enabled",
+			spec:     getDualStackNodeOutboundLBSpec(false),
+			existing: newDefaultNodeOutboundLB(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.FrontendIPConfigurations).To(HaveLen(2))
+				g.Expect(*lb.BackendAddressPools).To(HaveLen(2))
+				g.Expect(*lb.OutboundRules).To(HaveLen(2))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "IPv6-only node outbound load balancer only provides IPv6 egress",
+			spec:     getDualStackNodeOutboundLBSpec(true),
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.LoadBalancer{}))
+				lb := result.(network.LoadBalancer)
+				g.Expect(*lb.OutboundRules).To(HaveLen(1))
+				g.Expect(*(*lb.OutboundRules)[0].Name).To(Equal("OutboundNATAllProtocolsIPv6"))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
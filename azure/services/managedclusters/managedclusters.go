@@ -80,7 +80,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	result, resultErr := s.CreateOrUpdateResource(ctx, managedClusterSpec, serviceName)
-	if resultErr == nil {
+	// The result is nil when the managed cluster doesn't exist yet in dry-run mode, so there is nothing to update.
+	if resultErr == nil && result != nil {
 		managedCluster, ok := result.(containerservice.ManagedCluster)
 		if !ok {
 			return errors.Errorf("%T is not a containerservice.ManagedCluster", result)
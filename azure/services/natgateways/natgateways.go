@@ -24,11 +24,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "natgateways"
+// ServiceName is the name of this service.
+const ServiceName = "natgateways"
 
 // NatGatewayScope defines the scope interface for NAT gateway service.
 type NatGatewayScope interface {
@@ -55,7 +57,14 @@ func New(scope NatGatewayScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		publicips.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a NAT gateway.
@@ -74,36 +83,28 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed to check if NAT gateways are managed")
 	}
 
-	// We go through the list of NatGatewaySpecs to reconcile each one, independently of the resultingErr of the previous one.
 	specs := s.Scope.NatGatewaySpecs()
 	if len(specs) == 0 {
 		return nil
 	}
 
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
-	var resultingErr error
-	for _, natGatewaySpec := range specs {
-		result, err := s.CreateOrUpdateResource(ctx, natGatewaySpec, serviceName)
-		if err != nil {
-			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
-				resultingErr = err
-			}
+	results, resultingErr := s.CreateOrUpdateResources(ctx, specs, ServiceName, "")
+	for i, result := range results {
+		if result == nil {
+			continue
 		}
-		if err == nil {
-			natGateway, ok := result.(network.NatGateway)
-			if !ok {
-				// Return out of loop since this would be an unexpected fatal error
-				resultingErr = errors.Errorf("created resource %T is not a network.NatGateway", result)
-				break
-			}
-
-			// TODO: ideally we wouldn't need to set the subnet spec based on the result of the create operation
-			s.Scope.SetNatGatewayIDInSubnets(natGatewaySpec.ResourceName(), *natGateway.ID)
+		natGateway, ok := result.(network.NatGateway)
+		if !ok {
+			// Stop here since this would be an unexpected fatal error
+			resultingErr = errors.Errorf("created resource %T is not a network.NatGateway", result)
+			break
 		}
+
+		// TODO: ideally we wouldn't need to set the subnet spec based on the result of the create operation
+		s.Scope.SetNatGatewayIDInSubnets(specs[i].ResourceName(), *natGateway.ID)
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, resultingErr)
+	s.Scope.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, resultingErr)
 	return resultingErr
 }
 
@@ -127,19 +128,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of NatGatewaySpecs to delete each one, independently of the resultingErr of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (ie. error creating) -> operationNotDoneError (ie. creating in progress) -> no error (ie. created)
-	var resultingErr error
-	for _, natGatewaySpec := range specs {
-		if err := s.DeleteResource(ctx, natGatewaySpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || resultingErr == nil {
-				resultingErr = err
-			}
-		}
-	}
-	s.Scope.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, resultingErr)
-	return resultingErr
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.NATGatewaysReadyCondition)
 }
 
 // IsManaged returns true if the NAT gateways' lifecycles are managed.
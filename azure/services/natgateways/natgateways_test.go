@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways/mock_natgateways"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
 	clusterv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func init() {
@@ -95,9 +96,9 @@ func TestReconcileNatGateways(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(natGateway1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&natGatewaySpec1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{natGateway1}, nil)
 				s.SetNatGatewayIDInSubnets(natGatewaySpec1.Name, *natGateway1.ID)
-				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -107,8 +108,8 @@ func TestReconcileNatGateways(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(nil, internalError)
-				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&natGatewaySpec1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{nil}, internalError)
+				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -118,8 +119,8 @@ func TestReconcileNatGateways(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return("not a nat gateway", nil)
-				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, serviceName, gomockinternal.ErrStrEq("created resource string is not a network.NatGateway"))
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&natGatewaySpec1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{"not a nat gateway"}, nil)
+				s.UpdatePutStatus(infrav1.NATGatewaysReadyCondition, ServiceName, gomockinternal.ErrStrEq("created resource string is not a network.NatGateway"))
 			},
 		},
 	}
@@ -183,8 +184,7 @@ func TestDeleteNatGateway(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.DeleteResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&natGatewaySpec1}, ServiceName, infrav1.NATGatewaysReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -194,8 +194,7 @@ func TestDeleteNatGateway(t *testing.T) {
 			expect: func(s *mock_natgateways.MockNatGatewayScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.NatGatewaySpecs().Return([]azure.ResourceSpecGetter{&natGatewaySpec1})
-				r.DeleteResource(gomockinternal.AContext(), &natGatewaySpec1, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.NATGatewaysReadyCondition, serviceName, internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&natGatewaySpec1}, ServiceName, infrav1.NATGatewaysReadyCondition).Return(internalError)
 			},
 		},
 	}
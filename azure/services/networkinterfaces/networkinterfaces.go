@@ -71,20 +71,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of NICSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, nicSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, nicSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdatePutStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, result)
-	return result
+	_, err := s.CreateOrUpdateResources(ctx, specs, serviceName, infrav1.NetworkInterfaceReadyCondition)
+	return err
 }
 
 // Delete deletes the network interface with the provided name.
@@ -100,20 +88,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of NICSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, nicSpec := range specs {
-		if err := s.DeleteResource(ctx, nicSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdateDeleteStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, serviceName, infrav1.NetworkInterfaceReadyCondition)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO network interfaces.
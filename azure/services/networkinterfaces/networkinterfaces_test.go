@@ -91,8 +91,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNICSpec1, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec1}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return([]interface{}{nil}, nil)
 			},
 		},
 		{
@@ -100,8 +99,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec3})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNICSpec3, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec3}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return([]interface{}{nil}, nil)
 			},
 		},
 		{
@@ -109,9 +107,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNICSpec1, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNICSpec2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return([]interface{}{nil, nil}, nil)
 			},
 		},
 		{
@@ -119,9 +115,7 @@ func TestReconcileNetworkInterface(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNICSpec1, serviceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeNICSpec2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return([]interface{}{nil, nil}, internalError)
 			},
 		},
 	}
@@ -174,8 +168,7 @@ func TestDeleteNetworkInterface(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNICSpec1, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec1}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -183,9 +176,7 @@ func TestDeleteNetworkInterface(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNICSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNICSpec2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -193,9 +184,7 @@ func TestDeleteNetworkInterface(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_networkinterfaces.MockNICScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.NICSpecs().Return([]azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeNICSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeNICSpec2, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.NetworkInterfaceReadyCondition, serviceName, internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeNICSpec1, &fakeNICSpec2}, serviceName, infrav1.NetworkInterfaceReadyCondition).Return(internalError)
 			},
 		},
 	}
@@ -19,6 +19,7 @@ package networkinterfaces
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
@@ -31,29 +32,33 @@ import (
 
 // NICSpec defines the specification for a Network Interface.
 type NICSpec struct {
-	Name                      string
-	ResourceGroup             string
-	Location                  string
-	SubscriptionID            string
-	MachineName               string
-	SubnetName                string
-	VNetName                  string
-	VNetResourceGroup         string
-	StaticIPAddress           string
-	PublicLBName              string
-	PublicLBAddressPoolName   string
-	PublicLBNATRuleName       string
-	InternalLBName            string
-	InternalLBAddressPoolName string
-	PublicIPName              string
-	AcceleratedNetworking     *bool
-	IPv6Enabled               bool
-	EnableIPForwarding        bool
-	SKU                       *resourceskus.SKU
-	DNSServers                []string
-	AdditionalTags            infrav1.Tags
-	ClusterName               string
-	IPConfigs                 []IPConfig
+	Name                    string
+	ResourceGroup           string
+	Location                string
+	SubscriptionID          string
+	MachineName             string
+	SubnetName              string
+	VNetName                string
+	VNetResourceGroup       string
+	StaticIPAddress         string
+	PublicLBName            string
+	PublicLBAddressPoolName string
+	PublicLBNATRuleName     string
+	// PublicLBIPv6AddressPoolName is the IPv6 backend pool of the public load balancer which the IPv6 IP
+	// configuration of the network interface joins to get IPv6 egress.
+	PublicLBIPv6AddressPoolName string
+	InternalLBName              string
+	InternalLBAddressPoolName   string
+	PublicIPName                string
+	AcceleratedNetworking       *bool
+	IPv6Enabled                 bool
+	EnableIPForwarding          bool
+	SKU                         *resourceskus.SKU
+	DNSServers                  []string
+	AdditionalTags              infrav1.Tags
+	ClusterName                 string
+	IPConfigs                   []IPConfig
+	ApplicationSecurityGroups   []string
 }
 
 // IPConfig defines the specification for an IP address configuration.
@@ -80,11 +85,12 @@ func (s *NICSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the network interface.
 func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.Interface); !ok {
+		existingNIC, ok := existing.(network.Interface)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.Interface", existing)
 		}
-		// network interface already exists
-		return nil, nil
+		// network interface already exists, only its application security groups are reconciled
+		return s.applicationSecurityGroupsUpdate(existingNIC), nil
 	}
 
 	primaryIPConfig := &network.InterfaceIPConfigurationPropertiesFormat{
@@ -96,6 +102,10 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 	}
 	primaryIPConfig.Subnet = subnet
 
+	// All the IP configurations of a network interface must be in the same application security groups.
+	applicationSecurityGroups := s.applicationSecurityGroups()
+	primaryIPConfig.ApplicationSecurityGroups = applicationSecurityGroups
+
 	primaryIPConfig.PrivateIPAllocationMethod = network.IPAllocationMethodDynamic
 	if s.StaticIPAddress != "" {
 		primaryIPConfig.PrivateIPAllocationMethod = network.IPAllocationMethodStatic
@@ -159,6 +169,7 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		c := s.IPConfigs[i]
 		newIPConfigPropertiesFormat := &network.InterfaceIPConfigurationPropertiesFormat{}
 		newIPConfigPropertiesFormat.Subnet = subnet
+		newIPConfigPropertiesFormat.ApplicationSecurityGroups = applicationSecurityGroups
 		config := network.InterfaceIPConfiguration{
 			Name:                                     pointer.String(s.Name + "-" + strconv.Itoa(i)),
 			InterfaceIPConfigurationPropertiesFormat: newIPConfigPropertiesFormat,
@@ -191,11 +202,19 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		ipv6Config := network.InterfaceIPConfiguration{
 			Name: pointer.String("ipConfigv6"),
 			InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
-				PrivateIPAddressVersion: "IPv6",
-				Primary:                 pointer.Bool(false),
-				Subnet:                  &network.Subnet{ID: subnet.ID},
+				PrivateIPAddressVersion:   "IPv6",
+				Primary:                   pointer.Bool(false),
+				Subnet:                    &network.Subnet{ID: subnet.ID},
+				ApplicationSecurityGroups: applicationSecurityGroups,
 			},
 		}
+		if s.PublicLBName != "" && s.PublicLBIPv6AddressPoolName != "" {
+			ipv6Config.LoadBalancerBackendAddressPools = &[]network.BackendAddressPool{
+				{
+					ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, s.PublicLBName, s.PublicLBIPv6AddressPoolName)),
+				},
+			}
+		}
 
 		ipConfigurations = append(ipConfigurations, ipv6Config)
 	}
@@ -216,3 +235,72 @@ func (s *NICSpec) Parameters(ctx context.Context, existing interface{}) (paramet
 		})),
 	}, nil
 }
+
+// applicationSecurityGroups returns the application security groups the IP configurations of the network interface join.
+func (s *NICSpec) applicationSecurityGroups() *[]network.ApplicationSecurityGroup {
+	if len(s.ApplicationSecurityGroups) == 0 {
+		return nil
+	}
+	asgs := make([]network.ApplicationSecurityGroup, 0, len(s.ApplicationSecurityGroups))
+	for _, name := range s.ApplicationSecurityGroups {
+		asgs = append(asgs, network.ApplicationSecurityGroup{
+			ID: pointer.String(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+		})
+	}
+	return &asgs
+}
+
+// applicationSecurityGroupsUpdate returns the existing network interface with the IP configurations joined to the
+// application security groups of the spec, or nil if they are already members of exactly those groups.
+func (s *NICSpec) applicationSecurityGroupsUpdate(existing network.Interface) interface{} {
+	if existing.InterfacePropertiesFormat == nil || existing.IPConfigurations == nil {
+		return nil
+	}
+
+	want := make(map[string]struct{}, len(s.ApplicationSecurityGroups))
+	for _, name := range s.ApplicationSecurityGroups {
+		want[strings.ToLower(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name))] = struct{}{}
+	}
+
+	upToDate := true
+	for _, ipConfig := range *existing.IPConfigurations {
+		var got []network.ApplicationSecurityGroup
+		if ipConfig.InterfaceIPConfigurationPropertiesFormat != nil && ipConfig.ApplicationSecurityGroups != nil {
+			got = *ipConfig.ApplicationSecurityGroups
+		}
+		if len(got) != len(want) {
+			upToDate = false
+			break
+		}
+		for _, asg := range got {
+			if _, ok := want[strings.ToLower(pointer.StringDeref(asg.ID, ""))]; !ok {
+				upToDate = false
+				break
+			}
+		}
+	}
+	if upToDate {
+		return nil
+	}
+
+	applicationSecurityGroups := s.applicationSecurityGroups()
+	if applicationSecurityGroups == nil {
+		// an empty list, rather than nil, removes the IP configurations from their application security groups
+		applicationSecurityGroups = &[]network.ApplicationSecurityGroup{}
+	}
+	ipConfigurations := make([]network.InterfaceIPConfiguration, 0, len(*existing.IPConfigurations))
+	for _, ipConfig := range *existing.IPConfigurations {
+		if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil {
+			ipConfig.InterfaceIPConfigurationPropertiesFormat = &network.InterfaceIPConfigurationPropertiesFormat{}
+		} else {
+			properties := *ipConfig.InterfaceIPConfigurationPropertiesFormat
+			ipConfig.InterfaceIPConfigurationPropertiesFormat = &properties
+		}
+		ipConfig.ApplicationSecurityGroups = applicationSecurityGroups
+		ipConfigurations = append(ipConfigurations, ipConfig)
+	}
+	properties := *existing.InterfacePropertiesFormat
+	properties.IPConfigurations = &ipConfigurations
+	existing.InterfacePropertiesFormat = &properties
+	return existing
+}
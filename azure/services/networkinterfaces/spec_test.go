@@ -162,6 +162,23 @@ var (
 		ClusterName:           "my-cluster",
 	}
 
+	fakeDualStackOutboundNICSpec = NICSpec{
+		Name:                        "my-net-interface",
+		ResourceGroup:               "my-rg",
+		Location:                    "fake-location",
+		SubscriptionID:              "123",
+		MachineName:                 "azure-test1",
+		SubnetName:                  "my-subnet",
+		VNetName:                    "my-vnet",
+		IPv6Enabled:                 true,
+		VNetResourceGroup:           "my-rg",
+		PublicLBName:                "my-public-lb",
+		PublicLBAddressPoolName:     "my-public-lb-outboundBackendPool",
+		PublicLBIPv6AddressPoolName: "my-public-lb-outboundBackendPool-ipv6",
+		AcceleratedNetworking:       pointer.Bool(false),
+		ClusterName:                 "my-cluster",
+	}
+
 	fakeControlPlaneCustomDNSSettingsNICSpec = NICSpec{
 		Name:                      "my-net-interface",
 		ResourceGroup:             "my-rg",
@@ -232,6 +249,22 @@ var (
 		IPConfigs:             []IPConfig{{}, {}},
 		ClusterName:           "my-cluster",
 	}
+	fakeApplicationSecurityGroupsNICSpec = NICSpec{
+		Name:                      "my-net-interface",
+		ResourceGroup:             "my-rg",
+		Location:                  "fake-location",
+		SubscriptionID:            "123",
+		MachineName:               "azure-test1",
+		SubnetName:                "my-subnet",
+		VNetName:                  "my-vnet",
+		IPv6Enabled:               false,
+		VNetResourceGroup:         "my-rg",
+		AcceleratedNetworking:     nil,
+		SKU:                       &fakeSku,
+		IPConfigs:                 []IPConfig{{}, {}},
+		ClusterName:               "my-cluster",
+		ApplicationSecurityGroups: []string{"my-cluster-node-asg", "my-custom-asg"},
+	}
 	fakeTwoIPconfigWithPublicNICSpec = NICSpec{
 		Name:                  "my-net-interface",
 		ResourceGroup:         "my-rg",
@@ -478,6 +511,24 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for dual-stack network interface in an outbound load balancer",
+			spec:     &fakeDualStackOutboundNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				ipConfigs := *result.(network.Interface).IPConfigurations
+				g.Expect(ipConfigs).To(HaveLen(2))
+				g.Expect(*ipConfigs[0].LoadBalancerBackendAddressPools).To(Equal([]network.BackendAddressPool{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-outboundBackendPool")},
+				}))
+				g.Expect(*ipConfigs[1].Name).To(Equal("ipConfigv6"))
+				g.Expect(*ipConfigs[1].LoadBalancerBackendAddressPools).To(Equal([]network.BackendAddressPool{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-public-lb/backendAddressPools/my-public-lb-outboundBackendPool-ipv6")},
+				}))
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface default ipconfig",
 			spec:     &fakeDefaultIPconfigNICSpec,
@@ -586,6 +637,139 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:     "get parameters for network interface in application security groups",
+			spec:     &fakeApplicationSecurityGroupsNICSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				asgs := &[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-custom-asg")},
+				}
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				g.Expect(result.(network.Interface)).To(Equal(network.Interface{
+					Tags: map[string]*string{
+						"Name": pointer.String("my-net-interface"),
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+					},
+					Location: pointer.String("fake-location"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: pointer.Bool(true),
+						EnableIPForwarding:          pointer.Bool(false),
+						DNSSettings:                 &network.InterfaceDNSSettings{},
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								Name: pointer.String("pipConfig"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                         pointer.Bool(true),
+									Subnet:                          &network.Subnet{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod:       network.IPAllocationMethodDynamic,
+									LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{},
+									ApplicationSecurityGroups:       asgs,
+								},
+							},
+							{
+								Name: pointer.String("my-net-interface-1"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                   pointer.Bool(false),
+									Subnet:                    &network.Subnet{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet")},
+									PrivateIPAllocationMethod: network.IPAllocationMethodDynamic,
+									ApplicationSecurityGroups: asgs,
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "add existing network interface to application security groups",
+			spec: &fakeApplicationSecurityGroupsNICSpec,
+			existing: network.Interface{
+				Name:     pointer.String("my-net-interface"),
+				Location: pointer.String("fake-location"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					EnableAcceleratedNetworking: pointer.Bool(true),
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{
+							Name: pointer.String("pipConfig"),
+							InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+								Primary:          pointer.Bool(true),
+								PrivateIPAddress: pointer.String("10.0.0.4"),
+								ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{
+									{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+								},
+							},
+						},
+						{
+							Name: pointer.String("my-net-interface-1"),
+							InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+								Primary:          pointer.Bool(false),
+								PrivateIPAddress: pointer.String("10.0.0.5"),
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				asgs := &[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+					{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-custom-asg")},
+				}
+				g.Expect(result).To(BeAssignableToTypeOf(network.Interface{}))
+				g.Expect(result.(network.Interface)).To(Equal(network.Interface{
+					Name:     pointer.String("my-net-interface"),
+					Location: pointer.String("fake-location"),
+					InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+						EnableAcceleratedNetworking: pointer.Bool(true),
+						IPConfigurations: &[]network.InterfaceIPConfiguration{
+							{
+								Name: pointer.String("pipConfig"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                   pointer.Bool(true),
+									PrivateIPAddress:          pointer.String("10.0.0.4"),
+									ApplicationSecurityGroups: asgs,
+								},
+							},
+							{
+								Name: pointer.String("my-net-interface-1"),
+								InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+									Primary:                   pointer.Bool(false),
+									PrivateIPAddress:          pointer.String("10.0.0.5"),
+									ApplicationSecurityGroups: asgs,
+								},
+							},
+						},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing network interface already in application security groups",
+			spec: &fakeApplicationSecurityGroupsNICSpec,
+			existing: network.Interface{
+				Name: pointer.String("my-net-interface"),
+				InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+					IPConfigurations: &[]network.InterfaceIPConfiguration{
+						{
+							Name: pointer.String("pipConfig"),
+							InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+								ApplicationSecurityGroups: &[]network.ApplicationSecurityGroup{
+									{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-custom-asg")},
+									{ID: pointer.String("/subscriptions/123/resourcegroups/my-rg/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+								},
+							},
+						},
+					},
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
 		{
 			name:     "get parameters for network interface with two ipconfigs and a public ip",
 			spec:     &fakeTwoIPconfigWithPublicNICSpec,
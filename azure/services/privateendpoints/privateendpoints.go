@@ -22,6 +22,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -56,6 +57,13 @@ func (s *Service) Name() string {
 	return ServiceName
 }
 
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		subnets.ServiceName,
+	}
+}
+
 // Reconcile idempotently creates or updates a private endpoint.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "privateendpoints.Service.Reconcile")
@@ -69,23 +77,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of PrivateEndpointSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, privateEndpointSpec := range specs {
-		if privateEndpointSpec == nil {
-			continue
-		}
-		if _, err := s.CreateOrUpdateResource(ctx, privateEndpointSpec, ServiceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, result)
-	return result
+	_, err := s.CreateOrUpdateResources(ctx, withoutNilSpecs(specs), ServiceName, infrav1.PrivateEndpointsReadyCondition)
+	return err
 }
 
 // Delete deletes the private endpoint with the provided name.
@@ -101,25 +94,21 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of PrivateEndpointSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, privateEndpointSpec := range specs {
-		if privateEndpointSpec == nil {
-			continue
-		}
-		if err := s.DeleteResource(ctx, privateEndpointSpec, ServiceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-	s.Scope.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, result)
-	return result
+	return s.DeleteResources(ctx, withoutNilSpecs(specs), ServiceName, infrav1.PrivateEndpointsReadyCondition)
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO private endpoints.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
 }
+
+// withoutNilSpecs returns the specs that are not nil.
+func withoutNilSpecs(specs []azure.ResourceSpecGetter) []azure.ResourceSpecGetter {
+	result := make([]azure.ResourceSpecGetter, 0, len(specs))
+	for _, spec := range specs {
+		if spec != nil {
+			result = append(result, spec)
+		}
+	}
+	return result
+}
@@ -78,8 +78,7 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateEndpoint1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(&fakePrivateEndpoint1, nil)
-				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return([]interface{}{&fakePrivateEndpoint1}, nil)
 			},
 		},
 		{
@@ -87,8 +86,7 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[1:2])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(&fakePrivateEndpoint2, nil)
-				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint2}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return([]interface{}{&fakePrivateEndpoint2}, nil)
 			},
 		},
 		{
@@ -96,9 +94,7 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:2])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(&fakePrivateEndpoint1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(&fakePrivateEndpoint2, nil)
-				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return([]interface{}{&fakePrivateEndpoint1, &fakePrivateEndpoint2}, nil)
 			},
 		},
 		{
@@ -106,8 +102,7 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[3:])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &emptyPrivateEndpointSpec, ServiceName).Return(nil, internalError)
-				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&emptyPrivateEndpointSpec}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return([]interface{}{nil}, internalError)
 			},
 		},
 		{
@@ -115,10 +110,7 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:3])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(&fakePrivateEndpoint1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(&fakePrivateEndpoint2, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint3, ServiceName).Return(&fakePrivateEndpoint3, notDoneError)
-				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2, &fakePrivateEndpoint3}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return([]interface{}{&fakePrivateEndpoint1, &fakePrivateEndpoint2, &fakePrivateEndpoint3}, internalError)
 			},
 		},
 		{
@@ -126,10 +118,7 @@ func TestReconcilePrivateEndpoint(t *testing.T) {
 			expectedError: "operation type  on Azure resource / is not done",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:3])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(&fakePrivateEndpoint1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePrivateEndpoint3, ServiceName).Return(&fakePrivateEndpoint3, nil)
-				p.UpdatePutStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, notDoneError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2, &fakePrivateEndpoint3}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return([]interface{}{&fakePrivateEndpoint1, nil, &fakePrivateEndpoint3}, notDoneError)
 			},
 		},
 	}
@@ -173,8 +162,7 @@ func TestDeletePrivateEndpoints(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:1])
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -189,9 +177,7 @@ func TestDeletePrivateEndpoints(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:2])
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(nil)
-				p.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -199,9 +185,7 @@ func TestDeletePrivateEndpoints(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:2])
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(internalError)
-				p.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return(internalError)
 			},
 		},
 		{
@@ -209,10 +193,7 @@ func TestDeletePrivateEndpoints(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:3])
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(internalError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint3, ServiceName).Return(notDoneError)
-				p.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2, &fakePrivateEndpoint3}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return(internalError)
 			},
 		},
 		{
@@ -220,10 +201,7 @@ func TestDeletePrivateEndpoints(t *testing.T) {
 			expectedError: "operation type  on Azure resource / is not done",
 			expect: func(p *mock_privateendpoints.MockPrivateEndpointScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.PrivateEndpointSpecs().Return(fakePrivateEndpointSpecs[:3])
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePrivateEndpoint3, ServiceName).Return(notDoneError)
-				p.UpdateDeleteStatus(infrav1.PrivateEndpointsReadyCondition, ServiceName, notDoneError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateEndpoint1, &fakePrivateEndpoint2, &fakePrivateEndpoint3}, ServiceName, infrav1.PrivateEndpointsReadyCondition).Return(notDoneError)
 			},
 		},
 	}
@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "privatelinkservices"
+
+// PrivateLinkServiceScope defines the scope interface for a private link service.
+type PrivateLinkServiceScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	PrivateLinkServiceSpecs() []azure.ResourceSpecGetter
+	SetAPIServerPrivateLinkServiceAlias(string)
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PrivateLinkServiceScope
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope PrivateLinkServiceScope) *Service {
+	Client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		Reconciler: async.New(scope, Client, Client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		loadbalancers.ServiceName,
+		subnets.ServiceName,
+	}
+}
+
+// Reconcile idempotently creates or updates a private link service.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PrivateLinkServiceSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	results, resultErr := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.PrivateLinkServicesReadyCondition)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		privateLinkService, ok := result.(network.PrivateLinkService)
+		if !ok {
+			return errors.Errorf("%T is not a network.PrivateLinkService", result)
+		}
+		if privateLinkService.PrivateLinkServiceProperties != nil {
+			s.Scope.SetAPIServerPrivateLinkServiceAlias(pointer.StringDeref(privateLinkService.Alias, ""))
+		}
+	}
+
+	return resultErr
+}
+
+// Delete deletes the private link service with the provided name.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "privatelinkservices.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.PrivateLinkServiceSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.PrivateLinkServicesReadyCondition)
+}
+
+// IsManaged returns always returns true as CAPZ does not support BYO private link services.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
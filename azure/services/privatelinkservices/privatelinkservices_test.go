@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices/mock_privatelinkservices"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePrivateLinkServiceSpec = PrivateLinkServiceSpec{
+		Name:                      "my-cluster-apiserver-pls",
+		ResourceGroup:             "my-rg",
+		SubscriptionID:            "123",
+		Location:                  "eastus",
+		ClusterName:               "my-cluster",
+		LoadBalancerName:          "my-cluster-internal-lb",
+		FrontendIPConfigNames:     []string{"my-cluster-internal-lb-frontEnd"},
+		VNetName:                  "my-vnet",
+		VNetResourceGroup:         "my-vnet-rg",
+		SubnetName:                "my-cluster-controlplane-subnet",
+		VisibleSubscriptions:      []string{"22222222-2222-2222-2222-222222222222"},
+		AutoApprovedSubscriptions: []string{"11111111-1111-1111-1111-111111111111"},
+		AdditionalTags:            infrav1.Tags{"foo": "bar"},
+	}
+
+	fakePrivateLinkService = network.PrivateLinkService{
+		Name: pointer.String("my-cluster-apiserver-pls"),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			Alias: pointer.String("my-cluster-apiserver-pls.00000000-0000-0000-0000-000000000000.eastus.azure.privatelinkservice"),
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
+func TestReconcilePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no private link services",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create a private link service and set its alias",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec}, ServiceName, infrav1.PrivateLinkServicesReadyCondition).Return([]interface{}{fakePrivateLinkService}, nil)
+				s.SetAPIServerPrivateLinkServiceAlias("my-cluster-apiserver-pls.00000000-0000-0000-0000-000000000000.eastus.azure.privatelinkservice")
+			},
+		},
+		{
+			name:          "fail to create a private link service",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec}, ServiceName, infrav1.PrivateLinkServicesReadyCondition).Return([]interface{}{nil}, internalError)
+			},
+		},
+		{
+			name:          "result is not a private link service",
+			expectedError: "network.PrivateEndpoint is not a network.PrivateLinkService",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec}, ServiceName, infrav1.PrivateLinkServicesReadyCondition).Return([]interface{}{network.PrivateEndpoint{}}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_privatelinkservices.NewMockPrivateLinkServiceScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePrivateLinkService(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no private link services",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete a private link service",
+			expectedError: "",
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec})
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec}, ServiceName, infrav1.PrivateLinkServicesReadyCondition).Return(nil)
+			},
+		},
+		{
+			name:          "fail to delete a private link service",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_privatelinkservices.MockPrivateLinkServiceScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PrivateLinkServiceSpecs().Return([]azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec})
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePrivateLinkServiceSpec}, ServiceName, infrav1.PrivateLinkServicesReadyCondition).Return(internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_privatelinkservices.NewMockPrivateLinkServiceScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
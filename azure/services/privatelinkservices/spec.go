@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+)
+
+// PrivateLinkServiceSpec defines the specification for a private link service in front of an internal load balancer.
+type PrivateLinkServiceSpec struct {
+	Name                      string
+	ResourceGroup             string
+	SubscriptionID            string
+	Location                  string
+	ClusterName               string
+	LoadBalancerName          string
+	FrontendIPConfigNames     []string
+	VNetName                  string
+	VNetResourceGroup         string
+	SubnetName                string
+	VisibleSubscriptions      []string
+	AutoApprovedSubscriptions []string
+	AdditionalTags            infrav1.Tags
+}
+
+// ResourceName returns the name of the private link service.
+func (s *PrivateLinkServiceSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *PrivateLinkServiceSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for private link services.
+func (s *PrivateLinkServiceSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the private link service.
+func (s *PrivateLinkServiceSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
+	visibility := s.visibleSubscriptions()
+	autoApproval := sortedCopy(s.AutoApprovedSubscriptions)
+
+	if existing != nil {
+		existingPLS, ok := existing.(network.PrivateLinkService)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.PrivateLinkService", existing)
+		}
+		if existingPLS.PrivateLinkServiceProperties == nil {
+			return nil, errors.Errorf("private link service %s has no properties", s.Name)
+		}
+
+		// Only the subscriptions of the private link service can be updated.
+		var existingVisibility, existingAutoApproval []string
+		if existingPLS.Visibility != nil && existingPLS.Visibility.Subscriptions != nil {
+			existingVisibility = sortedCopy(*existingPLS.Visibility.Subscriptions)
+		}
+		if existingPLS.AutoApproval != nil && existingPLS.AutoApproval.Subscriptions != nil {
+			existingAutoApproval = sortedCopy(*existingPLS.AutoApproval.Subscriptions)
+		}
+		if equalSubscriptions(visibility, existingVisibility) && equalSubscriptions(autoApproval, existingAutoApproval) {
+			// private link service is up to date, nothing to do
+			return nil, nil
+		}
+
+		// Update a copy of the properties of the existing private link service, so that its IP configurations and
+		// connections are preserved.
+		properties := *existingPLS.PrivateLinkServiceProperties
+		properties.Visibility = &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &visibility}
+		properties.AutoApproval = &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &autoApproval}
+		existingPLS.PrivateLinkServiceProperties = &properties
+		return existingPLS, nil
+	}
+
+	frontendIPConfigurations := make([]network.FrontendIPConfiguration, 0, len(s.FrontendIPConfigNames))
+	for _, name := range s.FrontendIPConfigNames {
+		frontendIPConfigurations = append(frontendIPConfigurations, network.FrontendIPConfiguration{
+			ID: pointer.String(azure.FrontendIPConfigID(s.SubscriptionID, s.ResourceGroup, s.LoadBalancerName, name)),
+		})
+	}
+
+	return network.PrivateLinkService{
+		Name:     pointer.String(s.Name),
+		Location: pointer.String(s.Location),
+		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.ClusterName,
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Name:        pointer.String(s.Name),
+			Additional:  s.AdditionalTags,
+		})),
+		PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+			LoadBalancerFrontendIPConfigurations: &frontendIPConfigurations,
+			IPConfigurations: &[]network.PrivateLinkServiceIPConfiguration{
+				{
+					Name: pointer.String(s.Name + "-ipconfig"),
+					PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+						PrivateIPAllocationMethod: network.Dynamic,
+						PrivateIPAddressVersion:   network.IPv4,
+						Primary:                   pointer.Bool(true),
+						Subnet: &network.Subnet{
+							ID: pointer.String(azure.SubnetID(s.SubscriptionID, s.VNetResourceGroup, s.VNetName, s.SubnetName)),
+						},
+					},
+				},
+			},
+			Visibility:   &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &visibility},
+			AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &autoApproval},
+		},
+	}, nil
+}
+
+// visibleSubscriptions returns the sorted subscriptions the private link service is visible to. The auto approved
+// subscriptions are always visible.
+func (s *PrivateLinkServiceSpec) visibleSubscriptions() []string {
+	seen := make(map[string]struct{}, len(s.VisibleSubscriptions)+len(s.AutoApprovedSubscriptions))
+	subscriptions := make([]string, 0, len(s.VisibleSubscriptions)+len(s.AutoApprovedSubscriptions))
+	for _, subscription := range append(append([]string{}, s.VisibleSubscriptions...), s.AutoApprovedSubscriptions...) {
+		if _, ok := seen[subscription]; ok {
+			continue
+		}
+		seen[subscription] = struct{}{}
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Strings(subscriptions)
+	return subscriptions
+}
+
+// sortedCopy returns a sorted copy of the subscriptions.
+func sortedCopy(subscriptions []string) []string {
+	sorted := make([]string, len(subscriptions))
+	copy(sorted, subscriptions)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// equalSubscriptions returns true if the sorted subscriptions are the same.
+func equalSubscriptions(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package privatelinkservices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2022-05-01/network"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestParameters(t *testing.T) {
+	subnetID := "/subscriptions/123/resourceGroups/my-vnet-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-cluster-controlplane-subnet"
+	frontendIPConfigID := "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/my-cluster-internal-lb/frontendIPConfigurations/my-cluster-internal-lb-frontEnd"
+	visibility := []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"}
+	autoApproval := []string{"11111111-1111-1111-1111-111111111111"}
+
+	existingPLS := func(visible, autoApproved []string) network.PrivateLinkService {
+		return network.PrivateLinkService{
+			Name: pointer.String("my-cluster-apiserver-pls"),
+			PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+				LoadBalancerFrontendIPConfigurations: &[]network.FrontendIPConfiguration{{ID: pointer.String(frontendIPConfigID)}},
+				Visibility:                           &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &visible},
+				AutoApproval:                         &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &autoApproved},
+				Alias:                                pointer.String("my-cluster-apiserver-pls.alias"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:     "private link service does not exist",
+			existing: nil,
+			expected: network.PrivateLinkService{
+				Name:     pointer.String("my-cluster-apiserver-pls"),
+				Location: pointer.String("eastus"),
+				Tags: map[string]*string{
+					"Name": pointer.String("my-cluster-apiserver-pls"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+					"foo": pointer.String("bar"),
+				},
+				PrivateLinkServiceProperties: &network.PrivateLinkServiceProperties{
+					LoadBalancerFrontendIPConfigurations: &[]network.FrontendIPConfiguration{{ID: pointer.String(frontendIPConfigID)}},
+					IPConfigurations: &[]network.PrivateLinkServiceIPConfiguration{
+						{
+							Name: pointer.String("my-cluster-apiserver-pls-ipconfig"),
+							PrivateLinkServiceIPConfigurationProperties: &network.PrivateLinkServiceIPConfigurationProperties{
+								PrivateIPAllocationMethod: network.Dynamic,
+								PrivateIPAddressVersion:   network.IPv4,
+								Primary:                   pointer.Bool(true),
+								Subnet:                    &network.Subnet{ID: pointer.String(subnetID)},
+							},
+						},
+					},
+					Visibility:   &network.PrivateLinkServicePropertiesVisibility{Subscriptions: &visibility},
+					AutoApproval: &network.PrivateLinkServicePropertiesAutoApproval{Subscriptions: &autoApproval},
+				},
+			},
+		},
+		{
+			name:     "noop if the subscriptions of the existing private link service are up to date",
+			existing: existingPLS([]string{"22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111"}, autoApproval),
+			expected: nil,
+		},
+		{
+			name:     "update the subscriptions of the existing private link service",
+			existing: existingPLS([]string{"22222222-2222-2222-2222-222222222222"}, []string{}),
+			expected: existingPLS(visibility, autoApproval),
+		},
+		{
+			name:          "existing is not a private link service",
+			existing:      network.PrivateEndpoint{},
+			expectedError: "network.PrivateEndpoint is not a network.PrivateLinkService",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := fakePrivateLinkServiceSpec
+			result, err := spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(cmp.Diff(tc.expected, result)).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "proximityplacementgroups"
+
+// ProximityPlacementGroupScope defines the scope interface for a proximity placement groups service.
+type ProximityPlacementGroupScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	ProximityPlacementGroupSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope      ProximityPlacementGroupScope
+	TagsGetter async.TagsGetter
+	async.Reconciler
+}
+
+// New creates a new service.
+func New(scope ProximityPlacementGroupScope) *Service {
+	client := newClient(scope)
+	return &Service{
+		Scope:      scope,
+		TagsGetter: tags.NewClient(scope),
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+	}
+}
+
+// Reconcile idempotently creates the proximity placement groups of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.Service.Reconcile")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	specs := s.Scope.ProximityPlacementGroupSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.ProximityPlacementGroupsReadyCondition)
+	return err
+}
+
+// Delete deletes the proximity placement groups created by CAPZ.
+// Azure refuses to delete a proximity placement group while resources are placed in it.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "proximityplacementgroups.Service.Delete")
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
+	defer cancel()
+
+	var managedSpecs []azure.ResourceSpecGetter
+	for _, spec := range s.Scope.ProximityPlacementGroupSpecs() {
+		resourceID := azure.ProximityPlacementGroupID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+		result, err := s.TagsGetter.GetAtScope(ctx, resourceID)
+		if err != nil {
+			if azure.ResourceNotFound(err) {
+				continue
+			}
+			return errors.Wrap(err, "could not get proximity placement group management state")
+		}
+
+		tagsMap := make(map[string]*string)
+		if result.Properties != nil && result.Properties.Tags != nil {
+			tagsMap = result.Properties.Tags
+		}
+
+		if !converters.MapToTags(tagsMap).HasOwned(s.Scope.ClusterName()) {
+			log.V(2).Info("Skipping deletion of unmanaged proximity placement group", "proximityPlacementGroup", resourceID)
+			continue
+		}
+		managedSpecs = append(managedSpecs, spec)
+	}
+
+	if len(managedSpecs) == 0 {
+		return nil
+	}
+
+	return s.DeleteResources(ctx, managedSpecs, ServiceName, infrav1.ProximityPlacementGroupsReadyCondition)
+}
+
+// IsManaged returns always returns true as proximity placement groups are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
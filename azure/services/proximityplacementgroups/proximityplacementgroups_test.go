@@ -0,0 +1,216 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups/mock_proximityplacementgroups"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePPGSpec = ProximityPlacementGroupSpec{
+		Name:          "my-ppg",
+		ResourceGroup: "my-rg",
+		Location:      "eastus",
+		ClusterName:   "my-cluster",
+	}
+	fakePPGSpec2 = ProximityPlacementGroupSpec{
+		Name:          "my-other-ppg",
+		ResourceGroup: "my-rg",
+		Location:      "eastus",
+		ClusterName:   "my-cluster",
+	}
+
+	fakePPGID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+	fakePPGID2 = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-other-ppg"
+
+	managedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+			},
+		},
+	}
+
+	unmanagedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"foo": pointer.String("bar"),
+			},
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+)
+
+func TestReconcileProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no proximity placement groups",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "create proximity placement groups",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPGSpec, &fakePPGSpec2})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePPGSpec, &fakePPGSpec2}, ServiceName, infrav1.ProximityPlacementGroupsReadyCondition).Return([]interface{}{nil, nil}, nil)
+			},
+		},
+		{
+			name:          "fail to create a proximity placement group",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPGSpec})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePPGSpec}, ServiceName, infrav1.ProximityPlacementGroupsReadyCondition).Return([]interface{}{nil}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_proximityplacementgroups.NewMockProximityPlacementGroupScope(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteProximityPlacementGroups(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expect        func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+		expectedError string
+	}{
+		{
+			name:          "noop if no proximity placement groups",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "delete managed proximity placement groups only",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPGSpec, &fakePPGSpec2})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetAtScope(gomockinternal.AContext(), fakePPGID).Return(managedTags, nil)
+				m.GetAtScope(gomockinternal.AContext(), fakePPGID2).Return(unmanagedTags, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePPGSpec}, ServiceName, infrav1.ProximityPlacementGroupsReadyCondition).Return(nil)
+			},
+		},
+		{
+			name:          "skip proximity placement groups that don't exist",
+			expectedError: "",
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPGSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), fakePPGID).Return(resources.TagsResource{}, notFoundError)
+			},
+		},
+		{
+			name:          "fail to delete a proximity placement group",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPGSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				s.ClusterName().AnyTimes().Return("my-cluster")
+				m.GetAtScope(gomockinternal.AContext(), fakePPGID).Return(managedTags, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePPGSpec}, ServiceName, infrav1.ProximityPlacementGroupsReadyCondition).Return(internalError)
+			},
+		},
+		{
+			name:          "fail to get the management state of a proximity placement group",
+			expectedError: "could not get proximity placement group management state: " + internalError.Error(),
+			expect: func(s *mock_proximityplacementgroups.MockProximityPlacementGroupScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ProximityPlacementGroupSpecs().Return([]azure.ResourceSpecGetter{&fakePPGSpec})
+				s.SubscriptionID().AnyTimes().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), fakePPGID).Return(resources.TagsResource{}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_proximityplacementgroups.NewMockProximityPlacementGroupScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proximityplacementgroups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+func TestProximityPlacementGroupParameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		spec          ProximityPlacementGroupSpec
+		existing      interface{}
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name: "proximity placement group does not exist",
+			spec: ProximityPlacementGroupSpec{
+				Name:           "my-ppg",
+				ResourceGroup:  "my-rg",
+				Location:       "eastus",
+				ClusterName:    "my-cluster",
+				AdditionalTags: infrav1.Tags{"foo": "bar"},
+			},
+			existing: nil,
+			expected: compute.ProximityPlacementGroup{
+				Location: pointer.String("eastus"),
+				Tags: map[string]*string{
+					"Name": pointer.String("my-ppg"),
+					"foo":  pointer.String("bar"),
+					"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+				},
+				ProximityPlacementGroupProperties: &compute.ProximityPlacementGroupProperties{
+					ProximityPlacementGroupType: compute.ProximityPlacementGroupTypeStandard,
+				},
+			},
+		},
+		{
+			name:     "noop if the proximity placement group exists",
+			spec:     fakePPGSpec,
+			existing: compute.ProximityPlacementGroup{Name: pointer.String("my-ppg")},
+			expected: nil,
+		},
+		{
+			name:          "existing is not a proximity placement group",
+			spec:          fakePPGSpec,
+			existing:      compute.AvailabilitySet{},
+			expectedError: "compute.AvailabilitySet is not a compute.ProximityPlacementGroup",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(cmp.Diff(tc.expected, result)).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ServiceName is the name of this service.
+const ServiceName = "publicipprefixes"
+
+// PublicIPPrefixScope defines the scope interface for a public IP prefix service.
+type PublicIPPrefixScope interface {
+	azure.Authorizer
+	azure.AsyncStatusUpdater
+	azure.ClusterDescriber
+	PublicIPPrefixSpecs() []azure.ResourceSpecGetter
+}
+
+// Service provides operations on Azure resources.
+type Service struct {
+	Scope PublicIPPrefixScope
+	async.Reconciler
+	async.TagsGetter
+}
+
+// New creates a new service.
+func New(scope PublicIPPrefixScope) *Service {
+	client := NewClient(scope)
+	return &Service{
+		Scope:      scope,
+		TagsGetter: tags.NewClient(scope),
+		Reconciler: async.New(scope, client, client),
+	}
+}
+
+// Name returns the service name.
+func (s *Service) Name() string {
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+	}
+}
+
+// Reconcile idempotently creates the public IP prefixes that don't exist.
+func (s *Service) Reconcile(ctx context.Context) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Reconcile")
+	defer done()
+
+	specs := s.Scope.PublicIPPrefixSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.PublicIPPrefixesReadyCondition)
+	return err
+}
+
+// Delete deletes the public IP prefixes created by CAPZ.
+func (s *Service) Delete(ctx context.Context) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "publicipprefixes.Service.Delete")
+	defer done()
+
+	specs := s.Scope.PublicIPPrefixSpecs()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// Only the public IP prefixes managed by CAPZ are deleted.
+	var managedSpecs []azure.ResourceSpecGetter
+	for _, prefixSpec := range specs {
+		managed, err := s.isPrefixManaged(ctx, prefixSpec)
+		if err != nil && !azure.ResourceNotFound(err) {
+			return errors.Wrap(err, "could not get public IP prefix management state")
+		}
+
+		if !managed {
+			log.V(2).Info("Skipping deletion of unmanaged public IP prefix", "public ip prefix", prefixSpec.ResourceName())
+			continue
+		}
+		managedSpecs = append(managedSpecs, prefixSpec)
+	}
+	if len(managedSpecs) == 0 {
+		return nil
+	}
+
+	return s.DeleteResources(ctx, managedSpecs, ServiceName, infrav1.PublicIPPrefixesReadyCondition)
+}
+
+// isPrefixManaged returns true if the public IP prefix has an owned tag with the cluster name as value,
+// meaning that the prefix's lifecycle is managed.
+func (s *Service) isPrefixManaged(ctx context.Context, spec azure.ResourceSpecGetter) (bool, error) {
+	scope := azure.PublicIPPrefixID(s.Scope.SubscriptionID(), spec.ResourceGroupName(), spec.ResourceName())
+	result, err := s.TagsGetter.GetAtScope(ctx, scope)
+	if err != nil {
+		return false, err
+	}
+
+	tagsMap := make(map[string]*string)
+	if result.Properties != nil && result.Properties.Tags != nil {
+		tagsMap = result.Properties.Tags
+	}
+
+	tags := converters.MapToTags(tagsMap)
+	return tags.HasOwned(s.Scope.ClusterName()), nil
+}
+
+// IsManaged returns always returns true as public IP prefixes are managed on a one-by-one basis.
+func (s *Service) IsManaged(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes/mock_publicipprefixes"
+	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+)
+
+var (
+	fakePublicIPPrefixSpec1 = PublicIPPrefixSpec{
+		Name:           "my-prefix",
+		ResourceGroup:  "my-rg",
+		ClusterName:    "my-cluster",
+		Location:       "centralIndia",
+		PrefixLength:   28,
+		FailureDomains: []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+		AdditionalTags: infrav1.Tags{"foo": "bar"},
+	}
+	fakePublicIPPrefixSpec2 = PublicIPPrefixSpec{
+		Name:           "my-prefix-2",
+		ResourceGroup:  "my-other-rg",
+		ClusterName:    "my-cluster",
+		Location:       "centralIndia",
+		PrefixLength:   30,
+		FailureDomains: []string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+	}
+
+	managedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"foo": pointer.String("bar"),
+				"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+			},
+		},
+	}
+
+	unmanagedTags = resources.TagsResource{
+		Properties: &resources.Tags{
+			Tags: map[string]*string{
+				"foo": pointer.String("bar"),
+			},
+		},
+	}
+
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not Found")
+)
+
+func TestReconcilePublicIPPrefix(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "successfully create public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2}, ServiceName, infrav1.PublicIPPrefixesReadyCondition).Return([]interface{}{nil, nil}, nil)
+			},
+		},
+		{
+			name:          "fail to create a public IP prefix",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2})
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2}, ServiceName, infrav1.PublicIPPrefixesReadyCondition).Return([]interface{}{nil, nil}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeletePublicIPPrefix(t *testing.T) {
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder)
+	}{
+		{
+			name:          "noop if no public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{})
+			},
+		},
+		{
+			name:          "successfully delete managed public IP prefixes and ignore unmanaged public IP prefixes",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec1.ResourceGroupName(), fakePublicIPPrefixSpec1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec2.ResourceGroupName(), fakePublicIPPrefixSpec2.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1}, ServiceName, infrav1.PublicIPPrefixesReadyCondition).Return(nil)
+			},
+		},
+		{
+			name:          "noop if public IP prefixes are unmanaged or already deleted",
+			expectedError: "",
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec1.ResourceGroupName(), fakePublicIPPrefixSpec1.ResourceName())).Return(resources.TagsResource{}, notFoundError)
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec2.ResourceGroupName(), fakePublicIPPrefixSpec2.ResourceName())).Return(unmanagedTags, nil)
+				s.ClusterName().Return("my-cluster")
+			},
+		},
+		{
+			name:          "fail to get public IP prefix management state",
+			expectedError: "could not get public IP prefix management state: " + internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec1.ResourceGroupName(), fakePublicIPPrefixSpec1.ResourceName())).Return(resources.TagsResource{}, internalError)
+			},
+		},
+		{
+			name:          "fail to delete managed public IP prefix",
+			expectedError: internalError.Error(),
+			expect: func(s *mock_publicipprefixes.MockPublicIPPrefixScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.PublicIPPrefixSpecs().Return([]azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2})
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec1.ResourceGroupName(), fakePublicIPPrefixSpec1.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				s.SubscriptionID().Return("123")
+				m.GetAtScope(gomockinternal.AContext(), azure.PublicIPPrefixID("123", fakePublicIPPrefixSpec2.ResourceGroupName(), fakePublicIPPrefixSpec2.ResourceName())).Return(managedTags, nil)
+				s.ClusterName().Return("my-cluster")
+
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePublicIPPrefixSpec1, &fakePublicIPPrefixSpec2}, ServiceName, infrav1.PublicIPPrefixesReadyCondition).Return(internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_publicipprefixes.NewMockPublicIPPrefixScope(mockCtrl)
+			tagsGetterMock := mock_async.NewMockTagsGetter(mockCtrl)
+			reconcilerMock := mock_async.NewMockReconciler(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), tagsGetterMock.EXPECT(), reconcilerMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				TagsGetter: tagsGetterMock,
+				Reconciler: reconcilerMock,
+			}
+
+			err := s.Delete(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefixes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var fakePublicIPPrefix = network.PublicIPPrefix{
+	Sku:      &network.PublicIPPrefixSku{Name: network.PublicIPPrefixSkuNameStandard},
+	Location: pointer.String("centralIndia"),
+	Tags: map[string]*string{
+		"Name": pointer.String("my-prefix"),
+		"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+		"foo": pointer.String("bar"),
+	},
+	PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+		PublicIPAddressVersion: network.IPVersionIPv4,
+		PrefixLength:           pointer.Int32(28),
+	},
+	Zones: &[]string{"failure-domain-id-1", "failure-domain-id-2", "failure-domain-id-3"},
+}
+
+func TestParameters(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existing      interface{}
+		spec          PublicIPPrefixSpec
+		expected      interface{}
+		expectedError string
+	}{
+		{
+			name:          "noop if public IP prefix exists",
+			existing:      fakePublicIPPrefix,
+			spec:          fakePublicIPPrefixSpec1, // In publicipprefixes_test.go
+			expected:      nil,
+			expectedError: "",
+		},
+		{
+			name:          "public IP prefix does not exist",
+			existing:      nil,
+			spec:          fakePublicIPPrefixSpec1,
+			expected:      fakePublicIPPrefix,
+			expectedError: "",
+		},
+		{
+			name:          "existing is not a public IP prefix",
+			existing:      network.PublicIPAddress{},
+			spec:          fakePublicIPPrefixSpec1,
+			expected:      nil,
+			expectedError: "network.PublicIPAddress is not a network.PublicIPPrefix",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Diff between expected result and actual result:\n%s", cmp.Diff(tc.expected, result))
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicips
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/cloudtest"
+)
+
+func TestAzureClientAgainstFakeARM(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	arm := cloudtest.NewARMServer()
+	defer arm.Close()
+	arm.SetResource("/subscriptions/123/resourceGroups/my-rg", map[string]interface{}{"location": "eastus"})
+	client := &AzureClient{newPublicIPAddressesClient("123", arm.URL(), autorest.NullAuthorizer{})}
+
+	spec := &PublicIPSpec{
+		Name:           "my-publicip",
+		ResourceGroup:  "my-rg",
+		ClusterName:    "my-cluster",
+		Location:       "eastus",
+		FailureDomains: []string{"1"},
+	}
+	params, err := spec.Parameters(ctx, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Operations that complete within the Azure call timeout return the result directly.
+	result, future, err := client.CreateOrUpdateAsync(ctx, spec, params)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(future).To(BeNil())
+	g.Expect(result.(network.PublicIPAddress).ProvisioningState).To(Equal(network.ProvisioningStateSucceeded))
+
+	// Operations that don't complete in time return a future that can be stored in status and resumed later.
+	arm.SetLongRunning(100, time.Second)
+	_, future, err = client.CreateOrUpdateAsync(ctx, spec, params)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(future).NotTo(BeNil())
+	stored, err := converters.SDKToFuture(future, infrav1.PutFuture, ServiceName, spec.ResourceName(), spec.ResourceGroupName())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	arm.SetLongRunning(0, 0)
+	_, _, err = client.CreateOrUpdateAsync(ctx, spec, params)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("AnotherOperationInProgress"))
+
+	resumed, err := converters.FutureToSDK(*stored)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Eventually(func() (bool, error) {
+		return client.IsDone(ctx, resumed)
+	}).WithTimeout(10 * time.Second).Should(BeTrue())
+	result, err = client.Result(ctx, resumed, infrav1.PutFuture)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.(network.PublicIPAddress).Name).To(Equal(&spec.Name))
+
+	g.Expect(client.DeleteAsync(ctx, spec)).To(BeNil())
+	_, err = client.Get(ctx, spec)
+	g.Expect(err).To(HaveOccurred())
+}
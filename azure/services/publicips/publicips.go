@@ -24,11 +24,14 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "publicips"
+// ServiceName is the name of this service.
+const ServiceName = "publicips"
 
 // PublicIPScope defines the scope interface for a public IP service.
 type PublicIPScope interface {
@@ -60,7 +63,15 @@ func New(scope PublicIPScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+		publicipprefixes.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a public IP.
@@ -73,20 +84,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of PublicIPSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, publicIPSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, publicIPSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdatePutStatus(infrav1.PublicIPsReadyCondition, serviceName, result)
-	return result
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.PublicIPsReadyCondition)
+	return err
 }
 
 // Delete deletes the public IP with the provided scope.
@@ -99,12 +98,8 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	hasManagedPublicIPs := false
-
-	// We go through the list of VnetPeeringSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
+	// Only the public IPs managed by CAPZ are deleted.
+	var managedSpecs []azure.ResourceSpecGetter
 	for _, publicIPSpec := range specs {
 		managed, err := s.isIPManaged(ctx, publicIPSpec)
 		if err != nil && !azure.ResourceNotFound(err) {
@@ -115,23 +110,13 @@ func (s *Service) Delete(ctx context.Context) error {
 			log.V(2).Info("Skipping IP deletion for unmanaged public IP", "public ip", publicIPSpec.ResourceName())
 			continue
 		}
-
-		log.V(2).Info("deleting public IP", "public ip", publicIPSpec.ResourceName())
-		hasManagedPublicIPs = true
-		if err := s.DeleteResource(ctx, publicIPSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-
-		log.V(2).Info("deleted public IP", "public ip", publicIPSpec.ResourceName())
+		managedSpecs = append(managedSpecs, publicIPSpec)
 	}
-
-	if hasManagedPublicIPs {
-		s.Scope.UpdateDeleteStatus(infrav1.PublicIPsReadyCondition, serviceName, result)
+	if len(managedSpecs) == 0 {
+		return nil
 	}
 
-	return result
+	return s.DeleteResources(ctx, managedSpecs, ServiceName, infrav1.PublicIPsReadyCondition)
 }
 
 // isIPManaged returns true if the IP has an owned tag with the cluster name as value,
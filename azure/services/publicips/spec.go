@@ -38,6 +38,8 @@ type PublicIPSpec struct {
 	FailureDomains []string
 	AdditionalTags infrav1.Tags
 	IPTags         []infrav1.IPTag
+	// PublicIPPrefixID is the ID of the public IP prefix to allocate the public IP from, if any.
+	PublicIPPrefixID string
 }
 
 // ResourceName returns the name of the public IP.
@@ -79,6 +81,11 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 		}
 	}
 
+	var publicIPPrefix *network.SubResource
+	if s.PublicIPPrefixID != "" {
+		publicIPPrefix = &network.SubResource{ID: pointer.String(s.PublicIPPrefixID)}
+	}
+
 	return network.PublicIPAddress{
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
@@ -94,6 +101,7 @@ func (s *PublicIPSpec) Parameters(ctx context.Context, existing interface{}) (pa
 			PublicIPAllocationMethod: network.IPAllocationMethodStatic,
 			DNSSettings:              dnsSettings,
 			IPTags:                   converters.IPTagsToSDK(s.IPTags),
+			PublicIPPrefix:           publicIPPrefix,
 		},
 		Zones: &s.FailureDomains,
 	}, nil
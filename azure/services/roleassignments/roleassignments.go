@@ -141,10 +141,19 @@ func (s *Service) getVMSSPrincipalID(ctx context.Context) (*string, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "roleassignments.Service.getVMPrincipalID")
 	defer done()
 	log.V(2).Info("fetching principal ID for VMSS")
-	resultVMSS, err := s.virtualMachineScaleSetClient.Get(ctx, s.Scope.ResourceGroup(), s.Scope.Name())
+	spec := &scalesets.ScaleSetSpec{
+		ScaleSetSpec:  azure.ScaleSetSpec{Name: s.Scope.Name()},
+		ResourceGroup: s.Scope.ResourceGroup(),
+	}
+
+	resultVMSSIface, err := s.virtualMachineScaleSetClient.Get(ctx, spec)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get principal ID for VMSS")
 	}
+	resultVMSS, ok := resultVMSSIface.(compute.VirtualMachineScaleSet)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSet", resultVMSSIface)
+	}
 	return resultVMSS.Identity.PrincipalID, nil
 }
 
@@ -23,11 +23,13 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "routetables"
+// ServiceName is the name of this service.
+const ServiceName = "routetables"
 
 // RouteTableScope defines the scope interface for route table service.
 type RouteTableScope interface {
@@ -54,7 +56,14 @@ func New(scope RouteTableScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a set of route tables.
@@ -65,8 +74,6 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
 	defer cancel()
 
-	var resErr error
-
 	if managed, err := s.IsManaged(ctx); err == nil && !managed {
 		log.V(4).Info("Skipping route tables reconcile in custom vnet mode")
 		return nil
@@ -79,19 +86,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of route tables to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	for _, rtSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, rtSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || resErr == nil {
-				resErr = err
-			}
-		}
-	}
-
-	s.Scope.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, resErr)
-	return resErr
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.RouteTablesReadyCondition)
+	return err
 }
 
 // Delete deletes route tables.
@@ -116,19 +112,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of RouteTableSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one
-	// order of precedence is: error deleting -> deleting in progress -> deleted (no error)
-	var result error
-	for _, rtSpec := range specs {
-		if err := s.DeleteResource(ctx, rtSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-	s.Scope.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.RouteTablesReadyCondition)
 }
 
 // IsManaged returns true if the route tables' lifecycles are managed.
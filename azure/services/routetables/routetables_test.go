@@ -71,9 +71,7 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRT, &fakeRT2}, ServiceName, infrav1.RouteTablesReadyCondition).Return([]interface{}{nil, nil}, nil)
 			},
 		},
 		{
@@ -82,9 +80,7 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, nil)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRT, &fakeRT2}, ServiceName, infrav1.RouteTablesReadyCondition).Return([]interface{}{nil, nil}, errFake)
 			},
 		},
 		{
@@ -93,9 +89,7 @@ func TestReconcileRouteTables(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil, errFake)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil, notDoneError)
-				s.UpdatePutStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRT, &fakeRT2}, ServiceName, infrav1.RouteTablesReadyCondition).Return([]interface{}{nil, nil}, errFake)
 			},
 		},
 		{
@@ -156,9 +150,7 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRT, &fakeRT2}, ServiceName, infrav1.RouteTablesReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -167,9 +159,7 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRT, &fakeRT2}, ServiceName, infrav1.RouteTablesReadyCondition).Return(errFake)
 			},
 		},
 		{
@@ -178,9 +168,7 @@ func TestDeleteRouteTable(t *testing.T) {
 			expect: func(s *mock_routetables.MockRouteTableScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().Return(true)
 				s.RouteTableSpecs().Return([]azure.ResourceSpecGetter{&fakeRT, &fakeRT2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT, serviceName).Return(errFake)
-				r.DeleteResource(gomockinternal.AContext(), &fakeRT2, serviceName).Return(notDoneError)
-				s.UpdateDeleteStatus(infrav1.RouteTablesReadyCondition, serviceName, errFake)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRT, &fakeRT2}, ServiceName, infrav1.RouteTablesReadyCondition).Return(errFake)
 			},
 		},
 		{
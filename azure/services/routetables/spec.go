@@ -33,8 +33,17 @@ type RouteTableSpec struct {
 	Location       string
 	ClusterName    string
 	AdditionalTags infrav1.Tags
+	// NextHopIPAddress is the IP address of the virtual appliance the default route of the route table points to.
+	// The route table has no default route when it is empty.
+	NextHopIPAddress string
 }
 
+// defaultRouteName is the name of the route of the egress traffic to the virtual appliance.
+const defaultRouteName = "default-route"
+
+// defaultRouteAddressPrefix is the address prefix of the route of the egress traffic to the virtual appliance.
+const defaultRouteAddressPrefix = "0.0.0.0/0"
+
 // ResourceName returns the name of the route table.
 func (s *RouteTableSpec) ResourceName() string {
 	return s.Name
@@ -53,16 +62,37 @@ func (s *RouteTableSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the route table.
 func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.RouteTable); !ok {
+		existingRouteTable, ok := existing.(network.RouteTable)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.RouteTable", existing)
 		}
 		// route table already exists
-		// currently don't support specifying your own routes via spec.
-		return nil, nil
+		// currently don't support specifying your own routes via spec, only the default route to a virtual appliance.
+		if s.NextHopIPAddress == "" || existingRouteTable.RouteTablePropertiesFormat == nil || s.hasDefaultRoute(existingRouteTable.Routes) {
+			return nil, nil
+		}
+		// Keep the routes managed by others, e.g. the cloud provider, and replace the default route.
+		routes := []network.Route{s.defaultRoute()}
+		if existingRouteTable.Routes != nil {
+			for _, route := range *existingRouteTable.Routes {
+				if route.RoutePropertiesFormat == nil || pointer.StringDeref(route.AddressPrefix, "") != defaultRouteAddressPrefix {
+					routes = append(routes, route)
+				}
+			}
+		}
+		properties := *existingRouteTable.RouteTablePropertiesFormat
+		properties.Routes = &routes
+		existingRouteTable.RouteTablePropertiesFormat = &properties
+		return existingRouteTable, nil
+	}
+
+	properties := network.RouteTablePropertiesFormat{}
+	if s.NextHopIPAddress != "" {
+		properties.Routes = &[]network.Route{s.defaultRoute()}
 	}
 	return network.RouteTable{
 		Location:                   pointer.String(s.Location),
-		RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{},
+		RouteTablePropertiesFormat: &properties,
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
 			Lifecycle:   infrav1.ResourceLifecycleOwned,
@@ -71,3 +101,31 @@ func (s *RouteTableSpec) Parameters(ctx context.Context, existing interface{}) (
 		})),
 	}, nil
 }
+
+// defaultRoute returns the route of the egress traffic to the virtual appliance.
+func (s *RouteTableSpec) defaultRoute() network.Route {
+	return network.Route{
+		Name: pointer.String(defaultRouteName),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    pointer.String(defaultRouteAddressPrefix),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: pointer.String(s.NextHopIPAddress),
+		},
+	}
+}
+
+// hasDefaultRoute returns true if the routes contain the route of the egress traffic to the virtual appliance.
+func (s *RouteTableSpec) hasDefaultRoute(routes *[]network.Route) bool {
+	if routes == nil {
+		return false
+	}
+	for _, route := range *routes {
+		if route.RoutePropertiesFormat != nil &&
+			pointer.StringDeref(route.AddressPrefix, "") == defaultRouteAddressPrefix &&
+			route.NextHopType == network.RouteNextHopTypeVirtualAppliance &&
+			pointer.StringDeref(route.NextHopIPAddress, "") == s.NextHopIPAddress {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var (
+	fakeUDRRouteTableSpec = RouteTableSpec{
+		Name:             "my-routetable",
+		ResourceGroup:    "my-rg",
+		Location:         "westus",
+		ClusterName:      "my-cluster",
+		NextHopIPAddress: "10.10.0.4",
+	}
+
+	fakeDefaultRoute = network.Route{
+		Name: pointer.String("default-route"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    pointer.String("0.0.0.0/0"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: pointer.String("10.10.0.4"),
+		},
+	}
+
+	fakePodRoute = network.Route{
+		Name: pointer.String("pod-route"),
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:    pointer.String("192.168.0.0/24"),
+			NextHopType:      network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress: pointer.String("10.1.0.4"),
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *RouteTableSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "route table without routes",
+			spec:     &fakeRT,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result.(network.RouteTable).Routes).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "route table with a default route to the virtual appliance",
+			spec:     &fakeUDRRouteTableSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.RouteTable{}))
+				g.Expect(result.(network.RouteTable).Routes).To(Equal(&[]network.Route{fakeDefaultRoute}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing route table is left untouched without a virtual appliance",
+			spec: &fakeRT,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &[]network.Route{fakePodRoute}},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "existing route table with the default route is up to date",
+			spec: &fakeUDRRouteTableSpec,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &[]network.Route{fakePodRoute, fakeDefaultRoute}},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "default route is added to an existing route table, keeping the other routes",
+			spec: &fakeUDRRouteTableSpec,
+			existing: network.RouteTable{
+				Name:                       pointer.String("my-routetable"),
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &[]network.Route{fakePodRoute}},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.RouteTable{
+					Name:                       pointer.String("my-routetable"),
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &[]network.Route{fakeDefaultRoute, fakePodRoute}},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "default route to another next hop is replaced",
+			spec: &fakeUDRRouteTableSpec,
+			existing: network.RouteTable{
+				RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &[]network.Route{
+					{
+						Name: pointer.String("internet"),
+						RoutePropertiesFormat: &network.RoutePropertiesFormat{
+							AddressPrefix: pointer.String("0.0.0.0/0"),
+							NextHopType:   network.RouteNextHopTypeInternet,
+						},
+					},
+				}},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.RouteTable{
+					RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &[]network.Route{fakeDefaultRoute}},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing is not a route table",
+			spec:     &fakeUDRRouteTableSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a network.RouteTable",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
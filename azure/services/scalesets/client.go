@@ -18,7 +18,6 @@ package scalesets
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -30,7 +29,6 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
-	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -39,35 +37,19 @@ import (
 type Client interface {
 	List(context.Context, string) ([]compute.VirtualMachineScaleSet, error)
 	ListInstances(context.Context, string, string) ([]compute.VirtualMachineScaleSetVM, error)
-	Get(context.Context, string, string) (compute.VirtualMachineScaleSet, error)
-	CreateOrUpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSet) (*infrav1.Future, error)
-	UpdateAsync(context.Context, string, string, compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error)
-	GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSet, error)
+	Get(context.Context, azure.ResourceSpecGetter) (interface{}, error)
+	CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
+	DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
+	IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error)
+	Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error)
 	UpdateInstances(context.Context, string, string, []string) error
-	DeleteAsync(context.Context, string, string) (*infrav1.Future, error)
 }
 
-type (
-	// AzureClient contains the Azure go-sdk Client.
-	AzureClient struct {
-		scalesetvms compute.VirtualMachineScaleSetVMsClient
-		scalesets   compute.VirtualMachineScaleSetsClient
-	}
-
-	genericScaleSetFuture interface {
-		DoneWithContext(ctx context.Context, sender autorest.Sender) (done bool, err error)
-		Result(client compute.VirtualMachineScaleSetsClient) (vmss compute.VirtualMachineScaleSet, err error)
-	}
-
-	genericScaleSetFutureImpl struct {
-		azureautorest.FutureAPI
-		result func(client compute.VirtualMachineScaleSetsClient) (vmss compute.VirtualMachineScaleSet, err error)
-	}
-
-	deleteResultAdapter struct {
-		compute.VirtualMachineScaleSetsDeleteFuture
-	}
-)
+// AzureClient contains the Azure go-sdk Client.
+type AzureClient struct {
+	scalesetvms compute.VirtualMachineScaleSetVMsClient
+	scalesets   compute.VirtualMachineScaleSetsClient
+}
 
 var _ Client = &AzureClient{}
 
@@ -136,131 +118,68 @@ func (ac *AzureClient) List(ctx context.Context, resourceGroupName string) ([]co
 }
 
 // Get retrieves information about the model view of a virtual machine scale set.
-func (ac *AzureClient) Get(ctx context.Context, resourceGroupName, vmssName string) (compute.VirtualMachineScaleSet, error) {
+func (ac *AzureClient) Get(ctx context.Context, spec azure.ResourceSpecGetter) (result interface{}, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.Get")
 	defer done()
 
-	return ac.scalesets.Get(ctx, resourceGroupName, vmssName, "")
+	return ac.scalesets.Get(ctx, spec.ResourceGroupName(), spec.ResourceName(), "")
 }
 
-// CreateOrUpdateAsync the operation to create or update a virtual machine scale set without waiting for the operation
-// to complete.
-func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, resourceGroupName, vmssName string, vmss compute.VirtualMachineScaleSet) (*infrav1.Future, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.CreateOrUpdateAsync")
-	defer done()
-
-	future, err := ac.scalesets.CreateOrUpdate(ctx, resourceGroupName, vmssName, vmss)
-	if err != nil {
-		return nil, err
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
-	defer cancel()
-
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
-	if err != nil {
-		// if an error occurs, return the future.
-		// this means the long-running operation didn't finish in the specified timeout.
-		return converters.SDKToFuture(&future, infrav1.PutFuture, serviceName, vmssName, resourceGroupName)
-	}
-
-	// todo: this returns the result VMSS, we should use it
-	_, err = future.Result(ac.scalesets)
-
-	// if the operation completed, return a nil future.
-	return nil, err
-}
-
-// UpdateAsync update a VM scale set without waiting for the result of the operation. UpdateAsync sends a PATCH
-// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// CreateOrUpdateAsync creates or updates a virtual machine scale set asynchronously. A scale set is created with a PUT
+// request when the parameters are a compute.VirtualMachineScaleSet, and updated with a PATCH request when they are a
+// compute.VirtualMachineScaleSetUpdate, so that updates do not overwrite the fields modified by the cloud provider.
+// If the request is accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
-//
-// Parameters:
-//
-//	resourceGroupName - the name of the resource group.
-//	vmssName - the name of the VM scale set to create or update. parameters - the scale set object.
-func (ac *AzureClient) UpdateAsync(ctx context.Context, resourceGroupName, vmssName string, parameters compute.VirtualMachineScaleSetUpdate) (*infrav1.Future, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.UpdateAsync")
+func (ac *AzureClient) CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.CreateOrUpdateAsync")
 	defer done()
 
-	future, err := ac.scalesets.Update(ctx, resourceGroupName, vmssName, parameters)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed updating vmss named %q", vmssName)
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
-	defer cancel()
-
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
-	if err != nil {
-		// if an error occurs, return the future.
-		// this means the long-running operation didn't finish in the specified timeout.
-		return converters.SDKToFuture(&future, infrav1.PatchFuture, serviceName, vmssName, resourceGroupName)
-	}
-	// todo: this returns the result VMSS, we should use it
-	_, err = future.Result(ac.scalesets)
-
-	// if the operation completed, return a nil future.
-	return nil, err
-}
-
-// GetResultIfDone fetches the result of a long-running operation future if it is done.
-func (ac *AzureClient) GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSet, error) {
-	var genericFuture genericScaleSetFuture
-	futureData, err := base64.URLEncoding.DecodeString(future.Data)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, errors.Wrap(err, "failed to base64 decode future data")
-	}
-
-	switch future.Type {
-	case infrav1.PatchFuture:
-		var future compute.VirtualMachineScaleSetsUpdateFuture
-		if err := json.Unmarshal(futureData, &future); err != nil {
-			return compute.VirtualMachineScaleSet{}, errors.Wrap(err, "failed to unmarshal future data")
+	switch params := parameters.(type) {
+	case compute.VirtualMachineScaleSet:
+		createFuture, err := ac.scalesets.CreateOrUpdate(ctx, spec.ResourceGroupName(), spec.ResourceName(), params)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		genericFuture = &genericScaleSetFutureImpl{
-			FutureAPI: &future,
-			result:    future.Result,
-		}
-	case infrav1.PutFuture:
-		var future compute.VirtualMachineScaleSetsCreateOrUpdateFuture
-		if err := json.Unmarshal(futureData, &future); err != nil {
-			return compute.VirtualMachineScaleSet{}, errors.Wrap(err, "failed to unmarshal future data")
-		}
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+		defer cancel()
 
-		genericFuture = &genericScaleSetFutureImpl{
-			FutureAPI: &future,
-			result:    future.Result,
-		}
-	case infrav1.DeleteFuture:
-		var future compute.VirtualMachineScaleSetsDeleteFuture
-		if err := json.Unmarshal(futureData, &future); err != nil {
-			return compute.VirtualMachineScaleSet{}, errors.Wrap(err, "failed to unmarshal future data")
+		err = createFuture.WaitForCompletionRef(ctx, ac.scalesets.Client)
+		if err != nil {
+			// if an error occurs, return the future.
+			// this means the long-running operation didn't finish in the specified timeout.
+			return nil, &createFuture, err
 		}
+		result, err = createFuture.Result(ac.scalesets)
+		// if the operation completed, return a nil future
+		return result, nil, err
 
-		genericFuture = &deleteResultAdapter{
-			VirtualMachineScaleSetsDeleteFuture: future,
+	case compute.VirtualMachineScaleSetUpdate:
+		updateFuture, err := ac.scalesets.Update(ctx, spec.ResourceGroupName(), spec.ResourceName(), params)
+		if err != nil {
+			if azure.ResourceConflict(err) {
+				// Another operation is ongoing on the scale set, retry once it is likely to be done.
+				return nil, nil, azure.WithTransientError(err, 30*time.Second)
+			}
+			return nil, nil, err
 		}
-	default:
-		return compute.VirtualMachineScaleSet{}, errors.Errorf("unknown future type %q", future.Type)
-	}
 
-	done, err := genericFuture.DoneWithContext(ctx, ac.scalesets)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, errors.Wrap(err, "failed checking if the operation was complete")
-	}
+		ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+		defer cancel()
 
-	if !done {
-		return compute.VirtualMachineScaleSet{}, azure.WithTransientError(azure.NewOperationNotDoneError(future), 15*time.Second)
-	}
+		err = updateFuture.WaitForCompletionRef(ctx, ac.scalesets.Client)
+		if err != nil {
+			// if an error occurs, return the future.
+			// this means the long-running operation didn't finish in the specified timeout.
+			return nil, &updateFuture, err
+		}
+		result, err = updateFuture.Result(ac.scalesets)
+		// if the operation completed, return a nil future
+		return result, nil, err
 
-	vmss, err := genericFuture.Result(ac.scalesets)
-	if err != nil {
-		return vmss, errors.Wrap(err, "failed fetching the result of operation for vmss")
+	default:
+		return nil, nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSet or a compute.VirtualMachineScaleSetUpdate", parameters)
 	}
-
-	return vmss, nil
 }
 
 // UpdateInstances update instances of a VM scale set.
@@ -286,43 +205,66 @@ func (ac *AzureClient) UpdateInstances(ctx context.Context, resourceGroupName, v
 // DeleteAsync is the operation to delete a virtual machine scale set asynchronously. DeleteAsync sends a DELETE
 // request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
-//
-// Parameters:
-//
-//	resourceGroupName - the name of the resource group.
-//	vmssName - the name of the VM scale set to create or update. parameters - the scale set object.
-func (ac *AzureClient) DeleteAsync(ctx context.Context, resourceGroupName, vmssName string) (*infrav1.Future, error) {
+func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.DeleteAsync")
 	defer done()
 
-	future, err := ac.scalesets.Delete(ctx, resourceGroupName, vmssName, pointer.Bool(false))
+	deleteFuture, err := ac.scalesets.Delete(ctx, spec.ResourceGroupName(), spec.ResourceName(), pointer.Bool(false))
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed deleting vmss named %q", vmssName)
+		return nil, err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
 	defer cancel()
 
-	err = future.WaitForCompletionRef(ctx, ac.scalesets.Client)
+	err = deleteFuture.WaitForCompletionRef(ctx, ac.scalesets.Client)
 	if err != nil {
 		// if an error occurs, return the future.
 		// this means the long-running operation didn't finish in the specified timeout.
-		return converters.SDKToFuture(&future, infrav1.DeleteFuture, serviceName, vmssName, resourceGroupName)
+		return &deleteFuture, err
 	}
-	_, err = future.Result(ac.scalesets)
-
+	_, err = deleteFuture.Result(ac.scalesets)
 	// if the operation completed, return a nil future.
 	return nil, err
 }
 
-// Result wraps the delete result so that we can treat it generically. The only thing we care about is if the delete
-// was successful. If it wasn't, an error will be returned.
-func (da *deleteResultAdapter) Result(client compute.VirtualMachineScaleSetsClient) (compute.VirtualMachineScaleSet, error) {
-	_, err := da.VirtualMachineScaleSetsDeleteFuture.Result(client)
-	return compute.VirtualMachineScaleSet{}, err
+// IsDone returns true if the long-running operation has completed.
+func (ac *AzureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.IsDone")
+	defer done()
+
+	return future.DoneWithContext(ctx, ac.scalesets)
 }
 
-// Result returns the Result so that we can treat it generically.
-func (g *genericScaleSetFutureImpl) Result(client compute.VirtualMachineScaleSetsClient) (compute.VirtualMachineScaleSet, error) {
-	return g.result(client)
+// Result fetches the result of a long-running operation future.
+func (ac *AzureClient) Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "scalesets.AzureClient.Result")
+	defer done()
+
+	if future == nil {
+		return nil, errors.Errorf("cannot get result from nil future")
+	}
+
+	switch futureType {
+	case infrav1.PutFuture:
+		// Marshal and Unmarshal the future to put it into the correct future type so we can access the Result function.
+		// Updates of a scale set are also tracked with a PUT future, which is fine as both operations return the
+		// scale set once they are done.
+		var createFuture *compute.VirtualMachineScaleSetsCreateOrUpdateFuture
+		jsonData, err := future.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal future")
+		}
+		if err := json.Unmarshal(jsonData, &createFuture); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal future data")
+		}
+		return createFuture.Result(ac.scalesets)
+
+	case infrav1.DeleteFuture:
+		// Delete does not return a result scale set.
+		return nil, nil
+
+	default:
+		return nil, errors.Errorf("unknown future type %q", futureType)
+	}
 }
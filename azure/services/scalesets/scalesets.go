@@ -18,20 +18,16 @@ package scalesets
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
-	"strconv"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/pkg/errors"
-	"k8s.io/utils/pointer"
 	azprovider "sigs.k8s.io/cloud-provider-azure/pkg/provider"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
-	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
 	"sigs.k8s.io/cluster-api-provider-azure/util/slice"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -59,15 +55,18 @@ type (
 	Service struct {
 		Scope ScaleSetScope
 		Client
+		async.Reconciler
 		resourceSKUCache *resourceskus.Cache
 	}
 )
 
 // New creates a new service.
 func New(scope ScaleSetScope, skuCache *resourceskus.Cache) *Service {
+	client := NewClient(scope)
 	return &Service{
-		Client:           NewClient(scope),
+		Client:           client,
 		Scope:            scope,
+		Reconciler:       async.New(scope, client, client),
 		resourceSKUCache: skuCache,
 	}
 }
@@ -87,79 +86,83 @@ func (s *Service) Reconcile(ctx context.Context) (retErr error) {
 		return err
 	}
 
-	var err error
-
 	scaleSetSpec := s.Scope.ScaleSetSpec()
+	resourceSpec := &ScaleSetSpec{
+		ScaleSetSpec:  scaleSetSpec,
+		ResourceGroup: s.Scope.ResourceGroup(),
+	}
 
-	// check if there is an ongoing long running operation
-	var fetchedVMSS *azure.VMSS
-	future := s.Scope.GetLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PutFuture)
-	if future == nil {
-		future = s.Scope.GetLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PatchFuture)
+	// Updates of a scale set used to be tracked with a PATCH future, which the async reconciler does not know about.
+	// Track them as PUT futures so that an update started by a previous version of the controller is resumed.
+	if future := s.Scope.GetLongRunningOperationState(scaleSetSpec.Name, serviceName, infrav1.PatchFuture); future != nil {
+		future.Type = infrav1.PutFuture
+		s.Scope.SetLongRunningOperationState(future)
 	}
 
+	var fetchedVMSS *azure.VMSS
 	defer func() {
 		// save the updated state of the VMSS for the MachinePoolScope to use for updating K8s state
 		if fetchedVMSS == nil {
-			fetchedVMSS, err = s.getVirtualMachineScaleSet(ctx, scaleSetSpec.Name)
+			var err error
+			fetchedVMSS, err = s.getVirtualMachineScaleSet(ctx, resourceSpec)
 			if err != nil && !azure.ResourceNotFound(err) {
 				log.Error(err, "failed to get vmss in deferred update")
 			}
 		}
 
 		if fetchedVMSS != nil {
-			// Transform the VMSS resource representation to conform to the cloud-provider-azure representation
-			providerID, err := azprovider.ConvertResourceGroupNameToLower(azure.ProviderIDPrefix + fetchedVMSS.ID)
-			if err != nil {
-				log.Error(err, "failed to parse VMSS ID", "ID", fetchedVMSS.ID)
-			}
-			s.Scope.SetProviderID(providerID)
-			s.Scope.SetVMSSState(fetchedVMSS)
+			s.setVMSSState(ctx, fetchedVMSS)
 		}
 	}()
 
-	if future == nil {
-		fetchedVMSS, err = s.getVirtualMachineScaleSet(ctx, scaleSetSpec.Name)
-	} else {
-		fetchedVMSS, err = s.getVirtualMachineScaleSetIfDone(ctx, future)
-	}
-
-	switch {
-	case err != nil && !azure.ResourceNotFound(err):
-		// There was an error and it was not an HTTP 404 not found. This is either a transient error, like long running operation not done, or an Azure service error.
-		return errors.Wrapf(err, "failed to get VMSS %s", scaleSetSpec.Name)
-	case err != nil && azure.ResourceNotFound(err):
-		// HTTP(404) resource was not found, so we need to create it with a PUT
-		future, err = s.createVMSS(ctx)
-		if err != nil {
-			return errors.Wrap(err, "failed to start creating VMSS")
+	// Only the name and resource group of the scale set are needed to resume an ongoing operation.
+	var spec azure.ResourceSpecGetter = resourceSpec
+	if s.Scope.GetLongRunningOperationState(scaleSetSpec.Name, serviceName, infrav1.PutFuture) == nil {
+		// Reconcile the replicas of an existing scale set before computing its desired capacity.
+		var instances []compute.VirtualMachineScaleSetVM
+		existing, err := s.Client.Get(ctx, resourceSpec)
+		switch {
+		case err != nil && !azure.ResourceNotFound(err):
+			return errors.Wrapf(err, "failed to get VMSS %s", scaleSetSpec.Name)
+		case err == nil:
+			vmss, ok := existing.(compute.VirtualMachineScaleSet)
+			if !ok {
+				return errors.Errorf("%T is not a compute.VirtualMachineScaleSet", existing)
+			}
+			instances, err = s.Client.ListInstances(ctx, resourceSpec.ResourceGroupName(), resourceSpec.ResourceName())
+			if err != nil {
+				return errors.Wrap(err, "failed to list instances")
+			}
+			if err := s.Scope.ReconcileReplicas(ctx, converters.SDKToVMSS(vmss, instances)); err != nil {
+				return errors.Wrap(err, "unable to reconcile replicas")
+			}
 		}
-	case err == nil:
-		// HTTP(200)
-		// VMSS already exists and may have changes; update it with a PATCH
-		// we do this to avoid overwriting fields in networkProfile modified by cloud-provider
-		future, err = s.patchVMSSIfNeeded(ctx, fetchedVMSS)
+
+		spec, err = s.scaleSetSpec(ctx, instances)
 		if err != nil {
-			return errors.Wrap(err, "failed to start updating VMSS")
+			return err
 		}
 	}
 
-	// Try to get the VMSS to update status if we have created a long running operation. If the VMSS is still in a long
-	// running operation, getVirtualMachineScaleSetIfDone will return an azure.WithTransientError and requeue.
-	if future != nil {
-		fetchedVMSS, err = s.getVirtualMachineScaleSetIfDone(ctx, future)
-		if err != nil {
-			return errors.Wrapf(err, "failed to get VMSS %s after create or update", scaleSetSpec.Name)
-		}
+	result, err := s.CreateOrUpdateResource(ctx, spec, serviceName)
+	// The VMSS extensions are installed with the scale set, so the bootstrap succeeded once the scale set is created.
+	s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, err)
+	if err != nil {
+		return err
 	}
 
-	// If we get to here, we have completed any long running VMSS operations (creates / updates)
-	s.Scope.DeleteLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PutFuture)
-	s.Scope.DeleteLongRunningOperationState(s.Scope.ScaleSetSpec().Name, serviceName, infrav1.PatchFuture)
+	if result != nil {
+		vmss, ok := result.(compute.VirtualMachineScaleSet)
+		if !ok {
+			return errors.Errorf("%T is not a compute.VirtualMachineScaleSet", result)
+		}
 
-	// This also means that the VMSS extensions were successfully installed
-	// Note: we want to handle UpdatePutStatus when VMSSExtensions have an error when scalesets become an async service
-	s.Scope.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+		instances, err := s.Client.ListInstances(ctx, spec.ResourceGroupName(), spec.ResourceName())
+		if err != nil {
+			return errors.Wrap(err, "failed to list instances")
+		}
+		fetchedVMSS = converters.SDKToVMSS(vmss, instances)
+	}
 
 	return nil
 }
@@ -170,13 +173,14 @@ func (s *Service) Delete(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.Delete")
 	defer done()
 
-	var err error
-
-	vmssSpec := s.Scope.ScaleSetSpec()
+	spec := &ScaleSetSpec{
+		ScaleSetSpec:  s.Scope.ScaleSetSpec(),
+		ResourceGroup: s.Scope.ResourceGroup(),
+	}
 
 	defer func() {
 		// save the updated state of the VMSS for the MachinePoolScope to use for updating K8s state
-		fetchedVMSS, err := s.getVirtualMachineScaleSet(ctx, vmssSpec.Name)
+		fetchedVMSS, err := s.getVirtualMachineScaleSet(ctx, spec)
 		if err != nil && !azure.ResourceNotFound(err) {
 			log.Error(err, "failed to get vmss in deferred update")
 		}
@@ -186,89 +190,33 @@ func (s *Service) Delete(ctx context.Context) error {
 		}
 	}()
 
-	// check if there is an ongoing long running operation
-	future := s.Scope.GetLongRunningOperationState(vmssSpec.Name, serviceName, infrav1.DeleteFuture)
-	if future != nil {
-		// if the operation is not complete this will return an error
-		_, err := s.GetResultIfDone(ctx, future)
-		if err != nil {
-			return errors.Wrap(err, "failed to get result from future")
-		}
-
-		// ScaleSet has been deleted
-		s.Scope.DeleteLongRunningOperationState(vmssSpec.Name, serviceName, infrav1.DeleteFuture)
-		// Note: we want to handle UpdateDeleteStatus when VMSSExtensions have an error when scalesets become an async service
-		s.Scope.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
-
-		return nil
-	}
-
-	// no long running delete operation is active, so delete the ScaleSet
-	log.V(2).Info("deleting VMSS", "scale set", vmssSpec.Name)
-	future, err = s.Client.DeleteAsync(ctx, s.Scope.ResourceGroup(), vmssSpec.Name)
-	if err != nil {
-		if azure.ResourceNotFound(err) {
-			// already deleted
-			return nil
-		}
-		return errors.Wrapf(err, "failed to delete VMSS %s in resource group %s", vmssSpec.Name, s.Scope.ResourceGroup())
-	}
-
-	s.Scope.SetLongRunningOperationState(future)
-	if future != nil {
-		// if future exists, check state of the future
-		if _, err = s.GetResultIfDone(ctx, future); err != nil {
-			return errors.Wrap(err, "not done with long running operation, or failed to get result")
-		}
-	}
-
-	// future is either nil, or the result of the future is complete
-	s.Scope.DeleteLongRunningOperationState(vmssSpec.Name, serviceName, infrav1.DeleteFuture)
-	// Note: we want to handle UpdateDeleteStatus when VMSSExtensions have an error when scalesets become an async service
-	s.Scope.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
-
-	return nil
+	err := s.DeleteResource(ctx, spec, serviceName)
+	s.Scope.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, err)
+	return err
 }
 
-func (s *Service) createVMSS(ctx context.Context) (*infrav1.Future, error) {
-	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.createVMSS")
+// scaleSetSpec returns the full specification of the scale set, including the information needed to build its
+// parameters. The instances of an existing scale set are used to decide whether it has to be surged.
+func (s *Service) scaleSetSpec(ctx context.Context, instances []compute.VirtualMachineScaleSetVM) (*ScaleSetSpec, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.scaleSetSpec")
 	defer done()
 
-	spec := s.Scope.ScaleSetSpec()
-
-	vmss, err := s.buildVMSSFromSpec(ctx, spec)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed building VMSS from spec")
-	}
+	vmssSpec := s.Scope.ScaleSetSpec()
 
-	future, err := s.Client.CreateOrUpdateAsync(ctx, s.Scope.ResourceGroup(), spec.Name, vmss)
+	sku, err := s.resourceSKUCache.Get(ctx, vmssSpec.Size, resourceskus.VirtualMachines)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot create VMSS")
+		return nil, errors.Wrapf(err, "failed to get find SKU %s in compute api", vmssSpec.Size)
 	}
 
-	log.V(2).Info("starting to create VMSS", "scale set", spec.Name)
-	s.Scope.SetLongRunningOperationState(future)
-	return future, err
-}
-
-func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS) (*infrav1.Future, error) {
-	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.patchVMSSIfNeeded")
-	defer done()
-
-	if err := s.Scope.ReconcileReplicas(ctx, infraVMSS); err != nil {
-		return nil, errors.Wrap(err, "unable to reconcile replicas")
-	}
-
-	spec := s.Scope.ScaleSetSpec()
-
-	vmss, err := s.buildVMSSFromSpec(ctx, spec)
+	image, err := s.Scope.GetVMImage(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to generate scale set update parameters for %s", spec.Name)
+		return nil, errors.Wrap(err, "failed to get VM image")
 	}
+	s.Scope.SaveVMImageToStatus(image)
 
-	patch, err := getVMSSUpdateFromVMSS(vmss)
+	bootstrapData, err := s.Scope.GetBootstrapData(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to generate vmss patch for %s", spec.Name)
+		return nil, errors.Wrap(err, "failed to retrieve bootstrap data")
 	}
 
 	maxSurge, err := s.Scope.MaxSurge()
@@ -276,49 +224,34 @@ func (s *Service) patchVMSSIfNeeded(ctx context.Context, infraVMSS *azure.VMSS)
 		return nil, errors.Wrap(err, "failed to calculate maxSurge")
 	}
 
-	hasModelChanges := hasModelModifyingDifferences(infraVMSS, vmss)
-	var isFlex bool
-	for _, instance := range infraVMSS.Instances {
-		if instance.IsFlex() {
-			isFlex = true
-			break
-		}
-	}
-	updated := true
-	if !isFlex {
-		updated = infraVMSS.HasEnoughLatestModelOrNotMixedModel()
-	}
-	if maxSurge > 0 && (hasModelChanges || !updated) {
-		// surge capacity with the intention of lowering during instance reconciliation
-		surge := spec.Capacity + int64(maxSurge)
-		log.V(4).Info("surging...", "surge", surge, "hasModelChanges", hasModelChanges, "updated", updated)
-		patch.Sku.Capacity = pointer.Int64(surge)
-	}
+	return &ScaleSetSpec{
+		ScaleSetSpec:       vmssSpec,
+		ResourceGroup:      s.Scope.ResourceGroup(),
+		SubscriptionID:     s.Scope.SubscriptionID(),
+		Location:           s.Scope.Location(),
+		ClusterName:        s.Scope.ClusterName(),
+		AdditionalTags:     s.Scope.AdditionalTags(),
+		SKU:                sku,
+		VMImage:            image,
+		BootstrapData:      bootstrapData,
+		MaxSurge:           maxSurge,
+		VMSSExtensionSpecs: s.Scope.VMSSExtensionSpecs(),
+		VMSSInstances:      instances,
+	}, nil
+}
 
-	// If there are no model changes and no increase in the replica count, do not update the VMSS.
-	// Decreases in replica count is handled by deleting AzureMachinePoolMachine instances in the MachinePoolScope
-	if *patch.Sku.Capacity <= infraVMSS.Capacity && !hasModelChanges {
-		log.V(4).Info("nothing to update on vmss", "scale set", spec.Name, "newReplicas", *patch.Sku.Capacity, "oldReplicas", infraVMSS.Capacity, "hasChanges", hasModelChanges)
-		return nil, nil
-	}
+// setVMSSState saves the state of the VMSS and its provider ID in the scope.
+func (s *Service) setVMSSState(ctx context.Context, vmss *azure.VMSS) {
+	_, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.setVMSSState")
+	defer done()
 
-	log.V(4).Info("patching vmss", "scale set", spec.Name, "patch", patch)
-	future, err := s.UpdateAsync(ctx, s.Scope.ResourceGroup(), spec.Name, patch)
+	// Transform the VMSS resource representation to conform to the cloud-provider-azure representation
+	providerID, err := azprovider.ConvertResourceGroupNameToLower(azure.ProviderIDPrefix + vmss.ID)
 	if err != nil {
-		if azure.ResourceConflict(err) {
-			return nil, azure.WithTransientError(err, 30*time.Second)
-		}
-		return nil, errors.Wrap(err, "failed updating VMSS")
+		log.Error(err, "failed to parse VMSS ID", "ID", vmss.ID)
 	}
-
-	s.Scope.SetLongRunningOperationState(future)
-	log.V(2).Info("successfully started to update vmss", "scale set", spec.Name)
-	return future, err
-}
-
-func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss compute.VirtualMachineScaleSet) bool {
-	other := converters.SDKToVMSS(vmss, []compute.VirtualMachineScaleSetVM{})
-	return infraVMSS.HasModelChanges(*other)
+	s.Scope.SetProviderID(providerID)
+	s.Scope.SetVMSSState(vmss)
 }
 
 func (s *Service) validateSpec(ctx context.Context) error {
@@ -357,8 +290,18 @@ func (s *Service) validateSpec(ctx context.Context) error {
 		return azure.WithTerminalError(fmt.Errorf("vm size %s does not support ephemeral os. select a different vm size or disable ephemeral os", spec.Size))
 	}
 
-	if spec.SecurityProfile != nil && !sku.HasCapability(resourceskus.EncryptionAtHost) {
-		return azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", spec.Size))
+	if spec.SecurityProfile != nil {
+		if spec.SecurityProfile.EncryptionAtHost != nil && *spec.SecurityProfile.EncryptionAtHost && !sku.HasCapability(resourceskus.EncryptionAtHost) {
+			return azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", spec.Size))
+		}
+
+		if spec.SecurityProfile.SecurityType == infrav1.SecurityTypesTrustedLaunch && sku.HasCapability(resourceskus.TrustedLaunchDisabled) {
+			return azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", spec.Size))
+		}
+
+		if _, ok := sku.GetCapability(resourceskus.ConfidentialComputingType); spec.SecurityProfile.SecurityType == infrav1.SecurityTypesConfidentialVM && !ok {
+			return azure.WithTerminalError(errors.Errorf("confidential VM is not supported for VM type %s", spec.Size))
+		}
 	}
 
 	// Fetch location and zone to check for their support of ultra disks.
@@ -421,310 +364,30 @@ func (s *Service) validateSpec(ctx context.Context) error {
 		if !slice.Contains(azsInLocation, az) {
 			return azure.WithTerminalError(errors.Errorf("availability zone %s is not available for VM type %s in location %s", az, spec.Size, s.Scope.Location()))
 		}
-	}
-
-	return nil
-}
-
-func (s *Service) buildVMSSFromSpec(ctx context.Context, vmssSpec azure.ScaleSetSpec) (compute.VirtualMachineScaleSet, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.buildVMSSFromSpec")
-	defer done()
-
-	sku, err := s.resourceSKUCache.Get(ctx, vmssSpec.Size, resourceskus.VirtualMachines)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, errors.Wrapf(err, "failed to get find SKU %s in compute api", vmssSpec.Size)
-	}
-
-	if vmssSpec.AcceleratedNetworking == nil {
-		// set accelerated networking to the capability of the VMSize
-		accelNet := sku.HasCapability(resourceskus.AcceleratedNetworking)
-		vmssSpec.AcceleratedNetworking = &accelNet
-	}
-
-	extensions, err := s.generateExtensions(ctx)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, err
-	}
-
-	storageProfile, err := s.generateStorageProfile(ctx, vmssSpec, sku)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, err
-	}
-
-	securityProfile, err := getSecurityProfile(vmssSpec, sku)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, err
-	}
-
-	priority, evictionPolicy, billingProfile, err := converters.GetSpotVMOptions(vmssSpec.SpotVMOptions, vmssSpec.OSDisk.DiffDiskSettings)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, errors.Wrapf(err, "failed to get Spot VM options")
-	}
-
-	diagnosticsProfile := converters.GetDiagnosticsProfile(vmssSpec.DiagnosticsProfile)
-
-	// Get the node outbound LB backend pool ID
-	var backendAddressPools []compute.SubResource
-	if vmssSpec.PublicLBName != "" {
-		if vmssSpec.PublicLBAddressPoolName != "" {
-			backendAddressPools = append(backendAddressPools,
-				compute.SubResource{
-					ID: pointer.String(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.PublicLBName, vmssSpec.PublicLBAddressPoolName)),
-				})
-		}
-	}
-
-	osProfile, err := s.generateOSProfile(ctx, vmssSpec)
-	if err != nil {
-		return compute.VirtualMachineScaleSet{}, err
-	}
-
-	orchestrationMode := converters.GetOrchestrationMode(s.Scope.ScaleSetSpec().OrchestrationMode)
-	vmss := compute.VirtualMachineScaleSet{
-		Location: pointer.String(s.Scope.Location()),
-		Sku: &compute.Sku{
-			Name:     pointer.String(vmssSpec.Size),
-			Tier:     pointer.String("Standard"),
-			Capacity: pointer.Int64(vmssSpec.Capacity),
-		},
-		Zones: &vmssSpec.FailureDomains,
-		Plan:  s.generateImagePlan(ctx),
-		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
-			OrchestrationMode:    orchestrationMode,
-			SinglePlacementGroup: pointer.Bool(false),
-			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
-				OsProfile:          osProfile,
-				StorageProfile:     storageProfile,
-				SecurityProfile:    securityProfile,
-				DiagnosticsProfile: diagnosticsProfile,
-				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
-					NetworkInterfaceConfigurations: &[]compute.VirtualMachineScaleSetNetworkConfiguration{
-						{
-							Name: pointer.String(vmssSpec.Name),
-							VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
-								Primary:            pointer.Bool(true),
-								EnableIPForwarding: pointer.Bool(true),
-								IPConfigurations: &[]compute.VirtualMachineScaleSetIPConfiguration{
-									{
-										Name: pointer.String(vmssSpec.Name),
-										VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
-											Subnet: &compute.APIEntityReference{
-												ID: pointer.String(azure.SubnetID(s.Scope.SubscriptionID(), vmssSpec.VNetResourceGroup, vmssSpec.VNetName, vmssSpec.SubnetName)),
-											},
-											Primary:                         pointer.Bool(true),
-											PrivateIPAddressVersion:         compute.IPVersionIPv4,
-											LoadBalancerBackendAddressPools: &backendAddressPools,
-										},
-									},
-								},
-								EnableAcceleratedNetworking: vmssSpec.AcceleratedNetworking,
-							},
-						},
-					},
-				},
-				Priority:       priority,
-				EvictionPolicy: evictionPolicy,
-				BillingProfile: billingProfile,
-				ExtensionProfile: &compute.VirtualMachineScaleSetExtensionProfile{
-					Extensions: &extensions,
-				},
-			},
-		},
-	}
-
-	// Set properties specific to VMSS orchestration mode
-	switch orchestrationMode {
-	case compute.OrchestrationModeUniform:
-		vmss.VirtualMachineScaleSetProperties.Overprovision = pointer.Bool(false)
-		vmss.VirtualMachineScaleSetProperties.UpgradePolicy = &compute.UpgradePolicy{Mode: compute.UpgradeModeManual}
-	case compute.OrchestrationModeFlexible:
-		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkAPIVersion =
-			compute.NetworkAPIVersionTwoZeroTwoZeroHyphenMinusOneOneHyphenMinusZeroOne
-		vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = pointer.Int32(1)
-		if len(vmssSpec.FailureDomains) > 1 {
-			vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = pointer.Int32(int32(len(vmssSpec.FailureDomains)))
-		}
-	}
-
-	// Use custom NIC definitions in VMSS if set
-	if len(vmssSpec.NetworkInterfaces) > 0 {
-		nicConfigs := []compute.VirtualMachineScaleSetNetworkConfiguration{}
-		for i, n := range vmssSpec.NetworkInterfaces {
-			nicConfig := compute.VirtualMachineScaleSetNetworkConfiguration{}
-			nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties = &compute.VirtualMachineScaleSetNetworkConfigurationProperties{}
-			nicConfig.Name = pointer.String(vmssSpec.Name + "-" + strconv.Itoa(i))
-			if pointer.BoolDeref(n.AcceleratedNetworking, false) {
-				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.EnableAcceleratedNetworking = pointer.Bool(true)
-			} else {
-				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.EnableAcceleratedNetworking = pointer.Bool(false)
-			}
-			if n.PrivateIPConfigs == 0 {
-				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.IPConfigurations = &[]compute.VirtualMachineScaleSetIPConfiguration{
-					{
-						Name: pointer.String(vmssSpec.Name + "-" + strconv.Itoa(i)),
-						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
-							Subnet: &compute.APIEntityReference{
-								ID: pointer.String(azure.SubnetID(s.Scope.SubscriptionID(), vmssSpec.VNetResourceGroup, vmssSpec.VNetName, n.SubnetName)),
-							},
-							Primary:                         pointer.Bool(true),
-							PrivateIPAddressVersion:         compute.IPVersionIPv4,
-							LoadBalancerBackendAddressPools: &backendAddressPools,
-						},
-					},
-				}
-			} else {
-				ipconfigs := []compute.VirtualMachineScaleSetIPConfiguration{}
-
-				// Create IPConfigs
-				for j := 0; j < n.PrivateIPConfigs; j++ {
-					ipconfig := compute.VirtualMachineScaleSetIPConfiguration{
-						Name: pointer.String(fmt.Sprintf("private-ipConfig-%v", j)),
-						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
-							PrivateIPAddressVersion: compute.IPVersionIPv4,
-							Subnet: &compute.APIEntityReference{
-								ID: pointer.String(azure.SubnetID(s.Scope.SubscriptionID(), vmssSpec.VNetResourceGroup, vmssSpec.VNetName, n.SubnetName)),
-							},
-						},
-					}
-
-					ipconfig.Subnet = &compute.APIEntityReference{
-						ID: pointer.String(azure.SubnetID(s.Scope.SubscriptionID(), vmssSpec.VNetResourceGroup, vmssSpec.VNetName, n.SubnetName)),
-					}
-					ipconfigs = append(ipconfigs, ipconfig)
-				}
-				if i == 0 {
-					ipconfigs[0].LoadBalancerBackendAddressPools = &backendAddressPools
-				}
-				// Always use the first IPConfig as the Primary
-				ipconfigs[0].Primary = pointer.Bool(true)
-				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.IPConfigurations = &ipconfigs
-			}
-			nicConfigs = append(nicConfigs, nicConfig)
-		}
-		nicConfigs[0].VirtualMachineScaleSetNetworkConfigurationProperties.Primary = pointer.Bool(true)
-		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations = &nicConfigs
-	} else {
-		// Set default interface configuration if no custom ones are specified
-		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations = s.getVirtualMachineScaleSetDefaultNetworkConfiguration(vmssSpec)
-	}
-
-	// Assign Identity to VMSS
-	if vmssSpec.Identity == infrav1.VMIdentitySystemAssigned {
-		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
-			Type: compute.ResourceIdentityTypeSystemAssigned,
-		}
-	} else if vmssSpec.Identity == infrav1.VMIdentityUserAssigned {
-		userIdentitiesMap, err := converters.UserAssignedIdentitiesToVMSSSDK(vmssSpec.UserAssignedIdentities)
-		if err != nil {
-			return vmss, errors.Wrapf(err, "failed to assign identity %q", vmssSpec.Name)
-		}
-		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
-			Type:                   compute.ResourceIdentityTypeUserAssigned,
-			UserAssignedIdentities: userIdentitiesMap,
-		}
-	}
-
-	// Provisionally detect whether there is any Data Disk defined which uses UltraSSDs.
-	// If that's the case, enable the UltraSSD capability.
-	for _, dataDisk := range vmssSpec.DataDisks {
-		if dataDisk.ManagedDisk != nil && dataDisk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
-			vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{
-				UltraSSDEnabled: pointer.Bool(true),
-			}
+		// The instances of a scale set must be in the availability zone of the dedicated host group it is placed in.
+		if spec.HostGroupZone != "" && az != spec.HostGroupZone {
+			return azure.WithTerminalError(errors.Errorf("failure domain %s does not match the availability zone %s of the dedicated host group", az, spec.HostGroupZone))
 		}
 	}
 
-	// Set Additional Capabilities if any is present on the spec.
-	if vmssSpec.AdditionalCapabilities != nil {
-		// Set UltraSSDEnabled if a specific value is set on the spec for it.
-		if vmssSpec.AdditionalCapabilities.UltraSSDEnabled != nil {
-			vmss.AdditionalCapabilities.UltraSSDEnabled = vmssSpec.AdditionalCapabilities.UltraSSDEnabled
-		}
-	}
-
-	if vmssSpec.TerminateNotificationTimeout != nil {
-		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.ScheduledEventsProfile = &compute.ScheduledEventsProfile{
-			TerminateNotificationProfile: &compute.TerminateNotificationProfile{
-				NotBeforeTimeout: pointer.String(fmt.Sprintf("PT%dM", *vmssSpec.TerminateNotificationTimeout)),
-				Enable:           pointer.Bool(true),
-			},
-		}
-	}
-
-	tags := infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.Scope.ClusterName(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        pointer.String(vmssSpec.Name),
-		Role:        pointer.String(infrav1.Node),
-		Additional:  s.Scope.AdditionalTags(),
-	})
-
-	vmss.Tags = converters.TagsToMap(tags)
-	return vmss, nil
-}
-
-func (s *Service) getVirtualMachineScaleSetDefaultNetworkConfiguration(vmssSpec azure.ScaleSetSpec) *[]compute.VirtualMachineScaleSetNetworkConfiguration {
-	var backendAddressPools []compute.SubResource
-	if vmssSpec.PublicLBName != "" {
-		if vmssSpec.PublicLBAddressPoolName != "" {
-			backendAddressPools = append(backendAddressPools,
-				compute.SubResource{
-					ID: pointer.String(azure.AddressPoolID(s.Scope.SubscriptionID(), s.Scope.ResourceGroup(), vmssSpec.PublicLBName, vmssSpec.PublicLBAddressPoolName)),
-				})
-		}
-	}
-	return &[]compute.VirtualMachineScaleSetNetworkConfiguration{{
-		Name: pointer.String(vmssSpec.Name),
-		VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
-			Primary:            pointer.Bool(true),
-			EnableIPForwarding: pointer.Bool(true),
-			IPConfigurations: &[]compute.VirtualMachineScaleSetIPConfiguration{
-				{
-					Name: pointer.String(vmssSpec.Name),
-					VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
-						Subnet: &compute.APIEntityReference{
-							ID: pointer.String(azure.SubnetID(s.Scope.SubscriptionID(), vmssSpec.VNetResourceGroup, vmssSpec.VNetName, vmssSpec.SubnetName)),
-						},
-						Primary:                         pointer.Bool(true),
-						PrivateIPAddressVersion:         compute.IPVersionIPv4,
-						LoadBalancerBackendAddressPools: &backendAddressPools,
-					},
-				},
-			},
-			EnableAcceleratedNetworking: vmssSpec.AcceleratedNetworking,
-		},
-	}}
+	return nil
 }
 
 // getVirtualMachineScaleSet provides information about a Virtual Machine Scale Set and its instances.
-func (s *Service) getVirtualMachineScaleSet(ctx context.Context, vmssName string) (*azure.VMSS, error) {
+func (s *Service) getVirtualMachineScaleSet(ctx context.Context, spec azure.ResourceSpecGetter) (*azure.VMSS, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.getVirtualMachineScaleSet")
 	defer done()
 
-	vmss, err := s.Client.Get(ctx, s.Scope.ResourceGroup(), vmssName)
+	existing, err := s.Client.Get(ctx, spec)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get existing vmss")
 	}
-
-	vmssInstances, err := s.Client.ListInstances(ctx, s.Scope.ResourceGroup(), vmssName)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to list instances")
-	}
-
-	return converters.SDKToVMSS(vmss, vmssInstances), nil
-}
-
-// getVirtualMachineScaleSetIfDone gets a Virtual Machine Scale Set and its instances from Azure if the future is completed.
-func (s *Service) getVirtualMachineScaleSetIfDone(ctx context.Context, future *infrav1.Future) (*azure.VMSS, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.getVirtualMachineScaleSetIfDone")
-	defer done()
-
-	vmss, err := s.GetResultIfDone(ctx, future)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get result from future")
+	vmss, ok := existing.(compute.VirtualMachineScaleSet)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSet", existing)
 	}
 
-	vmssInstances, err := s.Client.ListInstances(ctx, future.ResourceGroup, future.Name)
+	vmssInstances, err := s.Client.ListInstances(ctx, spec.ResourceGroupName(), spec.ResourceName())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list instances")
 	}
@@ -732,209 +395,6 @@ func (s *Service) getVirtualMachineScaleSetIfDone(ctx context.Context, future *i
 	return converters.SDKToVMSS(vmss, vmssInstances), nil
 }
 
-func (s *Service) generateExtensions(ctx context.Context) ([]compute.VirtualMachineScaleSetExtension, error) {
-	extensions := make([]compute.VirtualMachineScaleSetExtension, len(s.Scope.VMSSExtensionSpecs()))
-	for i, extensionSpec := range s.Scope.VMSSExtensionSpecs() {
-		extensionSpec := extensionSpec
-		parameters, err := extensionSpec.Parameters(ctx, nil)
-		if err != nil {
-			return nil, err
-		}
-		vmssextension, ok := parameters.(compute.VirtualMachineScaleSetExtension)
-		if !ok {
-			return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSetExtension", parameters)
-		}
-		extensions[i] = vmssextension
-	}
-
-	return extensions, nil
-}
-
-// generateStorageProfile generates a pointer to a compute.VirtualMachineScaleSetStorageProfile which can utilized for VM creation.
-func (s *Service) generateStorageProfile(ctx context.Context, vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.VirtualMachineScaleSetStorageProfile, error) {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.generateStorageProfile")
-	defer done()
-
-	storageProfile := &compute.VirtualMachineScaleSetStorageProfile{
-		OsDisk: &compute.VirtualMachineScaleSetOSDisk{
-			OsType:       compute.OperatingSystemTypes(vmssSpec.OSDisk.OSType),
-			CreateOption: compute.DiskCreateOptionTypesFromImage,
-			DiskSizeGB:   vmssSpec.OSDisk.DiskSizeGB,
-		},
-	}
-
-	// enable ephemeral OS
-	if vmssSpec.OSDisk.DiffDiskSettings != nil {
-		if !sku.HasCapability(resourceskus.EphemeralOSDisk) {
-			return nil, fmt.Errorf("vm size %s does not support ephemeral os. select a different vm size or disable ephemeral os", vmssSpec.Size)
-		}
-
-		storageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
-			Option: compute.DiffDiskOptions(vmssSpec.OSDisk.DiffDiskSettings.Option),
-		}
-	}
-
-	if vmssSpec.OSDisk.ManagedDisk != nil {
-		storageProfile.OsDisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{}
-		if vmssSpec.OSDisk.ManagedDisk.StorageAccountType != "" {
-			storageProfile.OsDisk.ManagedDisk.StorageAccountType = compute.StorageAccountTypes(vmssSpec.OSDisk.ManagedDisk.StorageAccountType)
-		}
-		if vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
-			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
-		}
-	}
-
-	if vmssSpec.OSDisk.CachingType != "" {
-		storageProfile.OsDisk.Caching = compute.CachingTypes(vmssSpec.OSDisk.CachingType)
-	}
-
-	dataDisks := make([]compute.VirtualMachineScaleSetDataDisk, len(vmssSpec.DataDisks))
-	for i, disk := range vmssSpec.DataDisks {
-		dataDisks[i] = compute.VirtualMachineScaleSetDataDisk{
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
-			DiskSizeGB:   pointer.Int32(disk.DiskSizeGB),
-			Lun:          disk.Lun,
-			Name:         pointer.String(azure.GenerateDataDiskName(vmssSpec.Name, disk.NameSuffix)),
-		}
-
-		if disk.ManagedDisk != nil {
-			dataDisks[i].ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
-				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
-			}
-
-			if disk.ManagedDisk.DiskEncryptionSet != nil {
-				dataDisks[i].ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(disk.ManagedDisk.DiskEncryptionSet.ID)}
-			}
-		}
-	}
-	storageProfile.DataDisks = &dataDisks
-
-	image, err := s.Scope.GetVMImage(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get VM image")
-	}
-
-	s.Scope.SaveVMImageToStatus(image)
-
-	imageRef, err := converters.ImageToSDK(image)
-	if err != nil {
-		return nil, err
-	}
-
-	storageProfile.ImageReference = imageRef
-
-	return storageProfile, nil
-}
-
-func (s *Service) generateOSProfile(ctx context.Context, vmssSpec azure.ScaleSetSpec) (*compute.VirtualMachineScaleSetOSProfile, error) {
-	sshKey, err := base64.StdEncoding.DecodeString(vmssSpec.SSHKeyData)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode ssh public key")
-	}
-	bootstrapData, err := s.Scope.GetBootstrapData(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve bootstrap data")
-	}
-
-	osProfile := &compute.VirtualMachineScaleSetOSProfile{
-		ComputerNamePrefix: pointer.String(vmssSpec.Name),
-		AdminUsername:      pointer.String(azure.DefaultUserName),
-		CustomData:         pointer.String(bootstrapData),
-	}
-
-	switch vmssSpec.OSDisk.OSType {
-	case string(compute.OperatingSystemTypesWindows):
-		// Cloudbase-init is used to generate a password.
-		// https://cloudbase-init.readthedocs.io/en/latest/plugins.html#setting-password-main
-		//
-		// We generate a random password here in case of failure
-		// but the password on the VM will NOT be the same as created here.
-		// Access is provided via SSH public key that is set during deployment
-		// Azure also provides a way to reset user passwords in the case of need.
-		osProfile.AdminPassword = pointer.String(generators.SudoRandomPassword(123))
-		osProfile.WindowsConfiguration = &compute.WindowsConfiguration{
-			EnableAutomaticUpdates: pointer.Bool(false),
-		}
-	default:
-		osProfile.LinuxConfiguration = &compute.LinuxConfiguration{
-			DisablePasswordAuthentication: pointer.Bool(true),
-			SSH: &compute.SSHConfiguration{
-				PublicKeys: &[]compute.SSHPublicKey{
-					{
-						Path:    pointer.String(fmt.Sprintf("/home/%s/.ssh/authorized_keys", azure.DefaultUserName)),
-						KeyData: pointer.String(string(sshKey)),
-					},
-				},
-			},
-		}
-	}
-
-	return osProfile, nil
-}
-
-func (s *Service) generateImagePlan(ctx context.Context) *compute.Plan {
-	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.Service.generateImagePlan")
-	defer done()
-
-	image, err := s.Scope.GetVMImage(ctx)
-	if err != nil {
-		log.Error(err, "failed to get vm image, disabling Plan")
-		return nil
-	}
-
-	if image.SharedGallery != nil && image.SharedGallery.Publisher != nil && image.SharedGallery.SKU != nil && image.SharedGallery.Offer != nil {
-		return &compute.Plan{
-			Publisher: image.SharedGallery.Publisher,
-			Name:      image.SharedGallery.SKU,
-			Product:   image.SharedGallery.Offer,
-		}
-	}
-
-	if image.Marketplace == nil || !image.Marketplace.ThirdPartyImage {
-		return nil
-	}
-
-	if image.Marketplace.Publisher == "" || image.Marketplace.SKU == "" || image.Marketplace.Offer == "" {
-		return nil
-	}
-
-	return &compute.Plan{
-		Publisher: pointer.String(image.Marketplace.Publisher),
-		Name:      pointer.String(image.Marketplace.SKU),
-		Product:   pointer.String(image.Marketplace.Offer),
-	}
-}
-
-func getVMSSUpdateFromVMSS(vmss compute.VirtualMachineScaleSet) (compute.VirtualMachineScaleSetUpdate, error) {
-	jsonData, err := vmss.MarshalJSON()
-	if err != nil {
-		return compute.VirtualMachineScaleSetUpdate{}, err
-	}
-
-	var update compute.VirtualMachineScaleSetUpdate
-	if err := update.UnmarshalJSON(jsonData); err != nil {
-		return update, err
-	}
-
-	// wipe out network profile, so updates won't conflict with Cloud Provider updates
-	update.VirtualMachineProfile.NetworkProfile = nil
-	return update, nil
-}
-
-func getSecurityProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.SecurityProfile, error) {
-	if vmssSpec.SecurityProfile == nil {
-		return nil, nil
-	}
-
-	if !sku.HasCapability(resourceskus.EncryptionAtHost) {
-		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", vmssSpec.Size))
-	}
-
-	return &compute.SecurityProfile{
-		EncryptionAtHost: pointer.Bool(*vmssSpec.SecurityProfile.EncryptionAtHost),
-	}, nil
-}
-
 // IsManaged returns always returns true as CAPZ does not support BYO scale set.
 func (s *Service) IsManaged(ctx context.Context) (bool, error) {
 	return true, nil
@@ -25,11 +25,11 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
-	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/scalesets/mock_scalesets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
@@ -43,6 +43,11 @@ const (
 	vmSizeEPH             = "VM_SIZE_EPH"
 )
 
+var (
+	notFoundError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")
+	internalError = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
+)
+
 func init() {
 	_ = clusterv1.AddToScheme(scheme.Scheme)
 }
@@ -50,24 +55,21 @@ func init() {
 func TestGetExistingVMSS(t *testing.T) {
 	testcases := []struct {
 		name          string
-		vmssName      string
 		result        *azure.VMSS
 		expectedError string
 		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
 	}{
 		{
 			name:          "scale set not found",
-			vmssName:      "my-vmss",
 			result:        &azure.VMSS{},
 			expectedError: "failed to get existing vmss: #: Not found: StatusCode=404",
 			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
 			},
 		},
 		{
-			name:     "get existing vmss",
-			vmssName: "my-vmss",
+			name: "get existing vmss",
 			result: &azure.VMSS{
 				ID:       "my-id",
 				Name:     "my-vmss",
@@ -89,7 +91,7 @@ func TestGetExistingVMSS(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{
 					ID:   pointer.String("my-id"),
 					Name: pointer.String("my-vmss"),
 					Sku: &compute.Sku{
@@ -119,12 +121,11 @@ func TestGetExistingVMSS(t *testing.T) {
 		},
 		{
 			name:          "list instances fails",
-			vmssName:      "my-vmss",
 			result:        &azure.VMSS{},
 			expectedError: "failed to list instances: #: Not found: StatusCode=404",
 			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
 				s.ResourceGroup().AnyTimes().Return("my-rg")
-				m.Get(gomockinternal.AContext(), "my-rg", "my-vmss").Return(compute.VirtualMachineScaleSet{
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{
 					ID:   pointer.String("my-id"),
 					Name: pointer.String("my-vmss"),
 					VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
@@ -155,7 +156,7 @@ func TestGetExistingVMSS(t *testing.T) {
 				Client: clientMock,
 			}
 
-			result, err := s.getVirtualMachineScaleSet(context.TODO(), tc.vmssName)
+			result, err := s.getVirtualMachineScaleSet(context.TODO(), newDefaultResourceSpec())
 			if tc.expectedError != "" {
 				g.Expect(err).To(HaveOccurred())
 				t.Log(err.Error())
@@ -172,12 +173,14 @@ func TestReconcileVMSS(t *testing.T) {
 	var (
 		putFuture = &infrav1.Future{
 			Type:          infrav1.PutFuture,
+			ServiceName:   serviceName,
 			ResourceGroup: defaultResourceGroup,
 			Name:          defaultVMSSName,
 		}
 
 		patchFuture = &infrav1.Future{
 			Type:          infrav1.PatchFuture,
+			ServiceName:   serviceName,
 			ResourceGroup: defaultResourceGroup,
 			Name:          defaultVMSSName,
 		}
@@ -185,409 +188,116 @@ func TestReconcileVMSS(t *testing.T) {
 
 	testcases := []struct {
 		name          string
-		expect        func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
 		expectedError string
 	}{
 		{
-			name:          "should start creating a vmss",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newDefaultVMSSSpec()
-				defaultSpec.DataDisks = append(defaultSpec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
-			},
-		},
-		{
-			name:          "should finish creating a vmss when long running operation is done",
+			name:          "should create a vmss",
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newDefaultVMSSSpec()
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				createdVMSS := newDefaultVMSS("VM_SIZE")
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				setupDefaultVMSSExpectations(s)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{}, notFoundError)
+				spec := newDefaultScaleSetSpec()
+				spec.VMSSInstances = nil
+				r.CreateOrUpdateResource(gomockinternal.AContext(), gomockinternal.DiffEq(spec), serviceName).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				setupVMSSStateExpectations(s, m)
 			},
 		},
 		{
-			name:          "Windows VMSS should not get patched",
+			name:          "should reconcile the replicas of an existing vmss before updating it",
 			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newWindowsVMSSSpec()
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				createdVMSS := newDefaultWindowsVMSS()
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, createdVMSS, instances)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(defaultSpec.Name, serviceName, infrav1.PatchFuture)
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				setupDefaultVMSSExpectations(s)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
+				s.ReconcileReplicas(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azure.VMSS{})).Return(nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), gomockinternal.DiffEq(newDefaultScaleSetSpec()), serviceName).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
 				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				setupVMSSStateExpectations(s, m)
 			},
 		},
 		{
-			name:          "should start creating vmss with defaulted accelerated networking when size allows",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Size = "VM_SIZE_AN"
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE_AN")
-				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
-				(*netConfigs)[0].EnableAcceleratedNetworking = pointer.Bool(true)
-				vmss.Sku.Name = pointer.String(spec.Size)
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_AN"), putFuture)
-			},
-		},
-		{
-			name:          "should start creating vmss with custom networking when specified",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				spec.NetworkInterfaces = []infrav1.NetworkInterface{
-					{
-						SubnetName:            "my-subnet",
-						PrivateIPConfigs:      1,
-						AcceleratedNetworking: pointer.Bool(true),
-					},
-					{
-						SubnetName:            "subnet2",
-						PrivateIPConfigs:      2,
-						AcceleratedNetworking: pointer.Bool(true),
-					},
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
-				(*netConfigs)[0].Name = pointer.String("my-vmss-0")
-				(*netConfigs)[0].EnableIPForwarding = nil
-				nic1IPConfigs := (*netConfigs)[0].IPConfigurations
-				(*nic1IPConfigs)[0].Name = pointer.String("private-ipConfig-0")
-				(*nic1IPConfigs)[0].PrivateIPAddressVersion = compute.IPVersionIPv4
-				(*netConfigs)[0].EnableAcceleratedNetworking = pointer.Bool(true)
-				(*netConfigs)[0].Primary = pointer.Bool(true)
-				vmssIPConfigs := []compute.VirtualMachineScaleSetIPConfiguration{
-					{
-						Name: pointer.String("private-ipConfig-0"),
-						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
-							Primary:                 pointer.Bool(true),
-							PrivateIPAddressVersion: compute.IPVersionIPv4,
-							Subnet: &compute.APIEntityReference{
-								ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/subnet2"),
-							},
-						},
-					},
-					{
-						Name: pointer.String("private-ipConfig-1"),
-						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
-							PrivateIPAddressVersion: compute.IPVersionIPv4,
-							Subnet: &compute.APIEntityReference{
-								ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/subnet2"),
-							},
-						},
-					},
-				}
-				*netConfigs = append(*netConfigs, compute.VirtualMachineScaleSetNetworkConfiguration{
-					Name: pointer.String("my-vmss-1"),
-					VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
-						EnableAcceleratedNetworking: pointer.Bool(true),
-						IPConfigurations:            &vmssIPConfigs,
-					},
-				})
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
-			},
-		},
-		{
-			name:          "should start creating a vmss with spot vm",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
-			},
-		},
-		{
-			name:          "should start creating a vmss with spot vm and ephemeral disk",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Size = vmSizeEPH
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
-				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
-					Option: string(compute.DiffDiskOptionsLocal),
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS(vmSizeEPH)
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
-					Option: compute.DiffDiskOptionsLocal,
-				}
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS(vmSizeEPH), putFuture)
-			},
-		},
-		{
-			name:          "should start creating a vmss with spot vm and a defined delete evictionPolicy",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Size = vmSizeEPH
-				deletePolicy := infrav1.SpotEvictionPolicyDelete
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{EvictionPolicy: &deletePolicy}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS(vmSizeEPH)
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypesDelete
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS(vmSizeEPH), putFuture)
-			},
-		},
-		{
-			name:          "should start creating a vmss with spot vm and a maximum price",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				maxPrice := resource.MustParse("0.001")
-				spec.SpotVMOptions = &infrav1.SpotVMOptions{
-					MaxPrice: &maxPrice,
-				}
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{
-					MaxPrice: pointer.Float64(0.001),
-				}
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
-			},
-		},
-		{
-			name:          "should start creating a vmss with encryption",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.OSDisk.ManagedDisk.DiskEncryptionSet = &infrav1.DiskEncryptionSetParameters{
-					ID: "my-diskencryptionset-id",
-				}
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				osdisk := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk
-				osdisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
-					StorageAccountType: "Premium_LRS",
-					DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
-						ID: pointer.String("my-diskencryptionset-id"),
-					},
-				}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
-			},
-		},
-		{
-			name:          "can start creating a vmss with user assigned identity",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				spec.Identity = infrav1.VMIdentityUserAssigned
-				spec.UserAssignedIdentities = []infrav1.UserAssignedIdentity{
-					{
-						ProviderID: "azure:///subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1",
-					},
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
-					Type: compute.ResourceIdentityTypeUserAssigned,
-					UserAssignedIdentities: map[string]*compute.VirtualMachineScaleSetIdentityUserAssignedIdentitiesValue{
-						"/subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1": {},
-					},
-				}
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE"), putFuture)
+			name:          "should resume an ongoing operation without building the vmss parameters",
+			expectedError: "operation type PUT on Azure resource my-rg/my-vmss is not done",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(putFuture)
+				notDoneError := azure.NewOperationNotDoneError(putFuture)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), newDefaultResourceSpec(), serviceName).Return(nil, notDoneError)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, notDoneError)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				setupVMSSStateExpectations(s, m)
 			},
 		},
 		{
-			name:          "should start creating a vmss with encryption at host enabled",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Size = "VM_SIZE_EAH"
-				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: pointer.Bool(true)}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE_EAH")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
-					EncryptionAtHost: pointer.Bool(true),
-				}
-				vmss.Sku.Name = pointer.String(spec.Size)
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EAH"), putFuture)
+			name:          "should resume an update tracked with a PATCH future",
+			expectedError: "",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(patchFuture)
+				s.SetLongRunningOperationState(putFuture)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(putFuture)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), newDefaultResourceSpec(), serviceName).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				setupVMSSStateExpectations(s, m)
 			},
 		},
 		{
-			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
-			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:            defaultVMSSName,
-					Size:            "VM_SIZE",
-					Capacity:        2,
-					SSHKeyData:      "ZmFrZXNzaGtleQo=",
-					SecurityProfile: &infrav1.SecurityProfile{EncryptionAtHost: pointer.Bool(true)},
-				})
+			name:          "fails when getting the existing vmss fails",
+			expectedError: "failed to get VMSS my-vmss: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{}, internalError).Times(2)
 			},
 		},
 		{
-			name:          "should start creating a vmss with ephemeral osdisk",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PUT on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				defaultSpec := newDefaultVMSSSpec()
-				defaultSpec.Size = "VM_SIZE_EPH"
-				defaultSpec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
-					Option: "Local",
-				}
-				defaultSpec.OSDisk.CachingType = "ReadOnly"
-
-				s.ScaleSetSpec().Return(defaultSpec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				vmss := newDefaultVMSS("VM_SIZE_EPH")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
-					Option: compute.DiffDiskOptionsLocal,
-				}
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.Caching = compute.CachingTypesReadOnly
-
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(vmss)).
-					Return(putFuture, nil)
-				setupCreatingSucceededExpectations(s, m, newDefaultExistingVMSS("VM_SIZE_EPH"), putFuture)
+			name:          "fails when reconciling the replicas fails",
+			expectedError: "unable to reconcile replicas: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				s.Location().AnyTimes().Return("test-location")
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(newDefaultExistingVMSS("VM_SIZE"), nil).Times(2)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
+				s.ReconcileReplicas(gomockinternal.AContext(), gomock.AssignableToTypeOf(&azure.VMSS{})).Return(internalError)
+				setupVMSSStateExpectations(s, m)
 			},
 		},
 		{
-			name:          "should start updating when scale set already exists and not currently in a long running operation",
-			expectedError: "failed to get VMSS my-vmss after create or update: failed to get result from future: operation type PATCH on Azure resource my-rg/my-vmss is not done",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.Capacity = 2
-				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
-					NameSuffix: "my_disk_with_ultra_disks",
-					DiskSizeGB: 128,
-					Lun:        pointer.Int32(3),
-					ManagedDisk: &infrav1.ManagedDiskParameters{
-						StorageAccountType: "UltraSSD_LRS",
-					},
-				})
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-
-				setupDefaultVMSSUpdateExpectations(s)
-				existingVMSS := newDefaultExistingVMSS("VM_SIZE")
-				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				existingVMSS.Sku.Capacity = pointer.Int64(2)
-				existingVMSS.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-				instances := newDefaultInstances()
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(existingVMSS, nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
-
-				clone := newDefaultExistingVMSS("VM_SIZE")
-				clone.Sku.Capacity = pointer.Int64(3)
-				clone.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
-
-				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
-				g.Expect(err).NotTo(HaveOccurred())
-				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
-				patchVMSS.VirtualMachineProfile.NetworkProfile = nil
-				m.UpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomockinternal.DiffEq(patchVMSS)).
-					Return(patchFuture, nil)
-				s.SetLongRunningOperationState(patchFuture)
-				m.GetResultIfDone(gomockinternal.AContext(), patchFuture).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(patchFuture))
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(clone, nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil)
+			name:          "creating a vmss fails",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				setupDefaultVMSSExpectations(s)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{}, notFoundError).Times(2)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), gomock.AssignableToTypeOf(&ScaleSetSpec{}), serviceName).Return(nil, internalError)
+				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, internalError)
 			},
 		},
 		{
 			name:          "less than 2 vCPUs",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size should be bigger or equal to at least 2 vCPUs. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_1_CPU",
@@ -599,7 +309,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "Memory is less than 2Gi",
 			expectedError: "reconcile error that cannot be recovered occurred: vm memory should be bigger or equal to at least 2Gi. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_1_MEM",
@@ -611,7 +321,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "failed to get SKU",
 			expectedError: "failed to get SKU INVALID_VM_SIZE in compute api: reconcile error that cannot be recovered occurred: resource sku with name 'INVALID_VM_SIZE' and category 'virtualMachines' not found in location 'test-location'. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "INVALID_VM_SIZE",
@@ -620,23 +330,10 @@ func TestReconcileVMSS(t *testing.T) {
 				})
 			},
 		},
-		{
-			name:          "fails with internal error",
-			expectedError: "failed to start creating VMSS: cannot create VMSS: #: Internal error: StatusCode=500",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				setupDefaultVMSSStartCreatingExpectations(s, m)
-				m.CreateOrUpdateAsync(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName, gomock.AssignableToTypeOf(compute.VirtualMachineScaleSet{})).
-					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal error"))
-				m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
-					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
-			},
-		},
 		{
 			name:          "fail to create a vm with ultra disk implicitly enabled by data disk, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -656,7 +353,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -672,7 +369,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with ultra disk explicitly enabled via additional capabilities, when location not supported",
 			expectedError: "reconcile error that cannot be recovered occurred: vm size VM_SIZE_USSD does not support ultra disks in location test-location. select a different vm size or disable ultra disks. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE_USSD",
@@ -695,7 +392,7 @@ func TestReconcileVMSS(t *testing.T) {
 		{
 			name:          "fail to create a vm with diagnostics set to User Managed but empty StorageAccountURI",
 			expectedError: "reconcile error that cannot be recovered occurred: userManaged must be specified when storageAccountType is 'UserManaged'. Object will not be requeued",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
 					Name:       defaultVMSSName,
 					Size:       "VM_SIZE",
@@ -712,86 +409,40 @@ func TestReconcileVMSS(t *testing.T) {
 			},
 		},
 		{
-			name:          "successfully create a vm with diagnostics set to User Managed and StorageAccountURI set",
-			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				storageURI := "https://fakeurl"
-
+			name:          "creating a vmss in a dedicated host group in another zone fails",
+			expectedError: "reconcile error that cannot be recovered occurred: failure domain 1 does not match the availability zone 2 of the dedicated host group. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				spec := newDefaultVMSSSpec()
-				spec.DiagnosticsProfile = &infrav1.Diagnostics{
-					Boot: &infrav1.BootDiagnostics{
-						StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
-						UserManaged: &infrav1.UserManagedBootDiagnostics{
-							StorageAccountURI: storageURI,
-						},
-					},
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.DiagnosticsProfile = &compute.DiagnosticsProfile{BootDiagnostics: &compute.BootDiagnostics{
-					Enabled:    pointer.Bool(true),
-					StorageURI: &storageURI,
-				}}
-
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
-				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
-				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				spec.HostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg"
+				spec.HostGroupZone = "2"
+				s.ScaleSetSpec().Return(spec)
 				s.Location().AnyTimes().Return("test-location")
 			},
 		},
 		{
-			name:          "successfully create a vm with diagnostics set to Managed",
-			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.DiagnosticsProfile = &infrav1.Diagnostics{
-					Boot: &infrav1.BootDiagnostics{
-						StorageAccountType: infrav1.ManagedDiagnosticsStorage,
-					},
-				}
-
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.DiagnosticsProfile = &compute.DiagnosticsProfile{BootDiagnostics: &compute.BootDiagnostics{
-					Enabled: pointer.Bool(true),
-				}}
-
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
-				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
-				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
-				s.Location().AnyTimes().Return("test-location")
+			name:          "creating a confidential vmss for unsupported VM type fails",
+			expectedError: "reconcile error that cannot be recovered occurred: confidential VM is not supported for VM type VM_SIZE. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:            defaultVMSSName,
+					Size:            "VM_SIZE",
+					Capacity:        2,
+					SSHKeyData:      "ZmFrZXNzaGtleQo=",
+					SecurityProfile: &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesConfidentialVM},
+				})
 			},
 		},
 		{
-			name:          "successfully create a vm with diagnostics set to Disabled",
-			expectedError: "",
-			expect: func(g *WithT, s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				spec := newDefaultVMSSSpec()
-				spec.DiagnosticsProfile = &infrav1.Diagnostics{
-					Boot: &infrav1.BootDiagnostics{
-						StorageAccountType: infrav1.DisabledDiagnosticsStorage,
-					},
-				}
-				s.ScaleSetSpec().Return(spec).AnyTimes()
-
-				vmss := newDefaultVMSS("VM_SIZE")
-				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.DiagnosticsProfile = &compute.DiagnosticsProfile{BootDiagnostics: &compute.BootDiagnostics{
-					Enabled: pointer.Bool(false),
-				}}
-				instances := newDefaultInstances()
-
-				setupDefaultVMSSInProgressOperationDoneExpectations(s, m, vmss, instances)
-				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PutFuture)
-				s.DeleteLongRunningOperationState(spec.Name, serviceName, infrav1.PatchFuture)
-				s.UpdatePutStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
-				s.Location().AnyTimes().Return("test-location")
+			name:          "creating a vmss with encryption at host enabled for unsupported VM type fails",
+			expectedError: "reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
+					Name:            defaultVMSSName,
+					Size:            "VM_SIZE",
+					Capacity:        2,
+					SSHKeyData:      "ZmFrZXNzaGtleQo=",
+					SecurityProfile: &infrav1.SecurityProfile{EncryptionAtHost: pointer.Bool(true)},
+				})
 			},
 		},
 	}
@@ -806,12 +457,14 @@ func TestReconcileVMSS(t *testing.T) {
 
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 
-			tc.expect(g, scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT())
 
 			s := &Service{
 				Scope:            scopeMock,
 				Client:           clientMock,
+				Reconciler:       asyncMock,
 				resourceSKUCache: resourceskus.NewStaticCache(getFakeSkus(), "test-location"),
 			}
 
@@ -827,68 +480,50 @@ func TestReconcileVMSS(t *testing.T) {
 }
 
 func TestDeleteVMSS(t *testing.T) {
-	const (
-		resourceGroup = "my-rg"
-		name          = "my-vmss"
-	)
-
 	testcases := []struct {
 		name          string
 		expectedError string
-		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder)
+		expect        func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder)
 	}{
 		{
 			name:          "successfully delete an existing vmss",
 			expectedError: "",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:     "my-existing-vmss",
-					Size:     "VM_SIZE",
-					Capacity: 3,
-				}).AnyTimes()
-				s.ResourceGroup().AnyTimes().Return("my-existing-rg")
-				future := &infrav1.Future{}
-				s.GetLongRunningOperationState("my-existing-vmss", serviceName, infrav1.DeleteFuture).Return(future)
-				m.GetResultIfDone(gomockinternal.AContext(), future).Return(compute.VirtualMachineScaleSet{}, nil)
-				m.Get(gomockinternal.AContext(), "my-existing-rg", "my-existing-vmss").
-					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
-				s.DeleteLongRunningOperationState("my-existing-vmss", serviceName, infrav1.DeleteFuture)
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				r.DeleteResource(gomockinternal.AContext(), newDefaultResourceSpec(), serviceName).Return(nil)
 				s.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, nil)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(compute.VirtualMachineScaleSet{}, notFoundError)
 			},
 		},
 		{
-			name:          "vmss already deleted",
-			expectedError: "",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:     name,
-					Size:     "VM_SIZE",
-					Capacity: 3,
-				}).AnyTimes()
-				s.ResourceGroup().AnyTimes().Return(resourceGroup)
-				s.GetLongRunningOperationState(name, serviceName, infrav1.DeleteFuture).Return(nil)
-				m.DeleteAsync(gomockinternal.AContext(), resourceGroup, name).
-					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
-				m.Get(gomockinternal.AContext(), resourceGroup, name).
-					Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
+			name:          "vmss deletion in progress",
+			expectedError: "operation type DELETE on Azure resource my-rg/my-vmss is not done",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				notDoneError := azure.NewOperationNotDoneError(&infrav1.Future{
+					Type:          infrav1.DeleteFuture,
+					ResourceGroup: defaultResourceGroup,
+					Name:          defaultVMSSName,
+				})
+				r.DeleteResource(gomockinternal.AContext(), newDefaultResourceSpec(), serviceName).Return(notDoneError)
+				s.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, notDoneError)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
+				s.SetVMSSState(gomock.AssignableToTypeOf(&azure.VMSS{}))
 			},
 		},
 		{
 			name:          "vmss deletion fails",
-			expectedError: "failed to delete VMSS my-vmss in resource group my-rg: #: Internal Server Error: StatusCode=500",
-			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-				s.ScaleSetSpec().Return(azure.ScaleSetSpec{
-					Name:     name,
-					Size:     "VM_SIZE",
-					Capacity: 3,
-				}).AnyTimes()
-				s.ResourceGroup().AnyTimes().Return(resourceGroup)
-				s.GetLongRunningOperationState(name, serviceName, infrav1.DeleteFuture).Return(nil)
-				m.DeleteAsync(gomockinternal.AContext(), resourceGroup, name).
-					Return(nil, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error"))
-				m.Get(gomockinternal.AContext(), resourceGroup, name).
-					Return(newDefaultVMSS("VM_SIZE"), nil)
-				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil).AnyTimes()
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.ScaleSetSpec().Return(newDefaultVMSSSpec()).AnyTimes()
+				s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+				r.DeleteResource(gomockinternal.AContext(), newDefaultResourceSpec(), serviceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.BootstrapSucceededCondition, serviceName, internalError)
+				m.Get(gomockinternal.AContext(), newDefaultResourceSpec()).Return(newDefaultExistingVMSS("VM_SIZE"), nil)
+				m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
 				s.SetVMSSState(gomock.AssignableToTypeOf(&azure.VMSS{}))
 			},
 		},
@@ -903,12 +538,14 @@ func TestDeleteVMSS(t *testing.T) {
 			defer mockCtrl.Finish()
 			scopeMock := mock_scalesets.NewMockScaleSetScope(mockCtrl)
 			clientMock := mock_scalesets.NewMockClient(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
 
-			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT(), asyncMock.EXPECT())
 
 			s := &Service{
-				Scope:  scopeMock,
-				Client: clientMock,
+				Scope:      scopeMock,
+				Client:     clientMock,
+				Reconciler: asyncMock,
 			}
 
 			err := s.Delete(context.TODO())
@@ -1095,6 +732,34 @@ func getFakeSkus() []compute.ResourceSku {
 				},
 			},
 		},
+		{
+			Name:         pointer.String("VM_SIZE_CVM"),
+			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
+			Kind:         pointer.String(string(resourceskus.VirtualMachines)),
+			Locations: &[]string{
+				"test-location",
+			},
+			LocationInfo: &[]compute.ResourceSkuLocationInfo{
+				{
+					Location: pointer.String("test-location"),
+					Zones:    &[]string{"1", "3"},
+				},
+			},
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{
+					Name:  pointer.String(resourceskus.VCPUs),
+					Value: pointer.String("4"),
+				},
+				{
+					Name:  pointer.String(resourceskus.MemoryGB),
+					Value: pointer.String("16"),
+				},
+				{
+					Name:  pointer.String(resourceskus.ConfidentialComputingType),
+					Value: pointer.String("SNP"),
+				},
+			},
+		},
 		{
 			Name:         pointer.String("VM_SIZE_USSD"),
 			ResourceType: pointer.String(string(resourceskus.VirtualMachines)),
@@ -1224,10 +889,19 @@ func newDefaultVMSSSpec() azure.ScaleSetSpec {
 	}
 }
 
-func newWindowsVMSSSpec() azure.ScaleSetSpec {
-	vmss := newDefaultVMSSSpec()
-	vmss.OSDisk.OSType = azure.WindowsOS
-	return vmss
+func getFakeSKU(vmSize string) resourceskus.SKU {
+	sku, err := resourceskus.NewStaticCache(getFakeSkus(), "test-location").Get(context.TODO(), vmSize, resourceskus.VirtualMachines)
+	if err != nil {
+		panic(err)
+	}
+	return sku
+}
+
+func newDefaultResourceSpec() *ScaleSetSpec {
+	return &ScaleSetSpec{
+		ScaleSetSpec:  newDefaultVMSSSpec(),
+		ResourceGroup: defaultResourceGroup,
+	}
 }
 
 func newDefaultExistingVMSS(vmSize string) compute.VirtualMachineScaleSet {
@@ -1479,43 +1153,15 @@ func newDefaultInstances() []compute.VirtualMachineScaleSetVM {
 	}
 }
 
-func setupDefaultVMSSInProgressOperationDoneExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, createdVMSS compute.VirtualMachineScaleSet, instances []compute.VirtualMachineScaleSetVM) {
-	createdVMSS.ID = pointer.String("subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
-	createdVMSS.ProvisioningState = pointer.String(string(infrav1.Succeeded))
-	setupDefaultVMSSExpectations(s)
-	future := &infrav1.Future{
-		Type:          infrav1.PutFuture,
-		ResourceGroup: defaultResourceGroup,
-		Name:          defaultVMSSName,
-		Data:          "",
-	}
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(future)
-	m.GetResultIfDone(gomockinternal.AContext(), future).Return(createdVMSS, nil).AnyTimes()
-	m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(instances, nil).AnyTimes()
-	s.MaxSurge().Return(1, nil)
-	s.SetVMSSState(gomock.Any())
-	s.SetProviderID(azure.ProviderIDPrefix + *createdVMSS.ID)
-}
-
-func setupDefaultVMSSStartCreatingExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
-	setupDefaultVMSSExpectations(s)
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
-	m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).
-		Return(compute.VirtualMachineScaleSet{}, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found"))
-}
-
-func setupCreatingSucceededExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder, vmss compute.VirtualMachineScaleSet, future *infrav1.Future) {
-	s.SetLongRunningOperationState(future)
-	m.GetResultIfDone(gomockinternal.AContext(), future).Return(compute.VirtualMachineScaleSet{}, azure.NewOperationNotDoneError(future))
-	m.Get(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(vmss, nil)
-	m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil).AnyTimes()
-	s.SetVMSSState(gomock.Any())
-	s.SetProviderID(azure.ProviderIDPrefix + *vmss.ID)
-}
-
 func setupDefaultVMSSExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	setupVMSSExpectationsWithoutVMImage(s)
+	s.SubscriptionID().AnyTimes().Return(defaultSubscriptionID)
+	s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
+	s.AdditionalTags()
+	s.Location().AnyTimes().Return("test-location")
+	s.ClusterName().Return("my-cluster")
+	s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
+	s.VMSSExtensionSpecs().Return(newDefaultVMSSExtensionSpecs()).AnyTimes()
+	s.MaxSurge().Return(1, nil)
 	image := &infrav1.Image{
 		Marketplace: &infrav1.AzureMarketplaceImage{
 			ImagePlan: infrav1.ImagePlan{
@@ -1530,30 +1176,14 @@ func setupDefaultVMSSExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorde
 	s.SaveVMImageToStatus(image)
 }
 
-func setupUpdateVMSSExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	setupVMSSExpectationsWithoutVMImage(s)
-	image := &infrav1.Image{
-		Marketplace: &infrav1.AzureMarketplaceImage{
-			ImagePlan: infrav1.ImagePlan{
-				Publisher: "fake-publisher",
-				Offer:     "my-offer",
-				SKU:       "sku-id",
-			},
-			Version: "2.0",
-		},
-	}
-	s.GetVMImage(gomockinternal.AContext()).Return(image, nil).AnyTimes()
-	s.SaveVMImageToStatus(image)
+func setupVMSSStateExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder, m *mock_scalesets.MockClientMockRecorder) {
+	m.ListInstances(gomockinternal.AContext(), defaultResourceGroup, defaultVMSSName).Return(newDefaultInstances(), nil)
+	s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+	s.SetVMSSState(gomock.AssignableToTypeOf(&azure.VMSS{}))
 }
 
-func setupVMSSExpectationsWithoutVMImage(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	s.SubscriptionID().AnyTimes().Return(defaultSubscriptionID)
-	s.ResourceGroup().AnyTimes().Return(defaultResourceGroup)
-	s.AdditionalTags()
-	s.Location().AnyTimes().Return("test-location")
-	s.ClusterName().Return("my-cluster")
-	s.GetBootstrapData(gomockinternal.AContext()).Return("fake-bootstrap-data", nil)
-	s.VMSSExtensionSpecs().Return([]azure.ResourceSpecGetter{
+func newDefaultVMSSExtensionSpecs() []azure.ResourceSpecGetter {
+	return []azure.ResourceSpecGetter{
 		&VMSSExtensionSpec{
 			ExtensionSpec: azure.ExtensionSpec{
 				Name:      "someExtension",
@@ -1569,15 +1199,5 @@ func setupVMSSExpectationsWithoutVMImage(s *mock_scalesets.MockScaleSetScopeMock
 			},
 			ResourceGroup: "my-rg",
 		},
-	}).AnyTimes()
-	s.ReconcileReplicas(gomock.Any(), gomock.Any()).AnyTimes()
-}
-
-func setupDefaultVMSSUpdateExpectations(s *mock_scalesets.MockScaleSetScopeMockRecorder) {
-	setupUpdateVMSSExpectations(s)
-	s.SetProviderID(azure.ProviderIDPrefix + "subscriptions/1234/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PutFuture).Return(nil)
-	s.GetLongRunningOperationState(defaultVMSSName, serviceName, infrav1.PatchFuture).Return(nil)
-	s.MaxSurge().Return(1, nil)
-	s.SetVMSSState(gomock.Any())
+	}
 }
@@ -0,0 +1,668 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/util/generators"
+	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+)
+
+// ScaleSetSpec defines the specification for a Scale Set.
+type ScaleSetSpec struct {
+	azure.ScaleSetSpec
+	ResourceGroup      string
+	SubscriptionID     string
+	Location           string
+	ClusterName        string
+	AdditionalTags     infrav1.Tags
+	SKU                resourceskus.SKU
+	VMImage            *infrav1.Image
+	BootstrapData      string
+	MaxSurge           int
+	VMSSExtensionSpecs []azure.ResourceSpecGetter
+	VMSSInstances      []compute.VirtualMachineScaleSetVM
+}
+
+var _ azure.ResourceSpecGetterWithDrift = (*ScaleSetSpec)(nil)
+
+// ResourceName returns the name of the Scale Set.
+func (s *ScaleSetSpec) ResourceName() string {
+	return s.Name
+}
+
+// ResourceGroupName returns the name of the resource group.
+func (s *ScaleSetSpec) ResourceGroupName() string {
+	return s.ResourceGroup
+}
+
+// OwnerResourceName is a no-op for Scale Sets.
+func (s *ScaleSetSpec) OwnerResourceName() string {
+	return ""
+}
+
+// Parameters returns the parameters for the Scale Set. A new scale set is created with its full model, while an
+// existing one is patched with a compute.VirtualMachineScaleSetUpdate so that the fields of its network profile
+// modified by the cloud provider are not overwritten.
+func (s *ScaleSetSpec) Parameters(ctx context.Context, existing interface{}) (parameters interface{}, err error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "scalesets.ScaleSetSpec.Parameters")
+	defer done()
+
+	vmss, err := s.buildVMSSFromSpec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed building VMSS from spec")
+	}
+
+	if existing == nil {
+		return vmss, nil
+	}
+
+	existingVMSS, ok := existing.(compute.VirtualMachineScaleSet)
+	if !ok {
+		return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSet", existing)
+	}
+	infraVMSS := converters.SDKToVMSS(existingVMSS, s.VMSSInstances)
+
+	patch, err := getVMSSUpdateFromVMSS(vmss)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate vmss patch for %s", s.Name)
+	}
+
+	hasModelChanges := hasModelModifyingDifferences(infraVMSS, vmss)
+	var isFlex bool
+	for _, instance := range infraVMSS.Instances {
+		if instance.IsFlex() {
+			isFlex = true
+			break
+		}
+	}
+	updated := true
+	if !isFlex {
+		updated = infraVMSS.HasEnoughLatestModelOrNotMixedModel()
+	}
+	if s.MaxSurge > 0 && (hasModelChanges || !updated) {
+		// surge capacity with the intention of lowering during instance reconciliation
+		surge := s.Capacity + int64(s.MaxSurge)
+		log.V(4).Info("surging...", "surge", surge, "hasModelChanges", hasModelChanges, "updated", updated)
+		patch.Sku.Capacity = pointer.Int64(surge)
+	}
+
+	// If there are no model changes and no increase in the replica count, do not update the VMSS.
+	// Decreases in replica count is handled by deleting AzureMachinePoolMachine instances in the MachinePoolScope
+	if *patch.Sku.Capacity <= infraVMSS.Capacity && !hasModelChanges {
+		log.V(4).Info("nothing to update on vmss", "scale set", s.Name, "newReplicas", *patch.Sku.Capacity, "oldReplicas", infraVMSS.Capacity, "hasChanges", hasModelChanges)
+		return nil, nil
+	}
+
+	log.V(4).Info("patching vmss", "scale set", s.Name, "patch", patch)
+	return patch, nil
+}
+
+// DesiredParameters returns the full model of the Scale Set, to detect the drift of the existing Scale Set.
+func (s *ScaleSetSpec) DesiredParameters(ctx context.Context) (interface{}, error) {
+	return s.Parameters(ctx, nil)
+}
+
+// DriftIgnoredPaths returns the properties of the Scale Set that are not returned by Azure.
+func (s *ScaleSetSpec) DriftIgnoredPaths() []string {
+	return []string{
+		"properties.virtualMachineProfile.osProfile.customData",
+		"properties.virtualMachineProfile.osProfile.adminPassword",
+		"properties.virtualMachineProfile.extensionProfile.extensions[].properties.protectedSettings",
+	}
+}
+
+func (s *ScaleSetSpec) buildVMSSFromSpec(ctx context.Context) (compute.VirtualMachineScaleSet, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesets.ScaleSetSpec.buildVMSSFromSpec")
+	defer done()
+
+	// Work on a copy of the spec so that defaulting does not leak into the spec itself.
+	vmssSpec := s.ScaleSetSpec
+	if vmssSpec.AcceleratedNetworking == nil {
+		// set accelerated networking to the capability of the VMSize
+		accelNet := s.SKU.HasCapability(resourceskus.AcceleratedNetworking)
+		vmssSpec.AcceleratedNetworking = &accelNet
+	}
+
+	extensions, err := s.generateExtensions(ctx)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	storageProfile, err := s.generateStorageProfile(ctx, vmssSpec)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	securityProfile, err := getSecurityProfile(vmssSpec, s.SKU)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	priority, evictionPolicy, billingProfile, err := converters.GetSpotVMOptions(vmssSpec.SpotVMOptions, vmssSpec.OSDisk.DiffDiskSettings)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, errors.Wrapf(err, "failed to get Spot VM options")
+	}
+
+	diagnosticsProfile := converters.GetDiagnosticsProfile(vmssSpec.DiagnosticsProfile)
+
+	// Get the node outbound LB backend pool ID
+	var backendAddressPools []compute.SubResource
+	if vmssSpec.PublicLBName != "" {
+		if vmssSpec.PublicLBAddressPoolName != "" {
+			backendAddressPools = append(backendAddressPools,
+				compute.SubResource{
+					ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, vmssSpec.PublicLBName, vmssSpec.PublicLBAddressPoolName)),
+				})
+		}
+	}
+
+	osProfile, err := s.generateOSProfile(vmssSpec)
+	if err != nil {
+		return compute.VirtualMachineScaleSet{}, err
+	}
+
+	// The scale set defaults to the availability zone of its dedicated host group.
+	zones := vmssSpec.FailureDomains
+	if len(zones) == 0 && vmssSpec.HostGroupZone != "" {
+		zones = []string{vmssSpec.HostGroupZone}
+	}
+
+	orchestrationMode := converters.GetOrchestrationMode(vmssSpec.OrchestrationMode)
+	vmss := compute.VirtualMachineScaleSet{
+		Location: pointer.String(s.Location),
+		Sku: &compute.Sku{
+			Name:     pointer.String(vmssSpec.Size),
+			Tier:     pointer.String("Standard"),
+			Capacity: pointer.Int64(vmssSpec.Capacity),
+		},
+		Zones: &zones,
+		Plan:  s.generateImagePlan(),
+		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+			OrchestrationMode:    orchestrationMode,
+			SinglePlacementGroup: pointer.Bool(false),
+			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+				OsProfile:          osProfile,
+				StorageProfile:     storageProfile,
+				SecurityProfile:    securityProfile,
+				DiagnosticsProfile: diagnosticsProfile,
+				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
+					NetworkInterfaceConfigurations: &[]compute.VirtualMachineScaleSetNetworkConfiguration{
+						{
+							Name: pointer.String(vmssSpec.Name),
+							VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+								Primary:            pointer.Bool(true),
+								EnableIPForwarding: pointer.Bool(true),
+								IPConfigurations: &[]compute.VirtualMachineScaleSetIPConfiguration{
+									{
+										Name: pointer.String(vmssSpec.Name),
+										VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+											Subnet: &compute.APIEntityReference{
+												ID: pointer.String(azure.SubnetID(s.SubscriptionID, vmssSpec.VNetResourceGroup, vmssSpec.VNetName, vmssSpec.SubnetName)),
+											},
+											Primary:                         pointer.Bool(true),
+											PrivateIPAddressVersion:         compute.IPVersionIPv4,
+											LoadBalancerBackendAddressPools: &backendAddressPools,
+										},
+									},
+								},
+								EnableAcceleratedNetworking: vmssSpec.AcceleratedNetworking,
+							},
+						},
+					},
+				},
+				Priority:       priority,
+				EvictionPolicy: evictionPolicy,
+				BillingProfile: billingProfile,
+				ExtensionProfile: &compute.VirtualMachineScaleSetExtensionProfile{
+					Extensions: &extensions,
+				},
+			},
+		},
+	}
+
+	if vmssSpec.HostGroupID != "" {
+		vmss.VirtualMachineScaleSetProperties.HostGroup = &compute.SubResource{ID: pointer.String(vmssSpec.HostGroupID)}
+	}
+
+	if vmssSpec.ProximityPlacementGroupID != "" {
+		vmss.VirtualMachineScaleSetProperties.ProximityPlacementGroup = &compute.SubResource{ID: pointer.String(vmssSpec.ProximityPlacementGroupID)}
+	}
+
+	if vmssSpec.CapacityReservationGroupID != "" {
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.CapacityReservation = &compute.CapacityReservationProfile{
+			CapacityReservationGroup: &compute.SubResource{ID: pointer.String(vmssSpec.CapacityReservationGroupID)},
+		}
+	}
+
+	// Set properties specific to VMSS orchestration mode
+	switch orchestrationMode {
+	case compute.OrchestrationModeUniform:
+		vmss.VirtualMachineScaleSetProperties.Overprovision = pointer.Bool(false)
+		vmss.VirtualMachineScaleSetProperties.UpgradePolicy = &compute.UpgradePolicy{Mode: compute.UpgradeModeManual}
+	case compute.OrchestrationModeFlexible:
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkAPIVersion =
+			compute.NetworkAPIVersionTwoZeroTwoZeroHyphenMinusOneOneHyphenMinusZeroOne
+		vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = pointer.Int32(1)
+		if len(vmssSpec.FailureDomains) > 1 {
+			vmss.VirtualMachineScaleSetProperties.PlatformFaultDomainCount = pointer.Int32(int32(len(vmssSpec.FailureDomains)))
+		}
+	}
+
+	// Use custom NIC definitions in VMSS if set
+	if len(vmssSpec.NetworkInterfaces) > 0 {
+		nicConfigs := []compute.VirtualMachineScaleSetNetworkConfiguration{}
+		for i, n := range vmssSpec.NetworkInterfaces {
+			nicConfig := compute.VirtualMachineScaleSetNetworkConfiguration{}
+			nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties = &compute.VirtualMachineScaleSetNetworkConfigurationProperties{}
+			nicConfig.Name = pointer.String(vmssSpec.Name + "-" + strconv.Itoa(i))
+			if pointer.BoolDeref(n.AcceleratedNetworking, false) {
+				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.EnableAcceleratedNetworking = pointer.Bool(true)
+			} else {
+				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.EnableAcceleratedNetworking = pointer.Bool(false)
+			}
+			if n.PrivateIPConfigs == 0 {
+				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.IPConfigurations = &[]compute.VirtualMachineScaleSetIPConfiguration{
+					{
+						Name: pointer.String(vmssSpec.Name + "-" + strconv.Itoa(i)),
+						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+							Subnet: &compute.APIEntityReference{
+								ID: pointer.String(azure.SubnetID(s.SubscriptionID, vmssSpec.VNetResourceGroup, vmssSpec.VNetName, n.SubnetName)),
+							},
+							Primary:                         pointer.Bool(true),
+							PrivateIPAddressVersion:         compute.IPVersionIPv4,
+							LoadBalancerBackendAddressPools: &backendAddressPools,
+						},
+					},
+				}
+			} else {
+				ipconfigs := []compute.VirtualMachineScaleSetIPConfiguration{}
+
+				// Create IPConfigs
+				for j := 0; j < n.PrivateIPConfigs; j++ {
+					ipconfig := compute.VirtualMachineScaleSetIPConfiguration{
+						Name: pointer.String(fmt.Sprintf("private-ipConfig-%v", j)),
+						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+							PrivateIPAddressVersion: compute.IPVersionIPv4,
+							Subnet: &compute.APIEntityReference{
+								ID: pointer.String(azure.SubnetID(s.SubscriptionID, vmssSpec.VNetResourceGroup, vmssSpec.VNetName, n.SubnetName)),
+							},
+						},
+					}
+
+					ipconfig.Subnet = &compute.APIEntityReference{
+						ID: pointer.String(azure.SubnetID(s.SubscriptionID, vmssSpec.VNetResourceGroup, vmssSpec.VNetName, n.SubnetName)),
+					}
+					ipconfigs = append(ipconfigs, ipconfig)
+				}
+				if i == 0 {
+					ipconfigs[0].LoadBalancerBackendAddressPools = &backendAddressPools
+				}
+				// Always use the first IPConfig as the Primary
+				ipconfigs[0].Primary = pointer.Bool(true)
+				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.IPConfigurations = &ipconfigs
+			}
+			if vmssSpec.IPv6Enabled {
+				ipconfigs := append(*nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.IPConfigurations, s.getIPv6IPConfiguration(vmssSpec, n.SubnetName, i == 0))
+				nicConfig.VirtualMachineScaleSetNetworkConfigurationProperties.IPConfigurations = &ipconfigs
+			}
+			nicConfigs = append(nicConfigs, nicConfig)
+		}
+		nicConfigs[0].VirtualMachineScaleSetNetworkConfigurationProperties.Primary = pointer.Bool(true)
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations = &nicConfigs
+	} else {
+		// Set default interface configuration if no custom ones are specified
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations = s.getVirtualMachineScaleSetDefaultNetworkConfiguration(vmssSpec)
+	}
+
+	// Assign Identity to VMSS
+	if vmssSpec.Identity == infrav1.VMIdentitySystemAssigned {
+		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+			Type: compute.ResourceIdentityTypeSystemAssigned,
+		}
+	} else if vmssSpec.Identity == infrav1.VMIdentityUserAssigned {
+		userIdentitiesMap, err := converters.UserAssignedIdentitiesToVMSSSDK(vmssSpec.UserAssignedIdentities)
+		if err != nil {
+			return vmss, errors.Wrapf(err, "failed to assign identity %q", vmssSpec.Name)
+		}
+		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+			Type:                   compute.ResourceIdentityTypeUserAssigned,
+			UserAssignedIdentities: userIdentitiesMap,
+		}
+	}
+
+	// Provisionally detect whether there is any Data Disk defined which uses UltraSSDs.
+	// If that's the case, enable the UltraSSD capability.
+	for _, dataDisk := range vmssSpec.DataDisks {
+		if dataDisk.ManagedDisk != nil && dataDisk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) {
+			vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{
+				UltraSSDEnabled: pointer.Bool(true),
+			}
+		}
+	}
+
+	// Set Additional Capabilities if any is present on the spec.
+	if vmssSpec.AdditionalCapabilities != nil {
+		// Set UltraSSDEnabled if a specific value is set on the spec for it.
+		if vmssSpec.AdditionalCapabilities.UltraSSDEnabled != nil {
+			vmss.AdditionalCapabilities.UltraSSDEnabled = vmssSpec.AdditionalCapabilities.UltraSSDEnabled
+		}
+	}
+
+	if vmssSpec.TerminateNotificationTimeout != nil {
+		vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.ScheduledEventsProfile = &compute.ScheduledEventsProfile{
+			TerminateNotificationProfile: &compute.TerminateNotificationProfile{
+				NotBeforeTimeout: pointer.String(fmt.Sprintf("PT%dM", *vmssSpec.TerminateNotificationTimeout)),
+				Enable:           pointer.Bool(true),
+			},
+		}
+	}
+
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.ClusterName,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        pointer.String(vmssSpec.Name),
+		Role:        pointer.String(infrav1.Node),
+		Additional:  s.AdditionalTags,
+	})
+
+	vmss.Tags = converters.TagsToMap(tags)
+	return vmss, nil
+}
+
+func (s *ScaleSetSpec) getVirtualMachineScaleSetDefaultNetworkConfiguration(vmssSpec azure.ScaleSetSpec) *[]compute.VirtualMachineScaleSetNetworkConfiguration {
+	var backendAddressPools []compute.SubResource
+	if vmssSpec.PublicLBName != "" {
+		if vmssSpec.PublicLBAddressPoolName != "" {
+			backendAddressPools = append(backendAddressPools,
+				compute.SubResource{
+					ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, vmssSpec.PublicLBName, vmssSpec.PublicLBAddressPoolName)),
+				})
+		}
+	}
+	ipConfigs := []compute.VirtualMachineScaleSetIPConfiguration{
+		{
+			Name: pointer.String(vmssSpec.Name),
+			VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+				Subnet: &compute.APIEntityReference{
+					ID: pointer.String(azure.SubnetID(s.SubscriptionID, vmssSpec.VNetResourceGroup, vmssSpec.VNetName, vmssSpec.SubnetName)),
+				},
+				Primary:                         pointer.Bool(true),
+				PrivateIPAddressVersion:         compute.IPVersionIPv4,
+				LoadBalancerBackendAddressPools: &backendAddressPools,
+			},
+		},
+	}
+	if vmssSpec.IPv6Enabled {
+		ipConfigs = append(ipConfigs, s.getIPv6IPConfiguration(vmssSpec, vmssSpec.SubnetName, true))
+	}
+	return &[]compute.VirtualMachineScaleSetNetworkConfiguration{{
+		Name: pointer.String(vmssSpec.Name),
+		VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+			Primary:                     pointer.Bool(true),
+			EnableIPForwarding:          pointer.Bool(true),
+			IPConfigurations:            &ipConfigs,
+			EnableAcceleratedNetworking: vmssSpec.AcceleratedNetworking,
+		},
+	}}
+}
+
+// getIPv6IPConfiguration returns the IPv6 IP configuration of a network interface of a dual-stack scale set. The IPv6
+// IP configuration of the primary network interface joins the IPv6 backend pool of the public load balancer.
+func (s *ScaleSetSpec) getIPv6IPConfiguration(vmssSpec azure.ScaleSetSpec, subnetName string, primaryNetworkInterface bool) compute.VirtualMachineScaleSetIPConfiguration {
+	var backendAddressPools []compute.SubResource
+	if primaryNetworkInterface && vmssSpec.PublicLBName != "" && vmssSpec.PublicLBIPv6AddressPoolName != "" {
+		backendAddressPools = append(backendAddressPools,
+			compute.SubResource{
+				ID: pointer.String(azure.AddressPoolID(s.SubscriptionID, s.ResourceGroup, vmssSpec.PublicLBName, vmssSpec.PublicLBIPv6AddressPoolName)),
+			})
+	}
+	return compute.VirtualMachineScaleSetIPConfiguration{
+		Name: pointer.String("ipConfigv6"),
+		VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+			Subnet: &compute.APIEntityReference{
+				ID: pointer.String(azure.SubnetID(s.SubscriptionID, vmssSpec.VNetResourceGroup, vmssSpec.VNetName, subnetName)),
+			},
+			Primary:                         pointer.Bool(false),
+			PrivateIPAddressVersion:         compute.IPVersionIPv6,
+			LoadBalancerBackendAddressPools: &backendAddressPools,
+		},
+	}
+}
+
+func (s *ScaleSetSpec) generateExtensions(ctx context.Context) ([]compute.VirtualMachineScaleSetExtension, error) {
+	extensions := make([]compute.VirtualMachineScaleSetExtension, len(s.VMSSExtensionSpecs))
+	for i, extensionSpec := range s.VMSSExtensionSpecs {
+		extensionSpec := extensionSpec
+		parameters, err := extensionSpec.Parameters(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		vmssextension, ok := parameters.(compute.VirtualMachineScaleSetExtension)
+		if !ok {
+			return nil, errors.Errorf("%T is not a compute.VirtualMachineScaleSetExtension", parameters)
+		}
+		extensions[i] = vmssextension
+	}
+
+	return extensions, nil
+}
+
+// generateStorageProfile generates a pointer to a compute.VirtualMachineScaleSetStorageProfile which can utilized for VM creation.
+
+func (s *ScaleSetSpec) generateStorageProfile(ctx context.Context, vmssSpec azure.ScaleSetSpec) (*compute.VirtualMachineScaleSetStorageProfile, error) {
+	_, _, done := tele.StartSpanWithLogger(ctx, "scalesets.ScaleSetSpec.generateStorageProfile")
+	defer done()
+
+	storageProfile := &compute.VirtualMachineScaleSetStorageProfile{
+		OsDisk: &compute.VirtualMachineScaleSetOSDisk{
+			OsType:       compute.OperatingSystemTypes(vmssSpec.OSDisk.OSType),
+			CreateOption: compute.DiskCreateOptionTypesFromImage,
+			DiskSizeGB:   vmssSpec.OSDisk.DiskSizeGB,
+		},
+	}
+
+	// enable ephemeral OS
+	if vmssSpec.OSDisk.DiffDiskSettings != nil {
+		if !s.SKU.HasCapability(resourceskus.EphemeralOSDisk) {
+			return nil, fmt.Errorf("vm size %s does not support ephemeral os. select a different vm size or disable ephemeral os", vmssSpec.Size)
+		}
+
+		storageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+			Option: compute.DiffDiskOptions(vmssSpec.OSDisk.DiffDiskSettings.Option),
+		}
+	}
+
+	if vmssSpec.OSDisk.ManagedDisk != nil {
+		storageProfile.OsDisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{}
+		if vmssSpec.OSDisk.ManagedDisk.StorageAccountType != "" {
+			storageProfile.OsDisk.ManagedDisk.StorageAccountType = compute.StorageAccountTypes(vmssSpec.OSDisk.ManagedDisk.StorageAccountType)
+		}
+		if vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
+			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(vmssSpec.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
+		}
+		storageProfile.OsDisk.ManagedDisk.SecurityProfile = converters.GetDiskSecurityProfile(vmssSpec.OSDisk.ManagedDisk.SecurityProfile)
+	}
+
+	if vmssSpec.OSDisk.CachingType != "" {
+		storageProfile.OsDisk.Caching = compute.CachingTypes(vmssSpec.OSDisk.CachingType)
+	}
+
+	dataDisks := make([]compute.VirtualMachineScaleSetDataDisk, len(vmssSpec.DataDisks))
+	for i, disk := range vmssSpec.DataDisks {
+		dataDisks[i] = compute.VirtualMachineScaleSetDataDisk{
+			CreateOption: compute.DiskCreateOptionTypesEmpty,
+			DiskSizeGB:   pointer.Int32(disk.DiskSizeGB),
+			Lun:          disk.Lun,
+			Name:         pointer.String(azure.GenerateDataDiskName(vmssSpec.Name, disk.NameSuffix)),
+		}
+
+		if disk.ManagedDisk != nil {
+			dataDisks[i].ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
+				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
+			}
+
+			if disk.ManagedDisk.DiskEncryptionSet != nil {
+				dataDisks[i].ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(disk.ManagedDisk.DiskEncryptionSet.ID)}
+			}
+		}
+	}
+	storageProfile.DataDisks = &dataDisks
+
+	if s.VMImage == nil {
+		return nil, errors.New("failed to get VM image")
+	}
+
+	imageRef, err := converters.ImageToSDK(s.VMImage)
+	if err != nil {
+		return nil, err
+	}
+
+	storageProfile.ImageReference = imageRef
+
+	return storageProfile, nil
+}
+
+func (s *ScaleSetSpec) generateOSProfile(vmssSpec azure.ScaleSetSpec) (*compute.VirtualMachineScaleSetOSProfile, error) {
+	sshKey, err := base64.StdEncoding.DecodeString(vmssSpec.SSHKeyData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode ssh public key")
+	}
+
+	osProfile := &compute.VirtualMachineScaleSetOSProfile{
+		ComputerNamePrefix: pointer.String(vmssSpec.Name),
+		AdminUsername:      pointer.String(azure.DefaultUserName),
+		CustomData:         pointer.String(s.BootstrapData),
+	}
+
+	switch vmssSpec.OSDisk.OSType {
+	case string(compute.OperatingSystemTypesWindows):
+		// Cloudbase-init is used to generate a password.
+		// https://cloudbase-init.readthedocs.io/en/latest/plugins.html#setting-password-main
+		//
+		// We generate a random password here in case of failure
+		// but the password on the VM will NOT be the same as created here.
+		// Access is provided via SSH public key that is set during deployment
+		// Azure also provides a way to reset user passwords in the case of need.
+		osProfile.AdminPassword = pointer.String(generators.SudoRandomPassword(123))
+		osProfile.WindowsConfiguration = &compute.WindowsConfiguration{
+			EnableAutomaticUpdates: pointer.Bool(false),
+		}
+	default:
+		osProfile.LinuxConfiguration = &compute.LinuxConfiguration{
+			DisablePasswordAuthentication: pointer.Bool(true),
+			SSH: &compute.SSHConfiguration{
+				PublicKeys: &[]compute.SSHPublicKey{
+					{
+						Path:    pointer.String(fmt.Sprintf("/home/%s/.ssh/authorized_keys", azure.DefaultUserName)),
+						KeyData: pointer.String(string(sshKey)),
+					},
+				},
+			},
+		}
+	}
+
+	return osProfile, nil
+}
+
+func (s *ScaleSetSpec) generateImagePlan() *compute.Plan {
+	image := s.VMImage
+	if image == nil {
+		return nil
+	}
+
+	if image.SharedGallery != nil && image.SharedGallery.Publisher != nil && image.SharedGallery.SKU != nil && image.SharedGallery.Offer != nil {
+		return &compute.Plan{
+			Publisher: image.SharedGallery.Publisher,
+			Name:      image.SharedGallery.SKU,
+			Product:   image.SharedGallery.Offer,
+		}
+	}
+
+	if image.Marketplace == nil || !image.Marketplace.ThirdPartyImage {
+		return nil
+	}
+
+	if image.Marketplace.Publisher == "" || image.Marketplace.SKU == "" || image.Marketplace.Offer == "" {
+		return nil
+	}
+
+	return &compute.Plan{
+		Publisher: pointer.String(image.Marketplace.Publisher),
+		Name:      pointer.String(image.Marketplace.SKU),
+		Product:   pointer.String(image.Marketplace.Offer),
+	}
+}
+
+func getVMSSUpdateFromVMSS(vmss compute.VirtualMachineScaleSet) (compute.VirtualMachineScaleSetUpdate, error) {
+	jsonData, err := vmss.MarshalJSON()
+	if err != nil {
+		return compute.VirtualMachineScaleSetUpdate{}, err
+	}
+
+	var update compute.VirtualMachineScaleSetUpdate
+	if err := update.UnmarshalJSON(jsonData); err != nil {
+		return update, err
+	}
+
+	// wipe out network profile, so updates won't conflict with Cloud Provider updates
+	update.VirtualMachineProfile.NetworkProfile = nil
+	return update, nil
+}
+
+func getSecurityProfile(vmssSpec azure.ScaleSetSpec, sku resourceskus.SKU) (*compute.SecurityProfile, error) {
+	if vmssSpec.SecurityProfile == nil {
+		return nil, nil
+	}
+
+	if vmssSpec.SecurityProfile.EncryptionAtHost != nil && *vmssSpec.SecurityProfile.EncryptionAtHost && !sku.HasCapability(resourceskus.EncryptionAtHost) {
+		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", vmssSpec.Size))
+	}
+
+	switch vmssSpec.SecurityProfile.SecurityType {
+	case infrav1.SecurityTypesTrustedLaunch:
+		if sku.HasCapability(resourceskus.TrustedLaunchDisabled) {
+			return nil, azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", vmssSpec.Size))
+		}
+	case infrav1.SecurityTypesConfidentialVM:
+		if _, ok := sku.GetCapability(resourceskus.ConfidentialComputingType); !ok {
+			return nil, azure.WithTerminalError(errors.Errorf("confidential VM is not supported for VM type %s", vmssSpec.Size))
+		}
+	}
+
+	return &compute.SecurityProfile{
+		EncryptionAtHost: vmssSpec.SecurityProfile.EncryptionAtHost,
+		SecurityType:     compute.SecurityTypes(vmssSpec.SecurityProfile.SecurityType),
+		UefiSettings:     converters.GetUefiSettings(vmssSpec.SecurityProfile.UefiSettings),
+	}, nil
+}
+
+func hasModelModifyingDifferences(infraVMSS *azure.VMSS, vmss compute.VirtualMachineScaleSet) bool {
+	other := converters.SDKToVMSS(vmss, []compute.VirtualMachineScaleSetVM{})
+	return infraVMSS.HasModelChanges(*other)
+}
@@ -0,0 +1,667 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalesets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
+)
+
+func TestScaleSetParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		setup         func(spec *ScaleSetSpec)
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "fails if existing is not a VirtualMachineScaleSet",
+			existing: network.VirtualNetwork{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "network.VirtualNetwork is not a compute.VirtualMachineScaleSet",
+		},
+		{
+			name: "can create a vmss with an ultra disk",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create vmss with defaulted accelerated networking when size allows",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = "VM_SIZE_AN"
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_AN")
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				(*netConfigs)[0].EnableAcceleratedNetworking = pointer.Bool(true)
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create vmss with custom networking when specified",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.NetworkInterfaces = []infrav1.NetworkInterface{
+					{
+						SubnetName:            "my-subnet",
+						PrivateIPConfigs:      1,
+						AcceleratedNetworking: pointer.Bool(true),
+					},
+					{
+						SubnetName:            "subnet2",
+						PrivateIPConfigs:      2,
+						AcceleratedNetworking: pointer.Bool(true),
+					},
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				(*netConfigs)[0].Name = pointer.String("my-vmss-0")
+				(*netConfigs)[0].EnableIPForwarding = nil
+				nic1IPConfigs := (*netConfigs)[0].IPConfigurations
+				(*nic1IPConfigs)[0].Name = pointer.String("private-ipConfig-0")
+				(*nic1IPConfigs)[0].PrivateIPAddressVersion = compute.IPVersionIPv4
+				(*netConfigs)[0].EnableAcceleratedNetworking = pointer.Bool(true)
+				(*netConfigs)[0].Primary = pointer.Bool(true)
+				vmssIPConfigs := []compute.VirtualMachineScaleSetIPConfiguration{
+					{
+						Name: pointer.String("private-ipConfig-0"),
+						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+							Primary:                 pointer.Bool(true),
+							PrivateIPAddressVersion: compute.IPVersionIPv4,
+							Subnet: &compute.APIEntityReference{
+								ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/subnet2"),
+							},
+						},
+					},
+					{
+						Name: pointer.String("private-ipConfig-1"),
+						VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+							PrivateIPAddressVersion: compute.IPVersionIPv4,
+							Subnet: &compute.APIEntityReference{
+								ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/subnet2"),
+							},
+						},
+					},
+				}
+				*netConfigs = append(*netConfigs, compute.VirtualMachineScaleSetNetworkConfiguration{
+					Name: pointer.String("my-vmss-1"),
+					VirtualMachineScaleSetNetworkConfigurationProperties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
+						EnableAcceleratedNetworking: pointer.Bool(true),
+						IPConfigurations:            &vmssIPConfigs,
+					},
+				})
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a dual-stack vmss",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.IPv6Enabled = true
+				spec.PublicLBIPv6AddressPoolName = "backendPool-ipv6"
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				netConfigs := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.NetworkProfile.NetworkInterfaceConfigurations
+				ipConfigs := append(*(*netConfigs)[0].IPConfigurations, compute.VirtualMachineScaleSetIPConfiguration{
+					Name: pointer.String("ipConfigv6"),
+					VirtualMachineScaleSetIPConfigurationProperties: &compute.VirtualMachineScaleSetIPConfigurationProperties{
+						Subnet: &compute.APIEntityReference{
+							ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/subnets/my-subnet"),
+						},
+						Primary:                 pointer.Bool(false),
+						PrivateIPAddressVersion: compute.IPVersionIPv6,
+						LoadBalancerBackendAddressPools: &[]compute.SubResource{
+							{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/loadBalancers/capz-lb/backendAddressPools/backendPool-ipv6")},
+						},
+					},
+				})
+				(*netConfigs)[0].IPConfigurations = &ipConfigs
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with spot vm",
+			setup: func(spec *ScaleSetSpec) {
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss in a dedicated host group",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.FailureDomains = nil
+				spec.HostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg"
+				spec.HostGroupZone = "2"
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.Zones = &[]string{"2"}
+				vmss.VirtualMachineScaleSetProperties.HostGroup = &compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg")}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss in a proximity placement group allocated from a capacity reservation group",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.ProximityPlacementGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+				spec.CapacityReservationGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.ProximityPlacementGroup = &compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg")}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.CapacityReservation = &compute.CapacityReservationProfile{
+					CapacityReservationGroup: &compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg")},
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with spot vm and ephemeral disk",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = vmSizeEPH
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{}
+				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option: string(compute.DiffDiskOptionsLocal),
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS(vmSizeEPH)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+					Option: compute.DiffDiskOptionsLocal,
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with spot vm and a defined delete evictionPolicy",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = vmSizeEPH
+				deletePolicy := infrav1.SpotEvictionPolicyDelete
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{EvictionPolicy: &deletePolicy}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS(vmSizeEPH)
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypesDelete
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with spot vm and a maximum price",
+			setup: func(spec *ScaleSetSpec) {
+				maxPrice := resource.MustParse("0.001")
+				spec.SpotVMOptions = &infrav1.SpotVMOptions{
+					MaxPrice: &maxPrice,
+				}
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.Priority = compute.VirtualMachinePriorityTypesSpot
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.BillingProfile = &compute.BillingProfile{
+					MaxPrice: pointer.Float64(0.001),
+				}
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with encryption",
+			setup: func(spec *ScaleSetSpec) {
+				spec.OSDisk.ManagedDisk.DiskEncryptionSet = &infrav1.DiskEncryptionSetParameters{
+					ID: "my-diskencryptionset-id",
+				}
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				osdisk := vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk
+				osdisk.ManagedDisk = &compute.VirtualMachineScaleSetManagedDiskParameters{
+					StorageAccountType: "Premium_LRS",
+					DiskEncryptionSet: &compute.DiskEncryptionSetParameters{
+						ID: pointer.String("my-diskencryptionset-id"),
+					},
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with user assigned identity",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.Identity = infrav1.VMIdentityUserAssigned
+				spec.UserAssignedIdentities = []infrav1.UserAssignedIdentity{
+					{
+						ProviderID: "azure:///subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1",
+					},
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+					Type: compute.ResourceIdentityTypeUserAssigned,
+					UserAssignedIdentities: map[string]*compute.VirtualMachineScaleSetIdentityUserAssignedIdentitiesValue{
+						"/subscriptions/123/resourcegroups/456/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id1": {},
+					},
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with encryption at host enabled",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = "VM_SIZE_EAH"
+				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: pointer.Bool(true)}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_EAH")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					EncryptionAtHost: pointer.Bool(true),
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a trusted launch vmss",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = "VM_SIZE_CVM"
+				spec.SecurityProfile = &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_CVM")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesTrustedLaunch,
+					UefiSettings: &compute.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a confidential vmss",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = "VM_SIZE_CVM"
+				spec.SecurityProfile = &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						VTpmEnabled: pointer.Bool(true),
+					},
+				}
+				spec.OSDisk.ManagedDisk.SecurityProfile = &infrav1.VMDiskSecurityProfile{
+					SecurityEncryptionType: infrav1.SecurityEncryptionTypeVMGuestStateOnly,
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_CVM")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.SecurityProfile = &compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesConfidentialVM,
+					UefiSettings: &compute.UefiSettings{
+						VTpmEnabled: pointer.Bool(true),
+					},
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.ManagedDisk.SecurityProfile = &compute.VMDiskSecurityProfile{
+					SecurityEncryptionType: compute.SecurityEncryptionTypesVMGuestStateOnly,
+				}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "fails to create a confidential vmss for unsupported VM type",
+			setup: func(spec *ScaleSetSpec) {
+				spec.SecurityProfile = &infrav1.SecurityProfile{SecurityType: infrav1.SecurityTypesConfidentialVM}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "failed building VMSS from spec: reconcile error that cannot be recovered occurred: confidential VM is not supported for VM type VM_SIZE. Object will not be requeued",
+		},
+		{
+			name: "can create a vmss with ephemeral osdisk",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Size = "VM_SIZE_EPH"
+				spec.OSDisk.DiffDiskSettings = &infrav1.DiffDiskSettings{
+					Option: "Local",
+				}
+				spec.OSDisk.CachingType = "ReadOnly"
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE_EPH")
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.DiffDiskSettings = &compute.DiffDiskSettings{
+					Option: compute.DiffDiskOptionsLocal,
+				}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.StorageProfile.OsDisk.Caching = compute.CachingTypesReadOnly
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "fails to create a vmss with encryption at host enabled for unsupported VM type",
+			setup: func(spec *ScaleSetSpec) {
+				spec.SecurityProfile = &infrav1.SecurityProfile{EncryptionAtHost: pointer.Bool(true)}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "failed building VMSS from spec: reconcile error that cannot be recovered occurred: encryption at host is not supported for VM type VM_SIZE. Object will not be requeued",
+		},
+		{
+			name: "can create a vmss with diagnostics set to User Managed",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.DiagnosticsProfile = &infrav1.Diagnostics{
+					Boot: &infrav1.BootDiagnostics{
+						StorageAccountType: infrav1.UserManagedDiagnosticsStorage,
+						UserManaged: &infrav1.UserManagedBootDiagnostics{
+							StorageAccountURI: "https://fakeurl",
+						},
+					},
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.DiagnosticsProfile = &compute.DiagnosticsProfile{BootDiagnostics: &compute.BootDiagnostics{
+					Enabled:    pointer.Bool(true),
+					StorageURI: pointer.String("https://fakeurl"),
+				}}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a vmss with diagnostics set to Disabled",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.DiagnosticsProfile = &infrav1.Diagnostics{
+					Boot: &infrav1.BootDiagnostics{
+						StorageAccountType: infrav1.DisabledDiagnosticsStorage,
+					},
+				}
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				vmss := newDefaultVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				vmss.VirtualMachineScaleSetProperties.VirtualMachineProfile.DiagnosticsProfile = &compute.DiagnosticsProfile{BootDiagnostics: &compute.BootDiagnostics{
+					Enabled: pointer.Bool(false),
+				}}
+				g.Expect(cmp.Diff(vmss, result)).To(BeEmpty())
+			},
+		},
+		{
+			name: "can create a windows vmss",
+			setup: func(spec *ScaleSetSpec) {
+				spec.OSDisk.OSType = azure.WindowsOS
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineScaleSet{}))
+				vmss := result.(compute.VirtualMachineScaleSet)
+				g.Expect(vmss.VirtualMachineProfile.OsProfile.LinuxConfiguration).To(BeNil())
+				g.Expect(vmss.VirtualMachineProfile.OsProfile.WindowsConfiguration).To(Equal(&compute.WindowsConfiguration{
+					EnableAutomaticUpdates: pointer.Bool(false),
+				}))
+				g.Expect(vmss.VirtualMachineProfile.OsProfile.AdminPassword).NotTo(BeNil())
+			},
+		},
+		{
+			name: "returns nil if an existing windows vmss is up to date",
+			setup: func(spec *ScaleSetSpec) {
+				spec.OSDisk.OSType = azure.WindowsOS
+			},
+			existing: newDefaultWindowsVMSS(),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name:     "returns nil if an existing vmss is up to date",
+			existing: newDefaultExistingVMSS("VM_SIZE"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+		},
+		{
+			name: "patches an existing vmss when its replicas increase",
+			setup: func(spec *ScaleSetSpec) {
+				spec.Capacity = 3
+			},
+			existing: newDefaultExistingVMSS("VM_SIZE"),
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachineScaleSetUpdate{}))
+				patch := result.(compute.VirtualMachineScaleSetUpdate)
+				g.Expect(patch.Sku.Capacity).To(Equal(pointer.Int64(3)))
+				g.Expect(patch.VirtualMachineProfile.NetworkProfile).To(BeNil())
+			},
+		},
+		{
+			name: "surges an existing vmss when its model changes",
+			setup: func(spec *ScaleSetSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.DataDisk{
+					NameSuffix: "my_disk_with_ultra_disks",
+					DiskSizeGB: 128,
+					Lun:        pointer.Int32(3),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "UltraSSD_LRS",
+					},
+				})
+				spec.VMImage.Marketplace.Version = "2.0"
+			},
+			existing: func() compute.VirtualMachineScaleSet {
+				vmss := newDefaultExistingVMSS("VM_SIZE")
+				vmss.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				return vmss
+			}(),
+			expect: func(g *WithT, result interface{}) {
+				clone := newDefaultExistingVMSS("VM_SIZE")
+				clone.Sku.Capacity = pointer.Int64(3)
+				clone.VirtualMachineScaleSetProperties.AdditionalCapabilities = &compute.AdditionalCapabilities{UltraSSDEnabled: pointer.Bool(true)}
+				patchVMSS, err := getVMSSUpdateFromVMSS(clone)
+				g.Expect(err).NotTo(HaveOccurred())
+				patchVMSS.VirtualMachineProfile.StorageProfile.ImageReference.Version = pointer.String("2.0")
+				g.Expect(cmp.Diff(patchVMSS, result)).To(BeEmpty())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			spec := newDefaultScaleSetSpec()
+			if tc.setup != nil {
+				tc.setup(spec)
+			}
+			// The SKU of the spec always matches its VM size.
+			spec.SKU = getFakeSKU(spec.Size)
+
+			result, err := spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
+
+func newDefaultScaleSetSpec() *ScaleSetSpec {
+	return &ScaleSetSpec{
+		ScaleSetSpec:   newDefaultVMSSSpec(),
+		ResourceGroup:  defaultResourceGroup,
+		SubscriptionID: defaultSubscriptionID,
+		Location:       "test-location",
+		ClusterName:    "my-cluster",
+		SKU:            getFakeSKU("VM_SIZE"),
+		VMImage: &infrav1.Image{
+			Marketplace: &infrav1.AzureMarketplaceImage{
+				ImagePlan: infrav1.ImagePlan{
+					Publisher: "fake-publisher",
+					Offer:     "my-offer",
+					SKU:       "sku-id",
+				},
+				Version: "1.0",
+			},
+		},
+		BootstrapData:      "fake-bootstrap-data",
+		MaxSurge:           1,
+		VMSSExtensionSpecs: newDefaultVMSSExtensionSpecs(),
+		VMSSInstances:      newDefaultInstances(),
+	}
+}
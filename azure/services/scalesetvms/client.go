@@ -20,9 +20,11 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
@@ -37,12 +39,15 @@ type client interface {
 	Get(context.Context, string, string, string) (compute.VirtualMachineScaleSetVM, error)
 	GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSetVM, error)
 	DeleteAsync(context.Context, string, string, string) (*infrav1.Future, error)
+	ListNetworkInterfaces(context.Context, string, string, string) ([]network.Interface, error)
+	GetNetworkInterface(context.Context, string, string) (network.Interface, error)
 }
 
 type (
 	// azureClient contains the Azure go-sdk Client.
 	azureClient struct {
 		scalesetvms compute.VirtualMachineScaleSetVMsClient
+		interfaces  network.InterfacesClient
 	}
 
 	genericScaleSetVMFuture interface {
@@ -61,6 +66,7 @@ var _ client = &azureClient{}
 func newClient(auth azure.Authorizer) *azureClient {
 	return &azureClient{
 		scalesetvms: newVirtualMachineScaleSetVMsClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
+		interfaces:  newInterfacesClient(auth.SubscriptionID(), auth.BaseURI(), auth.Authorizer()),
 	}
 }
 
@@ -73,6 +79,13 @@ func newVirtualMachineScaleSetVMsClient(subscriptionID string, baseURI string, a
 	return c
 }
 
+// newInterfacesClient creates a new network interfaces client from subscription ID.
+func newInterfacesClient(subscriptionID string, baseURI string, authorizer autorest.Authorizer) network.InterfacesClient {
+	c := network.NewInterfacesClientWithBaseURI(baseURI, subscriptionID)
+	azure.SetAutoRestClientDefaults(&c.Client, authorizer)
+	return c
+}
+
 // Get retrieves the Virtual Machine Scale Set Virtual Machine.
 func (ac *azureClient) Get(ctx context.Context, resourceGroupName, vmssName, instanceID string) (compute.VirtualMachineScaleSetVM, error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.Get")
@@ -81,6 +94,34 @@ func (ac *azureClient) Get(ctx context.Context, resourceGroupName, vmssName, ins
 	return ac.scalesetvms.Get(ctx, resourceGroupName, vmssName, instanceID, "")
 }
 
+// ListNetworkInterfaces retrieves the network interfaces of a Virtual Machine Scale Set Virtual Machine.
+func (ac *azureClient) ListNetworkInterfaces(ctx context.Context, resourceGroupName, vmssName, instanceID string) ([]network.Interface, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.ListNetworkInterfaces")
+	defer done()
+
+	itr, err := ac.interfaces.ListVirtualMachineScaleSetVMNetworkInterfacesComplete(ctx, resourceGroupName, vmssName, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var nics []network.Interface
+	for ; itr.NotDone(); err = itr.NextWithContext(ctx) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate vm scale set vm network interfaces [%w]", err)
+		}
+		nics = append(nics, itr.Value())
+	}
+	return nics, nil
+}
+
+// GetNetworkInterface retrieves a network interface of a Virtual Machine Scale Set Flex Virtual Machine.
+func (ac *azureClient) GetNetworkInterface(ctx context.Context, resourceGroupName, nicName string) (network.Interface, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.GetNetworkInterface")
+	defer done()
+
+	return ac.interfaces.Get(ctx, resourceGroupName, nicName, "")
+}
+
 // GetResultIfDone fetches the result of a long-running operation future if it is done.
 func (ac *azureClient) GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachineScaleSetVM, error) {
 	ctx, _, spanDone := tele.StartSpanWithLogger(ctx, "scalesetvms.azureClient.GetResultIfDone")
@@ -25,6 +25,7 @@ import (
 	reflect "reflect"
 
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
@@ -83,6 +84,21 @@ func (mr *MockclientMockRecorder) Get(arg0, arg1, arg2, arg3 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockclient)(nil).Get), arg0, arg1, arg2, arg3)
 }
 
+// GetNetworkInterface mocks base method.
+func (m *Mockclient) GetNetworkInterface(arg0 context.Context, arg1, arg2 string) (network.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkInterface", arg0, arg1, arg2)
+	ret0, _ := ret[0].(network.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkInterface indicates an expected call of GetNetworkInterface.
+func (mr *MockclientMockRecorder) GetNetworkInterface(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkInterface", reflect.TypeOf((*Mockclient)(nil).GetNetworkInterface), arg0, arg1, arg2)
+}
+
 // GetResultIfDone mocks base method.
 func (m *Mockclient) GetResultIfDone(ctx context.Context, future *v1beta1.Future) (compute.VirtualMachineScaleSetVM, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +114,21 @@ func (mr *MockclientMockRecorder) GetResultIfDone(ctx, future interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultIfDone", reflect.TypeOf((*Mockclient)(nil).GetResultIfDone), ctx, future)
 }
 
+// ListNetworkInterfaces mocks base method.
+func (m *Mockclient) ListNetworkInterfaces(arg0 context.Context, arg1, arg2, arg3 string) ([]network.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworkInterfaces", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]network.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworkInterfaces indicates an expected call of ListNetworkInterfaces.
+func (mr *MockclientMockRecorder) ListNetworkInterfaces(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworkInterfaces", reflect.TypeOf((*Mockclient)(nil).ListNetworkInterfaces), arg0, arg1, arg2, arg3)
+}
+
 // MockgenericScaleSetVMFuture is a mock of genericScaleSetVMFuture interface.
 type MockgenericScaleSetVMFuture struct {
 	ctrl     *gomock.Controller
@@ -25,6 +25,7 @@ import (
 
 	autorest "github.com/Azure/go-autorest/autorest"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 	v1beta1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	azure "sigs.k8s.io/cluster-api-provider-azure/azure"
 	v1beta10 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -67,6 +68,20 @@ func (mr *MockScaleSetVMScopeMockRecorder) AdditionalTags() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdditionalTags", reflect.TypeOf((*MockScaleSetVMScope)(nil).AdditionalTags))
 }
 
+// Addresses mocks base method.
+func (m *MockScaleSetVMScope) Addresses() []v1.NodeAddress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Addresses")
+	ret0, _ := ret[0].([]v1.NodeAddress)
+	return ret0
+}
+
+// Addresses indicates an expected call of Addresses.
+func (mr *MockScaleSetVMScopeMockRecorder) Addresses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Addresses", reflect.TypeOf((*MockScaleSetVMScope)(nil).Addresses))
+}
+
 // Authorizer mocks base method.
 func (m *MockScaleSetVMScope) Authorizer() autorest.Authorizer {
 	m.ctrl.T.Helper()
@@ -247,6 +262,20 @@ func (mr *MockScaleSetVMScopeMockRecorder) InstanceID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceID", reflect.TypeOf((*MockScaleSetVMScope)(nil).InstanceID))
 }
 
+// IsIPv6Enabled mocks base method.
+func (m *MockScaleSetVMScope) IsIPv6Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsIPv6Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsIPv6Enabled indicates an expected call of IsIPv6Enabled.
+func (mr *MockScaleSetVMScopeMockRecorder) IsIPv6Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsIPv6Enabled", reflect.TypeOf((*MockScaleSetVMScope)(nil).IsIPv6Enabled))
+}
+
 // Location mocks base method.
 func (m *MockScaleSetVMScope) Location() string {
 	m.ctrl.T.Helper()
@@ -19,12 +19,17 @@ package scalesetvms
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
@@ -42,6 +47,8 @@ type (
 		InstanceID() string
 		ProviderID() string
 		ScaleSetName() string
+		IsIPv6Enabled() bool
+		Addresses() []corev1.NodeAddress
 		SetVMSSVM(vmssvm *azure.VMSSVM)
 	}
 
@@ -91,7 +98,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			}
 			return errors.Wrap(err, "failed getting vm")
 		}
-		s.Scope.SetVMSSVM(converters.SDKVMToVMSSVM(vm))
+		vmssVM := converters.SDKVMToVMSSVM(vm)
+		addresses, err := s.instanceAddresses(ctx, vmssVM.Name, func(ctx context.Context) ([]network.Interface, error) {
+			return s.getFlexVMNetworkInterfaces(ctx, vm)
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed getting vm network interfaces")
+		}
+		vmssVM.Addresses = addresses
+		s.Scope.SetVMSSVM(vmssVM)
 		return nil
 	}
 
@@ -105,10 +120,98 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed getting instance")
 	}
 
-	s.Scope.SetVMSSVM(converters.SDKToVMSSVM(instance))
+	vmssVM := converters.SDKToVMSSVM(instance)
+	addresses, err := s.instanceAddresses(ctx, vmssVM.Name, func(ctx context.Context) ([]network.Interface, error) {
+		return s.Client.ListNetworkInterfaces(ctx, resourceGroup, vmssName, instanceID)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed getting instance network interfaces")
+	}
+	vmssVM.Addresses = addresses
+	s.Scope.SetVMSSVM(vmssVM)
 	return nil
 }
 
+// instanceAddresses returns the addresses of a VMSS VM. The private addresses of its network interfaces are only
+// needed for dual-stack clusters, to find the IPv6 address of the node, so the network interfaces are only fetched
+// then, and only until an IPv6 address is recorded. The private addresses of a VMSS VM do not change.
+func (s *Service) instanceAddresses(ctx context.Context, name string, listNICs func(context.Context) ([]network.Interface, error)) ([]corev1.NodeAddress, error) {
+	if !s.Scope.IsIPv6Enabled() {
+		return nodeAddresses(name, nil), nil
+	}
+	if addresses := s.Scope.Addresses(); hasIPv6Address(addresses) {
+		return addresses, nil
+	}
+	nics, err := listNICs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nodeAddresses(name, nics), nil
+}
+
+// hasIPv6Address returns true if one of the addresses is an IPv6 internal IP.
+func hasIPv6Address(addresses []corev1.NodeAddress) bool {
+	for _, address := range addresses {
+		if address.Type != corev1.NodeInternalIP {
+			continue
+		}
+		if ip := net.ParseIP(address.Address); ip != nil && ip.To4() == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getFlexVMNetworkInterfaces fetches the network interfaces of a VMSS Flex VM.
+func (s *Service) getFlexVMNetworkInterfaces(ctx context.Context, vm compute.VirtualMachine) ([]network.Interface, error) {
+	if vm.VirtualMachineProperties == nil || vm.NetworkProfile == nil || vm.NetworkProfile.NetworkInterfaces == nil {
+		return nil, nil
+	}
+	var nics []network.Interface
+	for _, nicRef := range *vm.NetworkProfile.NetworkInterfaces {
+		if nicRef.ID == nil {
+			continue
+		}
+		parsed, err := azureautorest.ParseResourceID(*nicRef.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse network interface id %q", *nicRef.ID)
+		}
+		nic, err := s.Client.GetNetworkInterface(ctx, parsed.ResourceGroup, parsed.ResourceName)
+		if err != nil {
+			return nil, err
+		}
+		nics = append(nics, nic)
+	}
+	return nics, nil
+}
+
+// nodeAddresses returns the internal DNS name of a VMSS VM and the private IPv4 and IPv6 addresses of its network
+// interfaces.
+func nodeAddresses(name string, nics []network.Interface) []corev1.NodeAddress {
+	var addresses []corev1.NodeAddress
+	if name != "" {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeInternalDNS,
+			Address: name,
+		})
+	}
+	for _, nic := range nics {
+		if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil {
+			continue
+		}
+		for _, ipConfig := range *nic.IPConfigurations {
+			if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil || ipConfig.PrivateIPAddress == nil {
+				continue
+			}
+			addresses = append(addresses, corev1.NodeAddress{
+				Type:    corev1.NodeInternalIP,
+				Address: pointer.StringDeref(ipConfig.PrivateIPAddress, ""),
+			})
+		}
+	}
+	return addresses
+}
+
 // Delete deletes a scaleset instance asynchronously returning a future which encapsulates the long-running operation.
 func (s *Service) Delete(ctx context.Context) error {
 	var (
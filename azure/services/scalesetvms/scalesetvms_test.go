@@ -23,10 +23,12 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
@@ -109,9 +111,93 @@ func TestService_Reconcile(t *testing.T) {
 					InstanceID: pointer.String("0"),
 				}
 				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
+				s.IsIPv6Enabled().Return(false)
 				s.SetVMSSVM(converters.SDKToVMSSVM(vm))
 			},
 		},
+		{
+			Name: "should reconcile the dual-stack addresses of the instance",
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("0")
+				s.ProviderID().Return("foo")
+				s.ScaleSetName().Return("scaleset")
+				vm := compute.VirtualMachineScaleSetVM{
+					InstanceID: pointer.String("0"),
+					VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+						OsProfile: &compute.OSProfile{ComputerName: pointer.String("scaleset000000")},
+					},
+				}
+				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
+				s.IsIPv6Enabled().Return(true)
+				s.Addresses().Return([]corev1.NodeAddress{{Type: corev1.NodeInternalDNS, Address: "scaleset000000"}})
+				m.ListNetworkInterfaces(gomock2.AContext(), "rg", "scaleset", "0").Return([]network.Interface{
+					{
+						InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+							IPConfigurations: &[]network.InterfaceIPConfiguration{
+								{
+									InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+										PrivateIPAddress: pointer.String("10.1.0.4"),
+									},
+								},
+								{
+									InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+										PrivateIPAddress: pointer.String("2001:1234:5678:9abd::4"),
+									},
+								},
+							},
+						},
+					},
+				}, nil)
+				instance := converters.SDKToVMSSVM(vm)
+				instance.Addresses = []corev1.NodeAddress{
+					{Type: corev1.NodeInternalDNS, Address: "scaleset000000"},
+					{Type: corev1.NodeInternalIP, Address: "10.1.0.4"},
+					{Type: corev1.NodeInternalIP, Address: "2001:1234:5678:9abd::4"},
+				}
+				s.SetVMSSVM(instance)
+			},
+		},
+		{
+			Name: "if listing the network interfaces fails, then should respond with error",
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("0")
+				s.ProviderID().Return("foo")
+				s.ScaleSetName().Return("scaleset")
+				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(compute.VirtualMachineScaleSetVM{}, nil)
+				s.IsIPv6Enabled().Return(true)
+				s.Addresses().Return(nil)
+				m.ListNetworkInterfaces(gomock2.AContext(), "rg", "scaleset", "0").Return(nil, errors.New("boom"))
+			},
+			Err: errors.Wrap(errors.New("boom"), "failed getting instance network interfaces"),
+		},
+		{
+			Name: "should not list the network interfaces once the dual-stack addresses of the instance are known",
+			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
+				s.ResourceGroup().Return("rg")
+				s.InstanceID().Return("0")
+				s.ProviderID().Return("foo")
+				s.ScaleSetName().Return("scaleset")
+				vm := compute.VirtualMachineScaleSetVM{
+					InstanceID: pointer.String("0"),
+					VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+						OsProfile: &compute.OSProfile{ComputerName: pointer.String("scaleset000000")},
+					},
+				}
+				m.Get(gomock2.AContext(), "rg", "scaleset", "0").Return(vm, nil)
+				addresses := []corev1.NodeAddress{
+					{Type: corev1.NodeInternalDNS, Address: "scaleset000000"},
+					{Type: corev1.NodeInternalIP, Address: "10.1.0.4"},
+					{Type: corev1.NodeInternalIP, Address: "2001:1234:5678:9abd::4"},
+				}
+				s.IsIPv6Enabled().Return(true)
+				s.Addresses().Return(addresses)
+				instance := converters.SDKToVMSSVM(vm)
+				instance.Addresses = addresses
+				s.SetVMSSVM(instance)
+			},
+		},
 		{
 			Name: "if 404, then should respond with transient error",
 			Setup: func(s *mock_scalesetvms.MockScaleSetVMScopeMockRecorder, m *mock_scalesetvms.MockclientMockRecorder) {
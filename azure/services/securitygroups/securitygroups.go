@@ -22,12 +22,15 @@ import (
 	"github.com/pkg/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "securitygroups"
+// ServiceName is the name of this service.
+const ServiceName = "securitygroups"
 
 // NSGScope defines the scope interface for a security groups service.
 type NSGScope interface {
@@ -54,7 +57,15 @@ func New(scope NSGScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+		applicationsecuritygroups.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a set of network security groups.
@@ -78,21 +89,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	var resErr error
-
-	// We go through the list of security groups to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	for _, nsgSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, nsgSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || resErr == nil {
-				resErr = err
-			}
-		}
-	}
-
-	s.Scope.UpdatePutStatus(infrav1.SecurityGroupsReadyCondition, serviceName, resErr)
-	return resErr
+	_, err := s.CreateOrUpdateResources(ctx, specs, ServiceName, infrav1.SecurityGroupsReadyCondition)
+	return err
 }
 
 // Delete deletes network security groups.
@@ -116,21 +114,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	var result error
-
-	// We go through the list of security groups to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	for _, nsgSpec := range specs {
-		if err := s.DeleteResource(ctx, nsgSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdateDeleteStatus(infrav1.SecurityGroupsReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.SecurityGroupsReadyCondition)
 }
 
 // IsManaged returns true if the security groups' lifecycles are managed.
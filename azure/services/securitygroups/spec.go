@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
@@ -34,6 +35,7 @@ type NSGSpec struct {
 	Location       string
 	ClusterName    string
 	ResourceGroup  string
+	SubscriptionID string
 	AdditionalTags infrav1.Tags
 }
 
@@ -69,7 +71,7 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 		update := false
 		securityRules = *existingNSG.SecurityRules
 		for _, rule := range s.SecurityRules {
-			sdkRule := converters.SecurityRuleToSDK(rule)
+			sdkRule := s.securityRuleToSDK(rule)
 			if !ruleExists(securityRules, sdkRule) {
 				update = true
 				securityRules = append(securityRules, sdkRule)
@@ -82,7 +84,7 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 	} else {
 		// new security group
 		for _, rule := range s.SecurityRules {
-			securityRules = append(securityRules, converters.SecurityRuleToSDK(rule))
+			securityRules = append(securityRules, s.securityRuleToSDK(rule))
 		}
 	}
 
@@ -101,6 +103,30 @@ func (s *NSGSpec) Parameters(ctx context.Context, existing interface{}) (interfa
 	}, nil
 }
 
+// securityRuleToSDK converts a security rule to an Azure network security rule, referencing its application security
+// groups in the resource group of the security group.
+func (s *NSGSpec) securityRuleToSDK(rule infrav1.SecurityRule) network.SecurityRule {
+	sdkRule := converters.SecurityRuleToSDK(rule)
+	if len(rule.SourceApplicationSecurityGroups) > 0 {
+		sdkRule.SourceApplicationSecurityGroups = s.applicationSecurityGroups(rule.SourceApplicationSecurityGroups)
+	}
+	if len(rule.DestinationApplicationSecurityGroups) > 0 {
+		sdkRule.DestinationApplicationSecurityGroups = s.applicationSecurityGroups(rule.DestinationApplicationSecurityGroups)
+	}
+	return sdkRule
+}
+
+// applicationSecurityGroups returns references to the application security groups with the given names.
+func (s *NSGSpec) applicationSecurityGroups(names []string) *[]network.ApplicationSecurityGroup {
+	asgs := make([]network.ApplicationSecurityGroup, 0, len(names))
+	for _, name := range names {
+		asgs = append(asgs, network.ApplicationSecurityGroup{
+			ID: pointer.String(azure.ApplicationSecurityGroupID(s.SubscriptionID, s.ResourceGroup, name)),
+		})
+	}
+	return &asgs
+}
+
 // TODO: review this logic and make sure it is what we want. It seems incorrect to skip rules that don't have a certain protocol, etc.
 func ruleExists(rules []network.SecurityRule, rule network.SecurityRule) bool {
 	for _, existingRule := range rules {
@@ -61,6 +61,17 @@ var (
 		Destination:      pointer.String("*"),
 		DestinationPorts: pointer.String("80"),
 	}
+	asgRule = infrav1.SecurityRule{
+		Name:                                 "allow_apiserver",
+		Description:                          "Allow API server from nodes",
+		Priority:                             502,
+		Protocol:                             infrav1.SecurityGroupProtocolTCP,
+		Direction:                            infrav1.SecurityRuleDirectionInbound,
+		SourcePorts:                          pointer.String("*"),
+		DestinationPorts:                     pointer.String("6443"),
+		SourceApplicationSecurityGroups:      []string{"my-cluster-node-asg"},
+		DestinationApplicationSecurityGroups: []string{"my-cluster-controlplane-asg"},
+	}
 )
 
 func TestParameters(t *testing.T) {
@@ -168,6 +179,39 @@ func TestParameters(t *testing.T) {
 				}))
 			},
 		},
+		{
+			name: "NSG does not exist with a rule referencing application security groups",
+			spec: &NSGSpec{
+				Name:     "test-nsg",
+				Location: "test-location",
+				SecurityRules: infrav1.SecurityRules{
+					asgRule,
+				},
+				ResourceGroup:  "test-group",
+				SubscriptionID: "123",
+				ClusterName:    "my-cluster",
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				sdkRule := converters.SecurityRuleToSDK(asgRule)
+				sdkRule.SourceApplicationSecurityGroups = &[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-node-asg")},
+				}
+				sdkRule.DestinationApplicationSecurityGroups = &[]network.ApplicationSecurityGroup{
+					{ID: pointer.String("/subscriptions/123/resourceGroups/test-group/providers/Microsoft.Network/applicationSecurityGroups/my-cluster-controlplane-asg")},
+				}
+				g.Expect(result).To(Equal(network.SecurityGroup{
+					SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+						SecurityRules: &[]network.SecurityRule{sdkRule},
+					},
+					Location: pointer.String("test-location"),
+					Tags: map[string]*string{
+						"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": pointer.String("owned"),
+						"Name": pointer.String("test-nsg"),
+					},
+				}))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
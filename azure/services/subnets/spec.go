@@ -41,6 +41,8 @@ type SubnetSpec struct {
 	Role              infrav1.SubnetRole
 	NatGatewayName    string
 	ServiceEndpoints  infrav1.ServiceEndpoints
+	// DisablePrivateLinkServiceNetworkPolicies is required for subnets hosting a private link service.
+	DisablePrivateLinkServiceNetworkPolicies bool
 }
 
 // ResourceName returns the name of the subnet.
@@ -85,7 +87,9 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 
 		// Right now only serviceEndpoints are allowed to be updated. More to come later
 		diff := cmp.Diff(newServiceEndpoints, existingServiceEndpoints)
-		if diff == "" {
+		privateLinkServicePoliciesUpToDate := !s.DisablePrivateLinkServiceNetworkPolicies ||
+			(existingSubnet.SubnetPropertiesFormat != nil && existingSubnet.PrivateLinkServiceNetworkPolicies == network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled)
+		if diff == "" && privateLinkServicePoliciesUpToDate {
 			// up to date, nothing to do
 			return nil, nil
 		}
@@ -131,6 +135,10 @@ func (s *SubnetSpec) Parameters(ctx context.Context, existing interface{}) (para
 	}
 	subnetProperties.ServiceEndpoints = &serviceEndpoints
 
+	if s.DisablePrivateLinkServiceNetworkPolicies {
+		subnetProperties.PrivateLinkServiceNetworkPolicies = network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled
+	}
+
 	return network.Subnet{
 		SubnetPropertiesFormat: &subnetProperties,
 	}, nil
@@ -168,6 +168,37 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "disable private link service network policies on an existing subnet",
+			spec: &SubnetSpec{
+				Name:                                     "my-subnet-1",
+				ResourceGroup:                            "my-rg",
+				SubscriptionID:                           "123",
+				CIDRs:                                    []string{"10.0.0.0/16"},
+				IsVNetManaged:                            true,
+				VNetName:                                 "my-vnet",
+				VNetResourceGroup:                        "my-rg",
+				Role:                                     infrav1.SubnetControlPlane,
+				DisablePrivateLinkServiceNetworkPolicies: true,
+			},
+			existing: network.Subnet{
+				Name: pointer.String("my-subnet-1"),
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:                     pointer.String("10.0.0.0/16"),
+					PrivateLinkServiceNetworkPolicies: network.VirtualNetworkPrivateLinkServiceNetworkPoliciesEnabled,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.Subnet{
+					SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+						AddressPrefix:                     pointer.String("10.0.0.0/16"),
+						ServiceEndpoints:                  &[]network.ServiceEndpointPropertiesFormat{},
+						PrivateLinkServiceNetworkPolicies: network.VirtualNetworkPrivateLinkServiceNetworkPoliciesDisabled,
+					},
+				}))
+			},
+			expectedError: "",
+		},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -26,11 +26,17 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/securitygroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-const serviceName = "subnets"
+// ServiceName is the name of this service.
+const ServiceName = "subnets"
 
 // SubnetScope defines the scope interface for a subnet service.
 type SubnetScope interface {
@@ -59,7 +65,17 @@ func New(scope SubnetScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		virtualnetworks.ServiceName,
+		securitygroups.ServiceName,
+		routetables.ServiceName,
+		natgateways.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a subnet.
@@ -75,28 +91,23 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of SubnetSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var resultErr error
-	for _, subnetSpec := range specs {
-		result, err := s.CreateOrUpdateResource(ctx, subnetSpec, serviceName)
-		if err != nil {
-			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
-				resultErr = err
-			}
-		} else {
-			subnet, ok := result.(network.Subnet)
-			if !ok {
-				return errors.Errorf("%T is not a network.Subnet", result)
-			}
-			s.Scope.UpdateSubnetID(subnetSpec.ResourceName(), pointer.StringDeref(subnet.ID, ""))
-			s.Scope.UpdateSubnetCIDRs(subnetSpec.ResourceName(), converters.GetSubnetAddresses(subnet))
-		}
+	// The SubnetsReadyCondition is only updated when the vnet is managed.
+	var condition clusterv1.ConditionType
+	if s.Scope.IsVnetManaged() {
+		condition = infrav1.SubnetsReadyCondition
 	}
 
-	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, resultErr)
+	results, resultErr := s.CreateOrUpdateResources(ctx, specs, ServiceName, condition)
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		subnet, ok := result.(network.Subnet)
+		if !ok {
+			return errors.Errorf("%T is not a network.Subnet", result)
+		}
+		s.Scope.UpdateSubnetID(specs[i].ResourceName(), pointer.StringDeref(subnet.ID, ""))
+		s.Scope.UpdateSubnetCIDRs(specs[i].ResourceName(), converters.GetSubnetAddresses(subnet))
 	}
 
 	return resultErr
@@ -122,20 +133,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of SubnetSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, subnetSpec := range specs {
-		if err := s.DeleteResource(ctx, subnetSpec, serviceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
-		}
-	}
-
-	s.Scope.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, result)
-	return result
+	return s.DeleteResources(ctx, specs, ServiceName, infrav1.SubnetsReadyCondition)
 }
 
 // IsManaged returns true if the route tables' lifecycles are managed.
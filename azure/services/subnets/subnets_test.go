@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets/mock_subnets"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -210,12 +211,11 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{fakeSubnet1}, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, pointer.StringDeref(fakeSubnet1.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{pointer.StringDeref(fakeSubnet1.AddressPrefix, "")})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
@@ -224,16 +224,14 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(fakeSubnet1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{fakeSubnet1, fakeSubnet2}, nil)
 				s.UpdateSubnetID(fakeSubnetSpec1.Name, pointer.StringDeref(fakeSubnet1.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec1.Name, []string{pointer.StringDeref(fakeSubnet1.AddressPrefix, "")})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, pointer.StringDeref(fakeSubnet2.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{pointer.StringDeref(fakeSubnet2.AddressPrefix, "")})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
@@ -242,7 +240,7 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpecNotManaged})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpecNotManaged, serviceName).Return(fakeSubnetNotManaged, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpecNotManaged}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{fakeSubnetNotManaged}, nil)
 				s.UpdateSubnetID(fakeSubnetSpecNotManaged.Name, pointer.StringDeref(fakeSubnetNotManaged.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpecNotManaged.Name, []string{pointer.StringDeref(fakeSubnetNotManaged.AddressPrefix, "")})
 
@@ -255,12 +253,11 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeIpv6SubnetSpec}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{fakeIpv6Subnet}, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, pointer.StringDeref(fakeIpv6Subnet.ID, ""))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, azure.StringSlice(fakeIpv6Subnet.AddressPrefixes))
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
@@ -269,16 +266,14 @@ func TestReconcileSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeIpv6SubnetSpec, &fakeIpv6SubnetSpecCP})
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpec, serviceName).Return(fakeIpv6Subnet, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeIpv6SubnetSpec, &fakeIpv6SubnetSpecCP}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{fakeIpv6Subnet, fakeIpv6SubnetCP}, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpec.Name, pointer.StringDeref(fakeIpv6Subnet.ID, ""))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpec.Name, azure.StringSlice(fakeIpv6Subnet.AddressPrefixes))
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeIpv6SubnetSpecCP, serviceName).Return(fakeIpv6SubnetCP, nil)
 				s.UpdateSubnetID(fakeIpv6SubnetSpecCP.Name, pointer.StringDeref(fakeIpv6SubnetCP.ID, ""))
 				s.UpdateSubnetCIDRs(fakeIpv6SubnetSpecCP.Name, azure.StringSlice(fakeIpv6SubnetCP.AddressPrefixes))
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
 			},
 		},
 		{
@@ -286,10 +281,9 @@ func TestReconcileSubnets(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{nil}, internalError)
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
 			},
 		},
 		{
@@ -297,7 +291,9 @@ func TestReconcileSubnets(t *testing.T) {
 			expectedError: notASubnetErr.Error(),
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(notASubnet, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{notASubnet}, nil)
+
+				s.IsVnetManaged().AnyTimes().Return(true)
 			},
 		},
 		{
@@ -305,14 +301,12 @@ func TestReconcileSubnets(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2}, ServiceName, infrav1.SubnetsReadyCondition).Return([]interface{}{nil, fakeSubnet2}, internalError)
 
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(fakeSubnet2, nil)
 				s.UpdateSubnetID(fakeSubnetSpec2.Name, pointer.StringDeref(fakeSubnet2.ID, ""))
 				s.UpdateSubnetCIDRs(fakeSubnetSpec2.Name, []string{pointer.StringDeref(fakeSubnet2.AddressPrefix, "")})
 
 				s.IsVnetManaged().AnyTimes().Return(true)
-				s.UpdatePutStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
 			},
 		},
 	}
@@ -366,9 +360,7 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec2, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeSubnetSpec2}, ServiceName, infrav1.SubnetsReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -377,9 +369,7 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeCtrlPlaneSubnetSpec})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakeCtrlPlaneSubnetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1, &fakeCtrlPlaneSubnetSpec}, ServiceName, infrav1.SubnetsReadyCondition).Return(nil)
 			},
 		},
 		{
@@ -395,8 +385,7 @@ func TestDeleteSubnets(t *testing.T) {
 			expect: func(s *mock_subnets.MockSubnetScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.IsVnetManaged().AnyTimes().Return(true)
 				s.SubnetSpecs().Return([]azure.ResourceSpecGetter{&fakeSubnetSpec1})
-				r.DeleteResource(gomockinternal.AContext(), &fakeSubnetSpec1, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.SubnetsReadyCondition, serviceName, internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeSubnetSpec1}, ServiceName, infrav1.SubnetsReadyCondition).Return(internalError)
 			},
 		},
 	}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
@@ -57,6 +58,13 @@ func (s *Service) Name() string {
 	return serviceName
 }
 
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+	}
+}
+
 // Reconcile ensures tags are correct.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "tags.Service.Reconcile")
@@ -90,8 +98,8 @@ func (s *Service) Reconcile(ctx context.Context) error {
 					createdOrUpdatedTags[k] = pointer.String(v)
 				}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Merge", Properties: &resources.Tags{Tags: createdOrUpdatedTags}}); err != nil {
-					return errors.Wrap(err, "cannot update tags")
+				if err := s.updateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Merge", Properties: &resources.Tags{Tags: createdOrUpdatedTags}}); err != nil {
+					return err
 				}
 			}
 
@@ -101,11 +109,16 @@ func (s *Service) Reconcile(ctx context.Context) error {
 					deletedTags[k] = pointer.String(v)
 				}
 
-				if _, err := s.client.UpdateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Delete", Properties: &resources.Tags{Tags: deletedTags}}); err != nil {
-					return errors.Wrap(err, "cannot update tags")
+				if err := s.updateAtScope(ctx, tagsSpec.Scope, resources.TagsPatchResource{Operation: "Delete", Properties: &resources.Tags{Tags: deletedTags}}); err != nil {
+					return err
 				}
 			}
 
+			if s.dryRun() {
+				// The tags were not updated, so the last applied tags must not be either.
+				continue
+			}
+
 			// We also need to update the annotation if anything changed.
 			if err := s.Scope.UpdateAnnotationJSON(tagsSpec.Annotation, newAnnotation); err != nil {
 				return err
@@ -116,6 +129,29 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// updateAtScope patches the tags at the given scope, or records the PATCH in the plan in dry-run mode.
+func (s *Service) updateAtScope(ctx context.Context, scope string, parameters resources.TagsPatchResource) error {
+	if planner, ok := s.Scope.(azure.OperationPlanner); ok && planner.DryRun() {
+		planner.PlanOperation(azure.PlannedOperation{
+			ServiceName:  serviceName,
+			Method:       azure.PatchOperation,
+			ResourceName: scope,
+			Parameters:   parameters,
+		})
+		return nil
+	}
+	if _, err := s.client.UpdateAtScope(ctx, scope, parameters); err != nil {
+		return errors.Wrap(err, "cannot update tags")
+	}
+	return nil
+}
+
+// dryRun returns whether the scope is reconciled in dry-run mode.
+func (s *Service) dryRun() bool {
+	planner, ok := s.Scope.(azure.OperationPlanner)
+	return ok && planner.DryRun()
+}
+
 func (s *Service) isResourceManaged(tags map[string]*string) bool {
 	return converters.MapToTags(tags).HasOwned(s.Scope.ClusterName())
 }
@@ -44,8 +44,12 @@ type (
 	Client interface {
 		Get(context.Context, azure.ResourceSpecGetter) (interface{}, error)
 		GetByID(context.Context, string) (compute.VirtualMachine, error)
+		InstanceView(context.Context, azure.ResourceSpecGetter) (compute.VirtualMachineInstanceView, error)
 		CreateOrUpdateAsync(ctx context.Context, spec azure.ResourceSpecGetter, parameters interface{}) (result interface{}, future azureautorest.FutureAPI, err error)
 		DeleteAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
+		DeallocateAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
+		ResizeAsync(ctx context.Context, spec azure.ResourceSpecGetter, size string) (future azureautorest.FutureAPI, err error)
+		StartAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error)
 		IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error)
 		Result(ctx context.Context, future azureautorest.FutureAPI, futureType string) (result interface{}, err error)
 		GetResultIfDone(ctx context.Context, future *infrav1.Future) (compute.VirtualMachine, error)
@@ -99,6 +103,14 @@ func (ac *AzureClient) GetByID(ctx context.Context, resourceID string) (compute.
 	return ac.virtualmachines.Get(ctx, parsed.ResourceGroup, parsed.ResourceName, "")
 }
 
+// InstanceView retrieves the run-time state of a virtual machine.
+func (ac *AzureClient) InstanceView(ctx context.Context, spec azure.ResourceSpecGetter) (compute.VirtualMachineInstanceView, error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.InstanceView")
+	defer done()
+
+	return ac.virtualmachines.InstanceView(ctx, spec.ResourceGroupName(), spec.ResourceName())
+}
+
 // CreateOrUpdateAsync creates or updates a virtual machine asynchronously.
 // It sends a PUT request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
 // progress of the operation.
@@ -157,6 +169,91 @@ func (ac *AzureClient) DeleteAsync(ctx context.Context, spec azure.ResourceSpecG
 	return nil, err
 }
 
+// DeallocateAsync deallocates a virtual machine asynchronously. DeallocateAsync sends a POST
+// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *AzureClient) DeallocateAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Deallocate")
+	defer done()
+
+	deallocateFuture, err := ac.virtualmachines.Deallocate(ctx, spec.ResourceGroupName(), spec.ResourceName(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = deallocateFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return &deallocateFuture, err
+	}
+	_, err = deallocateFuture.Result(ac.virtualmachines)
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
+// ResizeAsync changes the size of a virtual machine asynchronously. ResizeAsync sends a PATCH
+// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *AzureClient) ResizeAsync(ctx context.Context, spec azure.ResourceSpecGetter, size string) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Resize")
+	defer done()
+
+	vm := compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			HardwareProfile: &compute.HardwareProfile{
+				VMSize: compute.VirtualMachineSizeTypes(size),
+			},
+		},
+	}
+	updateFuture, err := ac.virtualmachines.Update(ctx, spec.ResourceGroupName(), spec.ResourceName(), vm)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = updateFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return &updateFuture, err
+	}
+	_, err = updateFuture.Result(ac.virtualmachines)
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
+// StartAsync starts a virtual machine asynchronously. StartAsync sends a POST
+// request to Azure and if accepted without error, the func will return a Future which can be used to track the ongoing
+// progress of the operation.
+func (ac *AzureClient) StartAsync(ctx context.Context, spec azure.ResourceSpecGetter) (future azureautorest.FutureAPI, err error) {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.Start")
+	defer done()
+
+	startFuture, err := ac.virtualmachines.Start(ctx, spec.ResourceGroupName(), spec.ResourceName())
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureCallTimeout)
+	defer cancel()
+
+	err = startFuture.WaitForCompletionRef(ctx, ac.virtualmachines.Client)
+	if err != nil {
+		// if an error occurs, return the future.
+		// this means the long-running operation didn't finish in the specified timeout.
+		return &startFuture, err
+	}
+	_, err = startFuture.Result(ac.virtualmachines)
+	// if the operation completed, return a nil future.
+	return nil, err
+}
+
 // IsDone returns true if the long-running operation has completed.
 func (ac *AzureClient) IsDone(ctx context.Context, future azureautorest.FutureAPI) (isDone bool, err error) {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.AzureClient.IsDone")
@@ -207,6 +304,10 @@ func (ac *AzureClient) Result(ctx context.Context, future azureautorest.FutureAP
 		// Delete does not return a result VM.
 		return nil, nil
 
+	case infrav1.DeallocateFuture, infrav1.StartFuture:
+		// Deallocate and Start do not return a result VM.
+		return nil, nil
+
 	default:
 		return nil, errors.Errorf("unknown future type %q", futureType)
 	}
@@ -71,6 +71,21 @@ func (mr *MockClientMockRecorder) CreateOrUpdateAsync(ctx, spec, parameters inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateAsync", reflect.TypeOf((*MockClient)(nil).CreateOrUpdateAsync), ctx, spec, parameters)
 }
 
+// DeallocateAsync mocks base method.
+func (m *MockClient) DeallocateAsync(ctx context.Context, spec azure0.ResourceSpecGetter) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeallocateAsync", ctx, spec)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeallocateAsync indicates an expected call of DeallocateAsync.
+func (mr *MockClientMockRecorder) DeallocateAsync(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeallocateAsync", reflect.TypeOf((*MockClient)(nil).DeallocateAsync), ctx, spec)
+}
+
 // DeleteAsync mocks base method.
 func (m *MockClient) DeleteAsync(ctx context.Context, spec azure0.ResourceSpecGetter) (azure.FutureAPI, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +146,21 @@ func (mr *MockClientMockRecorder) GetResultIfDone(ctx, future interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResultIfDone", reflect.TypeOf((*MockClient)(nil).GetResultIfDone), ctx, future)
 }
 
+// InstanceView mocks base method.
+func (m *MockClient) InstanceView(arg0 context.Context, arg1 azure0.ResourceSpecGetter) (compute.VirtualMachineInstanceView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstanceView", arg0, arg1)
+	ret0, _ := ret[0].(compute.VirtualMachineInstanceView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstanceView indicates an expected call of InstanceView.
+func (mr *MockClientMockRecorder) InstanceView(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstanceView", reflect.TypeOf((*MockClient)(nil).InstanceView), arg0, arg1)
+}
+
 // IsDone mocks base method.
 func (m *MockClient) IsDone(ctx context.Context, future azure.FutureAPI) (bool, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +176,21 @@ func (mr *MockClientMockRecorder) IsDone(ctx, future interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDone", reflect.TypeOf((*MockClient)(nil).IsDone), ctx, future)
 }
 
+// ResizeAsync mocks base method.
+func (m *MockClient) ResizeAsync(ctx context.Context, spec azure0.ResourceSpecGetter, size string) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeAsync", ctx, spec, size)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResizeAsync indicates an expected call of ResizeAsync.
+func (mr *MockClientMockRecorder) ResizeAsync(ctx, spec, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeAsync", reflect.TypeOf((*MockClient)(nil).ResizeAsync), ctx, spec, size)
+}
+
 // Result mocks base method.
 func (m *MockClient) Result(ctx context.Context, future azure.FutureAPI, futureType string) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +206,21 @@ func (mr *MockClientMockRecorder) Result(ctx, future, futureType interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Result", reflect.TypeOf((*MockClient)(nil).Result), ctx, future, futureType)
 }
 
+// StartAsync mocks base method.
+func (m *MockClient) StartAsync(ctx context.Context, spec azure0.ResourceSpecGetter) (azure.FutureAPI, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAsync", ctx, spec)
+	ret0, _ := ret[0].(azure.FutureAPI)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAsync indicates an expected call of StartAsync.
+func (mr *MockClientMockRecorder) StartAsync(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAsync", reflect.TypeOf((*MockClient)(nil).StartAsync), ctx, spec)
+}
+
 // MockgenericVMFuture is a mock of genericVMFuture interface.
 type MockgenericVMFuture struct {
 	ctrl     *gomock.Controller
@@ -82,6 +82,20 @@ func (mr *MockVMScopeMockRecorder) BaseURI() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseURI", reflect.TypeOf((*MockVMScope)(nil).BaseURI))
 }
 
+// CapacityReservationID mocks base method.
+func (m *MockVMScope) CapacityReservationID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CapacityReservationID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CapacityReservationID indicates an expected call of CapacityReservationID.
+func (mr *MockVMScopeMockRecorder) CapacityReservationID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CapacityReservationID", reflect.TypeOf((*MockVMScope)(nil).CapacityReservationID))
+}
+
 // ClientID mocks base method.
 func (m *MockVMScope) ClientID() string {
 	m.ctrl.T.Helper()
@@ -188,6 +202,18 @@ func (mr *MockVMScopeMockRecorder) SetAnnotation(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAnnotation", reflect.TypeOf((*MockVMScope)(nil).SetAnnotation), arg0, arg1)
 }
 
+// SetCapacityReservationID mocks base method.
+func (m *MockVMScope) SetCapacityReservationID(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCapacityReservationID", arg0)
+}
+
+// SetCapacityReservationID indicates an expected call of SetCapacityReservationID.
+func (mr *MockVMScopeMockRecorder) SetCapacityReservationID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCapacityReservationID", reflect.TypeOf((*MockVMScope)(nil).SetCapacityReservationID), arg0)
+}
+
 // SetConditionFalse mocks base method.
 func (m *MockVMScope) SetConditionFalse(arg0 v1beta10.ConditionType, arg1 string, arg2 v1beta10.ConditionSeverity, arg3 string) {
 	m.ctrl.T.Helper()
@@ -236,6 +262,20 @@ func (mr *MockVMScopeMockRecorder) SetVMState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVMState", reflect.TypeOf((*MockVMScope)(nil).SetVMState), arg0)
 }
 
+// SpotVMStopped mocks base method.
+func (m *MockVMScope) SpotVMStopped() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpotVMStopped")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SpotVMStopped indicates an expected call of SpotVMStopped.
+func (mr *MockVMScopeMockRecorder) SpotVMStopped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpotVMStopped", reflect.TypeOf((*MockVMScope)(nil).SpotVMStopped))
+}
+
 // SubscriptionID mocks base method.
 func (m *MockVMScope) SubscriptionID() string {
 	m.ctrl.T.Helper()
@@ -300,6 +340,20 @@ func (mr *MockVMScopeMockRecorder) UpdatePutStatus(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePutStatus", reflect.TypeOf((*MockVMScope)(nil).UpdatePutStatus), arg0, arg1, arg2)
 }
 
+// VMResizeInProgress mocks base method.
+func (m *MockVMScope) VMResizeInProgress() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VMResizeInProgress")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VMResizeInProgress indicates an expected call of VMResizeInProgress.
+func (mr *MockVMScopeMockRecorder) VMResizeInProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VMResizeInProgress", reflect.TypeOf((*MockVMScope)(nil).VMResizeInProgress))
+}
+
 // VMSpec mocks base method.
 func (m *MockVMScope) VMSpec() azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
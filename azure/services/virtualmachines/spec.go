@@ -33,31 +33,39 @@ import (
 
 // VMSpec defines the specification for a Virtual Machine.
 type VMSpec struct {
-	Name                   string
-	ResourceGroup          string
-	Location               string
-	ClusterName            string
-	Role                   string
-	NICIDs                 []string
-	SSHKeyData             string
-	Size                   string
-	AvailabilitySetID      string
-	Zone                   string
-	Identity               infrav1.VMIdentity
-	OSDisk                 infrav1.OSDisk
-	DataDisks              []infrav1.DataDisk
-	UserAssignedIdentities []infrav1.UserAssignedIdentity
-	SpotVMOptions          *infrav1.SpotVMOptions
-	SecurityProfile        *infrav1.SecurityProfile
-	AdditionalTags         infrav1.Tags
-	AdditionalCapabilities *infrav1.AdditionalCapabilities
-	DiagnosticsProfile     *infrav1.Diagnostics
-	SKU                    resourceskus.SKU
-	Image                  *infrav1.Image
-	BootstrapData          string
-	ProviderID             string
+	Name                       string
+	ResourceGroup              string
+	Location                   string
+	ClusterName                string
+	Role                       string
+	NICIDs                     []string
+	SSHKeyData                 string
+	Size                       string
+	AvailabilitySetID          string
+	Zone                       string
+	HostGroupID                string
+	HostID                     string
+	HostGroupZone              string
+	ProximityPlacementGroupID  string
+	CapacityReservationGroupID string
+	Identity                   infrav1.VMIdentity
+	OSDisk                     infrav1.OSDisk
+	DataDisks                  []infrav1.DataDisk
+	UserAssignedIdentities     []infrav1.UserAssignedIdentity
+	SpotVMOptions              *infrav1.SpotVMOptions
+	SecurityProfile            *infrav1.SecurityProfile
+	AdditionalTags             infrav1.Tags
+	AdditionalCapabilities     *infrav1.AdditionalCapabilities
+	DiagnosticsProfile         *infrav1.Diagnostics
+	SKU                        resourceskus.SKU
+	Image                      *infrav1.Image
+	BootstrapData              string
+	ProviderID                 string
+	UpdateStrategy             infrav1.AzureMachineUpdateStrategy
 }
 
+var _ azure.ResourceSpecGetterWithDrift = (*VMSpec)(nil)
+
 // ResourceName returns the name of the virtual machine.
 func (s *VMSpec) ResourceName() string {
 	return s.Name
@@ -88,6 +96,16 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 		return nil, azure.VMDeletedError{ProviderID: s.ProviderID}
 	}
 
+	return s.DesiredParameters(ctx)
+}
+
+// DesiredParameters returns the parameters of the VM as it would be created, to detect the drift of the existing VM.
+func (s *VMSpec) DesiredParameters(ctx context.Context) (interface{}, error) {
+	// A VM must be in the availability zone of the dedicated host group it is placed in.
+	if s.HostGroupZone != "" && s.Zone != "" && s.Zone != s.HostGroupZone {
+		return nil, azure.WithTerminalError(errors.Errorf("failure domain %s does not match the availability zone %s of the dedicated host group", s.Zone, s.HostGroupZone))
+	}
+
 	storageProfile, err := s.generateStorageProfile()
 	if err != nil {
 		return nil, err
@@ -124,8 +142,12 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 			Additional:  s.AdditionalTags,
 		})),
 		VirtualMachineProperties: &compute.VirtualMachineProperties{
-			AdditionalCapabilities: s.generateAdditionalCapabilities(),
-			AvailabilitySet:        s.getAvailabilitySet(),
+			AdditionalCapabilities:  s.generateAdditionalCapabilities(),
+			AvailabilitySet:         s.getAvailabilitySet(),
+			HostGroup:               s.getHostGroup(),
+			Host:                    s.getHost(),
+			ProximityPlacementGroup: s.getProximityPlacementGroup(),
+			CapacityReservation:     s.getCapacityReservation(),
 			HardwareProfile: &compute.HardwareProfile{
 				VMSize: compute.VirtualMachineSizeTypes(s.Size),
 			},
@@ -145,6 +167,14 @@ func (s *VMSpec) Parameters(ctx context.Context, existing interface{}) (params i
 	}, nil
 }
 
+// DriftIgnoredPaths returns the properties of the VM that are not returned by Azure.
+func (s *VMSpec) DriftIgnoredPaths() []string {
+	return []string{
+		"properties.osProfile.customData",
+		"properties.osProfile.adminPassword",
+	}
+}
+
 // generateStorageProfile generates a pointer to a compute.StorageProfile which can utilized for VM creation.
 func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 	storageProfile := &compute.StorageProfile{
@@ -194,31 +224,16 @@ func (s *VMSpec) generateStorageProfile() (*compute.StorageProfile, error) {
 		if s.OSDisk.ManagedDisk.DiskEncryptionSet != nil {
 			storageProfile.OsDisk.ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(s.OSDisk.ManagedDisk.DiskEncryptionSet.ID)}
 		}
+		storageProfile.OsDisk.ManagedDisk.SecurityProfile = converters.GetDiskSecurityProfile(s.OSDisk.ManagedDisk.SecurityProfile)
 	}
 
 	dataDisks := make([]compute.DataDisk, len(s.DataDisks))
 	for i, disk := range s.DataDisks {
-		dataDisks[i] = compute.DataDisk{
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
-			DiskSizeGB:   pointer.Int32(disk.DiskSizeGB),
-			Lun:          disk.Lun,
-			Name:         pointer.String(azure.GenerateDataDiskName(s.Name, disk.NameSuffix)),
-			Caching:      compute.CachingTypes(disk.CachingType),
-		}
+		dataDisks[i] = converters.DataDiskToSDK(s.Name, disk)
 
-		if disk.ManagedDisk != nil {
-			dataDisks[i].ManagedDisk = &compute.ManagedDiskParameters{
-				StorageAccountType: compute.StorageAccountTypes(disk.ManagedDisk.StorageAccountType),
-			}
-
-			if disk.ManagedDisk.DiskEncryptionSet != nil {
-				dataDisks[i].ManagedDisk.DiskEncryptionSet = &compute.DiskEncryptionSetParameters{ID: pointer.String(disk.ManagedDisk.DiskEncryptionSet.ID)}
-			}
-
-			// check the support for ultra disks based on location and vm size
-			if disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.Zone) {
-				return nil, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
-			}
+		// check the support for ultra disks based on location and vm size
+		if disk.ManagedDisk != nil && disk.ManagedDisk.StorageAccountType == string(compute.StorageAccountTypesUltraSSDLRS) && !s.SKU.HasLocationCapability(resourceskus.UltraSSDAvailable, s.Location, s.zone()) {
+			return nil, azure.WithTerminalError(fmt.Errorf("vm size %s does not support ultra disks in location %s. select a different vm size or disable ultra disks", s.Size, s.Location))
 		}
 	}
 	storageProfile.DataDisks = &dataDisks
@@ -280,12 +295,25 @@ func (s *VMSpec) generateSecurityProfile() (*compute.SecurityProfile, error) {
 		return nil, nil
 	}
 
-	if !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
+	if s.SecurityProfile.EncryptionAtHost != nil && *s.SecurityProfile.EncryptionAtHost && !s.SKU.HasCapability(resourceskus.EncryptionAtHost) {
 		return nil, azure.WithTerminalError(errors.Errorf("encryption at host is not supported for VM type %s", s.Size))
 	}
 
+	switch s.SecurityProfile.SecurityType {
+	case infrav1.SecurityTypesTrustedLaunch:
+		if s.SKU.HasCapability(resourceskus.TrustedLaunchDisabled) {
+			return nil, azure.WithTerminalError(errors.Errorf("trusted launch is not supported for VM type %s", s.Size))
+		}
+	case infrav1.SecurityTypesConfidentialVM:
+		if _, ok := s.SKU.GetCapability(resourceskus.ConfidentialComputingType); !ok {
+			return nil, azure.WithTerminalError(errors.Errorf("confidential VM is not supported for VM type %s", s.Size))
+		}
+	}
+
 	return &compute.SecurityProfile{
 		EncryptionAtHost: s.SecurityProfile.EncryptionAtHost,
+		SecurityType:     compute.SecurityTypes(s.SecurityProfile.SecurityType),
+		UefiSettings:     converters.GetUefiSettings(s.SecurityProfile.UefiSettings),
 	}, nil
 }
 
@@ -339,10 +367,52 @@ func (s *VMSpec) getAvailabilitySet() *compute.SubResource {
 	return as
 }
 
+func (s *VMSpec) getHostGroup() *compute.SubResource {
+	var hostGroup *compute.SubResource
+	if s.HostGroupID != "" {
+		hostGroup = &compute.SubResource{ID: &s.HostGroupID}
+	}
+	return hostGroup
+}
+
+func (s *VMSpec) getHost() *compute.SubResource {
+	var host *compute.SubResource
+	if s.HostID != "" {
+		host = &compute.SubResource{ID: &s.HostID}
+	}
+	return host
+}
+
+func (s *VMSpec) getProximityPlacementGroup() *compute.SubResource {
+	var ppg *compute.SubResource
+	if s.ProximityPlacementGroupID != "" {
+		ppg = &compute.SubResource{ID: &s.ProximityPlacementGroupID}
+	}
+	return ppg
+}
+
+func (s *VMSpec) getCapacityReservation() *compute.CapacityReservationProfile {
+	var capacityReservation *compute.CapacityReservationProfile
+	if s.CapacityReservationGroupID != "" {
+		capacityReservation = &compute.CapacityReservationProfile{
+			CapacityReservationGroup: &compute.SubResource{ID: &s.CapacityReservationGroupID},
+		}
+	}
+	return capacityReservation
+}
+
+// zone returns the availability zone of the VM, which defaults to the zone of its dedicated host group.
+func (s *VMSpec) zone() string {
+	if s.Zone != "" {
+		return s.Zone
+	}
+	return s.HostGroupZone
+}
+
 func (s *VMSpec) getZones() *[]string {
 	var zones *[]string
-	if s.Zone != "" {
-		zones = &[]string{s.Zone}
+	if zone := s.zone(); zone != "" {
+		zones = &[]string{zone}
 	}
 	return zones
 }
@@ -18,6 +18,7 @@ package virtualmachines
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
@@ -72,6 +73,50 @@ var (
 		},
 	}
 
+	validSKUWithTrustedLaunchDisabled = resourceskus.SKU{
+		Name: pointer.String("Standard_D2v3"),
+		Kind: pointer.String(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  pointer.String(resourceskus.VCPUs),
+				Value: pointer.String("2"),
+			},
+			{
+				Name:  pointer.String(resourceskus.MemoryGB),
+				Value: pointer.String("4"),
+			},
+			{
+				Name:  pointer.String(resourceskus.TrustedLaunchDisabled),
+				Value: pointer.String(string(resourceskus.CapabilitySupported)),
+			},
+		},
+	}
+
+	validSKUWithConfidentialComputingType = resourceskus.SKU{
+		Name: pointer.String("Standard_DC2as_v5"),
+		Kind: pointer.String(string(resourceskus.VirtualMachines)),
+		Locations: &[]string{
+			"test-location",
+		},
+		Capabilities: &[]compute.ResourceSkuCapabilities{
+			{
+				Name:  pointer.String(resourceskus.VCPUs),
+				Value: pointer.String("2"),
+			},
+			{
+				Name:  pointer.String(resourceskus.MemoryGB),
+				Value: pointer.String("8"),
+			},
+			{
+				Name:  pointer.String(resourceskus.ConfidentialComputingType),
+				Value: pointer.String("SNP"),
+			},
+		},
+	}
+
 	validSKUWithEphemeralOS = resourceskus.SKU{
 		Name: pointer.String("Standard_D2v3"),
 		Kind: pointer.String(string(resourceskus.VirtualMachines)),
@@ -368,6 +413,233 @@ func TestParameters(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name: "can create a trusted launch vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				g.Expect(result.(compute.VirtualMachine).VirtualMachineProperties.SecurityProfile).To(Equal(&compute.SecurityProfile{
+					SecurityType: compute.SecurityTypesTrustedLaunch,
+					UefiSettings: &compute.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a trusted launch vm for unsupported VM type fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesTrustedLaunch,
+				},
+				SKU: validSKUWithTrustedLaunchDisabled,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: trusted launch is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
+		{
+			name: "can create a vm on a dedicated host",
+			spec: &VMSpec{
+				Name:          "my-vm",
+				Role:          infrav1.Node,
+				NICIDs:        []string{"my-nic"},
+				SSHKeyData:    "fakesshpublickey",
+				Size:          "Standard_D2v3",
+				Image:         &infrav1.Image{ID: pointer.String("fake-image-id")},
+				HostID:        "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg/hosts/my-host",
+				HostGroupZone: "2",
+				SKU:           validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.VirtualMachineProperties.Host).To(Equal(&compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg/hosts/my-host")}))
+				g.Expect(vm.VirtualMachineProperties.HostGroup).To(BeNil())
+				g.Expect(vm.Zones).To(Equal(&[]string{"2"}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm in a dedicated host group",
+			spec: &VMSpec{
+				Name:          "my-vm",
+				Role:          infrav1.Node,
+				NICIDs:        []string{"my-nic"},
+				SSHKeyData:    "fakesshpublickey",
+				Size:          "Standard_D2v3",
+				Zone:          "1",
+				Image:         &infrav1.Image{ID: pointer.String("fake-image-id")},
+				HostGroupID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg",
+				HostGroupZone: "1",
+				SKU:           validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.VirtualMachineProperties.HostGroup).To(Equal(&compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg")}))
+				g.Expect(vm.VirtualMachineProperties.Host).To(BeNil())
+				g.Expect(vm.Zones).To(Equal(&[]string{"1"}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a vm in a dedicated host group in another zone fails",
+			spec: &VMSpec{
+				Name:          "my-vm",
+				Role:          infrav1.Node,
+				NICIDs:        []string{"my-nic"},
+				SSHKeyData:    "fakesshpublickey",
+				Size:          "Standard_D2v3",
+				Zone:          "1",
+				Image:         &infrav1.Image{ID: pointer.String("fake-image-id")},
+				HostGroupID:   "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-hg",
+				HostGroupZone: "2",
+				SKU:           validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: failure domain 1 does not match the availability zone 2 of the dedicated host group. Object will not be requeued",
+		},
+		{
+			name: "can create a vm in a proximity placement group",
+			spec: &VMSpec{
+				Name:                      "my-vm",
+				Role:                      infrav1.Node,
+				NICIDs:                    []string{"my-nic"},
+				SSHKeyData:                "fakesshpublickey",
+				Size:                      "Standard_D2v3",
+				AvailabilitySetID:         "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/availabilitySets/my-as",
+				Image:                     &infrav1.Image{ID: pointer.String("fake-image-id")},
+				ProximityPlacementGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg",
+				SKU:                       validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.VirtualMachineProperties.ProximityPlacementGroup).To(Equal(&compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg")}))
+				g.Expect(vm.VirtualMachineProperties.CapacityReservation).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a vm allocated from a capacity reservation group",
+			spec: &VMSpec{
+				Name:                       "my-vm",
+				Role:                       infrav1.Node,
+				NICIDs:                     []string{"my-nic"},
+				SSHKeyData:                 "fakesshpublickey",
+				Size:                       "Standard_D2v3",
+				Zone:                       "1",
+				Image:                      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				CapacityReservationGroupID: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg",
+				SKU:                        validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.VirtualMachineProperties.CapacityReservation).To(Equal(&compute.CapacityReservationProfile{
+					CapacityReservationGroup: &compute.SubResource{ID: pointer.String("/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg")},
+				}))
+				g.Expect(vm.VirtualMachineProperties.ProximityPlacementGroup).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "can create a confidential vm",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_DC2as_v5",
+				Zone:       "1",
+				OSDisk: infrav1.OSDisk{
+					OSType:     "Linux",
+					DiskSizeGB: pointer.Int32(128),
+					ManagedDisk: &infrav1.ManagedDiskParameters{
+						StorageAccountType: "Premium_LRS",
+						SecurityProfile: &infrav1.VMDiskSecurityProfile{
+							SecurityEncryptionType: infrav1.SecurityEncryptionTypeDiskWithVMGuestState,
+						},
+					},
+				},
+				Image: &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+					UefiSettings: &infrav1.UefiSettings{
+						SecureBootEnabled: pointer.Bool(true),
+						VTpmEnabled:       pointer.Bool(true),
+					},
+				},
+				SKU: validSKUWithConfidentialComputingType,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(compute.VirtualMachine{}))
+				vm := result.(compute.VirtualMachine)
+				g.Expect(vm.VirtualMachineProperties.SecurityProfile.SecurityType).To(Equal(compute.SecurityTypesConfidentialVM))
+				g.Expect(vm.StorageProfile.OsDisk.ManagedDisk.SecurityProfile).To(Equal(&compute.VMDiskSecurityProfile{
+					SecurityEncryptionType: compute.SecurityEncryptionTypesDiskWithVMGuestState,
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "creating a confidential vm for unsupported VM type fails",
+			spec: &VMSpec{
+				Name:       "my-vm",
+				Role:       infrav1.Node,
+				NICIDs:     []string{"my-nic"},
+				SSHKeyData: "fakesshpublickey",
+				Size:       "Standard_D2v3",
+				Zone:       "1",
+				Image:      &infrav1.Image{ID: pointer.String("fake-image-id")},
+				SecurityProfile: &infrav1.SecurityProfile{
+					SecurityType: infrav1.SecurityTypesConfidentialVM,
+				},
+				SKU: validSKU,
+			},
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "reconcile error that cannot be recovered occurred: confidential VM is not supported for VM type Standard_D2v3. Object will not be requeued",
+		},
 		{
 			name: "can create a vm and assign it to an availability set",
 			spec: &VMSpec{
@@ -992,3 +1264,154 @@ func TestParameters(t *testing.T) {
 		})
 	}
 }
+
+// existingVMJSON is a VM as returned by a GET, which includes read-only and defaulted properties but never the
+// custom data of the VM, and lists the data disks in the order they were attached in.
+const existingVMJSON = `{
+  "id": "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+  "name": "my-vm",
+  "type": "Microsoft.Compute/virtualMachines",
+  "location": "westus2",
+  "tags": {
+    "Name": "my-vm",
+    "sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned",
+    "sigs.k8s.io_cluster-api-provider-azure_role": "node"
+  },
+  "zones": ["1"],
+  "properties": {
+    "vmId": "8a7e1e1c-0c4a-4a8e-9c1e-3c1f3a4b5c6d",
+    "hardwareProfile": {"vmSize": "Standard_D2v3"},
+    "storageProfile": {
+      "imageReference": {
+        "publisher": "cncf-upstream",
+        "offer": "capi",
+        "sku": "ubuntu-2204-gen1",
+        "version": "latest",
+        "exactVersion": "125.2.20230101"
+      },
+      "osDisk": {
+        "osType": "Linux",
+        "name": "my-vm_OSDisk",
+        "createOption": "FromImage",
+        "caching": "ReadWrite",
+        "managedDisk": {
+          "storageAccountType": "Premium_LRS",
+          "id": "/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Compute/disks/my-vm_OSDisk"
+        },
+        "deleteOption": "Detach",
+        "diskSizeGB": 128
+      },
+      "dataDisks": [
+        {
+          "lun": 1,
+          "name": "my-vm_data",
+          "createOption": "Empty",
+          "caching": "None",
+          "managedDisk": {
+            "storageAccountType": "Premium_LRS",
+            "id": "/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Compute/disks/my-vm_data"
+          },
+          "deleteOption": "Detach",
+          "diskSizeGB": 64,
+          "toBeDetached": false
+        },
+        {
+          "lun": 0,
+          "name": "my-vm_etcddisk",
+          "createOption": "Empty",
+          "caching": "ReadWrite",
+          "managedDisk": {
+            "storageAccountType": "Premium_LRS",
+            "id": "/subscriptions/123/resourceGroups/MY-RG/providers/Microsoft.Compute/disks/my-vm_etcddisk"
+          },
+          "deleteOption": "Detach",
+          "diskSizeGB": 256,
+          "toBeDetached": false
+        }
+      ]
+    },
+    "osProfile": {
+      "computerName": "my-vm",
+      "adminUsername": "capi",
+      "linuxConfiguration": {
+        "disablePasswordAuthentication": true,
+        "ssh": {
+          "publicKeys": [
+            {
+              "path": "/home/capi/.ssh/authorized_keys",
+              "keyData": "fakesshpublickey"
+            }
+          ]
+        },
+        "provisionVMAgent": true,
+        "patchSettings": {"patchMode": "ImageDefault", "assessmentMode": "ImageDefault"}
+      },
+      "secrets": [],
+      "allowExtensionOperations": true,
+      "requireGuestProvisionSignal": true
+    },
+    "networkProfile": {
+      "networkInterfaces": [
+        {
+          "id": "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/my-vm-nic",
+          "properties": {"primary": true}
+        }
+      ]
+    },
+    "provisioningState": "Succeeded"
+  }
+}`
+
+func TestDesiredParametersDrift(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := &VMSpec{
+		Name:          "my-vm",
+		ResourceGroup: "my-rg",
+		Location:      "westus2",
+		ClusterName:   "my-cluster",
+		Role:          infrav1.Node,
+		NICIDs:        []string{"/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/networkInterfaces/my-vm-nic"},
+		SSHKeyData:    "ZmFrZXNzaHB1YmxpY2tleQ==",
+		Size:          "Standard_D2v3",
+		Zone:          "1",
+		OSDisk: infrav1.OSDisk{
+			OSType:      "Linux",
+			DiskSizeGB:  pointer.Int32(128),
+			ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "Premium_LRS"},
+			CachingType: "ReadWrite",
+		},
+		DataDisks: []infrav1.DataDisk{
+			{NameSuffix: "etcddisk", DiskSizeGB: 256, Lun: pointer.Int32(0), CachingType: "ReadWrite"},
+			{NameSuffix: "data", DiskSizeGB: 64, Lun: pointer.Int32(1)},
+		},
+		Image: &infrav1.Image{
+			Marketplace: &infrav1.AzureMarketplaceImage{
+				ImagePlan: infrav1.ImagePlan{Publisher: "cncf-upstream", Offer: "capi", SKU: "ubuntu-2204-gen1"},
+				Version:   "latest",
+			},
+		},
+		SKU:           validSKU,
+		BootstrapData: "Y2xvdWQtY29uZmln",
+		ProviderID:    "azure:///subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+	}
+	var existing compute.VirtualMachine
+	g.Expect(json.Unmarshal([]byte(existingVMJSON), &existing)).To(Succeed())
+
+	// The desired parameters are available even though the VM exists and was given a provider ID.
+	desired, err := spec.DesiredParameters(context.TODO())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	differences, err := azure.Diff(desired, existing, spec.DriftIgnoredPaths()...)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(differences).To(BeEmpty())
+
+	existing.HardwareProfile.VMSize = "Standard_D4v3"
+	(*existing.StorageProfile.DataDisks)[1].DiskSizeGB = pointer.Int32(512)
+	differences, err = azure.Diff(desired, existing, spec.DriftIgnoredPaths()...)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(differences).To(Equal([]azure.Difference{
+		{Path: "properties.hardwareProfile.vmSize", Desired: "Standard_D2v3", Actual: "Standard_D4v3"},
+		{Path: "properties.storageProfile.dataDisks[my-vm_etcddisk].diskSizeGB", Desired: float64(256), Actual: float64(512)},
+	}))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
@@ -30,6 +31,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/capacityreservations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
@@ -38,7 +40,11 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-const serviceName = "virtualmachine"
+const (
+	serviceName = "virtualmachine"
+	// powerStateDeallocated is the status code reported in the instance view of a deallocated VM.
+	powerStateDeallocated = "PowerState/deallocated"
+)
 
 // VMScope defines the scope interface for a virtual machines service.
 type VMScope interface {
@@ -49,6 +55,10 @@ type VMScope interface {
 	SetProviderID(string)
 	SetAddresses([]corev1.NodeAddress)
 	SetVMState(infrav1.ProvisioningState)
+	CapacityReservationID() string
+	SetCapacityReservationID(string)
+	VMResizeInProgress() bool
+	SpotVMStopped() bool
 	SetConditionFalse(clusterv1.ConditionType, string, clusterv1.ConditionSeverity, string)
 }
 
@@ -56,20 +66,24 @@ type VMScope interface {
 type Service struct {
 	Scope VMScope
 	async.Reconciler
-	interfacesGetter async.Getter
-	publicIPsGetter  async.Getter
-	identitiesGetter identities.Client
+	client                     Client
+	interfacesGetter           async.Getter
+	publicIPsGetter            async.Getter
+	identitiesGetter           identities.Client
+	capacityReservationsGetter capacityreservations.Client
 }
 
 // New creates a new service.
 func New(scope VMScope) *Service {
 	Client := NewClient(scope)
 	return &Service{
-		Scope:            scope,
-		interfacesGetter: networkinterfaces.NewClient(scope),
-		publicIPsGetter:  publicips.NewClient(scope),
-		identitiesGetter: identities.NewClient(scope),
-		Reconciler:       async.New(scope, Client, Client),
+		Scope:                      scope,
+		client:                     Client,
+		interfacesGetter:           networkinterfaces.NewClient(scope),
+		publicIPsGetter:            publicips.NewClient(scope),
+		identitiesGetter:           identities.NewClient(scope),
+		capacityReservationsGetter: capacityreservations.NewClient(scope),
+		Reconciler:                 async.New(scope, Client, Client),
 	}
 }
 
@@ -80,7 +94,7 @@ func (s *Service) Name() string {
 
 // Reconcile idempotently creates or updates a virtual machine.
 func (s *Service) Reconcile(ctx context.Context) error {
-	ctx, _, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Reconcile")
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.Reconcile")
 	defer done()
 
 	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultAzureServiceReconcileTimeout)
@@ -122,10 +136,162 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			return errors.Errorf("%T is not a valid VM spec", vmSpec)
 		}
 
+		// The capacity reservation is only looked up until it is recorded for the current capacity reservation group,
+		// and it is only reported in the status, so failing to look it up does not fail the reconcile.
+		if spec.CapacityReservationGroupID != "" &&
+			!strings.HasPrefix(strings.ToLower(s.Scope.CapacityReservationID()), strings.ToLower(spec.CapacityReservationGroupID+"/")) {
+			capacityReservationID, err := s.capacityReservationsGetter.GetCapacityReservationID(ctx, spec.CapacityReservationGroupID, infraVM.ID)
+			if err != nil {
+				log.Error(err, "failed to get the capacity reservation of the VM", "capacityReservationGroupID", spec.CapacityReservationGroupID)
+			} else {
+				s.Scope.SetCapacityReservationID(capacityReservationID)
+			}
+		}
+
 		err = s.checkUserAssignedIdentities(ctx, spec.UserAssignedIdentities, infraVM.UserAssignedIdentities)
 		if err != nil {
 			return errors.Wrap(err, "failed to check user assigned identities")
 		}
+
+		if err := s.checkSpotEviction(ctx, spec, vm, providerID); err != nil {
+			return err
+		}
+
+		if err := s.reconcileSize(ctx, spec, vm); err != nil {
+			return errors.Wrap(err, "failed to resize VM")
+		}
+	}
+	return err
+}
+
+// reconcileSize resizes the VM in place when its size differs from the desired one and the machine uses the InPlace
+// update strategy. The VM is first resized while it is running, which restarts it on its current hardware cluster. It is
+// only deallocated, resized and started again when the new size cannot be allocated on that hardware cluster. The
+// progress of the resize is reported in the VMSizeReady condition, which stays false until the VM is running again.
+func (s *Service) reconcileSize(ctx context.Context, spec *VMSpec, vm compute.VirtualMachine) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.reconcileSize")
+	defer done()
+
+	if spec.UpdateStrategy != infrav1.AzureMachineUpdateStrategyInPlace {
+		return nil
+	}
+
+	var currentSize string
+	if vm.VirtualMachineProperties != nil && vm.HardwareProfile != nil {
+		currentSize = string(vm.HardwareProfile.VMSize)
+	}
+	resized := strings.EqualFold(currentSize, spec.Size)
+	if resized && !s.Scope.VMResizeInProgress() {
+		return nil
+	}
+
+	resize := func(ctx context.Context, vmSpec azure.ResourceSpecGetter) (azureautorest.FutureAPI, error) {
+		return s.client.ResizeAsync(ctx, vmSpec, spec.Size)
+	}
+
+	// The VM size in the model can already be updated while the resize operation is still ongoing.
+	deallocating := s.Scope.GetLongRunningOperationState(spec.ResourceName(), serviceName, infrav1.DeallocateFuture) != nil
+	if !resized || deallocating || s.Scope.GetLongRunningOperationState(spec.ResourceName(), serviceName, infrav1.PatchFuture) != nil {
+		if !deallocating {
+			if !resized {
+				log.V(2).Info("resizing VM in place", "from", currentSize, "to", spec.Size)
+			}
+			s.Scope.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, "resizing VM to "+spec.Size)
+			err := s.ExecuteOperation(ctx, spec, serviceName, azure.PatchOperation, infrav1.PatchFuture, resize)
+			if err != nil && !azure.AllocationFailed(err) {
+				return s.resizeFailed(err)
+			}
+			deallocating = err != nil
+		}
+
+		if deallocating {
+			log.V(2).Info("deallocating VM to resize it to a size not available on its hardware cluster", "size", spec.Size)
+			s.Scope.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMDeallocatingReason, clusterv1.ConditionSeverityInfo, "deallocating VM before resizing it to "+spec.Size)
+			if err := s.ExecuteOperation(ctx, spec, serviceName, azure.PostOperation, infrav1.DeallocateFuture, s.client.DeallocateAsync); err != nil {
+				return s.resizeFailed(err)
+			}
+
+			s.Scope.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, "resizing VM to "+spec.Size)
+			if err := s.ExecuteOperation(ctx, spec, serviceName, azure.PatchOperation, infrav1.PatchFuture, resize); err != nil {
+				return s.resizeFailed(err)
+			}
+		}
+	}
+
+	// The VM only needs to be started when it was deallocated to be resized.
+	if s.Scope.GetLongRunningOperationState(spec.ResourceName(), serviceName, infrav1.StartFuture) == nil {
+		deallocated, err := s.isDeallocated(ctx, spec)
+		if err != nil {
+			return err
+		}
+		if !deallocated {
+			log.V(2).Info("successfully resized VM in place", "size", spec.Size)
+			s.Scope.UpdatePutStatus(infrav1.VMSizeReadyCondition, serviceName, nil)
+			return nil
+		}
+	}
+
+	s.Scope.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMStartingReason, clusterv1.ConditionSeverityInfo, "starting VM after resizing it")
+	if err := s.ExecuteOperation(ctx, spec, serviceName, azure.PostOperation, infrav1.StartFuture, s.client.StartAsync); err != nil {
+		return s.resizeFailed(err)
+	}
+
+	log.V(2).Info("successfully resized VM in place", "size", spec.Size)
+	s.Scope.UpdatePutStatus(infrav1.VMSizeReadyCondition, serviceName, nil)
+	return nil
+}
+
+// isDeallocated returns whether the instance view of the VM reports it as deallocated.
+func (s *Service) isDeallocated(ctx context.Context, spec *VMSpec) (bool, error) {
+	instanceView, err := s.client.InstanceView(ctx, spec)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get VM instance view")
+	}
+	if instanceView.Statuses == nil {
+		return false, nil
+	}
+	for _, status := range *instanceView.Statuses {
+		if strings.EqualFold(pointer.StringDeref(status.Code, ""), powerStateDeallocated) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkSpotEviction returns a VMEvictedError when a Spot VM has been deallocated, which is how Azure evicts Spot VMs
+// with the Deallocate eviction policy. Spot VMs with the Delete eviction policy are removed on eviction and surface as a
+// VMDeletedError instead. The instance view of a VM reports the same power state whether it was evicted or stopped, so
+// a Spot VM is only not considered evicted when capz deallocated it to resize it in place, or when its AzureMachine is
+// annotated to mark its deallocation as intended.
+func (s *Service) checkSpotEviction(ctx context.Context, spec *VMSpec, vm compute.VirtualMachine, providerID string) error {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "virtualmachines.Service.checkSpotEviction")
+	defer done()
+
+	if vm.VirtualMachineProperties == nil || vm.Priority != compute.VirtualMachinePriorityTypesSpot {
+		return nil
+	}
+	if vm.EvictionPolicy == compute.VirtualMachineEvictionPolicyTypesDelete || s.Scope.VMResizeInProgress() {
+		return nil
+	}
+
+	deallocated, err := s.isDeallocated(ctx, spec)
+	if err != nil || !deallocated {
+		return err
+	}
+	if s.Scope.SpotVMStopped() {
+		log.V(2).Info("Spot VM has been deallocated on purpose", "providerID", providerID)
+		return nil
+	}
+
+	log.V(2).Info("Spot VM has been evicted", "providerID", providerID)
+	return azure.VMEvictedError{ProviderID: providerID}
+}
+
+// resizeFailed reports a failed step of the in-place resize of the VM in the VMSizeReady condition, unless the step is
+// still in progress. The condition stays false so the cycle is resumed during the next reconciliation.
+func (s *Service) resizeFailed(err error) error {
+	if !azure.IsOperationNotDoneError(err) {
+		s.Scope.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, err.Error())
 	}
 	return err
 }
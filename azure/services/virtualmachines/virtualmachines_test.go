@@ -20,17 +20,21 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/Azure/go-autorest/autorest"
+	azureautorest "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/capacityreservations/mock_capacityreservations"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/identities/mock_identities"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/networkinterfaces"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
@@ -220,6 +224,393 @@ func TestReconcileVM(t *testing.T) {
 	}
 }
 
+func TestReconcileVMCapacityReservation(t *testing.T) {
+	fakeCapacityReservationGroupID := "/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Compute/capacityReservationGroups/test-crg"
+	fakeVMSpecWithCapacityReservation := fakeVMSpec
+	fakeVMSpecWithCapacityReservation.AvailabilitySetID = ""
+	fakeVMSpecWithCapacityReservation.CapacityReservationGroupID = fakeCapacityReservationGroupID
+
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_capacityreservations.MockClientMockRecorder)
+	}{
+		{
+			name:          "sets the capacity reservation the vm is allocated against",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_capacityreservations.MockClientMockRecorder) {
+				s.CapacityReservationID().Return("")
+				c.GetCapacityReservationID(gomockinternal.AContext(), fakeCapacityReservationGroupID, *fakeExistingVM.ID).Return(fakeCapacityReservationGroupID+"/capacityReservations/test-cr", nil)
+				s.SetCapacityReservationID(fakeCapacityReservationGroupID + "/capacityReservations/test-cr")
+			},
+		},
+		{
+			name:          "does not look up the capacity reservation the vm is already known to be allocated against",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_capacityreservations.MockClientMockRecorder) {
+				s.CapacityReservationID().Return(fakeCapacityReservationGroupID + "/capacityReservations/test-cr")
+			},
+		},
+		{
+			name:          "looks up the capacity reservation again when the capacity reservation group changes",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_capacityreservations.MockClientMockRecorder) {
+				s.CapacityReservationID().Return("/subscriptions/123/resourceGroups/test-rg/providers/Microsoft.Compute/capacityReservationGroups/old-crg/capacityReservations/test-cr")
+				c.GetCapacityReservationID(gomockinternal.AContext(), fakeCapacityReservationGroupID, *fakeExistingVM.ID).Return(fakeCapacityReservationGroupID+"/capacityReservations/test-cr", nil)
+				s.SetCapacityReservationID(fakeCapacityReservationGroupID + "/capacityReservations/test-cr")
+			},
+		},
+		{
+			name:          "does not fail when the capacity reservation the vm is allocated against cannot be looked up",
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_capacityreservations.MockClientMockRecorder) {
+				s.CapacityReservationID().Return("")
+				c.GetCapacityReservationID(gomockinternal.AContext(), fakeCapacityReservationGroupID, *fakeExistingVM.ID).Return("", internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			interfaceMock := mock_async.NewMockGetter(mockCtrl)
+			publicIPMock := mock_async.NewMockGetter(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			capacityReservationsMock := mock_capacityreservations.NewMockClient(mockCtrl)
+
+			scopeMock.EXPECT().VMSpec().Return(&fakeVMSpecWithCapacityReservation)
+			asyncMock.EXPECT().CreateOrUpdateResource(gomockinternal.AContext(), &fakeVMSpecWithCapacityReservation, serviceName).Return(fakeExistingVM, nil)
+			scopeMock.EXPECT().UpdatePutStatus(infrav1.VMRunningCondition, serviceName, nil)
+			scopeMock.EXPECT().UpdatePutStatus(infrav1.DisksReadyCondition, serviceName, nil)
+			scopeMock.EXPECT().SetProviderID("azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm")
+			scopeMock.EXPECT().SetAnnotation("cluster-api-provider-azure", "true")
+			interfaceMock.EXPECT().Get(gomockinternal.AContext(), &fakeNetworkInterfaceGetterSpec).Return(fakeNetworkInterface, nil)
+			publicIPMock.EXPECT().Get(gomockinternal.AContext(), &fakePublicIPSpec).Return(fakePublicIPs, nil)
+			scopeMock.EXPECT().SetAddresses(fakeNodeAddresses)
+			scopeMock.EXPECT().SetVMState(infrav1.Succeeded)
+			tc.expect(scopeMock.EXPECT(), capacityReservationsMock.EXPECT())
+
+			s := &Service{
+				Scope:                      scopeMock,
+				interfacesGetter:           interfaceMock,
+				publicIPsGetter:            publicIPMock,
+				capacityReservationsGetter: capacityReservationsMock,
+				Reconciler:                 asyncMock,
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileVMSize(t *testing.T) {
+	fakeInPlaceVMSpec := fakeVMSpec
+	fakeInPlaceVMSpec.UpdateStrategy = infrav1.AzureMachineUpdateStrategyInPlace
+	fakeResizedVM := fakeExistingVM
+	fakeResizedVM.VirtualMachineProperties = &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{VMSize: compute.VirtualMachineSizeTypes(fakeVMSpec.Size)},
+	}
+	fakeOtherSizeVM := fakeExistingVM
+	fakeOtherSizeVM.VirtualMachineProperties = &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{VMSize: "Standard_Other_Size"},
+	}
+	runningInstanceView := compute.VirtualMachineInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{Code: pointer.String("ProvisioningState/succeeded")},
+			{Code: pointer.String("PowerState/running")},
+		},
+	}
+	deallocatedInstanceView := compute.VirtualMachineInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{Code: pointer.String("ProvisioningState/succeeded")},
+			{Code: pointer.String("PowerState/deallocated")},
+		},
+	}
+	allocationFailedError := autorest.NewErrorWithError(&azureautorest.RequestError{
+		ServiceError: &azureautorest.ServiceError{Code: "AllocationFailed"},
+	}, "", "", &http.Response{StatusCode: http.StatusConflict}, "Allocation failed")
+	operationNotDoneError := azure.WithTransientError(azure.NewOperationNotDoneError(&infrav1.Future{Type: infrav1.DeallocateFuture}), 15*time.Second)
+
+	testcases := []struct {
+		name          string
+		spec          VMSpec
+		vm            compute.VirtualMachine
+		expectedError string
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder)
+	}{
+		{
+			name:          "noop if the machine is not updated in place",
+			spec:          fakeVMSpec,
+			vm:            fakeOtherSizeVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+			},
+		},
+		{
+			name:          "noop if the vm has the desired size",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeResizedVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(false)
+			},
+		},
+		{
+			name:          "resizes the running vm without deallocating it",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeOtherSizeVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.DeallocateFuture).Return(nil)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PatchOperation, infrav1.PatchFuture, gomock.Any()).Return(nil)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.StartFuture).Return(nil)
+				c.InstanceView(gomockinternal.AContext(), &fakeInPlaceVMSpec).Return(runningInstanceView, nil)
+				s.UpdatePutStatus(infrav1.VMSizeReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "deallocates, resizes and starts the vm when the size cannot be allocated on its hardware cluster",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeOtherSizeVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.DeallocateFuture).Return(nil)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, gomock.Any()).Times(2)
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PatchOperation, infrav1.PatchFuture, gomock.Any()).Return(allocationFailedError)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMDeallocatingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PostOperation, infrav1.DeallocateFuture, gomock.Any()).Return(nil)
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PatchOperation, infrav1.PatchFuture, gomock.Any()).Return(nil)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.StartFuture).Return(nil)
+				c.InstanceView(gomockinternal.AContext(), &fakeInPlaceVMSpec).Return(deallocatedInstanceView, nil)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMStartingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PostOperation, infrav1.StartFuture, gomock.Any()).Return(nil)
+				s.UpdatePutStatus(infrav1.VMSizeReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fails to resize the running vm",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeOtherSizeVM,
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.DeallocateFuture).Return(nil)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PatchOperation, infrav1.PatchFuture, gomock.Any()).Return(internalError)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, internalError.Error())
+			},
+		},
+		{
+			name:          "vm deallocation is in progress",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeOtherSizeVM,
+			expectedError: operationNotDoneError.Error(),
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.DeallocateFuture).Return(&infrav1.Future{Type: infrav1.DeallocateFuture})
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMDeallocatingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PostOperation, infrav1.DeallocateFuture, gomock.Any()).Return(operationNotDoneError)
+			},
+		},
+		{
+			name:          "completes the ongoing resize of the deallocated vm and starts it",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeResizedVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(true)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.DeallocateFuture).Return(nil)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.PatchFuture).Return(&infrav1.Future{Type: infrav1.PatchFuture})
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PatchOperation, infrav1.PatchFuture, gomock.Any()).Return(nil)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.StartFuture).Return(nil)
+				c.InstanceView(gomockinternal.AContext(), &fakeInPlaceVMSpec).Return(deallocatedInstanceView, nil)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMStartingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PostOperation, infrav1.StartFuture, gomock.Any()).Return(nil)
+				s.UpdatePutStatus(infrav1.VMSizeReadyCondition, serviceName, nil)
+			},
+		},
+		{
+			name:          "fails to start the resized vm",
+			spec:          fakeInPlaceVMSpec,
+			vm:            fakeResizedVM,
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(true)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.DeallocateFuture).Return(nil)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.PatchFuture).Return(nil)
+				s.GetLongRunningOperationState(fakeInPlaceVMSpec.Name, serviceName, infrav1.StartFuture).Return(&infrav1.Future{Type: infrav1.StartFuture})
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMStartingReason, clusterv1.ConditionSeverityInfo, gomock.Any())
+				r.ExecuteOperation(gomockinternal.AContext(), &fakeInPlaceVMSpec, serviceName, azure.PostOperation, infrav1.StartFuture, gomock.Any()).Return(internalError)
+				s.SetConditionFalse(infrav1.VMSizeReadyCondition, infrav1.VMResizeFailedReason, clusterv1.ConditionSeverityError, internalError.Error())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				client:     clientMock,
+			}
+
+			spec := tc.spec
+			err := s.reconcileSize(context.TODO(), &spec, tc.vm)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestCheckSpotEviction(t *testing.T) {
+	fakeProviderID := "azure://subscriptions/123/resourceGroups/my_resource_group/providers/Microsoft.Compute/virtualMachines/my-vm"
+	fakeSpotVM := fakeExistingVM
+	fakeSpotVM.VirtualMachineProperties = &compute.VirtualMachineProperties{
+		Priority:       compute.VirtualMachinePriorityTypesSpot,
+		EvictionPolicy: compute.VirtualMachineEvictionPolicyTypesDeallocate,
+	}
+	fakeSpotDeleteVM := fakeExistingVM
+	fakeSpotDeleteVM.VirtualMachineProperties = &compute.VirtualMachineProperties{
+		Priority:       compute.VirtualMachinePriorityTypesSpot,
+		EvictionPolicy: compute.VirtualMachineEvictionPolicyTypesDelete,
+	}
+	runningInstanceView := compute.VirtualMachineInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{Code: pointer.String("ProvisioningState/succeeded")},
+			{Code: pointer.String("PowerState/running")},
+		},
+	}
+	deallocatedInstanceView := compute.VirtualMachineInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{Code: pointer.String("ProvisioningState/succeeded")},
+			{Code: pointer.String("PowerState/deallocated")},
+		},
+	}
+
+	testcases := []struct {
+		name          string
+		vm            compute.VirtualMachine
+		expectedError string
+		expect        func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder)
+	}{
+		{
+			name:          "noop if the vm is not a spot vm",
+			vm:            fakeExistingVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+			},
+		},
+		{
+			name:          "noop if the spot vm is deleted on eviction",
+			vm:            fakeSpotDeleteVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+			},
+		},
+		{
+			name:          "noop if the spot vm is being resized",
+			vm:            fakeSpotVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(true)
+			},
+		},
+		{
+			name:          "spot vm is running",
+			vm:            fakeSpotVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(false)
+				c.InstanceView(gomockinternal.AContext(), &fakeVMSpec).Return(runningInstanceView, nil)
+			},
+		},
+		{
+			name:          "spot vm has been evicted",
+			vm:            fakeSpotVM,
+			expectedError: azure.VMEvictedError{ProviderID: fakeProviderID}.Error(),
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(false)
+				c.InstanceView(gomockinternal.AContext(), &fakeVMSpec).Return(deallocatedInstanceView, nil)
+				s.SpotVMStopped().Return(false)
+			},
+		},
+		{
+			name:          "spot vm has been deallocated on purpose",
+			vm:            fakeSpotVM,
+			expectedError: "",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(false)
+				c.InstanceView(gomockinternal.AContext(), &fakeVMSpec).Return(deallocatedInstanceView, nil)
+				s.SpotVMStopped().Return(true)
+			},
+		},
+		{
+			name:          "fails to get the instance view of the spot vm",
+			vm:            fakeSpotVM,
+			expectedError: "failed to get VM instance view: #: Internal Server Error: StatusCode=500",
+			expect: func(s *mock_virtualmachines.MockVMScopeMockRecorder, c *mock_virtualmachines.MockClientMockRecorder) {
+				s.VMResizeInProgress().Return(false)
+				c.InstanceView(gomockinternal.AContext(), &fakeVMSpec).Return(compute.VirtualMachineInstanceView{}, internalError)
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scopeMock := mock_virtualmachines.NewMockVMScope(mockCtrl)
+			clientMock := mock_virtualmachines.NewMockClient(mockCtrl)
+			tc.expect(scopeMock.EXPECT(), clientMock.EXPECT())
+
+			s := &Service{
+				Scope:  scopeMock,
+				client: clientMock,
+			}
+
+			spec := fakeVMSpec
+			err := s.checkSpotEviction(context.TODO(), &spec, tc.vm, fakeProviderID)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestDeleteVM(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -279,6 +279,18 @@ func (mr *MockVNetScopeMockRecorder) UpdateSubnetCIDRs(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubnetCIDRs", reflect.TypeOf((*MockVNetScope)(nil).UpdateSubnetCIDRs), arg0, arg1)
 }
 
+// UpdateVnet mocks base method.
+func (m *MockVNetScope) UpdateVnet(id string, tags v1beta1.Tags, cidrBlocks []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateVnet", id, tags, cidrBlocks)
+}
+
+// UpdateVnet indicates an expected call of UpdateVnet.
+func (mr *MockVNetScopeMockRecorder) UpdateVnet(id, tags, cidrBlocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVnet", reflect.TypeOf((*MockVNetScope)(nil).UpdateVnet), id, tags, cidrBlocks)
+}
+
 // VNetSpec mocks base method.
 func (m *MockVNetScope) VNetSpec() azure.ResourceSpecGetter {
 	m.ctrl.T.Helper()
@@ -292,17 +304,3 @@ func (mr *MockVNetScopeMockRecorder) VNetSpec() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VNetSpec", reflect.TypeOf((*MockVNetScope)(nil).VNetSpec))
 }
-
-// Vnet mocks base method.
-func (m *MockVNetScope) Vnet() *v1beta1.VnetSpec {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Vnet")
-	ret0, _ := ret[0].(*v1beta1.VnetSpec)
-	return ret0
-}
-
-// Vnet indicates an expected call of Vnet.
-func (mr *MockVNetScopeMockRecorder) Vnet() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vnet", reflect.TypeOf((*MockVNetScope)(nil).Vnet))
-}
@@ -18,21 +18,28 @@ package virtualnetworks
 
 import (
 	"context"
+	"reflect"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/pkg/errors"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 )
 
 // VNetSpec defines the specification for a Virtual Network.
 type VNetSpec struct {
-	ResourceGroup  string
-	Name           string
-	CIDRs          []string
-	Location       string
-	ClusterName    string
-	AdditionalTags infrav1.Tags
+	ResourceGroup        string
+	Name                 string
+	CIDRs                []string
+	Location             string
+	ClusterName          string
+	AdditionalTags       infrav1.Tags
+	DDoSProtectionPlanID *string
+	Encryption           *infrav1.VnetEncryption
+	DHCPOptions          *infrav1.VnetDHCPOptions
 }
 
 // ResourceName returns the name of the vnet.
@@ -53,9 +60,34 @@ func (s *VNetSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the vnet.
 func (s *VNetSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
 	if existing != nil {
-		// vnet already exists, nothing to update.
-		return nil, nil
+		existingVnet, ok := existing.(network.VirtualNetwork)
+		if !ok {
+			return nil, errors.Errorf("%T is not a network.VirtualNetwork", existing)
+		}
+		// Only the vnets owned by the cluster are updated, custom vnets are never mutated.
+		if !converters.MapToTags(existingVnet.Tags).HasOwned(s.ClusterName) {
+			return nil, nil
+		}
+		// The vnet is updated from its existing state so that its subnets and peerings are preserved.
+		properties := network.VirtualNetworkPropertiesFormat{}
+		if existingVnet.VirtualNetworkPropertiesFormat != nil {
+			properties = *existingVnet.VirtualNetworkPropertiesFormat
+		}
+		existingVnet.VirtualNetworkPropertiesFormat = &properties
+		if !s.setProperties(existingVnet.VirtualNetworkPropertiesFormat) {
+			// vnet is up to date, nothing to update.
+			return nil, nil
+		}
+		return existingVnet, nil
 	}
+
+	properties := &network.VirtualNetworkPropertiesFormat{
+		AddressSpace: &network.AddressSpace{
+			AddressPrefixes: &s.CIDRs,
+		},
+	}
+	s.setProperties(properties)
+
 	return network.VirtualNetwork{
 		Tags: converters.TagsToMap(infrav1.Build(infrav1.BuildParams{
 			ClusterName: s.ClusterName,
@@ -64,11 +96,77 @@ func (s *VNetSpec) Parameters(ctx context.Context, existing interface{}) (interf
 			Role:        pointer.String(infrav1.CommonRole),
 			Additional:  s.AdditionalTags,
 		})),
-		Location: pointer.String(s.Location),
-		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
-			AddressSpace: &network.AddressSpace{
-				AddressPrefixes: &s.CIDRs,
-			},
-		},
+		Location:                       pointer.String(s.Location),
+		VirtualNetworkPropertiesFormat: properties,
 	}, nil
 }
+
+// setProperties sets the DDoS protection plan, the encryption and the DNS servers of the vnet that are set in the
+// spec, and returns whether any of them changed. The properties that are not set in the spec are left unchanged.
+func (s *VNetSpec) setProperties(properties *network.VirtualNetworkPropertiesFormat) bool {
+	changed := false
+
+	if s.DDoSProtectionPlanID != nil {
+		desiredPlanID := *s.DDoSProtectionPlanID
+		var planID string
+		if properties.DdosProtectionPlan != nil {
+			planID = pointer.StringDeref(properties.DdosProtectionPlan.ID, "")
+		}
+		ddosEnabled := pointer.BoolDeref(properties.EnableDdosProtection, false)
+		if !strings.EqualFold(planID, desiredPlanID) || ddosEnabled != (desiredPlanID != "") {
+			changed = true
+			properties.DdosProtectionPlan = nil
+			if desiredPlanID != "" {
+				properties.DdosProtectionPlan = &network.SubResource{ID: pointer.String(desiredPlanID)}
+			}
+			properties.EnableDdosProtection = pointer.Bool(desiredPlanID != "")
+		}
+	}
+
+	if s.Encryption != nil {
+		encryption := properties.Encryption
+		encryptionEnabled := encryption != nil && pointer.BoolDeref(encryption.Enabled, false)
+		if pointer.BoolDeref(s.Encryption.Enabled, true) {
+			enforcement := network.VirtualNetworkEncryptionEnforcement(s.Encryption.Enforcement)
+			if enforcement == "" {
+				enforcement = network.VirtualNetworkEncryptionEnforcementAllowUnencrypted
+			}
+			if !encryptionEnabled || !strings.EqualFold(string(encryption.Enforcement), string(enforcement)) {
+				changed = true
+				properties.Encryption = &network.VirtualNetworkEncryption{
+					Enabled:     pointer.Bool(true),
+					Enforcement: enforcement,
+				}
+			}
+		} else if encryptionEnabled {
+			changed = true
+			properties.Encryption = &network.VirtualNetworkEncryption{
+				Enabled: pointer.Bool(false),
+			}
+		}
+	}
+
+	if s.DHCPOptions != nil {
+		var dnsServers []string
+		if properties.DhcpOptions != nil {
+			dnsServers = azure.StringSlice(properties.DhcpOptions.DNSServers)
+		}
+		// The order of the DNS servers is significant.
+		if !reflect.DeepEqual(dnsServers, s.DHCPOptions.DNSServers) && (len(dnsServers) > 0 || len(s.DHCPOptions.DNSServers) > 0) {
+			changed = true
+			servers := make([]string, len(s.DHCPOptions.DNSServers))
+			copy(servers, s.DHCPOptions.DNSServers)
+			properties.DhcpOptions = &network.DhcpOptions{
+				DNSServers: &servers,
+			}
+		}
+	}
+
+	return changed
+}
+
+// managesProperties returns whether the spec sets the DDoS protection plan, the encryption or the DNS servers of the
+// vnet.
+func (s *VNetSpec) managesProperties() bool {
+	return s.DDoSProtectionPlanID != nil || s.Encryption != nil || s.DHCPOptions != nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+var (
+	fakeDDoSProtectionPlanID = "/subscriptions/123/resourceGroups/ddos-rg/providers/Microsoft.Network/ddosProtectionPlans/ddos-plan"
+
+	fakeProtectedVNetSpec = VNetSpec{
+		ResourceGroup:        "test-group",
+		Name:                 "test-vnet",
+		CIDRs:                []string{"10.0.0.0/8"},
+		Location:             "test-location",
+		ClusterName:          "test-cluster",
+		DDoSProtectionPlanID: pointer.String(fakeDDoSProtectionPlanID),
+		Encryption:           &infrav1.VnetEncryption{Enforcement: infrav1.VnetEncryptionEnforcementDropUnencrypted},
+		DHCPOptions:          &infrav1.VnetDHCPOptions{DNSServers: []string{"10.0.0.4", "10.0.0.5"}},
+	}
+
+	fakeUnprotectedVNetSpec = VNetSpec{
+		ResourceGroup:        "test-group",
+		Name:                 "test-vnet",
+		CIDRs:                []string{"10.0.0.0/8"},
+		Location:             "test-location",
+		ClusterName:          "test-cluster",
+		DDoSProtectionPlanID: pointer.String(""),
+		Encryption:           &infrav1.VnetEncryption{Enabled: pointer.Bool(false)},
+		DHCPOptions:          &infrav1.VnetDHCPOptions{},
+	}
+
+	fakeProtectedVNetProperties = network.VirtualNetworkPropertiesFormat{
+		EnableDdosProtection: pointer.Bool(true),
+		DdosProtectionPlan:   &network.SubResource{ID: pointer.String(fakeDDoSProtectionPlanID)},
+		Encryption: &network.VirtualNetworkEncryption{
+			Enabled:     pointer.Bool(true),
+			Enforcement: network.VirtualNetworkEncryptionEnforcementDropUnencrypted,
+		},
+		DhcpOptions: &network.DhcpOptions{DNSServers: &[]string{"10.0.0.4", "10.0.0.5"}},
+	}
+
+	fakeVNetSubnets = &[]network.Subnet{
+		{
+			Name: pointer.String("test-subnet"),
+			SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				AddressPrefix: pointer.String("10.0.0.0/16"),
+			},
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *VNetSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "new vnet without DDoS protection, encryption or DNS servers",
+			spec:     &fakeVNetSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				g.Expect(result.(network.VirtualNetwork).VirtualNetworkPropertiesFormat).To(Equal(&network.VirtualNetworkPropertiesFormat{
+					AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "new vnet with DDoS protection, encryption and DNS servers",
+			spec:     &fakeProtectedVNetSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				properties := fakeProtectedVNetProperties
+				properties.AddressSpace = &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}}
+				g.Expect(result.(network.VirtualNetwork).VirtualNetworkPropertiesFormat).To(Equal(&properties))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "custom vnet is not updated",
+			spec:     &fakeProtectedVNetSpec,
+			existing: customVnet,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "managed vnet without DDoS protection, encryption or DNS servers is up to date",
+			spec:     &fakeVNetSpec,
+			existing: managedVnet,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "managed vnet with DDoS protection, encryption and DNS servers is up to date",
+			spec: &fakeProtectedVNetSpec,
+			existing: network.VirtualNetwork{
+				Tags:                           managedVnet.Tags,
+				VirtualNetworkPropertiesFormat: &fakeProtectedVNetProperties,
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name: "managed vnet is updated in place, keeping its subnets",
+			spec: &fakeProtectedVNetSpec,
+			existing: network.VirtualNetwork{
+				Name: pointer.String("test-vnet"),
+				Tags: managedVnet.Tags,
+				VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+					AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+					Subnets:      fakeVNetSubnets,
+				},
+			},
+			expect: func(g *WithT, result interface{}) {
+				properties := fakeProtectedVNetProperties
+				properties.AddressSpace = &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}}
+				properties.Subnets = fakeVNetSubnets
+				g.Expect(result).To(Equal(network.VirtualNetwork{
+					Name:                           pointer.String("test-vnet"),
+					Tags:                           managedVnet.Tags,
+					VirtualNetworkPropertiesFormat: &properties,
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "DDoS protection, encryption and DNS servers not set in the spec are left unchanged",
+			spec: &fakeVNetSpec,
+			existing: network.VirtualNetwork{
+				Tags:                           managedVnet.Tags,
+				VirtualNetworkPropertiesFormat: &fakeProtectedVNetProperties,
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "new vnet with DDoS protection and encryption disabled and Azure-provided DNS",
+			spec:     &fakeUnprotectedVNetSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeAssignableToTypeOf(network.VirtualNetwork{}))
+				g.Expect(result.(network.VirtualNetwork).VirtualNetworkPropertiesFormat).To(Equal(&network.VirtualNetworkPropertiesFormat{
+					AddressSpace: &network.AddressSpace{AddressPrefixes: &[]string{"10.0.0.0/8"}},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name: "DDoS protection, encryption and DNS servers are removed from a managed vnet",
+			spec: &fakeUnprotectedVNetSpec,
+			existing: network.VirtualNetwork{
+				Tags:                           managedVnet.Tags,
+				VirtualNetworkPropertiesFormat: &fakeProtectedVNetProperties,
+			},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetwork{
+					Tags: managedVnet.Tags,
+					VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+						EnableDdosProtection: pointer.Bool(false),
+						Encryption:           &network.VirtualNetworkEncryption{Enabled: pointer.Bool(false)},
+						DhcpOptions:          &network.DhcpOptions{DNSServers: &[]string{}},
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing is not a virtual network",
+			spec:     &fakeVNetSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a network.VirtualNetwork",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -26,21 +26,23 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/converters"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/tags"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
 
-const serviceName = "virtualnetworks"
+// ServiceName is the name of this service.
+const ServiceName = "virtualnetworks"
 
 // VNetScope defines the scope interface for a virtual network service.
 type VNetScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
-	Vnet() *infrav1.VnetSpec
 	VNetSpec() azure.ResourceSpecGetter
 	ClusterName() string
 	IsVnetManaged() bool
+	UpdateVnet(id string, tags infrav1.Tags, cidrBlocks []string)
 	UpdateSubnetCIDRs(string, []string)
 }
 
@@ -66,7 +68,14 @@ func New(scope VNetScope) *Service {
 
 // Name returns the service name.
 func (s *Service) Name() string {
-	return serviceName
+	return ServiceName
+}
+
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		groups.ServiceName,
+	}
 }
 
 // Reconcile idempotently creates or updates a virtual network.
@@ -82,21 +91,25 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, serviceName)
+	result, err := s.CreateOrUpdateResource(ctx, vnetSpec, ServiceName)
 	if err == nil && result != nil {
 		existingVnet, ok := result.(network.VirtualNetwork)
 		if !ok {
 			return errors.Errorf("%T is not a network.VirtualNetwork", result)
 		}
-		vnet := s.Scope.Vnet()
-		vnet.ID = pointer.StringDeref(existingVnet.ID, "")
-		vnet.Tags = converters.MapToTags(existingVnet.Tags)
-
 		var prefixes []string
 		if existingVnet.VirtualNetworkPropertiesFormat != nil && existingVnet.VirtualNetworkPropertiesFormat.AddressSpace != nil {
 			prefixes = azure.StringSlice(existingVnet.VirtualNetworkPropertiesFormat.AddressSpace.AddressPrefixes)
 		}
-		vnet.CIDRBlocks = prefixes
+		vnetTags := converters.MapToTags(existingVnet.Tags)
+		s.Scope.UpdateVnet(pointer.StringDeref(existingVnet.ID, ""), vnetTags, prefixes)
+
+		// The DDoS protection, the encryption and the DNS servers of a virtual network that is not managed by capz are
+		// never modified, so they cannot be set in its spec.
+		if spec, ok := vnetSpec.(*VNetSpec); ok && spec.managesProperties() && !vnetTags.HasOwned(s.Scope.ClusterName()) {
+			return azure.WithTerminalError(errors.Errorf("the DDoS protection plan, the encryption and the DHCP options can only be set on a virtual network managed by capz, but virtual network %s is not",
+				pointer.StringDeref(existingVnet.Name, "")))
+		}
 
 		// Update the subnet CIDRs if they already exist.
 		// This makes sure the subnet CIDRs are up to date and there are no validation errors when updating the VNet.
@@ -109,7 +122,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	if s.Scope.IsVnetManaged() {
-		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, err)
+		s.Scope.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	}
 
 	return err
@@ -133,8 +146,8 @@ func (s *Service) Delete(ctx context.Context) error {
 	if err != nil {
 		if azure.ResourceNotFound(err) {
 			// already deleted or doesn't exist, cleanup status and return.
-			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), serviceName, infrav1.DeleteFuture)
-			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+			s.Scope.DeleteLongRunningOperationState(vnetSpec.ResourceName(), ServiceName, infrav1.DeleteFuture)
+			s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			return nil
 		}
 		return errors.Wrap(err, "could not get VNet management state")
@@ -144,8 +157,8 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	err = s.DeleteResource(ctx, vnetSpec, serviceName)
-	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, err)
+	err = s.DeleteResource(ctx, vnetSpec, ServiceName)
+	s.Scope.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, err)
 	return err
 }
 
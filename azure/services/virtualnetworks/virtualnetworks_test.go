@@ -121,7 +121,7 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(false)
 			},
 		},
@@ -130,9 +130,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, nil)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, nil)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -140,9 +140,9 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: internalError.Error(),
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil, internalError)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil, internalError)
 				s.IsVnetManaged().Return(true)
-				s.UpdatePutStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				s.UpdatePutStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -150,13 +150,23 @@ func TestReconcileVnet(t *testing.T) {
 			expectedError: "",
 			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				s.VNetSpec().Return(&fakeVNetSpec)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(customVnet, nil)
-				s.Vnet().Return(&infrav1.VnetSpec{})
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(customVnet, nil)
+				s.UpdateVnet(*customVnet.ID, infrav1.Tags{"foo": "bar", "something": "else"}, []string{"fake-cidr"})
 				s.UpdateSubnetCIDRs("test-subnet", []string{"subnet-cidr"})
 				s.UpdateSubnetCIDRs("test-subnet-2", []string{"subnet-cidr-1", "subnet-cidr-2"})
 				s.IsVnetManaged().Return(false)
 			},
 		},
+		{
+			name:          "existing vnet not managed by capz cannot be protected, encrypted or use custom DNS servers",
+			expectedError: "the DDoS protection plan, the encryption and the DHCP options can only be set on a virtual network managed by capz, but virtual network test-vnet is not",
+			expect: func(s *mock_virtualnetworks.MockVNetScopeMockRecorder, m *mock_async.MockTagsGetterMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
+				s.VNetSpec().Return(&fakeProtectedVNetSpec)
+				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakeProtectedVNetSpec, ServiceName).Return(customVnet, nil)
+				s.UpdateVnet(*customVnet.ID, infrav1.Tags{"foo": "bar", "something": "else"}, []string{"fake-cidr"})
+				s.ClusterName().Return("test-cluster")
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -211,8 +221,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.VNetID("123", fakeVNetSpec.ResourceGroupName(), fakeVNetSpec.Name)).Return(managedTags, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(nil)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, nil)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(nil)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, nil)
 			},
 		},
 		{
@@ -223,8 +233,8 @@ func TestDeleteVnet(t *testing.T) {
 				s.SubscriptionID().Return("123")
 				m.GetAtScope(gomockinternal.AContext(), azure.VNetID("123", fakeVNetSpec.ResourceGroupName(), fakeVNetSpec.Name)).Return(managedTags, nil)
 				s.ClusterName().Return("test-cluster")
-				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, serviceName).Return(internalError)
-				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, serviceName, internalError)
+				r.DeleteResource(gomockinternal.AContext(), &fakeVNetSpec, ServiceName).Return(internalError)
+				s.UpdateDeleteStatus(infrav1.VNetReadyCondition, ServiceName, internalError)
 			},
 		},
 		{
@@ -32,6 +32,8 @@ type VMExtensionSpec struct {
 	Location      string
 }
 
+var _ azure.ResourceSpecGetterWithDrift = (*VMExtensionSpec)(nil)
+
 // ResourceName returns the name of the VM extension.
 func (s *VMExtensionSpec) ResourceName() string {
 	return s.Name
@@ -47,6 +49,17 @@ func (s *VMExtensionSpec) OwnerResourceName() string {
 	return s.VMName
 }
 
+// DesiredParameters returns the parameters of the VM extension as it would be created, to detect the drift of the
+// existing VM extension.
+func (s *VMExtensionSpec) DesiredParameters(ctx context.Context) (interface{}, error) {
+	return s.Parameters(ctx, nil)
+}
+
+// DriftIgnoredPaths returns the properties of the VM extension that are not returned by Azure.
+func (s *VMExtensionSpec) DriftIgnoredPaths() []string {
+	return []string{"properties.protectedSettings"}
+}
+
 // Parameters returns the parameters for the VM extension.
 func (s *VMExtensionSpec) Parameters(ctx context.Context, existing interface{}) (interface{}, error) {
 	if existing != nil {
@@ -69,19 +69,7 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of ExtensionSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var resultErr error
-	for _, extensionSpec := range specs {
-		_, err := s.CreateOrUpdateResource(ctx, extensionSpec, serviceName)
-		if err != nil {
-			if !azure.IsOperationNotDoneError(err) || resultErr == nil {
-				resultErr = err
-			}
-		}
-	}
-
+	_, resultErr := s.CreateOrUpdateResources(ctx, specs, serviceName, "")
 	if azure.IsOperationNotDoneError(resultErr) {
 		resultErr = errors.Wrapf(resultErr, "extension is still in provisioning state. This likely means that bootstrapping has not yet completed on the VM")
 	} else if resultErr != nil {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 )
@@ -29,10 +30,23 @@ import (
 type VnetPeeringSpec struct {
 	SourceResourceGroup string
 	SourceVnetName      string
-	RemoteResourceGroup string
-	RemoteVnetName      string
-	PeeringName         string
-	SubscriptionID      string
+	// SourceSubscriptionID is the ID of the subscription of the source virtual network, in which the peering is
+	// managed. It is empty when the source virtual network belongs to the subscription of the cluster.
+	SourceSubscriptionID string
+	RemoteResourceGroup  string
+	RemoteVnetName       string
+	PeeringName          string
+	// SubscriptionID is the ID of the subscription of the remote virtual network.
+	SubscriptionID string
+	// IdentityRef is a reference to the AzureClusterIdentity used to manage the peering. It is nil when the peering
+	// is managed with the identity of the cluster.
+	IdentityRef *corev1.ObjectReference
+	// AuxiliaryIdentityRef is a reference to the AzureClusterIdentity of the remote virtual network. When it belongs
+	// to another tenant than the identity managing the peering, its token is sent as an auxiliary token.
+	AuxiliaryIdentityRef  *corev1.ObjectReference
+	AllowForwardedTraffic *bool
+	AllowGatewayTransit   *bool
+	UseRemoteGateways     *bool
 }
 
 // ResourceName returns the name of the virtual network peering.
@@ -53,11 +67,19 @@ func (s *VnetPeeringSpec) OwnerResourceName() string {
 // Parameters returns the parameters for the virtual network peering.
 func (s *VnetPeeringSpec) Parameters(ctx context.Context, existing interface{}) (params interface{}, err error) {
 	if existing != nil {
-		if _, ok := existing.(network.VirtualNetworkPeering); !ok {
+		existingPeering, ok := existing.(network.VirtualNetworkPeering)
+		if !ok {
 			return nil, errors.Errorf("%T is not a network.VnetPeering", existing)
 		}
-		// virtual network peering already exists
-		return nil, nil
+		if existingPeering.VirtualNetworkPeeringPropertiesFormat == nil || s.isUpToDate(*existingPeering.VirtualNetworkPeeringPropertiesFormat) {
+			// virtual network peering already exists
+			return nil, nil
+		}
+		// update the properties of the existing virtual network peering
+		properties := *existingPeering.VirtualNetworkPeeringPropertiesFormat
+		s.setProperties(&properties)
+		existingPeering.VirtualNetworkPeeringPropertiesFormat = &properties
+		return existingPeering, nil
 	}
 	vnetID := azure.VNetID(s.SubscriptionID, s.RemoteResourceGroup, s.RemoteVnetName)
 	peeringProperties := network.VirtualNetworkPeeringPropertiesFormat{
@@ -65,8 +87,32 @@ func (s *VnetPeeringSpec) Parameters(ctx context.Context, existing interface{})
 			ID: pointer.String(vnetID),
 		},
 	}
+	s.setProperties(&peeringProperties)
 	return network.VirtualNetworkPeering{
 		Name:                                  pointer.String(s.PeeringName),
 		VirtualNetworkPeeringPropertiesFormat: &peeringProperties,
 	}, nil
 }
+
+// isUpToDate returns true if the properties specified for the peering match the properties of an existing peering.
+func (s *VnetPeeringSpec) isUpToDate(properties network.VirtualNetworkPeeringPropertiesFormat) bool {
+	matches := func(desired, actual *bool) bool {
+		return desired == nil || *desired == pointer.BoolDeref(actual, false)
+	}
+	return matches(s.AllowForwardedTraffic, properties.AllowForwardedTraffic) &&
+		matches(s.AllowGatewayTransit, properties.AllowGatewayTransit) &&
+		matches(s.UseRemoteGateways, properties.UseRemoteGateways)
+}
+
+// setProperties sets the properties specified for the peering, leaving the others unchanged.
+func (s *VnetPeeringSpec) setProperties(properties *network.VirtualNetworkPeeringPropertiesFormat) {
+	if s.AllowForwardedTraffic != nil {
+		properties.AllowForwardedTraffic = pointer.Bool(*s.AllowForwardedTraffic)
+	}
+	if s.AllowGatewayTransit != nil {
+		properties.AllowGatewayTransit = pointer.Bool(*s.AllowGatewayTransit)
+	}
+	if s.UseRemoteGateways != nil {
+		properties.UseRemoteGateways = pointer.Bool(*s.UseRemoteGateways)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vnetpeerings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var (
+	fakeGatewayPeeringSpec = VnetPeeringSpec{
+		PeeringName:         "vnet1-to-hub",
+		SourceVnetName:      "vnet1",
+		SourceResourceGroup: "group1",
+		RemoteVnetName:      "hub",
+		RemoteResourceGroup: "hub-group",
+		SubscriptionID:      "hub-sub",
+		UseRemoteGateways:   pointer.Bool(true),
+	}
+
+	fakeExistingPeering = network.VirtualNetworkPeering{
+		Name: pointer.String("vnet1-to-hub"),
+		ID:   pointer.String("/subscriptions/sub1/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/vnet1/virtualNetworkPeerings/vnet1-to-hub"),
+		VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+			RemoteVirtualNetwork:  &network.SubResource{ID: pointer.String("/subscriptions/hub-sub/resourceGroups/hub-group/providers/Microsoft.Network/virtualNetworks/hub")},
+			AllowForwardedTraffic: pointer.Bool(true),
+			UseRemoteGateways:     pointer.Bool(false),
+		},
+	}
+)
+
+func TestParameters(t *testing.T) {
+	testcases := []struct {
+		name          string
+		spec          *VnetPeeringSpec
+		existing      interface{}
+		expect        func(g *WithT, result interface{})
+		expectedError string
+	}{
+		{
+			name:     "peering in the subscription of the remote virtual network",
+			spec:     &fakeGatewayPeeringSpec,
+			existing: nil,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetworkPeering{
+					Name: pointer.String("vnet1-to-hub"),
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+						RemoteVirtualNetwork: &network.SubResource{ID: pointer.String("/subscriptions/hub-sub/resourceGroups/hub-group/providers/Microsoft.Network/virtualNetworks/hub")},
+						UseRemoteGateways:    pointer.Bool(true),
+					},
+				}))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing peering is up to date",
+			spec:     &fakePeering1To2,
+			existing: fakeExistingPeering,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing peering properties are updated, keeping the properties that are not specified",
+			spec:     &fakeGatewayPeeringSpec,
+			existing: fakeExistingPeering,
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(Equal(network.VirtualNetworkPeering{
+					Name: pointer.String("vnet1-to-hub"),
+					ID:   pointer.String("/subscriptions/sub1/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/vnet1/virtualNetworkPeerings/vnet1-to-hub"),
+					VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+						RemoteVirtualNetwork:  &network.SubResource{ID: pointer.String("/subscriptions/hub-sub/resourceGroups/hub-group/providers/Microsoft.Network/virtualNetworks/hub")},
+						AllowForwardedTraffic: pointer.Bool(true),
+						UseRemoteGateways:     pointer.Bool(true),
+					},
+				}))
+				// the existing peering is left untouched
+				g.Expect(fakeExistingPeering.UseRemoteGateways).To(Equal(pointer.Bool(false)))
+			},
+			expectedError: "",
+		},
+		{
+			name:     "existing is not a virtual network peering",
+			spec:     &fakeGatewayPeeringSpec,
+			existing: struct{}{},
+			expect: func(g *WithT, result interface{}) {
+				g.Expect(result).To(BeNil())
+			},
+			expectedError: "struct {} is not a network.VnetPeering",
+		},
+	}
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			t.Parallel()
+
+			result, err := tc.spec.Parameters(context.TODO(), tc.existing)
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			tc.expect(g, result)
+		})
+	}
+}
@@ -19,9 +19,12 @@ package vnetpeerings
 import (
 	"context"
 
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-azure/util/tele"
 )
@@ -34,12 +37,16 @@ type VnetPeeringScope interface {
 	azure.Authorizer
 	azure.AsyncStatusUpdater
 	VnetPeeringSpecs() []azure.ResourceSpecGetter
+	VnetPeeringAuthorizer(ctx context.Context, subscriptionID string, identityRef, auxiliaryIdentityRef *corev1.ObjectReference) (azure.Authorizer, error)
 }
 
 // Service provides operations on Azure resources.
 type Service struct {
 	Scope VnetPeeringScope
 	async.Reconciler
+	// newReconciler creates the reconciler of the peerings that are managed with other credentials than the ones of
+	// the scope, e.g. the peerings of remote virtual networks in another subscription or tenant.
+	newReconciler func(auth azure.Authorizer) async.Reconciler
 }
 
 // New creates a new service.
@@ -48,6 +55,10 @@ func New(scope VnetPeeringScope) *Service {
 	return &Service{
 		Scope:      scope,
 		Reconciler: async.New(scope, Client, Client),
+		newReconciler: func(auth azure.Authorizer) async.Reconciler {
+			client := NewClient(auth)
+			return async.New(scope, client, client)
+		},
 	}
 }
 
@@ -56,6 +67,13 @@ func (s *Service) Name() string {
 	return ServiceName
 }
 
+// Dependencies returns the names of the services that must be reconciled before this service.
+func (s *Service) Dependencies() []string {
+	return []string{
+		virtualnetworks.ServiceName,
+	}
+}
+
 // Reconcile idempotently creates or updates a peering.
 func (s *Service) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "vnetpeerings.Service.Reconcile")
@@ -69,20 +87,18 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of VnetPeeringSpecs to reconcile each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error creating) -> operationNotDoneError (i.e. creating in progress) -> no error (i.e. created)
-	var result error
-	for _, peeringSpec := range specs {
-		if _, err := s.CreateOrUpdateResource(ctx, peeringSpec, ServiceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
-			}
+	var errs []error
+	for _, batch := range s.batchesByCredentials(ctx, specs) {
+		err := batch.err
+		if err == nil {
+			_, err = batch.reconciler.CreateOrUpdateResources(ctx, batch.specs, ServiceName, "")
 		}
+		errs = append(errs, err)
 	}
 
-	s.Scope.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, result)
-	return result
+	err := async.AggregateErrors(errs)
+	s.Scope.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, err)
+	return err
 }
 
 // Delete deletes the peering with the provided name.
@@ -98,19 +114,65 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	// We go through the list of VnetPeeringSpecs to delete each one, independently of the result of the previous one.
-	// If multiple errors occur, we return the most pressing one.
-	//  Order of precedence (highest -> lowest) is: error that is not an operationNotDoneError (i.e. error deleting) -> operationNotDoneError (i.e. deleting in progress) -> no error (i.e. deleted)
-	var result error
-	for _, peeringSpec := range specs {
-		if err := s.DeleteResource(ctx, peeringSpec, ServiceName); err != nil {
-			if !azure.IsOperationNotDoneError(err) || result == nil {
-				result = err
+	var errs []error
+	for _, batch := range s.batchesByCredentials(ctx, specs) {
+		err := batch.err
+		if err == nil {
+			err = batch.reconciler.DeleteResources(ctx, batch.specs, ServiceName, "")
+		}
+		errs = append(errs, err)
+	}
+
+	err := async.AggregateErrors(errs)
+	s.Scope.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, err)
+	return err
+}
+
+// peeringBatch is a batch of peerings managed with the same credentials.
+type peeringBatch struct {
+	reconciler async.Reconciler
+	specs      []azure.ResourceSpecGetter
+	err        error
+}
+
+// batchesByCredentials groups the peering specs by the subscription and the identities they are managed with. The
+// peerings managed with the credentials of the scope are reconciled by the service's reconciler. The batches of the
+// other peerings, including the peerings with a virtual network whose identity may belong to another tenant, get a
+// reconciler of their own, or the error that prevented from creating it.
+func (s *Service) batchesByCredentials(ctx context.Context, specs []azure.ResourceSpecGetter) []*peeringBatch {
+	var batches []*peeringBatch
+	batchesByKey := make(map[string]*peeringBatch)
+	for _, spec := range specs {
+		var subscriptionID string
+		var identityRef, auxiliaryIdentityRef *corev1.ObjectReference
+		if peeringSpec, ok := spec.(*VnetPeeringSpec); ok {
+			subscriptionID, identityRef, auxiliaryIdentityRef = peeringSpec.SourceSubscriptionID, peeringSpec.IdentityRef, peeringSpec.AuxiliaryIdentityRef
+		}
+
+		key := subscriptionID
+		if identityRef != nil {
+			key += "/" + identityRef.Namespace + "/" + identityRef.Name
+		}
+		if auxiliaryIdentityRef != nil {
+			key += "/auxiliary/" + auxiliaryIdentityRef.Namespace + "/" + auxiliaryIdentityRef.Name
+		}
+		batch, ok := batchesByKey[key]
+		if !ok {
+			batch = &peeringBatch{reconciler: s.Reconciler}
+			if key != "" {
+				auth, err := s.Scope.VnetPeeringAuthorizer(ctx, subscriptionID, identityRef, auxiliaryIdentityRef)
+				if err != nil {
+					batch.err = errors.Wrap(err, "failed to get the credentials to manage virtual network peerings")
+				} else {
+					batch.reconciler = s.newReconciler(auth)
+				}
 			}
+			batchesByKey[key] = batch
+			batches = append(batches, batch)
 		}
+		batch.specs = append(batch.specs, spec)
 	}
-	s.Scope.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, result)
-	return result
+	return batches
 }
 
 // IsManaged returns always returns true as CAPZ does not support BYO VNet peering.
@@ -25,11 +25,16 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/async/mock_async"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings/mock_vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 var (
@@ -73,6 +78,35 @@ var (
 		RemoteResourceGroup: "group4",
 		SubscriptionID:      "sub1",
 	}
+	fakeRemotePeering2To1 = VnetPeeringSpec{
+		PeeringName:          "vnet2-to-vnet1",
+		SourceVnetName:       "vnet2",
+		SourceResourceGroup:  "group2",
+		SourceSubscriptionID: "sub2",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SubscriptionID:       "sub1",
+		IdentityRef:          &corev1.ObjectReference{Name: "remote-identity", Namespace: "default"},
+	}
+	fakeRemotePeering3To1 = VnetPeeringSpec{
+		PeeringName:          "vnet3-to-vnet1",
+		SourceVnetName:       "vnet3",
+		SourceResourceGroup:  "group3",
+		SourceSubscriptionID: "sub2",
+		RemoteVnetName:       "vnet1",
+		RemoteResourceGroup:  "group1",
+		SubscriptionID:       "sub1",
+		IdentityRef:          &corev1.ObjectReference{Name: "remote-identity", Namespace: "default"},
+	}
+	fakeCrossTenantPeering1To2 = VnetPeeringSpec{
+		PeeringName:          "vnet1-to-vnet2",
+		SourceVnetName:       "vnet1",
+		SourceResourceGroup:  "group1",
+		RemoteVnetName:       "vnet2",
+		RemoteResourceGroup:  "group2",
+		SubscriptionID:       "sub2",
+		AuxiliaryIdentityRef: &corev1.ObjectReference{Name: "remote-identity", Namespace: "default"},
+	}
 	fakePeeringSpecs      = []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}
 	fakePeeringExtraSpecs = []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeeringExtra}
 	internalError         = autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusInternalServerError}, "Internal Server Error")
@@ -90,7 +124,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:1])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2}, nil)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -106,8 +140,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:2])
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1}, nil)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -116,9 +149,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringExtraSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeeringExtra, ServiceName).Return(&fakePeeringExtra, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeeringExtra}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1, &fakePeeringExtra}, nil)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -127,10 +158,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(&fakePeering1To3, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, nil)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -139,10 +167,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1, nil, &fakePeering3To1}, internalError)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -151,10 +176,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1, nil, &fakePeering3To1}, internalError)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -163,10 +185,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil, internalError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, nil, nil, &fakePeering3To1}, internalError)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -175,10 +194,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil, internalError)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1, nil, nil}, internalError)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -187,10 +203,7 @@ func TestReconcileVnetPeerings(t *testing.T) {
 			expectedError: "operation type  on Azure resource / is not done",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(&fakePeering1To2, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(&fakePeering2To1, nil)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil, notDoneError)
-				r.CreateOrUpdateResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(&fakePeering3To1, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering2To1, nil, &fakePeering3To1}, notDoneError)
 				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, notDoneError)
 			},
 		},
@@ -225,6 +238,121 @@ func TestReconcileVnetPeerings(t *testing.T) {
 	}
 }
 
+func TestReconcileVnetPeeringsWithRemoteCredentials(t *testing.T) {
+	remoteSpecs := []azure.ResourceSpecGetter{&fakePeering1To2, &fakeRemotePeering2To1, &fakePeering1To3, &fakeRemotePeering3To1}
+	testcases := []struct {
+		name          string
+		expectedError string
+		expect        func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, remote *mock_async.MockReconcilerMockRecorder, auth azure.Authorizer)
+	}{
+		{
+			name:          "create the remote peerings with the credentials of their subscription and identity",
+			expectedError: "",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, remote *mock_async.MockReconcilerMockRecorder, auth azure.Authorizer) {
+				p.VnetPeeringSpecs().Return(remoteSpecs)
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "sub2", fakeRemotePeering2To1.IdentityRef, nil).Return(auth, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering1To3}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering1To3}, nil)
+				remote.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRemotePeering2To1, &fakeRemotePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakeRemotePeering2To1, &fakeRemotePeering3To1}, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "create the peering with a virtual network of another tenant with an auxiliary token of the tenant",
+			expectedError: "",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, remote *mock_async.MockReconcilerMockRecorder, auth azure.Authorizer) {
+				p.VnetPeeringSpecs().Return([]azure.ResourceSpecGetter{&fakePeering1To3, &fakeCrossTenantPeering1To2})
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "", nil, fakeCrossTenantPeering1To2.AuxiliaryIdentityRef).Return(auth, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To3}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To3}, nil)
+				remote.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeCrossTenantPeering1To2}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakeCrossTenantPeering1To2}, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
+			},
+		},
+		{
+			name:          "error in creating the remote peerings takes precedence over not done error",
+			expectedError: "#: Internal Server Error: StatusCode=500",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, remote *mock_async.MockReconcilerMockRecorder, auth azure.Authorizer) {
+				p.VnetPeeringSpecs().Return(remoteSpecs)
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "sub2", fakeRemotePeering2To1.IdentityRef, nil).Return(auth, nil)
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering1To3}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{nil, &fakePeering1To3}, notDoneError)
+				remote.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRemotePeering2To1, &fakeRemotePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{nil, &fakeRemotePeering3To1}, internalError)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
+			},
+		},
+		{
+			name:          "the local peerings are created when the remote credentials are not available",
+			expectedError: "failed to get the credentials to manage virtual network peerings: identity not found",
+			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r, remote *mock_async.MockReconcilerMockRecorder, auth azure.Authorizer) {
+				p.VnetPeeringSpecs().Return(remoteSpecs)
+				p.VnetPeeringAuthorizer(gomockinternal.AContext(), "sub2", fakeRemotePeering2To1.IdentityRef, nil).Return(nil, errors.New("identity not found"))
+				r.CreateOrUpdateResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering1To3}, ServiceName, clusterv1.ConditionType("")).Return([]interface{}{&fakePeering1To2, &fakePeering1To3}, nil)
+				p.UpdatePutStatus(infrav1.VnetPeeringReadyCondition, ServiceName, gomock.Any())
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			t.Parallel()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+			asyncMock := mock_async.NewMockReconciler(mockCtrl)
+			remoteAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+			remoteAuth := mock_azure.NewMockAuthorizer(mockCtrl)
+
+			tc.expect(scopeMock.EXPECT(), asyncMock.EXPECT(), remoteAsyncMock.EXPECT(), remoteAuth)
+
+			s := &Service{
+				Scope:      scopeMock,
+				Reconciler: asyncMock,
+				newReconciler: func(auth azure.Authorizer) async.Reconciler {
+					g.Expect(auth).To(Equal(remoteAuth))
+					return remoteAsyncMock
+				},
+			}
+
+			err := s.Reconcile(context.TODO())
+			if tc.expectedError != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestDeleteVnetPeeringsWithRemoteCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scopeMock := mock_vnetpeerings.NewMockVnetPeeringScope(mockCtrl)
+	asyncMock := mock_async.NewMockReconciler(mockCtrl)
+	remoteAsyncMock := mock_async.NewMockReconciler(mockCtrl)
+	remoteAuth := mock_azure.NewMockAuthorizer(mockCtrl)
+
+	scopeMock.EXPECT().VnetPeeringSpecs().Return([]azure.ResourceSpecGetter{&fakePeering1To2, &fakeRemotePeering2To1})
+	scopeMock.EXPECT().VnetPeeringAuthorizer(gomockinternal.AContext(), "sub2", fakeRemotePeering2To1.IdentityRef, nil).Return(remoteAuth, nil)
+	asyncMock.EXPECT().DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2}, ServiceName, clusterv1.ConditionType("")).Return(nil)
+	remoteAsyncMock.EXPECT().DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakeRemotePeering2To1}, ServiceName, clusterv1.ConditionType("")).Return(notDoneError)
+	scopeMock.EXPECT().UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, notDoneError)
+
+	s := &Service{
+		Scope:      scopeMock,
+		Reconciler: asyncMock,
+		newReconciler: func(auth azure.Authorizer) async.Reconciler {
+			return remoteAsyncMock
+		},
+	}
+
+	err := s.Delete(context.TODO())
+	g.Expect(err).To(MatchError(notDoneError))
+}
+
 func TestDeleteVnetPeerings(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -236,7 +364,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:1])
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2}, ServiceName, clusterv1.ConditionType("")).Return(nil)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -252,8 +380,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs[:2])
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1}, ServiceName, clusterv1.ConditionType("")).Return(nil)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -262,9 +389,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringExtraSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeeringExtra, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeeringExtra}, ServiceName, clusterv1.ConditionType("")).Return(nil)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -273,10 +398,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return(nil)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, nil)
 			},
 		},
@@ -285,10 +407,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(internalError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return(internalError)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -297,10 +416,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(internalError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(notDoneError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return(internalError)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -309,10 +425,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "#: Internal Server Error: StatusCode=500",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(notDoneError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(internalError)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return(internalError)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, internalError)
 			},
 		},
@@ -321,10 +434,7 @@ func TestDeleteVnetPeerings(t *testing.T) {
 			expectedError: "operation type  on Azure resource / is not done",
 			expect: func(p *mock_vnetpeerings.MockVnetPeeringScopeMockRecorder, r *mock_async.MockReconcilerMockRecorder) {
 				p.VnetPeeringSpecs().Return(fakePeeringSpecs)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To2, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering2To1, ServiceName).Return(nil)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering1To3, ServiceName).Return(notDoneError)
-				r.DeleteResource(gomockinternal.AContext(), &fakePeering3To1, ServiceName).Return(nil)
+				r.DeleteResources(gomockinternal.AContext(), []azure.ResourceSpecGetter{&fakePeering1To2, &fakePeering2To1, &fakePeering1To3, &fakePeering3To1}, ServiceName, clusterv1.ConditionType("")).Return(notDoneError)
 				p.UpdateDeleteStatus(infrav1.VnetPeeringReadyCondition, ServiceName, notDoneError)
 			},
 		},
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
@@ -46,17 +47,21 @@ const (
 
 // ScaleSetSpec defines the specification for a Scale Set.
 type ScaleSetSpec struct {
-	Name                         string
-	Size                         string
-	Capacity                     int64
-	SSHKeyData                   string
-	OSDisk                       infrav1.OSDisk
-	DataDisks                    []infrav1.DataDisk
-	SubnetName                   string
-	VNetName                     string
-	VNetResourceGroup            string
-	PublicLBName                 string
-	PublicLBAddressPoolName      string
+	Name                    string
+	Size                    string
+	Capacity                int64
+	SSHKeyData              string
+	OSDisk                  infrav1.OSDisk
+	DataDisks               []infrav1.DataDisk
+	SubnetName              string
+	VNetName                string
+	VNetResourceGroup       string
+	PublicLBName            string
+	PublicLBAddressPoolName string
+	// PublicLBIPv6AddressPoolName is the IPv6 backend pool of the public load balancer which the IPv6 IP
+	// configuration of the primary network interface joins to get IPv6 egress.
+	PublicLBIPv6AddressPoolName  string
+	IPv6Enabled                  bool
 	AcceleratedNetworking        *bool
 	TerminateNotificationTimeout *int
 	Identity                     infrav1.VMIdentity
@@ -69,6 +74,14 @@ type ScaleSetSpec struct {
 	VMExtensions                 []infrav1.VMExtension
 	NetworkInterfaces            []infrav1.NetworkInterface
 	OrchestrationMode            infrav1.OrchestrationModeType
+	// HostGroupID is the resource ID of the dedicated host group the scale set is placed in.
+	HostGroupID string
+	// HostGroupZone is the availability zone of the dedicated host group, when the group is managed by the cluster.
+	HostGroupZone string
+	// ProximityPlacementGroupID is the resource ID of the proximity placement group the scale set is placed in.
+	ProximityPlacementGroupID string
+	// CapacityReservationGroupID is the resource ID of the capacity reservation group the instances are allocated from.
+	CapacityReservationGroupID string
 }
 
 // TagsSpec defines the specification for a set of tags.
@@ -101,6 +114,7 @@ type (
 		AvailabilityZone   string                    `json:"availabilityZone,omitempty"`
 		State              infrav1.ProvisioningState `json:"vmState,omitempty"`
 		BootstrappingState infrav1.ProvisioningState `json:"bootstrappingState,omitempty"`
+		Addresses          []corev1.NodeAddress      `json:"addresses,omitempty"`
 	}
 
 	// VMSS defines a virtual machine scale set.
@@ -110,7 +110,9 @@ func (acr *AzureClusterReconciler) SetupWithManager(ctx context.Context, mgr ctr
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremachinetemplates;azuremachinetemplates/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureclusteridentities;azureclusteridentities/status,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azureipampools;azureipampools/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=list;
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
 // Reconcile idempotently gets, creates, and updates a cluster.
 func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -168,6 +170,24 @@ func (acr *AzureClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		acr.Recorder.Eventf(azureCluster, corev1.EventTypeWarning, "AzureClusterIdentity", deprecatedManagerCredsWarning)
 	}
 
+	// The identities of the remote sides of the virtual network peerings must also be allowed in the namespace.
+	var identityRefs []*corev1.ObjectReference
+	if azureCluster.Spec.IdentityRef != nil {
+		identityRefs = append(identityRefs, azureCluster.Spec.IdentityRef)
+	}
+	for _, peering := range azureCluster.Spec.NetworkSpec.Vnet.Peerings {
+		if peering.IdentityRef != nil {
+			if err := EnsureClusterIdentity(ctx, acr.Client, azureCluster, peering.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+				return reconcile.Result{}, err
+			}
+			identityRefs = append(identityRefs, peering.IdentityRef)
+		}
+	}
+	// Release the identities of the peerings removed from the cluster.
+	if err := RemoveUnusedClusterIdentityFinalizers(ctx, acr.Client, azureCluster, identityRefs, infrav1.ClusterFinalizer); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Create the scope.
 	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
 		Client:       acr.Client,
@@ -215,7 +235,14 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
 	}
 
-	if err := acs.Reconcile(ctx); err != nil {
+	err = acs.Reconcile(ctx)
+	recordDriftEvents(acr.Recorder, clusterScope.AzureCluster, clusterScope.Drifts())
+	if clusterScope.DryRun() {
+		if err := publishPlan(ctx, acr.Client, acr.Recorder, azureCluster, clusterScope.Plan()); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
 		// Handle terminal & transient errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
@@ -241,6 +268,12 @@ func (acr *AzureClusterReconciler) reconcileNormal(ctx context.Context, clusterS
 		return reconcile.Result{}, wrappedErr
 	}
 
+	if clusterScope.DryRun() {
+		// Nothing was created or updated in Azure, so the AzureCluster is not ready.
+		log.V(2).Info("AzureCluster reconciled in dry-run mode", "operations", len(clusterScope.Plan()))
+		return reconcile.Result{}, nil
+	}
+
 	// Set APIEndpoints so the Cluster API Cluster Controller can pull them
 	if azureCluster.Spec.ControlPlaneEndpoint.Host == "" {
 		azureCluster.Spec.ControlPlaneEndpoint.Host = clusterScope.APIServerHost()
@@ -269,7 +302,13 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create a new AzureClusterReconciler")
 	}
 
-	if err := acs.Delete(ctx); err != nil {
+	err = acs.Delete(ctx)
+	if clusterScope.DryRun() {
+		if err := publishPlan(ctx, acr.Client, acr.Recorder, azureCluster, clusterScope.Plan()); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
 		// Handle transient errors
 		var reconcileError azure.ReconcileError
 		if errors.As(err, &reconcileError) {
@@ -289,6 +328,12 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		return reconcile.Result{}, wrappedErr
 	}
 
+	if clusterScope.DryRun() {
+		// Nothing was deleted in Azure, so the finalizer is kept.
+		log.V(2).Info("AzureCluster delete reconciled in dry-run mode", "operations", len(clusterScope.Plan()))
+		return reconcile.Result{}, nil
+	}
+
 	// Cluster is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(azureCluster, infrav1.ClusterFinalizer)
 
@@ -300,5 +345,13 @@ func (acr *AzureClusterReconciler) reconcileDelete(ctx context.Context, clusterS
 		}
 	}
 
+	for _, peering := range azureCluster.Spec.NetworkSpec.Vnet.Peerings {
+		if peering.IdentityRef != nil {
+			if err := RemoveClusterIdentityFinalizer(ctx, acr.Client, azureCluster, peering.IdentityRef, infrav1.ClusterFinalizer); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	return reconcile.Result{}, nil
 }
@@ -22,12 +22,20 @@ import (
 	"github.com/pkg/errors"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/applicationsecuritygroups"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/bastionhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/dedicatedhosts"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/ipam"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatedns"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privateendpoints"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/privatelinkservices"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/proximityplacementgroups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicipprefixes"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/publicips"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/routetables"
@@ -40,12 +48,19 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// maxConcurrentServices is the maximum number of AzureCluster services that are reconciled or deleted in parallel.
+const maxConcurrentServices = 4
+
 // azureClusterService is the reconciler called by the AzureCluster controller.
 type azureClusterService struct {
 	scope *scope.ClusterScope
 	// services is the list of services that are reconciled by this controller.
-	// The order of the services is important as it determines the order in which the services are reconciled.
+	// Services that declare their dependencies are reconciled as soon as their dependencies are, the others are
+	// reconciled after the service that precedes them in the list.
 	services []azure.ServiceReconciler
+	// ipam allocates the address space of the virtual network before the services are reconciled, and releases it
+	// once they are deleted.
+	ipam     azure.Reconciler
 	skuCache *resourceskus.Cache
 }
 
@@ -60,23 +75,31 @@ func newAzureClusterService(scope *scope.ClusterScope) (*azureClusterService, er
 		services: []azure.ServiceReconciler{
 			groups.New(scope),
 			virtualnetworks.New(scope),
+			applicationsecuritygroups.New(scope),
 			securitygroups.New(scope),
 			routetables.New(scope),
+			publicipprefixes.New(scope),
 			publicips.New(scope),
 			natgateways.New(scope),
 			subnets.New(scope),
 			vnetpeerings.New(scope),
 			loadbalancers.New(scope),
+			privatelinkservices.New(scope),
 			privatedns.New(scope),
 			bastionhosts.New(scope),
+			flowlogs.New(scope),
+			diagnosticsettings.New(scope),
 			privateendpoints.New(scope),
+			dedicatedhosts.New(scope),
+			proximityplacementgroups.New(scope),
 			tags.New(scope),
 		},
+		ipam:     ipam.New(scope),
 		skuCache: skuCache,
 	}, nil
 }
 
-// Reconcile reconciles all the services in a predetermined order.
+// Reconcile reconciles all the services, running services that don't depend on each other in parallel.
 func (s *azureClusterService) Reconcile(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Reconcile")
 	defer done()
@@ -85,19 +108,27 @@ func (s *azureClusterService) Reconcile(ctx context.Context) error {
 		return errors.Wrap(err, "failed to get availability zones")
 	}
 
+	if err := s.ipam.Reconcile(ctx); err != nil {
+		return errors.Wrap(err, "failed to allocate the AzureCluster address space")
+	}
+
 	s.scope.SetDNSName()
 	s.scope.SetControlPlaneSecurityRules()
 
-	for _, service := range s.services {
+	graph, err := azure.NewServiceGraph(s.services, s.maxConcurrentServices())
+	if err != nil {
+		return errors.Wrap(err, "failed to order AzureCluster services")
+	}
+
+	return graph.Reconcile(ctx, func(ctx context.Context, service azure.ServiceReconciler) error {
 		if err := service.Reconcile(ctx); err != nil {
 			return errors.Wrapf(err, "failed to reconcile AzureCluster service %s", service.Name())
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
-// Delete reconciles all the services in a predetermined order.
+// Delete deletes all the services in the reverse order of their dependencies.
 func (s *azureClusterService) Delete(ctx context.Context) error {
 	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.azureClusterService.Delete")
 	defer done()
@@ -110,8 +141,8 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 	managed, err := groupSvc.IsManaged(ctx)
 	if err != nil {
 		if azure.ResourceNotFound(err) {
-			// If the resource group is not found, there is nothing to delete, return early.
-			return nil
+			// If the resource group is not found, there is nothing to delete but the IPAM allocations, return early.
+			return s.deleteIPAM(ctx)
 		}
 		return errors.Wrap(err, "failed to determine if the AzureCluster resource group is managed")
 	}
@@ -125,23 +156,63 @@ func (s *azureClusterService) Delete(ctx context.Context) error {
 		if err := vnetPeeringsSvc.Delete(ctx); err != nil {
 			return errors.Wrap(err, "failed to delete peerings")
 		}
+		// Flow logs live in the resource group of the network watcher, so they need to be explicitly deleted as well.
+		flowLogsSvc, err := s.getService(flowlogs.ServiceName)
+		if err != nil {
+			return errors.Wrap(err, "failed to get flow logs service")
+		}
+		if err := flowLogsSvc.Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete flow logs")
+		}
+		// Diagnostic settings outlive the resources they observe, so they need to be explicitly deleted as well.
+		diagnosticSettingsSvc, err := s.getService(diagnosticsettings.ServiceName)
+		if err != nil {
+			return errors.Wrap(err, "failed to get diagnostic settings service")
+		}
+		if err := diagnosticSettingsSvc.Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete diagnostic settings")
+		}
 		// Delete the entire resource group directly.
 		if err := groupSvc.Delete(ctx); err != nil {
 			return errors.Wrap(err, "failed to delete resource group")
 		}
 	} else {
 		// If the resource group is not managed we need to delete resources inside the group one by one.
-		// services are deleted in reverse order from the order in which they are reconciled.
-		for i := len(s.services) - 1; i >= 0; i-- {
-			if err := s.services[i].Delete(ctx); err != nil {
-				return errors.Wrapf(err, "failed to delete AzureCluster service %s", s.services[i].Name())
+		// A service is deleted only once all the services that depend on it have been deleted.
+		graph, err := azure.NewServiceGraph(s.services, s.maxConcurrentServices())
+		if err != nil {
+			return errors.Wrap(err, "failed to order AzureCluster services")
+		}
+		if err := graph.Delete(ctx, func(ctx context.Context, service azure.ServiceReconciler) error {
+			if err := service.Delete(ctx); err != nil {
+				return errors.Wrapf(err, "failed to delete AzureCluster service %s", service.Name())
 			}
+			return nil
+		}); err != nil {
+			return err
 		}
 	}
 
+	return s.deleteIPAM(ctx)
+}
+
+// deleteIPAM releases the address space allocated to the cluster, once its virtual network is deleted.
+func (s *azureClusterService) deleteIPAM(ctx context.Context) error {
+	if err := s.ipam.Delete(ctx); err != nil {
+		return errors.Wrap(err, "failed to release the AzureCluster address space")
+	}
 	return nil
 }
 
+// maxConcurrentServices returns the maximum number of services that are reconciled or deleted in parallel.
+// Services are run one at a time in dry-run mode so that the plan lists their operations in a stable order.
+func (s *azureClusterService) maxConcurrentServices() int {
+	if s.scope.DryRun() {
+		return 1
+	}
+	return maxConcurrentServices
+}
+
 func (s *azureClusterService) getService(name string) (azure.ServiceReconciler, error) {
 	for _, service := range s.services {
 		if service.Name() == name {
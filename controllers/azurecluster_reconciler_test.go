@@ -19,31 +19,44 @@ package controllers
 import (
 	"context"
 	"errors"
+	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/diagnosticsettings"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/flowlogs"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/groups"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/natgateways"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/subnets"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/services/virtualnetworks"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/vnetpeerings"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/cloudtest"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestAzureClusterServiceReconcile(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
-		expect        func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
+		expect        func(ipam *mock_azure.MockReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
 	}{
 		"all services are reconciled in order": {
 			expectedError: "",
-			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
+					ipam.Reconcile(gomockinternal.AContext()).Return(nil),
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(nil),
 					three.Reconcile(gomockinternal.AContext()).Return(nil))
@@ -51,13 +64,20 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 		},
 		"service reconcile fails": {
 			expectedError: "failed to reconcile AzureCluster service two: some error happened",
-			expect: func(one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
+					ipam.Reconcile(gomockinternal.AContext()).Return(nil),
 					one.Reconcile(gomockinternal.AContext()).Return(nil),
 					two.Reconcile(gomockinternal.AContext()).Return(errors.New("some error happened")),
 					two.Name().Return("two"))
 			},
 		},
+		"address space allocation fails": {
+			expectedError: "failed to allocate the AzureCluster address space: no free /16 address prefix left",
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				ipam.Reconcile(gomockinternal.AContext()).Return(errors.New("no free /16 address prefix left"))
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -68,11 +88,12 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 			t.Parallel()
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
+			ipamMock := mock_azure.NewMockReconciler(mockCtrl)
 			svcOneMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcTwoMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
-			tc.expect(svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			tc.expect(ipamMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
 
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
@@ -84,6 +105,7 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 					svcTwoMock,
 					svcThreeMock,
 				},
+				ipam:     ipamMock,
 				skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
 			}
 
@@ -101,23 +123,33 @@ func TestAzureClusterServiceReconcile(t *testing.T) {
 func TestAzureClusterServiceDelete(t *testing.T) {
 	cases := map[string]struct {
 		expectedError string
-		expect        func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
+		expect        func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder)
 	}{
 		"Resource Group is deleted successfully": {
 			expectedError: "",
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Name().Return(groups.ServiceName),
 					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
 					grp.Name().Return(groups.ServiceName),
 					vpr.Name().Return(vnetpeerings.ServiceName),
 					vpr.Delete(gomockinternal.AContext()).Return(nil),
-					grp.Delete(gomockinternal.AContext()).Return(nil))
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					flg.Name().Return(flowlogs.ServiceName),
+					flg.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					flg.Name().Return(flowlogs.ServiceName),
+					dgs.Name().Return(diagnosticsettings.ServiceName),
+					dgs.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil),
+					ipam.Delete(gomockinternal.AContext()).Return(nil))
 			},
 		},
 		"Error when checking if resource group is managed": {
 			expectedError: "failed to determine if the AzureCluster resource group is managed: an error happened",
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Name().Return(groups.ServiceName),
 					grp.IsManaged(gomockinternal.AContext()).Return(false, errors.New("an error happened")))
@@ -125,32 +157,44 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 		},
 		"Resource Group delete fails": {
 			expectedError: "failed to delete resource group: internal error",
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Name().Return(groups.ServiceName),
 					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
 					grp.Name().Return(groups.ServiceName),
 					vpr.Name().Return(vnetpeerings.ServiceName),
 					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					flg.Name().Return(flowlogs.ServiceName),
+					flg.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					flg.Name().Return(flowlogs.ServiceName),
+					dgs.Name().Return(diagnosticsettings.ServiceName),
+					dgs.Delete(gomockinternal.AContext()).Return(nil),
 					grp.Delete(gomockinternal.AContext()).Return(errors.New("internal error")))
 			},
 		},
 		"Resource Group not owned by cluster": {
 			expectedError: "",
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Name().Return(groups.ServiceName),
 					grp.IsManaged(gomockinternal.AContext()).Return(false, nil),
 					three.Delete(gomockinternal.AContext()).Return(nil),
 					two.Delete(gomockinternal.AContext()).Return(nil),
 					one.Delete(gomockinternal.AContext()).Return(nil),
+					dgs.Delete(gomockinternal.AContext()).Return(nil),
+					flg.Delete(gomockinternal.AContext()).Return(nil),
 					vpr.Delete(gomockinternal.AContext()).Return(nil),
-					grp.Delete(gomockinternal.AContext()).Return(nil))
+					grp.Delete(gomockinternal.AContext()).Return(nil),
+					ipam.Delete(gomockinternal.AContext()).Return(nil))
 			},
 		},
 		"service delete fails": {
 			expectedError: "failed to delete AzureCluster service two: some error happened",
-			expect: func(grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
 				gomock.InOrder(
 					grp.Name().Return(groups.ServiceName),
 					grp.IsManaged(gomockinternal.AContext()).Return(false, nil),
@@ -159,6 +203,37 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 					two.Name().Return("two"))
 			},
 		},
+		"Resource Group not found": {
+			expectedError: "",
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(false, autorest.NewErrorWithResponse("", "", &http.Response{StatusCode: http.StatusNotFound}, "Not found")),
+					ipam.Delete(gomockinternal.AContext()).Return(nil))
+			},
+		},
+		"address space release fails": {
+			expectedError: "failed to release the AzureCluster address space: pool was updated concurrently",
+			expect: func(ipam *mock_azure.MockReconcilerMockRecorder, grp *mock_azure.MockServiceReconcilerMockRecorder, vpr *mock_azure.MockServiceReconcilerMockRecorder, flg *mock_azure.MockServiceReconcilerMockRecorder, dgs *mock_azure.MockServiceReconcilerMockRecorder, one *mock_azure.MockServiceReconcilerMockRecorder, two *mock_azure.MockServiceReconcilerMockRecorder, three *mock_azure.MockServiceReconcilerMockRecorder) {
+				gomock.InOrder(
+					grp.Name().Return(groups.ServiceName),
+					grp.IsManaged(gomockinternal.AContext()).Return(true, nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					vpr.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					flg.Name().Return(flowlogs.ServiceName),
+					flg.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Name().Return(groups.ServiceName),
+					vpr.Name().Return(vnetpeerings.ServiceName),
+					flg.Name().Return(flowlogs.ServiceName),
+					dgs.Name().Return(diagnosticsettings.ServiceName),
+					dgs.Delete(gomockinternal.AContext()).Return(nil),
+					grp.Delete(gomockinternal.AContext()).Return(nil),
+					ipam.Delete(gomockinternal.AContext()).Return(errors.New("pool was updated concurrently")))
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -169,13 +244,16 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 			t.Parallel()
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
+			ipamMock := mock_azure.NewMockReconciler(mockCtrl)
 			groupsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			vnetpeeringsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			flowlogsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
+			diagnosticsettingsMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcOneMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcTwoMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 			svcThreeMock := mock_azure.NewMockServiceReconciler(mockCtrl)
 
-			tc.expect(groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
+			tc.expect(ipamMock.EXPECT(), groupsMock.EXPECT(), vnetpeeringsMock.EXPECT(), flowlogsMock.EXPECT(), diagnosticsettingsMock.EXPECT(), svcOneMock.EXPECT(), svcTwoMock.EXPECT(), svcThreeMock.EXPECT())
 
 			s := &azureClusterService{
 				scope: &scope.ClusterScope{
@@ -184,10 +262,13 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 				services: []azure.ServiceReconciler{
 					groupsMock,
 					vnetpeeringsMock,
+					flowlogsMock,
+					diagnosticsettingsMock,
 					svcOneMock,
 					svcTwoMock,
 					svcThreeMock,
 				},
+				ipam:     ipamMock,
 				skuCache: resourceskus.NewStaticCache([]compute.ResourceSku{}, ""),
 			}
 
@@ -201,3 +282,140 @@ func TestAzureClusterServiceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureClusterServiceAgainstFakeARM(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	arm := cloudtest.NewARMServer()
+	defer arm.Close()
+	clusterScope := newFakeARMClusterScope(g, arm, func(*infrav1.AzureCluster) {})
+
+	s, err := newAzureClusterService(clusterScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(s.Reconcile(ctx)).To(Succeed())
+	networkPrefix := "/subscriptions/123/resourceGroups/my-cluster/providers/Microsoft.Network/"
+	g.Expect(arm.ResourceIDs()).To(ContainElements(
+		"/subscriptions/123/resourcegroups/my-cluster",
+		networkPrefix+"virtualNetworks/my-cluster-vnet",
+		networkPrefix+"virtualNetworks/my-cluster-vnet/subnets/my-cluster-controlplane-subnet",
+		networkPrefix+"virtualNetworks/my-cluster-vnet/subnets/my-cluster-node-subnet",
+		networkPrefix+"networkSecurityGroups/my-cluster-controlplane-nsg",
+		networkPrefix+"loadBalancers/my-cluster-public-lb",
+		networkPrefix+"publicIPAddresses/pip-my-cluster-apiserver",
+	))
+	g.Expect(clusterScope.Vnet().ID).To(Equal(networkPrefix + "virtualNetworks/my-cluster-vnet"))
+	for _, subnet := range clusterScope.Subnets() {
+		g.Expect(subnet.ID).To(HavePrefix(networkPrefix + "virtualNetworks/my-cluster-vnet/subnets/"))
+	}
+
+	// A second reconcile finds every resource up to date.
+	requests := len(arm.Requests())
+	g.Expect(s.Reconcile(ctx)).To(Succeed())
+	g.Expect(arm.Requests()).To(HaveLen(requests))
+
+	g.Expect(s.Delete(ctx)).To(Succeed())
+	g.Expect(arm.ResourceIDs()).To(BeEmpty())
+}
+
+func TestAzureClusterServicesUpdateSubnetsConcurrently(t *testing.T) {
+	// The virtualnetworks, natgateways and subnets services all update the subnets of the AzureCluster. Reconcile them
+	// at the same time, repeatedly, so that updates that are not made atomically are caught by the race detector or
+	// result in a lost update.
+	for i := 0; i < 10; i++ {
+		g := NewWithT(t)
+		ctx := context.Background()
+
+		arm := cloudtest.NewARMServer()
+		clusterScope := newFakeARMClusterScope(g, arm, func(azureCluster *infrav1.AzureCluster) {
+			azureCluster.Spec.NetworkSpec.Subnets = infrav1.Subnets{
+				{SubnetClassSpec: infrav1.SubnetClassSpec{Name: "cp-subnet", Role: infrav1.SubnetControlPlane}},
+				{
+					SubnetClassSpec: infrav1.SubnetClassSpec{Name: "node-subnet", Role: infrav1.SubnetNode},
+					NatGateway:      infrav1.NatGateway{NatGatewayClassSpec: infrav1.NatGatewayClassSpec{Name: "node-natgw"}},
+				},
+			}
+		})
+		vnetID := "/subscriptions/123/resourceGroups/my-cluster/providers/Microsoft.Network/virtualNetworks/my-cluster-vnet"
+		arm.SetResource("/subscriptions/123/resourceGroups/my-cluster", map[string]interface{}{"location": "westus2"})
+		arm.SetResource(vnetID, map[string]interface{}{
+			"location": "westus2",
+			"tags":     map[string]interface{}{"sigs.k8s.io_cluster-api-provider-azure_cluster_my-cluster": "owned"},
+			"properties": map[string]interface{}{
+				"addressSpace": map[string]interface{}{"addressPrefixes": []interface{}{"10.0.0.0/8"}},
+				"subnets": []interface{}{
+					map[string]interface{}{"name": "cp-subnet", "properties": map[string]interface{}{"addressPrefix": "10.0.0.0/16"}},
+					map[string]interface{}{"name": "node-subnet", "properties": map[string]interface{}{"addressPrefix": "10.1.0.0/16"}},
+				},
+			},
+		})
+
+		services := []azure.ServiceReconciler{
+			virtualnetworks.New(clusterScope),
+			natgateways.New(clusterScope),
+			subnets.New(clusterScope),
+		}
+		errs := make([]error, len(services))
+		var wg sync.WaitGroup
+		for j := range services {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				errs[j] = services[j].Reconcile(ctx)
+			}(j)
+		}
+		wg.Wait()
+		arm.Close()
+
+		for _, err := range errs {
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+		g.Expect(clusterScope.Vnet().ID).To(Equal(vnetID))
+		cpSubnet := clusterScope.Subnet("cp-subnet")
+		g.Expect(cpSubnet.ID).To(Equal(vnetID + "/subnets/cp-subnet"))
+		g.Expect(cpSubnet.CIDRBlocks).To(Equal([]string{"10.0.0.0/16"}))
+		nodeSubnet := clusterScope.Subnet("node-subnet")
+		g.Expect(nodeSubnet.ID).To(Equal(vnetID + "/subnets/node-subnet"))
+		g.Expect(nodeSubnet.CIDRBlocks).To(Equal([]string{"10.1.0.0/16"}))
+		g.Expect(nodeSubnet.NatGateway.ID).To(Equal("/subscriptions/123/resourceGroups/my-cluster/providers/Microsoft.Network/natGateways/node-natgw"))
+	}
+}
+
+// newFakeARMClusterScope returns the scope of a defaulted AzureCluster whose Azure clients use the fake ARM server.
+// The AzureCluster can be customized before it is defaulted, and objs are added to the client of the scope.
+func newFakeARMClusterScope(g *WithT, arm *cloudtest.ARMServer, customize func(*infrav1.AzureCluster), objs ...client.Object) *scope.ClusterScope {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+				Location:       "westus2",
+			},
+		},
+	}
+	customize(azureCluster)
+	azureCluster.Default(nil)
+	fakeClient := fake.NewClientBuilder().WithScheme(setupScheme(g)).WithObjects(append(objs, cluster, azureCluster)...).Build()
+
+	clusterScope, err := scope.NewClusterScope(context.Background(), scope.ClusterScopeParams{
+		AzureClients: scope.AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Client:       fakeClient,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	clusterScope.ResourceManagerEndpoint = arm.URL()
+	return clusterScope
+}
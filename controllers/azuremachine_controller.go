@@ -298,7 +298,20 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 		return reconcile.Result{}, errors.Wrap(err, "failed to create azure machine service")
 	}
 
-	if err := ams.Reconcile(ctx); err != nil {
+	err = ams.Reconcile(ctx)
+	recordDriftEvents(amr.Recorder, machineScope.AzureMachine, machineScope.Drifts())
+	if err != nil {
+		// A Spot VM with the Delete eviction policy is removed by Azure when it is evicted.
+		if errors.As(err, &azure.VMDeletedError{}) && isSpotVMDeletedOnEviction(machineScope.AzureMachine) {
+			machineScope.SetVMState(infrav1.Deleted)
+			return amr.reconcileSpotEviction(ctx, machineScope, errors.Wrap(err, "Spot VM was most likely evicted"))
+		}
+
+		// The Spot VM was evicted by Azure, we mark it as failed and leave it to MHC for remediation.
+		if errors.As(err, &azure.VMEvictedError{}) {
+			return amr.reconcileSpotEviction(ctx, machineScope, err)
+		}
+
 		// This means that a VM was created and managed by this controller, but is not present anymore.
 		// In this case, we mark it as failed and leave it to MHC for remediation
 		if errors.As(err, &azure.VMDeletedError{}) {
@@ -340,6 +353,27 @@ func (amr *AzureMachineReconciler) reconcileNormal(ctx context.Context, machineS
 	return reconcile.Result{}, nil
 }
 
+// reconcileSpotEviction marks the AzureMachine of an evicted Spot VM as failed, which lets a MachineHealthCheck
+// remediate its Machine.
+func (amr *AzureMachineReconciler) reconcileSpotEviction(ctx context.Context, machineScope *scope.MachineScope, err error) (reconcile.Result, error) {
+	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileSpotEviction")
+	defer done()
+
+	log.Info("Spot VM has been evicted", "reason", err.Error())
+	amr.Recorder.Eventf(machineScope.AzureMachine, corev1.EventTypeWarning, infrav1.SpotEvictedReason, err.Error())
+	machineScope.SetFailureReason(infrav1.SpotEvictedMachineError)
+	machineScope.SetFailureMessage(err)
+	machineScope.SetNotReady()
+	machineScope.SetConditionFalse(infrav1.VMRunningCondition, infrav1.SpotEvictedReason, clusterv1.ConditionSeverityError, err.Error())
+	return reconcile.Result{}, nil
+}
+
+// isSpotVMDeletedOnEviction returns true if the AzureMachine uses a Spot VM that Azure deletes when it is evicted.
+func isSpotVMDeletedOnEviction(azureMachine *infrav1.AzureMachine) bool {
+	spotVMOptions := azureMachine.Spec.SpotVMOptions
+	return spotVMOptions != nil && spotVMOptions.EvictionPolicy != nil && *spotVMOptions.EvictionPolicy == infrav1.SpotEvictionPolicyDelete
+}
+
 func (amr *AzureMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	ctx, log, done := tele.StartSpanWithLogger(ctx, "controllers.AzureMachineReconciler.reconcileDelete")
 	defer done()
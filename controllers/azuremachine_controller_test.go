@@ -28,10 +28,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -193,6 +195,71 @@ func TestConditions(t *testing.T) {
 	}
 }
 
+func TestReconcileSpotEviction(t *testing.T) {
+	g := NewWithT(t)
+	scheme := setupScheme(g)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-cluster",
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		Spec: infrav1.AzureClusterSpec{
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				SubscriptionID: "123",
+			},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName: "my-cluster",
+			},
+			Name: "my-machine",
+		},
+	}
+	azureMachine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "azure-test1",
+		},
+		Spec: infrav1.AzureMachineSpec{
+			SpotVMOptions: &infrav1.SpotVMOptions{},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, machine, azureCluster, azureMachine).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	reconciler := NewAzureMachineReconciler(client, recorder, reconciler.DefaultLoopTimeout, "")
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		AzureClients: scope.AzureClients{
+			Authorizer: autorest.NullAuthorizer{},
+		},
+		Client:       client,
+		Cluster:      cluster,
+		AzureCluster: azureCluster,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       client,
+		ClusterScope: clusterScope,
+		Machine:      machine,
+		AzureMachine: azureMachine,
+		Cache:        &scope.MachineCache{},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = reconciler.reconcileSpotEviction(context.TODO(), machineScope, azure.VMEvictedError{ProviderID: "azure:///my-vm"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(machineScope.AzureMachine.Status.FailureReason).To(HaveValue(Equal(infrav1.SpotEvictedMachineError)))
+	g.Expect(machineScope.AzureMachine.Status.Ready).To(BeFalse())
+	g.Expect(conditions.GetReason(machineScope.AzureMachine, infrav1.VMRunningCondition)).To(Equal(infrav1.SpotEvictedReason))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.SpotEvictedReason)))
+}
+
 func conditionsMatch(i, j clusterv1.Condition) bool {
 	return i.Type == j.Type &&
 		i.Status == j.Status &&
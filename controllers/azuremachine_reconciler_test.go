@@ -19,17 +19,23 @@ package controllers
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2021-11-01/compute"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/mock_azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/services/resourceskus"
 	gomockinternal "sigs.k8s.io/cluster-api-provider-azure/internal/test/matchers/gomock"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/cloudtest"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -170,3 +176,148 @@ func TestAzureMachineServiceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestAzureMachineServiceAgainstFakeARM(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	arm := cloudtest.NewARMServer()
+	defer arm.Close()
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-machine",
+			Namespace: "default",
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName:           "my-cluster",
+				clusterv1.MachineDeploymentLabelName: "md-0",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "my-cluster",
+			Bootstrap:   clusterv1.Bootstrap{DataSecretName: pointer.String("my-machine-bootstrap")},
+		},
+	}
+	azureMachine := &infrav1.AzureMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-machine",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureMachineSpec{
+			VMSize: "Standard_D2s_v3",
+			Image: &infrav1.Image{
+				Marketplace: &infrav1.AzureMarketplaceImage{
+					ImagePlan: infrav1.ImagePlan{Publisher: "cncf-upstream", Offer: "capi", SKU: "ubuntu-2004-gen1"},
+					Version:   "latest",
+				},
+			},
+			OSDisk: infrav1.OSDisk{
+				OSType:      azure.LinuxOS,
+				DiskSizeGB:  pointer.Int32(128),
+				ManagedDisk: &infrav1.ManagedDiskParameters{StorageAccountType: "Premium_LRS"},
+				CachingType: "None",
+			},
+			DataDisks: []infrav1.DataDisk{
+				{NameSuffix: "etcddisk", DiskSizeGB: 256, Lun: pointer.Int32(0), CachingType: "ReadWrite"},
+			},
+			SSHPublicKey: "c3NoLXJzYSBBQUFBQjNOemFDMXljMkVBQUFBREFRQUJBQUFCQVFDeQ==",
+		},
+	}
+	bootstrapSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-machine-bootstrap",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{"value": []byte("#cloud-config")},
+	}
+	// The resource SKUs cache is shared by every scope with the same location and credentials, use a location that no
+	// other test uses so that the SKUs are listed from this fake ARM server.
+	clusterScope := newFakeARMClusterScope(g, arm, func(azureCluster *infrav1.AzureCluster) {
+		azureCluster.Spec.Location = "westeurope"
+	}, machine, azureMachine, bootstrapSecret)
+
+	arm.SetResource("/subscriptions/123/resourceGroups/my-cluster", map[string]interface{}{"location": "westeurope"})
+	skusPrefix := "/subscriptions/123/providers/Microsoft.Compute/skus/"
+	arm.SetResource(skusPrefix+"Standard_D2s_v3", map[string]interface{}{
+		"resourceType": "virtualMachines",
+		"locations":    []interface{}{"westeurope"},
+		"capabilities": []interface{}{
+			map[string]interface{}{"name": "vCPUs", "value": "2"},
+			map[string]interface{}{"name": "MemoryGB", "value": "8"},
+		},
+	})
+	arm.SetResource(skusPrefix+"Aligned", map[string]interface{}{
+		"resourceType": "availabilitySets",
+		"locations":    []interface{}{"westeurope"},
+		"capabilities": []interface{}{
+			map[string]interface{}{"name": "MaximumPlatformFaultDomainCount", "value": "3"},
+		},
+	})
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:       clusterScope.Client,
+		ClusterScope: clusterScope,
+		Machine:      machine,
+		AzureMachine: azureMachine,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(machineScope.InitMachineCache(ctx)).To(Succeed())
+
+	s, err := newAzureMachineService(machineScope)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	computePrefix := "/subscriptions/123/resourceGroups/my-cluster/providers/Microsoft.Compute/"
+	nicID := "/subscriptions/123/resourceGroups/my-cluster/providers/Microsoft.Network/networkInterfaces/my-machine-nic"
+	vmID := computePrefix + "virtualMachines/my-machine"
+	// The availability set of the MachineDeployment was created along with another of its machines.
+	asID := computePrefix + "availabilitySets/my-cluster_md-0-as"
+	arm.SetResource(asID, map[string]interface{}{
+		"location":   "westeurope",
+		"sku":        map[string]interface{}{"name": "Aligned"},
+		"properties": map[string]interface{}{"platformFaultDomainCount": 3},
+	})
+
+	// A conflict with an operation started outside of CAPZ fails the reconcile until the operation is over.
+	arm.InjectError(http.MethodPut, vmID, http.StatusConflict, "AnotherOperationInProgress")
+	g.Expect(s.Reconcile(ctx)).To(MatchError(ContainSubstring("AnotherOperationInProgress")))
+	_, ok := arm.GetResource(nicID)
+	g.Expect(ok).To(BeTrue())
+	_, ok = arm.GetResource(vmID)
+	g.Expect(ok).To(BeFalse())
+
+	// Long-running operations that don't complete within the call timeout are tracked in the status of the
+	// AzureMachine and polled by subsequent reconciles.
+	arm.SetLongRunning(1, 3*time.Second)
+	err = s.Reconcile(ctx)
+	g.Expect(azure.IsOperationNotDoneError(err)).To(BeTrue(), "expected an operation not done error, got %v", err)
+	g.Expect(azureMachine.Status.LongRunningOperationStates).NotTo(BeEmpty())
+	g.Eventually(func() error { return s.Reconcile(ctx) }, 10).Should(Succeed())
+	g.Expect(azureMachine.Status.LongRunningOperationStates).To(BeEmpty())
+
+	g.Expect(arm.ResourceIDs()).To(ContainElements(
+		nicID,
+		asID,
+		vmID,
+	))
+	vm, ok := arm.GetResource(vmID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(vm).To(HaveKeyWithValue("properties", HaveKeyWithValue("storageProfile", HaveKeyWithValue("dataDisks", ConsistOf(
+		HaveKeyWithValue("name", "my-machine_etcddisk"),
+	)))))
+	g.Expect(machineScope.ProviderID()).To(Equal("azure://" + vmID))
+
+	// A second reconcile finds every resource up to date.
+	arm.SetLongRunning(-1, 0)
+	requests := len(arm.Requests())
+	g.Expect(s.Reconcile(ctx)).To(Succeed())
+	g.Expect(arm.Requests()).To(HaveLen(requests))
+
+	// Azure creates the OS and data disks along with the VM, and CAPZ deletes them along with it.
+	osDiskID := computePrefix + "disks/my-machine_OSDisk"
+	dataDiskID := computePrefix + "disks/my-machine_etcddisk"
+	arm.SetResource(osDiskID, map[string]interface{}{"location": "westeurope"})
+	arm.SetResource(dataDiskID, map[string]interface{}{"location": "westeurope"})
+
+	g.Expect(s.Delete(ctx)).To(Succeed())
+	g.Expect(arm.ResourceIDs()).NotTo(ContainElements(nicID, vmID, osDiskID, dataDiskID))
+}
@@ -111,6 +111,7 @@ func (amcpr *AzureManagedControlPlaneReconciler) SetupWithManager(ctx context.Co
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedcontrolplanes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=azuremanagedcontrolplanes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
 // Reconcile idempotently gets, creates, and updates a managed control plane.
 func (amcpr *AzureManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -233,7 +234,13 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 		return reconcile.Result{}, err
 	}
 
-	if err := newAzureManagedControlPlaneReconciler(scope).Reconcile(ctx); err != nil {
+	err := newAzureManagedControlPlaneReconciler(scope).Reconcile(ctx)
+	if scope.DryRun() {
+		if err := publishPlan(ctx, amcpr.Client, amcpr.Recorder, scope.ControlPlane, scope.Plan()); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
 		// Handle transient and terminal errors
 		log := log.WithValues("name", scope.ControlPlane.Name, "namespace", scope.ControlPlane.Namespace)
 		var reconcileError azure.ReconcileError
@@ -254,6 +261,12 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileNormal(ctx context.Con
 		return reconcile.Result{}, errors.Wrapf(err, "error creating AzureManagedControlPlane %s/%s", scope.ControlPlane.Namespace, scope.ControlPlane.Name)
 	}
 
+	if scope.DryRun() {
+		// Nothing was created or updated in Azure, so the AzureManagedControlPlane is not ready.
+		log.Info("Successfully reconciled in dry-run mode", "operations", len(scope.Plan()))
+		return reconcile.Result{}, nil
+	}
+
 	// No errors, so mark us ready so the Cluster API Cluster Controller can pull it
 	scope.ControlPlane.Status.Ready = true
 	scope.ControlPlane.Status.Initialized = true
@@ -269,10 +282,22 @@ func (amcpr *AzureManagedControlPlaneReconciler) reconcileDelete(ctx context.Con
 
 	log.Info("Reconciling AzureManagedControlPlane delete")
 
-	if err := newAzureManagedControlPlaneReconciler(scope).Delete(ctx); err != nil {
+	err := newAzureManagedControlPlaneReconciler(scope).Delete(ctx)
+	if scope.DryRun() {
+		if err := publishPlan(ctx, amcpr.Client, amcpr.Recorder, scope.ControlPlane, scope.Plan()); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "error deleting AzureManagedControlPlane %s/%s", scope.ControlPlane.Namespace, scope.ControlPlane.Name)
 	}
 
+	if scope.DryRun() {
+		// Nothing was deleted in Azure, so the finalizer is kept.
+		log.Info("Successfully reconciled delete in dry-run mode", "operations", len(scope.Plan()))
+		return reconcile.Result{}, nil
+	}
+
 	// Cluster is deleted so remove the finalizer.
 	controllerutil.RemoveFinalizer(scope.ControlPlane, infrav1.ManagedClusterFinalizer)
 
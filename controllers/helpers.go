@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -33,6 +34,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
@@ -603,6 +606,51 @@ func ShouldDeleteIndividualResources(ctx context.Context, clusterScope *scope.Cl
 	return err != nil || !managed
 }
 
+// recordDriftEvents emits a warning event on obj for every Azure resource that drifted from its desired state.
+func recordDriftEvents(recorder record.EventRecorder, obj runtime.Object, drifts []azure.ResourceDrift) {
+	for _, drift := range drifts {
+		recorder.Event(obj, corev1.EventTypeWarning, string(infrav1.DriftDetectedCondition), drift.String())
+	}
+}
+
+// PlanConfigMapDataKey is the key of the JSON plan in the ConfigMap published by the controllers in dry-run mode.
+const PlanConfigMapDataKey = "plan.json"
+
+// PlanConfigMapName returns the name of the ConfigMap holding the dry-run plan of the object with the given name.
+func PlanConfigMapName(name string) string {
+	return name + "-plan"
+}
+
+// publishPlan writes the operations planned while reconciling owner in dry-run mode to a ConfigMap owned by owner,
+// and emits an event pointing to it.
+func publishPlan(ctx context.Context, c client.Client, recorder record.EventRecorder, owner client.Object, plan azure.Plan) error {
+	ctx, _, done := tele.StartSpanWithLogger(ctx, "controllers.publishPlan")
+	defer done()
+
+	data, err := plan.JSON()
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PlanConfigMapName(owner.GetName()),
+			Namespace: owner.GetNamespace(),
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c, configMap, func() error {
+		configMap.Data = map[string]string{
+			PlanConfigMapDataKey: string(data),
+		}
+		return controllerutil.SetOwnerReference(owner, configMap, c.Scheme())
+	}); err != nil {
+		return errors.Wrapf(err, "failed to publish the dry-run plan to ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+	}
+
+	recorder.Eventf(owner, corev1.EventTypeNormal, "DryRunPlanned", "planned %d Azure operation(s), see ConfigMap %s", len(plan), configMap.Name)
+	return nil
+}
+
 // GetClusterIdentityFromRef returns the AzureClusterIdentity referenced by the AzureCluster.
 func GetClusterIdentityFromRef(ctx context.Context, c client.Client, azureClusterNamespace string, ref *corev1.ObjectReference) (*infrav1.AzureClusterIdentity, error) {
 	identity := &infrav1.AzureClusterIdentity{}
@@ -679,6 +727,79 @@ func RemoveClusterIdentityFinalizer(ctx context.Context, c client.Client, object
 	return nil
 }
 
+// RemoveUnusedClusterIdentityFinalizers removes the finalizer on the AzureClusterIdentities which are no longer
+// referenced by identityRefs, e.g. the identity of a virtual network peering that was removed from the cluster.
+// The identities referenced on the previous reconcile are tracked in the IdentitiesLastAppliedAnnotation of the object,
+// so only the identities dropped since then are fetched.
+func RemoveUnusedClusterIdentityFinalizers(ctx context.Context, c client.Client, object client.Object, identityRefs []*corev1.ObjectReference, finalizerPrefix string) error {
+	name := object.GetName()
+	namespace := object.GetNamespace()
+	finalizer := clusterIdentityFinalizer(finalizerPrefix, namespace, name)
+
+	used := sets.NewString()
+	for _, ref := range identityRefs {
+		key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+		if key.Namespace == "" {
+			key.Namespace = namespace
+		}
+		used.Insert(key.String())
+	}
+
+	var lastApplied []string
+	if value, ok := object.GetAnnotations()[azure.IdentitiesLastAppliedAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &lastApplied); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal the %s annotation", azure.IdentitiesLastAppliedAnnotation)
+		}
+	}
+
+	for _, previous := range lastApplied {
+		if used.Has(previous) {
+			continue
+		}
+		identity := &infrav1.AzureClusterIdentity{}
+		identityNamespace, identityName, _ := strings.Cut(previous, string(types.Separator))
+		if err := c.Get(ctx, client.ObjectKey{Namespace: identityNamespace, Name: identityName}, identity); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to get AzureClusterIdentity %s", previous)
+		}
+		if !controllerutil.ContainsFinalizer(identity, finalizer) {
+			continue
+		}
+		identityHelper, err := patch.NewHelper(identity, c)
+		if err != nil {
+			return errors.Wrap(err, "failed to init patch helper")
+		}
+		controllerutil.RemoveFinalizer(identity, finalizer)
+		if err := identityHelper.Patch(ctx, identity); err != nil {
+			return errors.Wrap(err, "failed to patch AzureClusterIdentity")
+		}
+	}
+
+	if used.Equal(sets.NewString(lastApplied...)) {
+		return nil
+	}
+	value, err := json.Marshal(used.List())
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the %s annotation", azure.IdentitiesLastAppliedAnnotation)
+	}
+	before, ok := object.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.Errorf("expected a client.Object, got %T", object)
+	}
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[azure.IdentitiesLastAppliedAnnotation] = string(value)
+	object.SetAnnotations(annotations)
+	if err := c.Patch(ctx, object, client.MergeFrom(before)); err != nil {
+		return errors.Wrapf(err, "failed to patch the %s annotation", azure.IdentitiesLastAppliedAnnotation)
+	}
+	return nil
+}
+
 // MachinePoolToInfrastructureMapFunc returns a handler.MapFunc that watches for
 // MachinePool events and returns reconciliation requests for an infrastructure provider object.
 func MachinePoolToInfrastructureMapFunc(gvk schema.GroupVersionKind, log logr.Logger) handler.MapFunc {
@@ -35,9 +35,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure"
 	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/internal/test/mock_log"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -99,9 +101,9 @@ func TestGetCloudProviderConfig(t *testing.T) {
 
 	cluster := newCluster("foo")
 	azureCluster := newAzureCluster("bar")
-	azureCluster.Default()
+	azureCluster.Default(nil)
 	azureClusterCustomVnet := newAzureClusterWithCustomVnet("bar")
-	azureClusterCustomVnet.Default()
+	azureClusterCustomVnet.Default(nil)
 
 	cases := map[string]struct {
 		cluster                    *clusterv1.Cluster
@@ -280,7 +282,7 @@ func TestReconcileAzureSecret(t *testing.T) {
 	cluster := newCluster("foo")
 	azureCluster := newAzureCluster("bar")
 
-	azureCluster.Default()
+	azureCluster.Default(nil)
 	cluster.Name = "testCluster"
 
 	scheme := setupScheme(g)
@@ -1310,3 +1312,88 @@ func Test_ManagedMachinePoolToInfrastructureMapFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishPlan(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := setupScheme(g)
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+			UID:       "1234",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(azureCluster).Build()
+	recorder := record.NewFakeRecorder(10)
+
+	plan := azure.Plan{
+		{ServiceName: "groups", Method: azure.PutOperation, ResourceName: "my-rg", Parameters: map[string]string{"location": "eastus"}},
+		{ServiceName: "virtualnetworks", Method: azure.DeleteOperation, ResourceGroup: "my-rg", ResourceName: "my-vnet"},
+	}
+	g.Expect(publishPlan(context.TODO(), fakeClient, recorder, azureCluster, plan)).To(Succeed())
+	// Publishing again updates the existing ConfigMap.
+	g.Expect(publishPlan(context.TODO(), fakeClient, recorder, azureCluster, plan[:1])).To(Succeed())
+
+	configMap := &corev1.ConfigMap{}
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "my-cluster-plan"}, configMap)).To(Succeed())
+	g.Expect(configMap.OwnerReferences).To(HaveLen(1))
+	g.Expect(configMap.OwnerReferences[0].Name).To(Equal("my-cluster"))
+	g.Expect(configMap.OwnerReferences[0].Kind).To(Equal("AzureCluster"))
+	g.Expect(configMap.Data[PlanConfigMapDataKey]).To(MatchJSON(`[{"service": "groups", "method": "PUT", "resourceName": "my-rg", "parameters": {"location": "eastus"}}]`))
+	g.Expect(recorder.Events).To(Receive(Equal("Normal DryRunPlanned planned 2 Azure operation(s), see ConfigMap my-cluster-plan")))
+	g.Expect(recorder.Events).To(Receive(Equal("Normal DryRunPlanned planned 1 Azure operation(s), see ConfigMap my-cluster-plan")))
+}
+
+func TestRemoveUnusedClusterIdentityFinalizers(t *testing.T) {
+	g := NewWithT(t)
+	scheme := setupScheme(g)
+
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+			Annotations: map[string]string{
+				azure.IdentitiesLastAppliedAnnotation: `["default/cluster-identity","hub/deleted-hub-identity","hub/hub-identity","hub/removed-hub-identity"]`,
+			},
+		},
+	}
+	finalizer := clusterIdentityFinalizer(infrav1.ClusterFinalizer, "default", "my-cluster")
+	otherFinalizer := clusterIdentityFinalizer(infrav1.ClusterFinalizer, "default", "other-cluster")
+	newIdentity := func(name, namespace string, finalizers ...string) *infrav1.AzureClusterIdentity {
+		return &infrav1.AzureClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Finalizers: finalizers},
+		}
+	}
+	initObjects := []runtime.Object{
+		azureCluster,
+		newIdentity("cluster-identity", "default", finalizer),
+		newIdentity("hub-identity", "hub", finalizer),
+		newIdentity("removed-hub-identity", "hub", finalizer, otherFinalizer),
+		newIdentity("other-identity", "default", otherFinalizer),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(initObjects...).Build()
+
+	identityRefs := []*corev1.ObjectReference{
+		{Name: "cluster-identity"},
+		{Name: "hub-identity", Namespace: "hub"},
+	}
+	g.Expect(RemoveUnusedClusterIdentityFinalizers(context.TODO(), fakeClient, azureCluster, identityRefs, infrav1.ClusterFinalizer)).To(Succeed())
+
+	// The identities no longer referenced are dropped from the annotation.
+	updated := &infrav1.AzureCluster{}
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(azureCluster), updated)).To(Succeed())
+	g.Expect(updated.Annotations[azure.IdentitiesLastAppliedAnnotation]).To(MatchJSON(`["default/cluster-identity","hub/hub-identity"]`))
+
+	expectedFinalizers := map[client.ObjectKey][]string{
+		{Name: "cluster-identity", Namespace: "default"}: {finalizer},
+		{Name: "hub-identity", Namespace: "hub"}:         {finalizer},
+		{Name: "removed-hub-identity", Namespace: "hub"}: {otherFinalizer},
+		{Name: "other-identity", Namespace: "default"}:   {otherFinalizer},
+	}
+	for key, finalizers := range expectedFinalizers {
+		identity := &infrav1.AzureClusterIdentity{}
+		g.Expect(fakeClient.Get(context.TODO(), key, identity)).To(Succeed())
+		g.Expect(identity.Finalizers).To(Equal(finalizers), "finalizers of %s", key)
+	}
+}
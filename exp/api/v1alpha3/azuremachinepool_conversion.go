@@ -93,6 +93,8 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+
 	if len(restored.Spec.Template.VMExtensions) > 0 {
 		dst.Spec.Template.VMExtensions = restored.Spec.Template.VMExtensions
 	}
@@ -105,9 +107,31 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Diagnostics = restored.Spec.Template.Diagnostics
 	}
 
+	if restored.Spec.Template.SecurityProfile != nil && dst.Spec.Template.SecurityProfile != nil {
+		dst.Spec.Template.SecurityProfile.SecurityType = restored.Spec.Template.SecurityProfile.SecurityType
+		dst.Spec.Template.SecurityProfile.UefiSettings = restored.Spec.Template.SecurityProfile.UefiSettings
+	}
+
+	if restored.Spec.Template.OSDisk.ManagedDisk != nil && dst.Spec.Template.OSDisk.ManagedDisk != nil {
+		dst.Spec.Template.OSDisk.ManagedDisk.SecurityProfile = restored.Spec.Template.OSDisk.ManagedDisk.SecurityProfile
+	}
+
+	for i, disk := range restored.Spec.Template.DataDisks {
+		if i < len(dst.Spec.Template.DataDisks) && disk.ManagedDisk != nil && dst.Spec.Template.DataDisks[i].ManagedDisk != nil {
+			dst.Spec.Template.DataDisks[i].ManagedDisk.SecurityProfile = disk.ManagedDisk.SecurityProfile
+		}
+	}
+
 	// Restore orchestration mode
 	dst.Spec.OrchestrationMode = restored.Spec.OrchestrationMode
 
+	// Restore the dedicated host group.
+	dst.Spec.HostGroupID = restored.Spec.HostGroupID
+
+	// Restore the proximity placement group and capacity reservation group placement.
+	dst.Spec.ProximityPlacementGroupID = restored.Spec.ProximityPlacementGroupID
+	dst.Spec.CapacityReservationGroupID = restored.Spec.CapacityReservationGroupID
+
 	if restored.Spec.SystemAssignedIdentityRole != nil {
 		dst.Spec.SystemAssignedIdentityRole = restored.Spec.SystemAssignedIdentityRole
 	}
@@ -34,6 +34,26 @@ func Convert_v1beta1_OSDisk_To_v1alpha3_OSDisk(in *infrav1.OSDisk, out *infrav1a
 	return infrav1alpha3.Convert_v1beta1_OSDisk_To_v1alpha3_OSDisk(in, out, s)
 }
 
+// Convert_v1alpha3_DataDisk_To_v1beta1_DataDisk is a conversion function.
+func Convert_v1alpha3_DataDisk_To_v1beta1_DataDisk(in *infrav1alpha3.DataDisk, out *infrav1.DataDisk, s conversion.Scope) error {
+	return infrav1alpha3.Convert_v1alpha3_DataDisk_To_v1beta1_DataDisk(in, out, s)
+}
+
+// Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk is a conversion function.
+func Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in *infrav1.DataDisk, out *infrav1alpha3.DataDisk, s conversion.Scope) error {
+	return infrav1alpha3.Convert_v1beta1_DataDisk_To_v1alpha3_DataDisk(in, out, s)
+}
+
+// Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile is a conversion function.
+func Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(in *infrav1alpha3.SecurityProfile, out *infrav1.SecurityProfile, s conversion.Scope) error {
+	return infrav1alpha3.Convert_v1alpha3_SecurityProfile_To_v1beta1_SecurityProfile(in, out, s)
+}
+
+// Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile is a conversion function.
+func Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in *infrav1.SecurityProfile, out *infrav1alpha3.SecurityProfile, s conversion.Scope) error {
+	return infrav1alpha3.Convert_v1beta1_SecurityProfile_To_v1alpha3_SecurityProfile(in, out, s)
+}
+
 // Convert_v1alpha3_Image_To_v1beta1_Image is a conversion function.
 func Convert_v1alpha3_Image_To_v1beta1_Image(in *infrav1alpha3.Image, out *infrav1.Image, s conversion.Scope) error {
 	return infrav1alpha3.Convert_v1alpha3_Image_To_v1beta1_Image(in, out, s)
@@ -65,6 +65,21 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Diagnostics = restored.Spec.Template.Diagnostics
 	}
 
+	if restored.Spec.Template.SecurityProfile != nil && dst.Spec.Template.SecurityProfile != nil {
+		dst.Spec.Template.SecurityProfile.SecurityType = restored.Spec.Template.SecurityProfile.SecurityType
+		dst.Spec.Template.SecurityProfile.UefiSettings = restored.Spec.Template.SecurityProfile.UefiSettings
+	}
+
+	if restored.Spec.Template.OSDisk.ManagedDisk != nil && dst.Spec.Template.OSDisk.ManagedDisk != nil {
+		dst.Spec.Template.OSDisk.ManagedDisk.SecurityProfile = restored.Spec.Template.OSDisk.ManagedDisk.SecurityProfile
+	}
+
+	for i, disk := range restored.Spec.Template.DataDisks {
+		if i < len(dst.Spec.Template.DataDisks) && disk.ManagedDisk != nil && dst.Spec.Template.DataDisks[i].ManagedDisk != nil {
+			dst.Spec.Template.DataDisks[i].ManagedDisk.SecurityProfile = disk.ManagedDisk.SecurityProfile
+		}
+	}
+
 	for i, r := range restored.Status.LongRunningOperationStates {
 		if r.Name == dst.Status.LongRunningOperationStates[i].Name {
 			dst.Status.LongRunningOperationStates[i].ServiceName = r.ServiceName
@@ -74,10 +89,20 @@ func (src *AzureMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 	// Restore orchestration mode
 	dst.Spec.OrchestrationMode = restored.Spec.OrchestrationMode
 
+	// Restore the dedicated host group.
+	dst.Spec.HostGroupID = restored.Spec.HostGroupID
+
+	// Restore the proximity placement group and capacity reservation group placement.
+	dst.Spec.ProximityPlacementGroupID = restored.Spec.ProximityPlacementGroupID
+	dst.Spec.CapacityReservationGroupID = restored.Spec.CapacityReservationGroupID
+
 	if restored.Spec.SystemAssignedIdentityRole != nil {
 		dst.Spec.SystemAssignedIdentityRole = restored.Spec.SystemAssignedIdentityRole
 	}
 
+	// Restore the history of Azure operations.
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+
 	return nil
 }
 
@@ -129,3 +154,8 @@ func Convert_v1alpha4_SpotVMOptions_To_v1beta1_SpotVMOptions(in *infrav1alpha4.S
 	out.MaxPrice = (*resource.Quantity)(unsafe.Pointer(in.MaxPrice))
 	return nil
 }
+
+// Convert_v1beta1_AzureMachinePoolStatus_To_v1alpha4_AzureMachinePoolStatus is an autogenerated conversion function.
+func Convert_v1beta1_AzureMachinePoolStatus_To_v1alpha4_AzureMachinePoolStatus(in *infrav1exp.AzureMachinePoolStatus, out *AzureMachinePoolStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolStatus_To_v1alpha4_AzureMachinePoolStatus(in, out, s)
+}
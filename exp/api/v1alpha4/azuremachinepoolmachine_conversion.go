@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha4
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1beta1"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
@@ -33,6 +34,8 @@ func (src *AzureMachinePoolMachine) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Spec = restored.Spec
+	dst.Status.OperationHistory = restored.Status.OperationHistory
+	dst.Status.Addresses = restored.Status.Addresses
 
 	return nil
 }
@@ -60,3 +63,8 @@ func (dst *AzureMachinePoolMachineList) ConvertFrom(srcRaw conversion.Hub) error
 	src := srcRaw.(*infrav1exp.AzureMachinePoolMachineList)
 	return Convert_v1beta1_AzureMachinePoolMachineList_To_v1alpha4_AzureMachinePoolMachineList(src, dst, nil)
 }
+
+// Convert_v1beta1_AzureMachinePoolMachineStatus_To_v1alpha4_AzureMachinePoolMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_AzureMachinePoolMachineStatus_To_v1alpha4_AzureMachinePoolMachineStatus(in *infrav1exp.AzureMachinePoolMachineStatus, out *AzureMachinePoolMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AzureMachinePoolMachineStatus_To_v1alpha4_AzureMachinePoolMachineStatus(in, out, s)
+}
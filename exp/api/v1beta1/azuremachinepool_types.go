@@ -164,6 +164,21 @@ type (
 		// OrchestrationMode specifies the orchestration mode for the Virtual Machine Scale Set
 		// +kubebuilder:default=Uniform
 		OrchestrationMode infrav1.OrchestrationModeType `json:"orchestrationMode,omitempty"`
+
+		// HostGroupID is the Azure resource ID of the dedicated host group the Virtual Machine Scale Set is placed in.
+		// Azure chooses the dedicated hosts of the instances, which requires automatic placement to be enabled on the group.
+		// +optional
+		HostGroupID string `json:"hostGroupID,omitempty"`
+
+		// ProximityPlacementGroupID is the Azure resource ID of the proximity placement group the Virtual Machine Scale
+		// Set is placed in.
+		// +optional
+		ProximityPlacementGroupID string `json:"proximityPlacementGroupID,omitempty"`
+
+		// CapacityReservationGroupID is the Azure resource ID of the capacity reservation group the instances of the
+		// Virtual Machine Scale Set are allocated from.
+		// +optional
+		CapacityReservationGroupID string `json:"capacityReservationGroupID,omitempty"`
 	}
 
 	// AzureMachinePoolDeploymentStrategyType is the type of deployment strategy employed to rollout a new version of
@@ -306,6 +321,11 @@ type (
 		// next reconciliation loop.
 		// +optional
 		LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
+
+		// OperationHistory records the most recent Azure operations that created, updated or deleted resources,
+		// with their timing and result. At most MaxOperationHistory operations are kept.
+		// +optional
+		OperationHistory infrav1.Operations `json:"operationHistory,omitempty"`
 	}
 
 	// AzureMachinePoolInstanceStatus provides status information for each instance in the VMSS.
@@ -388,6 +408,16 @@ func (amp *AzureMachinePool) SetFutures(futures infrav1.Futures) {
 	amp.Status.LongRunningOperationStates = futures
 }
 
+// GetOperationHistory returns the history of the Azure operations of an AzureMachinePool API object.
+func (amp *AzureMachinePool) GetOperationHistory() infrav1.Operations {
+	return amp.Status.OperationHistory
+}
+
+// SetOperationHistory will set the given history of Azure operations on an AzureMachinePool object.
+func (amp *AzureMachinePool) SetOperationHistory(operations infrav1.Operations) {
+	amp.Status.OperationHistory = operations
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureMachinePool{}, &AzureMachinePoolList{})
 }
@@ -30,6 +30,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/util/azure"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -87,6 +88,10 @@ func (amp *AzureMachinePool) Validate(old runtime.Object, client client.Client)
 		amp.ValidateSystemAssignedIdentity(old),
 		amp.ValidateSystemAssignedIdentityRole,
 		amp.ValidateNetwork,
+		amp.ValidateSecurityProfile,
+		amp.ValidateVMSizeCapabilities(old, client),
+		amp.ValidateDedicatedHost(old, client),
+		amp.ValidatePlacementGroups(old),
 	}
 
 	var errs []error
@@ -107,6 +112,120 @@ func (amp *AzureMachinePool) ValidateNetwork() error {
 	return nil
 }
 
+// ValidateSecurityProfile of an AzureMachinePool.
+func (amp *AzureMachinePool) ValidateSecurityProfile() error {
+	if errs := infrav1.ValidateSecurityProfile(amp.Spec.Template.SecurityProfile, amp.Spec.Template.OSDisk, field.NewPath("securityProfile"), field.NewPath("osDisk")); len(errs) > 0 {
+		return kerrors.NewAggregate(errs.ToAggregate().Errors())
+	}
+
+	return nil
+}
+
+// ValidateVMSizeCapabilities validates the security profile of an AzureMachinePool against the capabilities of its VM
+// size when it is created or when its VM size changes.
+func (amp *AzureMachinePool) ValidateVMSizeCapabilities(old runtime.Object, c client.Client) func() error {
+	return func() error {
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if oldMachinePool.Spec.Template.VMSize == amp.Spec.Template.VMSize &&
+				reflect.DeepEqual(oldMachinePool.Spec.Template.SecurityProfile, amp.Spec.Template.SecurityProfile) {
+				return nil
+			}
+		}
+
+		if errs := infrav1.ValidateVMSizeCapabilities(c, amp.Namespace, amp.Labels[clusterv1.ClusterLabelName],
+			amp.Spec.Template.VMSize, amp.Spec.Template.SecurityProfile, field.NewPath("securityProfile")); len(errs) > 0 {
+			return kerrors.NewAggregate(errs.ToAggregate().Errors())
+		}
+
+		return nil
+	}
+}
+
+// ValidateDedicatedHost validates the dedicated host group of an AzureMachinePool, which cannot change once the
+// Virtual Machine Scale Set is created, and which must be in the failure domains of the MachinePool when it is zonal.
+func (amp *AzureMachinePool) ValidateDedicatedHost(old runtime.Object, c client.Client) func() error {
+	return func() error {
+		fldPath := field.NewPath("hostGroupID")
+		errs := infrav1.ValidateDedicatedHost(amp.Spec.HostGroupID, "", amp.Spec.Template.SpotVMOptions, fldPath, nil)
+		if old == nil {
+			errs = append(errs, amp.validateDedicatedHostGroupZone(c, fldPath)...)
+		} else {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if oldMachinePool.Spec.HostGroupID != amp.Spec.HostGroupID {
+				errs = append(errs, field.Invalid(fldPath, amp.Spec.HostGroupID, "field is immutable"))
+			}
+		}
+		if len(errs) > 0 {
+			return kerrors.NewAggregate(errs.ToAggregate().Errors())
+		}
+
+		return nil
+	}
+}
+
+// validateDedicatedHostGroupZone validates that the failure domains of the MachinePool of an AzureMachinePool placed in
+// a dedicated host group managed by the cluster match the availability zone of the group.
+func (amp *AzureMachinePool) validateDedicatedHostGroupZone(c client.Client, fldPath *field.Path) field.ErrorList {
+	zone := infrav1.GetDedicatedHostGroupZone(c, amp.Namespace, amp.Labels[clusterv1.ClusterLabelName], amp.Spec.HostGroupID)
+	if zone == "" {
+		return nil
+	}
+
+	parent, err := azure.FindParentMachinePool(amp.Name, c)
+	if err != nil {
+		// The MachinePool may be created after the AzureMachinePool, the zone is validated again when the scale set
+		// is created.
+		return nil
+	}
+
+	for _, failureDomain := range parent.Spec.FailureDomains {
+		if failureDomain != zone {
+			return field.ErrorList{field.Invalid(fldPath, amp.Spec.HostGroupID,
+				fmt.Sprintf("the availability zone %s of the dedicated host group does not match the failure domain %s of the MachinePool", zone, failureDomain))}
+		}
+	}
+
+	return nil
+}
+
+// ValidatePlacementGroups validates the proximity placement group and the capacity reservation group of an
+// AzureMachinePool, which cannot change once the Virtual Machine Scale Set is created.
+func (amp *AzureMachinePool) ValidatePlacementGroups(old runtime.Object) func() error {
+	return func() error {
+		ppgPath := field.NewPath("proximityPlacementGroupID")
+		crgPath := field.NewPath("capacityReservationGroupID")
+		errs := infrav1.ValidatePlacementGroups(amp.Spec.ProximityPlacementGroupID, amp.Spec.CapacityReservationGroupID,
+			amp.Spec.HostGroupID != "", amp.Spec.Template.SpotVMOptions, ppgPath, crgPath)
+		if old != nil {
+			oldMachinePool, ok := old.(*AzureMachinePool)
+			if !ok {
+				return fmt.Errorf("unexpected type for old azure machine pool object. Expected: %q, Got: %q",
+					"AzureMachinePool", reflect.TypeOf(old))
+			}
+			if oldMachinePool.Spec.ProximityPlacementGroupID != amp.Spec.ProximityPlacementGroupID {
+				errs = append(errs, field.Invalid(ppgPath, amp.Spec.ProximityPlacementGroupID, "field is immutable"))
+			}
+			if oldMachinePool.Spec.CapacityReservationGroupID != amp.Spec.CapacityReservationGroupID {
+				errs = append(errs, field.Invalid(crgPath, amp.Spec.CapacityReservationGroupID, "field is immutable"))
+			}
+		}
+		if len(errs) > 0 {
+			return kerrors.NewAggregate(errs.ToAggregate().Errors())
+		}
+
+		return nil
+	}
+}
+
 // ValidateImage of an AzureMachinePool.
 func (amp *AzureMachinePool) ValidateImage() error {
 	if amp.Spec.Template.Image != nil {
@@ -31,6 +31,7 @@ import (
 	"golang.org/x/crypto/ssh"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -41,10 +42,14 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	capifeature "sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
 	validSSHPublicKey = generateSSHPublicKey(true)
+	validHostGroupID  = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+	validPPGID        = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/proximityPlacementGroups/my-ppg"
+	validCRGID        = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/capacityReservationGroups/my-crg"
 	zero              = intstr.FromInt(0)
 	one               = intstr.FromInt(1)
 )
@@ -90,6 +95,31 @@ func TestAzureMachinePool_ValidateCreate(t *testing.T) {
 			amp:     getKnownValidAzureMachinePool(),
 			wantErr: false,
 		},
+		{
+			name:    "azuremachinepool with dedicated host group",
+			amp:     createMachinePoolWithHostGroupID(validHostGroupID),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with invalid dedicated host group ID",
+			amp:     createMachinePoolWithHostGroupID(validHostGroupID + "/hosts/my-host"),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with proximity placement group",
+			amp:     createMachinePoolWithPlacementGroups(validPPGID, ""),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with capacity reservation group",
+			amp:     createMachinePoolWithPlacementGroups("", validCRGID),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with capacity reservation group and proximity placement group",
+			amp:     createMachinePoolWithPlacementGroups(validPPGID, validCRGID),
+			wantErr: true,
+		},
 		{
 			name:    "azuremachinepool with marketplace image - full",
 			amp:     createMachinePoolWithMarketPlaceImage("PUB1234", "OFFER1234", "SKU1234", "1.0.0", pointer.Int(10)),
@@ -326,6 +356,36 @@ func TestAzureMachinePool_ValidateUpdate(t *testing.T) {
 			amp:     createMachinePoolWithSystemAssignedIdentity(string(uuid.NewUUID())),
 			wantErr: true,
 		},
+		{
+			name:    "azuremachinepool with dedicated host group unchanged",
+			oldAMP:  createMachinePoolWithHostGroupID(validHostGroupID),
+			amp:     createMachinePoolWithHostGroupID(validHostGroupID),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with dedicated host group changed",
+			oldAMP:  createMachinePoolWithHostGroupID(""),
+			amp:     createMachinePoolWithHostGroupID(validHostGroupID),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with placement groups unchanged",
+			oldAMP:  createMachinePoolWithPlacementGroups(validPPGID, ""),
+			amp:     createMachinePoolWithPlacementGroups(validPPGID, ""),
+			wantErr: false,
+		},
+		{
+			name:    "azuremachinepool with proximity placement group changed",
+			oldAMP:  createMachinePoolWithPlacementGroups(validPPGID, ""),
+			amp:     createMachinePoolWithPlacementGroups("", ""),
+			wantErr: true,
+		},
+		{
+			name:    "azuremachinepool with capacity reservation group changed",
+			oldAMP:  createMachinePoolWithPlacementGroups("", ""),
+			amp:     createMachinePoolWithPlacementGroups("", validCRGID),
+			wantErr: true,
+		},
 		{
 			name:   "azuremachinepool with invalid MaxSurge and MaxUnavailable rolling upgrade configuration",
 			oldAMP: createMachinePoolWithStrategy(AzureMachinePoolDeploymentStrategy{}),
@@ -547,6 +607,23 @@ func createMachinePoolWithSystemAssignedIdentity(role string) *AzureMachinePool
 	}
 }
 
+func createMachinePoolWithHostGroupID(hostGroupID string) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			HostGroupID: hostGroupID,
+		},
+	}
+}
+
+func createMachinePoolWithPlacementGroups(proximityPlacementGroupID, capacityReservationGroupID string) *AzureMachinePool {
+	return &AzureMachinePool{
+		Spec: AzureMachinePoolSpec{
+			ProximityPlacementGroupID:  proximityPlacementGroupID,
+			CapacityReservationGroupID: capacityReservationGroupID,
+		},
+	}
+}
+
 func createMachinePoolWithDiagnostics(diagnosticsType infrav1.BootDiagnosticsStorageAccountType, userManaged *infrav1.UserManagedBootDiagnostics) *AzureMachinePool {
 	var diagnostics *infrav1.Diagnostics
 
@@ -613,6 +690,176 @@ func createMachinePoolWithOrchestrationMode(mode compute.OrchestrationMode) *Azu
 	}
 }
 
+type fakeVMSizeCapabilitiesGetter struct {
+	capabilities map[string]infrav1.VMSizeCapabilities
+}
+
+func (f fakeVMSizeCapabilitiesGetter) GetVMSizeCapabilities(_ context.Context, _, _, vmSize string) (infrav1.VMSizeCapabilities, error) {
+	capabilities, ok := f.capabilities[vmSize]
+	if !ok {
+		return infrav1.VMSizeCapabilities{}, errors.Errorf("unknown VM size %s", vmSize)
+	}
+	return capabilities, nil
+}
+
+func TestAzureMachinePool_ValidateVMSizeCapabilities(t *testing.T) {
+	g := NewWithT(t)
+
+	cli := infrav1.ClientWithVMSizeCapabilities{
+		VMSizeCapabilitiesGetter: fakeVMSizeCapabilitiesGetter{
+			capabilities: map[string]infrav1.VMSizeCapabilities{
+				"Standard_D2s_v3": {TrustedLaunch: true},
+				"Standard_A2_v2":  {},
+			},
+		},
+	}
+
+	withVMSize := func(vmSize string, securityType infrav1.SecurityTypes) *AzureMachinePool {
+		amp := getKnownValidAzureMachinePool()
+		amp.Labels = map[string]string{clusterv1.ClusterLabelName: "my-cluster"}
+		amp.Spec.Template.VMSize = vmSize
+		amp.Spec.Template.SecurityProfile = &infrav1.SecurityProfile{SecurityType: securityType}
+		return amp
+	}
+
+	tests := []struct {
+		name    string
+		oldAMP  *AzureMachinePool
+		amp     *AzureMachinePool
+		wantErr bool
+	}{
+		{
+			name: "create with trusted launch supported by the VM size",
+			amp:  withVMSize("Standard_D2s_v3", infrav1.SecurityTypesTrustedLaunch),
+		},
+		{
+			name:    "create with trusted launch not supported by the VM size",
+			amp:     withVMSize("Standard_A2_v2", infrav1.SecurityTypesTrustedLaunch),
+			wantErr: true,
+		},
+		{
+			name:    "create with confidential VM not supported by the VM size",
+			amp:     withVMSize("Standard_D2s_v3", infrav1.SecurityTypesConfidentialVM),
+			wantErr: true,
+		},
+		{
+			name:    "update to a VM size that does not support trusted launch",
+			oldAMP:  withVMSize("Standard_D2s_v3", infrav1.SecurityTypesTrustedLaunch),
+			amp:     withVMSize("Standard_A2_v2", infrav1.SecurityTypesTrustedLaunch),
+			wantErr: true,
+		},
+		{
+			name: "create with capabilities of the VM size that cannot be determined",
+			amp:  withVMSize("Standard_Unknown", infrav1.SecurityTypesConfidentialVM),
+		},
+		{
+			name:   "update without changing the VM size or the security profile",
+			oldAMP: withVMSize("Standard_A2_v2", infrav1.SecurityTypesTrustedLaunch),
+			amp:    withVMSize("Standard_A2_v2", infrav1.SecurityTypesTrustedLaunch),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var old runtime.Object
+			if tc.oldAMP != nil {
+				old = tc.oldAMP
+			}
+			err := tc.amp.ValidateVMSizeCapabilities(old, cli)()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestAzureMachinePool_ValidateDedicatedHostGroupZone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = expv1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{Kind: "AzureCluster", Name: "my-cluster-abcde"},
+		},
+	}
+	azureCluster := &infrav1.AzureCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster-abcde",
+			Namespace: "default",
+		},
+		Spec: infrav1.AzureClusterSpec{
+			ResourceGroup: "my-rg",
+			AzureClusterClassSpec: infrav1.AzureClusterClassSpec{
+				DedicatedHostGroups: []infrav1.DedicatedHostGroup{
+					{Name: "my-host-group", FailureDomain: pointer.String("1"), AutomaticPlacement: true},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		failureDomains []string
+		wantErr        bool
+	}{
+		{
+			name:           "failure domains matching the zone of the host group",
+			failureDomains: []string{"1"},
+		},
+		{
+			name:           "failure domains not matching the zone of the host group",
+			failureDomains: []string{"1", "2"},
+			wantErr:        true,
+		},
+		{
+			name: "no failure domains",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machinePool := &expv1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-machine-pool",
+					Namespace: "default",
+				},
+				Spec: expv1.MachinePoolSpec{
+					ClusterName:    "my-cluster",
+					FailureDomains: tc.failureDomains,
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							InfrastructureRef: corev1.ObjectReference{Name: "my-amp"},
+						},
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cluster, azureCluster, machinePool).Build()
+
+			amp := getKnownValidAzureMachinePool()
+			amp.ObjectMeta = metav1.ObjectMeta{
+				Name:      "my-amp",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterLabelName: "my-cluster"},
+			}
+			amp.Spec.HostGroupID = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/hostGroups/my-host-group"
+			err := amp.ValidateDedicatedHost(nil, fakeClient)()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestAzureMachinePool_ValidateCreateFailure(t *testing.T) {
 	g := NewWithT(t)
 
@@ -59,6 +59,10 @@ type (
 		// +optional
 		InstanceName string `json:"instanceName"`
 
+		// Addresses contains the addresses of the network interfaces of the Azure virtual machine instance.
+		// +optional
+		Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
+
 		// FailureReason will be set in the event that there is a terminal problem
 		// reconciling the MachinePool machine and will contain a succinct value suitable
 		// for machine interpretation.
@@ -88,6 +92,11 @@ type (
 		// +optional
 		LongRunningOperationStates infrav1.Futures `json:"longRunningOperationStates,omitempty"`
 
+		// OperationHistory records the most recent Azure operations that created, updated or deleted resources,
+		// with their timing and result. At most MaxOperationHistory operations are kept.
+		// +optional
+		OperationHistory infrav1.Operations `json:"operationHistory,omitempty"`
+
 		// LatestModelApplied indicates the instance is running the most up-to-date VMSS model. A VMSS model describes
 		// the image version the VM is running. If the instance is not running the latest model, it means the instance
 		// may not be running the version of Kubernetes the Machine Pool has specified and needs to be updated.
@@ -149,6 +158,16 @@ func (ampm *AzureMachinePoolMachine) SetFutures(futures infrav1.Futures) {
 	ampm.Status.LongRunningOperationStates = futures
 }
 
+// GetOperationHistory returns the history of the Azure operations of an AzureMachinePoolMachine API object.
+func (ampm *AzureMachinePoolMachine) GetOperationHistory() infrav1.Operations {
+	return ampm.Status.OperationHistory
+}
+
+// SetOperationHistory will set the given history of Azure operations on an AzureMachinePoolMachine object.
+func (ampm *AzureMachinePoolMachine) SetOperationHistory(operations infrav1.Operations) {
+	ampm.Status.OperationHistory = operations
+}
+
 func init() {
 	SchemeBuilder.Register(&AzureMachinePoolMachine{}, &AzureMachinePoolMachineList{})
 }
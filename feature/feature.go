@@ -42,6 +42,10 @@ const (
 	// owner: @nojnhuh
 	// alpha: v1.7
 	AKSResourceHealth featuregate.Feature = "AKSResourceHealth"
+
+	// IPv6Only is the feature gate for the experimental IPv6-only network mode of AzureClusters.
+	// alpha: v1.8
+	IPv6Only featuregate.Feature = "IPv6Only"
 )
 
 func init() {
@@ -54,4 +58,5 @@ var defaultCAPZFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
 	AKS:               {Default: true, PreRelease: featuregate.GA, LockToDefault: true}, // Remove in 1.12
 	AKSResourceHealth: {Default: false, PreRelease: featuregate.Alpha},
+	IPv6Only:          {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -39,6 +39,7 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha3"
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-azure/api/v1alpha4"
 	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-azure/azure/scope"
 	"sigs.k8s.io/cluster-api-provider-azure/controllers"
 	infrav1alpha3exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha3"
 	infrav1alpha4exp "sigs.k8s.io/cluster-api-provider-azure/exp/api/v1alpha4"
@@ -47,6 +48,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-azure/feature"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/coalescing"
 	"sigs.k8s.io/cluster-api-provider-azure/pkg/ot"
+	"sigs.k8s.io/cluster-api-provider-azure/pkg/ratelimit"
 	"sigs.k8s.io/cluster-api-provider-azure/util/reconciler"
 	webhookutils "sigs.k8s.io/cluster-api-provider-azure/util/webhook"
 	"sigs.k8s.io/cluster-api-provider-azure/version"
@@ -115,6 +117,7 @@ var (
 	webhookPort                        int
 	reconcileTimeout                   time.Duration
 	enableTracing                      bool
+	azureAPIRateLimit                  = ratelimit.DefaultConfig
 )
 
 // InitFlags initializes all command-line flags.
@@ -241,6 +244,30 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Enable tracing to the opentelemetry-collector service in the same namespace.",
 	)
 
+	fs.Float64Var(&azureAPIRateLimit.ReadQPS,
+		"azure-api-read-qps",
+		ratelimit.DefaultConfig.ReadQPS,
+		"The maximum sustained rate of Azure API read requests per subscription",
+	)
+
+	fs.IntVar(&azureAPIRateLimit.ReadBurst,
+		"azure-api-read-burst",
+		ratelimit.DefaultConfig.ReadBurst,
+		"The maximum burst of Azure API read requests per subscription",
+	)
+
+	fs.Float64Var(&azureAPIRateLimit.WriteQPS,
+		"azure-api-write-qps",
+		ratelimit.DefaultConfig.WriteQPS,
+		"The maximum sustained rate of Azure API write requests per subscription",
+	)
+
+	fs.IntVar(&azureAPIRateLimit.WriteBurst,
+		"azure-api-write-burst",
+		ratelimit.DefaultConfig.WriteBurst,
+		"The maximum burst of Azure API write requests per subscription",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
 
@@ -317,6 +344,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	ratelimit.SetDefault(azureAPIRateLimit)
+
 	registerControllers(ctx, mgr)
 
 	registerWebhooks(mgr)
@@ -478,11 +507,6 @@ func registerControllers(ctx context.Context, mgr manager.Manager) {
 }
 
 func registerWebhooks(mgr manager.Manager) {
-	if err := (&infrav1.AzureCluster{}).SetupWebhookWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "AzureCluster")
-		os.Exit(1)
-	}
-
 	if err := (&infrav1.AzureClusterTemplate{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AzureClusterTemplate")
 		os.Exit(1)
@@ -510,18 +534,30 @@ func registerWebhooks(mgr manager.Manager) {
 		os.Exit(1)
 	}
 
+	// The AzureMachine and AzureMachinePool webhooks validate the security profiles against the capabilities of the VM sizes.
+	vmSizeCapabilitiesClient := infrav1.ClientWithVMSizeCapabilities{
+		Client:                   mgr.GetClient(),
+		VMSizeCapabilitiesGetter: scope.NewVMSizeCapabilitiesGetter(mgr.GetClient()),
+	}
+
 	hookServer := mgr.GetWebhookServer()
+	hookServer.Register("/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster", webhookutils.NewMutatingWebhook(
+		&infrav1.AzureCluster{}, mgr.GetClient(),
+	))
+	hookServer.Register("/validate-infrastructure-cluster-x-k8s-io-v1beta1-azurecluster", webhookutils.NewValidatingWebhook(
+		&infrav1.AzureCluster{}, mgr.GetClient(),
+	))
 	hookServer.Register("/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachinepool", webhookutils.NewMutatingWebhook(
 		&infrav1exp.AzureMachinePool{}, mgr.GetClient(),
 	))
 	hookServer.Register("/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachinepool", webhookutils.NewValidatingWebhook(
-		&infrav1exp.AzureMachinePool{}, mgr.GetClient(),
+		&infrav1exp.AzureMachinePool{}, vmSizeCapabilitiesClient,
 	))
 	hookServer.Register("/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachine", webhookutils.NewMutatingWebhook(
 		&infrav1.AzureMachine{}, mgr.GetClient(),
 	))
 	hookServer.Register("/validate-infrastructure-cluster-x-k8s-io-v1beta1-azuremachine", webhookutils.NewValidatingWebhook(
-		&infrav1.AzureMachine{}, mgr.GetClient(),
+		&infrav1.AzureMachine{}, vmSizeCapabilitiesClient,
 	))
 	hookServer.Register("/mutate-infrastructure-cluster-x-k8s-io-v1beta1-azuremanagedmachinepool", webhookutils.NewMutatingWebhook(
 		&infrav1.AzureManagedMachinePool{}, mgr.GetClient(),
@@ -0,0 +1,541 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// operationsPath is the path prefix under which the fake ARM server exposes the status of long-running operations.
+	operationsPath = "/cloudtest/operations/"
+
+	provisioningStateSucceeded = "Succeeded"
+	provisioningStateUpdating  = "Updating"
+	provisioningStateDeleting  = "Deleting"
+
+	operationStatusInProgress = "InProgress"
+	operationStatusSucceeded  = "Succeeded"
+)
+
+// ARMRequest is a request received by the fake ARM server.
+type ARMRequest struct {
+	Method string
+	Path   string
+}
+
+// ARMServer is an in-process fake of the Azure Resource Manager API. It stores resources in memory, keyed by
+// resource ID, and implements the PUT/GET/DELETE and long-running operation semantics that the autorest clients
+// rely on: Azure-AsyncOperation polling, Retry-After headers and 409 conflicts for resources with an operation in progress.
+// Point an autorest client at it by using URL() as its base URI.
+type ARMServer struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	resources  map[string]*armResource
+	operations map[string]*armOperation
+	failures   map[string][]armFailure
+	requests   []ARMRequest
+
+	// pollsBeforeDone is the number of times an operation needs to be polled before it completes.
+	// A negative value makes every PUT and DELETE complete synchronously.
+	pollsBeforeDone int
+	retryAfter      time.Duration
+}
+
+type armResource struct {
+	id         string
+	body       map[string]interface{}
+	inProgress *armOperation
+}
+
+type armOperation struct {
+	id          string
+	method      string
+	resourceKey string
+	pollsLeft   int
+	status      string
+}
+
+type armFailure struct {
+	statusCode int
+	code       string
+	message    string
+}
+
+// NewARMServer starts a new fake ARM server. By default all operations complete synchronously.
+// Callers must call Close when done with the server.
+func NewARMServer() *ARMServer {
+	s := &ARMServer{
+		resources:       make(map[string]*armResource),
+		operations:      make(map[string]*armOperation),
+		failures:        make(map[string][]armFailure),
+		pollsBeforeDone: -1,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the base URI of the fake ARM server.
+func (s *ARMServer) URL() string {
+	return s.server.URL
+}
+
+// Close shuts down the fake ARM server.
+func (s *ARMServer) Close() {
+	s.server.Close()
+}
+
+// SetLongRunning makes subsequent PUT and DELETE requests return an Azure-AsyncOperation header instead of
+// completing synchronously. Each operation completes after it has been polled polls times, and every response
+// carries a Retry-After header with the given duration. A negative polls value restores synchronous behavior.
+func (s *ARMServer) SetLongRunning(polls int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pollsBeforeDone = polls
+	s.retryAfter = retryAfter
+}
+
+// InjectError makes the next request with the given method on the given resource ID fail with the given
+// HTTP status code and ARM error code. Errors injected for the same request are returned in order.
+func (s *ARMServer) InjectError(method, resourceID string, statusCode int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := failureKey(method, resourceID)
+	s.failures[key] = append(s.failures[key], armFailure{
+		statusCode: statusCode,
+		code:       code,
+		message:    fmt.Sprintf("injected %s failure for %s", code, resourceID),
+	})
+}
+
+// SetResource stores a resource as if it already existed in Azure, e.g. a pre-existing virtual network.
+func (s *ARMServer) SetResource(resourceID string, body map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[resourceKey(resourceID)] = &armResource{
+		id:   resourceID,
+		body: normalizeResource(resourceID, body, provisioningStateSucceeded),
+	}
+}
+
+// GetResource returns the stored representation of a resource and whether it exists.
+func (s *ARMServer) GetResource(resourceID string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res, ok := s.resources[resourceKey(resourceID)]
+	if !ok {
+		return nil, false
+	}
+	return copyBody(res.body), true
+}
+
+// ResourceIDs returns the IDs of all stored resources, sorted alphabetically.
+func (s *ARMServer) ResourceIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.resources))
+	for _, res := range s.resources {
+		ids = append(ids, res.id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Requests returns the PUT, PATCH, POST and DELETE requests received by the server, in the order they arrived.
+// Polling and GET requests are not recorded.
+func (s *ARMServer) Requests() []ARMRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ARMRequest{}, s.requests...)
+}
+
+func (s *ARMServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, operationsPath) {
+		s.handleOperation(w, r)
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if r.Method != http.MethodGet {
+		s.requests = append(s.requests, ARMRequest{Method: r.Method, Path: path})
+	}
+
+	if failures := s.failures[failureKey(r.Method, path)]; len(failures) > 0 {
+		f := failures[0]
+		s.failures[failureKey(r.Method, path)] = failures[1:]
+		writeError(w, f.statusCode, f.code, f.message)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGet(w, path)
+	case http.MethodPut, http.MethodPatch:
+		s.handlePut(w, r, path)
+	case http.MethodDelete:
+		s.handleDelete(w, r, path)
+	case http.MethodPost:
+		s.handlePost(w, r, path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not supported", r.Method))
+	}
+}
+
+func (s *ARMServer) handleGet(w http.ResponseWriter, path string) {
+	if isCollection(path) {
+		value := []interface{}{}
+		for _, key := range s.sortedKeys() {
+			if parentKey(key) == resourceKey(path) {
+				value = append(value, s.resources[key].body)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"value": value})
+		return
+	}
+
+	res, ok := s.resources[resourceKey(path)]
+	if !ok {
+		writeNotFound(w, path)
+		return
+	}
+	writeJSON(w, http.StatusOK, res.body)
+}
+
+func (s *ARMServer) handlePut(w http.ResponseWriter, r *http.Request, path string) {
+	key := resourceKey(path)
+	existing, exists := s.resources[key]
+	if exists && existing.inProgress != nil {
+		writeConflict(w, path)
+		return
+	}
+	if r.Method == http.MethodPatch && !exists {
+		writeNotFound(w, path)
+		return
+	}
+	if code, ok := s.missingParent(key); !ok {
+		writeError(w, http.StatusNotFound, code, fmt.Sprintf("the parent of resource %s was not found", path))
+		return
+	}
+
+	body := map[string]interface{}{}
+	if raw, err := io.ReadAll(r.Body); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return
+	} else if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+	}
+	if r.Method == http.MethodPatch {
+		body = mergeBody(existing.body, body)
+	}
+
+	statusCode := http.StatusOK
+	if !exists {
+		statusCode = http.StatusCreated
+	}
+
+	if s.pollsBeforeDone < 0 {
+		s.resources[key] = &armResource{id: path, body: normalizeResource(path, body, provisioningStateSucceeded)}
+		writeJSON(w, statusCode, s.resources[key].body)
+		return
+	}
+
+	res := &armResource{id: path, body: normalizeResource(path, body, provisioningStateUpdating)}
+	res.inProgress = s.startOperation(r.Method, key)
+	s.resources[key] = res
+	s.writeAsyncHeaders(w, r, res.inProgress, false)
+	writeJSON(w, statusCode, res.body)
+}
+
+func (s *ARMServer) handleDelete(w http.ResponseWriter, r *http.Request, path string) {
+	key := resourceKey(path)
+	res, exists := s.resources[key]
+	if !exists {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if res.inProgress != nil {
+		writeConflict(w, path)
+		return
+	}
+
+	if s.pollsBeforeDone < 0 {
+		s.deleteResource(key)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	setProvisioningState(res.body, provisioningStateDeleting)
+	res.inProgress = s.startOperation(r.Method, key)
+	s.writeAsyncHeaders(w, r, res.inProgress, true)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handlePost handles resource actions such as deallocate or restart. Actions have no effect on the stored
+// resource, but follow the same long-running operation semantics as PUT and DELETE.
+func (s *ARMServer) handlePost(w http.ResponseWriter, r *http.Request, path string) {
+	key := parentKey(resourceKey(path))
+	res, exists := s.resources[key]
+	if !exists {
+		writeNotFound(w, path)
+		return
+	}
+	if res.inProgress != nil {
+		writeConflict(w, path)
+		return
+	}
+
+	if s.pollsBeforeDone < 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	res.inProgress = s.startOperation(r.Method, key)
+	s.writeAsyncHeaders(w, r, res.inProgress, true)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *ARMServer) handleOperation(w http.ResponseWriter, r *http.Request) {
+	op, ok := s.operations[strings.TrimPrefix(r.URL.Path, operationsPath)]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	if op.status == operationStatusInProgress {
+		if op.pollsLeft > 0 {
+			op.pollsLeft--
+		} else {
+			s.completeOperation(op)
+		}
+	}
+
+	body := map[string]interface{}{"status": op.status}
+	if op.status == operationStatusInProgress {
+		w.Header().Set("Retry-After", retryAfterSeconds(s.retryAfter))
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *ARMServer) startOperation(method, key string) *armOperation {
+	op := &armOperation{
+		id:          uuid.New().String(),
+		method:      method,
+		resourceKey: key,
+		pollsLeft:   s.pollsBeforeDone,
+		status:      operationStatusInProgress,
+	}
+	s.operations[op.id] = op
+	return op
+}
+
+func (s *ARMServer) completeOperation(op *armOperation) {
+	op.status = operationStatusSucceeded
+	res, ok := s.resources[op.resourceKey]
+	if !ok {
+		return
+	}
+	res.inProgress = nil
+	switch op.method {
+	case http.MethodDelete:
+		s.deleteResource(op.resourceKey)
+	case http.MethodPut, http.MethodPatch:
+		setProvisioningState(res.body, provisioningStateSucceeded)
+	}
+}
+
+// deleteResource removes a resource and every resource nested under it, the way deleting a resource group
+// or a virtual network removes their children.
+func (s *ARMServer) deleteResource(key string) {
+	for k := range s.resources {
+		if k == key || strings.HasPrefix(k, key+"/") {
+			delete(s.resources, k)
+		}
+	}
+}
+
+// missingParent returns false, along with the ARM error code to return, if the resource group or the parent
+// resource of a resource does not exist.
+func (s *ARMServer) missingParent(key string) (string, bool) {
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	if len(segments) > 4 && segments[2] == "resourcegroups" {
+		if _, ok := s.resources["/"+strings.Join(segments[:4], "/")]; !ok {
+			return "ResourceGroupNotFound", false
+		}
+	}
+	// A child resource has at least subscriptions/{id}/resourcegroups/{rg}/providers/{ns}/{type}/{name}/{type}/{name}.
+	if len(segments) > 8 && segments[4] == "providers" {
+		if _, ok := s.resources[parentKey(parentKey(key))]; !ok {
+			return "ParentResourceNotFound", false
+		}
+	}
+	return "", true
+}
+
+func (s *ARMServer) writeAsyncHeaders(w http.ResponseWriter, r *http.Request, op *armOperation, withLocation bool) {
+	opURL := fmt.Sprintf("http://%s%s%s", r.Host, operationsPath, op.id)
+	w.Header().Set("Azure-AsyncOperation", opURL)
+	if withLocation {
+		w.Header().Set("Location", opURL)
+	}
+	w.Header().Set("Retry-After", retryAfterSeconds(s.retryAfter))
+}
+
+func (s *ARMServer) sortedKeys() []string {
+	keys := make([]string, 0, len(s.resources))
+	for k := range s.resources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// normalizeResource sets the read-only properties that ARM adds to every resource.
+func normalizeResource(resourceID string, body map[string]interface{}, provisioningState string) map[string]interface{} {
+	body = copyBody(body)
+	segments := strings.Split(strings.TrimPrefix(resourceID, "/"), "/")
+	body["id"] = resourceID
+	body["name"] = segments[len(segments)-1]
+	if resourceType := typeFromSegments(segments); resourceType != "" {
+		body["type"] = resourceType
+	}
+	setProvisioningState(body, provisioningState)
+	return body
+}
+
+// typeFromSegments returns the fully qualified resource type, e.g. Microsoft.Network/virtualNetworks/subnets.
+func typeFromSegments(segments []string) string {
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+1 < len(segments) {
+			parts := []string{segments[i+1]}
+			for j := i + 2; j < len(segments); j += 2 {
+				parts = append(parts, segments[j])
+			}
+			return strings.Join(parts, "/")
+		}
+	}
+	if len(segments) == 4 && strings.EqualFold(segments[2], "resourceGroups") {
+		return "Microsoft.Resources/resourceGroups"
+	}
+	return ""
+}
+
+func setProvisioningState(body map[string]interface{}, state string) {
+	props, ok := body["properties"].(map[string]interface{})
+	if !ok {
+		props = map[string]interface{}{}
+		body["properties"] = props
+	}
+	props["provisioningState"] = state
+}
+
+func mergeBody(existing, patch map[string]interface{}) map[string]interface{} {
+	merged := copyBody(existing)
+	for k, v := range patch {
+		if patchMap, ok := v.(map[string]interface{}); ok {
+			if existingMap, ok := merged[k].(map[string]interface{}); ok {
+				merged[k] = mergeBody(existingMap, patchMap)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// copyBody returns a deep copy of a JSON object.
+func copyBody(body map[string]interface{}) map[string]interface{} {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// resourceKey returns the case-insensitive key used to store a resource, since ARM resource IDs are case insensitive.
+func resourceKey(resourceID string) string {
+	return strings.ToLower(strings.TrimSuffix(resourceID, "/"))
+}
+
+func parentKey(key string) string {
+	return key[:strings.LastIndex(key, "/")]
+}
+
+// isCollection returns true if the path refers to a list of resources rather than a single resource.
+// ARM resource IDs are made of type/name pairs, with the exception of "providers" and the provider namespace.
+func isCollection(path string) bool {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	count := len(segments)
+	for _, segment := range segments {
+		if strings.EqualFold(segment, "providers") {
+			count -= 2
+		}
+	}
+	return count%2 == 1
+}
+
+func failureKey(method, resourceID string) string {
+	return method + " " + resourceKey(resourceID)
+}
+
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(d.Seconds()))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, path string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("the resource %s was not found", path))
+}
+
+func writeConflict(w http.ResponseWriter, path string) {
+	writeError(w, http.StatusConflict, "AnotherOperationInProgress", fmt.Sprintf("another operation on resource %s is in progress", path))
+}
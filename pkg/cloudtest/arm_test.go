@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2021-08-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	testSubscriptionID = "123"
+	testResourceGroup  = "my-rg"
+	testPublicIPID     = "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"
+)
+
+func newTestClients(s *ARMServer) (resources.GroupsClient, network.PublicIPAddressesClient) {
+	groups := resources.NewGroupsClientWithBaseURI(s.URL(), testSubscriptionID)
+	groups.Authorizer = autorest.NullAuthorizer{}
+	groups.RetryAttempts = 1
+	publicIPs := network.NewPublicIPAddressesClientWithBaseURI(s.URL(), testSubscriptionID)
+	publicIPs.Authorizer = autorest.NullAuthorizer{}
+	publicIPs.RetryAttempts = 1
+	return groups, publicIPs
+}
+
+func TestARMServerSynchronousOperations(t *testing.T) {
+	g := NewWithT(t)
+	s := NewARMServer()
+	defer s.Close()
+	ctx := context.Background()
+	groups, publicIPs := newTestClients(s)
+
+	_, err := publicIPs.CreateOrUpdate(ctx, testResourceGroup, "my-ip", network.PublicIPAddress{Location: pointer.String("westus")})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("ResourceGroupNotFound"))
+
+	group, err := groups.CreateOrUpdate(ctx, testResourceGroup, resources.Group{Location: pointer.String("westus")})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(group.Name).To(Equal(pointer.String(testResourceGroup)))
+
+	future, err := publicIPs.CreateOrUpdate(ctx, testResourceGroup, "my-ip", network.PublicIPAddress{Location: pointer.String("westus")})
+	g.Expect(err).NotTo(HaveOccurred())
+	done, err := future.DoneWithContext(ctx, publicIPs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+	ip, err := future.Result(publicIPs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ip.ID).To(Equal(pointer.String(testPublicIPID)))
+	g.Expect(ip.Type).To(Equal(pointer.String("Microsoft.Network/publicIPAddresses")))
+	g.Expect(ip.ProvisioningState).To(Equal(network.ProvisioningStateSucceeded))
+
+	list, err := publicIPs.List(ctx, testResourceGroup)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(list.Values()).To(HaveLen(1))
+
+	_, err = groups.Delete(ctx, testResourceGroup)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(s.ResourceIDs()).To(BeEmpty())
+
+	_, err = publicIPs.Get(ctx, testResourceGroup, "my-ip", "")
+	g.Expect(err).To(HaveOccurred())
+	var detailedErr autorest.DetailedError
+	g.Expect(err).To(BeAssignableToTypeOf(detailedErr))
+	g.Expect(err.(autorest.DetailedError).StatusCode).To(Equal(http.StatusNotFound))
+
+	g.Expect(s.Requests()).To(Equal([]ARMRequest{
+		{Method: http.MethodPut, Path: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"},
+		{Method: http.MethodPut, Path: "/subscriptions/123/resourcegroups/my-rg"},
+		{Method: http.MethodPut, Path: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Network/publicIPAddresses/my-ip"},
+		{Method: http.MethodDelete, Path: "/subscriptions/123/resourcegroups/my-rg"},
+	}))
+}
+
+func TestARMServerLongRunningOperations(t *testing.T) {
+	g := NewWithT(t)
+	s := NewARMServer()
+	defer s.Close()
+	ctx := context.Background()
+	groups, publicIPs := newTestClients(s)
+
+	_, err := groups.CreateOrUpdate(ctx, testResourceGroup, resources.Group{Location: pointer.String("westus")})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s.SetLongRunning(1, 0)
+	future, err := publicIPs.CreateOrUpdate(ctx, testResourceGroup, "my-ip", network.PublicIPAddress{Location: pointer.String("westus")})
+	g.Expect(err).NotTo(HaveOccurred())
+	delay, ok := future.GetPollingDelay()
+	g.Expect(ok).To(BeTrue())
+	g.Expect(delay).To(BeZero())
+
+	// A second PUT while the first one is in progress results in a conflict.
+	_, err = publicIPs.CreateOrUpdate(ctx, testResourceGroup, "my-ip", network.PublicIPAddress{Location: pointer.String("westus")})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("AnotherOperationInProgress"))
+
+	done, err := future.DoneWithContext(ctx, publicIPs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+	body, ok := s.GetResource(testPublicIPID)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(body["properties"]).To(HaveKeyWithValue("provisioningState", "Updating"))
+
+	done, err = future.DoneWithContext(ctx, publicIPs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+	ip, err := future.Result(publicIPs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ip.ProvisioningState).To(Equal(network.ProvisioningStateSucceeded))
+
+	deleteFuture, err := publicIPs.Delete(ctx, testResourceGroup, "my-ip")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(deleteFuture.WaitForCompletionRef(ctx, publicIPs.Client)).To(Succeed())
+	_, ok = s.GetResource(testPublicIPID)
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestARMServerInjectError(t *testing.T) {
+	g := NewWithT(t)
+	s := NewARMServer()
+	defer s.Close()
+	ctx := context.Background()
+	groups, _ := newTestClients(s)
+
+	s.InjectError(http.MethodGet, "/subscriptions/123/resourcegroups/my-rg", http.StatusForbidden, "AuthorizationFailed")
+	_, err := groups.Get(ctx, testResourceGroup)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(autorest.DetailedError).StatusCode).To(Equal(http.StatusForbidden))
+
+	// Injected errors are only returned once.
+	_, err = groups.Get(ctx, testResourceGroup)
+	g.Expect(err.(autorest.DetailedError).StatusCode).To(Equal(http.StatusNotFound))
+}
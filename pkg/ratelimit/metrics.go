@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	waitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "capz_azure_api_rate_limit_wait_seconds",
+			Help:    "Time in seconds Azure API requests waited for the rate limiter of their subscription.",
+			Buckets: []float64{0, 0.01, 0.1, 0.5, 1, 5, 10, 30, 60},
+		},
+		[]string{"subscription", "operation"},
+	)
+	queueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_azure_api_rate_limit_queue_length",
+			Help: "Number of Azure API requests waiting for the rate limiter of their subscription.",
+		},
+		[]string{"subscription", "operation"},
+	)
+	remainingRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capz_azure_api_rate_limit_remaining",
+			Help: "Number of Azure API requests left to a subscription, as last reported by Azure Resource Manager.",
+		},
+		[]string{"subscription", "operation"},
+	)
+	throttledRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capz_azure_api_throttled_requests_total",
+			Help: "Total number of Azure API requests throttled by Azure Resource Manager.",
+		},
+		[]string{"subscription", "operation"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(waitSeconds, queueLength, remainingRequests, throttledRequests)
+}
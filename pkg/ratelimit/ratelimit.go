@@ -0,0 +1,378 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit implements a process-wide rate limiter for Azure Resource Manager requests, which keeps the
+// requests of each subscription under the ARM throttling limits.
+package ratelimit
+
+import (
+	"container/heap"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/pkg/errors"
+)
+
+const (
+	// remainingReadsHeader is the header ARM uses to return the number of reads left to a subscription.
+	remainingReadsHeader = "x-ms-ratelimit-remaining-subscription-reads"
+	// remainingWritesHeader is the header ARM uses to return the number of writes left to a subscription.
+	remainingWritesHeader = "x-ms-ratelimit-remaining-subscription-writes"
+
+	// readOperation labels the limiter of read requests.
+	readOperation = "read"
+	// writeOperation labels the limiter of write requests.
+	writeOperation = "write"
+)
+
+// Priority is the priority of a request waiting for the rate limiter. Requests with a lower value are sent first.
+type Priority int
+
+const (
+	// PriorityDelete is the priority of delete requests, so that deleted clusters free their resources first.
+	PriorityDelete Priority = iota
+	// PriorityRead is the priority of read requests, including the status checks of long-running operations.
+	PriorityRead
+	// PriorityWrite is the priority of create and update requests.
+	PriorityWrite
+)
+
+// Config is the configuration of a Limiter.
+type Config struct {
+	// ReadQPS is the sustained rate of read requests per subscription.
+	ReadQPS float64
+	// ReadBurst is the maximum number of read requests per subscription sent at once.
+	ReadBurst int
+	// WriteQPS is the sustained rate of write requests per subscription.
+	WriteQPS float64
+	// WriteBurst is the maximum number of write requests per subscription sent at once.
+	WriteBurst int
+}
+
+// DefaultConfig matches the token bucket ARM uses to throttle the requests of a subscription.
+var DefaultConfig = Config{
+	ReadQPS:    25,
+	ReadBurst:  250,
+	WriteQPS:   10,
+	WriteBurst: 200,
+}
+
+var (
+	defaultLimiter   = NewLimiter(DefaultConfig)
+	defaultLimiterMu sync.RWMutex
+)
+
+// SetDefault replaces the process-wide Limiter used by SendDecorator with one using the given configuration.
+func SetDefault(config Config) {
+	defaultLimiterMu.Lock()
+	defer defaultLimiterMu.Unlock()
+	defaultLimiter = NewLimiter(config)
+}
+
+// SendDecorator is an autorest.SendDecorator which sends requests through the process-wide Limiter.
+func SendDecorator(snd autorest.Sender) autorest.Sender {
+	defaultLimiterMu.RLock()
+	defer defaultLimiterMu.RUnlock()
+	return defaultLimiter.SendDecorator(snd)
+}
+
+// Limiter limits the rate of the read and write requests to each Azure subscription.
+type Limiter struct {
+	config  Config
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewLimiter creates a new Limiter with the given configuration.
+func NewLimiter(config Config) *Limiter {
+	return &Limiter{
+		config:  config,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// SendDecorator returns an autorest.SendDecorator which waits for the limiter of the subscription of each request
+// before sending it, and adapts the limiter to the throttling headers of the response.
+func (l *Limiter) SendDecorator(snd autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		subscription := subscriptionFromPath(r.URL.Path)
+		if subscription == "" {
+			return snd.Do(r)
+		}
+
+		b := l.bucket(subscription, operationOf(r.Method))
+		if err := b.wait(r.Context(), priorityOf(r.Method)); err != nil {
+			return nil, errors.Wrapf(err, "failed waiting for the rate limiter of subscription %s", subscription)
+		}
+
+		resp, err := snd.Do(r)
+		if resp != nil {
+			b.observe(resp)
+		}
+		return resp, err
+	})
+}
+
+// bucket returns the bucket of the given subscription and operation, creating it if needed.
+func (l *Limiter) bucket(subscription, operation string) *bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := subscription + "/" + operation
+	b, ok := l.buckets[key]
+	if !ok {
+		qps, burst := l.config.ReadQPS, l.config.ReadBurst
+		if operation == writeOperation {
+			qps, burst = l.config.WriteQPS, l.config.WriteBurst
+		}
+		b = newBucket(subscription, operation, qps, burst)
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// waiter is a request waiting for a token.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int
+}
+
+// waiterQueue is a heap of waiters ordered by priority, then by arrival.
+type waiterQueue []*waiter
+
+func (q waiterQueue) Len() int { return len(q) }
+
+func (q waiterQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waiterQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waiterQueue) Push(x interface{}) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waiterQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*q = old[:n-1]
+	return w
+}
+
+// bucket is a token bucket whose waiters are served by priority.
+type bucket struct {
+	subscription string
+	operation    string
+	qps          float64
+	burst        float64
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	waiters     waiterQueue
+	seq         uint64
+	timer       *time.Timer
+}
+
+func newBucket(subscription, operation string, qps float64, burst int) *bucket {
+	return &bucket{
+		subscription: subscription,
+		operation:    operation,
+		qps:          qps,
+		burst:        float64(burst),
+		tokens:       float64(burst),
+		last:         time.Now(),
+	}
+}
+
+// wait blocks until a token is available to a request of the given priority, or the context is done.
+func (b *bucket) wait(ctx context.Context, priority Priority) error {
+	start := time.Now()
+
+	b.mu.Lock()
+	b.refill(start)
+	if len(b.waiters) == 0 && !start.Before(b.pausedUntil) && b.tokens >= 1 {
+		b.tokens--
+		b.mu.Unlock()
+		waitSeconds.WithLabelValues(b.subscription, b.operation).Observe(0)
+		return nil
+	}
+
+	b.seq++
+	w := &waiter{priority: priority, seq: b.seq, ready: make(chan struct{})}
+	heap.Push(&b.waiters, w)
+	queueLength.WithLabelValues(b.subscription, b.operation).Set(float64(len(b.waiters)))
+	b.schedule(start)
+	b.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		waitSeconds.WithLabelValues(b.subscription, b.operation).Observe(time.Since(start).Seconds())
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		select {
+		case <-w.ready:
+			// The token was granted concurrently, give it back.
+			b.tokens++
+		default:
+			heap.Remove(&b.waiters, w.index)
+			queueLength.WithLabelValues(b.subscription, b.operation).Set(float64(len(b.waiters)))
+		}
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens accumulated since the last refill. It must be called with the lock held.
+func (b *bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.qps
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// schedule grants the available tokens to the waiters and arranges to be called again when the next token is
+// available to the remaining ones. It must be called with the lock held.
+func (b *bucket) schedule(now time.Time) {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	if now.Before(b.pausedUntil) {
+		if len(b.waiters) > 0 {
+			b.timer = time.AfterFunc(b.pausedUntil.Sub(now), b.dispatch)
+		}
+		return
+	}
+
+	for len(b.waiters) > 0 && b.tokens >= 1 {
+		w := heap.Pop(&b.waiters).(*waiter)
+		b.tokens--
+		close(w.ready)
+	}
+	queueLength.WithLabelValues(b.subscription, b.operation).Set(float64(len(b.waiters)))
+
+	if len(b.waiters) > 0 && b.qps > 0 {
+		next := time.Duration((1 - b.tokens) / b.qps * float64(time.Second))
+		b.timer = time.AfterFunc(next, b.dispatch)
+	}
+}
+
+// dispatch refills the bucket and serves its waiters.
+func (b *bucket) dispatch() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+	b.schedule(now)
+}
+
+// observe adapts the bucket to the throttling headers of a response. The bucket never holds more tokens than ARM
+// reports as remaining, and is paused for the duration ARM asks for when it throttles a request.
+func (b *bucket) observe(resp *http.Response) {
+	header := remainingReadsHeader
+	if b.operation == writeOperation {
+		header = remainingWritesHeader
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+	if remaining, err := strconv.Atoi(resp.Header.Get(header)); err == nil {
+		remainingRequests.WithLabelValues(b.subscription, b.operation).Set(float64(remaining))
+		if float64(remaining) < b.tokens {
+			b.tokens = float64(remaining)
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		throttledRequests.WithLabelValues(b.subscription, b.operation).Inc()
+		b.tokens = 0
+		if until := now.Add(retryAfter(resp)); until.After(b.pausedUntil) {
+			b.pausedUntil = until
+		}
+		b.schedule(now)
+	}
+}
+
+// retryAfter returns the duration of the Retry-After header of a response, or the time to wait for one token if
+// there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Second
+}
+
+// subscriptionFromPath returns the subscription ID of an ARM request path, or an empty string if the request is not
+// scoped to a subscription.
+func subscriptionFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) >= 2 && strings.EqualFold(parts[0], "subscriptions") {
+		return strings.ToLower(parts[1])
+	}
+	return ""
+}
+
+// operationOf returns whether a request of the given method counts against the read or write limit of ARM.
+func operationOf(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return readOperation
+	default:
+		return writeOperation
+	}
+}
+
+// priorityOf returns the priority of a request of the given method.
+func priorityOf(method string) Priority {
+	switch method {
+	case http.MethodDelete:
+		return PriorityDelete
+	case http.MethodGet, http.MethodHead:
+		return PriorityRead
+	default:
+		return PriorityWrite
+	}
+}
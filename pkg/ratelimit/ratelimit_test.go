@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testSubscription = "123"
+
+func TestSubscriptionFromPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "/subscriptions/123/resourceGroups/my-rg/providers/Microsoft.Compute/virtualMachines/my-vm",
+			want: "123",
+		},
+		{
+			path: "/Subscriptions/ABC/providers/Microsoft.Compute/skus",
+			want: "abc",
+		},
+		{
+			path: "/providers/Microsoft.Compute/operations",
+			want: "",
+		},
+		{
+			path: "/subscriptions",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(subscriptionFromPath(tt.path)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPriorityOf(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(priorityOf(http.MethodDelete)).To(Equal(PriorityDelete))
+	g.Expect(priorityOf(http.MethodGet)).To(Equal(PriorityRead))
+	g.Expect(priorityOf(http.MethodPut)).To(Equal(PriorityWrite))
+	g.Expect(priorityOf(http.MethodPatch)).To(Equal(PriorityWrite))
+	g.Expect(operationOf(http.MethodGet)).To(Equal(readOperation))
+	g.Expect(operationOf(http.MethodDelete)).To(Equal(writeOperation))
+}
+
+func TestBucketPriority(t *testing.T) {
+	g := NewWithT(t)
+
+	// The bucket is never refilled, tokens are added one at a time below.
+	b := newBucket(testSubscription, writeOperation, 0, 1)
+	g.Expect(b.wait(context.TODO(), PriorityWrite)).To(Succeed())
+
+	granted := make(chan Priority)
+	for i, p := range []Priority{PriorityWrite, PriorityRead, PriorityDelete, PriorityWrite} {
+		p := p
+		go func() {
+			g.Expect(b.wait(context.TODO(), p)).To(Succeed())
+			granted <- p
+		}()
+		g.Eventually(func() int {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			return len(b.waiters)
+		}).Should(Equal(i + 1))
+	}
+
+	var order []Priority
+	for i := 0; i < 4; i++ {
+		b.mu.Lock()
+		b.tokens++
+		b.schedule(time.Now())
+		b.mu.Unlock()
+		order = append(order, <-granted)
+	}
+	g.Expect(order).To(Equal([]Priority{PriorityDelete, PriorityRead, PriorityWrite, PriorityWrite}))
+}
+
+func TestBucketThrottling(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		status int
+	}{
+		{
+			name:   "pauses after a throttled request",
+			header: http.Header{"Retry-After": []string{"5"}},
+			status: http.StatusTooManyRequests,
+		},
+		{
+			name:   "never exceeds the remaining requests reported by Azure",
+			header: http.Header{http.CanonicalHeaderKey(remainingWritesHeader): []string{"0"}},
+			status: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			b := newBucket(testSubscription, writeOperation, 1, 100)
+			b.observe(&http.Response{StatusCode: tt.status, Header: tt.header})
+
+			ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+			defer cancel()
+			g.Expect(b.wait(ctx, PriorityDelete)).To(MatchError(context.DeadlineExceeded))
+
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			g.Expect(b.waiters).To(BeEmpty())
+		})
+	}
+}
+
+func TestSendDecorator(t *testing.T) {
+	g := NewWithT(t)
+
+	l := NewLimiter(Config{ReadQPS: 1, ReadBurst: 1, WriteQPS: 1, WriteBurst: 1})
+	sent := 0
+	sender := l.SendDecorator(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"60"}},
+			Request:    r,
+		}, nil
+	}))
+
+	newRequest := func(ctx context.Context, method, path string) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, method, "https://management.azure.com"+path, http.NoBody)
+		g.Expect(err).NotTo(HaveOccurred())
+		return req
+	}
+
+	_, err := sender.Do(newRequest(context.TODO(), http.MethodPut, "/subscriptions/123/resourceGroups/my-rg"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(testutil.ToFloat64(throttledRequests.WithLabelValues(testSubscription, writeOperation))).To(BeNumerically(">=", 1))
+
+	// The subscription is throttled, but its reads and the requests of other subscriptions are not.
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	_, err = sender.Do(newRequest(ctx, http.MethodDelete, "/subscriptions/123/resourceGroups/my-rg"))
+	g.Expect(err).To(MatchError(ContainSubstring("failed waiting for the rate limiter of subscription 123")))
+	_, err = sender.Do(newRequest(context.TODO(), http.MethodGet, "/subscriptions/123/resourceGroups/my-rg"))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = sender.Do(newRequest(context.TODO(), http.MethodPut, "/subscriptions/456/resourceGroups/my-rg"))
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = sender.Do(newRequest(context.TODO(), http.MethodGet, "/providers/Microsoft.Compute/operations"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(sent).To(Equal(4))
+}
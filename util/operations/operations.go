@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Getter interface defines methods that an object should implement in order to
+// use the operations package for getting its operation history.
+type Getter interface {
+	client.Object
+
+	// GetOperationHistory returns the history of Azure operations for an object.
+	GetOperationHistory() infrav1.Operations
+}
+
+// Setter interface defines methods that an object should implement in order to
+// use the operations package for recording operations.
+type Setter interface {
+	Getter
+	SetOperationHistory(infrav1.Operations)
+}
+
+// Record adds the given operation to the operation history of an object.
+//
+// NOTE: If an in-progress operation of the same type on the same resource exists, it is replaced by the given
+// operation, which keeps its start time if it has none. Only the MaxOperationHistory most recent operations are kept.
+func Record(to Setter, op infrav1.Operation) {
+	if to == nil {
+		return
+	}
+
+	history := to.GetOperationHistory()
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		if h.Result == infrav1.OperationInProgress && h.Type == op.Type && h.ServiceName == op.ServiceName && h.Name == op.Name {
+			if op.StartTime == nil {
+				op.StartTime = h.StartTime
+			}
+			// Move the operation to the end to keep the history ordered by recency.
+			history = append(history[:i], history[i+1:]...)
+			break
+		}
+	}
+
+	history = append(history, op)
+
+	if len(history) > infrav1.MaxOperationHistory {
+		history = history[len(history)-infrav1.MaxOperationHistory:]
+	}
+
+	to.SetOperationHistory(history)
+}
+
+// Get returns the most recent operation of the given type on the named resource, or nil if there is none.
+func Get(from Getter, name, service, operationType string) *infrav1.Operation {
+	history := from.GetOperationHistory()
+	for i := len(history) - 1; i >= 0; i-- {
+		op := history[i]
+		if op.Name == name && op.ServiceName == service && op.Type == operationType {
+			return &op
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
+)
+
+const testService = "test-service"
+
+func TestRecord(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(time.Minute))
+
+	a := fakeOperation("a", infrav1.OperationSucceeded)
+	b := fakeOperation("b", infrav1.OperationSucceeded)
+	inProgressC := fakeOperation("c", infrav1.OperationInProgress)
+	inProgressC.StartTime = &start
+	doneC := fakeOperation("c", infrav1.OperationFailed)
+	doneC.EndTime = &end
+	doneC.Error = "failed"
+	wantC := doneC
+	wantC.StartTime = &start
+	deleteC := doneC
+	deleteC.Type = "DELETE"
+
+	full := infrav1.Operations{}
+	for i := 0; i < infrav1.MaxOperationHistory; i++ {
+		full = append(full, fakeOperation(fmt.Sprintf("op-%d", i), infrav1.OperationSucceeded))
+	}
+
+	tests := []struct {
+		name string
+		to   Setter
+		op   infrav1.Operation
+		want infrav1.Operations
+	}{
+		{
+			name: "Record adds an operation",
+			to:   setterWithHistory(nil),
+			op:   a,
+			want: infrav1.Operations{a},
+		},
+		{
+			name: "Record appends completed operations",
+			to:   setterWithHistory(infrav1.Operations{a}),
+			op:   a,
+			want: infrav1.Operations{a, a},
+		},
+		{
+			name: "Record replaces an in-progress operation and keeps its start time",
+			to:   setterWithHistory(infrav1.Operations{inProgressC, b}),
+			op:   doneC,
+			want: infrav1.Operations{b, wantC},
+		},
+		{
+			name: "Record does not replace an in-progress operation of another type",
+			to:   setterWithHistory(infrav1.Operations{inProgressC}),
+			op:   deleteC,
+			want: infrav1.Operations{inProgressC, deleteC},
+		},
+		{
+			name: "Record drops the oldest operations when the history is full",
+			to:   setterWithHistory(append(infrav1.Operations{}, full...)),
+			op:   a,
+			want: append(append(infrav1.Operations{}, full[1:]...), a),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			Record(tt.to, tt.op)
+
+			g.Expect(tt.to.GetOperationHistory()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	g := NewWithT(t)
+
+	older := fakeOperation("a", infrav1.OperationFailed)
+	newer := fakeOperation("a", infrav1.OperationSucceeded)
+	from := setterWithHistory(infrav1.Operations{older, newer, fakeOperation("b", infrav1.OperationSucceeded)})
+
+	g.Expect(Get(from, "a", testService, "PUT")).To(Equal(&newer))
+	g.Expect(Get(from, "a", testService, "DELETE")).To(BeNil())
+	g.Expect(Get(from, "c", testService, "PUT")).To(BeNil())
+}
+
+func fakeOperation(name string, result infrav1.OperationResult) infrav1.Operation {
+	return infrav1.Operation{
+		Type:          "PUT",
+		ResourceGroup: "test-rg",
+		ServiceName:   testService,
+		Name:          name,
+		Result:        result,
+	}
+}
+
+func setterWithHistory(history infrav1.Operations) Setter {
+	obj := &infrav1.AzureCluster{}
+	obj.SetOperationHistory(history)
+	return obj
+}